@@ -1,8 +1,10 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/util/crypto"
@@ -12,16 +14,18 @@ func main() {
 	// Define command-line flags
 	encryptCmd := flag.Bool("encrypt", false, "Encrypt an environment file")
 	decryptCmd := flag.Bool("decrypt", false, "Decrypt an environment file")
+	rekeyCmd := flag.Bool("rekey", false, "Re-encrypt an environment file encrypted with OLD_ENCRYPTION_KEY under the current key")
 	inputFlag := flag.String("input", "", "Input file path")
-	outputFlag := flag.String("output", "", "Output file path")
+	outputFlag := flag.String("output", "", "Output file path (with -rekey, defaults to the input file)")
 	keyFlag := flag.String("key", "", "Encryption key (base64-encoded)")
+	jsonFlag := flag.Bool("json", false, "Print the result as JSON")
 
 	// Parse flags
 	flag.Parse()
 
 	// Validate flags
-	if !*encryptCmd && !*decryptCmd {
-		fmt.Println("Error: Either -encrypt or -decrypt must be specified")
+	if !*encryptCmd && !*decryptCmd && !*rekeyCmd {
+		fmt.Println("Error: One of -encrypt, -decrypt or -rekey must be specified")
 		flag.Usage()
 		os.Exit(1)
 	}
@@ -32,6 +36,10 @@ func main() {
 		os.Exit(1)
 	}
 
+	if *outputFlag == "" && *rekeyCmd {
+		// Rekey rewrites the file in place unless told otherwise
+		*outputFlag = *inputFlag
+	}
 	if *outputFlag == "" {
 		fmt.Println("Error: Output file path must be specified")
 		flag.Usage()
@@ -68,19 +76,83 @@ func main() {
 	envManager := crypto.NewEnvManager(encryptionSvc, "")
 
 	// Perform operation
-	if *encryptCmd {
-		fmt.Printf("Encrypting %s to %s...\n", *inputFlag, *outputFlag)
-		if err := envManager.EncryptEnvFile(*inputFlag, *outputFlag); err != nil {
-			fmt.Printf("Error encrypting environment file: %v\n", err)
+	var operation, failure, done string
+	var run func() error
+	if *rekeyCmd {
+		oldKey := os.Getenv("OLD_ENCRYPTION_KEY")
+		if oldKey == "" {
+			fmt.Println("Error: The previous key must be provided via the OLD_ENCRYPTION_KEY environment variable")
+			os.Exit(1)
+		}
+		oldEncryptionSvc, err := crypto.NewAESEncryptionServiceWithKey(oldKey)
+		if err != nil {
+			fmt.Printf("Error creating encryption service for the old key: %v\n", err)
 			os.Exit(1)
 		}
-		fmt.Println("Encryption successful")
+
+		operation, failure, done = "Re-encrypting %s to %s under the current key...", "re-encrypting", "Re-encryption"
+		run = func() error { return envManager.RekeyEnvFile(oldEncryptionSvc, *inputFlag, *outputFlag) }
+	} else if *encryptCmd {
+		operation, failure, done = "Encrypting %s to %s...", "encrypting", "Encryption"
+		run = func() error { return envManager.EncryptEnvFile(*inputFlag, *outputFlag) }
 	} else {
-		fmt.Printf("Decrypting %s to %s...\n", *inputFlag, *outputFlag)
-		if err := envManager.DecryptEnvFile(*inputFlag, *outputFlag); err != nil {
-			fmt.Printf("Error decrypting environment file: %v\n", err)
+		operation, failure, done = "Decrypting %s to %s...", "decrypting", "Decryption"
+		run = func() error { return envManager.DecryptEnvFile(*inputFlag, *outputFlag) }
+	}
+
+	if *jsonFlag {
+		res := newResult(*inputFlag, *outputFlag, run())
+		if err := writeResult(os.Stdout, res); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing result: %v\n", err)
 			os.Exit(1)
 		}
-		fmt.Println("Decryption successful")
+		if res.Status != statusOK {
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Printf(operation+"\n", *inputFlag, *outputFlag)
+	if err := run(); err != nil {
+		fmt.Printf("Error %s environment file: %v\n", failure, err)
+		os.Exit(1)
 	}
+	fmt.Println(done + " successful")
+}
+
+// Statuses of a result
+const (
+	statusOK    = "ok"
+	statusError = "error"
+)
+
+// result is the outcome of an operation as printed with -json
+type result struct {
+	Status string `json:"status"`
+	Input  string `json:"input"`
+	Output string `json:"output"`
+	// Bytes is the size of the written output file
+	Bytes int64  `json:"bytes"`
+	Error string `json:"error,omitempty"`
+}
+
+// newResult describes an operation that read input and wrote output, failing with err
+func newResult(input, output string, err error) result {
+	res := result{Status: statusOK, Input: input, Output: output}
+	if err == nil {
+		var info os.FileInfo
+		if info, err = os.Stat(output); err == nil {
+			res.Bytes = info.Size()
+		}
+	}
+	if err != nil {
+		res.Status = statusError
+		res.Error = err.Error()
+	}
+	return res
+}
+
+// writeResult prints res as a single line of JSON
+func writeResult(w io.Writer, res result) error {
+	return json.NewEncoder(w).Encode(res)
 }
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeResult parses a printed result into its raw fields
+func decodeResult(t *testing.T, res result) map[string]interface{} {
+	t.Helper()
+	var out bytes.Buffer
+	require.NoError(t, writeResult(&out, res))
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &fields))
+	return fields
+}
+
+func TestWriteResult_Success(t *testing.T) {
+	output := filepath.Join(t.TempDir(), ".env.enc")
+	require.NoError(t, os.WriteFile(output, []byte("API_KEY=ENC:abc\n"), 0600))
+
+	fields := decodeResult(t, newResult(".env", output, nil))
+	assert.Equal(t, map[string]interface{}{
+		"status": "ok",
+		"input":  ".env",
+		"output": output,
+		"bytes":  float64(16),
+	}, fields)
+}
+
+func TestWriteResult_Failure(t *testing.T) {
+	output := filepath.Join(t.TempDir(), ".env.enc")
+
+	fields := decodeResult(t, newResult(".env", output, errors.New("no such file")))
+	assert.Equal(t, "error", fields["status"])
+	assert.Equal(t, ".env", fields["input"])
+	assert.Equal(t, output, fields["output"])
+	assert.Equal(t, float64(0), fields["bytes"])
+	assert.Equal(t, "no such file", fields["error"])
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/factory"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/infrastructure/database"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func main() {
+	file := flag.String("file", "", "candle CSV to import, as written by the candle export; - reads stdin")
+	symbol := flag.String("symbol", "", "symbol the candles belong to, e.g. BTCUSDT")
+	interval := flag.String("interval", "", "candle interval, e.g. 1h")
+	exchange := flag.String("exchange", "mexc", "exchange the candles belong to")
+	flag.Parse()
+
+	// Configure logging
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+	logger := log.With().Str("component", "candle-import").Logger()
+
+	if *file == "" || *symbol == "" || *interval == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	input := os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to open candle CSV")
+		}
+		defer f.Close()
+		input = f
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to load configuration")
+	}
+
+	// Connect to database
+	db, err := database.Connect(cfg, &logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to connect to database")
+	}
+
+	marketData, err := factory.NewMarketFactory(cfg, &logger, db).CreateMarketDataUseCase()
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to create market data use case")
+	}
+
+	result, err := marketData.ImportCandlesCSV(context.Background(), input, strings.ToUpper(*symbol), *exchange, market.Interval(*interval))
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to import candles")
+	}
+
+	for _, lineErr := range result.Errors {
+		fmt.Fprintf(os.Stderr, "line %d: %s\n", lineErr.Line, lineErr.Message)
+	}
+	fmt.Printf("Imported %d candles, skipped %d rows, %d duplicates\n", result.Imported, result.Skipped, result.Duplicates)
+}
@@ -1,9 +1,15 @@
 package main
 
 import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/util/crypto"
 )
@@ -14,6 +20,10 @@ func main() {
 	rotateCmd := flag.Bool("rotate", false, "Rotate encryption keys")
 	bitsFlag := flag.Int("bits", 256, "Key size in bits (must be a multiple of 8)")
 	envFlag := flag.Bool("env", false, "Output in environment variable format")
+	jsonFlag := flag.Bool("json", false, "Print generated keys and key configurations as JSON")
+	verifyCmd := flag.Bool("verify", false, "Verify that sample ciphertexts decrypt under the key config in ENCRYPTION_CURRENT_KEY_ID and ENCRYPTION_KEYS")
+	ciphertextFlag := flag.String("ciphertext", "", "Sample ciphertext to verify, base64-encoded")
+	ciphertextFileFlag := flag.String("ciphertext-file", "", "File of sample ciphertexts to verify, one base64-encoded or JSON ciphertext per line")
 
 	// Parse flags
 	flag.Parse()
@@ -32,8 +42,9 @@ func main() {
 			}
 
 			// Print configuration
-			for k, v := range config {
-				fmt.Printf("export %s=\"%s\"\n", k, v)
+			if err := writeConfig(os.Stdout, config, *jsonFlag); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing key configuration: %v\n", err)
+				os.Exit(1)
 			}
 		} else {
 			// Generate single key
@@ -43,7 +54,14 @@ func main() {
 				os.Exit(1)
 			}
 
-			fmt.Println(key)
+			if *jsonFlag {
+				if err := json.NewEncoder(os.Stdout).Encode(map[string]string{"key": key}); err != nil {
+					fmt.Fprintf(os.Stderr, "Error writing key: %v\n", err)
+					os.Exit(1)
+				}
+			} else {
+				fmt.Println(key)
+			}
 		}
 		return
 	}
@@ -63,12 +81,97 @@ func main() {
 		}
 
 		// Print new configuration
-		for k, v := range newConfig {
-			fmt.Printf("export %s=\"%s\"\n", k, v)
+		if err := writeConfig(os.Stdout, newConfig, *jsonFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing key configuration: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Verify a rotated key config against existing ciphertexts
+	if *verifyCmd {
+		config := map[string]string{
+			"ENCRYPTION_CURRENT_KEY_ID": os.Getenv("ENCRYPTION_CURRENT_KEY_ID"),
+			"ENCRYPTION_KEYS":           os.Getenv("ENCRYPTION_KEYS"),
+		}
+
+		samples, err := readSamples(*ciphertextFlag, *ciphertextFileFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading sample ciphertexts: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := keyGen.VerifyKeyConfig(config, samples); err != nil {
+			fmt.Fprintf(os.Stderr, "Key config verification failed: %v\n", err)
+			os.Exit(1)
 		}
+		fmt.Printf("Verified %d sample ciphertexts decrypt under the key config\n", len(samples))
 		return
 	}
 
 	// If no command specified, print usage
 	flag.Usage()
 }
+
+// writeConfig prints a key configuration as a JSON object or as export lines sorted by
+// variable name
+func writeConfig(w io.Writer, config map[string]string, asJSON bool) error {
+	if asJSON {
+		return json.NewEncoder(w).Encode(config)
+	}
+
+	names := make([]string, 0, len(config))
+	for name := range config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := fmt.Fprintf(w, "export %s=\"%s\"\n", name, config[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readSamples collects the sample ciphertexts given on the command line and in a file.
+// Ciphertexts of the enhanced format may be given as their JSON; anything else is
+// base64-decoded. Blank lines and lines starting with # are skipped.
+func readSamples(ciphertext, path string) ([][]byte, error) {
+	var lines []string
+	if ciphertext != "" {
+		lines = append(lines, ciphertext)
+	}
+	if path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	var samples [][]byte
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "{") {
+			samples = append(samples, []byte(line))
+			continue
+		}
+		sample, err := base64.StdEncoding.DecodeString(line)
+		if err != nil {
+			return nil, fmt.Errorf("ciphertext %q is neither JSON nor base64: %w", line, err)
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/util/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteConfig_JSON(t *testing.T) {
+	config, err := crypto.NewKeyGenerator().GenerateKeyConfig()
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, writeConfig(&out, config, true))
+
+	var parsed map[string]string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &parsed))
+	assert.Equal(t, config, parsed)
+	assert.NotEmpty(t, parsed["ENCRYPTION_CURRENT_KEY_ID"])
+	assert.NotEmpty(t, parsed["ENCRYPTION_KEYS"])
+}
+
+func TestWriteConfig_Env(t *testing.T) {
+	config := map[string]string{"ENCRYPTION_KEYS": "k1:abc", "ENCRYPTION_CURRENT_KEY_ID": "k1"}
+
+	var out bytes.Buffer
+	require.NoError(t, writeConfig(&out, config, false))
+	assert.Equal(t, "export ENCRYPTION_CURRENT_KEY_ID=\"k1\"\nexport ENCRYPTION_KEYS=\"k1:abc\"\n", out.String())
+}
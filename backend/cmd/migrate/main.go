@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
@@ -11,6 +12,13 @@ import (
 )
 
 func main() {
+	dir := flag.String("dir", "", "directory of versioned SQL migrations; when empty the models are auto-migrated")
+	rollback := flag.Int("rollback", 0, "number of SQL migrations to roll back")
+	rollbackTo := flag.Int64("rollback-to", -1, "roll back SQL migrations newer than this version")
+	status := flag.Bool("status", false, "list applied and pending SQL migrations")
+	dryRun := flag.Bool("dry-run", false, "print the SQL of pending migrations without applying it")
+	flag.Parse()
+
 	// Configure logging
 	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 	logger := log.With().Str("component", "migration").Logger()
@@ -27,11 +35,61 @@ func main() {
 		logger.Fatal().Err(err).Msg("Failed to connect to database")
 	}
 
-	// Run migrations
-	logger.Info().Msg("Starting database migrations")
-	if err := database.RunMigrations(db, &logger); err != nil {
-		logger.Fatal().Err(err).Msg("Failed to run migrations")
+	if *dir == "" {
+		// Run migrations
+		logger.Info().Msg("Starting database migrations")
+		if err := database.RunMigrations(db, &logger); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to run migrations")
+		}
+		fmt.Println("Migrations completed successfully")
+		return
+	}
+
+	migrations, err := database.LoadMigrations(os.DirFS(*dir), ".")
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to load migrations")
+	}
+	manager, err := database.NewMigrationManager(db, migrations, &logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid migrations")
+	}
+
+	switch {
+	case *status:
+		statuses, err := manager.Status()
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to read migration status")
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if s.Modified {
+				state += " (modified)"
+			}
+			fmt.Printf("%6d  %-40s  %s\n", s.Version, s.Name, state)
+		}
+		return
+	case *dryRun:
+		if err := manager.DryRun(os.Stdout); err != nil {
+			logger.Fatal().Err(err).Msg("Dry run failed")
+		}
+		return
+	case *rollback > 0:
+		err = manager.Rollback(*rollback)
+	case *rollbackTo >= 0:
+		err = manager.RollbackTo(*rollbackTo)
+	default:
+		err = manager.RunMigrations()
+	}
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Migration failed")
 	}
 
-	fmt.Println("Migrations completed successfully")
+	version, err := manager.CurrentVersion()
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to read schema version")
+	}
+	fmt.Printf("Schema is at version %d\n", version)
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -13,14 +14,23 @@ import (
 
 	adapterhttp "github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/delivery/http"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/delivery/http/handler"
+	httpmiddleware "github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/http/middleware"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/repo"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/wallet"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/di"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/factory"
-	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/logger"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/lifecycle"
+	applog "github.com/RyanLisse/go-crypto-bot-clean/backend/internal/logger"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/scheduler"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/telemetry"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/util/crypto"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/worker"
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 )
 
 func init() {
@@ -34,12 +44,24 @@ func init() {
 
 func main() {
 	// Initialize logger
-	logger := logger.NewLogger()
+	logger := applog.NewLogger()
 	logger.Info().Msg("Starting crypto bot backend service")
 
 	// Load configuration
 	cfg := config.LoadConfig(logger)
 
+	// Apply the configured log level, or the override set at runtime if one is persisted
+	logLevels := applog.NewLevelController(cfg.LogLevelOverrideFile)
+	if err := logLevels.Reload(cfg.LogLevel); err != nil {
+		logger.Warn().Err(err).Msg("Failed to apply log level")
+	}
+
+	// Export request traces when an OTLP endpoint is configured
+	shutdownTracing, err := telemetry.Setup(context.Background(), cfg.Version, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to set up tracing")
+	}
+
 	// Initialize DB connection
 	db := gorm.NewDB(cfg, logger)
 
@@ -48,14 +70,18 @@ func main() {
 		logger.Fatal().Err(err).Msg("Failed to run database migrations")
 	}
 
+	// Every MEXC client shares one circuit breaker, so a MEXC outage opens it for all of
+	// them and the health check reports it
+	mexcBreaker := factory.NewMEXCCircuitBreaker(cfg, logger)
+
 	// Initialize DI container
-	container := di.NewContainer(cfg, logger, db)
+	container := di.NewContainer(cfg, logger, db).WithMEXCCircuitBreaker(mexcBreaker)
 	if err := container.Initialize(); err != nil {
 		logger.Fatal().Err(err).Msg("Failed to initialize dependency injection container")
 	}
 
 	// Initialize factories
-	marketFactory := factory.NewMarketFactory(cfg, logger, db)
+	marketFactory := factory.NewMarketFactory(cfg, logger, db).WithMEXCCircuitBreaker(mexcBreaker)
 	statusFactory := factory.NewStatusFactory(cfg, logger, db)
 	accountFactory := factory.NewAccountFactory(cfg, logger, db)
 	apiCredentialFactory := factory.NewAPICredentialFactory(db, logger)
@@ -70,7 +96,13 @@ func main() {
 		logger.Fatal().Err(err).Msg("Failed to create market data use case")
 	}
 	mexcClient := marketFactory.CreateMEXCClient()
-	marketDataHandler := handler.NewMarketDataHandler(marketDataUseCase, mexcClient, logger)
+	marketDataHandler := handler.NewMarketDataHandler(marketDataUseCase, mexcClient, logger).
+		WithResponseCache(cfg.ResponseCache)
+	adminSyncHandler := handler.NewAdminSyncHandler(
+		marketFactory.CreateMarketSyncUseCase().WithEventPublisher(container.GetDomainEventBus()),
+		logger,
+	)
+	logLevelHandler := handler.NewLogLevelHandler(logLevels, logger)
 	logger.Info().Msg("Created market data handler")
 
 	// Create status use case and handler
@@ -78,12 +110,32 @@ func main() {
 	statusHandler := statusFactory.CreateStatusHandler()
 	logger.Info().Msg("Created status handler")
 	statusFactory.RegisterStatusProviders(statusUseCase, marketFactory)
-	if err := statusUseCase.Start(context.Background()); err != nil {
-		logger.Error().Err(err).Msg("Failed to start status monitoring")
-	}
 
-	// Create alert handler
-	alertHandler := statusFactory.CreateAlertHandler()
+	// Background components are started in registration order and stopped in reverse
+	lifecycleManager := lifecycle.NewLifecycleManager(lifecycle.DefaultStopTimeout, logger)
+	// Registered first so it stops last, once the other components have emitted their spans
+	lifecycleManager.Append(lifecycle.Hook{
+		Name:   "tracing",
+		OnStop: shutdownTracing,
+	})
+	lifecycleManager.Append(lifecycle.Hook{
+		Name: "status monitoring",
+		OnStart: func(ctx context.Context) error {
+			// The service can run without status monitoring
+			if err := statusUseCase.Start(ctx); err != nil {
+				logger.Error().Err(err).Msg("Failed to start status monitoring")
+			}
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			statusUseCase.Stop()
+			return nil
+		},
+	})
+
+	// Create alert handler. The autobuyer raises its alerts on the same notifier.
+	alertNotifier := statusFactory.CreateAlertNotifier()
+	alertHandler := handler.NewAlertHandler(alertNotifier, logger)
 	logger.Info().Msg("Created alert handler")
 
 	// Create test and auth handlers
@@ -93,7 +145,8 @@ func main() {
 	logger.Info().Msg("Created auth handler")
 
 	// Create account handler using the account factory
-	accountHandler := accountFactory.CreateAccountHandler(mexcClient)
+	accountHandler := accountFactory.CreateAccountHandler(mexcClient).
+		WithSnapshot(accountFactory.CreateAccountSnapshotUseCase(container.GetTradeUseCase()))
 	logger.Info().Msg("Created account handler")
 
 	// Create API credential handler
@@ -123,8 +176,18 @@ func main() {
 	walletProviderRegistry.RegisterProvider(ethereumProvider)
 	logger.Info().Msg("Registered Ethereum wallet provider")
 
+	// Register BSC and Polygon providers
+	for _, provider := range factory.NewWalletConnectionFactory(cfg, logger, db).CreateEVMChainProviders() {
+		walletProviderRegistry.RegisterProvider(provider)
+		logger.Info().Str("provider", provider.GetName()).Int64("chainID", provider.GetChainID()).Msg("Registered EVM wallet provider")
+	}
+
 	// Register MEXC provider
-	mexcProvider := wallet.NewMEXCProvider(mexcClient, logger)
+	mexcProvider := wallet.NewMEXCProvider(
+		mexcClient,
+		logger,
+		wallet.WithTransactionRepository(factory.NewRepositoryFactory(db, logger, cfg).CreateWalletTransactionRepository()),
+	)
 	walletProviderRegistry.RegisterProvider(mexcProvider)
 	logger.Info().Msg("Registered MEXC wallet provider")
 
@@ -165,47 +228,271 @@ func main() {
 	)
 	logger.Info().Msg("Created wallet data sync service")
 
-	// Use the wallet data sync service
-	_ = walletDataSyncService // Will be used by wallet service
+	// Sync every user's wallets in the background
+	walletSyncScheduler := walletDataSyncFactory.CreateWalletSyncScheduler(walletDataSyncService, apiCredentialManagerService)
+	walletSyncStatusHandler := handler.NewWalletSyncStatusHandler(walletSyncScheduler, logger)
+	if cfg.Wallet.Sync.Enabled {
+		lifecycleManager.Append(lifecycle.Hook{
+			Name:    "wallet sync scheduler",
+			OnStart: walletSyncScheduler.Start,
+			OnStop: func(ctx context.Context) error {
+				walletSyncScheduler.Stop()
+				return nil
+			},
+		})
+	}
+
+	// Report the use of the pre-trade risk limits
+	tradeFactory := factory.NewTradeFactory(cfg, logger, db).WithEventPublisher(container.GetDomainEventBus())
+	riskLimitsHandler := handler.NewRiskLimitsHandler(
+		tradeFactory.CreateRiskManager(marketFactory.CreateMarketDataService()),
+		logger,
+	)
+
+	// Place orders and report the order history and PnL of the signed-in user. The trade
+	// service enforces the risk limits, so no separate risk use case is passed.
+	orderRepo := tradeFactory.CreateOrderRepository()
+	symbolRepo := factory.NewRepositoryFactory(db, logger, cfg).CreateSymbolRepository()
+	tradeService := tradeFactory.CreateTradeService(mexcClient, marketFactory.CreateMarketDataService(), symbolRepo, orderRepo)
+	tradeUseCase := tradeFactory.CreateTradeUseCase(mexcClient, symbolRepo, orderRepo, tradeService, nil, container.GetTransactionManager())
+	tradeHandler := tradeFactory.CreateTradeHandler(tradeUseCase)
+
+	// Autobuy coins as they start trading and free the autobuyer's position slots as its
+	// positions are sold
+	autoBuyFactory := factory.NewAutoBuyFactory(cfg, logger, db, marketFactory, tradeFactory).
+		WithTradeUseCase(tradeUseCase).
+		WithAlertNotifier(alertNotifier)
+	autobuyService := autoBuyFactory.CreateAutobuyService()
+	autoBuyHandler := autoBuyFactory.CreateAutoBuyHandler()
+	var autobuyBuys, autobuyReleases port.Subscription
+	lifecycleManager.Append(lifecycle.Hook{
+		Name: "autobuy",
+		OnStart: func(ctx context.Context) error {
+			autobuyBuys = autobuyService.BuyNewListings(container.GetDomainEventBus())
+			autobuyReleases = autobuyService.ReleaseOnSell(container.GetDomainEventBus())
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			autobuyBuys.Unsubscribe()
+			autobuyReleases.Unsubscribe()
+			return nil
+		},
+	})
+
+	// Announce the coins that start trading to the autobuyer, replaying the listings
+	// recorded shortly before a restart
+	tradableRelay := usecase.NewTradableRelay(
+		container.GetEventBus(),
+		container.GetNewCoinRepository(),
+		container.GetDomainEventBus(),
+		usecase.DefaultTradableReplayWindow,
+		logger,
+	)
+	lifecycleManager.Append(lifecycle.Hook{
+		Name:    "tradable relay",
+		OnStart: tradableRelay.Start,
+		OnStop: func(ctx context.Context) error {
+			tradableRelay.Stop()
+			return nil
+		},
+	})
+
+	// Detect new listings
+	newCoinWorker := worker.NewNewCoinWorker(container.GetNewCoinUseCase(), cfg, *logger)
+	lifecycleManager.Append(lifecycle.Hook{
+		Name: "new coin detection",
+		OnStart: func(ctx context.Context) error {
+			go newCoinWorker.Start(ctx)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			newCoinWorker.Stop()
+			return nil
+		},
+	})
+
+	// Alert ahead of listings MEXC announces, re-arming the alerts scheduled before a restart
+	if cfg.AnnouncementParser.Enabled {
+		announcementParser := factory.NewMEXCFactory(cfg, logger).CreateAnnouncementParser(
+			factory.NewRepositoryFactory(db, logger, cfg).CreateScheduledListingRepository(),
+			container.GetDomainEventBus(),
+		)
+		lifecycleManager.Append(lifecycle.Hook{
+			Name: "announcement parser",
+			OnStart: func(ctx context.Context) error {
+				if _, err := announcementParser.RestoreSchedules(ctx); err != nil {
+					logger.Error().Err(err).Msg("Failed to restore pre-listing alerts")
+				}
+				announcementParser.StartPolling(ctx)
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				announcementParser.StopPolling()
+				announcementParser.CancelSchedules()
+				return nil
+			},
+		})
+	}
+
+	// Periodic jobs, started once every job is registered
+	schedulerLogger := logger.With().Str("component", "scheduler").Logger()
+	jobScheduler := scheduler.New(&schedulerLogger)
+	if cfg.Scheduler.LockEnabled {
+		// Replicas sharing the database run each job on the instance holding its lock
+		hostname, _ := os.Hostname()
+		instanceID := hostname + "-" + uuid.NewString()
+		jobScheduler.WithLock(repo.NewGormJobLockRepository(db, &schedulerLogger), instanceID, cfg.Scheduler.LockTTL)
+		schedulerLogger.Info().Str("instance", instanceID).Msg("Scheduled jobs run on the instance holding their lock")
+	}
+
+	// Trail stops on open long positions
+	if cfg.Trading.TrailingStop.Enabled {
+		_, symbolRepo := marketFactory.CreateMarketRepository()
+		trailingStopManager := tradeFactory.CreateTrailingStopManager(
+			container.GetPositionUseCase(),
+			container.GetTradeUseCase(),
+			marketFactory.CreateMarketDataService(),
+			symbolRepo,
+		)
+		if err := jobScheduler.AddInterval("trailing stops", cfg.Trading.TrailingStop.Interval, trailingStopManager.Check); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to schedule trailing stops")
+		}
+	}
+
+	// Keep orders current with the exchange, pushing updates from the user data stream
+	// when API keys are set, and publish fills and cancellations to the order streams
+	if cfg.Trading.Reconciliation.Enabled {
+		orderPublisher, _ := container.GetDomainEventBus().(port.OrderEventPublisher)
+		reconciliationService := tradeFactory.CreateReconciliationService(mexcClient, orderRepo, orderPublisher)
+		userDataStream := tradeFactory.CreateUserDataStream()
+		err := jobScheduler.AddInterval("order reconciliation", cfg.Trading.Reconciliation.Interval, func(ctx context.Context) error {
+			_, err := reconciliationService.ReconcileOnce(ctx)
+			return err
+		})
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to schedule order reconciliation")
+		}
+		lifecycleManager.Append(lifecycle.Hook{
+			Name: "order stream",
+			OnStart: func(ctx context.Context) error {
+				if cfg.MEXC.APIKey == "" {
+					return nil
+				}
+				// Orders are still reconciled by polling without the stream
+				if err := tradeFactory.StartOrderStreaming(ctx, userDataStream, reconciliationService); err != nil {
+					logger.Error().Err(err).Msg("Failed to start user data stream")
+				}
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				userDataStream.Stop()
+				return nil
+			},
+		})
+	}
+
+	lifecycleManager.Append(lifecycle.Hook{
+		Name:    "job scheduler",
+		OnStart: jobScheduler.Start,
+		OnStop:  jobScheduler.Stop,
+	})
 
 	// Create AI factory and handler
-	aiFactory := factory.NewAIFactory(cfg, *logger)
+	aiFactory := factory.NewAIFactory(cfg, *logger, db).WithMEXCCircuitBreaker(mexcBreaker)
 	aiHandler, err := aiFactory.CreateAIHandler()
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to create AI handler")
 	}
+	marketRepo, _ := marketFactory.CreateMarketRepository()
+	aiSignalUsecase, err := aiFactory.CreateAISignalUsecase(marketRepo)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to create AI signal use case")
+	}
+	aiHandler.WithSignalUsecase(aiSignalUsecase)
 	logger.Info().Msg("Created AI handler")
 
 	// Log the AI handler details
 	logger.Debug().Interface("aiHandler", aiHandler).Msg("AI handler details")
 
 	// Initialize router (now modular)
-	r := adapterhttp.NewRouter(cfg, logger, db)
+	r := adapterhttp.NewRouter(cfg, logger, db, mexcBreaker, lifecycleManager)
 
 	// Create MEXC handler
 	// mexcClient is already defined above
-	mexcHandler := handler.NewMEXCHandler(mexcClient, logger)
+	mexcHandler := handler.NewMEXCHandler(mexcClient, logger).WithExchangeInfoCacheTTL(cfg.ResponseCache.ExchangeInfo)
 	logger.Info().Msg("Created MEXC handler")
 
+	// Use the auth middleware
+	authMiddleware, err := adapterhttp.GetAuthMiddleware(cfg, logger, db)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to create auth middleware, falling back to test auth")
+		// Fallback to test auth middleware
+		authMiddleware = adapterhttp.GetTestAuthMiddleware(cfg, logger, db)
+	}
+
+	// Keep the local user records in step with Clerk's user lifecycle
+	if cfg.Auth.ClerkWebhookSecret != "" {
+		clerkWebhookHandler, err := factory.NewAuthFactory(db, logger).CreateClerkWebhookHandler(cfg.Auth.ClerkWebhookSecret)
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to create Clerk webhook handler, webhooks are disabled")
+		} else {
+			clerkWebhookHandler.RegisterRoutes(r)
+			logger.Info().Msg("Registered Clerk webhook at /webhooks/clerk")
+		}
+	}
+
+	// Push the user's order updates to clients
+	orderStreamHandler := handler.NewOrderStreamHandler(container.GetDomainEventBus(), logger)
+
+	// Push live tickers of the symbols clients ask for, polled while anyone listens
+	tickerFeed := usecase.NewTickerFeed(mexcClient, cfg.Market.Stream.TickerPollInterval, logger)
+	tickerStreamHandler := handler.NewTickerStreamHandler(tickerFeed, cfg.Market.Stream.TickerMaxRate, logger)
+	lifecycleManager.Append(lifecycle.Hook{
+		Name:    "ticker feed",
+		OnStart: tickerFeed.Start,
+		OnStop: func(ctx context.Context) error {
+			tickerFeed.Stop()
+			return nil
+		},
+	})
+
+	// Deliver bot events to the webhook endpoints users register
+	webhookFactory := factory.NewWebhookFactory(cfg, logger, db)
+	webhookEndpointHandler := webhookFactory.CreateWebhookEndpointHandler()
+	if cfg.OutboundWebhooks.Enabled {
+		webhookDispatcher := webhookFactory.CreateWebhookDispatcher(container.GetDomainEventBus())
+		lifecycleManager.Append(lifecycle.Hook{
+			Name:    "webhook dispatcher",
+			OnStart: webhookDispatcher.Start,
+			OnStop: func(ctx context.Context) error {
+				webhookDispatcher.Stop()
+				return nil
+			},
+		})
+	}
+
+	// Notify users about their orders
+	if cfg.Notifications.Trades.Enabled {
+		tradeNotifier := tradeFactory.CreateTradeNotifier(container.GetDomainEventBus(), container.GetNotificationRepository())
+		lifecycleManager.Append(lifecycle.Hook{
+			Name:    "trade notifier",
+			OnStart: tradeNotifier.Start,
+			OnStop: func(ctx context.Context) error {
+				tradeNotifier.Stop()
+				return nil
+			},
+		})
+	}
+
 	// API v1 routes
 	r.Route("/api/v1", func(r chi.Router) {
+		// Bound request bodies and handler time; route groups may tighten these
+		r.Use(httpmiddleware.RequestLimits(cfg.RequestLimits.Default))
+
 		// Public routes
 		r.Group(func(r chi.Router) {
 			statusHandler.RegisterRoutes(r)
 			authHandler.RegisterRoutes(r)
-
-			// Register AI routes without authentication for testing
-			logger.Info().Msg("Registering AI routes without authentication for testing")
-			// Create a dummy auth middleware that doesn't actually require authentication
-			dummyAuthMiddleware := func(next http.Handler) http.Handler {
-				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-					// Set a dummy user ID in the context
-					ctx := context.WithValue(r.Context(), "user_id", "test_user_id")
-					next.ServeHTTP(w, r.WithContext(ctx))
-				})
-			}
-			aiHandler.RegisterRoutes(r, dummyAuthMiddleware)
-			logger.Info().Msg("Registered AI routes at /api/v1/ai/* without authentication")
 		})
 
 		// Conditionally register test/dev endpoints
@@ -230,14 +517,6 @@ func main() {
 
 		// Protected routes (require authentication)
 		r.Group(func(r chi.Router) {
-			// Use the auth middleware
-			authMiddleware, err := adapterhttp.GetAuthMiddleware(cfg, logger, db)
-			if err != nil {
-				logger.Error().Err(err).Msg("Failed to create auth middleware, falling back to test auth")
-				// Fallback to test auth middleware
-				authMiddleware = adapterhttp.GetTestAuthMiddleware(cfg, logger, db)
-			}
-
 			// Use the middleware's RequireAuthentication method
 			r.Use(authMiddleware.RequireAuthentication)
 			marketDataHandler.RegisterRoutes(r)
@@ -246,25 +525,82 @@ func main() {
 			apiCredentialHandler.RegisterRoutes(r)
 			web3WalletHandler.RegisterRoutes(r, authMiddleware)
 			addressValidatorHandler.RegisterRoutes(r)
+			adminSyncHandler.RegisterRoutes(r, authMiddleware)
+			logLevelHandler.RegisterRoutes(r, authMiddleware)
+			walletSyncStatusHandler.RegisterRoutes(r, authMiddleware)
+			riskLimitsHandler.RegisterRoutes(r)
+			tradeHandler.RegisterRoutes(r)
+			aiHandler.RegisterRoutes(r, authMiddleware.RequireAuthentication)
+			autoBuyHandler.RegisterRoutes(r)
+			webhookEndpointHandler.RegisterRoutes(r)
 		})
 	})
 
-	// Create HTTP server
+	// Server-Sent Event streams stay open while the client is connected, so they are
+	// routed outside the handler time limit of the API routes
+	r.Route("/api/v1/stream", func(r chi.Router) {
+		r.Use(authMiddleware.RequireAuthentication)
+		orderStreamHandler.RegisterRoutes(r)
+		tickerStreamHandler.RegisterRoutes(r)
+	})
+	// Candle exports stream a page at a time for as long as the range takes, so they are
+	// routed outside the handler time limit too
+	r.With(authMiddleware.RequireAuthentication).Get("/api/v1/market/{symbol}/candles/export", marketDataHandler.ExportCandles)
+
+	// Create HTTP server. Requests arriving once shutdown begins are rejected with 503
+	// while the in-flight ones complete.
+	drainer := httpmiddleware.NewRequestDrainer(logger)
 	server := &http.Server{
-		Addr:    fmt.Sprintf(":%d", cfg.Server.Port),
-		Handler: r,
+		Addr:              fmt.Sprintf(":%d", cfg.Server.Port),
+		Handler:           drainer.Middleware(r),
+		ReadHeaderTimeout: cfg.Server.ReadTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
 	}
 
+	// The HTTP server is registered last, so it stops taking requests before the
+	// components that serve them are stopped
+	lifecycleManager.Append(lifecycle.Hook{
+		Name: "http server",
+		OnStop: func(ctx context.Context) error {
+			// Open streams would otherwise hold the drain until it times out
+			orderStreamHandler.Close()
+			tickerStreamHandler.Close()
+			drainErr := drainer.Drain(ctx)
+			return errors.Join(drainErr, server.Shutdown(ctx))
+		},
+		StopTimeout: 5 * time.Second,
+	})
+	if err := lifecycleManager.Start(context.Background()); err != nil {
+		logger.Fatal().Err(err).Msg("Failed to start background components")
+	}
+
+	// Reload the log level on SIGHUP; a persisted override stays in effect
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			reloaded, err := config.Load()
+			if err != nil {
+				logger.Error().Err(err).Msg("Failed to reload configuration")
+				continue
+			}
+			if err := logLevels.Reload(reloaded.LogLevel); err != nil {
+				logger.Warn().Err(err).Msg("Failed to apply log level")
+			}
+			logger.Info().Interface("log_level", logLevels.Status()).Msg("Configuration reloaded")
+		}
+	}()
+
 	// Graceful shutdown
 	shutdown := make(chan os.Signal, 1)
+	stopped := make(chan struct{})
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
 	go func() {
+		defer close(stopped)
 		<-shutdown
 		logger.Info().Msg("Shutting down server...")
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		if err := server.Shutdown(ctx); err != nil {
-			logger.Error().Err(err).Msg("Server shutdown error")
+		if err := lifecycleManager.Stop(context.Background()); err != nil {
+			logger.Error().Err(err).Msg("Shutdown error")
 		}
 	}()
 
@@ -273,5 +609,6 @@ func main() {
 	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		logger.Fatal().Err(err).Msg("Server failed to start")
 	}
+	<-stopped
 	logger.Info().Msg("Server shutdown complete")
 }
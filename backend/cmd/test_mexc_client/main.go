@@ -95,7 +95,8 @@ func main() {
 	// Try direct HTTP call to test if the API key is valid
 	log.Info().Msg("Testing API key directly with HTTP request")
 	client := &http.Client{Timeout: 10 * time.Second}
-	req, err := http.NewRequest("GET", "https://api.mexc.com/api/v3/exchangeInfo", nil)
+	baseURL, _ := cfg.MEXCEndpoints()
+	req, err := http.NewRequest("GET", baseURL+"/api/v3/exchangeInfo", nil)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to create test request")
 		os.Exit(1)
@@ -128,7 +129,8 @@ func main() {
 
 	// Initialize MEXC client
 	log.Info().Msg("Initializing MEXC client")
-	mexcClient := mexc.NewClient(apiKey, apiSecret, log) // Use directly from environment
+	log.Info().Str("baseURL", baseURL).Msg("Using MEXC endpoint")
+	mexcClient := mexc.NewClientWithBaseURL(apiKey, apiSecret, baseURL, log) // Use credentials directly from environment
 
 	// Test a simple API call
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
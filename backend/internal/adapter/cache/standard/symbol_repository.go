@@ -0,0 +1,130 @@
+package standard
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+)
+
+// cachedSymbol is a symbol looked up by GetBySymbol and when it stops being served
+type cachedSymbol struct {
+	symbol    *market.Symbol
+	expiresAt time.Time
+}
+
+// CachedSymbolRepository caches the symbols returned by GetBySymbol of a SymbolRepository
+// for a TTL, as symbol metadata rarely changes but is read for every order. Writes through
+// the repository drop the symbols they change from the cache. Lookups that fail are not
+// cached, and every other read goes to the repository.
+type CachedSymbolRepository struct {
+	port.SymbolRepository
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	symbols map[string]cachedSymbol
+	// generation changes on every invalidation, so a lookup that raced a write does not
+	// cache what it read before the write
+	generation uint64
+}
+
+// NewCachedSymbolRepository creates a CachedSymbolRepository over repo
+func NewCachedSymbolRepository(repo port.SymbolRepository, ttl time.Duration) *CachedSymbolRepository {
+	return &CachedSymbolRepository{
+		SymbolRepository: repo,
+		ttl:              ttl,
+		now:              time.Now,
+		symbols:          make(map[string]cachedSymbol),
+	}
+}
+
+// GetBySymbol returns the cached symbol while it is fresh, or looks it up and caches it
+func (r *CachedSymbolRepository) GetBySymbol(ctx context.Context, symbol string) (*market.Symbol, error) {
+	r.mu.Lock()
+	cached, ok := r.symbols[symbol]
+	generation := r.generation
+	r.mu.Unlock()
+	if ok && r.now().Before(cached.expiresAt) {
+		return copySymbol(cached.symbol), nil
+	}
+
+	found, err := r.SymbolRepository.GetBySymbol(ctx, symbol)
+	if err != nil || found == nil {
+		return found, err
+	}
+
+	r.mu.Lock()
+	if r.generation == generation {
+		r.symbols[symbol] = cachedSymbol{symbol: copySymbol(found), expiresAt: r.now().Add(r.ttl)}
+	}
+	r.mu.Unlock()
+	return found, nil
+}
+
+// Create stores a new symbol and drops it from the cache
+func (r *CachedSymbolRepository) Create(ctx context.Context, symbol *market.Symbol) error {
+	defer r.invalidate(symbol.Symbol)
+	return r.SymbolRepository.Create(ctx, symbol)
+}
+
+// Update updates a symbol and drops it from the cache
+func (r *CachedSymbolRepository) Update(ctx context.Context, symbol *market.Symbol) error {
+	defer r.invalidate(symbol.Symbol)
+	return r.SymbolRepository.Update(ctx, symbol)
+}
+
+// Upsert creates or updates a symbol and drops it from the cache
+func (r *CachedSymbolRepository) Upsert(ctx context.Context, symbol *market.Symbol) error {
+	defer r.invalidate(symbol.Symbol)
+	return r.SymbolRepository.Upsert(ctx, symbol)
+}
+
+// UpsertMany upserts symbols and drops them from the cache
+func (r *CachedSymbolRepository) UpsertMany(ctx context.Context, symbols []*market.Symbol) (int, int, error) {
+	names := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		names[i] = symbol.Symbol
+	}
+	defer r.invalidate(names...)
+	return r.SymbolRepository.UpsertMany(ctx, symbols)
+}
+
+// Delete soft-deletes a symbol and drops it from the cache
+func (r *CachedSymbolRepository) Delete(ctx context.Context, symbol string) error {
+	defer r.invalidate(symbol)
+	return r.SymbolRepository.Delete(ctx, symbol)
+}
+
+// Restore reverts the soft-delete of a symbol and drops it from the cache
+func (r *CachedSymbolRepository) Restore(ctx context.Context, symbol string) error {
+	defer r.invalidate(symbol)
+	return r.SymbolRepository.Restore(ctx, symbol)
+}
+
+// invalidate drops symbols from the cache. It runs after the write, whether or not the
+// write succeeded, as a failed write may still have changed some of the symbols.
+func (r *CachedSymbolRepository) invalidate(symbols ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.generation++
+	for _, symbol := range symbols {
+		delete(r.symbols, symbol)
+	}
+}
+
+// copySymbol returns a copy that callers may modify without affecting the cache
+func copySymbol(symbol *market.Symbol) *market.Symbol {
+	copied := *symbol
+	copied.AllowedOrderTypes = append([]string(nil), symbol.AllowedOrderTypes...)
+	if symbol.DeletedAt != nil {
+		deletedAt := *symbol.DeletedAt
+		copied.DeletedAt = &deletedAt
+	}
+	return &copied
+}
+
+// Ensure CachedSymbolRepository implements port.SymbolRepository
+var _ port.SymbolRepository = (*CachedSymbolRepository)(nil)
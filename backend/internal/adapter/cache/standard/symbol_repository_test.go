@@ -0,0 +1,92 @@
+package standard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingSymbols serves stored symbols and counts the lookups
+type countingSymbols struct {
+	port.SymbolRepository
+	symbols map[string]market.Symbol
+	lookups int
+}
+
+func (s *countingSymbols) GetBySymbol(ctx context.Context, symbol string) (*market.Symbol, error) {
+	s.lookups++
+	stored, ok := s.symbols[symbol]
+	if !ok {
+		return nil, errors.New("symbol not found")
+	}
+	return &stored, nil
+}
+
+func (s *countingSymbols) Update(ctx context.Context, symbol *market.Symbol) error {
+	s.symbols[symbol.Symbol] = *symbol
+	return nil
+}
+
+func newTestCachedSymbols() (*CachedSymbolRepository, *countingSymbols, *time.Time) {
+	repo := &countingSymbols{symbols: map[string]market.Symbol{
+		"BTCUSDT": {Symbol: "BTCUSDT", Status: "TRADING", TickSize: 0.01, AllowedOrderTypes: []string{"LIMIT", "MARKET"}},
+	}}
+	cached := NewCachedSymbolRepository(repo, time.Minute)
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	cached.now = func() time.Time { return now }
+	return cached, repo, &now
+}
+
+func TestCachedSymbolRepository_GetBySymbolHitsCacheWithinTTL(t *testing.T) {
+	ctx := context.Background()
+	cached, repo, now := newTestCachedSymbols()
+
+	first, err := cached.GetBySymbol(ctx, "BTCUSDT")
+	require.NoError(t, err)
+	first.AllowedOrderTypes[0] = "changed by caller"
+
+	*now = now.Add(59 * time.Second)
+	second, err := cached.GetBySymbol(ctx, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, 1, repo.lookups)
+	assert.Equal(t, 0.01, second.TickSize)
+	assert.Equal(t, []string{"LIMIT", "MARKET"}, second.AllowedOrderTypes, "callers cannot change the cached symbol")
+
+	*now = now.Add(time.Second)
+	_, err = cached.GetBySymbol(ctx, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, 2, repo.lookups, "the symbol is looked up again once the TTL has passed")
+
+	// Failed lookups are not cached
+	for i := 0; i < 2; i++ {
+		_, err = cached.GetBySymbol(ctx, "ETHUSDT")
+		require.Error(t, err)
+	}
+	assert.Equal(t, 4, repo.lookups)
+}
+
+func TestCachedSymbolRepository_UpdateInvalidates(t *testing.T) {
+	ctx := context.Background()
+	cached, repo, _ := newTestCachedSymbols()
+
+	symbol, err := cached.GetBySymbol(ctx, "BTCUSDT")
+	require.NoError(t, err)
+
+	symbol.Status = "BREAK"
+	require.NoError(t, cached.Update(ctx, symbol))
+
+	updated, err := cached.GetBySymbol(ctx, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, "BREAK", updated.Status)
+	assert.Equal(t, 2, repo.lookups)
+
+	_, err = cached.GetBySymbol(ctx, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, 2, repo.lookups, "the updated symbol is cached again")
+}
@@ -1,46 +1,116 @@
 package delivery
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sync"
 
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
 	"github.com/rs/zerolog"
 )
 
-// InMemoryEventBus implements port.EventBus using in-memory channels
+// InMemoryEventBus implements port.EventBus and port.DomainEventBus using in-memory channels
 type InMemoryEventBus struct {
 	listeners []func(*model.NewCoinEvent)
 	mu        sync.RWMutex
 	logger    zerolog.Logger
+	store     port.EventStore
+
+	options     EventBusOptions
+	subscribers []*topicSubscriber
+	subMu       sync.RWMutex
+	nextSubID   uint64
 }
 
-// NewInMemoryEventBus creates a new InMemoryEventBus
+// NewInMemoryEventBus creates a new InMemoryEventBus with the default subscriber options
 func NewInMemoryEventBus(logger zerolog.Logger) *InMemoryEventBus {
+	return NewInMemoryEventBusWithOptions(logger, DefaultEventBusOptions())
+}
+
+// NewInMemoryEventBusWithOptions creates a new InMemoryEventBus whose topic subscribers
+// use the given buffer size and overflow policy
+func NewInMemoryEventBusWithOptions(logger zerolog.Logger, options EventBusOptions) *InMemoryEventBus {
+	if options.BufferSize <= 0 {
+		options.BufferSize = DefaultEventBusOptions().BufferSize
+	}
 	return &InMemoryEventBus{
 		listeners: make([]func(*model.NewCoinEvent), 0),
 		logger:    logger.With().Str("component", "InMemoryEventBus").Logger(),
+		options:   options,
 	}
 }
 
-// Publish sends an event to all registered listeners asynchronously
+// WithEventStore makes the bus record every published event in store before fan-out,
+// which enables SubscribeFrom
+func (b *InMemoryEventBus) WithEventStore(store port.EventStore) *InMemoryEventBus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.store = store
+	return b
+}
+
+// Publish records the event in the event store, if any, and then sends it to all
+// registered listeners asynchronously
 func (b *InMemoryEventBus) Publish(event *model.NewCoinEvent) {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
+	if b.store != nil {
+		if offset, err := b.store.Append(context.Background(), event); err != nil {
+			b.logger.Error().Err(err).Str("coin_id", event.CoinID).Msg("Failed to record event before publishing")
+		} else {
+			b.logger.Debug().Int64("offset", offset).Str("event_id", event.ID).Msg("Recorded event")
+		}
+	}
+
 	b.logger.Info().Str("event_type", event.EventType).Str("coin_id", event.CoinID).Msg("Publishing event")
 	for _, listener := range b.listeners {
-		go func(l func(*model.NewCoinEvent)) {
-			defer func() {
-				if r := recover(); r != nil {
-					b.logger.Error().Interface("panic", r).Msg("Recovered from panic in event listener")
-				}
-			}()
-			l(event)
-		}(listener)
+		go b.notify(listener, event)
 	}
 }
 
+// SubscribeFrom replays stored events with an offset of at least offset to the listener,
+// in order, and keeps it subscribed to new events. Replay runs on the caller's goroutine
+// after the listener is registered, so live events may arrive while it is in progress;
+// listeners should deduplicate by event ID.
+func (b *InMemoryEventBus) SubscribeFrom(ctx context.Context, offset int64, listener func(*model.NewCoinEvent)) error {
+	b.mu.Lock()
+	if b.store == nil {
+		b.mu.Unlock()
+		return errors.New("event bus has no event store to replay from")
+	}
+	// Reading and registering under the write lock ensures no event falls between the
+	// replayed log and live delivery
+	stored, err := b.store.ReadFrom(ctx, offset, 0)
+	if err != nil {
+		b.mu.Unlock()
+		return fmt.Errorf("failed to read event log: %w", err)
+	}
+	b.listeners = append(b.listeners, listener)
+	b.mu.Unlock()
+
+	b.logger.Info().Int64("offset", offset).Int("replayed", len(stored)).Msg("Replaying events to new listener")
+	for _, record := range stored {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		b.notify(listener, record.Event)
+	}
+	return nil
+}
+
+// notify invokes a listener, recovering from panics
+func (b *InMemoryEventBus) notify(listener func(*model.NewCoinEvent), event *model.NewCoinEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Error().Interface("panic", r).Msg("Recovered from panic in event listener")
+		}
+	}()
+	listener(event)
+}
+
 // Subscribe adds a listener for new coin events
 func (b *InMemoryEventBus) Subscribe(listener func(*model.NewCoinEvent)) {
 	b.mu.Lock()
@@ -0,0 +1,81 @@
+package delivery
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/repo"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// coinEventRecorder collects the NewCoinEvents delivered to a listener
+type coinEventRecorder struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (r *coinEventRecorder) handle(evt *model.NewCoinEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ids = append(r.ids, evt.ID)
+}
+
+func (r *coinEventRecorder) received() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.ids...)
+}
+
+func newStoredEventBus(t *testing.T) *InMemoryEventBus {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.NewCoinEventLogEntity{}))
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	return NewInMemoryEventBus(logger).WithEventStore(repo.NewGormEventStore(db, &logger))
+}
+
+func TestInMemoryEventBus_SubscribeFromReplaysStoredEvents(t *testing.T) {
+	bus := newStoredEventBus(t)
+
+	// Published while no subscriber was running
+	bus.Publish(&model.NewCoinEvent{ID: "evt-1", CoinID: "coin-1", EventType: "new_coin_detected", NewStatus: model.StatusExpected})
+	bus.Publish(&model.NewCoinEvent{ID: "evt-2", CoinID: "coin-1", EventType: "status_changed", OldStatus: model.StatusExpected, NewStatus: model.StatusTrading})
+
+	late := &coinEventRecorder{}
+	require.NoError(t, bus.SubscribeFrom(context.Background(), 0, late.handle))
+	assert.Equal(t, []string{"evt-1", "evt-2"}, late.received())
+
+	// The listener stays subscribed to live events after the replay
+	bus.Publish(&model.NewCoinEvent{ID: "evt-3", CoinID: "coin-2", EventType: "new_coin_detected"})
+	require.Eventually(t, func() bool { return len(late.received()) == 3 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "evt-3", late.received()[2])
+}
+
+func TestInMemoryEventBus_SubscribeFromOffsetSkipsEarlierEvents(t *testing.T) {
+	bus := newStoredEventBus(t)
+
+	bus.Publish(&model.NewCoinEvent{ID: "evt-1", CoinID: "coin-1"})
+	bus.Publish(&model.NewCoinEvent{ID: "evt-2", CoinID: "coin-1"})
+
+	stored, err := bus.store.ReadFrom(context.Background(), 0, 0)
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+
+	recorder := &coinEventRecorder{}
+	require.NoError(t, bus.SubscribeFrom(context.Background(), stored[1].Offset, recorder.handle))
+	assert.Equal(t, []string{"evt-2"}, recorder.received())
+}
+
+func TestInMemoryEventBus_SubscribeFromRequiresStore(t *testing.T) {
+	bus := NewInMemoryEventBus(zerolog.Nop())
+	err := bus.SubscribeFrom(context.Background(), 0, func(*model.NewCoinEvent) {})
+	assert.Error(t, err)
+}
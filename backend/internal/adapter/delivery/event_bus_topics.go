@@ -0,0 +1,199 @@
+package delivery
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+)
+
+// OverflowPolicy decides what happens when a subscriber's buffer is full
+type OverflowPolicy string
+
+const (
+	// OverflowDrop discards the event for the full subscriber; other subscribers are unaffected
+	OverflowDrop OverflowPolicy = "drop"
+	// OverflowBlock makes the publisher wait until the subscriber has room, which also
+	// delays delivery to subscribers later in the list
+	OverflowBlock OverflowPolicy = "block"
+)
+
+// EventBusOptions configures the per-subscriber queues of topic subscriptions
+type EventBusOptions struct {
+	BufferSize int
+	Overflow   OverflowPolicy
+}
+
+// DefaultEventBusOptions returns the options used by NewInMemoryEventBus
+func DefaultEventBusOptions() EventBusOptions {
+	return EventBusOptions{
+		BufferSize: 64,
+		Overflow:   OverflowDrop,
+	}
+}
+
+// topicSubscriber is a single topic subscription with its own queue and worker goroutine
+type topicSubscriber struct {
+	id      uint64
+	bus     *InMemoryEventBus
+	topics  map[event.EventType]struct{} // Empty means all topics
+	handler func(event.DomainEvent)
+	queue   chan event.DomainEvent
+	done    chan struct{}
+	once    sync.Once
+	dropped atomic.Int64
+}
+
+// PublishEvent implements port.DomainEventBus. Each matching subscriber receives the event
+// on its own queue, so a slow handler only delays its own deliveries.
+func (b *InMemoryEventBus) PublishEvent(evt event.DomainEvent) {
+	if evt == nil {
+		return
+	}
+
+	b.subMu.RLock()
+	subscribers := make([]*topicSubscriber, 0, len(b.subscribers))
+	for _, sub := range b.subscribers {
+		if sub.wants(evt.Type()) {
+			subscribers = append(subscribers, sub)
+		}
+	}
+	b.subMu.RUnlock()
+
+	b.logger.Debug().
+		Str("event_type", string(evt.Type())).
+		Str("aggregate_id", evt.AggregateID()).
+		Int("subscribers", len(subscribers)).
+		Msg("Publishing domain event")
+
+	for _, sub := range subscribers {
+		sub.enqueue(evt, b.options.Overflow)
+	}
+}
+
+// PublishOrderEvent implements port.OrderEventPublisher by routing order transitions to
+// topic subscribers
+func (b *InMemoryEventBus) PublishOrderEvent(evt *event.OrderStatusChanged) {
+	if evt == nil {
+		return
+	}
+	b.PublishEvent(evt)
+}
+
+// SubscribeTopics implements port.DomainEventBus
+func (b *InMemoryEventBus) SubscribeTopics(handler func(event.DomainEvent), topics ...event.EventType) port.Subscription {
+	sub := &topicSubscriber{
+		bus:     b,
+		topics:  make(map[event.EventType]struct{}, len(topics)),
+		handler: handler,
+		queue:   make(chan event.DomainEvent, b.options.BufferSize),
+		done:    make(chan struct{}),
+	}
+	for _, topic := range topics {
+		sub.topics[topic] = struct{}{}
+	}
+
+	b.subMu.Lock()
+	b.nextSubID++
+	sub.id = b.nextSubID
+	b.subscribers = append(b.subscribers, sub)
+	b.subMu.Unlock()
+
+	go sub.run()
+
+	b.logger.Info().Uint64("subscription_id", sub.id).Int("topics", len(topics)).Msg("Topic subscriber registered")
+	return sub
+}
+
+// Unsubscribe implements port.Subscription. Events still queued are discarded and no new
+// delivery starts once it returns; a delivery already in progress runs to completion.
+func (s *topicSubscriber) Unsubscribe() {
+	s.once.Do(func() {
+		// Close done before taking the lock so a publisher blocked on this subscriber is released
+		close(s.done)
+
+		b := s.bus
+		b.subMu.Lock()
+		for i, sub := range b.subscribers {
+			if sub == s {
+				b.subscribers = append(b.subscribers[:i:i], b.subscribers[i+1:]...)
+				break
+			}
+		}
+		b.subMu.Unlock()
+
+		b.logger.Info().
+			Uint64("subscription_id", s.id).
+			Int64("dropped", s.dropped.Load()).
+			Msg("Topic subscriber unsubscribed")
+	})
+}
+
+// wants reports whether the subscriber is interested in the event type
+func (s *topicSubscriber) wants(eventType event.EventType) bool {
+	if len(s.topics) == 0 {
+		return true
+	}
+	_, ok := s.topics[eventType]
+	return ok
+}
+
+// enqueue hands the event to the subscriber's queue according to the overflow policy
+func (s *topicSubscriber) enqueue(evt event.DomainEvent, policy OverflowPolicy) {
+	if policy == OverflowBlock {
+		select {
+		case s.queue <- evt:
+		case <-s.done:
+		}
+		return
+	}
+
+	select {
+	case s.queue <- evt:
+	case <-s.done:
+	default:
+		dropped := s.dropped.Add(1)
+		s.bus.logger.Warn().
+			Uint64("subscription_id", s.id).
+			Str("event_type", string(evt.Type())).
+			Int64("dropped", dropped).
+			Msg("Subscriber queue full, dropping event")
+	}
+}
+
+// run delivers queued events to the handler until the subscription ends
+func (s *topicSubscriber) run() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case evt := <-s.queue:
+			// Re-check so that nothing is delivered after Unsubscribe returns
+			select {
+			case <-s.done:
+				return
+			default:
+			}
+			s.deliver(evt)
+		}
+	}
+}
+
+// deliver invokes the handler, recovering from panics so the worker keeps running
+func (s *topicSubscriber) deliver(evt event.DomainEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.bus.logger.Error().Interface("panic", r).Uint64("subscription_id", s.id).Msg("Recovered from panic in topic subscriber")
+		}
+	}()
+	s.handler(evt)
+}
+
+// Compile-time interface checks
+var (
+	_ port.EventBus            = (*InMemoryEventBus)(nil)
+	_ port.ReplayableEventBus  = (*InMemoryEventBus)(nil)
+	_ port.DomainEventBus      = (*InMemoryEventBus)(nil)
+	_ port.OrderEventPublisher = (*InMemoryEventBus)(nil)
+)
@@ -0,0 +1,175 @@
+package delivery
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eventRecorder collects the events delivered to a handler
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []event.DomainEvent
+}
+
+func (r *eventRecorder) handle(evt event.DomainEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, evt)
+}
+
+func (r *eventRecorder) types() []event.EventType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	types := make([]event.EventType, 0, len(r.events))
+	for _, evt := range r.events {
+		types = append(types, evt.Type())
+	}
+	return types
+}
+
+func (r *eventRecorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.events)
+}
+
+func newListingEvent() event.DomainEvent {
+	now := time.Now()
+	return event.NewNewCoinTradable(&model.NewCoin{Symbol: "NEWUSDT", BecameTradableAt: &now}, nil, nil)
+}
+
+func orderFilledEvent() *event.OrderStatusChanged {
+	return event.NewOrderStatusChanged(&model.Order{OrderID: "1", Status: model.OrderStatusFilled}, model.OrderStatusNew)
+}
+
+func riskAlertEvent() event.DomainEvent {
+	return event.NewRiskAlertRaised(model.NewRiskAssessment("user", model.RiskTypeConcentration, model.RiskLevelHigh, "too concentrated"))
+}
+
+func TestInMemoryEventBus_SubscribeTopicsRoutesByType(t *testing.T) {
+	bus := NewInMemoryEventBus(zerolog.Nop())
+
+	listings := &eventRecorder{}
+	orders := &eventRecorder{}
+	all := &eventRecorder{}
+	defer bus.SubscribeTopics(listings.handle, event.NewCoinTradableEvent).Unsubscribe()
+	defer bus.SubscribeTopics(orders.handle, event.OrderFilledEvent, event.RiskAlertEvent).Unsubscribe()
+	defer bus.SubscribeTopics(all.handle).Unsubscribe()
+
+	bus.PublishEvent(newListingEvent())
+	bus.PublishOrderEvent(orderFilledEvent())
+	bus.PublishEvent(riskAlertEvent())
+
+	require.Eventually(t, func() bool { return all.count() == 3 }, time.Second, 5*time.Millisecond)
+	require.Eventually(t, func() bool { return orders.count() == 2 }, time.Second, 5*time.Millisecond)
+	require.Eventually(t, func() bool { return listings.count() == 1 }, time.Second, 5*time.Millisecond)
+
+	assert.Equal(t, []event.EventType{event.NewCoinTradableEvent}, listings.types())
+	assert.Equal(t, []event.EventType{event.OrderFilledEvent, event.RiskAlertEvent}, orders.types())
+	assert.Equal(t, []event.EventType{event.NewCoinTradableEvent, event.OrderFilledEvent, event.RiskAlertEvent}, all.types())
+}
+
+func TestInMemoryEventBus_Unsubscribe(t *testing.T) {
+	bus := NewInMemoryEventBus(zerolog.Nop())
+
+	stopped := &eventRecorder{}
+	active := &eventRecorder{}
+	sub := bus.SubscribeTopics(stopped.handle, event.OrderFilledEvent)
+	defer bus.SubscribeTopics(active.handle, event.OrderFilledEvent).Unsubscribe()
+
+	bus.PublishOrderEvent(orderFilledEvent())
+	require.Eventually(t, func() bool { return stopped.count() == 1 && active.count() == 1 }, time.Second, 5*time.Millisecond)
+
+	sub.Unsubscribe()
+	sub.Unsubscribe() // Second call is a no-op
+
+	bus.PublishOrderEvent(orderFilledEvent())
+	require.Eventually(t, func() bool { return active.count() == 2 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, 1, stopped.count())
+
+	bus.subMu.RLock()
+	assert.Len(t, bus.subscribers, 1)
+	bus.subMu.RUnlock()
+}
+
+func TestInMemoryEventBus_SlowSubscriberDropsWithoutBlockingOthers(t *testing.T) {
+	bus := NewInMemoryEventBusWithOptions(zerolog.Nop(), EventBusOptions{BufferSize: 1, Overflow: OverflowDrop})
+
+	release := make(chan struct{})
+	slow := &eventRecorder{}
+	slowSub := bus.SubscribeTopics(func(evt event.DomainEvent) {
+		<-release
+		slow.handle(evt)
+	}, event.OrderFilledEvent)
+	fast := &eventRecorder{}
+	defer bus.SubscribeTopics(fast.handle, event.OrderFilledEvent).Unsubscribe()
+
+	published := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			bus.PublishOrderEvent(orderFilledEvent())
+		}
+		close(published)
+	}()
+
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("publisher blocked on a slow subscriber")
+	}
+	require.Eventually(t, func() bool { return fast.count() > 0 }, time.Second, 5*time.Millisecond)
+
+	assert.Positive(t, slowSub.(*topicSubscriber).dropped.Load())
+
+	close(release)
+	slowSub.Unsubscribe()
+	assert.LessOrEqual(t, slow.count(), 2)
+}
+
+func TestInMemoryEventBus_BlockPolicyDeliversEverything(t *testing.T) {
+	bus := NewInMemoryEventBusWithOptions(zerolog.Nop(), EventBusOptions{BufferSize: 1, Overflow: OverflowBlock})
+
+	recorder := &eventRecorder{}
+	defer bus.SubscribeTopics(func(evt event.DomainEvent) {
+		time.Sleep(time.Millisecond)
+		recorder.handle(evt)
+	}, event.OrderFilledEvent).Unsubscribe()
+
+	for i := 0; i < 10; i++ {
+		bus.PublishOrderEvent(orderFilledEvent())
+	}
+
+	require.Eventually(t, func() bool { return recorder.count() == 10 }, time.Second, 5*time.Millisecond)
+}
+
+func TestInMemoryEventBus_UnsubscribeReleasesBlockedPublisher(t *testing.T) {
+	bus := NewInMemoryEventBusWithOptions(zerolog.Nop(), EventBusOptions{BufferSize: 1, Overflow: OverflowBlock})
+
+	block := make(chan struct{})
+	defer close(block)
+	sub := bus.SubscribeTopics(func(event.DomainEvent) { <-block }, event.OrderFilledEvent)
+
+	published := make(chan struct{})
+	go func() {
+		for i := 0; i < 5; i++ {
+			bus.PublishOrderEvent(orderFilledEvent())
+		}
+		close(published)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	sub.Unsubscribe()
+
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("publisher still blocked after unsubscribe")
+	}
+}
@@ -16,8 +16,9 @@ import (
 
 // AccountHandler handles account-related endpoints
 type AccountHandler struct {
-	useCase usecase.AccountUsecase
-	logger  *zerolog.Logger
+	useCase  usecase.AccountUsecase
+	snapshot *usecase.AccountSnapshotUseCase
+	logger   *zerolog.Logger
 }
 
 // NewAccountHandler creates a new AccountHandler
@@ -28,6 +29,12 @@ func NewAccountHandler(useCase usecase.AccountUsecase, logger *zerolog.Logger) *
 	}
 }
 
+// WithSnapshot enables the account snapshot endpoint
+func (h *AccountHandler) WithSnapshot(snapshot *usecase.AccountSnapshotUseCase) *AccountHandler {
+	h.snapshot = snapshot
+	return h
+}
+
 // RegisterRoutes registers the account routes
 func (h *AccountHandler) RegisterRoutes(r chi.Router) {
 	h.logger.Info().Msg("Registering account routes")
@@ -37,6 +44,9 @@ func (h *AccountHandler) RegisterRoutes(r chi.Router) {
 		r.Get("/wallet", h.GetWallet)
 		r.Get("/balance/{asset}", h.GetBalanceHistory)
 		r.Post("/refresh", h.RefreshWallet)
+		if h.snapshot != nil {
+			r.Get("/snapshot", h.GetSnapshot)
+		}
 	})
 	h.logger.Info().Msg("Account routes registered")
 }
@@ -75,6 +85,28 @@ func (h *AccountHandler) GetWallet(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetSnapshot returns the authenticated user's balances, open orders and recent orders
+// in one response. Sections that could not be gathered are flagged rather than failing
+// the request.
+func (h *AccountHandler) GetSnapshot(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		apperror.WriteError(w, apperror.NewUnauthorized("User not authenticated", nil))
+		return
+	}
+
+	snapshot := h.snapshot.GetSnapshot(r.Context(), userID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    snapshot,
+	}); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode account snapshot")
+	}
+}
+
 // GetBalanceHistory returns the balance history for a specific asset
 func (h *AccountHandler) GetBalanceHistory(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
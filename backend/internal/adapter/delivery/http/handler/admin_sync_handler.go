@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/http/middleware"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+)
+
+// adminRole is the role required to trigger a sync
+const adminRole = "admin"
+
+// AdminSyncHandler lets operators refresh market data without restarting
+type AdminSyncHandler struct {
+	sync   *usecase.MarketSyncUseCase
+	logger *zerolog.Logger
+}
+
+// NewAdminSyncHandler creates an AdminSyncHandler
+func NewAdminSyncHandler(sync *usecase.MarketSyncUseCase, logger *zerolog.Logger) *AdminSyncHandler {
+	return &AdminSyncHandler{sync: sync, logger: logger}
+}
+
+// RegisterRoutes registers the admin sync routes, which require the admin role
+func (h *AdminSyncHandler) RegisterRoutes(r chi.Router, authMiddleware middleware.AuthMiddleware) {
+	r.Route("/admin/sync", func(r chi.Router) {
+		r.Use(authMiddleware.RequireAuthentication)
+		r.Use(authMiddleware.RequireRole(adminRole))
+		r.Post("/symbols", h.SyncSymbols)
+		r.Post("/tickers", h.SyncTickers)
+	})
+}
+
+// SyncSymbols refreshes the stored symbols from the exchange
+func (h *AdminSyncHandler) SyncSymbols(w http.ResponseWriter, r *http.Request) {
+	result, err := h.sync.SyncSymbols(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Manual symbol sync failed")
+		apperror.WriteError(w, err)
+		return
+	}
+	h.writeResult(w, map[string]interface{}{
+		"fetched":        result.Fetched,
+		"created":        result.Created,
+		"updated":        result.Updated,
+		"status_changed": result.StatusChanged,
+		"duration_ms":    result.Duration.Milliseconds(),
+	})
+}
+
+// SyncTickers refreshes the stored tickers of the trading symbols
+func (h *AdminSyncHandler) SyncTickers(w http.ResponseWriter, r *http.Request) {
+	result, err := h.sync.SyncTickers(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Manual ticker sync failed")
+		apperror.WriteError(w, err)
+		return
+	}
+	h.writeResult(w, map[string]interface{}{
+		"symbols":     result.Symbols,
+		"saved":       result.Saved,
+		"failed":      result.Failed,
+		"duration_ms": result.Duration.Milliseconds(),
+	})
+}
+
+// writeResult writes a sync summary
+func (h *AdminSyncHandler) writeResult(w http.ResponseWriter, data map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	}); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode sync response")
+	}
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/http/middleware"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// syncMockMEXC mocks the exchange calls a sync makes
+type syncMockMEXC struct {
+	port.MEXCClient
+	mock.Mock
+}
+
+func (m *syncMockMEXC) GetExchangeInfo(ctx context.Context) (*model.ExchangeInfo, error) {
+	args := m.Called(ctx)
+	info, _ := args.Get(0).(*model.ExchangeInfo)
+	return info, args.Error(1)
+}
+
+func (m *syncMockMEXC) GetMarketData(ctx context.Context, symbol string) (*model.Ticker, error) {
+	args := m.Called(ctx, symbol)
+	ticker, _ := args.Get(0).(*model.Ticker)
+	return ticker, args.Error(1)
+}
+
+// syncStubSymbols records the symbols upserted and serves stored ones
+type syncStubSymbols struct {
+	port.SymbolRepository
+	stored   []*market.Symbol
+	upserted []*market.Symbol
+}
+
+func (s *syncStubSymbols) UpsertMany(ctx context.Context, symbols []*market.Symbol) (int, int, error) {
+	s.upserted = append(s.upserted, symbols...)
+	return len(symbols) - 1, 1, nil
+}
+
+func (s *syncStubSymbols) GetByExchange(ctx context.Context, exchange string) ([]*market.Symbol, error) {
+	return s.stored, nil
+}
+
+// syncStubMarket records the tickers saved
+type syncStubMarket struct {
+	port.MarketRepository
+	saved []*market.Ticker
+}
+
+func (m *syncStubMarket) SaveTicker(ctx context.Context, ticker *market.Ticker) error {
+	m.saved = append(m.saved, ticker)
+	return nil
+}
+
+// roleAuth grants the request the configured roles
+type roleAuth struct {
+	middleware.AuthMiddleware
+	roles []string
+}
+
+func (a roleAuth) RequireAuthentication(next http.Handler) http.Handler { return next }
+
+func (a roleAuth) RequireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, granted := range a.roles {
+				if granted == role {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			apperror.WriteError(w, apperror.NewForbidden("Insufficient permissions", nil))
+		})
+	}
+}
+
+func newAdminSyncRouter(client port.MEXCClient, symbols *syncStubSymbols, markets *syncStubMarket, roles ...string) http.Handler {
+	logger := zerolog.Nop()
+	h := NewAdminSyncHandler(usecase.NewMarketSyncUseCase(client, symbols, markets, &logger), &logger)
+	r := chi.NewRouter()
+	h.RegisterRoutes(r, roleAuth{roles: roles})
+	return r
+}
+
+func decodeSyncData(t *testing.T, rec *httptest.ResponseRecorder) map[string]float64 {
+	t.Helper()
+	var body struct {
+		Data map[string]float64 `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return body.Data
+}
+
+func TestAdminSyncHandler_SyncSymbolsUpsertsExchangeSymbols(t *testing.T) {
+	client := &syncMockMEXC{}
+	client.On("GetExchangeInfo", mock.Anything).Return(&model.ExchangeInfo{Symbols: []model.SymbolInfo{
+		{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT", Status: "TRADING", PricePrecision: 2},
+		{Symbol: "NEWUSDT", BaseAsset: "NEW", QuoteAsset: "USDT", Status: "PRE_TRADING", OrderTypes: []string{"LIMIT"}},
+	}}, nil)
+	symbols := &syncStubSymbols{}
+
+	rec := httptest.NewRecorder()
+	newAdminSyncRouter(client, symbols, &syncStubMarket{}, "admin").ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/sync/symbols", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	data := decodeSyncData(t, rec)
+	assert.Equal(t, 2.0, data["fetched"])
+	assert.Equal(t, 1.0, data["created"])
+	assert.Equal(t, 1.0, data["updated"])
+	assert.Contains(t, data, "duration_ms")
+
+	require.Len(t, symbols.upserted, 2)
+	assert.Equal(t, "BTCUSDT", symbols.upserted[0].Symbol)
+	assert.Equal(t, "mexc", symbols.upserted[0].Exchange)
+	assert.Equal(t, []string{"LIMIT", "MARKET"}, symbols.upserted[0].AllowedOrderTypes)
+	assert.Equal(t, []string{"LIMIT"}, symbols.upserted[1].AllowedOrderTypes)
+	client.AssertExpectations(t)
+}
+
+func TestAdminSyncHandler_SyncTickersSavesTradingSymbols(t *testing.T) {
+	client := &syncMockMEXC{}
+	client.On("GetMarketData", mock.Anything, "BTCUSDT").Return(&model.Ticker{Symbol: "BTCUSDT", LastPrice: 65000, HighPrice: 66000}, nil)
+	client.On("GetMarketData", mock.Anything, "ETHUSDT").Return(nil, errors.New("timeout"))
+	symbols := &syncStubSymbols{stored: []*market.Symbol{
+		{Symbol: "BTCUSDT", Status: "TRADING"},
+		{Symbol: "ETHUSDT", Status: "TRADING"},
+		{Symbol: "OLDUSDT", Status: "HALT"},
+	}}
+	markets := &syncStubMarket{}
+
+	rec := httptest.NewRecorder()
+	newAdminSyncRouter(client, symbols, markets, "admin").ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/sync/tickers", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	data := decodeSyncData(t, rec)
+	assert.Equal(t, 2.0, data["symbols"])
+	assert.Equal(t, 1.0, data["saved"])
+	assert.Equal(t, 1.0, data["failed"])
+
+	require.Len(t, markets.saved, 1)
+	assert.Equal(t, 65000.0, markets.saved[0].Price)
+	assert.Equal(t, 66000.0, markets.saved[0].High24h)
+	client.AssertNotCalled(t, "GetMarketData", mock.Anything, "OLDUSDT")
+}
+
+func TestAdminSyncHandler_RequiresAdminRole(t *testing.T) {
+	client := &syncMockMEXC{}
+	symbols := &syncStubSymbols{}
+
+	rec := httptest.NewRecorder()
+	newAdminSyncRouter(client, symbols, &syncStubMarket{}, "user").ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/sync/symbols", nil))
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Empty(t, symbols.upserted)
+	client.AssertNotCalled(t, "GetExchangeInfo", mock.Anything)
+}
+
+// syncBatchMEXC also fetches tickers in batch
+type syncBatchMEXC struct {
+	syncMockMEXC
+}
+
+func (m *syncBatchMEXC) GetTickers(ctx context.Context, symbols []string) ([]*model.Ticker, error) {
+	args := m.Called(ctx, symbols)
+	tickers, _ := args.Get(0).([]*model.Ticker)
+	return tickers, args.Error(1)
+}
+
+func TestAdminSyncHandler_SyncTickersUsesBatchFetch(t *testing.T) {
+	client := &syncBatchMEXC{}
+	client.On("GetTickers", mock.Anything, []string{"BTCUSDT", "ETHUSDT"}).Return([]*model.Ticker{{Symbol: "ETHUSDT", LastPrice: 3500}}, nil)
+	symbols := &syncStubSymbols{stored: []*market.Symbol{
+		{Symbol: "BTCUSDT", Status: "TRADING"},
+		{Symbol: "ETHUSDT", Status: "TRADING"},
+	}}
+	markets := &syncStubMarket{}
+
+	rec := httptest.NewRecorder()
+	newAdminSyncRouter(client, symbols, markets, "admin").ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/sync/tickers", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	data := decodeSyncData(t, rec)
+	assert.Equal(t, 1.0, data["saved"])
+	assert.Equal(t, 1.0, data["failed"])
+	require.Len(t, markets.saved, 1)
+	assert.Equal(t, "ETHUSDT", markets.saved[0].Symbol)
+	client.AssertNotCalled(t, "GetMarketData", mock.Anything, mock.Anything)
+}
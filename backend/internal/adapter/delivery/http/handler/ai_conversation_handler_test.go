@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/http/middleware"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/repo"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// historyRecordingAI replies with a numbered answer and records the history it was given
+type historyRecordingAI struct {
+	port.AIService
+	histories [][]model.AIMessage
+}
+
+func (a *historyRecordingAI) ChatWithHistory(ctx context.Context, messages []model.AIMessage, tradingContext map[string]interface{}) (*model.AIMessage, error) {
+	a.histories = append(a.histories, messages)
+	return &model.AIMessage{Content: fmt.Sprintf("answer %d", len(a.histories))}, nil
+}
+
+func newConversationRouter(t *testing.T, ai port.AIService) chi.Router {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.AIConversationEntity{}, &entity.AIMessageEntity{}))
+
+	logger := zerolog.Nop()
+	uc := usecase.NewAIUsecase(ai, repo.NewGormAIConversationRepository(db, &logger), nil, logger)
+	h := NewAIHandler(uc, &logger)
+
+	r := chi.NewRouter()
+	h.RegisterRoutes(r, withTestUser("user-1"))
+	return r
+}
+
+// withTestUser stands in for the auth middleware, storing userID where it would
+func withTestUser(userID string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			ctx := context.WithValue(req.Context(), middleware.UserIDKey{}, userID)
+			next.ServeHTTP(w, req.WithContext(ctx))
+		})
+	}
+}
+
+func postChat(t *testing.T, r chi.Router, message, sessionID string) ChatResponse {
+	t.Helper()
+	body, _ := json.Marshal(ChatRequest{Message: message, SessionID: sessionID})
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ai/chat", bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp struct {
+		Data ChatResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp.Data
+}
+
+func TestAIHandler_Chat_SecondRequestIncludesPriorTurns(t *testing.T) {
+	ai := &historyRecordingAI{}
+	r := newConversationRouter(t, ai)
+
+	first := postChat(t, r, "What is BTC doing?", "")
+	require.NotEmpty(t, first.SessionID)
+	postChat(t, r, "And ETH?", first.SessionID)
+
+	require.Len(t, ai.histories, 2)
+	second := ai.histories[1]
+	require.Len(t, second, 3)
+	assert.Equal(t, "What is BTC doing?", second[0].Content)
+	assert.Equal(t, "answer 1", second[1].Content)
+	assert.Equal(t, "assistant", second[1].Role)
+	assert.Equal(t, "And ETH?", second[2].Content)
+}
+
+func TestAIHandler_ListAndDeleteConversations(t *testing.T) {
+	r := newConversationRouter(t, &historyRecordingAI{})
+	first := postChat(t, r, "Hello", "")
+	postChat(t, r, "Another topic", "")
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ai/conversations?limit=10", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var list struct {
+		Data []model.AIConversation `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Len(t, list.Data, 2)
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/ai/conversations/"+first.SessionID, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ai/conversations", nil))
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Len(t, list.Data, 1)
+}
+
+func TestAIHandler_Chat_IgnoresBodyUserID(t *testing.T) {
+	logger := zerolog.Nop()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.AIConversationEntity{}, &entity.AIMessageEntity{}))
+	uc := usecase.NewAIUsecase(&historyRecordingAI{}, repo.NewGormAIConversationRepository(db, &logger), nil, logger)
+	h := NewAIHandler(uc, &logger)
+
+	r := chi.NewRouter()
+	h.RegisterRoutes(r, func(next http.Handler) http.Handler { return next })
+
+	body, _ := json.Marshal(ChatRequest{UserID: "user-1", Message: "Hello"})
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ai/chat", bytes.NewReader(body)))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ai/chat/stream", bytes.NewReader(body)))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
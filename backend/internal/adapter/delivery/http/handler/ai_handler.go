@@ -2,18 +2,22 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/delivery/http/response"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/http/middleware"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
 	"github.com/go-chi/chi/v5"
 	"github.com/rs/zerolog"
 )
 
 type AIHandler struct {
-	useCase *usecase.AIUsecase
-	logger  *zerolog.Logger
+	useCase       *usecase.AIUsecase
+	signalUseCase *usecase.AISignalUsecase
+	logger        *zerolog.Logger
 }
 
 func NewAIHandler(useCase *usecase.AIUsecase, logger *zerolog.Logger) *AIHandler {
@@ -23,6 +27,12 @@ func NewAIHandler(useCase *usecase.AIUsecase, logger *zerolog.Logger) *AIHandler
 	}
 }
 
+// WithSignalUsecase enables the trade-signal endpoint
+func (h *AIHandler) WithSignalUsecase(signalUseCase *usecase.AISignalUsecase) *AIHandler {
+	h.signalUseCase = signalUseCase
+	return h
+}
+
 // ChatRequest represents a request to the chat endpoint
 type ChatRequest struct {
 	UserID         string                 `json:"user_id"`
@@ -34,20 +44,84 @@ type ChatRequest struct {
 // ChatResponse represents a response from the chat endpoint
 type ChatResponse struct {
 	Response      string                 `json:"response"`
+	SessionID     string                 `json:"session_id,omitempty"`
 	FunctionCalls map[string]interface{} `json:"function_calls,omitempty"`
 }
 
-// GetHistory returns the authenticated user's conversation history
+// SignalRequest represents a request to the trade-signal endpoint
+type SignalRequest struct {
+	Symbol string `json:"symbol"`
+}
+
+// Signal asks the AI for a structured trade signal on a symbol
+func (h *AIHandler) Signal(w http.ResponseWriter, r *http.Request) {
+	if h.signalUseCase == nil {
+		response.WriteJSON(w, http.StatusServiceUnavailable, response.Error("AI signals are not enabled"))
+		return
+	}
+
+	var req SignalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to decode signal request")
+		response.WriteJSON(w, http.StatusBadRequest, response.Error("Invalid request format"))
+		return
+	}
+	if strings.TrimSpace(req.Symbol) == "" {
+		response.WriteJSON(w, http.StatusBadRequest, response.Error("Symbol is required"))
+		return
+	}
+
+	signal, err := h.signalUseCase.GenerateSignal(r.Context(), req.Symbol)
+	if err != nil {
+		h.logger.Error().Err(err).Str("symbol", req.Symbol).Msg("Failed to generate AI signal")
+
+		var signalErr *usecase.AISignalError
+		switch {
+		case errors.Is(err, usecase.ErrNoSignalMarketData):
+			response.WriteJSON(w, http.StatusNotFound, response.Error("No market data available for symbol"))
+		case errors.As(err, &signalErr) && signalErr.Kind == usecase.AISignalMalformedResponse:
+			response.WriteJSON(w, http.StatusBadGateway, response.Error("AI returned an invalid signal"))
+		case errors.As(err, &signalErr):
+			response.WriteJSON(w, http.StatusBadGateway, response.Error("AI service unavailable"))
+		default:
+			response.WriteJSON(w, http.StatusInternalServerError, response.Error("Failed to generate signal"))
+		}
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, response.Success(signal))
+}
+
+// GetHistory returns the authenticated user's most recent conversations
 func (h *AIHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
-	userID, ok := r.Context().Value("user_id").(string)
+	h.listConversations(w, r, 10, 0)
+}
+
+// ListConversations returns the authenticated user's conversations, most recently
+// updated first. Supports limit and offset query parameters.
+func (h *AIHandler) ListConversations(w http.ResponseWriter, r *http.Request) {
+	limit := 20
+	offset := 0
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		if parsedLimit, err := parseInt(limitParam, 1, 100); err == nil {
+			limit = parsedLimit
+		}
+	}
+	if offsetParam := r.URL.Query().Get("offset"); offsetParam != "" {
+		if parsedOffset, err := parseInt(offsetParam, 0, 1000); err == nil {
+			offset = parsedOffset
+		}
+	}
+	h.listConversations(w, r, limit, offset)
+}
+
+func (h *AIHandler) listConversations(w http.ResponseWriter, r *http.Request, limit, offset int) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
 	if !ok || userID == "" {
 		h.logger.Error().Msg("User ID not found in context")
 		response.WriteJSON(w, http.StatusUnauthorized, response.Error("User not authenticated"))
 		return
 	}
-	// Optionally support pagination
-	limit := 10
-	offset := 0
 	convs, err := h.useCase.ListConversations(r.Context(), userID, limit, offset)
 	if err != nil {
 		h.logger.Error().Err(err).Str("user_id", userID).Msg("Failed to fetch conversation history")
@@ -57,6 +131,13 @@ func (h *AIHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
 	response.WriteJSON(w, http.StatusOK, response.Success(convs))
 }
 
+// chatUserID returns the authenticated user. The user_id in the request body is ignored so
+// callers cannot read or write another user's conversations.
+func chatUserID(r *http.Request) (string, bool) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	return userID, ok && userID != ""
+}
+
 // Chat handles chat requests
 func (h *AIHandler) Chat(w http.ResponseWriter, r *http.Request) {
 	var req ChatRequest
@@ -71,6 +152,13 @@ func (h *AIHandler) Chat(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	userID, ok := chatUserID(r)
+	if !ok {
+		response.WriteJSON(w, http.StatusUnauthorized, response.Error("User not authenticated"))
+		return
+	}
+	req.UserID = userID
+
 	// Log the incoming request
 	h.logger.Info().Str("user_id", req.UserID).Str("session_id", req.SessionID).Msg("Received chat request")
 
@@ -93,18 +181,93 @@ func (h *AIHandler) Chat(w http.ResponseWriter, r *http.Request) {
 	// Create response
 	resp := ChatResponse{
 		Response:      aiMessage.Content,
+		SessionID:     aiMessage.ConversationID,
 		FunctionCalls: functionCalls,
 	}
 
 	response.WriteJSON(w, http.StatusOK, response.Success(resp))
 }
 
+// ChatStream handles chat requests like Chat but streams the response as Server-Sent
+// Events. Each piece of generated content is sent as a "delta" event, followed by a
+// "done" event carrying the complete response or an "error" event. Generation stops
+// when the client disconnects.
+func (h *AIHandler) ChatStream(w http.ResponseWriter, r *http.Request) {
+	var req ChatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to decode chat request")
+		response.WriteJSON(w, http.StatusBadRequest, response.Error("Invalid request format"))
+		return
+	}
+	if req.Message == "" {
+		response.WriteJSON(w, http.StatusBadRequest, response.Error("Message cannot be empty"))
+		return
+	}
+	userID, ok := chatUserID(r)
+	if !ok {
+		response.WriteJSON(w, http.StatusUnauthorized, response.Error("User not authenticated"))
+		return
+	}
+	req.UserID = userID
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		response.WriteJSON(w, http.StatusInternalServerError, response.Error("Streaming is not supported"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ctx := r.Context()
+	h.logger.Info().Str("user_id", req.UserID).Str("session_id", req.SessionID).Msg("Received streaming chat request")
+
+	aiMessage, err := h.useCase.ChatStream(ctx, req.UserID, req.Message, req.SessionID, req.TradingContext, func(delta string) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return writeSSE(w, flusher, "delta", map[string]string{"content": delta})
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			h.logger.Info().Str("user_id", req.UserID).Msg("Client disconnected during streaming chat")
+			return
+		}
+		h.logger.Error().Err(err).Msg("Failed to stream AI response")
+		_ = writeSSE(w, flusher, "error", map[string]string{"message": "Failed to process chat request"})
+		return
+	}
+
+	_ = writeSSE(w, flusher, "done", ChatResponse{Response: aiMessage.Content, SessionID: aiMessage.ConversationID})
+}
+
+// writeSSE writes one Server-Sent Event with a JSON payload and flushes it to the client
+func writeSSE(w http.ResponseWriter, flusher http.Flusher, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+	flusher.Flush()
+	return nil
+}
+
 func (h *AIHandler) RegisterRoutes(r chi.Router, authMiddleware func(http.Handler) http.Handler) {
 	r.Route("/ai", func(r chi.Router) {
 		// Chat endpoint
 		r.With(authMiddleware).Post("/chat", h.Chat)
+		r.With(authMiddleware).Post("/chat/stream", h.ChatStream)
+		// Trade signal endpoint
+		r.With(authMiddleware).Post("/signal", h.Signal)
 		// Conversation history endpoints
 		r.With(authMiddleware).Get("/history", h.GetHistory)
+		r.With(authMiddleware).Get("/conversations", h.ListConversations)
 		r.With(authMiddleware).Get("/conversations/{conversationID}", h.GetConversation)
 		r.With(authMiddleware).Get("/conversations/{conversationID}/messages", h.GetConversationMessages)
 		r.With(authMiddleware).Delete("/conversations/{conversationID}", h.DeleteConversation)
@@ -113,7 +276,7 @@ func (h *AIHandler) RegisterRoutes(r chi.Router, authMiddleware func(http.Handle
 
 // GetConversation returns details for a specific conversation
 func (h *AIHandler) GetConversation(w http.ResponseWriter, r *http.Request) {
-	userID, ok := r.Context().Value("user_id").(string)
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
 	if !ok || userID == "" {
 		h.logger.Error().Msg("User ID not found in context")
 		response.WriteJSON(w, http.StatusUnauthorized, response.Error("User not authenticated"))
@@ -138,7 +301,7 @@ func (h *AIHandler) GetConversation(w http.ResponseWriter, r *http.Request) {
 
 // GetConversationMessages returns messages for a specific conversation
 func (h *AIHandler) GetConversationMessages(w http.ResponseWriter, r *http.Request) {
-	userID, ok := r.Context().Value("user_id").(string)
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
 	if !ok || userID == "" {
 		h.logger.Error().Msg("User ID not found in context")
 		response.WriteJSON(w, http.StatusUnauthorized, response.Error("User not authenticated"))
@@ -189,7 +352,7 @@ func (h *AIHandler) GetConversationMessages(w http.ResponseWriter, r *http.Reque
 
 // DeleteConversation deletes a specific conversation
 func (h *AIHandler) DeleteConversation(w http.ResponseWriter, r *http.Request) {
-	userID, ok := r.Context().Value("user_id").(string)
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
 	if !ok || userID == "" {
 		h.logger.Error().Msg("User ID not found in context")
 		response.WriteJSON(w, http.StatusUnauthorized, response.Error("User not authenticated"))
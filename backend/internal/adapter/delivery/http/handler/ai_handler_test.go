@@ -7,8 +7,6 @@ import (
 	"net/http/httptest"
 	"testing"
 
-	"context"
-
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/delivery/http/response"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/mocks/usecase"
@@ -27,13 +25,7 @@ func TestAIHandler_Chat_Authenticated(t *testing.T) {
 	h := NewAIHandler(useCase, &logger)
 
 	r := chi.NewRouter()
-	h.RegisterRoutes(r, func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-			ctx := req.Context()
-			ctx = contextWithUserID(ctx, "test-user")
-			next.ServeHTTP(w, req.WithContext(ctx))
-		})
-	})
+	h.RegisterRoutes(r, withTestUser("test-user"))
 
 	body := map[string]interface{}{
 		"user_id":         "test-user",
@@ -52,8 +44,4 @@ func TestAIHandler_Chat_Authenticated(t *testing.T) {
 	assert.True(t, resp.Success)
 }
 
-func contextWithUserID(ctx context.Context, userID string) context.Context {
-	return context.WithValue(ctx, "user_id", userID)
-}
-
 // Add more tests for conversation history, pagination, and error cases as needed.
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signalStubAI answers every chat with canned content
+type signalStubAI struct {
+	port.AIService
+	content string
+	err     error
+}
+
+func (s *signalStubAI) ChatWithHistory(ctx context.Context, messages []model.AIMessage, tradingContext map[string]interface{}) (*model.AIMessage, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &model.AIMessage{Role: "assistant", Content: s.content}, nil
+}
+
+// signalStubMarket serves one ticker for every symbol
+type signalStubMarket struct {
+	port.MarketRepository
+}
+
+func (m *signalStubMarket) GetTicker(ctx context.Context, symbol, exchange string) (*market.Ticker, error) {
+	return &market.Ticker{Symbol: symbol, Price: 1.5}, nil
+}
+
+func (m *signalStubMarket) GetCandles(ctx context.Context, symbol, exchange string, interval market.Interval, start, end time.Time, limit int) ([]*market.Candle, error) {
+	return nil, nil
+}
+
+func postSignal(t *testing.T, ai port.AIService, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	logger := zerolog.Nop()
+	h := NewAIHandler(nil, &logger).
+		WithSignalUsecase(usecase.NewAISignalUsecase(ai, &signalStubMarket{}, "mexc", logger))
+
+	r := chi.NewRouter()
+	h.RegisterRoutes(r, func(next http.Handler) http.Handler { return next })
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ai/signal", bytes.NewBufferString(body)))
+	return rec
+}
+
+func TestAIHandler_Signal(t *testing.T) {
+	rec := postSignal(t, &signalStubAI{content: `{"action":"SELL","confidence":0.6,"rationale":"Overextended."}`}, `{"symbol":"ADAUSDT"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Success bool                `json:"success"`
+		Data    model.AITradeSignal `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.True(t, resp.Success)
+	assert.Equal(t, "ADAUSDT", resp.Data.Symbol)
+	assert.Equal(t, model.SignalSell, resp.Data.Action)
+	assert.InDelta(t, 0.6, resp.Data.Confidence, 1e-9)
+}
+
+func TestAIHandler_Signal_Errors(t *testing.T) {
+	assert.Equal(t, http.StatusBadRequest, postSignal(t, &signalStubAI{}, `{"symbol":""}`).Code)
+	assert.Equal(t, http.StatusBadGateway, postSignal(t, &signalStubAI{content: "not json"}, `{"symbol":"ADAUSDT"}`).Code)
+	assert.Equal(t, http.StatusBadGateway, postSignal(t, &signalStubAI{err: errors.New("down")}, `{"symbol":"ADAUSDT"}`).Code)
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/memory"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streamStubAI streams a fixed list of chunks, optionally blocking after the first one
+// until the request context is cancelled
+type streamStubAI struct {
+	port.AIService
+	chunks    []string
+	block     bool
+	cancelled chan struct{}
+}
+
+func (s *streamStubAI) ChatWithHistoryStream(ctx context.Context, messages []model.AIMessage, tradingContext map[string]interface{}, onDelta func(delta string) error) (*model.AIMessage, error) {
+	for i, chunk := range s.chunks {
+		if err := onDelta(chunk); err != nil {
+			return nil, err
+		}
+		if s.block && i == 0 {
+			<-ctx.Done()
+			close(s.cancelled)
+			return nil, ctx.Err()
+		}
+	}
+	return &model.AIMessage{Role: "assistant", Content: strings.Join(s.chunks, "")}, nil
+}
+
+func newStreamServer(ai port.AIService) *httptest.Server {
+	logger := zerolog.Nop()
+	uc := usecase.NewAIUsecase(ai, memory.NewConversationMemoryRepository(logger), nil, logger)
+	h := NewAIHandler(uc, &logger)
+
+	r := chi.NewRouter()
+	h.RegisterRoutes(r, withTestUser("u1"))
+	return httptest.NewServer(r)
+}
+
+// sseEvent is one parsed Server-Sent Event
+type sseEvent struct {
+	name string
+	data string
+}
+
+func readSSE(t *testing.T, scanner *bufio.Scanner) (sseEvent, bool) {
+	t.Helper()
+	var ev sseEvent
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			return ev, true
+		case strings.HasPrefix(line, "event: "):
+			ev.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			ev.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+	return ev, false
+}
+
+func TestAIHandler_ChatStream(t *testing.T) {
+	ts := newStreamServer(&streamStubAI{chunks: []string{"Bitcoin", " looks", " strong"}})
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/ai/chat/stream", "application/json", bytes.NewBufferString(`{"user_id":"u1","message":"How is BTC?"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	scanner := bufio.NewScanner(resp.Body)
+	var events []sseEvent
+	for {
+		ev, ok := readSSE(t, scanner)
+		if !ok {
+			break
+		}
+		events = append(events, ev)
+	}
+
+	require.Len(t, events, 4)
+	assert.Equal(t, sseEvent{"delta", `{"content":"Bitcoin"}`}, events[0])
+	assert.Equal(t, sseEvent{"delta", `{"content":" looks"}`}, events[1])
+	assert.Equal(t, sseEvent{"delta", `{"content":" strong"}`}, events[2])
+	assert.Equal(t, "done", events[3].name)
+	assert.Contains(t, events[3].data, `"response":"Bitcoin looks strong"`)
+}
+
+func TestAIHandler_ChatStream_StopsOnClientDisconnect(t *testing.T) {
+	ai := &streamStubAI{chunks: []string{"first", "second"}, block: true, cancelled: make(chan struct{})}
+	ts := newStreamServer(ai)
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ts.URL+"/ai/chat/stream", bytes.NewBufferString(`{"user_id":"u1","message":"hi"}`))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	ev, ok := readSSE(t, bufio.NewScanner(resp.Body))
+	require.True(t, ok)
+	assert.Equal(t, sseEvent{"delta", `{"content":"first"}`}, ev)
+
+	cancel()
+	select {
+	case <-ai.cancelled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("model generation was not cancelled after the client disconnected")
+	}
+}
+
+func TestAIHandler_ChatStream_NonStreamingServiceSendsSingleDelta(t *testing.T) {
+	ts := newStreamServer(&signalStubAI{content: "Full answer"})
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/ai/chat/stream", "application/json", bytes.NewBufferString(`{"user_id":"u1","message":"hi"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	first, _ := readSSE(t, scanner)
+	second, _ := readSSE(t, scanner)
+	assert.Equal(t, sseEvent{"delta", `{"content":"Full answer"}`}, first)
+	assert.Equal(t, "done", second.name)
+}
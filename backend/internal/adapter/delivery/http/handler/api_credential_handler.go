@@ -38,10 +38,20 @@ func (h *APICredentialHandler) RegisterRoutes(r chi.Router) {
 
 // CreateCredentialRequest represents the request body for creating an API credential
 type CreateCredentialRequest struct {
-	Exchange  string `json:"exchange"`
-	APIKey    string `json:"apiKey"`
-	APISecret string `json:"apiSecret"`
-	Label     string `json:"label"`
+	Exchange  string `json:"exchange" validate:"required"`
+	APIKey    string `json:"apiKey" validate:"required"`
+	APISecret string `json:"apiSecret" validate:"required"`
+	Label     string `json:"label" validate:"max=50"`
+}
+
+// ValidateRules applies the exchange-specific key and secret formats
+func (req *CreateCredentialRequest) ValidateRules(errs validation.Errors) {
+	validation.NewCredentialValidator().
+		ValidateExchange(req.Exchange).
+		ValidateAPIKey(req.APIKey, req.Exchange).
+		ValidateAPISecret(req.APISecret, req.Exchange).
+		ValidateLabel(req.Label).
+		AddTo(errs)
 }
 
 // CreateCredential creates a new API credential
@@ -54,23 +64,11 @@ func (h *APICredentialHandler) CreateCredential(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	// Parse request body
+	// Parse and validate request body
 	var req CreateCredentialRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.logger.Error().Err(err).Msg("Failed to decode request body")
-		apperror.WriteError(w, apperror.NewInvalid("Invalid request body", nil, err))
-		return
-	}
-
-	// Validate request using the credential validator
-	validator := validation.NewCredentialValidator()
-	validator.ValidateExchange(req.Exchange).
-		ValidateAPIKey(req.APIKey, req.Exchange).
-		ValidateAPISecret(req.APISecret, req.Exchange).
-		ValidateLabel(req.Label)
-
-	if validator.HasErrors() {
-		apperror.WriteError(w, validator.ToAppError())
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		h.logger.Debug().Err(err).Msg("Rejected create credential request")
+		apperror.WriteError(w, err)
 		return
 	}
 
@@ -201,7 +199,7 @@ func (h *APICredentialHandler) GetCredential(w http.ResponseWriter, r *http.Requ
 type UpdateCredentialRequest struct {
 	APIKey    string `json:"apiKey"`
 	APISecret string `json:"apiSecret"`
-	Label     string `json:"label"`
+	Label     string `json:"label" validate:"max=50"`
 }
 
 // UpdateCredential updates an API credential
@@ -221,11 +219,11 @@ func (h *APICredentialHandler) UpdateCredential(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	// Parse request body
+	// Parse and validate request body
 	var req UpdateCredentialRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.logger.Error().Err(err).Msg("Failed to decode request body")
-		apperror.WriteError(w, apperror.NewInvalid("Invalid request body", nil, err))
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		h.logger.Debug().Err(err).Msg("Rejected update credential request")
+		apperror.WriteError(w, err)
 		return
 	}
 
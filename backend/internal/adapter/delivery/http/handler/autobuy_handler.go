@@ -1,14 +1,19 @@
 package handler
 
 import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
 	"github.com/go-chi/chi/v5"
 	"github.com/rs/zerolog"
 )
 
 type AutoBuyHandler struct {
-	useCase usecase.AutoBuyUseCase
-	logger  *zerolog.Logger
+	useCase       usecase.AutoBuyUseCase
+	simulatedBuys usecase.SimulatedBuyProvider
+	logger        *zerolog.Logger
 }
 
 func NewAutoBuyHandler(useCase usecase.AutoBuyUseCase, logger *zerolog.Logger) *AutoBuyHandler {
@@ -18,9 +23,32 @@ func NewAutoBuyHandler(useCase usecase.AutoBuyUseCase, logger *zerolog.Logger) *
 	}
 }
 
+// WithSimulatedBuys exposes the dry-run history of the new-listing autobuyer
+func (h *AutoBuyHandler) WithSimulatedBuys(provider usecase.SimulatedBuyProvider) *AutoBuyHandler {
+	h.simulatedBuys = provider
+	return h
+}
+
 func (h *AutoBuyHandler) RegisterRoutes(r chi.Router) {
 	r.Route("/autobuy", func(r chi.Router) {
-		// Add auto-buy related routes here
-		// Example: r.Post("/rules", h.CreateRule)
+		// Buys recorded while the autobuyer runs in dry-run mode
+		r.Get("/dry-run", h.GetSimulatedBuys)
 	})
 }
+
+// GetSimulatedBuys returns the buys the autobuyer would have placed in dry-run mode
+func (h *AutoBuyHandler) GetSimulatedBuys(w http.ResponseWriter, r *http.Request) {
+	if h.simulatedBuys == nil {
+		apperror.WriteError(w, apperror.NewNotFound("dry-run history", "autobuy", nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    h.simulatedBuys.GetSimulatedBuys(),
+	}); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode dry-run response")
+	}
+}
@@ -0,0 +1,272 @@
+package handler
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/go-chi/chi/v5"
+)
+
+// candleExportPageSize is the number of candles read from the database per page while exporting
+const candleExportPageSize = 1000
+
+// CandleExportStatusTrailer is the trailer reporting whether an export finished. Once the
+// body has started the status code can no longer change, so a failed export ends with the
+// trailer set to CandleExportTruncated instead of CandleExportComplete.
+const CandleExportStatusTrailer = "X-Export-Status"
+
+// Values of the CandleExportStatusTrailer
+const (
+	CandleExportComplete  = "complete"
+	CandleExportTruncated = "truncated"
+)
+
+// candleCSVHeader lists the exported CSV columns. Times are Unix milliseconds so the file
+// can be fed back into the backtester and the CSV importer.
+var candleCSVHeader = []string{"open_time", "close_time", "open", "high", "low", "close", "volume", "quote_volume", "trade_count"}
+
+// candleEncoder writes candles in one export format
+type candleEncoder interface {
+	begin() error
+	write(candles []*market.Candle) error
+	end() error
+}
+
+// ExportCandles streams the candle history of a symbol as CSV or JSON. Query parameters:
+// interval (required), from (required) and to, as RFC 3339 or Unix milliseconds, and
+// format (csv or json, default csv). The response is gzip-compressed when the client
+// accepts it and ends with the CandleExportStatusTrailer. Exports of long ranges outlast
+// the API's handler time limit, so the route is mounted outside it.
+func (h *MarketDataHandler) ExportCandles(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	symbol := strings.ToUpper(chi.URLParam(r, "symbol"))
+	problems := map[string]string{}
+
+	interval := market.Interval(query.Get("interval"))
+	if interval == "" {
+		problems["interval"] = "is required"
+	}
+
+	from, err := parseExportTime(query.Get("from"))
+	if err != nil {
+		problems["from"] = err.Error()
+	}
+
+	to := time.Now().UTC()
+	if raw := query.Get("to"); raw != "" {
+		if to, err = parseExportTime(raw); err != nil {
+			problems["to"] = err.Error()
+		}
+	}
+	if len(problems) == 0 && to.Before(from) {
+		problems["to"] = "must not be before from"
+	}
+
+	format := strings.ToLower(query.Get("format"))
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		problems["format"] = "must be csv or json"
+	}
+
+	if len(problems) > 0 {
+		apperror.WriteError(w, apperror.NewValidation("Invalid export parameters", problems, nil))
+		return
+	}
+
+	flusher, _ := w.(http.Flusher)
+	var out io.Writer = w
+	var gz *gzip.Writer
+	flush := func() {
+		if gz != nil {
+			gz.Flush()
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	var encoder candleEncoder
+	started := false
+	start := func() error {
+		started = true
+		if format == "json" {
+			w.Header().Set("Content-Type", "application/json")
+		} else {
+			w.Header().Set("Content-Type", "text/csv")
+		}
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s_%s.%s"`, symbol, interval, format))
+		w.Header().Set("Trailer", CandleExportStatusTrailer)
+		if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Header().Add("Vary", "Accept-Encoding")
+			gz = gzip.NewWriter(w)
+			out = gz
+		}
+		w.WriteHeader(http.StatusOK)
+
+		if format == "json" {
+			encoder = &jsonCandleEncoder{w: out}
+		} else {
+			encoder = &csvCandleEncoder{w: csv.NewWriter(out)}
+		}
+		return encoder.begin()
+	}
+
+	err = h.useCase.StreamCandles(r.Context(), "mexc", symbol, interval, from, to, h.exportPageSize, func(candles []*market.Candle) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := encoder.write(candles); err != nil {
+			return err
+		}
+		flush()
+		return nil
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("symbol", symbol).Str("interval", string(interval)).Msg("Failed to export candles")
+		if !started {
+			apperror.WriteError(w, err)
+			return
+		}
+		// The output is left unterminated and the trailer marks it truncated
+		w.Header().Set(CandleExportStatusTrailer, CandleExportTruncated)
+		return
+	}
+
+	if !started {
+		if err := start(); err != nil {
+			h.logger.Error().Err(err).Msg("Failed to write candle export")
+			return
+		}
+	}
+	if err := encoder.end(); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to finish candle export")
+		w.Header().Set(CandleExportStatusTrailer, CandleExportTruncated)
+		return
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			h.logger.Error().Err(err).Msg("Failed to close gzip stream")
+			w.Header().Set(CandleExportStatusTrailer, CandleExportTruncated)
+			return
+		}
+	}
+	w.Header().Set(CandleExportStatusTrailer, CandleExportComplete)
+}
+
+// parseExportTime accepts RFC 3339 timestamps or Unix milliseconds
+func parseExportTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("is required")
+	}
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(ms).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("must be RFC 3339 or Unix milliseconds")
+	}
+	return t, nil
+}
+
+// csvCandleEncoder writes candles as CSV rows
+type csvCandleEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvCandleEncoder) begin() error {
+	e.w.Write(candleCSVHeader)
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvCandleEncoder) write(candles []*market.Candle) error {
+	for _, c := range candles {
+		e.w.Write([]string{
+			strconv.FormatInt(c.OpenTime.UnixMilli(), 10),
+			strconv.FormatInt(c.CloseTime.UnixMilli(), 10),
+			strconv.FormatFloat(c.Open, 'f', -1, 64),
+			strconv.FormatFloat(c.High, 'f', -1, 64),
+			strconv.FormatFloat(c.Low, 'f', -1, 64),
+			strconv.FormatFloat(c.Close, 'f', -1, 64),
+			strconv.FormatFloat(c.Volume, 'f', -1, 64),
+			strconv.FormatFloat(c.QuoteVolume, 'f', -1, 64),
+			strconv.FormatInt(c.TradeCount, 10),
+		})
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvCandleEncoder) end() error {
+	return nil
+}
+
+// jsonCandleEncoder writes candles as the elements of one JSON array
+type jsonCandleEncoder struct {
+	w     io.Writer
+	count int
+}
+
+// exportedCandle is the JSON shape of an exported candle
+type exportedCandle struct {
+	OpenTime    time.Time `json:"openTime"`
+	CloseTime   time.Time `json:"closeTime"`
+	Open        float64   `json:"open"`
+	High        float64   `json:"high"`
+	Low         float64   `json:"low"`
+	Close       float64   `json:"close"`
+	Volume      float64   `json:"volume"`
+	QuoteVolume float64   `json:"quoteVolume"`
+	TradeCount  int64     `json:"tradeCount"`
+}
+
+func (e *jsonCandleEncoder) begin() error {
+	_, err := io.WriteString(e.w, "[")
+	return err
+}
+
+func (e *jsonCandleEncoder) write(candles []*market.Candle) error {
+	for _, c := range candles {
+		data, err := json.Marshal(exportedCandle{
+			OpenTime:    c.OpenTime.UTC(),
+			CloseTime:   c.CloseTime.UTC(),
+			Open:        c.Open,
+			High:        c.High,
+			Low:         c.Low,
+			Close:       c.Close,
+			Volume:      c.Volume,
+			QuoteVolume: c.QuoteVolume,
+			TradeCount:  c.TradeCount,
+		})
+		if err != nil {
+			return err
+		}
+		if e.count > 0 {
+			data = append([]byte(","), data...)
+		}
+		if _, err := e.w.Write(data); err != nil {
+			return err
+		}
+		e.count++
+	}
+	return nil
+}
+
+func (e *jsonCandleEncoder) end() error {
+	_, err := io.WriteString(e.w, "]\n")
+	return err
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exportStubMarket serves stored candles page by page and records how much of the
+// response had been written when each page was requested. When cancelAt is set, the
+// request is cancelled as that page is read.
+type exportStubMarket struct {
+	port.MarketRepository
+	candles     []*market.Candle
+	recorder    *httptest.ResponseRecorder
+	bodyAtFetch []int
+	limits      []int
+	cancelAt    int
+	cancel      context.CancelFunc
+}
+
+func (m *exportStubMarket) GetCandles(ctx context.Context, symbol, exchange string, interval market.Interval, start, end time.Time, limit int) ([]*market.Candle, error) {
+	m.limits = append(m.limits, limit)
+	if m.cancelAt > 0 && len(m.limits) == m.cancelAt {
+		m.cancel()
+		return nil, ctx.Err()
+	}
+	if m.recorder != nil {
+		m.bodyAtFetch = append(m.bodyAtFetch, m.recorder.Body.Len())
+	}
+	var page []*market.Candle
+	for _, c := range m.candles {
+		if !c.OpenTime.Before(start) && !c.OpenTime.After(end) && len(page) < limit {
+			page = append(page, c)
+		}
+	}
+	return page, nil
+}
+
+var exportBase = time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+func exportCandles(n int) []*market.Candle {
+	candles := make([]*market.Candle, 0, n)
+	for i := 0; i < n; i++ {
+		open := exportBase.Add(time.Duration(i) * time.Hour)
+		candles = append(candles, &market.Candle{
+			Symbol:     "BTCUSDT",
+			Interval:   market.Interval1h,
+			OpenTime:   open,
+			CloseTime:  open.Add(time.Hour - time.Millisecond),
+			Open:       100 + float64(i),
+			High:       110 + float64(i),
+			Low:        90 + float64(i),
+			Close:      105 + float64(i),
+			Volume:     10,
+			TradeCount: int64(i),
+		})
+	}
+	return candles
+}
+
+func newExportRouter(repo port.MarketRepository, pageSize int) http.Handler {
+	logger := zerolog.Nop()
+	h := NewMarketDataHandler(usecase.NewMarketDataUseCase(repo, nil, nil, &logger), nil, &logger)
+	h.exportPageSize = pageSize
+	router := chi.NewRouter()
+	router.Get("/market/{symbol}/candles/export", h.ExportCandles)
+	return router
+}
+
+func exportURL(query string) string {
+	return "/market/BTCUSDT/candles/export?interval=1h&from=2026-02-01T00:00:00Z&to=2026-02-02T00:00:00Z" + query
+}
+
+func TestExportCandlesCSVStreamsPages(t *testing.T) {
+	repo := &exportStubMarket{candles: exportCandles(5)}
+	router := newExportRouter(repo, 2)
+
+	w := httptest.NewRecorder()
+	repo.recorder = w
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, exportURL(""), nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "BTCUSDT_1h.csv")
+
+	rows, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 6)
+	assert.Equal(t, candleCSVHeader, rows[0])
+	assert.Equal(t, []string{"1769904000000", "1769907599999", "100", "110", "90", "105", "10", "0", "0"}, rows[1])
+	assert.Equal(t, "104", rows[5][2])
+
+	// Every query is bounded by the page size and earlier pages were already written
+	// to the client before the next one was read
+	assert.Equal(t, []int{2, 2, 2}, repo.limits)
+	require.Len(t, repo.bodyAtFetch, 3)
+	assert.Zero(t, repo.bodyAtFetch[0])
+	assert.Greater(t, repo.bodyAtFetch[1], 0)
+	assert.Greater(t, repo.bodyAtFetch[2], repo.bodyAtFetch[1])
+	assert.True(t, w.Flushed)
+	assert.Equal(t, CandleExportComplete, w.Result().Trailer.Get(CandleExportStatusTrailer))
+}
+
+func TestExportCandlesMarksCancelledExportTruncated(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	repo := &exportStubMarket{candles: exportCandles(5), cancelAt: 2, cancel: cancel}
+	router := newExportRouter(repo, 2)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, exportURL(""), nil).WithContext(ctx))
+
+	// The first page was already sent, so the status stays 200 and only the trailer
+	// tells the client the export is incomplete
+	require.Equal(t, http.StatusOK, w.Code)
+	rows, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, CandleExportTruncated, w.Result().Trailer.Get(CandleExportStatusTrailer))
+}
+
+func TestExportCandlesJSONWithGzip(t *testing.T) {
+	router := newExportRouter(&exportStubMarket{candles: exportCandles(3)}, 2)
+
+	req := httptest.NewRequest(http.MethodGet, exportURL("&format=json"), nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+
+	reader, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(reader)
+	require.NoError(t, err)
+
+	var candles []exportedCandle
+	require.NoError(t, json.Unmarshal(body, &candles))
+	require.Len(t, candles, 3)
+	assert.Equal(t, exportBase, candles[0].OpenTime)
+	assert.Equal(t, 107.0, candles[2].Close)
+}
+
+func TestExportCandlesEmptyRange(t *testing.T) {
+	router := newExportRouter(&exportStubMarket{}, 2)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, exportURL("&format=json"), nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+}
+
+func TestExportCandlesRejectsInvalidParameters(t *testing.T) {
+	router := newExportRouter(&exportStubMarket{}, 2)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/market/BTCUSDT/candles/export?from=yesterday&format=xml", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	body := w.Body.String()
+	for _, field := range []string{"interval", "from", "format"} {
+		assert.True(t, strings.Contains(body, `"`+field+`"`), "missing problem for %s", field)
+	}
+}
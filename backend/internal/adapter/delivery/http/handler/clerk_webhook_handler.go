@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+)
+
+// Settings of the Clerk webhook receiver
+const (
+	clerkWebhookSource    = "clerk"
+	clerkWebhookMaxBody   = 1 << 20
+	clerkWebhookTolerance = 5 * time.Minute
+	svixSecretPrefix      = "whsec_"
+	svixSignatureVersion  = "v1"
+)
+
+// Clerk user lifecycle event types
+const (
+	clerkEventUserCreated = "user.created"
+	clerkEventUserUpdated = "user.updated"
+	clerkEventUserDeleted = "user.deleted"
+)
+
+// errInvalidSvixSignature is returned when a webhook's Svix headers do not verify
+var errInvalidSvixSignature = errors.New("invalid webhook signature")
+
+// ClerkUserSyncer applies Clerk's user lifecycle to the local user records
+type ClerkUserSyncer interface {
+	SyncUser(ctx context.Context, id, email, name string) (*model.User, error)
+	DeactivateUser(ctx context.Context, id string) error
+}
+
+// clerkWebhookEvent is the envelope of a Clerk webhook
+type clerkWebhookEvent struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// clerkUser is the part of Clerk's user object kept locally
+type clerkUser struct {
+	ID                    string `json:"id"`
+	FirstName             string `json:"first_name"`
+	LastName              string `json:"last_name"`
+	Username              string `json:"username"`
+	PrimaryEmailAddressID string `json:"primary_email_address_id"`
+	EmailAddresses        []struct {
+		ID           string `json:"id"`
+		EmailAddress string `json:"email_address"`
+	} `json:"email_addresses"`
+}
+
+// primaryEmail returns the user's primary email address, or the first one if none is marked
+func (u clerkUser) primaryEmail() string {
+	for _, address := range u.EmailAddresses {
+		if address.ID == u.PrimaryEmailAddressID {
+			return address.EmailAddress
+		}
+	}
+	if len(u.EmailAddresses) > 0 {
+		return u.EmailAddresses[0].EmailAddress
+	}
+	return ""
+}
+
+// displayName returns the user's full name, falling back to the username
+func (u clerkUser) displayName() string {
+	if name := strings.TrimSpace(u.FirstName + " " + u.LastName); name != "" {
+		return name
+	}
+	return u.Username
+}
+
+// ClerkWebhookHandler receives Clerk's user lifecycle webhooks. Deliveries are verified with
+// the endpoint's Svix signing secret and deduplicated by their Svix message ID, so retries
+// and replays are applied once.
+type ClerkWebhookHandler struct {
+	secret []byte
+	users  ClerkUserSyncer
+	events port.WebhookEventRepository
+	logger *zerolog.Logger
+	now    func() time.Time
+}
+
+// NewClerkWebhookHandler creates a ClerkWebhookHandler verifying deliveries with secret, the
+// endpoint's whsec_ signing secret from the Clerk dashboard
+func NewClerkWebhookHandler(secret string, users ClerkUserSyncer, events port.WebhookEventRepository, logger *zerolog.Logger) (*ClerkWebhookHandler, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, svixSecretPrefix))
+	if err != nil || len(key) == 0 {
+		return nil, fmt.Errorf("invalid Clerk webhook secret")
+	}
+	return &ClerkWebhookHandler{
+		secret: key,
+		users:  users,
+		events: events,
+		logger: logger,
+		now:    time.Now,
+	}, nil
+}
+
+// RegisterRoutes registers the webhook route. It must be mounted outside the authenticated
+// API, as Clerk signs its requests instead of sending a session.
+func (h *ClerkWebhookHandler) RegisterRoutes(r chi.Router) {
+	r.Post("/webhooks/clerk", h.HandleWebhook)
+}
+
+// HandleWebhook applies a user.created, user.updated or user.deleted event. Other event
+// types are acknowledged and ignored. A delivery that fails to apply gets a 5xx, so Clerk
+// retries it.
+func (h *ClerkWebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, clerkWebhookMaxBody))
+	if err != nil {
+		apperror.WriteError(w, apperror.NewInvalid("Failed to read webhook body", nil, err))
+		return
+	}
+
+	eventID := r.Header.Get("svix-id")
+	if err := h.verify(eventID, r.Header.Get("svix-timestamp"), r.Header.Get("svix-signature"), body); err != nil {
+		h.logger.Warn().Err(err).Str("svix_id", eventID).Msg("Rejected Clerk webhook")
+		apperror.WriteError(w, apperror.NewUnauthorized("Invalid webhook signature", err))
+		return
+	}
+
+	var evt clerkWebhookEvent
+	if err := json.Unmarshal(body, &evt); err != nil || evt.Type == "" {
+		apperror.WriteError(w, apperror.NewInvalid("Invalid webhook payload", nil, err))
+		return
+	}
+
+	ctx := r.Context()
+	seen, err := h.events.Seen(ctx, clerkWebhookSource, eventID)
+	if err != nil {
+		apperror.WriteError(w, apperror.NewInternal(fmt.Errorf("failed to check webhook event: %w", err)))
+		return
+	}
+	if seen {
+		h.logger.Info().Str("svix_id", eventID).Str("type", evt.Type).Msg("Ignoring replayed Clerk webhook")
+		h.writeResult(w, eventID, "duplicate")
+		return
+	}
+
+	result, err := h.apply(ctx, evt)
+	if err != nil {
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			apperror.WriteError(w, appErr)
+		} else {
+			h.logger.Error().Err(err).Str("svix_id", eventID).Str("type", evt.Type).Msg("Failed to apply Clerk webhook")
+			apperror.WriteError(w, apperror.NewInternal(err))
+		}
+		return
+	}
+
+	if err := h.events.Record(ctx, clerkWebhookSource, eventID, evt.Type); err != nil {
+		// The event was applied; a redelivery would be applied again, which is harmless
+		h.logger.Error().Err(err).Str("svix_id", eventID).Msg("Failed to record Clerk webhook")
+	}
+	h.logger.Info().Str("svix_id", eventID).Str("type", evt.Type).Str("result", result).Msg("Handled Clerk webhook")
+	h.writeResult(w, eventID, result)
+}
+
+// apply updates the local user record for evt and describes what was done
+func (h *ClerkWebhookHandler) apply(ctx context.Context, evt clerkWebhookEvent) (string, error) {
+	var user clerkUser
+	switch evt.Type {
+	case clerkEventUserCreated, clerkEventUserUpdated, clerkEventUserDeleted:
+		if err := json.Unmarshal(evt.Data, &user); err != nil || user.ID == "" {
+			return "", apperror.NewInvalid("Invalid user in webhook payload", nil, err)
+		}
+	default:
+		return "ignored", nil
+	}
+
+	if evt.Type == clerkEventUserDeleted {
+		if err := h.users.DeactivateUser(ctx, user.ID); err != nil {
+			return "", fmt.Errorf("failed to deactivate user %s: %w", user.ID, err)
+		}
+		return "deactivated", nil
+	}
+
+	email := user.primaryEmail()
+	if email == "" {
+		return "", apperror.NewInvalid("User in webhook payload has no email address", nil, nil)
+	}
+	if _, err := h.users.SyncUser(ctx, user.ID, email, user.displayName()); err != nil {
+		return "", fmt.Errorf("failed to sync user %s: %w", user.ID, err)
+	}
+	return "synced", nil
+}
+
+// verify checks the Svix signature of a delivery: an HMAC-SHA256 over
+// "<id>.<timestamp>.<body>", sent base64-encoded as one of the space-separated "v1,<sig>"
+// entries. Deliveries timestamped outside the tolerance are rejected to limit replays.
+func (h *ClerkWebhookHandler) verify(id, timestamp, signatures string, body []byte) error {
+	if id == "" || timestamp == "" || signatures == "" {
+		return fmt.Errorf("%w: missing svix headers", errInvalidSvixSignature)
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: malformed timestamp", errInvalidSvixSignature)
+	}
+	if age := h.now().Sub(time.Unix(seconds, 0)); math.Abs(float64(age)) > float64(clerkWebhookTolerance) {
+		return fmt.Errorf("%w: timestamp outside tolerance", errInvalidSvixSignature)
+	}
+
+	expected := svixSignature(h.secret, id, timestamp, body)
+	for _, entry := range strings.Fields(signatures) {
+		version, signature, ok := strings.Cut(entry, ",")
+		if !ok || version != svixSignatureVersion {
+			continue
+		}
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: no matching signature", errInvalidSvixSignature)
+}
+
+// svixSignature returns the base64-encoded v1 signature of a delivery
+func svixSignature(secret []byte, id, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(id + "." + timestamp + "."))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// writeResult acknowledges a webhook delivery
+func (h *ClerkWebhookHandler) writeResult(w http.ResponseWriter, eventID, result string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data": map[string]string{
+			"event_id": eventID,
+			"result":   result,
+		},
+	}); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode Clerk webhook response")
+	}
+}
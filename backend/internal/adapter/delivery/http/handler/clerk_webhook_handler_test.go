@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var clerkTestSecret = []byte("clerk-webhook-test-secret")
+
+// memClerkUsers keeps synced users in memory
+type memClerkUsers map[string]*model.User
+
+func (m memClerkUsers) SyncUser(ctx context.Context, id, email, name string) (*model.User, error) {
+	user := model.NewUser(id, email, name)
+	m[id] = user
+	return user, nil
+}
+
+func (m memClerkUsers) DeactivateUser(ctx context.Context, id string) error {
+	if user, ok := m[id]; ok {
+		now := time.Now()
+		user.DeactivatedAt = &now
+	}
+	return nil
+}
+
+// memWebhookEvents remembers recorded events in memory
+type memWebhookEvents map[string]string
+
+func (m memWebhookEvents) Seen(ctx context.Context, source, eventID string) (bool, error) {
+	_, ok := m[source+"/"+eventID]
+	return ok, nil
+}
+
+func (m memWebhookEvents) Record(ctx context.Context, source, eventID, eventType string) error {
+	m[source+"/"+eventID] = eventType
+	return nil
+}
+
+func newClerkWebhookRouter(t *testing.T, users memClerkUsers, events memWebhookEvents) http.Handler {
+	t.Helper()
+	logger := zerolog.Nop()
+	h, err := NewClerkWebhookHandler("whsec_"+base64.StdEncoding.EncodeToString(clerkTestSecret), users, events, &logger)
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+	h.RegisterRoutes(r)
+	return r
+}
+
+// signedClerkRequest builds a webhook delivery signed the way Svix signs it
+func signedClerkRequest(id, body string) *http.Request {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/clerk", bytes.NewBufferString(body))
+	req.Header.Set("svix-id", id)
+	req.Header.Set("svix-timestamp", timestamp)
+	req.Header.Set("svix-signature", "v1,"+svixSignature(clerkTestSecret, id, timestamp, []byte(body)))
+	return req
+}
+
+const clerkUserCreated = `{"type":"user.created","data":{"id":"user_123","first_name":"Ada","last_name":"Lovelace",` +
+	`"primary_email_address_id":"idn_2","email_addresses":[{"id":"idn_1","email_address":"old@example.com"},{"id":"idn_2","email_address":"ada@example.com"}]}}`
+
+func TestClerkWebhookHandler_AppliesSignedEvents(t *testing.T) {
+	users := memClerkUsers{}
+	events := memWebhookEvents{}
+	router := newClerkWebhookRouter(t, users, events)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, signedClerkRequest("msg_1", clerkUserCreated))
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"result":"synced"`)
+	require.Contains(t, users, "user_123")
+	assert.Equal(t, "ada@example.com", users["user_123"].Email)
+	assert.Equal(t, "Ada Lovelace", users["user_123"].Name)
+	assert.Equal(t, "user.created", events["clerk/msg_1"])
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, signedClerkRequest("msg_2", `{"type":"user.deleted","data":{"id":"user_123","deleted":true}}`))
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.False(t, users["user_123"].IsActive())
+}
+
+func TestClerkWebhookHandler_IgnoresReplayedEvent(t *testing.T) {
+	users := memClerkUsers{}
+	events := memWebhookEvents{}
+	router := newClerkWebhookRouter(t, users, events)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, signedClerkRequest("msg_1", clerkUserCreated))
+	require.Equal(t, http.StatusOK, rec.Code)
+	delete(users, "user_123")
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, signedClerkRequest("msg_1", clerkUserCreated))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"result":"duplicate"`)
+	assert.NotContains(t, users, "user_123", "the replay is not applied again")
+}
+
+func TestClerkWebhookHandler_RejectsBadSignature(t *testing.T) {
+	users := memClerkUsers{}
+	events := memWebhookEvents{}
+	router := newClerkWebhookRouter(t, users, events)
+
+	req := signedClerkRequest("msg_1", clerkUserCreated)
+	req.Header.Set("svix-signature", "v1,"+base64.StdEncoding.EncodeToString([]byte("forged")))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// A valid signature over a different body does not verify either
+	signed := signedClerkRequest("msg_1", clerkUserCreated)
+	tampered := signedClerkRequest("msg_1", `{"type":"user.deleted","data":{"id":"user_123"}}`)
+	tampered.Header.Set("svix-signature", signed.Header.Get("svix-signature"))
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, tampered)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	assert.Empty(t, users)
+	assert.Empty(t, events)
+}
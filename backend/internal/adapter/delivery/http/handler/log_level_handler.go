@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/http/middleware"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+)
+
+// LogLevelHandler lets operators change the log level without restarting
+type LogLevelHandler struct {
+	levels *logger.LevelController
+	logger *zerolog.Logger
+}
+
+// NewLogLevelHandler creates a LogLevelHandler
+func NewLogLevelHandler(levels *logger.LevelController, logger *zerolog.Logger) *LogLevelHandler {
+	return &LogLevelHandler{levels: levels, logger: logger}
+}
+
+// SetLogLevelRequest is the request body for changing the log level
+type SetLogLevelRequest struct {
+	Level string `json:"level"`
+}
+
+// RegisterRoutes registers the log level routes, which require the admin role
+func (h *LogLevelHandler) RegisterRoutes(r chi.Router, authMiddleware middleware.AuthMiddleware) {
+	r.Route("/admin/log-level", func(r chi.Router) {
+		r.Use(authMiddleware.RequireAuthentication)
+		r.Use(authMiddleware.RequireRole(adminRole))
+		r.Get("/", h.GetLogLevel)
+		r.Put("/", h.SetLogLevel)
+		r.Delete("/", h.ClearLogLevel)
+	})
+}
+
+// GetLogLevel returns the active log level
+func (h *LogLevelHandler) GetLogLevel(w http.ResponseWriter, r *http.Request) {
+	h.writeStatus(w)
+}
+
+// SetLogLevel overrides the log level until the override is cleared
+func (h *LogLevelHandler) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	var req SetLogLevelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apperror.WriteError(w, apperror.NewInvalid("Invalid request body", nil, err))
+		return
+	}
+
+	previous := h.levels.Status().Level
+	if err := h.levels.SetOverride(req.Level); err != nil {
+		if errors.Is(err, logger.ErrInvalidLevel) {
+			apperror.WriteError(w, apperror.NewInvalid("Invalid log level", map[string]string{"level": req.Level}, err))
+			return
+		}
+		h.logger.Error().Err(err).Msg("Failed to set log level")
+		apperror.WriteError(w, apperror.NewInternal(err))
+		return
+	}
+	h.logger.Warn().Str("from", previous).Str("to", h.levels.Status().Level).Msg("Log level overridden")
+	h.writeStatus(w)
+}
+
+// ClearLogLevel removes the override, returning to the configured level
+func (h *LogLevelHandler) ClearLogLevel(w http.ResponseWriter, r *http.Request) {
+	if err := h.levels.ClearOverride(); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to clear log level override")
+		apperror.WriteError(w, apperror.NewInternal(err))
+		return
+	}
+	h.logger.Warn().Str("level", h.levels.Status().Level).Msg("Log level override cleared")
+	h.writeStatus(w)
+}
+
+// writeStatus writes the current log level status
+func (h *LogLevelHandler) writeStatus(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    h.levels.Status(),
+	}); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode log level response")
+	}
+}
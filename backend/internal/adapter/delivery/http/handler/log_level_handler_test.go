@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogLevelHandler_SetLevelEnablesDebugLines(t *testing.T) {
+	previous := zerolog.GlobalLevel()
+	t.Cleanup(func() { zerolog.SetGlobalLevel(previous) })
+
+	levels := logger.NewLevelController(filepath.Join(t.TempDir(), "log_level.json"))
+	require.NoError(t, levels.Reload("info"))
+	nop := zerolog.Nop()
+	r := chi.NewRouter()
+	NewLogLevelHandler(levels, &nop).RegisterRoutes(r, roleAuth{roles: []string{"admin"}})
+
+	var buf bytes.Buffer
+	appLog := zerolog.New(&buf)
+	appLog.Debug().Msg("suppressed")
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/log-level", strings.NewReader(`{"level":"debug"}`)))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"override":"debug"`)
+
+	appLog.Debug().Msg("emitted")
+	assert.NotContains(t, buf.String(), "suppressed")
+	assert.Contains(t, buf.String(), "emitted")
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/log-level", strings.NewReader(`{"level":"loud"}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/log-level", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, zerolog.InfoLevel, zerolog.GlobalLevel())
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/http/response"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/go-chi/chi/v5"
+)
+
+// maxOrderBookMetricsDepth caps the number of levels per side OrderBookMetrics sums over
+const maxOrderBookMetricsDepth = 500
+
+// GetOrderBookMetrics returns the best bid and ask, spread, mid price and volume
+// imbalance over the top depth levels (query parameter, default 10) of the order book.
+// The latest stored book is used; when none is stored the book is fetched from MEXC.
+func (h *MarketDataHandler) GetOrderBookMetrics(w http.ResponseWriter, r *http.Request) {
+	symbol := strings.ToUpper(chi.URLParam(r, "symbol"))
+
+	depth := 10
+	if raw := r.URL.Query().Get("depth"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 || parsed > maxOrderBookMetricsDepth {
+			apperror.WriteError(w, apperror.NewInvalid("Depth must be an integer between 1 and 500", map[string]string{"depth": raw}, err))
+			return
+		}
+		depth = parsed
+	}
+
+	exchange := "mexc" // Default exchange
+	stats, err := h.useCase.GetOrderBookMetrics(r.Context(), exchange, symbol, depth)
+	if errors.Is(err, apperror.ErrNotFound) && h.mexcClient != nil {
+		var live *model.OrderBook
+		live, err = h.mexcClient.GetOrderBook(r.Context(), symbol, depth)
+		if err != nil {
+			h.logger.Error().Err(err).Str("symbol", symbol).Msg("Failed to get MEXC order book")
+			apperror.WriteError(w, mexcError("Failed to get MEXC order book", err))
+			return
+		}
+		stats, err = market.OrderBookMetrics(toMarketOrderBook(live, exchange), depth)
+	}
+
+	switch {
+	case errors.Is(err, market.ErrEmptyOrderBook), errors.Is(err, apperror.ErrNotFound):
+		apperror.WriteError(w, apperror.NewNotFound("Order book", symbol, err))
+	case err != nil:
+		h.logger.Error().Err(err).Str("symbol", symbol).Msg("Failed to compute order book metrics")
+		apperror.WriteError(w, err)
+	default:
+		response.WriteJSON(w, http.StatusOK, response.Success(stats))
+	}
+}
+
+// GetVWAP returns the volume-weighted average price of a symbol over the stored candles
+// of interval (required) opening between from (required) and to (default now), given as
+// RFC 3339 or Unix milliseconds
+func (h *MarketDataHandler) GetVWAP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	symbol := strings.ToUpper(chi.URLParam(r, "symbol"))
+	problems := map[string]string{}
+
+	interval := market.Interval(query.Get("interval"))
+	if interval == "" {
+		problems["interval"] = "is required"
+	}
+
+	from, err := parseExportTime(query.Get("from"))
+	if err != nil {
+		problems["from"] = err.Error()
+	}
+
+	to := time.Now().UTC()
+	if raw := query.Get("to"); raw != "" {
+		if to, err = parseExportTime(raw); err != nil {
+			problems["to"] = err.Error()
+		}
+	}
+	if len(problems) == 0 && to.Before(from) {
+		problems["to"] = "must not be before from"
+	}
+
+	if len(problems) > 0 {
+		apperror.WriteError(w, apperror.NewValidation("Invalid VWAP parameters", problems, nil))
+		return
+	}
+
+	result, err := h.useCase.GetVWAP(r.Context(), "mexc", symbol, interval, from, to)
+	if err != nil {
+		h.logger.Debug().Err(err).Str("symbol", symbol).Str("interval", string(interval)).Msg("Failed to compute VWAP")
+		apperror.WriteError(w, err)
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, response.Success(result))
+}
+
+// toMarketOrderBook converts an order book returned by the exchange client
+func toMarketOrderBook(ob *model.OrderBook, exchange string) *market.OrderBook {
+	if ob == nil {
+		return nil
+	}
+	converted := &market.OrderBook{
+		Symbol:       ob.Symbol,
+		Exchange:     exchange,
+		LastUpdated:  ob.Timestamp,
+		LastUpdateID: ob.LastUpdateID,
+		Bids:         make([]market.OrderBookEntry, len(ob.Bids)),
+		Asks:         make([]market.OrderBookEntry, len(ob.Asks)),
+	}
+	for i, level := range ob.Bids {
+		converted.Bids[i] = market.OrderBookEntry{Price: level.Price, Quantity: level.Quantity}
+	}
+	for i, level := range ob.Asks {
+		converted.Asks[i] = market.OrderBookEntry{Price: level.Price, Quantity: level.Quantity}
+	}
+	return converted
+}
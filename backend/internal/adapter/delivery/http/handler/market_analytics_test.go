@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// analyticsStubMarket serves a stored order book, or ErrNotFound when there is none
+type analyticsStubMarket struct {
+	port.MarketRepository
+	orderBook *market.OrderBook
+}
+
+func (m *analyticsStubMarket) GetOrderBook(ctx context.Context, symbol, exchange string, depth int) (*market.OrderBook, error) {
+	if m.orderBook == nil {
+		return nil, apperror.ErrNotFound
+	}
+	return m.orderBook, nil
+}
+
+// liveBookClient returns a fixed exchange order book
+type liveBookClient struct {
+	port.MEXCClient
+	orderBook *model.OrderBook
+}
+
+func (c *liveBookClient) GetOrderBook(ctx context.Context, symbol string, depth int) (*model.OrderBook, error) {
+	return c.orderBook, nil
+}
+
+func newAnalyticsRouter(repo port.MarketRepository, client port.MEXCClient) http.Handler {
+	logger := zerolog.Nop()
+	h := NewMarketDataHandler(usecase.NewMarketDataUseCase(repo, nil, nil, &logger), client, &logger)
+	router := chi.NewRouter()
+	h.RegisterRoutes(router)
+	return router
+}
+
+func getOrderBookMetrics(t *testing.T, router http.Handler, url string) (int, market.OrderBookStats) {
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+	var body struct {
+		Data market.OrderBookStats `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return rec.Code, body.Data
+}
+
+func TestGetOrderBookMetrics_StoredBook(t *testing.T) {
+	repo := &analyticsStubMarket{orderBook: &market.OrderBook{
+		Symbol: "BTCUSDT",
+		Bids:   []market.OrderBookEntry{{Price: 100, Quantity: 3}},
+		Asks:   []market.OrderBookEntry{{Price: 102, Quantity: 1}},
+	}}
+
+	status, stats := getOrderBookMetrics(t, newAnalyticsRouter(repo, nil), "/market/orderbook/btcusdt/metrics?depth=5")
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 2.0, stats.Spread)
+	assert.Equal(t, 101.0, stats.MidPrice)
+	assert.Equal(t, 0.5, stats.Imbalance)
+}
+
+func TestGetOrderBookMetrics_FallsBackToLiveBook(t *testing.T) {
+	client := &liveBookClient{orderBook: &model.OrderBook{
+		Symbol: "BTCUSDT",
+		Bids:   []model.OrderBookEntry{{Price: 50, Quantity: 1}},
+		Asks:   []model.OrderBookEntry{{Price: 51, Quantity: 3}},
+	}}
+
+	status, stats := getOrderBookMetrics(t, newAnalyticsRouter(&analyticsStubMarket{}, client), "/market/orderbook/BTCUSDT/metrics")
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 50.0, stats.BestBid)
+	assert.Equal(t, -0.5, stats.Imbalance)
+}
+
+func TestGetOrderBookMetrics_Errors(t *testing.T) {
+	router := newAnalyticsRouter(&analyticsStubMarket{}, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/market/orderbook/BTCUSDT/metrics", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/market/orderbook/BTCUSDT/metrics?depth=0", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGetVWAP(t *testing.T) {
+	// exportCandles(3): typical prices (110+90+105)/3 + i, volume 10 each
+	repo := &exportStubMarket{candles: exportCandles(3)}
+	router := newExportRouter(repo, 2)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/market/BTCUSDT/vwap?interval=1h&from=2026-02-01T00:00:00Z&to=2026-02-02T00:00:00Z", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var body struct {
+		Data usecase.VWAPResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, 3, body.Data.Candles)
+	assert.Equal(t, 30.0, body.Data.Volume)
+	assert.InDelta(t, 305.0/3+1, body.Data.VWAP, 1e-9)
+}
+
+func TestGetVWAP_EmptyRange(t *testing.T) {
+	router := newExportRouter(&exportStubMarket{candles: exportCandles(3)}, 10)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/market/BTCUSDT/vwap?interval=1h&from=2025-01-01T00:00:00Z&to=2025-01-02T00:00:00Z", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "no candles for BTCUSDT 1h")
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/market/BTCUSDT/vwap?from=2025-01-01T00:00:00Z", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -3,9 +3,14 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/delivery/http/pagination"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/http/middleware"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/http/response"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
@@ -14,19 +19,29 @@ import (
 )
 
 type MarketDataHandler struct {
-	useCase    *usecase.MarketDataUseCase
-	logger     *zerolog.Logger
-	mexcClient port.MEXCClient
+	useCase        *usecase.MarketDataUseCase
+	logger         *zerolog.Logger
+	mexcClient     port.MEXCClient
+	exportPageSize int
+	// responseCache sets how long clients may cache symbols and candles
+	responseCache config.ResponseCacheConfig
 }
 
 func NewMarketDataHandler(useCase *usecase.MarketDataUseCase, mexcClient port.MEXCClient, logger *zerolog.Logger) *MarketDataHandler {
 	return &MarketDataHandler{
-		useCase:    useCase,
-		logger:     logger,
-		mexcClient: mexcClient,
+		useCase:        useCase,
+		logger:         logger,
+		mexcClient:     mexcClient,
+		exportPageSize: candleExportPageSize,
 	}
 }
 
+// WithResponseCache sets how long clients may cache the symbol and candle responses
+func (h *MarketDataHandler) WithResponseCache(cache config.ResponseCacheConfig) *MarketDataHandler {
+	h.responseCache = cache
+	return h
+}
+
 func (h *MarketDataHandler) RegisterRoutes(r chi.Router) {
 	r.Route("/market", func(r chi.Router) {
 		// Get all tickers
@@ -39,12 +54,19 @@ func (h *MarketDataHandler) RegisterRoutes(r chi.Router) {
 
 		// Get order book for a specific symbol
 		r.Get("/orderbook/{symbol}", h.GetOrderBook)
+		// Spread and imbalance signals from the top of the order book
+		r.Get("/orderbook/{symbol}/metrics", h.GetOrderBookMetrics)
 
 		// Get candles for a specific symbol and interval
-		r.Get("/candles/{symbol}/{interval}", h.GetCandles)
+		r.With(middleware.ResponseCache(h.responseCache.Candles)).Get("/candles/{symbol}/{interval}", h.GetCandles)
 
 		// Get all symbols
-		r.Get("/symbols", h.GetSymbols)
+		r.With(middleware.ResponseCache(h.responseCache.Symbols)).Get("/symbols", h.GetSymbols)
+		// Search symbols by pair, base asset or quote asset
+		r.With(middleware.ResponseCache(h.responseCache.Symbols)).Get("/symbols/search", h.SearchSymbols)
+
+		// Volume-weighted average price over a range of stored candles
+		r.Get("/{symbol}/vwap", h.GetVWAP)
 	})
 }
 
@@ -210,9 +232,35 @@ func (h *MarketDataHandler) GetCandles(w http.ResponseWriter, r *http.Request) {
 	response.WriteJSON(w, http.StatusOK, response.Success(candles))
 }
 
-// GetSymbols returns all available trading symbols
+// symbolPageOptions lists the sort fields and filters accepted by GetSymbols
+var symbolPageOptions = pagination.Options{
+	DefaultLimit: 100,
+	SortFields:   []string{"symbol", "baseAsset", "quoteAsset", "exchange"},
+	DefaultSort:  "symbol",
+	FilterFields: []string{"exchange", "baseAsset", "quoteAsset", "status"},
+}
+
+// symbolSorter sorts symbols by the whitelisted fields, breaking ties by exchange and pair
+var symbolSorter = pagination.Sorter[market.Symbol]{
+	Fields: map[string]pagination.SortKey[market.Symbol]{
+		"symbol":     func(s market.Symbol) interface{} { return s.Symbol },
+		"baseAsset":  func(s market.Symbol) interface{} { return s.BaseAsset },
+		"quoteAsset": func(s market.Symbol) interface{} { return s.QuoteAsset },
+		"exchange":   func(s market.Symbol) interface{} { return s.Exchange },
+	},
+	ID: func(s market.Symbol) string { return s.Exchange + ":" + s.Symbol },
+}
+
+// GetSymbols returns a page of trading symbols, optionally filtered by exchange, base
+// asset, quote asset or status
 func (h *MarketDataHandler) GetSymbols(w http.ResponseWriter, r *http.Request) {
-	h.logger.Debug().Msg("Getting all symbols")
+	params, err := pagination.Parse(r, symbolPageOptions)
+	if err != nil {
+		apperror.WriteError(w, err)
+		return
+	}
+
+	h.logger.Debug().Interface("filters", params.Filters).Msg("Getting symbols")
 
 	// Get real data from the use case
 	symbols, err := h.useCase.GetAllSymbols(r.Context())
@@ -222,5 +270,65 @@ func (h *MarketDataHandler) GetSymbols(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	filtered := make([]market.Symbol, 0, len(symbols))
+	for _, symbol := range symbols {
+		if matchesSymbolFilters(symbol, params.Filters) {
+			filtered = append(filtered, symbol)
+		}
+	}
+
+	page, err := symbolSorter.Paginate(filtered, params)
+	if err != nil {
+		apperror.WriteError(w, err)
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, response.Success(page))
+}
+
+// maxSymbolSearchResults caps the number of symbols returned by SearchSymbols
+const maxSymbolSearchResults = 50
+
+// SearchSymbols returns symbols matching the q query parameter, best matches first
+func (h *MarketDataHandler) SearchSymbols(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		apperror.WriteError(w, apperror.NewInvalid("Query parameter q is required", nil, nil))
+		return
+	}
+
+	limit := 20
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			apperror.WriteError(w, apperror.NewInvalid("Limit must be a positive integer", map[string]string{"limit": raw}, err))
+			return
+		}
+		limit = min(parsed, maxSymbolSearchResults)
+	}
+
+	symbols, err := h.useCase.SearchSymbols(r.Context(), query, limit)
+	if err != nil {
+		h.logger.Error().Err(err).Str("query", query).Msg("Failed to search symbols")
+		response.WriteJSON(w, http.StatusInternalServerError, response.Error("internal_error", "Failed to search symbols"))
+		return
+	}
+
 	response.WriteJSON(w, http.StatusOK, response.Success(symbols))
 }
+
+// matchesSymbolFilters reports whether a symbol matches every filter, ignoring case
+func matchesSymbolFilters(symbol market.Symbol, filters map[string]string) bool {
+	fields := map[string]string{
+		"exchange":   symbol.Exchange,
+		"baseAsset":  symbol.BaseAsset,
+		"quoteAsset": symbol.QuoteAsset,
+		"status":     symbol.Status,
+	}
+	for field, value := range filters {
+		if !strings.EqualFold(fields[field], value) {
+			return false
+		}
+	}
+	return true
+}
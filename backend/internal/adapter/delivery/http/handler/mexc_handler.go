@@ -3,8 +3,11 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/http/middleware"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/http/response"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
 	"github.com/go-chi/chi/v5"
@@ -15,6 +18,8 @@ import (
 type MEXCHandler struct {
 	mexcClient port.MEXCClient
 	logger     *zerolog.Logger
+	// exchangeInfoTTL is how long clients may cache the exchange info
+	exchangeInfoTTL time.Duration
 }
 
 // NewMEXCHandler creates a new MEXCHandler
@@ -25,6 +30,12 @@ func NewMEXCHandler(mexcClient port.MEXCClient, logger *zerolog.Logger) *MEXCHan
 	}
 }
 
+// WithExchangeInfoCacheTTL sets how long clients may cache the exchange info
+func (h *MEXCHandler) WithExchangeInfoCacheTTL(ttl time.Duration) *MEXCHandler {
+	h.exchangeInfoTTL = ttl
+	return h
+}
+
 // RegisterRoutes registers the MEXC API routes
 func (h *MEXCHandler) RegisterRoutes(r chi.Router) {
 	r.Route("/mexc", func(r chi.Router) {
@@ -35,7 +46,7 @@ func (h *MEXCHandler) RegisterRoutes(r chi.Router) {
 		r.Get("/ticker/{symbol}", h.GetTicker)
 		r.Get("/orderbook/{symbol}", h.GetOrderBook)
 		r.Get("/klines/{symbol}/{interval}", h.GetKlines)
-		r.Get("/exchange-info", h.GetExchangeInfo)
+		r.With(middleware.ResponseCache(h.exchangeInfoTTL)).Get("/exchange-info", h.GetExchangeInfo)
 
 		// Symbol endpoints
 		r.Get("/symbol/{symbol}", h.GetSymbolInfo)
@@ -53,7 +64,7 @@ func (h *MEXCHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
 	account, err := h.mexcClient.GetAccount(r.Context())
 	if err != nil {
 		h.logger.Error().Err(err).Msg("Failed to get MEXC account information")
-		response.WriteJSON(w, http.StatusInternalServerError, response.Error("internal_error", "Failed to get MEXC account information"))
+		apperror.WriteError(w, mexcError("Failed to get MEXC account information", err))
 		return
 	}
 
@@ -64,7 +75,7 @@ func (h *MEXCHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
 func (h *MEXCHandler) GetTicker(w http.ResponseWriter, r *http.Request) {
 	symbol := chi.URLParam(r, "symbol")
 	if symbol == "" {
-		response.WriteJSON(w, http.StatusBadRequest, response.Error("invalid_symbol", "Symbol is required"))
+		apperror.WriteError(w, apperror.NewInvalid("Symbol is required", nil, nil))
 		return
 	}
 
@@ -74,7 +85,7 @@ func (h *MEXCHandler) GetTicker(w http.ResponseWriter, r *http.Request) {
 	ticker, err := h.mexcClient.GetMarketData(r.Context(), symbol)
 	if err != nil {
 		h.logger.Error().Err(err).Str("symbol", symbol).Msg("Failed to get MEXC ticker")
-		response.WriteJSON(w, http.StatusInternalServerError, response.Error("internal_error", "Failed to get MEXC ticker"))
+		apperror.WriteError(w, mexcError("Failed to get MEXC ticker", err))
 		return
 	}
 
@@ -85,7 +96,7 @@ func (h *MEXCHandler) GetTicker(w http.ResponseWriter, r *http.Request) {
 func (h *MEXCHandler) GetOrderBook(w http.ResponseWriter, r *http.Request) {
 	symbol := chi.URLParam(r, "symbol")
 	if symbol == "" {
-		response.WriteJSON(w, http.StatusBadRequest, response.Error("invalid_symbol", "Symbol is required"))
+		apperror.WriteError(w, apperror.NewInvalid("Symbol is required", nil, nil))
 		return
 	}
 
@@ -95,7 +106,7 @@ func (h *MEXCHandler) GetOrderBook(w http.ResponseWriter, r *http.Request) {
 	if depthStr != "" {
 		parsedDepth, err := strconv.Atoi(depthStr)
 		if err != nil || parsedDepth <= 0 {
-			response.WriteJSON(w, http.StatusBadRequest, response.Error("invalid_depth", "Depth must be a positive integer"))
+			apperror.WriteError(w, apperror.NewInvalid("Depth must be a positive integer", map[string]string{"depth": depthStr}, err))
 			return
 		}
 		depth = parsedDepth
@@ -107,7 +118,7 @@ func (h *MEXCHandler) GetOrderBook(w http.ResponseWriter, r *http.Request) {
 	orderBook, err := h.mexcClient.GetOrderBook(r.Context(), symbol, depth)
 	if err != nil {
 		h.logger.Error().Err(err).Str("symbol", symbol).Msg("Failed to get MEXC order book")
-		response.WriteJSON(w, http.StatusInternalServerError, response.Error("internal_error", "Failed to get MEXC order book"))
+		apperror.WriteError(w, mexcError("Failed to get MEXC order book", err))
 		return
 	}
 
@@ -120,7 +131,7 @@ func (h *MEXCHandler) GetKlines(w http.ResponseWriter, r *http.Request) {
 	intervalStr := chi.URLParam(r, "interval")
 
 	if symbol == "" || intervalStr == "" {
-		response.WriteJSON(w, http.StatusBadRequest, response.Error("invalid_params", "Symbol and interval are required"))
+		apperror.WriteError(w, apperror.NewInvalid("Symbol and interval are required", nil, nil))
 		return
 	}
 
@@ -130,7 +141,7 @@ func (h *MEXCHandler) GetKlines(w http.ResponseWriter, r *http.Request) {
 	if limitStr != "" {
 		parsedLimit, err := strconv.Atoi(limitStr)
 		if err != nil || parsedLimit <= 0 {
-			response.WriteJSON(w, http.StatusBadRequest, response.Error("invalid_limit", "Limit must be a positive integer"))
+			apperror.WriteError(w, apperror.NewInvalid("Limit must be a positive integer", map[string]string{"limit": limitStr}, err))
 			return
 		}
 		limit = parsedLimit
@@ -145,7 +156,7 @@ func (h *MEXCHandler) GetKlines(w http.ResponseWriter, r *http.Request) {
 	klines, err := h.mexcClient.GetKlines(r.Context(), symbol, interval, limit)
 	if err != nil {
 		h.logger.Error().Err(err).Str("symbol", symbol).Str("interval", string(interval)).Msg("Failed to get MEXC klines")
-		response.WriteJSON(w, http.StatusInternalServerError, response.Error("internal_error", "Failed to get MEXC klines"))
+		apperror.WriteError(w, mexcError("Failed to get MEXC klines", err))
 		return
 	}
 
@@ -160,7 +171,7 @@ func (h *MEXCHandler) GetExchangeInfo(w http.ResponseWriter, r *http.Request) {
 	exchangeInfo, err := h.mexcClient.GetExchangeInfo(r.Context())
 	if err != nil {
 		h.logger.Error().Err(err).Msg("Failed to get MEXC exchange info")
-		response.WriteJSON(w, http.StatusInternalServerError, response.Error("internal_error", "Failed to get MEXC exchange info"))
+		apperror.WriteError(w, mexcError("Failed to get MEXC exchange info", err))
 		return
 	}
 
@@ -171,7 +182,7 @@ func (h *MEXCHandler) GetExchangeInfo(w http.ResponseWriter, r *http.Request) {
 func (h *MEXCHandler) GetSymbolInfo(w http.ResponseWriter, r *http.Request) {
 	symbol := chi.URLParam(r, "symbol")
 	if symbol == "" {
-		response.WriteJSON(w, http.StatusBadRequest, response.Error("invalid_symbol", "Symbol is required"))
+		apperror.WriteError(w, apperror.NewInvalid("Symbol is required", nil, nil))
 		return
 	}
 
@@ -181,7 +192,7 @@ func (h *MEXCHandler) GetSymbolInfo(w http.ResponseWriter, r *http.Request) {
 	symbolInfo, err := h.mexcClient.GetSymbolInfo(r.Context(), symbol)
 	if err != nil {
 		h.logger.Error().Err(err).Str("symbol", symbol).Msg("Failed to get MEXC symbol info")
-		response.WriteJSON(w, http.StatusInternalServerError, response.Error("internal_error", "Failed to get MEXC symbol info"))
+		apperror.WriteError(w, mexcError("Failed to get MEXC symbol info", err))
 		return
 	}
 
@@ -196,9 +207,19 @@ func (h *MEXCHandler) GetNewListings(w http.ResponseWriter, r *http.Request) {
 	newListings, err := h.mexcClient.GetNewListings(r.Context())
 	if err != nil {
 		h.logger.Error().Err(err).Msg("Failed to get MEXC new listings")
-		response.WriteJSON(w, http.StatusInternalServerError, response.Error("internal_error", "Failed to get MEXC new listings"))
+		apperror.WriteError(w, mexcError("Failed to get MEXC new listings", err))
 		return
 	}
 
 	response.WriteJSON(w, http.StatusOK, response.Success(newListings))
 }
+
+// mexcError keeps application errors from the client, such as ErrNotFound, and reports
+// anything else as an external service failure
+func mexcError(message string, err error) error {
+	var appErr *apperror.AppError
+	if apperror.As(err, &appErr) {
+		return appErr
+	}
+	return apperror.NewExternalService("MEXC", message, err)
+}
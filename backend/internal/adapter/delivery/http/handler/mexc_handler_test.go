@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mexcStubClient returns a fixed ticker or error
+type mexcStubClient struct {
+	port.MEXCClient
+	ticker *model.Ticker
+	err    error
+}
+
+func (c *mexcStubClient) GetMarketData(ctx context.Context, symbol string) (*model.Ticker, error) {
+	return c.ticker, c.err
+}
+
+func (c *mexcStubClient) GetOrderBook(ctx context.Context, symbol string, depth int) (*model.OrderBook, error) {
+	return &model.OrderBook{Symbol: symbol}, c.err
+}
+
+func newMEXCTestRouter(client port.MEXCClient) http.Handler {
+	logger := zerolog.Nop()
+	router := chi.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(apperror.RequestIDHeader, "req-42")
+			next.ServeHTTP(w, r)
+		})
+	})
+	NewMEXCHandler(client, &logger).RegisterRoutes(router)
+	return router
+}
+
+func TestMEXCHandlerErrorEnvelope(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		clientErr  error
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "client failure is an external service error",
+			path:       "/mexc/ticker/BTCUSDT",
+			clientErr:  errors.New("connection refused"),
+			wantStatus: http.StatusServiceUnavailable,
+			wantCode:   "EXTERNAL_SERVICE_ERROR",
+		},
+		{
+			name:       "client not found is kept",
+			path:       "/mexc/ticker/NOPEUSDT",
+			clientErr:  apperror.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+		{
+			name:       "invalid depth is rejected",
+			path:       "/mexc/orderbook/BTCUSDT?depth=-1",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "INVALID_INPUT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newMEXCTestRouter(&mexcStubClient{err: tt.clientErr})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			var body apperror.ErrorEnvelope
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantCode, body.Code)
+			assert.NotEmpty(t, body.Message)
+			assert.Equal(t, "req-42", body.RequestID)
+		})
+	}
+}
+
+func TestMEXCHandlerGetTicker(t *testing.T) {
+	router := newMEXCTestRouter(&mexcStubClient{ticker: &model.Ticker{Symbol: "BTCUSDT", LastPrice: 50000}})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/mexc/ticker/BTCUSDT", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"success":true`)
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/delivery"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/http/middleware"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+)
+
+// Defaults of the order stream
+const (
+	defaultOrderStreamBuffer    = 32
+	defaultOrderStreamHeartbeat = 15 * time.Second
+)
+
+// orderStreamEvent is the payload of an "order" event
+type orderStreamEvent struct {
+	Type       event.EventType   `json:"type"`
+	Order      *model.Order      `json:"order"`
+	OldStatus  model.OrderStatus `json:"old_status"`
+	NewStatus  model.OrderStatus `json:"new_status"`
+	OccurredAt time.Time         `json:"occurred_at"`
+}
+
+// OrderStreamHandler pushes the authenticated user's order fills and cancellations to the
+// client as Server-Sent Events. Order events are fanned out through a hub keyed by user ID.
+type OrderStreamHandler struct {
+	hub          *delivery.Hub[*event.OrderStatusChanged]
+	subscription port.Subscription
+	heartbeat    time.Duration
+	logger       *zerolog.Logger
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewOrderStreamHandler creates an OrderStreamHandler that forwards the order events
+// published on events
+func NewOrderStreamHandler(events port.DomainEventBus, logger *zerolog.Logger) *OrderStreamHandler {
+	h := &OrderStreamHandler{
+		hub:       delivery.NewHub[*event.OrderStatusChanged](defaultOrderStreamBuffer, *logger),
+		heartbeat: defaultOrderStreamHeartbeat,
+		logger:    logger,
+		closed:    make(chan struct{}),
+	}
+	h.subscription = events.SubscribeTopics(func(evt event.DomainEvent) {
+		changed, ok := evt.(*event.OrderStatusChanged)
+		if !ok || changed.Order == nil || changed.Order.UserID == "" {
+			return
+		}
+		h.hub.Publish(changed.Order.UserID, changed)
+	}, event.OrderFilledEvent, event.OrderCanceledEvent, event.OrderRejectedEvent, event.OrderExpiredEvent)
+	return h
+}
+
+// RegisterRoutes registers the order stream route on a router mounted at /stream behind
+// the authentication middleware
+func (h *OrderStreamHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/orders", h.StreamOrders)
+}
+
+// Close ends every open stream. Streams never complete on their own, so they are closed
+// before the server waits for in-flight requests on shutdown.
+func (h *OrderStreamHandler) Close() {
+	h.closeOnce.Do(func() {
+		h.subscription.Unsubscribe()
+		close(h.closed)
+	})
+}
+
+// StreamOrders streams the user's order updates as "order" events until the client
+// disconnects. A comment is sent every heartbeat so dead connections are noticed. A client
+// that falls a full buffer behind is sent an "error" event and disconnected; it should
+// reload its orders and reconnect.
+func (h *OrderStreamHandler) StreamOrders(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		apperror.WriteError(w, apperror.NewUnauthorized("User not authenticated", nil))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		apperror.WriteError(w, apperror.NewInternal(fmt.Errorf("streaming is not supported")))
+		return
+	}
+
+	subscription := h.hub.Subscribe(userID)
+	defer subscription.Unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	h.logger.Info().Str("user_id", userID).Msg("Order stream opened")
+	defer h.logger.Info().Str("user_id", userID).Msg("Order stream closed")
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	ctx := r.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.closed:
+			return
+		case changed, ok := <-subscription.C():
+			if !ok {
+				h.logger.Warn().Str("user_id", userID).Msg("Order stream client too slow, disconnecting")
+				_ = writeSSE(w, flusher, "error", map[string]string{"message": "Client fell behind, order updates were dropped"})
+				return
+			}
+			if err := writeSSE(w, flusher, "order", orderStreamEvent{
+				Type:       changed.Type(),
+				Order:      changed.Order,
+				OldStatus:  changed.OldStatus,
+				NewStatus:  changed.NewStatus,
+				OccurredAt: changed.OccurredAt(),
+			}); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
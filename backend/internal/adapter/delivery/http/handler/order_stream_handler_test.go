@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/delivery"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/http/middleware"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newOrderStreamServer serves the order stream, authenticating requests as the user named
+// in the X-User-ID header
+func newOrderStreamServer(t *testing.T) (*httptest.Server, *delivery.InMemoryEventBus, *OrderStreamHandler) {
+	t.Helper()
+	logger := zerolog.Nop()
+	bus := delivery.NewInMemoryEventBus(logger)
+	h := NewOrderStreamHandler(bus, &logger)
+
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if userID := r.Header.Get("X-User-ID"); userID != "" {
+				r = r.WithContext(context.WithValue(r.Context(), middleware.UserIDKey{}, userID))
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+	r.Route("/stream", h.RegisterRoutes)
+	ts := httptest.NewServer(r)
+	t.Cleanup(ts.Close)
+	return ts, bus, h
+}
+
+// openOrderStream connects to the order stream as userID. The subscription exists once the
+// response headers have arrived.
+func openOrderStream(t *testing.T, ts *httptest.Server, userID string) *bufio.Scanner {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/stream/orders", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-User-ID", userID)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	return bufio.NewScanner(resp.Body)
+}
+
+func filledOrder(orderID, userID string) *event.OrderStatusChanged {
+	order := &model.Order{OrderID: orderID, UserID: userID, Symbol: "BTCUSDT", Status: model.OrderStatusFilled, ExecutedQty: 0.5}
+	return event.NewOrderStatusChanged(order, model.OrderStatusNew)
+}
+
+func TestOrderStreamHandler_DeliversFillsToMatchingUserOnly(t *testing.T) {
+	ts, bus, _ := newOrderStreamServer(t)
+	alice := openOrderStream(t, ts, "alice")
+	bob := openOrderStream(t, ts, "bob")
+
+	bus.PublishOrderEvent(filledOrder("order-alice", "alice"))
+	bus.PublishOrderEvent(filledOrder("order-bob", "bob"))
+
+	for user, stream := range map[string]*bufio.Scanner{"alice": alice, "bob": bob} {
+		ev, ok := readSSE(t, stream)
+		require.True(t, ok, user)
+		assert.Equal(t, "order", ev.name)
+
+		var payload orderStreamEvent
+		require.NoError(t, json.Unmarshal([]byte(ev.data), &payload))
+		// Each user's first event is their own fill, so the other user's was filtered out
+		assert.Equal(t, "order-"+user, payload.Order.OrderID)
+		assert.Equal(t, user, payload.Order.UserID)
+		assert.Equal(t, event.OrderFilledEvent, payload.Type)
+		assert.Equal(t, model.OrderStatusNew, payload.OldStatus)
+		assert.Equal(t, model.OrderStatusFilled, payload.NewStatus)
+	}
+}
+
+func TestOrderStreamHandler_RequiresAuthentication(t *testing.T) {
+	ts, _, _ := newOrderStreamServer(t)
+
+	resp, err := http.Get(ts.URL + "/stream/orders")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestOrderStreamHandler_CloseEndsStreams(t *testing.T) {
+	ts, _, h := newOrderStreamServer(t)
+	stream := openOrderStream(t, ts, "alice")
+
+	h.Close()
+
+	_, ok := readSSE(t, stream)
+	assert.False(t, ok, "the stream ends without further events")
+}
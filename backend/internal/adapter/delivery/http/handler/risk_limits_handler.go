@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+)
+
+// RiskLimitReporter reports the utilization of the pre-trade risk limits
+type RiskLimitReporter interface {
+	Utilization(ctx context.Context) (*model.RiskLimitUtilization, error)
+}
+
+// RiskLimitsHandler exposes how much of each pre-trade risk limit is in use
+type RiskLimitsHandler struct {
+	limits RiskLimitReporter
+	logger *zerolog.Logger
+}
+
+// NewRiskLimitsHandler creates a RiskLimitsHandler
+func NewRiskLimitsHandler(limits RiskLimitReporter, logger *zerolog.Logger) *RiskLimitsHandler {
+	return &RiskLimitsHandler{limits: limits, logger: logger}
+}
+
+// RegisterRoutes registers the risk limit routes
+func (h *RiskLimitsHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/risk/limits", h.GetUtilization)
+}
+
+// GetUtilization returns the current use of every risk limit
+func (h *RiskLimitsHandler) GetUtilization(w http.ResponseWriter, r *http.Request) {
+	utilization, err := h.limits.Utilization(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to get risk limit utilization")
+		apperror.WriteError(w, apperror.NewInternal(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    utilization,
+	}); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode risk limit utilization response")
+	}
+}
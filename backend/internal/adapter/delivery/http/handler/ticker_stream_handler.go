@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+)
+
+// Defaults of the ticker stream
+const (
+	defaultTickerStreamHeartbeat = 15 * time.Second
+	maxTickerStreamSymbols       = 50
+)
+
+// TickerStreamHandler pushes live ticker updates of the requested symbols to the client as
+// Server-Sent Events
+type TickerStreamHandler struct {
+	feed      *usecase.TickerFeed
+	minGap    time.Duration
+	heartbeat time.Duration
+	logger    *zerolog.Logger
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewTickerStreamHandler creates a TickerStreamHandler sending at most maxRate events per
+// second to each client
+func NewTickerStreamHandler(feed *usecase.TickerFeed, maxRate float64, logger *zerolog.Logger) *TickerStreamHandler {
+	return &TickerStreamHandler{
+		feed:      feed,
+		minGap:    time.Duration(float64(time.Second) / maxRate),
+		heartbeat: defaultTickerStreamHeartbeat,
+		logger:    logger,
+		closed:    make(chan struct{}),
+	}
+}
+
+// RegisterRoutes registers the ticker stream route on a router mounted at /stream
+func (h *TickerStreamHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/tickers", h.StreamTickers)
+}
+
+// Close ends every open stream
+func (h *TickerStreamHandler) Close() {
+	h.closeOnce.Do(func() { close(h.closed) })
+}
+
+// StreamTickers streams the tickers of the comma-separated symbols query parameter as
+// "tickers" events, each holding the latest ticker of every symbol updated since the
+// previous event. Events are sent at most maxRate times per second; updates arriving in
+// between are coalesced so only the newest price of a symbol is sent. A client that falls
+// too far behind is sent an "error" event and disconnected.
+func (h *TickerStreamHandler) StreamTickers(w http.ResponseWriter, r *http.Request) {
+	symbols := parseSymbols(r.URL.Query().Get("symbols"))
+	if len(symbols) == 0 {
+		apperror.WriteError(w, apperror.NewInvalid("At least one symbol is required", nil, nil))
+		return
+	}
+	if len(symbols) > maxTickerStreamSymbols {
+		apperror.WriteError(w, apperror.NewInvalid(fmt.Sprintf("At most %d symbols can be streamed", maxTickerStreamSymbols), nil, nil))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		apperror.WriteError(w, apperror.NewInternal(fmt.Errorf("streaming is not supported")))
+		return
+	}
+
+	subscription := h.feed.Subscribe(symbols)
+	defer subscription.Unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	h.logger.Debug().Strs("symbols", symbols).Msg("Ticker stream opened")
+	defer h.logger.Debug().Strs("symbols", symbols).Msg("Ticker stream closed")
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	// Updates wait in pending, keeping the newest per symbol, until the next event is due.
+	// throttle fires when it is; it is nil while nothing is waiting.
+	pending := make(map[string]*model.Ticker)
+	var throttle <-chan time.Time
+	var lastSent time.Time
+	send := func() error {
+		tickers := make([]*model.Ticker, 0, len(pending))
+		for _, ticker := range pending {
+			tickers = append(tickers, ticker)
+		}
+		sort.Slice(tickers, func(i, j int) bool { return tickers[i].Symbol < tickers[j].Symbol })
+		pending = make(map[string]*model.Ticker)
+		lastSent = time.Now()
+		return writeSSE(w, flusher, "tickers", tickers)
+	}
+
+	ctx := r.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.closed:
+			return
+		case ticker, ok := <-subscription.C():
+			if !ok {
+				h.logger.Warn().Strs("symbols", symbols).Msg("Ticker stream client too slow, disconnecting")
+				_ = writeSSE(w, flusher, "error", map[string]string{"message": "Client fell behind, ticker updates were dropped"})
+				return
+			}
+			pending[strings.ToUpper(ticker.Symbol)] = ticker
+			if throttle != nil {
+				// Already waiting to send; the update goes out with the rest
+				continue
+			}
+			if wait := h.minGap - time.Since(lastSent); wait > 0 {
+				throttle = time.After(wait)
+				continue
+			}
+			if err := send(); err != nil {
+				return
+			}
+		case <-throttle:
+			throttle = nil
+			if err := send(); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// parseSymbols splits a comma-separated symbol list, upper-casing and de-duplicating it
+func parseSymbols(raw string) []string {
+	seen := make(map[string]bool)
+	var symbols []string
+	for _, part := range strings.Split(raw, ",") {
+		symbol := strings.ToUpper(strings.TrimSpace(part))
+		if symbol == "" || seen[symbol] {
+			continue
+		}
+		seen[symbol] = true
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
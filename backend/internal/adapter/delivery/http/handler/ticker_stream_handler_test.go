@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTickerStreamServer serves the ticker stream from a feed the test publishes to
+func newTickerStreamServer(t *testing.T, maxRate float64) (*httptest.Server, *usecase.TickerFeed) {
+	t.Helper()
+	logger := zerolog.Nop()
+	feed := usecase.NewTickerFeed(nil, time.Second, &logger)
+	h := NewTickerStreamHandler(feed, maxRate, &logger)
+
+	r := chi.NewRouter()
+	r.Route("/stream", h.RegisterRoutes)
+	ts := httptest.NewServer(r)
+	t.Cleanup(ts.Close)
+	return ts, feed
+}
+
+// openTickerStream connects to the ticker stream. The subscription exists once the response
+// headers have arrived.
+func openTickerStream(t *testing.T, ts *httptest.Server, symbols string) *bufio.Scanner {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/stream/tickers?symbols="+symbols, nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	return bufio.NewScanner(resp.Body)
+}
+
+// readTickers reads the next "tickers" event
+func readTickers(t *testing.T, stream *bufio.Scanner) []*model.Ticker {
+	t.Helper()
+	ev, ok := readSSE(t, stream)
+	require.True(t, ok, "stream ended")
+	require.Equal(t, "tickers", ev.name)
+
+	var tickers []*model.Ticker
+	require.NoError(t, json.Unmarshal([]byte(ev.data), &tickers))
+	return tickers
+}
+
+func TestTickerStreamHandler_SendsRequestedSymbolsOnly(t *testing.T) {
+	ts, feed := newTickerStreamServer(t, 10)
+	stream := openTickerStream(t, ts, "btcusdt,ETHUSDT")
+
+	feed.Publish(&model.Ticker{Symbol: "BTCUSDT", LastPrice: 60000})
+	feed.Publish(&model.Ticker{Symbol: "DOGEUSDT", LastPrice: 0.1})
+	feed.Publish(&model.Ticker{Symbol: "ETHUSDT", LastPrice: 3000})
+
+	prices := make(map[string]float64)
+	for len(prices) < 2 {
+		for _, ticker := range readTickers(t, stream) {
+			prices[ticker.Symbol] = ticker.LastPrice
+		}
+	}
+	assert.Equal(t, map[string]float64{"BTCUSDT": 60000, "ETHUSDT": 3000}, prices)
+}
+
+func TestTickerStreamHandler_CoalescesRapidUpdates(t *testing.T) {
+	// At most one event every 200ms
+	ts, feed := newTickerStreamServer(t, 5)
+	stream := openTickerStream(t, ts, "BTCUSDT")
+
+	for price := 1; price <= 100; price++ {
+		feed.Publish(&model.Ticker{Symbol: "BTCUSDT", LastPrice: float64(price)})
+	}
+
+	events := 0
+	var last *model.Ticker
+	for last == nil || last.LastPrice < 100 {
+		tickers := readTickers(t, stream)
+		require.Len(t, tickers, 1)
+		last = tickers[0]
+		events++
+	}
+	// The first update may go out at once; every later one is folded into the next event
+	assert.LessOrEqual(t, events, 2)
+	assert.Equal(t, 100.0, last.LastPrice)
+}
+
+func TestTickerStreamHandler_RequiresSymbols(t *testing.T) {
+	ts, _ := newTickerStreamServer(t, 5)
+
+	resp, err := http.Get(ts.URL + "/stream/tickers?symbols=,")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
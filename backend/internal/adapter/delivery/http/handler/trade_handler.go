@@ -1,6 +1,16 @@
 package handler
 
 import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/delivery/http/validation"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/http/middleware"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
 	"github.com/go-chi/chi/v5"
 	"github.com/rs/zerolog"
@@ -9,6 +19,8 @@ import (
 type TradeHandler struct {
 	useCase usecase.TradeUseCase
 	logger  *zerolog.Logger
+	// orderLimits bounds order placement requests
+	orderLimits config.RouteLimits
 }
 
 func NewTradeHandler(useCase usecase.TradeUseCase, logger *zerolog.Logger) *TradeHandler {
@@ -18,9 +30,153 @@ func NewTradeHandler(useCase usecase.TradeUseCase, logger *zerolog.Logger) *Trad
 	}
 }
 
+// WithOrderLimits sets the body size and timeout limits for order placement
+func (h *TradeHandler) WithOrderLimits(limits config.RouteLimits) *TradeHandler {
+	h.orderLimits = limits
+	return h
+}
+
 func (h *TradeHandler) RegisterRoutes(r chi.Router) {
 	r.Route("/trade", func(r chi.Router) {
-		// Add trading routes here
-		// Example: r.Post("/orders", h.PlaceOrder)
+		r.With(middleware.RequestLimits(h.orderLimits)).Post("/orders", h.PlaceOrder)
+		r.Get("/orders/{symbol}/history", h.GetOrderHistory)
+
+		// Realized PnL computed from order history
+		r.Get("/pnl/{symbol}", h.GetPnLReport)
 	})
 }
+
+// GetPnLReport returns the FIFO realized PnL report of the authenticated user for a symbol
+func (h *TradeHandler) GetPnLReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok || userID == "" {
+		apperror.WriteError(w, apperror.NewUnauthorized("User not authenticated", nil))
+		return
+	}
+
+	symbol := chi.URLParam(r, "symbol")
+	if symbol == "" {
+		apperror.WriteError(w, apperror.NewInvalid("Symbol is required", nil, nil))
+		return
+	}
+
+	report, err := h.useCase.GetPnLReport(ctx, userID, symbol)
+	if err != nil {
+		h.logger.Error().Err(err).Str("symbol", symbol).Msg("Failed to compute PnL report")
+		apperror.WriteError(w, apperror.NewInternal(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    report,
+	}); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode PnL report response")
+	}
+}
+
+// PlaceOrderRequest represents the request body for placing an order
+type PlaceOrderRequest struct {
+	Symbol      string            `json:"symbol" validate:"required"`
+	Side        model.OrderSide   `json:"side" validate:"required,oneof=BUY SELL"`
+	Type        model.OrderType   `json:"type" validate:"required,oneof=LIMIT MARKET"`
+	Quantity    float64           `json:"quantity" validate:"required,gt=0"`
+	Price       float64           `json:"price" validate:"gte=0"`
+	TimeInForce model.TimeInForce `json:"time_in_force" validate:"oneof=GTC IOC FOK"`
+}
+
+// ValidateRules requires a positive price for limit orders
+func (req *PlaceOrderRequest) ValidateRules(errs validation.Errors) {
+	if req.Type == model.OrderTypeLimit && req.Price <= 0 {
+		errs.Add("price", "must be greater than 0 when type is LIMIT")
+	}
+}
+
+// PlaceOrder validates and places a new order for the authenticated user
+func (h *TradeHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		apperror.WriteError(w, apperror.NewUnauthorized("User not authenticated", nil))
+		return
+	}
+
+	var req PlaceOrderRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		apperror.WriteError(w, err)
+		return
+	}
+
+	order, err := h.useCase.PlaceOrder(r.Context(), model.OrderRequest{
+		UserID:      userID,
+		Symbol:      req.Symbol,
+		Side:        req.Side,
+		Type:        req.Type,
+		Quantity:    req.Quantity,
+		Price:       req.Price,
+		TimeInForce: req.TimeInForce,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("symbol", req.Symbol).Msg("Failed to place order")
+		apperror.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    order,
+	}); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode order response")
+	}
+}
+
+// GetOrderHistory returns a page of the authenticated user's order history for a symbol,
+// newest first. Pass the returned nextCursor as the cursor query parameter to fetch the
+// following page.
+func (h *TradeHandler) GetOrderHistory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		apperror.WriteError(w, apperror.NewUnauthorized("User not authenticated", nil))
+		return
+	}
+
+	symbol := chi.URLParam(r, "symbol")
+	if symbol == "" {
+		apperror.WriteError(w, apperror.NewInvalid("Symbol is required", nil, nil))
+		return
+	}
+
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 || parsed > 500 {
+			apperror.WriteError(w, apperror.NewInvalid("Limit must be between 1 and 500", map[string]string{"limit": raw}, err))
+			return
+		}
+		limit = parsed
+	}
+
+	page, err := h.useCase.GetOrderHistoryPage(r.Context(), userID, symbol, r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidOrderCursor) {
+			apperror.WriteError(w, apperror.NewInvalid("Cursor is invalid", nil, err))
+			return
+		}
+		h.logger.Error().Err(err).Str("symbol", symbol).Msg("Failed to get order history")
+		apperror.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    page,
+	}); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode order history response")
+	}
+}
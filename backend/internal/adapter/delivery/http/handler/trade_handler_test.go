@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tradeStubUseCase records placed orders and the users PnL reports and history pages are
+// requested for
+type tradeStubUseCase struct {
+	usecase.TradeUseCase
+	placed       []model.OrderRequest
+	pnlUsers     []string
+	historyUsers []string
+}
+
+func (u *tradeStubUseCase) PlaceOrder(ctx context.Context, req model.OrderRequest) (*model.Order, error) {
+	u.placed = append(u.placed, req)
+	return &model.Order{Symbol: req.Symbol, Side: req.Side, Type: req.Type, Quantity: req.Quantity, Price: req.Price}, nil
+}
+
+func (u *tradeStubUseCase) GetPnLReport(ctx context.Context, userID, symbol string) (*model.PnLReport, error) {
+	u.pnlUsers = append(u.pnlUsers, userID)
+	return &model.PnLReport{Symbol: symbol}, nil
+}
+
+func (u *tradeStubUseCase) GetOrderHistoryPage(ctx context.Context, userID, symbol, cursor string, limit int) (*model.OrderPage, error) {
+	u.historyUsers = append(u.historyUsers, userID)
+	return &model.OrderPage{}, nil
+}
+
+// newTradeTestRouter serves the trade routes to userID, or to anonymous callers when empty
+func newTradeTestRouter(uc usecase.TradeUseCase, userID string) http.Handler {
+	logger := zerolog.Nop()
+	router := chi.NewRouter()
+	if userID != "" {
+		router.Use(withTestUser(userID))
+	}
+	NewTradeHandler(uc, &logger).RegisterRoutes(router)
+	return router
+}
+
+func TestPlaceOrderReportsAllViolations(t *testing.T) {
+	uc := &tradeStubUseCase{}
+	router := newTradeTestRouter(uc, "alice")
+
+	body := `{"symbol":"","side":"HOLD","type":"LIMIT","quantity":0,"time_in_force":"DAY"}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/trade/orders", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var envelope apperror.ErrorEnvelope
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &envelope))
+	assert.Equal(t, "VALIDATION_ERROR", envelope.Code)
+	assert.Equal(t, map[string]interface{}{
+		"symbol":        "is required",
+		"side":          "must be one of BUY, SELL",
+		"quantity":      "is required",
+		"price":         "must be greater than 0 when type is LIMIT",
+		"time_in_force": "must be one of GTC, IOC, FOK",
+	}, envelope.Details)
+	assert.Empty(t, uc.placed)
+}
+
+func TestPlaceOrderAcceptsMarketOrderWithoutPrice(t *testing.T) {
+	uc := &tradeStubUseCase{}
+	router := newTradeTestRouter(uc, "alice")
+
+	body := `{"symbol":"BTCUSDT","side":"BUY","type":"MARKET","quantity":0.25}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/trade/orders", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	require.Len(t, uc.placed, 1)
+	assert.Equal(t, model.OrderTypeMarket, uc.placed[0].Type)
+	assert.Equal(t, 0.25, uc.placed[0].Quantity)
+	assert.Equal(t, "alice", uc.placed[0].UserID)
+}
+
+func TestPlaceOrderRejectsMalformedBody(t *testing.T) {
+	uc := &tradeStubUseCase{}
+	router := newTradeTestRouter(uc, "alice")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/trade/orders", strings.NewReader(`{"symbol":`)))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var envelope apperror.ErrorEnvelope
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &envelope))
+	assert.Equal(t, "INVALID_INPUT", envelope.Code)
+	assert.Empty(t, uc.placed)
+}
+
+func TestPlaceOrderAppliesOrderLimits(t *testing.T) {
+	uc := &tradeStubUseCase{}
+	logger := zerolog.Nop()
+	router := chi.NewRouter()
+	router.Use(withTestUser("alice"))
+	NewTradeHandler(uc, &logger).WithOrderLimits(config.RouteLimits{MaxBodyBytes: 64}).RegisterRoutes(router)
+
+	body := `{"symbol":"BTCUSDT","side":"BUY","type":"MARKET","quantity":0.25,"padding":"` + strings.Repeat("x", 64) + `"}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/trade/orders", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Empty(t, uc.placed)
+}
+
+func TestPlaceOrderRequiresAuthentication(t *testing.T) {
+	uc := &tradeStubUseCase{}
+	body := `{"symbol":"BTCUSDT","side":"BUY","type":"MARKET","quantity":0.25}`
+	w := httptest.NewRecorder()
+	newTradeTestRouter(uc, "").ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/trade/orders", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	var envelope apperror.ErrorEnvelope
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &envelope))
+	assert.Equal(t, "UNAUTHORIZED", envelope.Code)
+	assert.Empty(t, uc.placed)
+}
+
+func TestGetPnLReportUsesAuthenticatedUser(t *testing.T) {
+	uc := &tradeStubUseCase{}
+	router := newTradeTestRouter(uc, "alice")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/trade/pnl/BTCUSDT", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{"alice"}, uc.pnlUsers)
+
+	w = httptest.NewRecorder()
+	newTradeTestRouter(uc, "").ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/trade/pnl/BTCUSDT", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Len(t, uc.pnlUsers, 1)
+}
+
+func TestGetOrderHistoryUsesAuthenticatedUser(t *testing.T) {
+	uc := &tradeStubUseCase{}
+	router := newTradeTestRouter(uc, "alice")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/trade/orders/BTCUSDT/history", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{"alice"}, uc.historyUsers)
+
+	w = httptest.NewRecorder()
+	newTradeTestRouter(uc, "").ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/trade/orders/BTCUSDT/history", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Len(t, uc.historyUsers, 1)
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/delivery/http/validation"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/http/middleware"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
@@ -70,6 +71,26 @@ func (h *WalletHandler) GetWallets(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(wallets)
 }
 
+// CreateWalletRequest represents the request body for creating a wallet
+type CreateWalletRequest struct {
+	Exchange string           `json:"exchange"`
+	Type     model.WalletType `json:"type" validate:"required,oneof=EXCHANGE WEB3 CUSTOM"`
+}
+
+// ValidateRules requires an exchange for exchange wallets
+func (req *CreateWalletRequest) ValidateRules(errs validation.Errors) {
+	if req.Type == model.WalletTypeExchange && req.Exchange == "" {
+		errs.Add("exchange", "is required when type is EXCHANGE")
+	}
+}
+
+// UpdateWalletMetadataRequest represents the request body for updating wallet metadata
+type UpdateWalletMetadataRequest struct {
+	Name        string   `json:"name" validate:"max=100"`
+	Description string   `json:"description" validate:"max=500"`
+	Tags        []string `json:"tags" validate:"max=20"`
+}
+
 // CreateWallet handles the create wallet endpoint
 func (h *WalletHandler) CreateWallet(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context
@@ -79,13 +100,10 @@ func (h *WalletHandler) CreateWallet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Parse request body
-	var request struct {
-		Exchange string          `json:"exchange"`
-		Type     model.WalletType `json:"type"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		apperror.WriteError(w, apperror.NewInvalid("Invalid request body", nil, nil))
+	// Parse and validate request body
+	var request CreateWalletRequest
+	if err := validation.DecodeAndValidate(r, &request); err != nil {
+		apperror.WriteError(w, err)
 		return
 	}
 
@@ -222,14 +240,10 @@ func (h *WalletHandler) UpdateWalletMetadata(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	// Parse request body
-	var request struct {
-		Name        string   `json:"name"`
-		Description string   `json:"description"`
-		Tags        []string `json:"tags"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		apperror.WriteError(w, apperror.NewInvalid("Invalid request body", nil, nil))
+	// Parse and validate request body
+	var request UpdateWalletMetadataRequest
+	if err := validation.DecodeAndValidate(r, &request); err != nil {
+		apperror.WriteError(w, err)
 		return
 	}
 
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/http/middleware"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+)
+
+// WalletSyncStatusHandler reports the outcome of scheduled wallet syncs
+type WalletSyncStatusHandler struct {
+	scheduler *usecase.WalletSyncScheduler
+	logger    *zerolog.Logger
+}
+
+// NewWalletSyncStatusHandler creates a WalletSyncStatusHandler
+func NewWalletSyncStatusHandler(scheduler *usecase.WalletSyncScheduler, logger *zerolog.Logger) *WalletSyncStatusHandler {
+	return &WalletSyncStatusHandler{scheduler: scheduler, logger: logger}
+}
+
+// RegisterRoutes registers the wallet sync status routes, which require the admin role
+func (h *WalletSyncStatusHandler) RegisterRoutes(r chi.Router, authMiddleware middleware.AuthMiddleware) {
+	r.Route("/admin/wallet-sync", func(r chi.Router) {
+		r.Use(authMiddleware.RequireAuthentication)
+		r.Use(authMiddleware.RequireRole(adminRole))
+		r.Get("/", h.ListStatuses)
+		r.Get("/{userID}", h.GetStatus)
+	})
+}
+
+// ListStatuses returns the last sync status of every scheduled user
+func (h *WalletSyncStatusHandler) ListStatuses(w http.ResponseWriter, r *http.Request) {
+	h.writeData(w, h.scheduler.Statuses())
+}
+
+// GetStatus returns the last sync status of one user
+func (h *WalletSyncStatusHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+	status, ok := h.scheduler.Status(userID)
+	if !ok {
+		apperror.WriteError(w, apperror.NewNotFound("Wallet sync status", userID, nil))
+		return
+	}
+	h.writeData(w, status)
+}
+
+// writeData writes data as a successful response
+func (h *WalletSyncStatusHandler) writeData(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	}); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode wallet sync status response")
+	}
+}
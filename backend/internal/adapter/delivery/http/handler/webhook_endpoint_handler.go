@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/http/middleware"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+)
+
+// createWebhookEndpointRequest is the body of an endpoint registration
+type createWebhookEndpointRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+}
+
+// createdWebhookEndpoint is a newly registered endpoint together with its signing secret
+type createdWebhookEndpoint struct {
+	*model.WebhookEndpoint
+	Secret string `json:"secret"`
+}
+
+// WebhookEndpointHandler lets users manage the endpoints bot events are delivered to
+type WebhookEndpointHandler struct {
+	useCase usecase.WebhookEndpointUseCase
+	logger  *zerolog.Logger
+}
+
+// NewWebhookEndpointHandler creates a WebhookEndpointHandler
+func NewWebhookEndpointHandler(useCase usecase.WebhookEndpointUseCase, logger *zerolog.Logger) *WebhookEndpointHandler {
+	return &WebhookEndpointHandler{useCase: useCase, logger: logger}
+}
+
+// RegisterRoutes registers the webhook endpoint routes
+func (h *WebhookEndpointHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/webhooks/endpoints", func(r chi.Router) {
+		r.Get("/", h.ListEndpoints)
+		r.Post("/", h.CreateEndpoint)
+		r.Delete("/{id}", h.DeleteEndpoint)
+		r.Get("/{id}/deliveries", h.ListDeliveries)
+	})
+}
+
+// ListEndpoints returns the user's endpoints
+func (h *WebhookEndpointHandler) ListEndpoints(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		apperror.WriteError(w, apperror.NewUnauthorized("User not authenticated", nil))
+		return
+	}
+
+	endpoints, err := h.useCase.ListEndpoints(r.Context(), userID)
+	if err != nil {
+		h.writeError(w, err, "Failed to list webhook endpoints")
+		return
+	}
+	h.writeData(w, http.StatusOK, endpoints)
+}
+
+// CreateEndpoint registers an endpoint. The response carries the signing secret, which is
+// not shown again.
+func (h *WebhookEndpointHandler) CreateEndpoint(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		apperror.WriteError(w, apperror.NewUnauthorized("User not authenticated", nil))
+		return
+	}
+
+	var req createWebhookEndpointRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apperror.WriteError(w, apperror.NewInvalid("Invalid request body", nil, err))
+		return
+	}
+
+	endpoint, err := h.useCase.CreateEndpoint(r.Context(), userID, req.URL, req.EventTypes)
+	if err != nil {
+		h.writeError(w, err, "Failed to create webhook endpoint")
+		return
+	}
+	h.writeData(w, http.StatusCreated, createdWebhookEndpoint{WebhookEndpoint: endpoint, Secret: endpoint.Secret})
+}
+
+// DeleteEndpoint removes one of the user's endpoints
+func (h *WebhookEndpointHandler) DeleteEndpoint(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		apperror.WriteError(w, apperror.NewUnauthorized("User not authenticated", nil))
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if err := h.useCase.DeleteEndpoint(r.Context(), userID, id); err != nil {
+		h.writeError(w, err, "Failed to delete webhook endpoint")
+		return
+	}
+	h.writeData(w, http.StatusOK, map[string]string{"id": id})
+}
+
+// ListDeliveries returns the latest deliveries to one of the user's endpoints
+func (h *WebhookEndpointHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		apperror.WriteError(w, apperror.NewUnauthorized("User not authenticated", nil))
+		return
+	}
+
+	limit := 50
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > 500 {
+			apperror.WriteError(w, apperror.NewInvalid("Limit must be between 1 and 500", map[string]string{"limit": raw}, err))
+			return
+		}
+		limit = parsed
+	}
+
+	deliveries, err := h.useCase.ListDeliveries(r.Context(), userID, chi.URLParam(r, "id"), limit)
+	if err != nil {
+		h.writeError(w, err, "Failed to list webhook deliveries")
+		return
+	}
+	h.writeData(w, http.StatusOK, deliveries)
+}
+
+// writeError writes application errors as they are and logs anything else as internal
+func (h *WebhookEndpointHandler) writeError(w http.ResponseWriter, err error, msg string) {
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		apperror.WriteError(w, appErr)
+		return
+	}
+	h.logger.Error().Err(err).Msg(msg)
+	apperror.WriteError(w, apperror.NewInternal(err))
+}
+
+// writeData writes a successful response
+func (h *WebhookEndpointHandler) writeData(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	}); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode webhook endpoint response")
+	}
+}
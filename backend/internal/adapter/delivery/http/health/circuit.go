@@ -0,0 +1,42 @@
+package health
+
+import (
+	"context"
+	"time"
+)
+
+// Circuit breaker states as reported by CircuitReporter
+const (
+	circuitOpen     = "open"
+	circuitHalfOpen = "half_open"
+)
+
+// CircuitReporter exposes the state of a circuit breaker guarding an external dependency
+type CircuitReporter interface {
+	// CircuitStatus returns the state (closed, open or half_open), the consecutive failure
+	// count and, while open, when the next probe is allowed
+	CircuitStatus() (state string, failures int, retryAt time.Time)
+}
+
+// NewCircuitCheck reports a circuit breaker: down while open, degraded while half-open
+// and up while closed
+func NewCircuitCheck(breaker CircuitReporter) CheckFunc {
+	return func(ctx context.Context) Result {
+		state, failures, retryAt := breaker.CircuitStatus()
+		details := map[string]interface{}{
+			"state":                state,
+			"consecutive_failures": failures,
+		}
+		if !retryAt.IsZero() {
+			details["retry_at"] = retryAt.Format(time.RFC3339)
+		}
+
+		switch state {
+		case circuitOpen:
+			return Result{Status: StatusDown, Message: "circuit breaker is open; calls fail fast", Details: details}
+		case circuitHalfOpen:
+			return Result{Status: StatusDegraded, Message: "circuit breaker is probing for recovery", Details: details}
+		}
+		return Result{Status: StatusUp, Details: details}
+	}
+}
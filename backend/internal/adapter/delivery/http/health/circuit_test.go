@@ -0,0 +1,37 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeCircuit reports a fixed circuit breaker status
+type fakeCircuit struct {
+	state    string
+	failures int
+	retryAt  time.Time
+}
+
+func (c fakeCircuit) CircuitStatus() (string, int, time.Time) {
+	return c.state, c.failures, c.retryAt
+}
+
+func TestCircuitCheck_MapsStates(t *testing.T) {
+	retryAt := time.Date(2024, 1, 1, 0, 0, 30, 0, time.UTC)
+
+	open := NewCircuitCheck(fakeCircuit{state: "open", failures: 5, retryAt: retryAt})(context.Background())
+	assert.Equal(t, StatusDown, open.Status)
+	assert.Equal(t, 5, open.Details["consecutive_failures"])
+	assert.Equal(t, "2024-01-01T00:00:30Z", open.Details["retry_at"])
+
+	halfOpen := NewCircuitCheck(fakeCircuit{state: "half_open", failures: 5})(context.Background())
+	assert.Equal(t, StatusDegraded, halfOpen.Status)
+	assert.NotContains(t, halfOpen.Details, "retry_at")
+
+	closed := NewCircuitCheck(fakeCircuit{state: "closed"})(context.Background())
+	assert.Equal(t, StatusUp, closed.Status)
+	assert.Equal(t, "closed", closed.Details["state"])
+}
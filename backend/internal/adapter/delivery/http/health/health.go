@@ -0,0 +1,290 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Status is the health of a single component or of the service as a whole
+type Status string
+
+// Component and service health states
+const (
+	StatusUp       Status = "up"
+	StatusDegraded Status = "degraded"
+	StatusDown     Status = "down"
+)
+
+// Defaults applied to components registered without explicit options
+const (
+	DefaultCheckTimeout = 3 * time.Second
+	DefaultCacheTTL     = 5 * time.Second
+)
+
+// Result is the outcome of one component check
+type Result struct {
+	Status  Status                 `json:"status"`
+	Message string                 `json:"message,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// CheckFunc reports the current health of a component
+type CheckFunc func(ctx context.Context) Result
+
+// ComponentReport is a component's check result as exposed by the detailed endpoint
+type ComponentReport struct {
+	Name      string    `json:"name"`
+	Critical  bool      `json:"critical"`
+	CheckedAt time.Time `json:"checked_at"`
+	Result
+}
+
+// Report is the aggregated health of all registered components
+type Report struct {
+	Status     Status            `json:"status"`
+	Version    string            `json:"version"`
+	Timestamp  time.Time         `json:"timestamp"`
+	Components []ComponentReport `json:"components"`
+}
+
+// ComponentOptions configures how a component is checked
+type ComponentOptions struct {
+	// Critical components fail readiness when they report StatusDown
+	Critical bool
+	// Timeout bounds a single check. A check that does not finish in time is reported as
+	// StatusDegraded. Zero uses DefaultCheckTimeout.
+	Timeout time.Duration
+	// CacheTTL is how long a result is reused before the check runs again. Zero uses
+	// DefaultCacheTTL; a negative value disables caching.
+	CacheTTL time.Duration
+}
+
+// component is a registered dependency check with its last result
+type component struct {
+	name      string
+	critical  bool
+	check     CheckFunc
+	timeout   time.Duration
+	cacheTTL  time.Duration
+	mu        sync.Mutex
+	last      Result
+	checkedAt time.Time
+}
+
+// run returns the cached result while it is fresh and otherwise runs the check under the
+// component's timeout. Concurrent callers wait for a single check instead of each calling
+// the dependency.
+func (c *component) run(ctx context.Context) (Result, time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.checkedAt.IsZero() && c.cacheTTL > 0 && time.Since(c.checkedAt) < c.cacheTTL {
+		return c.last, c.checkedAt
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	done := make(chan Result, 1)
+	go func() {
+		done <- c.check(checkCtx)
+	}()
+
+	select {
+	case c.last = <-done:
+	case <-checkCtx.Done():
+		c.last = Result{
+			Status:  StatusDegraded,
+			Message: fmt.Sprintf("check did not complete within %s: %v", c.timeout, checkCtx.Err()),
+		}
+	}
+	c.checkedAt = time.Now()
+	return c.last, c.checkedAt
+}
+
+// HealthCheck aggregates component checks and serves the health endpoints. Liveness only
+// reports that the process is serving requests; readiness fails when a component that was
+// registered as critical is down. Non-critical components can degrade the detailed report
+// without taking the service out of rotation.
+type HealthCheck struct {
+	version    string
+	logger     *zerolog.Logger
+	mu         sync.RWMutex
+	components map[string]*component
+	// history records check results when enabled; see EnableHistory
+	history         *History
+	retention       time.Duration
+	cancelRecording context.CancelFunc
+	recordingDone   chan struct{}
+}
+
+// NewHealthCheck creates a new HealthCheck with an always-up "system" component
+func NewHealthCheck(version string, logger *zerolog.Logger) *HealthCheck {
+	h := &HealthCheck{
+		version:    version,
+		logger:     logger,
+		components: make(map[string]*component),
+	}
+	h.Register("system", false, func(ctx context.Context) Result {
+		return Result{Status: StatusUp}
+	})
+	return h
+}
+
+// Register adds or replaces a component check with the default timeout and cache TTL.
+// Critical components fail readiness when they report StatusDown.
+func (h *HealthCheck) Register(name string, critical bool, check CheckFunc) {
+	h.RegisterWithOptions(name, check, ComponentOptions{Critical: critical})
+}
+
+// RegisterWithOptions adds or replaces a component check
+func (h *HealthCheck) RegisterWithOptions(name string, check CheckFunc, opts ComponentOptions) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultCheckTimeout
+	}
+	if opts.CacheTTL == 0 {
+		opts.CacheTTL = DefaultCacheTTL
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.components[name] = &component{
+		name:     name,
+		critical: opts.Critical,
+		check:    check,
+		timeout:  opts.Timeout,
+		cacheTTL: opts.CacheTTL,
+	}
+}
+
+// Check runs every component check concurrently and aggregates the results. The service
+// is down when a critical component is down and degraded when any other component is not
+// up.
+func (h *HealthCheck) Check(ctx context.Context) Report {
+	h.mu.RLock()
+	components := make([]*component, 0, len(h.components))
+	for _, c := range h.components {
+		components = append(components, c)
+	}
+	history := h.history
+	h.mu.RUnlock()
+	sort.Slice(components, func(i, j int) bool { return components[i].name < components[j].name })
+
+	report := Report{
+		Status:     StatusUp,
+		Version:    h.version,
+		Timestamp:  time.Now(),
+		Components: make([]ComponentReport, len(components)),
+	}
+
+	var wg sync.WaitGroup
+	for i, c := range components {
+		wg.Add(1)
+		go func(i int, c *component) {
+			defer wg.Done()
+			result, checkedAt := c.run(ctx)
+			report.Components[i] = ComponentReport{
+				Name:      c.name,
+				Critical:  c.critical,
+				CheckedAt: checkedAt,
+				Result:    result,
+			}
+		}(i, c)
+	}
+	wg.Wait()
+
+	for i, c := range components {
+		result := report.Components[i].Result
+		if history != nil {
+			history.Record(c.name, result.Status, report.Components[i].CheckedAt)
+		}
+
+		switch {
+		case result.Status == StatusDown && c.critical:
+			report.Status = StatusDown
+		case result.Status != StatusUp && report.Status == StatusUp:
+			report.Status = StatusDegraded
+		}
+		if result.Status != StatusUp {
+			h.logger.Warn().
+				Str("component", c.name).
+				Str("status", string(result.Status)).
+				Bool("critical", c.critical).
+				Str("message", result.Message).
+				Msg("Health check component not up")
+		}
+	}
+	return report
+}
+
+// Handler serves the basic /health endpoint. It does not run component checks.
+func (h *HealthCheck) Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"status":    "ok",
+			"version":   h.version,
+			"timestamp": time.Now().Format(time.RFC3339),
+		})
+	}
+}
+
+// DetailedHandler serves /health/detailed with the result of every component check. It
+// responds 503 when a critical component is down.
+func (h *HealthCheck) DetailedHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := h.Check(r.Context())
+		writeJSON(w, statusCode(report.Status), report)
+	}
+}
+
+// LivenessHandler reports that the process is up and serving requests. It never checks
+// dependencies, so a failing dependency does not get the process restarted.
+func (h *HealthCheck) LivenessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"status":    StatusUp,
+			"timestamp": time.Now().Format(time.RFC3339),
+		})
+	}
+}
+
+// ReadinessHandler reports whether the service can take traffic. It responds 503 when a
+// critical component is down; degraded or non-critical components keep it ready.
+func (h *HealthCheck) ReadinessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := h.Check(r.Context())
+		failing := []string{}
+		for _, c := range report.Components {
+			if c.Critical && c.Status == StatusDown {
+				failing = append(failing, c.Name)
+			}
+		}
+		writeJSON(w, statusCode(report.Status), map[string]interface{}{
+			"status":    report.Status,
+			"ready":     report.Status != StatusDown,
+			"failing":   failing,
+			"timestamp": report.Timestamp.Format(time.RFC3339),
+		})
+	}
+}
+
+// statusCode maps the aggregated status to an HTTP status code
+func statusCode(status Status) int {
+	if status == StatusDown {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
+
+func writeJSON(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,177 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHealthCheck() *HealthCheck {
+	logger := zerolog.Nop()
+	return NewHealthCheck("test-version", &logger)
+}
+
+func staticCheck(status Status) CheckFunc {
+	return func(ctx context.Context) Result {
+		return Result{Status: status, Message: string(status)}
+	}
+}
+
+func serve(t *testing.T, handler http.HandlerFunc) (int, map[string]interface{}) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return rec.Code, body
+}
+
+func TestReadiness_NonCriticalDownStaysReady(t *testing.T) {
+	h := newTestHealthCheck()
+	h.Register("database", true, staticCheck(StatusUp))
+	h.Register("mexc", false, staticCheck(StatusDown))
+
+	code, body := serve(t, h.ReadinessHandler())
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, true, body["ready"])
+	assert.Equal(t, string(StatusDegraded), body["status"])
+}
+
+func TestReadiness_CriticalDownFails(t *testing.T) {
+	h := newTestHealthCheck()
+	h.Register("database", true, staticCheck(StatusDown))
+	h.Register("mexc", false, staticCheck(StatusUp))
+
+	code, body := serve(t, h.ReadinessHandler())
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, false, body["ready"])
+	assert.Equal(t, []interface{}{"database"}, body["failing"])
+}
+
+func TestReadiness_CriticalDegradedStaysReady(t *testing.T) {
+	h := newTestHealthCheck()
+	h.Register("database", true, staticCheck(StatusDegraded))
+
+	code, body := serve(t, h.ReadinessHandler())
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, true, body["ready"])
+}
+
+func TestLiveness_IgnoresDependencies(t *testing.T) {
+	h := newTestHealthCheck()
+	called := false
+	h.Register("database", true, func(ctx context.Context) Result {
+		called = true
+		return Result{Status: StatusDown}
+	})
+
+	code, body := serve(t, h.LivenessHandler())
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, string(StatusUp), body["status"])
+	assert.False(t, called)
+}
+
+func TestDetailedHandler_ReportsComponents(t *testing.T) {
+	h := newTestHealthCheck()
+	h.Register("database", true, staticCheck(StatusDown))
+
+	code, body := serve(t, h.DetailedHandler())
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "test-version", body["version"])
+
+	components := body["components"].([]interface{})
+	require.Len(t, components, 2)
+	first := components[0].(map[string]interface{})
+	assert.Equal(t, "database", first["name"])
+	assert.Equal(t, true, first["critical"])
+	assert.Equal(t, string(StatusDown), first["status"])
+	assert.Equal(t, "system", components[1].(map[string]interface{})["name"])
+}
+
+func TestHandler_BasicStatus(t *testing.T) {
+	h := newTestHealthCheck()
+	h.Register("database", true, staticCheck(StatusDown))
+
+	code, body := serve(t, h.Handler())
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", body["status"])
+	assert.Equal(t, "test-version", body["version"])
+}
+
+func TestCheck_SlowComponentTimesOutAsDegraded(t *testing.T) {
+	h := newTestHealthCheck()
+	h.RegisterWithOptions("turso", func(ctx context.Context) Result {
+		select {
+		case <-time.After(time.Second):
+			return Result{Status: StatusUp}
+		case <-ctx.Done():
+			<-time.After(time.Second)
+			return Result{Status: StatusDown}
+		}
+	}, ComponentOptions{Critical: true, Timeout: 20 * time.Millisecond, CacheTTL: -1})
+
+	start := time.Now()
+	report := h.Check(context.Background())
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	assert.Equal(t, StatusDegraded, report.Status)
+	turso := report.Components[1]
+	assert.Equal(t, "turso", turso.Name)
+	assert.Equal(t, StatusDegraded, turso.Status)
+	assert.Contains(t, turso.Message, "did not complete within 20ms")
+}
+
+func TestCheck_ReusesCachedResult(t *testing.T) {
+	h := newTestHealthCheck()
+	var calls atomic.Int32
+	h.RegisterWithOptions("mexc", func(ctx context.Context) Result {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return Result{Status: StatusUp}
+	}, ComponentOptions{Timeout: time.Second, CacheTTL: time.Hour})
+
+	first := h.Check(context.Background())
+	for i := 0; i < 5; i++ {
+		report := h.Check(context.Background())
+		assert.Equal(t, first.Components[0].CheckedAt, report.Components[0].CheckedAt)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestCheck_CachesTimeoutResult(t *testing.T) {
+	h := newTestHealthCheck()
+	var calls atomic.Int32
+	h.RegisterWithOptions("mexc", func(ctx context.Context) Result {
+		calls.Add(1)
+		<-ctx.Done()
+		return Result{Status: StatusDown}
+	}, ComponentOptions{Timeout: 10 * time.Millisecond, CacheTTL: time.Hour})
+
+	h.Check(context.Background())
+	report := h.Check(context.Background())
+	assert.Equal(t, StatusDegraded, report.Components[0].Status)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestCheck_ExpiredCacheRunsAgain(t *testing.T) {
+	h := newTestHealthCheck()
+	var calls atomic.Int32
+	h.RegisterWithOptions("mexc", func(ctx context.Context) Result {
+		calls.Add(1)
+		return Result{Status: StatusUp}
+	}, ComponentOptions{CacheTTL: 10 * time.Millisecond})
+
+	h.Check(context.Background())
+	time.Sleep(20 * time.Millisecond)
+	h.Check(context.Background())
+	assert.Equal(t, int32(2), calls.Load())
+}
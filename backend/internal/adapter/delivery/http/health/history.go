@@ -0,0 +1,218 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Defaults of the check history
+const (
+	DefaultHistoryRetention = 7 * 24 * time.Hour
+	DefaultHistoryInterval  = time.Minute
+	DefaultUptimeWindow     = 24 * time.Hour
+)
+
+// Sample is the recorded outcome of one component check
+type Sample struct {
+	Status    Status
+	CheckedAt time.Time
+}
+
+// ComponentUptime is the availability of a component over a window
+type ComponentUptime struct {
+	Name string `json:"name"`
+	// UptimePercent is the share of the observed time the component was not down.
+	// Degraded time counts as up.
+	UptimePercent float64 `json:"uptime_percent"`
+	// ObservedSeconds is how much of the window is covered by recorded checks
+	ObservedSeconds float64 `json:"observed_seconds"`
+	Samples         int     `json:"samples"`
+}
+
+// History keeps the results of component checks for computing uptime. Samples are kept
+// per component in the order they were checked.
+type History struct {
+	mu      sync.Mutex
+	samples map[string][]Sample
+}
+
+// NewHistory creates an empty History
+func NewHistory() *History {
+	return &History{samples: make(map[string][]Sample)}
+}
+
+// Record adds the outcome of a check. A result served from the component's cache has the
+// checked-at time of the previous sample and is not recorded again.
+func (hs *History) Record(component string, status Status, checkedAt time.Time) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	samples := hs.samples[component]
+	if n := len(samples); n > 0 && !checkedAt.After(samples[n-1].CheckedAt) {
+		return
+	}
+	hs.samples[component] = append(samples, Sample{Status: status, CheckedAt: checkedAt})
+}
+
+// Purge removes the samples checked before cutoff and returns how many were removed. The
+// time before a component's first remaining sample is no longer observed.
+func (hs *History) Purge(cutoff time.Time) int {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	removed := 0
+	for component, samples := range hs.samples {
+		keep := sort.Search(len(samples), func(i int) bool { return !samples[i].CheckedAt.Before(cutoff) })
+		removed += keep
+		if keep == len(samples) {
+			delete(hs.samples, component)
+			continue
+		}
+		hs.samples[component] = append([]Sample(nil), samples[keep:]...)
+	}
+	return removed
+}
+
+// Uptime returns the availability of every component between from and to, sorted by
+// name. A sample's status holds until the next sample of the component, so the result
+// is weighted by time rather than by the number of checks. Time before a component's
+// first sample is not observed and does not count against it.
+func (hs *History) Uptime(from, to time.Time) []ComponentUptime {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	uptimes := make([]ComponentUptime, 0, len(hs.samples))
+	for component, samples := range hs.samples {
+		uptime := ComponentUptime{Name: component}
+		var up, observed time.Duration
+		for i, sample := range samples {
+			start, end := sample.CheckedAt, to
+			if i+1 < len(samples) {
+				end = samples[i+1].CheckedAt
+			}
+			if start.Before(from) {
+				start = from
+			}
+			if end.After(to) {
+				end = to
+			}
+			if !end.After(start) {
+				continue
+			}
+			if !sample.CheckedAt.Before(from) {
+				uptime.Samples++
+			}
+			observed += end.Sub(start)
+			if sample.Status != StatusDown {
+				up += end.Sub(start)
+			}
+		}
+		if observed == 0 {
+			continue
+		}
+		uptime.UptimePercent = float64(up) / float64(observed) * 100
+		uptime.ObservedSeconds = observed.Seconds()
+		uptimes = append(uptimes, uptime)
+	}
+	sort.Slice(uptimes, func(i, j int) bool { return uptimes[i].Name < uptimes[j].Name })
+	return uptimes
+}
+
+// EnableHistory records the outcome of every component check and keeps it for retention.
+// Non-positive retention uses DefaultHistoryRetention.
+func (h *HealthCheck) EnableHistory(retention time.Duration) {
+	if retention <= 0 {
+		retention = DefaultHistoryRetention
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.history = NewHistory()
+	h.retention = retention
+}
+
+// StartRecording checks every component on each interval, so the history covers periods
+// without health requests, and purges samples older than the retention. It does nothing
+// unless the history is enabled. Non-positive interval uses DefaultHistoryInterval.
+func (h *HealthCheck) StartRecording(ctx context.Context, interval time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.history == nil || h.cancelRecording != nil {
+		return
+	}
+	if interval <= 0 {
+		interval = DefaultHistoryInterval
+	}
+
+	ctx, h.cancelRecording = context.WithCancel(ctx)
+	h.recordingDone = make(chan struct{})
+	history, retention, done := h.history, h.retention, h.recordingDone
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			h.Check(ctx)
+			if removed := history.Purge(time.Now().Add(-retention)); removed > 0 {
+				h.logger.Debug().Int("removed", removed).Msg("Purged health check history")
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// StopRecording ends background checks and waits for the current one to finish
+func (h *HealthCheck) StopRecording() {
+	h.mu.Lock()
+	cancel, done := h.cancelRecording, h.recordingDone
+	h.cancelRecording, h.recordingDone = nil, nil
+	h.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+// UptimeHandler serves /health/uptime with the uptime of every component over the window
+// query parameter, a duration such as "24h" that may not exceed the retention. It defaults
+// to DefaultUptimeWindow, or the retention when that is shorter.
+func (h *HealthCheck) UptimeHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.mu.RLock()
+		history, retention := h.history, h.retention
+		h.mu.RUnlock()
+		if history == nil {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"error": "health check history is not enabled"})
+			return
+		}
+
+		window := min(DefaultUptimeWindow, retention)
+		if raw := r.URL.Query().Get("window"); raw != "" {
+			parsed, err := time.ParseDuration(raw)
+			if err != nil || parsed <= 0 || parsed > retention {
+				writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+					"error": "window must be a positive duration of at most " + retention.String(),
+				})
+				return
+			}
+			window = parsed
+		}
+
+		to := time.Now()
+		from := to.Add(-window)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"window":     window.String(),
+			"from":       from.Format(time.RFC3339),
+			"to":         to.Format(time.RFC3339),
+			"components": history.Uptime(from, to),
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory_UptimeIsWeightedByTime(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	history := NewHistory()
+	// The database is up for 6h, down for 2h, then degraded (still up) for 4h
+	history.Record("database", StatusUp, start)
+	history.Record("database", StatusUp, start.Add(3*time.Hour))
+	history.Record("database", StatusDown, start.Add(6*time.Hour))
+	history.Record("database", StatusDegraded, start.Add(8*time.Hour))
+	// MEXC is only checked from 10h on and is down since
+	history.Record("mexc", StatusDown, start.Add(10*time.Hour))
+	// A cached result repeats the previous checked-at time and is not counted again
+	history.Record("mexc", StatusUp, start.Add(10*time.Hour))
+
+	uptimes := history.Uptime(start, start.Add(12*time.Hour))
+	require.Len(t, uptimes, 2)
+	assert.Equal(t, "database", uptimes[0].Name)
+	assert.InDelta(t, 10.0/12*100, uptimes[0].UptimePercent, 1e-9)
+	assert.Equal(t, 4, uptimes[0].Samples)
+	assert.Equal(t, (12 * time.Hour).Seconds(), uptimes[0].ObservedSeconds)
+	assert.Equal(t, "mexc", uptimes[1].Name)
+	assert.Equal(t, 0.0, uptimes[1].UptimePercent)
+	assert.Equal(t, 1, uptimes[1].Samples)
+	assert.Equal(t, (2 * time.Hour).Seconds(), uptimes[1].ObservedSeconds, "time before the first check is not observed")
+
+	// A window starting while the database was down carries that status into the window
+	uptimes = history.Uptime(start.Add(7*time.Hour), start.Add(9*time.Hour))
+	require.Len(t, uptimes, 1)
+	assert.InDelta(t, 50.0, uptimes[0].UptimePercent, 1e-9)
+	assert.Equal(t, 1, uptimes[0].Samples)
+}
+
+func TestHistory_PurgeRemovesOnlyOlderSamples(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	history := NewHistory()
+	history.Record("database", StatusDown, start)
+	history.Record("database", StatusUp, start.Add(time.Hour))
+	history.Record("database", StatusUp, start.Add(2*time.Hour))
+	history.Record("mexc", StatusDown, start.Add(30*time.Minute))
+
+	assert.Equal(t, 2, history.Purge(start.Add(time.Hour)))
+
+	uptimes := history.Uptime(start, start.Add(3*time.Hour))
+	require.Len(t, uptimes, 1, "components without samples are dropped")
+	assert.Equal(t, "database", uptimes[0].Name)
+	assert.Equal(t, 100.0, uptimes[0].UptimePercent)
+	assert.Equal(t, 2, uptimes[0].Samples)
+	assert.Equal(t, 0, history.Purge(start.Add(time.Hour)))
+}
+
+func TestUptimeHandler_ReportsRecordedChecks(t *testing.T) {
+	h := newTestHealthCheck()
+	h.RegisterWithOptions("database", staticCheck(StatusDown), ComponentOptions{CacheTTL: -1})
+
+	rec := httptest.NewRecorder()
+	h.UptimeHandler()(rec, httptest.NewRequest(http.MethodGet, "/health/uptime", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code, "history is off by default")
+
+	h.EnableHistory(time.Hour)
+	h.history.Record("database", StatusUp, time.Now().Add(-30*time.Minute))
+	h.Check(context.Background())
+
+	rec = httptest.NewRecorder()
+	h.UptimeHandler()(rec, httptest.NewRequest(http.MethodGet, "/health/uptime?window=1h", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var body struct {
+		Window     string            `json:"window"`
+		Components []ComponentUptime `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "1h0m0s", body.Window)
+	components := body.Components
+	require.Len(t, components, 2)
+	assert.Equal(t, "database", components[0].Name)
+	assert.Equal(t, 2, components[0].Samples)
+	assert.Greater(t, components[0].UptimePercent, 99.0, "the database was down only since the latest check")
+	assert.Equal(t, "system", components[1].Name)
+
+	rec = httptest.NewRecorder()
+	h.UptimeHandler()(rec, httptest.NewRequest(http.MethodGet, "/health/uptime?window=48h", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "the window may not exceed the retention")
+}
@@ -0,0 +1,147 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Defaults for the MEXC connectivity component
+const (
+	DefaultMEXCPingInterval     = 30 * time.Second
+	DefaultMEXCFailureThreshold = 3
+)
+
+// Pinger checks connectivity to an exchange
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// MEXCComponent actively pings MEXC in the background and reports the result of the most
+// recent probes. A single failure degrades the component; FailureThreshold consecutive
+// failures mark it down.
+type MEXCComponent struct {
+	pinger           Pinger
+	interval         time.Duration
+	failureThreshold int
+	logger           *zerolog.Logger
+
+	mu                  sync.RWMutex
+	probed              bool
+	lastSuccess         time.Time
+	lastLatency         time.Duration
+	lastError           error
+	consecutiveFailures int
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewMEXCComponent creates a MEXC connectivity component. Non-positive interval and
+// threshold values fall back to the defaults.
+func NewMEXCComponent(pinger Pinger, interval time.Duration, failureThreshold int, logger *zerolog.Logger) *MEXCComponent {
+	if interval <= 0 {
+		interval = DefaultMEXCPingInterval
+	}
+	if failureThreshold <= 0 {
+		failureThreshold = DefaultMEXCFailureThreshold
+	}
+	return &MEXCComponent{
+		pinger:           pinger,
+		interval:         interval,
+		failureThreshold: failureThreshold,
+		logger:           logger,
+	}
+}
+
+// Start probes MEXC immediately and then on every interval until ctx is cancelled or Stop
+// is called
+func (m *MEXCComponent) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	m.cancel = cancel
+	m.done = make(chan struct{})
+
+	go func() {
+		defer close(m.done)
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		m.Probe(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.Probe(ctx)
+			}
+		}
+	}()
+}
+
+// Stop ends background probing and waits for the current probe to finish
+func (m *MEXCComponent) Stop() {
+	if m.cancel == nil {
+		return
+	}
+	m.cancel()
+	<-m.done
+}
+
+// Probe pings MEXC once and records the outcome. The ping is bounded by the probe interval.
+func (m *MEXCComponent) Probe(ctx context.Context) {
+	pingCtx, cancel := context.WithTimeout(ctx, m.interval)
+	defer cancel()
+
+	start := time.Now()
+	err := m.pinger.Ping(pingCtx)
+	latency := time.Since(start)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.probed = true
+	m.lastLatency = latency
+	m.lastError = err
+	if err != nil {
+		m.consecutiveFailures++
+		m.logger.Warn().
+			Err(err).
+			Int("consecutiveFailures", m.consecutiveFailures).
+			Msg("MEXC ping failed")
+		return
+	}
+	m.consecutiveFailures = 0
+	m.lastSuccess = time.Now()
+}
+
+// Check implements CheckFunc from the state recorded by the latest probes. It does not
+// call MEXC itself.
+func (m *MEXCComponent) Check(ctx context.Context) Result {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	details := map[string]interface{}{
+		"latency_ms":           m.lastLatency.Milliseconds(),
+		"consecutive_failures": m.consecutiveFailures,
+	}
+	if !m.lastSuccess.IsZero() {
+		details["last_success"] = m.lastSuccess.Format(time.RFC3339)
+	}
+
+	switch {
+	case !m.probed:
+		return Result{Status: StatusDegraded, Message: "MEXC has not been probed yet", Details: details}
+	case m.consecutiveFailures >= m.failureThreshold:
+		return Result{
+			Status:  StatusDown,
+			Message: fmt.Sprintf("%d consecutive ping failures: %v", m.consecutiveFailures, m.lastError),
+			Details: details,
+		}
+	case m.consecutiveFailures > 0:
+		return Result{Status: StatusDegraded, Message: m.lastError.Error(), Details: details}
+	}
+	return Result{Status: StatusUp, Details: details}
+}
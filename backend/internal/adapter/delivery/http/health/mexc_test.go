@@ -0,0 +1,112 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePinger fails every ping while failed is set
+type fakePinger struct {
+	mu     sync.Mutex
+	calls  int
+	failed bool
+}
+
+func (p *fakePinger) Ping(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	if p.failed {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func (p *fakePinger) setFailing(failing bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failed = failing
+}
+
+func newTestMEXCComponent(pinger Pinger) *MEXCComponent {
+	logger := zerolog.Nop()
+	return NewMEXCComponent(pinger, time.Hour, 3, &logger)
+}
+
+func TestMEXCComponent_DegradedBeforeFirstProbe(t *testing.T) {
+	component := newTestMEXCComponent(&fakePinger{})
+	assert.Equal(t, StatusDegraded, component.Check(context.Background()).Status)
+}
+
+func TestMEXCComponent_UpReportsLastSuccessAndLatency(t *testing.T) {
+	component := newTestMEXCComponent(&fakePinger{})
+	component.Probe(context.Background())
+
+	result := component.Check(context.Background())
+	assert.Equal(t, StatusUp, result.Status)
+	assert.Contains(t, result.Details, "last_success")
+	assert.Contains(t, result.Details, "latency_ms")
+	assert.Equal(t, 0, result.Details["consecutive_failures"])
+}
+
+func TestMEXCComponent_RepeatedFailuresFlipToDown(t *testing.T) {
+	pinger := &fakePinger{}
+	component := newTestMEXCComponent(pinger)
+	ctx := context.Background()
+
+	component.Probe(ctx)
+	require.Equal(t, StatusUp, component.Check(ctx).Status)
+
+	pinger.setFailing(true)
+	component.Probe(ctx)
+	assert.Equal(t, StatusDegraded, component.Check(ctx).Status)
+	component.Probe(ctx)
+	assert.Equal(t, StatusDegraded, component.Check(ctx).Status)
+	component.Probe(ctx)
+
+	result := component.Check(ctx)
+	assert.Equal(t, StatusDown, result.Status)
+	assert.Contains(t, result.Message, "3 consecutive ping failures")
+	assert.Contains(t, result.Details, "last_success")
+
+	pinger.setFailing(false)
+	component.Probe(ctx)
+	assert.Equal(t, StatusUp, component.Check(ctx).Status)
+}
+
+func TestMEXCComponent_StartProbesImmediately(t *testing.T) {
+	pinger := &fakePinger{}
+	component := newTestMEXCComponent(pinger)
+	component.Start(context.Background())
+
+	assert.Eventually(t, func() bool {
+		return component.Check(context.Background()).Status == StatusUp
+	}, time.Second, 5*time.Millisecond)
+	component.Stop()
+
+	pinger.mu.Lock()
+	defer pinger.mu.Unlock()
+	assert.Equal(t, 1, pinger.calls)
+}
+
+func TestMEXCComponent_DownKeepsReadinessWhenNotCritical(t *testing.T) {
+	pinger := &fakePinger{}
+	pinger.setFailing(true)
+	component := newTestMEXCComponent(pinger)
+	for i := 0; i < 3; i++ {
+		component.Probe(context.Background())
+	}
+
+	h := newTestHealthCheck()
+	h.Register("mexc", false, component.Check)
+	report := h.Check(context.Background())
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.Equal(t, StatusDown, report.Components[0].Status)
+}
@@ -1,27 +1,34 @@
 package http
 
 import (
+	"context"
 	"net/http"
-	"time"
 
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/delivery/http/health"
 	httpmiddleware "github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/http/middleware"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/factory"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/lifecycle"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/pkg/platform/mexc"
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/rs/zerolog"
 	"gorm.io/gorm"
 )
 
-// NewRouter initializes the HTTP router with all middleware and base routes.
-func NewRouter(cfg *config.Config, logger *zerolog.Logger, db *gorm.DB) *chi.Mux {
+// NewRouter initializes the HTTP router with all middleware and base routes. The health
+// check reports mexcBreaker, the circuit breaker shared by the MEXC clients, and its
+// background probes are started and stopped by lifecycleManager.
+func NewRouter(cfg *config.Config, logger *zerolog.Logger, db *gorm.DB, mexcBreaker *mexc.CircuitBreaker, lifecycleManager *lifecycle.LifecycleManager) *chi.Mux {
 	r := chi.NewRouter()
 
 	// Create consolidated factory
-	consolidatedFactory := factory.NewConsolidatedFactory(db, logger, cfg)
+	consolidatedFactory := factory.NewConsolidatedFactory(db, logger, cfg).WithMEXCCircuitBreaker(mexcBreaker)
 
 	// Global middleware
 	r.Use(chimiddleware.RequestID)
+	r.Use(httpmiddleware.CorrelationIDMiddleware(logger))
+	r.Use(httpmiddleware.Tracing())
 	r.Use(chimiddleware.RealIP)
 	r.Use(chimiddleware.Logger)
 	r.Use(chimiddleware.Recoverer)
@@ -41,12 +48,46 @@ func NewRouter(cfg *config.Config, logger *zerolog.Logger, db *gorm.DB) *chi.Mux
 	secureHeadersMiddleware := consolidatedFactory.GetSecureHeadersHandler()
 	r.Use(secureHeadersMiddleware)
 
-	// Health check endpoint
-	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"status":"ok","version":"` + cfg.Version + `","timestamp":"` + time.Now().Format(time.RFC3339) + `"}`))
+	// Health check endpoints
+	healthCheck := health.NewHealthCheck(cfg.Version, logger)
+	if db != nil {
+		healthCheck.Register("database", true, databaseCheck(db))
+	}
+	if pinger, ok := consolidatedFactory.GetMEXCClient().(health.Pinger); ok && cfg.MEXC.APIKey != "" {
+		// Ping through the shared client so the pings and the breaker agree on outages
+		mexcHealth := health.NewMEXCComponent(pinger, 0, 0, logger)
+		lifecycleManager.Append(lifecycle.Hook{
+			Name: "MEXC health probe",
+			OnStart: func(ctx context.Context) error {
+				mexcHealth.Start(ctx)
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				mexcHealth.Stop()
+				return nil
+			},
+		})
+		healthCheck.Register("mexc", false, mexcHealth.Check)
+		healthCheck.Register("mexc_circuit", false, health.NewCircuitCheck(consolidatedFactory.GetMEXCCircuitBreaker()))
+	}
+	// Keep a week of check results so uptime can be reported per component
+	healthCheck.EnableHistory(health.DefaultHistoryRetention)
+	lifecycleManager.Append(lifecycle.Hook{
+		Name: "health history",
+		OnStart: func(ctx context.Context) error {
+			healthCheck.StartRecording(ctx, health.DefaultHistoryInterval)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			healthCheck.StopRecording()
+			return nil
+		},
 	})
+	r.Get("/health", healthCheck.Handler())
+	r.Get("/health/detailed", healthCheck.DetailedHandler())
+	r.Get("/health/live", healthCheck.LivenessHandler())
+	r.Get("/health/ready", healthCheck.ReadinessHandler())
+	r.Get("/health/uptime", healthCheck.UptimeHandler())
 
 	// Root level test endpoint
 	r.Get("/root-test", func(w http.ResponseWriter, r *http.Request) {
@@ -59,6 +100,20 @@ func NewRouter(cfg *config.Config, logger *zerolog.Logger, db *gorm.DB) *chi.Mux
 	return r
 }
 
+// databaseCheck reports the database as down when it cannot be pinged
+func databaseCheck(db *gorm.DB) health.CheckFunc {
+	return func(ctx context.Context) health.Result {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return health.Result{Status: health.StatusDown, Message: err.Error()}
+		}
+		if err := sqlDB.PingContext(ctx); err != nil {
+			return health.Result{Status: health.StatusDown, Message: err.Error()}
+		}
+		return health.Result{Status: health.StatusUp}
+	}
+}
+
 // GetAuthMiddleware returns the authentication middleware from the consolidated factory
 func GetAuthMiddleware(cfg *config.Config, logger *zerolog.Logger, db *gorm.DB) (httpmiddleware.AuthMiddleware, error) {
 	consolidatedFactory := factory.NewConsolidatedFactory(db, logger, cfg)
@@ -8,13 +8,14 @@ import (
 	"testing"
 
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/lifecycle"
 	"github.com/rs/zerolog"
 )
 
 func TestNewRouter_HealthAndRootEndpoints(t *testing.T) {
 	logger := zerolog.Nop()
 	cfg := &config.Config{Version: "test-version"}
-	router := NewRouter(cfg, &logger, nil)
+	router := NewRouter(cfg, &logger, nil, nil, lifecycle.NewLifecycleManager(0, &logger))
 
 	ts := httptest.NewServer(router)
 	defer ts.Close()
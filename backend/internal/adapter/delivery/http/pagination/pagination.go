@@ -0,0 +1,276 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+)
+
+// Direction is the order in which a list is sorted
+type Direction string
+
+// Sort directions
+const (
+	Asc  Direction = "asc"
+	Desc Direction = "desc"
+)
+
+// Default page sizes used when Options leaves them unset
+const (
+	DefaultLimit = 50
+	MaxLimit     = 500
+)
+
+// Options describes what a list endpoint accepts
+type Options struct {
+	DefaultLimit int
+	MaxLimit     int
+	// SortFields whitelists the fields clients may sort by
+	SortFields  []string
+	DefaultSort string
+	DefaultDir  Direction
+	// FilterFields whitelists the query parameters copied into PageParams.Filters
+	FilterFields []string
+}
+
+// PageParams are the paging, sorting and filtering parameters of a list request
+type PageParams struct {
+	Limit     int
+	Offset    int
+	Cursor    string
+	SortField string
+	SortDir   Direction
+	Filters   map[string]string
+}
+
+// PagedResponse is the envelope returned by paginated list endpoints
+type PagedResponse[T any] struct {
+	Items      []T    `json:"items"`
+	Total      int    `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset,omitempty"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// Parse reads limit, offset, cursor, sort, order and the whitelisted filters from the
+// query string. Invalid values, sort fields outside the whitelist and requests combining
+// offset with cursor are rejected with a validation error.
+func Parse(r *http.Request, opts Options) (PageParams, error) {
+	query := r.URL.Query()
+	problems := map[string]string{}
+
+	params := PageParams{
+		Limit:     opts.DefaultLimit,
+		Cursor:    query.Get("cursor"),
+		SortField: opts.DefaultSort,
+		SortDir:   opts.DefaultDir,
+		Filters:   map[string]string{},
+	}
+	if params.Limit <= 0 {
+		params.Limit = DefaultLimit
+	}
+	if params.SortDir == "" {
+		params.SortDir = Asc
+	}
+	maxLimit := opts.MaxLimit
+	if maxLimit <= 0 {
+		maxLimit = MaxLimit
+	}
+
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			problems["limit"] = "must be a positive integer"
+		} else {
+			params.Limit = min(limit, maxLimit)
+		}
+	}
+
+	if raw := query.Get("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		switch {
+		case err != nil || offset < 0:
+			problems["offset"] = "must be a non-negative integer"
+		case params.Cursor != "":
+			problems["offset"] = "cannot be combined with cursor"
+		default:
+			params.Offset = offset
+		}
+	}
+
+	if field := query.Get("sort"); field != "" {
+		if !contains(opts.SortFields, field) {
+			problems["sort"] = "must be one of " + strings.Join(opts.SortFields, ", ")
+		} else {
+			params.SortField = field
+		}
+	}
+
+	if raw := query.Get("order"); raw != "" {
+		switch dir := Direction(strings.ToLower(raw)); dir {
+		case Asc, Desc:
+			params.SortDir = dir
+		default:
+			problems["order"] = "must be asc or desc"
+		}
+	}
+
+	for _, field := range opts.FilterFields {
+		if value := query.Get(field); value != "" {
+			params.Filters[field] = value
+		}
+	}
+
+	if len(problems) > 0 {
+		return PageParams{}, apperror.NewValidation("Invalid pagination parameters", problems, nil)
+	}
+	return params, nil
+}
+
+// SortKey returns the value of a sortable field. Supported types are string, int,
+// int64, float64 and time.Time.
+type SortKey[T any] func(item T) interface{}
+
+// Sorter sorts and pages items of one type
+type Sorter[T any] struct {
+	// Fields maps each whitelisted sort field to its key
+	Fields map[string]SortKey[T]
+	// ID returns a unique identifier used to break ties so pages stay stable
+	ID func(item T) string
+}
+
+// cursor marks the last item of a page
+type cursor struct {
+	Field string `json:"f"`
+	Key   string `json:"k"`
+	ID    string `json:"id"`
+}
+
+// Paginate sorts items by the requested field, with the ID as tie-breaker, and returns
+// the requested page. A cursor resumes strictly after the item it was issued for, so
+// items added or removed elsewhere in the list never cause duplicates or gaps.
+func (s Sorter[T]) Paginate(items []T, params PageParams) (PagedResponse[T], error) {
+	key, ok := s.Fields[params.SortField]
+	if !ok {
+		return PagedResponse[T]{}, apperror.NewValidation("Invalid pagination parameters", map[string]string{"sort": "is not a sortable field"}, nil)
+	}
+
+	sorted := make([]T, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return s.compare(key, sorted[i], keyString(key(sorted[j])), s.ID(sorted[j]), params.SortDir) < 0
+	})
+
+	start := min(params.Offset, len(sorted))
+	if params.Cursor != "" {
+		after, err := decodeCursor(params.Cursor)
+		if err != nil || after.Field != params.SortField {
+			return PagedResponse[T]{}, apperror.NewValidation("Invalid pagination parameters", map[string]string{"cursor": "is invalid or was issued for another sort"}, err)
+		}
+		start = sort.Search(len(sorted), func(i int) bool {
+			return s.compare(key, sorted[i], after.Key, after.ID, params.SortDir) > 0
+		})
+	}
+	end := min(start+params.Limit, len(sorted))
+
+	page := PagedResponse[T]{
+		Items:  sorted[start:end],
+		Total:  len(sorted),
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	}
+	if end < len(sorted) && end > start {
+		last := sorted[end-1]
+		page.NextCursor = encodeCursor(cursor{Field: params.SortField, Key: keyString(key(last)), ID: s.ID(last)})
+	}
+	return page, nil
+}
+
+// compare orders item against the position described by a key and ID in the given
+// direction, returning a negative number when item comes first
+func (s Sorter[T]) compare(key SortKey[T], item T, otherKey, otherID string, dir Direction) int {
+	result := compareKey(key(item), otherKey)
+	if result == 0 {
+		result = strings.Compare(s.ID(item), otherID)
+	}
+	if dir == Desc {
+		return -result
+	}
+	return result
+}
+
+// keyString encodes a sort key so that compareKey can restore its type
+func keyString(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// compareKey compares a sort key against an encoded key of the same type
+func compareKey(value interface{}, encoded string) int {
+	switch v := value.(type) {
+	case string:
+		return strings.Compare(v, encoded)
+	case int:
+		other, _ := strconv.ParseInt(encoded, 10, 64)
+		return compareOrdered(int64(v), other)
+	case int64:
+		other, _ := strconv.ParseInt(encoded, 10, 64)
+		return compareOrdered(v, other)
+	case float64:
+		other, _ := strconv.ParseFloat(encoded, 64)
+		return compareOrdered(v, other)
+	case time.Time:
+		other, _ := time.Parse(time.RFC3339Nano, encoded)
+		return v.Compare(other)
+	default:
+		return strings.Compare(fmt.Sprint(v), encoded)
+	}
+}
+
+func compareOrdered[V int64 | float64](a, b V) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func encodeCursor(c cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(token string) (cursor, error) {
+	var c cursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(data, &c)
+	return c, err
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package pagination
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	ID        string
+	Name      string
+	Price     float64
+	CreatedAt time.Time
+}
+
+var testSorter = Sorter[testItem]{
+	Fields: map[string]SortKey[testItem]{
+		"name":      func(i testItem) interface{} { return i.Name },
+		"price":     func(i testItem) interface{} { return i.Price },
+		"createdAt": func(i testItem) interface{} { return i.CreatedAt },
+	},
+	ID: func(i testItem) string { return i.ID },
+}
+
+var testOptions = Options{
+	DefaultLimit: 2,
+	MaxLimit:     10,
+	SortFields:   []string{"name", "price", "createdAt"},
+	DefaultSort:  "name",
+	FilterFields: []string{"status"},
+}
+
+func parse(t *testing.T, query string) PageParams {
+	t.Helper()
+	params, err := Parse(httptest.NewRequest("GET", "/items?"+query, nil), testOptions)
+	require.NoError(t, err)
+	return params
+}
+
+func ids(items []testItem) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.ID)
+	}
+	return result
+}
+
+func TestParseDefaultsAndFilters(t *testing.T) {
+	params := parse(t, "status=TRADING&other=x")
+
+	assert.Equal(t, 2, params.Limit)
+	assert.Equal(t, "name", params.SortField)
+	assert.Equal(t, Asc, params.SortDir)
+	assert.Equal(t, map[string]string{"status": "TRADING"}, params.Filters)
+}
+
+func TestParseCapsLimit(t *testing.T) {
+	assert.Equal(t, 10, parse(t, "limit=1000").Limit)
+}
+
+func TestParseRejectsInvalidParameters(t *testing.T) {
+	_, err := Parse(httptest.NewRequest("GET", "/items?sort=password&order=sideways&limit=0&offset=1&cursor=abc", nil), testOptions)
+	require.Error(t, err)
+
+	appErr := apperror.Resolve(err)
+	assert.Equal(t, "VALIDATION_ERROR", appErr.Code)
+	assert.Equal(t, map[string]string{
+		"sort":   "must be one of name, price, createdAt",
+		"order":  "must be asc or desc",
+		"limit":  "must be a positive integer",
+		"offset": "cannot be combined with cursor",
+	}, appErr.Details)
+}
+
+func TestPaginateWithOffset(t *testing.T) {
+	items := []testItem{{ID: "3", Name: "c"}, {ID: "1", Name: "a"}, {ID: "2", Name: "b"}}
+
+	page, err := testSorter.Paginate(items, parse(t, "offset=1&order=desc"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"2", "1"}, ids(page.Items))
+	assert.Equal(t, 3, page.Total)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestPaginateCursorIsStableAcrossInserts(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := make([]testItem, 0, 5)
+	for i := 0; i < 5; i++ {
+		items = append(items, testItem{ID: fmt.Sprintf("item-%d", i), CreatedAt: base.Add(time.Duration(i) * time.Minute)})
+	}
+
+	first, err := testSorter.Paginate(items, parse(t, "sort=createdAt&order=desc"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"item-4", "item-3"}, ids(first.Items))
+	require.NotEmpty(t, first.NextCursor)
+
+	// A newer item arrives and an item with the same timestamp as the cursor is added
+	items = append(items,
+		testItem{ID: "item-new", CreatedAt: base.Add(time.Hour)},
+		testItem{ID: "item-2b", CreatedAt: base.Add(2 * time.Minute)},
+	)
+
+	second, err := testSorter.Paginate(items, parse(t, "sort=createdAt&order=desc&cursor="+first.NextCursor))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"item-2b", "item-2"}, ids(second.Items))
+
+	third, err := testSorter.Paginate(items, parse(t, "sort=createdAt&order=desc&cursor="+second.NextCursor))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"item-1", "item-0"}, ids(third.Items))
+	assert.Empty(t, third.NextCursor)
+}
+
+func TestPaginateSortsNumbers(t *testing.T) {
+	items := []testItem{{ID: "a", Price: 10}, {ID: "b", Price: 9.5}, {ID: "c", Price: 100}}
+
+	page, err := testSorter.Paginate(items, parse(t, "sort=price&limit=3"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a", "c"}, ids(page.Items))
+}
+
+func TestPaginateRejectsCursorFromAnotherSort(t *testing.T) {
+	items := []testItem{{ID: "1", Name: "a", Price: 1}, {ID: "2", Name: "b", Price: 2}, {ID: "3", Name: "c", Price: 3}}
+
+	first, err := testSorter.Paginate(items, parse(t, "sort=name"))
+	require.NoError(t, err)
+
+	_, err = testSorter.Paginate(items, parse(t, "sort=price&cursor="+first.NextCursor))
+	assert.Equal(t, "VALIDATION_ERROR", apperror.Resolve(err).Code)
+}
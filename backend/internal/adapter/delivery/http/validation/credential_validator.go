@@ -185,6 +185,13 @@ func (v *CredentialValidator) HasErrors() bool {
 	return len(v.errors) > 0
 }
 
+// AddTo records the validator's problems in errs, keeping the first message per field
+func (v *CredentialValidator) AddTo(errs Errors) {
+	for _, err := range v.errors {
+		errs.Add(err.Field, err.Message)
+	}
+}
+
 // ToAppError converts validation errors to an AppError
 func (v *CredentialValidator) ToAppError() *apperror.AppError {
 	if !v.HasErrors() {
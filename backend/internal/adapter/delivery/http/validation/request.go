@@ -0,0 +1,201 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+)
+
+// tagName is the struct tag holding comma-separated validation rules, e.g.
+// `validate:"required,oneof=BUY SELL"`. Supported rules are required, gt, gte, lt, lte,
+// min, max and oneof. min and max compare lengths for strings and slices and values for
+// numbers. Rules other than required are skipped for zero values.
+const tagName = "validate"
+
+// Errors aggregates field-level validation problems keyed by JSON field name
+type Errors map[string]string
+
+// Add records a problem for field, keeping the first message reported for it
+func (e Errors) Add(field, message string) {
+	if _, exists := e[field]; !exists {
+		e[field] = message
+	}
+}
+
+// Error implements error, listing the problems sorted by field
+func (e Errors) Error() string {
+	fields := make([]string, 0, len(e))
+	for field := range e {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	problems := make([]string, 0, len(fields))
+	for _, field := range fields {
+		problems = append(problems, field+": "+e[field])
+	}
+	return "validation failed: " + strings.Join(problems, "; ")
+}
+
+// FieldErrors implements apperror.FieldErrorer
+func (e Errors) FieldErrors() map[string]string {
+	return e
+}
+
+// RuleValidator is implemented by requests with rules spanning several fields, such as
+// a price that is only required for limit orders. ValidateRules runs after the tag rules.
+type RuleValidator interface {
+	ValidateRules(errs Errors)
+}
+
+// DecodeAndValidate decodes the JSON request body into dst and validates it. A malformed
+// body yields an INVALID_INPUT error; otherwise every field problem is returned at once
+// as Errors.
+func DecodeAndValidate(r *http.Request, dst interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		return apperror.NewInvalid("Invalid request body", nil, err)
+	}
+	return Struct(dst)
+}
+
+// Struct validates the tagged fields of the struct v points to and then applies its
+// RuleValidator rules. It returns nil when v is valid.
+func Struct(v interface{}) error {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("validation target must be a struct, got %s", value.Kind())
+	}
+
+	errs := Errors{}
+	structType := value.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag := field.Tag.Get(tagName)
+		if tag == "" || !field.IsExported() {
+			continue
+		}
+		if message := checkField(value.Field(i), tag); message != "" {
+			errs.Add(fieldName(field), message)
+		}
+	}
+
+	if rules, ok := v.(RuleValidator); ok {
+		rules.ValidateRules(errs)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// fieldName returns the JSON name of a struct field
+func fieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// checkField applies the rules in tag to value and returns the first failure message
+func checkField(value reflect.Value, tag string) string {
+	rules := strings.Split(tag, ",")
+	if isZero(value) {
+		for _, rule := range rules {
+			if rule == "required" {
+				return "is required"
+			}
+		}
+		return ""
+	}
+
+	for _, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		var message string
+		switch name {
+		case "required":
+		case "oneof":
+			message = checkOneOf(value, param)
+		case "gt", "gte", "lt", "lte", "min", "max":
+			message = checkBound(value, name, param)
+		default:
+			message = fmt.Sprintf("has unknown validation rule %q", name)
+		}
+		if message != "" {
+			return message
+		}
+	}
+	return ""
+}
+
+// isZero reports whether value is empty; strings containing only spaces count as empty
+func isZero(value reflect.Value) bool {
+	if value.Kind() == reflect.String {
+		return strings.TrimSpace(value.String()) == ""
+	}
+	return value.IsZero()
+}
+
+// checkOneOf requires a string value to equal one of the space-separated options
+func checkOneOf(value reflect.Value, options string) string {
+	if value.Kind() != reflect.String {
+		return "must be a string"
+	}
+	for _, option := range strings.Fields(options) {
+		if value.String() == option {
+			return ""
+		}
+	}
+	return "must be one of " + strings.Join(strings.Fields(options), ", ")
+}
+
+// checkBound compares a number, or the length of a string or slice, against param
+func checkBound(value reflect.Value, rule, param string) string {
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return fmt.Sprintf("has invalid %s bound %q", rule, param)
+	}
+
+	var actual float64
+	subject := "must be"
+	switch value.Kind() {
+	case reflect.String:
+		actual = float64(len([]rune(value.String())))
+		subject = "length must be"
+	case reflect.Slice, reflect.Map, reflect.Array:
+		actual = float64(value.Len())
+		subject = "length must be"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		actual = float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		actual = float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		actual = value.Float()
+	default:
+		return fmt.Sprintf("does not support the %s rule", rule)
+	}
+
+	var ok bool
+	var relation string
+	switch rule {
+	case "gt":
+		ok, relation = actual > limit, "greater than"
+	case "gte", "min":
+		ok, relation = actual >= limit, "at least"
+	case "lt":
+		ok, relation = actual < limit, "less than"
+	case "lte", "max":
+		ok, relation = actual <= limit, "at most"
+	}
+	if ok {
+		return ""
+	}
+	return fmt.Sprintf("%s %s %s", subject, relation, param)
+}
@@ -0,0 +1,73 @@
+package validation
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testOrderRequest struct {
+	Symbol   string   `json:"symbol" validate:"required,max=12"`
+	Side     string   `json:"side" validate:"required,oneof=BUY SELL"`
+	Type     string   `json:"type" validate:"required,oneof=LIMIT MARKET"`
+	Quantity float64  `json:"quantity" validate:"required,gt=0"`
+	Price    float64  `json:"price" validate:"gte=0"`
+	Tags     []string `json:"tags" validate:"max=2"`
+	Note     string   `json:"note"`
+}
+
+func (req *testOrderRequest) ValidateRules(errs Errors) {
+	if req.Type == "LIMIT" && req.Price <= 0 {
+		errs.Add("price", "must be greater than 0 when type is LIMIT")
+	}
+}
+
+func TestDecodeAndValidateAggregatesViolations(t *testing.T) {
+	body := `{"symbol":"","side":"HOLD","type":"LIMIT","quantity":-1,"tags":["a","b","c"]}`
+	r := httptest.NewRequest("POST", "/orders", strings.NewReader(body))
+
+	var req testOrderRequest
+	err := DecodeAndValidate(r, &req)
+	require.Error(t, err)
+
+	var errs Errors
+	require.ErrorAs(t, err, &errs)
+	assert.Equal(t, Errors{
+		"symbol":   "is required",
+		"side":     "must be one of BUY, SELL",
+		"quantity": "must be greater than 0",
+		"price":    "must be greater than 0 when type is LIMIT",
+		"tags":     "length must be at most 2",
+	}, errs)
+
+	appErr := apperror.Resolve(err)
+	assert.Equal(t, "VALIDATION_ERROR", appErr.Code)
+	assert.Equal(t, map[string]string(errs), appErr.Details)
+}
+
+func TestDecodeAndValidateAcceptsValidRequest(t *testing.T) {
+	body := `{"symbol":"BTCUSDT","side":"BUY","type":"MARKET","quantity":0.5}`
+	r := httptest.NewRequest("POST", "/orders", strings.NewReader(body))
+
+	var req testOrderRequest
+	require.NoError(t, DecodeAndValidate(r, &req))
+	assert.Equal(t, "BTCUSDT", req.Symbol)
+}
+
+func TestDecodeAndValidateRejectsMalformedBody(t *testing.T) {
+	r := httptest.NewRequest("POST", "/orders", strings.NewReader(`{"symbol":`))
+
+	var req testOrderRequest
+	err := DecodeAndValidate(r, &req)
+	assert.True(t, apperror.IsInvalid(err))
+}
+
+func TestStructChecksStringLength(t *testing.T) {
+	err := Struct(&testOrderRequest{Symbol: "ABCDEFGHIJKLMN", Side: "SELL", Type: "MARKET", Quantity: 1})
+
+	assert.Equal(t, Errors{"symbol": "length must be at most 12"}, err)
+}
@@ -0,0 +1,132 @@
+package delivery
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// Hub fans messages out to the subscribers of named topics, such as the clients of an SSE
+// or WebSocket stream. Every subscriber has a bounded buffer. A subscriber whose buffer is
+// full when a message arrives is dropped and its channel closed, so a slow consumer never
+// blocks the producer or the other subscribers.
+type Hub[T any] struct {
+	buffer int
+	logger zerolog.Logger
+
+	mu     sync.Mutex
+	topics map[string]map[*HubSubscription[T]]struct{}
+}
+
+// HubSubscription receives the messages published to its topics on C until it is
+// unsubscribed or dropped, after which C is closed
+type HubSubscription[T any] struct {
+	hub    *Hub[T]
+	topics []string
+	ch     chan T
+
+	// closed and dropped are guarded by the hub's mutex
+	closed  bool
+	dropped bool
+}
+
+// NewHub creates a Hub whose subscribers buffer up to buffer messages each
+func NewHub[T any](buffer int, logger zerolog.Logger) *Hub[T] {
+	if buffer <= 0 {
+		buffer = 1
+	}
+	return &Hub[T]{
+		buffer: buffer,
+		logger: logger.With().Str("component", "Hub").Logger(),
+		topics: make(map[string]map[*HubSubscription[T]]struct{}),
+	}
+}
+
+// Subscribe starts receiving the messages published to any of topics
+func (h *Hub[T]) Subscribe(topics ...string) *HubSubscription[T] {
+	sub := &HubSubscription[T]{
+		hub:    h,
+		topics: append([]string(nil), topics...),
+		ch:     make(chan T, h.buffer),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, topic := range sub.topics {
+		subscribers, ok := h.topics[topic]
+		if !ok {
+			subscribers = make(map[*HubSubscription[T]]struct{})
+			h.topics[topic] = subscribers
+		}
+		subscribers[sub] = struct{}{}
+	}
+	return sub
+}
+
+// Publish delivers msg to every subscriber of topic without blocking, dropping the
+// subscribers that cannot take it. It returns the number of subscribers reached.
+func (h *Hub[T]) Publish(topic string, msg T) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delivered := 0
+	for sub := range h.topics[topic] {
+		select {
+		case sub.ch <- msg:
+			delivered++
+		default:
+			h.logger.Warn().Str("topic", topic).Int("buffer", h.buffer).Msg("Dropping slow subscriber")
+			sub.dropped = true
+			h.remove(sub)
+		}
+	}
+	return delivered
+}
+
+// Topics returns the topics that have at least one subscriber, in order
+func (h *Hub[T]) Topics() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	topics := make([]string, 0, len(h.topics))
+	for topic := range h.topics {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// remove unregisters sub and closes its channel. The caller must hold h.mu.
+func (h *Hub[T]) remove(sub *HubSubscription[T]) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	for _, topic := range sub.topics {
+		delete(h.topics[topic], sub)
+		if len(h.topics[topic]) == 0 {
+			delete(h.topics, topic)
+		}
+	}
+	close(sub.ch)
+}
+
+// C returns the channel the subscription's messages arrive on
+func (s *HubSubscription[T]) C() <-chan T {
+	return s.ch
+}
+
+// Dropped reports whether the hub dropped the subscription because it fell behind
+func (s *HubSubscription[T]) Dropped() bool {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	return s.dropped
+}
+
+// Unsubscribe stops the subscription and closes C. It is safe to call more than once.
+func (s *HubSubscription[T]) Unsubscribe() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.remove(s)
+}
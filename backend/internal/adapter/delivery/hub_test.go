@@ -0,0 +1,91 @@
+package delivery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receive reads the next message of sub, failing the test if none arrives
+func receive[T any](t *testing.T, sub *HubSubscription[T]) T {
+	t.Helper()
+	select {
+	case msg, ok := <-sub.C():
+		require.True(t, ok, "subscription closed")
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("no message received")
+	}
+	var zero T
+	return zero
+}
+
+func TestHub_PublishReachesSubscribersOfTopic(t *testing.T) {
+	hub := NewHub[string](4, zerolog.Nop())
+	btc := hub.Subscribe("BTCUSDT")
+	both := hub.Subscribe("BTCUSDT", "ETHUSDT")
+
+	assert.Equal(t, 2, hub.Publish("BTCUSDT", "btc-1"))
+	assert.Equal(t, 1, hub.Publish("ETHUSDT", "eth-1"))
+	assert.Equal(t, 0, hub.Publish("DOGEUSDT", "doge-1"))
+
+	assert.Equal(t, "btc-1", receive(t, btc))
+	assert.Equal(t, "btc-1", receive(t, both))
+	assert.Equal(t, "eth-1", receive(t, both))
+	assert.Empty(t, btc.C(), "messages of other topics are not delivered")
+	assert.Equal(t, []string{"BTCUSDT", "ETHUSDT"}, hub.Topics())
+}
+
+func TestHub_UnsubscribeClosesChannelAndRemovesTopic(t *testing.T) {
+	hub := NewHub[string](4, zerolog.Nop())
+	sub := hub.Subscribe("orders:alice")
+
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+
+	_, ok := <-sub.C()
+	assert.False(t, ok, "the channel is closed")
+	assert.False(t, sub.Dropped())
+	assert.Empty(t, hub.Topics())
+	assert.Equal(t, 0, hub.Publish("orders:alice", "order-1"))
+}
+
+func TestHub_DropsSlowSubscriberWithoutBlockingOthers(t *testing.T) {
+	hub := NewHub[int](2, zerolog.Nop())
+	slow := hub.Subscribe("ticks")
+	fast := hub.Subscribe("ticks")
+
+	received := make(chan int, 10)
+	go func() {
+		for msg := range fast.C() {
+			received <- msg
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 1; i <= 5; i++ {
+			hub.Publish("ticks", i)
+			// Let the fast subscriber keep up
+			assert.Equal(t, i, <-received)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publishing blocked on the slow subscriber")
+	}
+
+	assert.True(t, slow.Dropped())
+	var buffered []int
+	for msg := range slow.C() {
+		buffered = append(buffered, msg)
+	}
+	assert.Equal(t, []int{1, 2}, buffered, "the slow subscriber keeps what it buffered, then its channel closes")
+	assert.False(t, fast.Dropped())
+	fast.Unsubscribe()
+}
@@ -3,6 +3,7 @@ package ai
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -46,6 +47,27 @@ func (s *StubAIService) ChatWithHistory(ctx context.Context, messages []model.AI
 	}, nil
 }
 
+// ChatWithHistoryStream streams the stub response one word at a time
+func (s *StubAIService) ChatWithHistoryStream(ctx context.Context, messages []model.AIMessage, tradingContext map[string]interface{}, onDelta func(delta string) error) (*model.AIMessage, error) {
+	message, err := s.ChatWithHistory(ctx, messages, tradingContext)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, word := range strings.Fields(message.Content) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			word = " " + word
+		}
+		if err := onDelta(word); err != nil {
+			return nil, err
+		}
+	}
+	return message, nil
+}
+
 // GenerateInsight generates an insight based on provided data
 func (s *StubAIService) GenerateInsight(ctx context.Context, insightType string, data map[string]interface{}) (*model.AIInsight, error) {
 	return &model.AIInsight{
@@ -0,0 +1,93 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+)
+
+var (
+	// ErrExchangeNotFound is returned for an exchange that is not registered
+	ErrExchangeNotFound = errors.New("exchange not registered")
+	// ErrTradingNotSupported is returned when trading through a market-data-only exchange
+	ErrTradingNotSupported = errors.New("exchange does not support trading")
+)
+
+// Registry holds the exchange clients keyed by lower-case exchange name
+type Registry struct {
+	mu         sync.RWMutex
+	clients    map[string]port.ExchangeClient
+	marketData map[string]port.ExchangeMarketData
+}
+
+// Ensure Registry implements port.ExchangeRegistry
+var _ port.ExchangeRegistry = (*Registry)(nil)
+
+// NewRegistry creates an empty exchange registry
+func NewRegistry() *Registry {
+	return &Registry{
+		clients:    make(map[string]port.ExchangeClient),
+		marketData: make(map[string]port.ExchangeMarketData),
+	}
+}
+
+// Register adds an exchange that supports market data and trading, replacing any
+// exchange registered under the same name
+func (r *Registry) Register(name string, client port.ExchangeClient) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := strings.ToLower(name)
+	r.clients[key] = client
+	r.marketData[key] = client
+}
+
+// RegisterMarketData adds an exchange that only serves market data, replacing any
+// exchange registered under the same name
+func (r *Registry) RegisterMarketData(name string, client port.ExchangeMarketData) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := strings.ToLower(name)
+	delete(r.clients, key)
+	r.marketData[key] = client
+}
+
+// Client returns the trading client of an exchange
+func (r *Registry) Client(name string) (port.ExchangeClient, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	key := strings.ToLower(name)
+	if client, ok := r.clients[key]; ok {
+		return client, nil
+	}
+	if _, ok := r.marketData[key]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrTradingNotSupported, name)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrExchangeNotFound, name)
+}
+
+// MarketData returns the market data client of an exchange
+func (r *Registry) MarketData(name string) (port.ExchangeMarketData, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	client, ok := r.marketData[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrExchangeNotFound, name)
+	}
+	return client, nil
+}
+
+// Names lists the registered exchanges in alphabetical order
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.marketData))
+	for name := range r.marketData {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,52 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubClient is an exchange client that is never called
+type stubClient struct {
+	port.ExchangeClient
+	name string
+}
+
+// stubMarketData is a market-data-only exchange client that is never called
+type stubMarketData struct {
+	port.ExchangeMarketData
+}
+
+func TestRegistry_LooksUpClientsByName(t *testing.T) {
+	registry := NewRegistry()
+	mexc := &stubClient{name: "mexc"}
+	registry.Register("MEXC", mexc)
+
+	client, err := registry.Client("mexc")
+	require.NoError(t, err)
+	assert.Same(t, mexc, client)
+
+	marketData, err := registry.MarketData("Mexc")
+	require.NoError(t, err)
+	assert.Same(t, mexc, marketData)
+
+	_, err = registry.Client("kraken")
+	assert.ErrorIs(t, err, ErrExchangeNotFound)
+	_, err = registry.MarketData("kraken")
+	assert.ErrorIs(t, err, ErrExchangeNotFound)
+}
+
+func TestRegistry_MarketDataOnlyExchangeCannotTrade(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("mexc", &stubClient{name: "mexc"})
+	registry.RegisterMarketData("binance", &stubMarketData{})
+
+	_, err := registry.MarketData("binance")
+	require.NoError(t, err)
+	_, err = registry.Client("binance")
+	assert.ErrorIs(t, err, ErrTradingNotSupported)
+
+	assert.Equal(t, []string{"binance", "mexc"}, registry.Names())
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/logger"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// CorrelationIDMiddleware puts the request id and a logger carrying it into the request
+// context, so handler and service logs can be correlated with a request through
+// logger.FromContext. The id assigned by chi's RequestID middleware is used when present,
+// then the incoming X-Request-ID header, and a new UUID otherwise. The id is echoed in
+// the X-Request-ID response header.
+func CorrelationIDMiddleware(base *zerolog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := chimiddleware.GetReqID(r.Context())
+			if requestID == "" {
+				requestID = r.Header.Get(chimiddleware.RequestIDHeader)
+			}
+			if requestID == "" {
+				requestID = uuid.NewString()
+			}
+			w.Header().Set(chimiddleware.RequestIDHeader, requestID)
+
+			requestLogger := base.With().Str(logger.RequestIDField, requestID).Logger()
+			ctx := logger.WithRequestID(r.Context(), requestID)
+			ctx = logger.WithContext(ctx, &requestLogger)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
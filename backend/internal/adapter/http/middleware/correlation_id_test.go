@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/logger"
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorrelationIDMiddleware_HandlerLogsIncomingRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	base := zerolog.New(&buf)
+
+	r := chi.NewRouter()
+	r.Use(chimiddleware.RequestID)
+	r.Use(CorrelationIDMiddleware(&base))
+	r.Get("/orders", func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).Info().Msg("Listing orders")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	req.Header.Set("X-Request-Id", "req-12345")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, buf.String(), `"request_id":"req-12345"`)
+	assert.Contains(t, buf.String(), "Listing orders")
+	assert.Equal(t, "req-12345", rec.Header().Get("X-Request-Id"))
+}
+
+func TestCorrelationIDMiddleware_DownstreamLoggerIsEnriched(t *testing.T) {
+	var base, component bytes.Buffer
+	baseLogger := zerolog.New(&base)
+	componentLogger := zerolog.New(&component)
+
+	handler := CorrelationIDMiddleware(&baseLogger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.Enrich(r.Context(), &componentLogger).Info().Msg("Calling MEXC")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Request-Id", "req-abc")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Contains(t, component.String(), `"request_id":"req-abc"`)
+}
+
+func TestCorrelationIDMiddleware_GeneratesMissingRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	base := zerolog.New(&buf)
+
+	var seen string
+	handler := CorrelationIDMiddleware(&base)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = logger.RequestIDFromContext(r.Context())
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.NotEmpty(t, seen)
+	assert.Equal(t, seen, rec.Header().Get("X-Request-Id"))
+}
@@ -1,59 +1,52 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
+	"github.com/go-chi/cors"
 	"github.com/rs/zerolog"
 )
 
-// CORSMiddleware creates a middleware that handles CORS
-func CORSMiddleware(cfg *config.Config, logger *zerolog.Logger) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Get allowed origins from config or use default
-			allowedOrigins := cfg.Server.CORSAllowedOrigins
-			if len(allowedOrigins) == 0 {
-				// Default to allow all in development
-				if cfg.ENV == "development" {
-					allowedOrigins = []string{"*"}
-				} else {
-					// In production, default to the frontend URL if configured
-					if cfg.Server.FrontendURL != "" {
-						allowedOrigins = []string{cfg.Server.FrontendURL}
-					}
-				}
-			}
-
-			// Get the origin from the request
-			origin := r.Header.Get("Origin")
-
-			// Check if the origin is allowed
-			allowed := false
-			for _, allowedOrigin := range allowedOrigins {
-				if allowedOrigin == "*" || allowedOrigin == origin {
-					allowed = true
-					break
-				}
-			}
-
-			// Set CORS headers if origin is allowed
-			if allowed {
-				w.Header().Set("Access-Control-Allow-Origin", origin)
-				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Clerk-Auth-Token")
-				w.Header().Set("Access-Control-Allow-Credentials", "true")
-				w.Header().Set("Access-Control-Max-Age", "86400") // 24 hours
-			}
-
-			// Handle preflight requests
-			if r.Method == http.MethodOptions {
-				w.WriteHeader(http.StatusOK)
-				return
-			}
+// ErrInsecureCORS is returned for a CORS configuration that allows credentials from any origin
+var ErrInsecureCORS = errors.New("CORS must not allow credentials from the * origin")
+
+// NewCORSHandler builds the chi CORS middleware from settings. Requests from origins that
+// are not allowed get no CORS headers, so browsers block them. Without any allowed
+// origin every cross-origin request is blocked.
+func NewCORSHandler(settings config.CORSConfig) (func(http.Handler) http.Handler, error) {
+	options := cors.Options{
+		AllowedOrigins:   settings.AllowedOrigins,
+		AllowedMethods:   settings.AllowedMethods,
+		AllowedHeaders:   settings.AllowedHeaders,
+		ExposedHeaders:   settings.ExposedHeaders,
+		AllowCredentials: settings.AllowCredentials,
+		MaxAge:           int(settings.MaxAge.Seconds()),
+	}
+	for _, origin := range settings.AllowedOrigins {
+		if origin == "*" && settings.AllowCredentials {
+			return nil, ErrInsecureCORS
+		}
+	}
+	if len(settings.AllowedOrigins) == 0 {
+		// The chi middleware treats an empty list as allowing every origin
+		options.AllowOriginFunc = func(r *http.Request, origin string) bool { return false }
+	}
+	return cors.Handler(options), nil
+}
 
-			// Call the next handler
-			next.ServeHTTP(w, r)
-		})
+// CORSMiddleware creates a middleware that handles CORS as configured. Configuration
+// validation rejects an insecure combination at startup; should one get here anyway,
+// credentials are not allowed.
+func CORSMiddleware(cfg *config.Config, logger *zerolog.Logger) func(http.Handler) http.Handler {
+	settings := cfg.ResolvedCORS()
+	handler, err := NewCORSHandler(settings)
+	if err != nil {
+		logger.Error().Err(err).Strs("origins", settings.AllowedOrigins).Msg("Insecure CORS configuration, disabling credentials")
+		settings.AllowCredentials = false
+		handler, _ = NewCORSHandler(settings)
 	}
+	logger.Info().Strs("origins", settings.AllowedOrigins).Bool("credentials", settings.AllowCredentials).Msg("CORS configured")
+	return handler
 }
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// corsTestHandler wraps an OK handler in the CORS middleware of cfg
+func corsTestHandler(cfg *config.Config) http.Handler {
+	logger := zerolog.Nop()
+	return CORSMiddleware(cfg, &logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func corsRequest(handler http.Handler, method, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/v1/market/tickers", nil)
+	req.Header.Set("Origin", origin)
+	if method == http.MethodOptions {
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCORSMiddleware_AllowsConfiguredOrigin(t *testing.T) {
+	cfg := &config.Config{CORS: config.GetDefaultCORSConfig()}
+	cfg.CORS.AllowedOrigins = []string{"https://app.example.com"}
+	handler := corsTestHandler(cfg)
+
+	rec := corsRequest(handler, http.MethodGet, "https://app.example.com")
+	assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+
+	rec = corsRequest(handler, http.MethodOptions, "https://app.example.com")
+	assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "86400", rec.Header().Get("Access-Control-Max-Age"))
+}
+
+func TestCORSMiddleware_BlocksOtherOrigins(t *testing.T) {
+	cfg := &config.Config{CORS: config.GetDefaultCORSConfig()}
+	cfg.CORS.AllowedOrigins = []string{"https://app.example.com"}
+	handler := corsTestHandler(cfg)
+
+	for _, method := range []string{http.MethodGet, http.MethodOptions} {
+		rec := corsRequest(handler, method, "https://evil.example.com")
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"), method)
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"), method)
+	}
+}
+
+func TestCORSMiddleware_DefaultsToFrontendURL(t *testing.T) {
+	cfg := &config.Config{CORS: config.GetDefaultCORSConfig()}
+	cfg.Server.FrontendURL = "https://frontend.example.com"
+	handler := corsTestHandler(cfg)
+
+	rec := corsRequest(handler, http.MethodGet, "https://frontend.example.com")
+	assert.Equal(t, "https://frontend.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	rec = corsRequest(handler, http.MethodGet, "https://other.example.com")
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestNewCORSHandler_RejectsWildcardWithCredentials(t *testing.T) {
+	settings := config.GetDefaultCORSConfig()
+	settings.AllowedOrigins = []string{"*"}
+	_, err := NewCORSHandler(settings)
+	assert.ErrorIs(t, err, ErrInsecureCORS)
+
+	settings.AllowCredentials = false
+	_, err = NewCORSHandler(settings)
+	require.NoError(t, err)
+}
+
+func TestNewCORSHandler_NoOriginsBlocksEverything(t *testing.T) {
+	handler, err := NewCORSHandler(config.GetDefaultCORSConfig())
+	require.NoError(t, err)
+	wrapped := handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rec := corsRequest(wrapped, http.MethodGet, "https://app.example.com")
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
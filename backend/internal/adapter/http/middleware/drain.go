@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/rs/zerolog"
+)
+
+// errShuttingDown is returned to requests that arrive while the server drains
+var errShuttingDown = &apperror.AppError{
+	StatusCode: http.StatusServiceUnavailable,
+	Code:       "SHUTTING_DOWN",
+	Message:    "Server is shutting down",
+}
+
+// RequestDrainer tracks in-flight requests so shutdown can wait for them. Once draining
+// begins, new requests are rejected with 503 while the in-flight ones complete.
+type RequestDrainer struct {
+	logger *zerolog.Logger
+
+	mu       sync.Mutex
+	inFlight int
+	draining bool
+	// idle is closed once draining has begun and no request is in flight
+	idle chan struct{}
+}
+
+// NewRequestDrainer creates a RequestDrainer
+func NewRequestDrainer(logger *zerolog.Logger) *RequestDrainer {
+	return &RequestDrainer{logger: logger}
+}
+
+// Middleware counts requests in flight and rejects new ones while draining
+func (d *RequestDrainer) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		d.mu.Lock()
+		if d.draining {
+			d.mu.Unlock()
+			w.Header().Set("Connection", "close")
+			w.Header().Set("Retry-After", "5")
+			apperror.WriteError(w, errShuttingDown)
+			return
+		}
+		d.inFlight++
+		d.mu.Unlock()
+
+		defer d.done()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// InFlight returns the number of requests being served
+func (d *RequestDrainer) InFlight() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.inFlight
+}
+
+// Drain stops admitting requests and waits until the in-flight ones complete or ctx ends,
+// logging how many were drained. It returns ctx's error when requests were still running.
+func (d *RequestDrainer) Drain(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.draining {
+		d.draining = true
+		d.idle = make(chan struct{})
+		if d.inFlight == 0 {
+			close(d.idle)
+		}
+	}
+	pending, idle := d.inFlight, d.idle
+	d.mu.Unlock()
+
+	d.logger.Info().Int("in_flight", pending).Msg("Draining in-flight requests")
+	select {
+	case <-idle:
+		d.logger.Info().Int("drained", pending).Msg("All in-flight requests completed")
+		return nil
+	case <-ctx.Done():
+		remaining := d.InFlight()
+		d.logger.Warn().Int("drained", pending-remaining).Int("abandoned", remaining).Msg("Timed out draining in-flight requests")
+		return ctx.Err()
+	}
+}
+
+// done records that a request has completed
+func (d *RequestDrainer) done() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.inFlight--
+	if d.draining && d.inFlight == 0 {
+		close(d.idle)
+	}
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestDrainer_SlowRequestCompletesWhileNewOnesAreRejected(t *testing.T) {
+	logger := zerolog.Nop()
+	drainer := NewRequestDrainer(&logger)
+
+	release := make(chan struct{})
+	server := httptest.NewServer(drainer.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			<-release
+		}
+		w.Write([]byte("done"))
+	})))
+	defer server.Close()
+
+	// Start the slow request and wait until it is in flight
+	slow := make(chan *http.Response, 1)
+	go func() {
+		resp, err := http.Get(server.URL + "/slow")
+		if assert.NoError(t, err) {
+			slow <- resp
+		}
+	}()
+	require.Eventually(t, func() bool { return drainer.InFlight() == 1 }, time.Second, time.Millisecond)
+
+	// Trigger shutdown
+	drained := make(chan error, 1)
+	go func() { drained <- drainer.Drain(context.Background()) }()
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(server.URL + "/fast")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusServiceUnavailable
+	}, time.Second, time.Millisecond, "new requests are rejected while draining")
+
+	select {
+	case <-drained:
+		t.Fatal("drain finished while a request was in flight")
+	default:
+	}
+
+	close(release)
+	resp := <-slow
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "done", string(body))
+	assert.NoError(t, <-drained)
+}
+
+func TestRequestDrainer_DrainTimesOut(t *testing.T) {
+	logger := zerolog.Nop()
+	drainer := NewRequestDrainer(&logger)
+	release := make(chan struct{})
+	defer close(release)
+
+	handler := drainer.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { <-release }))
+	go handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/stuck", nil))
+	require.Eventually(t, func() bool { return drainer.InFlight() == 1 }, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, drainer.Drain(ctx), context.DeadlineExceeded)
+}
+
+func TestRequestDrainer_DrainWithNothingInFlight(t *testing.T) {
+	logger := zerolog.Nop()
+	drainer := NewRequestDrainer(&logger)
+	require.NoError(t, drainer.Drain(context.Background()))
+
+	rec := httptest.NewRecorder()
+	drainer.Middleware(http.NotFoundHandler()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), "SHUTTING_DOWN")
+}
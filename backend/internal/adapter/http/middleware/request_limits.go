@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+var (
+	// errBodyTooLarge is returned for a request body over the route's limit
+	errBodyTooLarge = &apperror.AppError{
+		StatusCode: http.StatusRequestEntityTooLarge,
+		Code:       "PAYLOAD_TOO_LARGE",
+		Message:    "Request body is too large",
+	}
+	// errBodyTimeout is returned when the client did not send the body in time
+	errBodyTimeout = &apperror.AppError{
+		StatusCode: http.StatusRequestTimeout,
+		Code:       "REQUEST_TIMEOUT",
+		Message:    "Request body was not received in time",
+	}
+)
+
+// RequestLimits enforces the limits of a route group. The body is read up front, at most
+// MaxBodyBytes of it, so an oversized body is rejected with 413 before the handler runs
+// and a handler never holds more than the limit in memory. ReadTimeout bounds reading the
+// body and HandlerTimeout is applied with chi's Timeout middleware. Applied to nested
+// groups, the tightest limits win.
+func RequestLimits(limits config.RouteLimits) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limits.HandlerTimeout > 0 {
+			next = chimiddleware.Timeout(limits.HandlerTimeout)(next)
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if limits.MaxBodyBytes > 0 {
+				if r.ContentLength > limits.MaxBodyBytes {
+					w.Header().Set("Connection", "close")
+					apperror.WriteError(w, errBodyTooLarge)
+					return
+				}
+				if err := bufferBody(w, r, limits); err != nil {
+					apperror.WriteError(w, err)
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// bufferBody replaces the request body with an in-memory copy of at most MaxBodyBytes
+func bufferBody(w http.ResponseWriter, r *http.Request, limits config.RouteLimits) error {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	if limits.ReadTimeout > 0 {
+		// Writers that do not support deadlines leave the server's read timeout in charge
+		controller := http.NewResponseController(w)
+		if controller.SetReadDeadline(time.Now().Add(limits.ReadTimeout)) == nil {
+			defer controller.SetReadDeadline(time.Time{})
+		}
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limits.MaxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			return errBodyTooLarge
+		case errors.Is(err, os.ErrDeadlineExceeded):
+			return errBodyTimeout
+		default:
+			return apperror.NewInvalid("Failed to read request body", nil, err)
+		}
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return nil
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoHandler writes back the request body
+var echoHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Write(body)
+})
+
+func TestRequestLimits_AcceptsBodyWithinLimit(t *testing.T) {
+	handler := RequestLimits(config.RouteLimits{MaxBodyBytes: 16})(echoHandler)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"qty":1}`)))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"qty":1}`, rec.Body.String())
+}
+
+func TestRequestLimits_RejectsOversizedBody(t *testing.T) {
+	called := false
+	handler := RequestLimits(config.RouteLimits{MaxBodyBytes: 16})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(strings.Repeat("x", 17))))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Contains(t, rec.Body.String(), "PAYLOAD_TOO_LARGE")
+
+	// A body without a declared length is cut off at the limit too
+	req := httptest.NewRequest(http.MethodPost, "/orders", io.MultiReader(strings.NewReader(strings.Repeat("x", 64))))
+	req.ContentLength = -1
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.False(t, called)
+}
+
+func TestRequestLimits_NestedGroupsApplyTightestLimit(t *testing.T) {
+	handler := RequestLimits(config.RouteLimits{MaxBodyBytes: 1 << 20})(
+		RequestLimits(config.RouteLimits{MaxBodyBytes: 8})(echoHandler))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader("123456789")))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestRequestLimits_SlowBodyTimesOut(t *testing.T) {
+	server := httptest.NewServer(RequestLimits(config.RouteLimits{MaxBodyBytes: 1024, ReadTimeout: 50 * time.Millisecond})(echoHandler))
+	defer server.Close()
+
+	// The client sends part of the body and then stalls
+	body, writer := io.Pipe()
+	defer writer.Close()
+	go writer.Write([]byte("partial"))
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, body)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusRequestTimeout, resp.StatusCode)
+}
+
+func TestRequestLimits_HandlerTimeout(t *testing.T) {
+	handler := RequestLimits(config.RouteLimits{HandlerTimeout: 20 * time.Millisecond})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		assert.ErrorIs(t, r.Context().Err(), context.DeadlineExceeded)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/logs", nil))
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+}
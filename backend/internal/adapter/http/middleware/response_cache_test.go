@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bodyHandler serves whatever body currently points to
+func bodyHandler(body *string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(*body))
+	})
+}
+
+func getWithETag(handler http.Handler, etag string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/market/symbols", nil)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestResponseCache_NotModifiedForMatchingETag(t *testing.T) {
+	body := `{"symbols":["BTCUSDT"]}`
+	handler := ResponseCache(time.Minute)(bodyHandler(&body))
+
+	first := getWithETag(handler, "")
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, body, first.Body.String())
+	assert.Equal(t, "public, max-age=60", first.Header().Get("Cache-Control"))
+	assert.Equal(t, "application/json", first.Header().Get("Content-Type"))
+
+	repeated := getWithETag(handler, etag)
+	assert.Equal(t, http.StatusNotModified, repeated.Code)
+	assert.Empty(t, repeated.Body.String())
+	assert.Equal(t, etag, repeated.Header().Get("ETag"))
+	assert.Equal(t, "public, max-age=60", repeated.Header().Get("Cache-Control"))
+
+	// Weak and listed ETags match too
+	assert.Equal(t, http.StatusNotModified, getWithETag(handler, `"other", W/`+etag).Code)
+}
+
+func TestResponseCache_ChangedBodyGetsNewETag(t *testing.T) {
+	body := `{"symbols":["BTCUSDT"]}`
+	handler := ResponseCache(0)(bodyHandler(&body))
+
+	first := getWithETag(handler, "")
+	etag := first.Header().Get("ETag")
+	assert.Equal(t, "no-cache", first.Header().Get("Cache-Control"))
+
+	body = `{"symbols":["BTCUSDT","ETHUSDT"]}`
+	changed := getWithETag(handler, etag)
+	assert.Equal(t, http.StatusOK, changed.Code)
+	assert.Equal(t, body, changed.Body.String())
+	assert.NotEmpty(t, changed.Header().Get("ETag"))
+	assert.NotEqual(t, etag, changed.Header().Get("ETag"))
+}
+
+func TestResponseCache_PassesThroughErrorsAndWrites(t *testing.T) {
+	failing := ResponseCache(time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	rec := getWithETag(failing, "*")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), "unavailable")
+	assert.Empty(t, rec.Header().Get("ETag"))
+	assert.Empty(t, rec.Header().Get("Cache-Control"))
+
+	body := `{"ok":true}`
+	handler := ResponseCache(time.Minute)(bodyHandler(&body))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/market/symbols", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("ETag"))
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	gormadapter "github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/pkg/platform/mexc"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type tracedTicker struct {
+	Symbol string `gorm:"primaryKey"`
+	Price  float64
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) string {
+	for _, attr := range span.Attributes() {
+		if attr.Key == key {
+			return attr.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestTracing_RequestSpanParentsMEXCAndDBSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	exchange := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"symbol":"BTCUSDT","lastPrice":"65000"}`))
+	}))
+	defer exchange.Close()
+	logger := zerolog.Nop()
+	client := mexc.NewClientWithBaseURL("", "", exchange.URL, &logger)
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "tracing.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&tracedTicker{}))
+	require.NoError(t, gormadapter.RegisterTracing(db))
+
+	r := chi.NewRouter()
+	r.Use(Tracing())
+	r.Get("/tickers/{symbol}", func(w http.ResponseWriter, r *http.Request) {
+		ticker, err := client.GetMarketData(r.Context(), chi.URLParam(r, "symbol"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		db.WithContext(r.Context()).Create(&tracedTicker{Symbol: ticker.Symbol, Price: ticker.LastPrice})
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tickers/BTCUSDT", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	request, ok := spans["GET /tickers/{symbol}"]
+	require.True(t, ok, "request span named after the route")
+	assert.False(t, request.Parent().IsValid())
+
+	exchangeCall, ok := spans["mexc GET /api/v3/ticker/24hr"]
+	require.True(t, ok, "MEXC client span")
+	assert.Equal(t, request.SpanContext().SpanID(), exchangeCall.Parent().SpanID())
+	assert.Equal(t, "BTCUSDT", spanAttribute(exchangeCall, "symbol"))
+	assert.Equal(t, "/api/v3/ticker/24hr", spanAttribute(exchangeCall, "mexc.endpoint"))
+
+	insert, ok := spans["db.create"]
+	require.True(t, ok, "database span")
+	assert.Equal(t, request.SpanContext().SpanID(), insert.Parent().SpanID())
+	assert.Equal(t, "traced_tickers", spanAttribute(insert, "db.table"))
+	assert.Contains(t, spanAttribute(insert, "db.statement"), "INSERT INTO `traced_tickers`")
+}
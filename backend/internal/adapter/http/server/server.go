@@ -12,7 +12,6 @@ import (
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/factory"
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
 	"github.com/rs/zerolog"
 	"gorm.io/gorm"
 )
@@ -60,15 +59,8 @@ func (s *Server) SetupRoutes() error {
 	s.router.Use(chimiddleware.Recoverer)
 	s.router.Use(chimiddleware.Timeout(60 * time.Second))
 
-	// Set up CORS
-	s.router.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
-		AllowCredentials: true,
-		MaxAge:           300,
-	}))
+	// Set up CORS from configuration
+	s.router.Use(middleware.CORSMiddleware(s.config, s.logger))
 
 	// Set up authentication middleware
 	s.router.Use(authMiddleware.Middleware())
@@ -11,7 +11,6 @@ import (
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
 	"github.com/rs/zerolog"
 )
 
@@ -55,15 +54,8 @@ func (s *ExampleServer) SetupRoutes() error {
 	// Other middleware
 	s.router.Use(chimiddleware.Timeout(60 * time.Second))
 
-	// Set up CORS
-	s.router.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-Request-ID"},
-		ExposedHeaders:   []string{"Link", "X-Request-ID"},
-		AllowCredentials: true,
-		MaxAge:           300,
-	}))
+	// Set up CORS from configuration
+	s.router.Use(middleware.CORSMiddleware(s.config, s.logger))
 
 	// Register example routes
 	errorExampleController.RegisterRoutes(s.router)
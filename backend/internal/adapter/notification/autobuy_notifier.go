@@ -0,0 +1,28 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+)
+
+// AutobuyNotifier raises an alert for each buy of the new-listing autobuyer
+type AutobuyNotifier struct {
+	alerts *AlertNotifier
+	logger *zerolog.Logger
+}
+
+// NewAutobuyNotifier creates a new AutobuyNotifier
+func NewAutobuyNotifier(alerts *AlertNotifier, logger *zerolog.Logger) *AutobuyNotifier {
+	return &AutobuyNotifier{
+		alerts: alerts,
+		logger: logger,
+	}
+}
+
+// Notify raises an info alert with the message
+func (n *AutobuyNotifier) Notify(message string) {
+	if err := n.alerts.CreateAlert(context.Background(), AlertLevelInfo, "Autobuy", message, "autobuy"); err != nil {
+		n.logger.Error().Err(err).Msg("Failed to raise autobuy alert")
+	}
+}
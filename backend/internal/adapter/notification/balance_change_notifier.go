@@ -0,0 +1,61 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+)
+
+// Notification types used for detected balance changes
+const (
+	NotificationTypeDeposit    = "wallet_deposit"
+	NotificationTypeWithdrawal = "wallet_withdrawal"
+)
+
+// BalanceChangeNotifier stores user notifications for deposits and withdrawals detected during wallet syncs
+type BalanceChangeNotifier struct {
+	repo   port.NotificationRepository
+	logger *zerolog.Logger
+}
+
+// NewBalanceChangeNotifier creates a new BalanceChangeNotifier
+func NewBalanceChangeNotifier(repo port.NotificationRepository, logger *zerolog.Logger) *BalanceChangeNotifier {
+	return &BalanceChangeNotifier{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// NotifyBalanceChange sends a deposit or withdrawal notification to the wallet owner
+func (n *BalanceChangeNotifier) NotifyBalanceChange(ctx context.Context, tx *model.WalletTransaction) error {
+	notificationType := NotificationTypeDeposit
+	title := fmt.Sprintf("Deposit detected: %g %s", tx.Amount, tx.Asset)
+	if tx.Type == model.TransactionTypeWithdrawal {
+		notificationType = NotificationTypeWithdrawal
+		title = fmt.Sprintf("Withdrawal detected: %g %s", tx.Amount, tx.Asset)
+	}
+
+	message := fmt.Sprintf("%s balance changed from %g to %g", tx.Asset, tx.PreviousBalance, tx.NewBalance)
+
+	n.logger.Info().
+		Str("userID", tx.UserID).
+		Str("walletID", tx.WalletID).
+		Str("type", notificationType).
+		Str("asset", string(tx.Asset)).
+		Float64("amount", tx.Amount).
+		Msg("Wallet balance change detected")
+
+	return n.repo.SaveNotification(ctx, map[string]interface{}{
+		"user_id": tx.UserID,
+		"type":    notificationType,
+		"title":   title,
+		"message": message,
+		"data":    tx,
+	})
+}
+
+// Ensure BalanceChangeNotifier implements port.BalanceChangeNotifier
+var _ port.BalanceChangeNotifier = (*BalanceChangeNotifier)(nil)
@@ -0,0 +1,166 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+)
+
+// Notification types used for trades
+const (
+	NotificationTypeOrderPlaced    = "order_placed"
+	NotificationTypeOrderCanceled  = "order_canceled"
+	NotificationTypeOrderFailed    = "order_failed"
+	NotificationTypePositionOpened = "position_opened"
+	NotificationTypePositionClosed = "position_closed"
+)
+
+// TradeNotifier sends user notifications for orders placed, filled, canceled, and given up
+// after retries. A filled buy opens a position and a filled sell closes one.
+type TradeNotifier struct {
+	events        port.DomainEventBus
+	notifications *UserNotificationService
+	logger        *zerolog.Logger
+
+	mu           sync.Mutex
+	subscription port.Subscription
+}
+
+// NewTradeNotifier creates a new TradeNotifier
+func NewTradeNotifier(events port.DomainEventBus, notifications *UserNotificationService, logger *zerolog.Logger) *TradeNotifier {
+	return &TradeNotifier{
+		events:        events,
+		notifications: notifications,
+		logger:        logger,
+	}
+}
+
+// Start subscribes to the order events on the event bus
+func (n *TradeNotifier) Start(ctx context.Context) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.subscription != nil {
+		return fmt.Errorf("trade notifier is already running")
+	}
+
+	// Notifications are stored after the request that placed the order has finished
+	ctx = context.WithoutCancel(ctx)
+	n.subscription = n.events.SubscribeTopics(func(evt event.DomainEvent) {
+		if err := n.Notify(ctx, evt); err != nil {
+			n.logger.Error().Err(err).
+				Str("event_type", string(evt.Type())).
+				Str("order_id", evt.AggregateID()).
+				Msg("Failed to send trade notification")
+		}
+	}, event.OrderPlacedEvent, event.OrderFilledEvent, event.OrderCanceledEvent, event.OrderFailedEvent)
+
+	n.logger.Info().Msg("Trade notifier started")
+	return nil
+}
+
+// Stop unsubscribes from the event bus
+func (n *TradeNotifier) Stop() {
+	n.mu.Lock()
+	subscription := n.subscription
+	n.subscription = nil
+	n.mu.Unlock()
+
+	if subscription != nil {
+		subscription.Unsubscribe()
+		n.logger.Info().Msg("Trade notifier stopped")
+	}
+}
+
+// Notify sends the notification for an order event to the order's owner. Other events and
+// orders without an owner are ignored.
+func (n *TradeNotifier) Notify(ctx context.Context, evt event.DomainEvent) error {
+	var order *model.Order
+	switch e := evt.(type) {
+	case *event.OrderPlaced:
+		order = e.Order
+	case *event.OrderStatusChanged:
+		order = e.Order
+	case *event.OrderFailed:
+		return n.notifyFailed(ctx, e.Retry)
+	}
+	if order == nil || order.UserID == "" {
+		return nil
+	}
+
+	notificationType, title, message := formatTradeNotification(evt.Type(), order)
+	n.logger.Info().
+		Str("userID", order.UserID).
+		Str("orderID", order.OrderID).
+		Str("type", notificationType).
+		Msg("Trade notification")
+
+	return n.notifications.Send(ctx, UserNotification{
+		UserID:  order.UserID,
+		Type:    notificationType,
+		Title:   title,
+		Message: message,
+		Data:    order,
+	})
+}
+
+// notifyFailed tells the owner of a queued order that it could not be placed
+func (n *TradeNotifier) notifyFailed(ctx context.Context, retry *model.OrderRetry) error {
+	request := retry.Request
+	if request.UserID == "" {
+		return nil
+	}
+
+	details := fmt.Sprintf("%s %s %g %s", request.Side, request.Type, request.Quantity, request.Symbol)
+	if request.Price > 0 {
+		details += fmt.Sprintf(" at %g", request.Price)
+	}
+	n.logger.Info().
+		Str("userID", request.UserID).
+		Str("retryID", retry.ID).
+		Str("type", NotificationTypeOrderFailed).
+		Msg("Trade notification")
+
+	return n.notifications.Send(ctx, UserNotification{
+		UserID:  request.UserID,
+		Type:    NotificationTypeOrderFailed,
+		Title:   fmt.Sprintf("Order failed: %s", request.Symbol),
+		Message: fmt.Sprintf("Could not place %s after %d attempts: %s", details, retry.Attempts, retry.LastError),
+		Data:    retry,
+	})
+}
+
+// formatTradeNotification returns the notification type, title, and message for an order event
+func formatTradeNotification(eventType event.EventType, order *model.Order) (string, string, string) {
+	price := order.Price
+	if order.AvgFillPrice > 0 {
+		price = order.AvgFillPrice
+	}
+	details := fmt.Sprintf("%s %s %g %s", order.Side, order.Type, order.Quantity, order.Symbol)
+	if price > 0 {
+		details += fmt.Sprintf(" at %g", price)
+	}
+
+	switch {
+	case eventType == event.OrderCanceledEvent:
+		return NotificationTypeOrderCanceled,
+			fmt.Sprintf("Order canceled: %s", order.Symbol),
+			fmt.Sprintf("Canceled %s order %s", details, order.OrderID)
+	case eventType == event.OrderFilledEvent && order.Side == model.OrderSideSell:
+		return NotificationTypePositionClosed,
+			fmt.Sprintf("Position closed: %s", order.Symbol),
+			fmt.Sprintf("Filled %s", details)
+	case eventType == event.OrderFilledEvent:
+		return NotificationTypePositionOpened,
+			fmt.Sprintf("Position opened: %s", order.Symbol),
+			fmt.Sprintf("Filled %s", details)
+	default:
+		return NotificationTypeOrderPlaced,
+			fmt.Sprintf("Order placed: %s", order.Symbol),
+			fmt.Sprintf("Placed %s", details)
+	}
+}
@@ -0,0 +1,169 @@
+package notification
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/delivery"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/service"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fillingExchange fills every order as it is placed
+type fillingExchange struct {
+	port.ExchangeClient
+}
+
+func (e *fillingExchange) PlaceOrder(ctx context.Context, symbol string, side model.OrderSide, orderType model.OrderType, quantity float64, price float64, timeInForce model.TimeInForce) (*model.Order, error) {
+	return &model.Order{
+		OrderID:      "mexc-1",
+		Symbol:       symbol,
+		Side:         side,
+		Type:         orderType,
+		Status:       model.OrderStatusFilled,
+		Quantity:     quantity,
+		ExecutedQty:  quantity,
+		AvgFillPrice: 65000,
+	}, nil
+}
+
+// tradableSymbols knows every symbol, without trading filters
+type tradableSymbols struct {
+	port.SymbolRepository
+}
+
+func (s tradableSymbols) GetBySymbol(ctx context.Context, symbol string) (*market.Symbol, error) {
+	return &market.Symbol{Symbol: symbol}, nil
+}
+
+// acceptingOrders stores nothing
+type acceptingOrders struct {
+	port.OrderRepository
+}
+
+func (acceptingOrders) Create(ctx context.Context, order *model.Order) error { return nil }
+
+// savedNotifications records every saved notification
+type savedNotifications struct {
+	port.NotificationRepository
+	mu    sync.Mutex
+	saved []map[string]interface{}
+}
+
+func (s *savedNotifications) SaveNotification(ctx context.Context, notification map[string]interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saved = append(s.saved, notification)
+	return nil
+}
+
+// sentNotifications records every delivered notification title
+type sentNotifications struct {
+	mu     sync.Mutex
+	titles []string
+}
+
+func (s *sentNotifications) SendNotification(ctx context.Context, userID, title, message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.titles = append(s.titles, userID+": "+title)
+	return nil
+}
+
+func (s *savedNotifications) types() []interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	types := make([]interface{}, len(s.saved))
+	for i, notification := range s.saved {
+		types[i] = notification["type"]
+	}
+	return types
+}
+
+func TestTradeNotifier_PlacedOrderIsNotified(t *testing.T) {
+	logger := zerolog.Nop()
+	bus := delivery.NewInMemoryEventBus(logger)
+	notifications := &savedNotifications{}
+	sent := &sentNotifications{}
+	notifier := NewTradeNotifier(bus, NewUserNotificationService(notifications, &logger, sent), &logger)
+	require.NoError(t, notifier.Start(context.Background()))
+	defer notifier.Stop()
+
+	trades := service.NewMexcTradeService(&fillingExchange{}, nil, tradableSymbols{}, acceptingOrders{}, &logger).
+		WithEventPublisher(bus)
+	_, err := trades.PlaceOrder(context.Background(), &model.OrderRequest{
+		UserID:   "user-1",
+		Symbol:   "BTCUSDT",
+		Side:     model.OrderSideBuy,
+		Type:     model.OrderTypeMarket,
+		Quantity: 0.5,
+	})
+	require.NoError(t, err)
+
+	// The market order is announced as placed and, being filled at once, as a new position
+	require.Eventually(t, func() bool { return len(notifications.types()) == 2 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []interface{}{NotificationTypeOrderPlaced, NotificationTypePositionOpened}, notifications.types())
+
+	opened := notifications.saved[1]
+	assert.Equal(t, "user-1", opened["user_id"])
+	assert.Equal(t, "Position opened: BTCUSDT", opened["title"])
+	assert.Equal(t, "Filled BUY MARKET 0.5 BTCUSDT at 65000", opened["message"])
+
+	// Both were also delivered through the notification service
+	sent.mu.Lock()
+	defer sent.mu.Unlock()
+	assert.Equal(t, []string{"user-1: Order placed: BTCUSDT", "user-1: Position opened: BTCUSDT"}, sent.titles)
+}
+
+func TestTradeNotifier_FormatsOrderEvents(t *testing.T) {
+	logger := zerolog.Nop()
+	notifications := &savedNotifications{}
+	notifier := NewTradeNotifier(delivery.NewInMemoryEventBus(logger), NewUserNotificationService(notifications, &logger), &logger)
+	ctx := context.Background()
+
+	sell := &model.Order{OrderID: "mexc-2", UserID: "user-1", Symbol: "ETHUSDT", Side: model.OrderSideSell, Type: model.OrderTypeLimit, Quantity: 2, Price: 3000, Status: model.OrderStatusFilled}
+	require.NoError(t, notifier.Notify(ctx, event.NewOrderStatusChanged(sell, model.OrderStatusNew)))
+
+	canceled := *sell
+	canceled.Status = model.OrderStatusCanceled
+	require.NoError(t, notifier.Notify(ctx, event.NewOrderStatusChanged(&canceled, model.OrderStatusNew)))
+
+	// Orders without an owner have nobody to notify
+	anonymous := *sell
+	anonymous.UserID = ""
+	require.NoError(t, notifier.Notify(ctx, event.NewOrderPlaced(&anonymous)))
+
+	assert.Equal(t, []interface{}{NotificationTypePositionClosed, NotificationTypeOrderCanceled}, notifications.types())
+	assert.Equal(t, "Filled SELL LIMIT 2 ETHUSDT at 3000", notifications.saved[0]["message"])
+	assert.Equal(t, "Canceled SELL LIMIT 2 ETHUSDT at 3000 order mexc-2", notifications.saved[1]["message"])
+}
+
+func TestTradeNotifier_FailedOrderIsNotified(t *testing.T) {
+	logger := zerolog.Nop()
+	notifications := &savedNotifications{}
+	notifier := NewTradeNotifier(delivery.NewInMemoryEventBus(logger), NewUserNotificationService(notifications, &logger), &logger)
+
+	retry := &model.OrderRetry{
+		ID:        "retry-1",
+		Request:   model.OrderRequest{UserID: "user-1", Symbol: "BTCUSDT", Side: model.OrderSideBuy, Type: model.OrderTypeLimit, Quantity: 0.5, Price: 64000},
+		Status:    model.OrderRetryFailed,
+		Attempts:  5,
+		LastError: "failed to place order: request failed with status 503",
+	}
+	require.NoError(t, notifier.Notify(context.Background(), event.NewOrderFailed(retry)))
+
+	require.Len(t, notifications.saved, 1)
+	failed := notifications.saved[0]
+	assert.Equal(t, NotificationTypeOrderFailed, failed["type"])
+	assert.Equal(t, "user-1", failed["user_id"])
+	assert.Equal(t, "Order failed: BTCUSDT", failed["title"])
+	assert.Equal(t, "Could not place BUY LIMIT 0.5 BTCUSDT at 64000 after 5 attempts: failed to place order: request failed with status 503", failed["message"])
+}
@@ -0,0 +1,66 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+)
+
+// UserNotification is a notification addressed to one user
+type UserNotification struct {
+	UserID  string
+	Type    string
+	Title   string
+	Message string
+	// Data is stored with the notification for clients to render details
+	Data interface{}
+}
+
+// NotificationSender delivers notifications to users outside the application, such as to
+// the console or by email
+type NotificationSender interface {
+	SendNotification(ctx context.Context, userID, title, message string) error
+}
+
+// UserNotificationService stores user notifications, so clients can list them, and
+// delivers each one through its senders
+type UserNotificationService struct {
+	repo    port.NotificationRepository
+	senders []NotificationSender
+	logger  *zerolog.Logger
+}
+
+// NewUserNotificationService creates a new UserNotificationService
+func NewUserNotificationService(repo port.NotificationRepository, logger *zerolog.Logger, senders ...NotificationSender) *UserNotificationService {
+	return &UserNotificationService{
+		repo:    repo,
+		senders: senders,
+		logger:  logger,
+	}
+}
+
+// Send stores the notification and delivers it through every sender. A failed delivery
+// is logged and does not affect the others; the stored notification is kept.
+func (s *UserNotificationService) Send(ctx context.Context, notification UserNotification) error {
+	err := s.repo.SaveNotification(ctx, map[string]interface{}{
+		"user_id": notification.UserID,
+		"type":    notification.Type,
+		"title":   notification.Title,
+		"message": notification.Message,
+		"data":    notification.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, sender := range s.senders {
+		if err := sender.SendNotification(ctx, notification.UserID, notification.Title, notification.Message); err != nil {
+			s.logger.Error().Err(err).
+				Str("userID", notification.UserID).
+				Str("type", notification.Type).
+				Msg("Failed to deliver notification")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,383 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// Headers sent with every webhook delivery
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// errWebhookAddressNotPublic is returned when an endpoint resolves to an address webhooks
+// may not be delivered to
+var errWebhookAddressNotPublic = errors.New("webhook address is not public")
+
+// WebhookDispatcherOptions configures deliveries to webhook endpoints
+type WebhookDispatcherOptions struct {
+	// MaxAttempts is how often a delivery is tried before it is marked failed
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry; it doubles up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Timeout bounds each delivery attempt
+	Timeout time.Duration
+	// QueueSize bounds the deliveries waiting for a worker; more are recorded as failed
+	QueueSize int
+	Workers   int
+}
+
+// DefaultWebhookDispatcherOptions returns the options used for unset fields
+func DefaultWebhookDispatcherOptions() WebhookDispatcherOptions {
+	return WebhookDispatcherOptions{
+		MaxAttempts:    5,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+		Timeout:        10 * time.Second,
+		QueueSize:      256,
+		Workers:        4,
+	}
+}
+
+// webhookPayload is the JSON body of a delivery
+type webhookPayload struct {
+	ID         string            `json:"id"`
+	Type       event.EventType   `json:"type"`
+	OccurredAt time.Time         `json:"occurred_at"`
+	Data       event.DomainEvent `json:"data"`
+}
+
+// webhookJob is a delivery waiting for a worker
+type webhookJob struct {
+	endpoint *model.WebhookEndpoint
+	delivery *model.WebhookDelivery
+	body     []byte
+}
+
+// WebhookDispatcher delivers bot events to the webhook endpoints users registered. It
+// subscribes to order fills and cancellations, new listings and risk alerts on the event
+// bus. Events of a user, such as their fills, go to that user's endpoints only; market-wide
+// events go to every endpoint accepting their type. Payloads are signed with the
+// endpoint's secret, failed attempts are retried with exponential backoff, and the outcome
+// of every delivery is recorded.
+type WebhookDispatcher struct {
+	events     port.DomainEventBus
+	endpoints  port.WebhookEndpointRepository
+	deliveries port.WebhookDeliveryRepository
+	options    WebhookDispatcherOptions
+	client     *http.Client
+	logger     *zerolog.Logger
+	now        func() time.Time
+	// sleep waits between attempts; it returns early with ctx's error when ctx ends
+	sleep func(ctx context.Context, d time.Duration) error
+
+	mu           sync.Mutex
+	subscription port.Subscription
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+}
+
+// NewWebhookDispatcher creates a WebhookDispatcher. Zero options take their defaults.
+func NewWebhookDispatcher(
+	events port.DomainEventBus,
+	endpoints port.WebhookEndpointRepository,
+	deliveries port.WebhookDeliveryRepository,
+	options WebhookDispatcherOptions,
+	logger *zerolog.Logger,
+) *WebhookDispatcher {
+	defaults := DefaultWebhookDispatcherOptions()
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = defaults.MaxAttempts
+	}
+	if options.InitialBackoff <= 0 {
+		options.InitialBackoff = defaults.InitialBackoff
+	}
+	if options.MaxBackoff < options.InitialBackoff {
+		options.MaxBackoff = max(defaults.MaxBackoff, options.InitialBackoff)
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = defaults.Timeout
+	}
+	if options.QueueSize <= 0 {
+		options.QueueSize = defaults.QueueSize
+	}
+	if options.Workers <= 0 {
+		options.Workers = defaults.Workers
+	}
+
+	return &WebhookDispatcher{
+		events:     events,
+		endpoints:  endpoints,
+		deliveries: deliveries,
+		options:    options,
+		client:     newWebhookClient(options.Timeout),
+		logger:     logger,
+		now:        time.Now,
+		sleep:      sleepContext,
+	}
+}
+
+// newWebhookClient creates the client deliveries are sent with. Endpoints are user input,
+// so the dialer checks every address after DNS resolution and refuses loopback, private
+// and link-local ones, and redirects are returned as responses instead of followed.
+func newWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("%w: %s", errWebhookAddressNotPublic, address)
+			}
+			if !model.IsPublicWebhookAddress(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", errWebhookAddressNotPublic, addrPort.Addr())
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would be dialed instead of the endpoint, bypassing the address check
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// SignWebhookPayload returns the signature header of a payload sent at timestamp:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<body>" keyed with secret>".
+// Receivers recompute the HMAC and compare it in constant time, and should reject old
+// timestamps to limit replays.
+func SignWebhookPayload(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts + "."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Start subscribes to the event bus and starts the delivery workers
+func (d *WebhookDispatcher) Start(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.cancel != nil {
+		return fmt.Errorf("webhook dispatcher is already running")
+	}
+
+	ctx, d.cancel = context.WithCancel(ctx)
+	queue := make(chan webhookJob, d.options.QueueSize)
+	for i := 0; i < d.options.Workers; i++ {
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-queue:
+					d.deliver(ctx, job)
+				}
+			}
+		}()
+	}
+	d.subscription = d.events.SubscribeTopics(func(evt event.DomainEvent) {
+		d.dispatch(ctx, queue, evt)
+	}, event.WebhookEventTypes...)
+
+	d.logger.Info().Int("workers", d.options.Workers).Msg("Webhook dispatcher started")
+	return nil
+}
+
+// Stop unsubscribes from the event bus and waits for the workers to finish. Retries still
+// pending are abandoned; their deliveries stay recorded as pending.
+func (d *WebhookDispatcher) Stop() {
+	d.mu.Lock()
+	cancel := d.cancel
+	subscription := d.subscription
+	d.cancel = nil
+	d.subscription = nil
+	d.mu.Unlock()
+
+	if cancel != nil {
+		subscription.Unsubscribe()
+		cancel()
+		d.wg.Wait()
+		d.logger.Info().Msg("Webhook dispatcher stopped")
+	}
+}
+
+// dispatch queues a delivery of evt to every endpoint that should receive it
+func (d *WebhookDispatcher) dispatch(ctx context.Context, queue chan<- webhookJob, evt event.DomainEvent) {
+	endpoints, err := d.endpoints.ListActive(ctx)
+	if err != nil {
+		d.logger.Error().Err(err).Str("event_type", string(evt.Type())).Msg("Failed to list webhook endpoints")
+		return
+	}
+
+	userID, personal := eventOwner(evt)
+	var body []byte
+	for _, endpoint := range endpoints {
+		if !endpoint.Accepts(string(evt.Type())) || (personal && (userID == "" || endpoint.UserID != userID)) {
+			continue
+		}
+		if body == nil {
+			body, err = json.Marshal(webhookPayload{
+				ID:         uuid.New().String(),
+				Type:       evt.Type(),
+				OccurredAt: evt.OccurredAt(),
+				Data:       evt,
+			})
+			if err != nil {
+				d.logger.Error().Err(err).Str("event_type", string(evt.Type())).Msg("Failed to encode webhook payload")
+				return
+			}
+		}
+
+		delivery := &model.WebhookDelivery{
+			ID:         uuid.New().String(),
+			EndpointID: endpoint.ID,
+			EventType:  string(evt.Type()),
+			Status:     model.WebhookDeliveryPending,
+			CreatedAt:  d.now(),
+		}
+		select {
+		case queue <- webhookJob{endpoint: endpoint, delivery: delivery, body: body}:
+		default:
+			d.logger.Warn().Str("endpoint_id", endpoint.ID).Msg("Webhook queue full, dropping delivery")
+			delivery.LastError = "delivery queue full"
+			d.complete(ctx, delivery, model.WebhookDeliveryFailed)
+		}
+	}
+}
+
+// deliver sends the payload to the endpoint, retrying with backoff, and records the outcome
+func (d *WebhookDispatcher) deliver(ctx context.Context, job webhookJob) {
+	delivery := job.delivery
+	d.record(ctx, delivery)
+
+	backoff := d.options.InitialBackoff
+	for {
+		delivery.Attempts++
+		retryable, err := d.attempt(ctx, job)
+		if err == nil {
+			d.complete(ctx, delivery, model.WebhookDeliverySucceeded)
+			return
+		}
+		delivery.LastError = err.Error()
+		if !retryable || delivery.Attempts >= d.options.MaxAttempts {
+			d.logger.Warn().Err(err).
+				Str("endpoint_id", job.endpoint.ID).
+				Str("delivery_id", delivery.ID).
+				Int("attempts", delivery.Attempts).
+				Msg("Webhook delivery failed")
+			d.complete(ctx, delivery, model.WebhookDeliveryFailed)
+			return
+		}
+
+		d.record(ctx, delivery)
+		if err := d.sleep(ctx, backoff); err != nil {
+			return
+		}
+		backoff = min(backoff*2, d.options.MaxBackoff)
+	}
+}
+
+// attempt makes one delivery attempt and reports whether a failure is worth retrying.
+// Client errors other than timeouts and rate limiting are not retried, and neither are
+// endpoints resolving to non-public addresses.
+func (d *WebhookDispatcher) attempt(ctx context.Context, job webhookJob) (retryable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, job.endpoint.URL, bytes.NewReader(job.body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, job.delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, job.delivery.ID)
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(job.endpoint.Secret, d.now(), job.body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return !errors.Is(err, errWebhookAddressNotPublic), err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	job.delivery.StatusCode = resp.StatusCode
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retryable = resp.StatusCode >= 500 || resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests
+	return retryable, fmt.Errorf("endpoint returned status %d", resp.StatusCode)
+}
+
+// complete records the final status of a delivery
+func (d *WebhookDispatcher) complete(ctx context.Context, delivery *model.WebhookDelivery, status model.WebhookDeliveryStatus) {
+	completedAt := d.now()
+	delivery.Status = status
+	delivery.CompletedAt = &completedAt
+	d.record(ctx, delivery)
+}
+
+// record stores the delivery's current state. The stored status is informational, so a
+// failure to store it does not stop the delivery.
+func (d *WebhookDispatcher) record(ctx context.Context, delivery *model.WebhookDelivery) {
+	if err := d.deliveries.Save(context.WithoutCancel(ctx), delivery); err != nil {
+		d.logger.Error().Err(err).Str("delivery_id", delivery.ID).Msg("Failed to record webhook delivery")
+	}
+}
+
+// eventOwner returns the user a personal event belongs to. Market-wide events are not
+// personal. A personal event without a known user is delivered to no one.
+func eventOwner(evt event.DomainEvent) (userID string, personal bool) {
+	switch e := evt.(type) {
+	case *event.OrderStatusChanged:
+		if e.Order != nil {
+			userID = e.Order.UserID
+		}
+		return userID, true
+	case *event.RiskAlertRaised:
+		if e.Assessment != nil {
+			userID = e.Assessment.UserID
+		}
+		return userID, true
+	}
+	return "", false
+}
+
+// sleepContext waits for d or until ctx ends
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
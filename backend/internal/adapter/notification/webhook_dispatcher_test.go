@@ -0,0 +1,234 @@
+package notification
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/delivery"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memWebhookEndpoints serves a fixed set of endpoints
+type memWebhookEndpoints []*model.WebhookEndpoint
+
+func (m memWebhookEndpoints) Save(ctx context.Context, endpoint *model.WebhookEndpoint) error {
+	return nil
+}
+
+func (m memWebhookEndpoints) GetByID(ctx context.Context, id string) (*model.WebhookEndpoint, error) {
+	for _, endpoint := range m {
+		if endpoint.ID == id {
+			return endpoint, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m memWebhookEndpoints) ListByUser(ctx context.Context, userID string) ([]*model.WebhookEndpoint, error) {
+	return nil, nil
+}
+
+func (m memWebhookEndpoints) ListActive(ctx context.Context) ([]*model.WebhookEndpoint, error) {
+	return m, nil
+}
+
+func (m memWebhookEndpoints) Delete(ctx context.Context, id string) error {
+	return nil
+}
+
+// memWebhookDeliveries keeps the latest state of every delivery
+type memWebhookDeliveries struct {
+	mu         sync.Mutex
+	deliveries map[string]model.WebhookDelivery
+}
+
+func (m *memWebhookDeliveries) Save(ctx context.Context, delivery *model.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.deliveries == nil {
+		m.deliveries = make(map[string]model.WebhookDelivery)
+	}
+	m.deliveries[delivery.ID] = *delivery
+	return nil
+}
+
+func (m *memWebhookDeliveries) ListByEndpoint(ctx context.Context, endpointID string, limit int) ([]*model.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deliveries []*model.WebhookDelivery
+	for _, d := range m.deliveries {
+		if d.EndpointID == endpointID {
+			d := d
+			deliveries = append(deliveries, &d)
+		}
+	}
+	return deliveries, nil
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	body := []byte(`{"type":"OrderFilled"}`)
+	timestamp := time.Unix(1700000000, 0)
+
+	mac := hmac.New(sha256.New, []byte("secret-1"))
+	mac.Write([]byte("1700000000." + string(body)))
+	want := "t=1700000000,v1=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.Equal(t, want, SignWebhookPayload("secret-1", timestamp, body))
+	assert.NotEqual(t, want, SignWebhookPayload("secret-2", timestamp, body), "the signature depends on the secret")
+	assert.NotEqual(t, want, SignWebhookPayload("secret-1", timestamp.Add(time.Second), body), "and on the timestamp")
+	assert.NotEqual(t, want, SignWebhookPayload("secret-1", timestamp, []byte(`{}`)), "and on the body")
+}
+
+func TestWebhookDispatcher_RetriesUntilDelivered(t *testing.T) {
+	var mu sync.Mutex
+	var statuses []int
+	var received []*http.Request
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		status := http.StatusOK
+		if len(statuses) == 0 {
+			status = http.StatusInternalServerError
+		}
+		statuses = append(statuses, status)
+		received = append(received, r)
+		bodies = append(bodies, body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	logger := zerolog.Nop()
+	bus := delivery.NewInMemoryEventBus(logger)
+	endpoints := memWebhookEndpoints{
+		{ID: "ep-alice", UserID: "alice", URL: server.URL, Secret: "alice-secret", EventTypes: []string{"OrderFilled"}, Active: true},
+		// Another user's endpoint does not receive alice's fills
+		{ID: "ep-bob", UserID: "bob", URL: server.URL, Secret: "bob-secret", Active: true},
+	}
+	deliveries := &memWebhookDeliveries{}
+	dispatcher := NewWebhookDispatcher(bus, endpoints, deliveries, WebhookDispatcherOptions{MaxAttempts: 3, InitialBackoff: time.Second}, &logger)
+	// The test server listens on loopback, which the default dialer refuses
+	dispatcher.client.Transport = server.Client().Transport
+	var backoffs []time.Duration
+	dispatcher.sleep = func(ctx context.Context, d time.Duration) error {
+		backoffs = append(backoffs, d)
+		return nil
+	}
+	require.NoError(t, dispatcher.Start(context.Background()))
+	defer dispatcher.Stop()
+
+	order := &model.Order{OrderID: "order-1", UserID: "alice", Symbol: "BTCUSDT", Status: model.OrderStatusFilled}
+	bus.PublishEvent(event.NewOrderStatusChanged(order, model.OrderStatusNew))
+
+	var delivered []*model.WebhookDelivery
+	require.Eventually(t, func() bool {
+		delivered, _ = deliveries.ListByEndpoint(context.Background(), "ep-alice", 10)
+		return len(delivered) == 1 && delivered[0].Status == model.WebhookDeliverySucceeded
+	}, 2*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, 2, delivered[0].Attempts)
+	assert.Equal(t, http.StatusOK, delivered[0].StatusCode)
+	assert.Equal(t, "OrderFilled", delivered[0].EventType)
+	assert.Equal(t, []time.Duration{time.Second}, backoffs)
+	bobDeliveries, _ := deliveries.ListByEndpoint(context.Background(), "ep-bob", 10)
+	assert.Empty(t, bobDeliveries)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []int{http.StatusInternalServerError, http.StatusOK}, statuses)
+	for i, req := range received {
+		assert.Equal(t, "OrderFilled", req.Header.Get(WebhookEventHeader))
+		assert.Equal(t, delivered[0].ID, req.Header.Get(WebhookDeliveryHeader))
+
+		signature := req.Header.Get(WebhookSignatureHeader)
+		ts, _, ok := strings.Cut(strings.TrimPrefix(signature, "t="), ",")
+		require.True(t, ok, signature)
+		seconds, err := strconv.ParseInt(ts, 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, SignWebhookPayload("alice-secret", time.Unix(seconds, 0), bodies[i]), signature)
+	}
+
+	var payload struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	}
+	require.NoError(t, json.Unmarshal(bodies[1], &payload))
+	assert.NotEmpty(t, payload.ID)
+	assert.Equal(t, "OrderFilled", payload.Type)
+	assert.Equal(t, bodies[0], bodies[1], "retries resend the same payload")
+}
+
+// newFillDispatcher starts a dispatcher with one endpoint of alice at url and publishes a fill
+func newFillDispatcher(t *testing.T, url string, allowLoopback bool) *memWebhookDeliveries {
+	t.Helper()
+	logger := zerolog.Nop()
+	bus := delivery.NewInMemoryEventBus(logger)
+	endpoints := memWebhookEndpoints{{ID: "ep-alice", UserID: "alice", URL: url, Secret: "secret", Active: true}}
+	deliveries := &memWebhookDeliveries{}
+	dispatcher := NewWebhookDispatcher(bus, endpoints, deliveries, WebhookDispatcherOptions{MaxAttempts: 3}, &logger)
+	if allowLoopback {
+		dispatcher.client.Transport = http.DefaultTransport
+	}
+	dispatcher.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+	require.NoError(t, dispatcher.Start(context.Background()))
+	t.Cleanup(dispatcher.Stop)
+
+	order := &model.Order{OrderID: "order-1", UserID: "alice", Symbol: "BTCUSDT", Status: model.OrderStatusFilled}
+	bus.PublishEvent(event.NewOrderStatusChanged(order, model.OrderStatusNew))
+	return deliveries
+}
+
+func TestWebhookDispatcher_RefusesLoopbackEndpoints(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+	}))
+	defer server.Close()
+
+	deliveries := newFillDispatcher(t, server.URL, false)
+
+	var delivered []*model.WebhookDelivery
+	require.Eventually(t, func() bool {
+		delivered, _ = deliveries.ListByEndpoint(context.Background(), "ep-alice", 10)
+		return len(delivered) == 1 && delivered[0].Status == model.WebhookDeliveryFailed
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, delivered[0].Attempts, "refused addresses are not retried")
+	assert.Zero(t, hits.Load())
+}
+
+func TestWebhookDispatcher_DoesNotFollowRedirects(t *testing.T) {
+	var redirected atomic.Int32
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected.Add(1)
+	}))
+	defer target.Close()
+	server := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer server.Close()
+
+	deliveries := newFillDispatcher(t, server.URL, true)
+
+	var delivered []*model.WebhookDelivery
+	require.Eventually(t, func() bool {
+		delivered, _ = deliveries.ListByEndpoint(context.Background(), "ep-alice", 10)
+		return len(delivered) == 1 && delivered[0].Status == model.WebhookDeliveryFailed
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, http.StatusTemporaryRedirect, delivered[0].StatusCode)
+	assert.Zero(t, redirected.Load())
+}
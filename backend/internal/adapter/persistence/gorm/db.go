@@ -2,7 +2,6 @@ package gorm
 
 import (
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"time"
@@ -28,21 +27,15 @@ func NewDBConnection(cfg *config.Config, logger zerolog.Logger) (*gorm.DB, error
 	if cfg.ENV == "development" {
 		logLevel = gormlogger.Info
 	} else {
-		logLevel = gormlogger.Error
+		// Warn rather than Error so slow queries are still reported
+		logLevel = gormlogger.Warn
 	}
 
-	// Create a log writer that uses our zerolog instance
-	logWriter := log.New(zerolog.ConsoleWriter{Out: os.Stdout, NoColor: false}, "[GORM] ", log.LstdFlags)
-
-	gormLogger := gormlogger.New(
-		logWriter,
-		gormlogger.Config{
-			SlowThreshold:             2 * time.Second, // Threshold for slow SQL queries
-			LogLevel:                  logLevel,
-			IgnoreRecordNotFoundError: true,
-			Colorful:                  true,
-		},
-	)
+	slowThreshold := cfg.Database.SlowQueryThreshold
+	if slowThreshold == 0 {
+		slowThreshold = DefaultSlowQueryThreshold
+	}
+	gormLogger := NewQueryLogger(logger, logLevel, slowThreshold)
 
 	// Connect to the database based on driver type
 	var db *gorm.DB
@@ -63,6 +56,10 @@ func NewDBConnection(cfg *config.Config, logger zerolog.Logger) (*gorm.DB, error
 		return nil, fmt.Errorf("unsupported database driver: %s", cfg.Database.Driver)
 	}
 
+	if err := RegisterTracing(db); err != nil {
+		return nil, fmt.Errorf("failed to register query tracing: %w", err)
+	}
+
 	// Configure connection pool
 	sqlDB, err := db.DB()
 	if err != nil {
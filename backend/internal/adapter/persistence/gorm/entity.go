@@ -2,6 +2,8 @@ package gorm
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // SymbolEntity is the GORM model for trading pair information
@@ -20,6 +22,7 @@ type SymbolEntity struct {
 	AllowedOrderTypes string
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
+	DeletedAt         gorm.DeletedAt `gorm:"index"`
 }
 
 // TableName sets the table name for SymbolEntity
@@ -27,6 +30,15 @@ func (SymbolEntity) TableName() string {
 	return "symbols"
 }
 
+// deletedAtToDomain converts a soft-delete timestamp to nil for live rows
+func deletedAtToDomain(deletedAt gorm.DeletedAt) *time.Time {
+	if !deletedAt.Valid {
+		return nil
+	}
+	t := deletedAt.Time
+	return &t
+}
+
 // TickerEntity is the GORM model for ticker data
 type TickerEntity struct {
 	ID            string `gorm:"primaryKey"`
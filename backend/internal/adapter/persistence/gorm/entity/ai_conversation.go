@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+)
+
+// AIConversationEntity is an AI assistant conversation owned by a user
+type AIConversationEntity struct {
+	ID        string    `gorm:"primaryKey;type:varchar(50)"`
+	UserID    string    `gorm:"index;type:varchar(50)"`
+	Title     string    `gorm:"type:varchar(255)"`
+	Tags      string    `gorm:"type:text"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (AIConversationEntity) TableName() string { return "ai_conversations" }
+
+// AIMessageEntity is a single turn of an AI assistant conversation
+type AIMessageEntity struct {
+	ID             string    `gorm:"primaryKey;type:varchar(50)"`
+	ConversationID string    `gorm:"index:idx_ai_messages_conversation_time;type:varchar(50)"`
+	Role           string    `gorm:"type:varchar(20)"`
+	Content        string    `gorm:"type:text"`
+	Timestamp      time.Time `gorm:"index:idx_ai_messages_conversation_time"`
+	Metadata       []byte    `gorm:"type:json"`
+}
+
+func (AIMessageEntity) TableName() string { return "ai_messages" }
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+)
+
+// AuditLogEntity is a row of the append-only audit trail. Sequence is assigned when the
+// entry is recorded and orders the hash chain.
+type AuditLogEntity struct {
+	Sequence     int64     `gorm:"primaryKey;autoIncrement:false"`
+	Actor        string    `gorm:"type:varchar(100);not null;index"`
+	Action       string    `gorm:"type:varchar(50);not null;index"`
+	ResourceType string    `gorm:"type:varchar(50);not null"`
+	ResourceID   string    `gorm:"type:varchar(100);index"`
+	Outcome      string    `gorm:"type:varchar(20);not null"`
+	Details      string    `gorm:"type:text"`
+	RequestID    string    `gorm:"type:varchar(100)"`
+	Timestamp    time.Time `gorm:"not null;index"`
+	PrevHash     string    `gorm:"type:varchar(64);not null"`
+	Hash         string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+}
+
+func (AuditLogEntity) TableName() string { return "audit_log" }
@@ -78,3 +78,21 @@ func (e *AutoBuyExecutionEntity) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+// AutoBuySpendEntity records quote spent by the new-listing autobuyer so budget caps
+// survive restarts
+type AutoBuySpendEntity struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	Symbol    string    `gorm:"not null;index"`
+	Amount    float64   `gorm:"not null"`
+	Quantity  float64   `gorm:"not null;default:0"` // Quantity bought
+	Remaining float64   `gorm:"not null;default:0"` // Quantity not sold yet
+	Open      bool      `gorm:"not null;default:true;index"`
+	CreatedAt time.Time `gorm:"not null;autoCreateTime"`
+	ClosedAt  *time.Time
+}
+
+// TableName specifies the table name for the AutoBuySpendEntity
+func (AutoBuySpendEntity) TableName() string {
+	return "auto_buy_spend"
+}
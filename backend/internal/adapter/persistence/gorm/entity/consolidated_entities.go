@@ -44,6 +44,8 @@ type UserEntity struct {
 	Name      string    `gorm:"type:varchar(100)"`
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+	// DeactivatedAt is set when the user was deleted at the identity provider
+	DeactivatedAt *time.Time `gorm:"index"`
 }
 
 // TableName returns the table name for the UserEntity
@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"time"
+)
+
+// JobLockEntity stores which instance holds the lock of a scheduled job, and until when
+type JobLockEntity struct {
+	Name      string    `gorm:"primaryKey;type:varchar(100)"`
+	Owner     string    `gorm:"type:varchar(100);not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null"`
+}
+
+func (JobLockEntity) TableName() string { return "job_locks" }
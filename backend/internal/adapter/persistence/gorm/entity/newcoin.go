@@ -13,3 +13,32 @@ type NewCoinEntity struct {
 }
 
 func (NewCoinEntity) TableName() string { return "new_coins" }
+
+// NewCoinEventLogEntity is an append-only record of a published NewCoinEvent. Offset is
+// assigned by the database and orders the log for replay.
+type NewCoinEventLogEntity struct {
+	Offset    int64     `gorm:"column:log_offset;primaryKey;autoIncrement"`
+	EventID   string    `gorm:"type:varchar(50);not null;uniqueIndex"`
+	CoinID    string    `gorm:"type:varchar(50);index"`
+	EventType string    `gorm:"type:varchar(50)"`
+	OldStatus string    `gorm:"type:varchar(20)"`
+	NewStatus string    `gorm:"type:varchar(20)"`
+	Data      []byte    `gorm:"type:json"`
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (NewCoinEventLogEntity) TableName() string { return "new_coin_event_log" }
+
+// ScheduledListingEntity is a pending pre-listing alert
+type ScheduledListingEntity struct {
+	ID          string     `gorm:"primaryKey;type:varchar(100)"`
+	Symbol      string     `gorm:"type:varchar(50);not null;index"`
+	Title       string     `gorm:"type:text"`
+	URL         string     `gorm:"type:text"`
+	ListingTime time.Time  `gorm:"not null"`
+	AlertAt     time.Time  `gorm:"not null;index"`
+	FiredAt     *time.Time `gorm:"index"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime"`
+}
+
+func (ScheduledListingEntity) TableName() string { return "scheduled_listings" }
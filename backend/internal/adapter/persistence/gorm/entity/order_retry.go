@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+)
+
+// OrderRetryEntity stores an order request waiting to be placed again
+type OrderRetryEntity struct {
+	ID            string  `gorm:"primaryKey;type:varchar(50)"`
+	UserID        string  `gorm:"type:varchar(50);index"`
+	Symbol        string  `gorm:"type:varchar(20);not null"`
+	Side          string  `gorm:"type:varchar(10);not null"`
+	Type          string  `gorm:"type:varchar(20);not null"`
+	Quantity      float64 `gorm:"not null"`
+	Price         float64
+	TimeInForce   string    `gorm:"type:varchar(10)"`
+	Status        string    `gorm:"type:varchar(20);not null;index:idx_order_retries_due,priority:1"`
+	Attempts      int       `gorm:"not null"`
+	NextAttemptAt time.Time `gorm:"not null;index:idx_order_retries_due,priority:2"`
+	LastError     string    `gorm:"type:text"`
+	OrderID       string    `gorm:"type:varchar(50)"`
+	CreatedAt     time.Time `gorm:"not null"`
+	UpdatedAt     time.Time `gorm:"not null"`
+}
+
+func (OrderRetryEntity) TableName() string { return "order_retries" }
@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+)
+
+// TrailingStopEntity stores the trailing stop of an open position
+type TrailingStopEntity struct {
+	PositionID   string    `gorm:"primaryKey;type:varchar(50)"`
+	Symbol       string    `gorm:"type:varchar(20);not null"`
+	HighestPrice float64   `gorm:"not null"`
+	StopPrice    float64   `gorm:"not null"`
+	UpdatedAt    time.Time `gorm:"not null"`
+}
+
+func (TrailingStopEntity) TableName() string { return "trailing_stops" }
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+)
+
+// WalletTransactionEntity stores a balance change detected during a wallet sync
+type WalletTransactionEntity struct {
+	ID              string    `gorm:"primaryKey;type:varchar(50)"`
+	UserID          string    `gorm:"index;type:varchar(50);not null"`
+	WalletID        string    `gorm:"index;type:varchar(50);not null"`
+	Type            string    `gorm:"type:varchar(20);not null"`
+	Asset           string    `gorm:"type:varchar(20);not null"`
+	Amount          float64   `gorm:"not null"`
+	PreviousBalance float64   `gorm:"not null"`
+	NewBalance      float64   `gorm:"not null"`
+	DetectedAt      time.Time `gorm:"index;not null"`
+	ExternalID      string    `gorm:"type:varchar(100)"`
+	Status          string    `gorm:"type:varchar(20)"`
+	Network         string    `gorm:"type:varchar(50)"`
+	Address         string    `gorm:"type:varchar(255)"`
+	TxID            string    `gorm:"type:varchar(255)"`
+	Fee             float64
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+}
+
+func (WalletTransactionEntity) TableName() string { return "wallet_transactions" }
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+)
+
+// WebhookEndpointEntity stores a user's outbound webhook endpoint
+type WebhookEndpointEntity struct {
+	ID     string `gorm:"primaryKey;type:varchar(50)"`
+	UserID string `gorm:"type:varchar(50);not null;index"`
+	URL    string `gorm:"type:varchar(500);not null"`
+	Secret string `gorm:"type:varchar(100);not null"`
+	// EventTypes is a comma-separated list; empty means every event
+	EventTypes string    `gorm:"type:varchar(500)"`
+	Active     bool      `gorm:"not null;index"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+func (WebhookEndpointEntity) TableName() string { return "webhook_endpoints" }
+
+// WebhookDeliveryEntity stores the status of a delivery to a webhook endpoint
+type WebhookDeliveryEntity struct {
+	ID          string `gorm:"primaryKey;type:varchar(50)"`
+	EndpointID  string `gorm:"type:varchar(50);not null;index:idx_webhook_delivery_endpoint_created"`
+	EventType   string `gorm:"type:varchar(64);not null"`
+	Status      string `gorm:"type:varchar(16);not null"`
+	Attempts    int    `gorm:"not null"`
+	StatusCode  int
+	LastError   string    `gorm:"type:text"`
+	CreatedAt   time.Time `gorm:"not null;index:idx_webhook_delivery_endpoint_created"`
+	CompletedAt *time.Time
+}
+
+func (WebhookDeliveryEntity) TableName() string { return "webhook_deliveries" }
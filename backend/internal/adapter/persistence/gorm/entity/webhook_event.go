@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"time"
+)
+
+// ProcessedWebhookEventEntity records an inbound webhook event that was handled
+type ProcessedWebhookEventEntity struct {
+	Source      string    `gorm:"primaryKey;type:varchar(32)"`
+	EventID     string    `gorm:"primaryKey;type:varchar(100)"`
+	EventType   string    `gorm:"type:varchar(64);not null"`
+	ProcessedAt time.Time `gorm:"not null;index"`
+}
+
+func (ProcessedWebhookEventEntity) TableName() string { return "processed_webhook_events" }
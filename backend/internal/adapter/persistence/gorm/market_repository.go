@@ -11,6 +11,7 @@ import (
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
 	"github.com/rs/zerolog"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Ensure MarketRepository implements the proper interfaces
@@ -301,6 +302,25 @@ func (r *MarketRepository) GetCandles(ctx context.Context, symbol, exchange stri
 	return candles, nil
 }
 
+// GetResampledCandles reads source candles within a time range and aggregates them into
+// candles of the target interval
+func (r *MarketRepository) GetResampledCandles(ctx context.Context, symbol, exchange string, source, target market.Interval, start, end time.Time) ([]*market.Candle, error) {
+	if err := market.CanAggregate(source, target); err != nil {
+		return nil, apperror.NewInvalid("Cannot resample candles", map[string]string{"source": string(source), "target": string(target)}, err)
+	}
+
+	candles, err := r.GetCandles(ctx, symbol, exchange, source, start, end, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	resampled, err := market.Aggregate(candles, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resample %s candles to %s: %w", source, target, err)
+	}
+	return resampled, nil
+}
+
 // GetLatestCandle retrieves the most recent candle for a symbol and interval
 func (r *MarketRepository) GetLatestCandle(ctx context.Context, symbol, exchange string, interval market.Interval) (*market.Candle, error) {
 	var entity CandleEntity
@@ -461,11 +481,20 @@ func (r *MarketRepository) GetByExchange(ctx context.Context, exchange string) (
 	return symbols, nil
 }
 
-// GetAll returns all available Symbols
+// GetAll returns all available Symbols, excluding soft-deleted ones
 func (r *MarketRepository) GetAll(ctx context.Context) ([]*market.Symbol, error) {
+	return r.getAllSymbols(r.db.WithContext(ctx))
+}
+
+// GetAllIncludingDeleted returns all Symbols, including soft-deleted ones
+func (r *MarketRepository) GetAllIncludingDeleted(ctx context.Context) ([]*market.Symbol, error) {
+	return r.getAllSymbols(r.db.WithContext(ctx).Unscoped())
+}
+
+func (r *MarketRepository) getAllSymbols(db *gorm.DB) ([]*market.Symbol, error) {
 	var entities []SymbolEntity
 
-	result := r.db.WithContext(ctx).Find(&entities)
+	result := db.Find(&entities)
 	if result.Error != nil {
 		r.logger.Error().Err(result.Error).Msg("Failed to get all symbols")
 		return nil, fmt.Errorf("failed to get all symbols: %w", result.Error)
@@ -499,7 +528,40 @@ func (r *MarketRepository) Update(ctx context.Context, symbol *market.Symbol) er
 	return nil
 }
 
-// Delete removes a Symbol
+// Upsert creates a Symbol or updates the existing one with the same symbol and exchange
+// in a single statement
+func (r *MarketRepository) Upsert(ctx context.Context, symbol *market.Symbol) error {
+	entity := r.symbolToEntity(symbol)
+
+	result := r.db.WithContext(ctx).Clauses(symbolUpsert).Create(&entity)
+	if result.Error != nil {
+		r.logger.Error().Err(result.Error).Str("symbol", symbol.Symbol).Msg("Failed to upsert symbol")
+		return fmt.Errorf("failed to upsert symbol: %w", result.Error)
+	}
+
+	r.logger.Debug().Str("symbol", symbol.Symbol).Str("exchange", symbol.Exchange).Msg("Symbol upserted successfully")
+	return nil
+}
+
+// UpsertMany upserts symbols in a single transaction and reports how many were created
+// and how many already existed
+func (r *MarketRepository) UpsertMany(ctx context.Context, symbols []*market.Symbol) (created, updated int, err error) {
+	entities := make([]SymbolEntity, len(symbols))
+	for i, symbol := range symbols {
+		entities[i] = r.symbolToEntity(symbol)
+	}
+
+	created, updated, err = upsertSymbols(r.db.WithContext(ctx), entities)
+	if err != nil {
+		r.logger.Error().Err(err).Int("count", len(symbols)).Msg("Failed to upsert symbols")
+		return 0, 0, fmt.Errorf("failed to upsert symbols: %w", err)
+	}
+
+	r.logger.Info().Int("created", created).Int("updated", updated).Msg("Symbols upserted successfully")
+	return created, updated, nil
+}
+
+// Delete soft-deletes a Symbol; the row is kept for the candles and orders referencing it
 func (r *MarketRepository) Delete(ctx context.Context, symbol string) error {
 	result := r.db.WithContext(ctx).Where("symbol = ?", symbol).Delete(&SymbolEntity{})
 	if result.Error != nil {
@@ -516,6 +578,74 @@ func (r *MarketRepository) Delete(ctx context.Context, symbol string) error {
 	return nil
 }
 
+// Restore reverts the soft-delete of a Symbol
+func (r *MarketRepository) Restore(ctx context.Context, symbol string) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&SymbolEntity{}).
+		Where("symbol = ? AND deleted_at IS NOT NULL", symbol).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		r.logger.Error().Err(result.Error).Str("symbol", symbol).Msg("Failed to restore symbol")
+		return fmt.Errorf("failed to restore symbol: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.Warn().Str("symbol", symbol).Msg("No deleted symbol found to restore")
+		return apperror.ErrNotFound
+	}
+
+	r.logger.Info().Str("symbol", symbol).Msg("Symbol restored successfully")
+	return nil
+}
+
+// symbolSearchRank orders search matches: exact pair, exact base asset, pair prefix,
+// base asset prefix, exact quote asset, then any other substring match
+const symbolSearchRank = `CASE
+	WHEN UPPER(symbol) = ? THEN 0
+	WHEN UPPER(base_asset) = ? THEN 1
+	WHEN UPPER(symbol) LIKE ? ESCAPE '\' THEN 2
+	WHEN UPPER(base_asset) LIKE ? ESCAPE '\' THEN 3
+	WHEN UPPER(quote_asset) = ? THEN 4
+	ELSE 5
+END`
+
+// SearchSymbols finds symbols matching query against the pair, base asset and quote asset
+func (r *MarketRepository) SearchSymbols(ctx context.Context, query string, limit int) ([]*market.Symbol, error) {
+	term := strings.ToUpper(strings.TrimSpace(query))
+	if term == "" {
+		return []*market.Symbol{}, nil
+	}
+	escaped := escapeLike(term)
+	contains := "%" + escaped + "%"
+	prefix := escaped + "%"
+
+	var entities []SymbolEntity
+	db := r.db.WithContext(ctx).
+		Where("UPPER(symbol) LIKE ? ESCAPE '\\' OR UPPER(base_asset) LIKE ? ESCAPE '\\' OR UPPER(quote_asset) LIKE ? ESCAPE '\\'", contains, contains, contains).
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:  symbolSearchRank + ", symbol, exchange",
+			Vars: []interface{}{term, term, prefix, prefix, term},
+		}})
+	if limit > 0 {
+		db = db.Limit(limit)
+	}
+
+	if err := db.Find(&entities).Error; err != nil {
+		r.logger.Error().Err(err).Str("query", query).Msg("Failed to search symbols")
+		return nil, fmt.Errorf("failed to search symbols: %w", err)
+	}
+
+	symbols := make([]*market.Symbol, len(entities))
+	for i, entity := range entities {
+		symbols[i] = r.symbolToDomain(&entity)
+	}
+	return symbols, nil
+}
+
+// escapeLike escapes LIKE wildcards so they match literally
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
 // Helper methods to convert between domain model and database entity
 
 func (r *MarketRepository) tickerToEntity(ticker *market.Ticker) TickerEntity {
@@ -681,6 +811,7 @@ func (r *MarketRepository) symbolToDomain(entity *SymbolEntity) *market.Symbol {
 		AllowedOrderTypes: allowedOrderTypes,
 		CreatedAt:         entity.CreatedAt,
 		UpdatedAt:         entity.UpdatedAt,
+		DeletedAt:         deletedAtToDomain(entity.DeletedAt),
 	}
 }
 
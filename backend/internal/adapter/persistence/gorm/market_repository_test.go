@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
@@ -287,6 +288,51 @@ func TestGetLatestCandle(t *testing.T) {
 	assert.Equal(t, candle2.Close, latestCandle.Close)
 }
 
+func TestGetResampledCandles(t *testing.T) {
+	repo, cleanup := setupTestRepository(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Ten one-minute candles make two five-minute candles
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	candles := make([]*market.Candle, 10)
+	for i := range candles {
+		open := start.Add(time.Duration(i) * time.Minute)
+		candles[i] = &market.Candle{
+			Symbol:    "BTCUSDT",
+			Exchange:  "mexc",
+			Interval:  market.Interval1m,
+			OpenTime:  open,
+			CloseTime: open.Add(time.Minute - time.Millisecond),
+			Open:      100 + float64(i),
+			High:      101 + float64(i),
+			Low:       99 + float64(i),
+			Close:     100.5 + float64(i),
+			Volume:    1,
+			Complete:  true,
+		}
+	}
+	require.NoError(t, repo.SaveCandles(ctx, candles))
+
+	resampled, err := repo.GetResampledCandles(ctx, "BTCUSDT", "mexc", market.Interval1m, market.Interval5m, start, start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, resampled, 2)
+
+	assert.Equal(t, market.Interval5m, resampled[0].Interval)
+	assert.Equal(t, start.Unix(), resampled[0].OpenTime.Unix())
+	assert.Equal(t, 100.0, resampled[0].Open)
+	assert.Equal(t, 105.0, resampled[0].High)
+	assert.Equal(t, 99.0, resampled[0].Low)
+	assert.Equal(t, 104.5, resampled[0].Close)
+	assert.Equal(t, 5.0, resampled[0].Volume)
+	assert.Equal(t, 105.0, resampled[1].Open)
+
+	// The target must be a whole multiple of the source, whether or not any candles are stored
+	_, err = repo.GetResampledCandles(ctx, "BTCUSDT", "mexc", market.Interval5m, market.Interval1m, start, start.Add(time.Hour))
+	assert.ErrorIs(t, err, apperror.ErrInvalidInput)
+}
+
 func TestSaveAndGetSymbol(t *testing.T) {
 	repo, cleanup := setupTestRepository(t)
 	defer cleanup()
@@ -487,6 +533,89 @@ func TestDeleteSymbol(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestSoftDeleteAndRestoreSymbol(t *testing.T) {
+	repo, cleanup := setupTestRepository(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	for _, pair := range []string{"BTCUSDT", "ETHUSDT"} {
+		require.NoError(t, repo.Create(ctx, &market.Symbol{Symbol: pair, Exchange: "mexc", Status: "TRADING"}))
+	}
+	require.NoError(t, repo.Delete(ctx, "BTCUSDT"))
+
+	// Hidden from the default queries
+	symbols, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, symbols, 1)
+	assert.Equal(t, "ETHUSDT", symbols[0].Symbol)
+	_, err = repo.GetBySymbol(ctx, "BTCUSDT")
+	assert.Error(t, err)
+
+	// The row is kept
+	all, err := repo.GetAllIncludingDeleted(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	for _, symbol := range all {
+		assert.Equal(t, symbol.Symbol == "BTCUSDT", symbol.DeletedAt != nil, symbol.Symbol)
+	}
+
+	require.NoError(t, repo.Restore(ctx, "BTCUSDT"))
+	restored, err := repo.GetBySymbol(ctx, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+	symbols, err = repo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, symbols, 2)
+
+	// Only deleted symbols can be restored
+	assert.Error(t, repo.Restore(ctx, "BTCUSDT"))
+	assert.Error(t, repo.Restore(ctx, "XRPUSDT"))
+}
+
+func TestUpsertSymbol(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	logger := zerolog.Nop()
+
+	repos := map[string]interface {
+		Upsert(ctx context.Context, symbol *market.Symbol) error
+		GetBySymbol(ctx context.Context, symbol string) (*market.Symbol, error)
+		GetAll(ctx context.Context) ([]*market.Symbol, error)
+	}{
+		"MarketRepository": NewMarketRepository(db, &logger),
+		"SymbolRepository": NewSymbolRepository(db, &logger),
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, db.Unscoped().Where("1 = 1").Delete(&SymbolEntity{}).Error)
+			ctx := context.Background()
+
+			require.NoError(t, repo.Upsert(ctx, &market.Symbol{
+				Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT", Exchange: "mexc",
+				Status: "TRADING", PricePrecision: 2, AllowedOrderTypes: []string{"LIMIT"},
+			}))
+			created, err := repo.GetBySymbol(ctx, "BTCUSDT")
+			require.NoError(t, err)
+
+			require.NoError(t, repo.Upsert(ctx, &market.Symbol{
+				Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT", Exchange: "mexc",
+				Status: "BREAK", PricePrecision: 4, AllowedOrderTypes: []string{"LIMIT", "MARKET"},
+			}))
+
+			symbols, err := repo.GetAll(ctx)
+			require.NoError(t, err)
+			require.Len(t, symbols, 1)
+			updated := symbols[0]
+			assert.Equal(t, "BREAK", updated.Status)
+			assert.Equal(t, 4, updated.PricePrecision)
+			assert.Equal(t, []string{"LIMIT", "MARKET"}, updated.AllowedOrderTypes)
+			assert.True(t, updated.CreatedAt.Equal(created.CreatedAt), "creation time is kept")
+		})
+	}
+}
+
 func TestPurgeOldData(t *testing.T) {
 	repo, cleanup := setupTestRepository(t)
 	defer cleanup()
@@ -569,3 +698,51 @@ func TestPurgeOldData(t *testing.T) {
 	assert.Equal(t, 1, len(candles))
 	assert.Equal(t, newTime.Unix(), candles[0].OpenTime.Unix())
 }
+
+func TestMarketRepository_SearchSymbols(t *testing.T) {
+	repo, cleanup := setupTestRepository(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, s := range []struct{ symbol, base, quote string }{
+		{"WBTCUSDT", "WBTC", "USDT"},
+		{"ETHBTC", "ETH", "BTC"},
+		{"BTCDOWNUSDT", "BTCDOWN", "USDT"},
+		{"ETHUSDT", "ETH", "USDT"},
+		{"BTCUSDT", "BTC", "USDT"},
+	} {
+		require.NoError(t, repo.Create(ctx, &market.Symbol{
+			Symbol:     s.symbol,
+			BaseAsset:  s.base,
+			QuoteAsset: s.quote,
+			Exchange:   "mexc",
+			Status:     "TRADING",
+		}))
+	}
+
+	names := func(symbols []*market.Symbol) []string {
+		result := make([]string, 0, len(symbols))
+		for _, symbol := range symbols {
+			result = append(result, symbol.Symbol)
+		}
+		return result
+	}
+
+	results, err := repo.SearchSymbols(ctx, "btc", 10)
+	require.NoError(t, err)
+	// Exact base match, then pair prefix, then exact quote, then other substrings
+	assert.Equal(t, []string{"BTCUSDT", "BTCDOWNUSDT", "ETHBTC", "WBTCUSDT"}, names(results))
+
+	results, err = repo.SearchSymbols(ctx, "BTC", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BTCUSDT", "BTCDOWNUSDT"}, names(results))
+
+	results, err = repo.SearchSymbols(ctx, "ethusdt", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ETHUSDT"}, names(results))
+
+	// LIKE wildcards are matched literally
+	results, err = repo.SearchSymbols(ctx, "%", 10)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
@@ -21,6 +21,7 @@ type SymbolEntity struct {
 	AllowedOrderTypes string
 	CreatedAt         string
 	UpdatedAt         string
+	DeletedAt         gorm.DeletedAt `gorm:"index"`
 }
 
 // TableName sets the table name for SymbolEntity
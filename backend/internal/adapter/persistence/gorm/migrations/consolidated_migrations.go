@@ -21,6 +21,7 @@ func RunConsolidatedMigrations(db *gorm.DB, logger *zerolog.Logger) error {
 		&entity.EnhancedWalletEntity{},
 		&entity.EnhancedWalletBalanceEntity{},
 		&entity.EnhancedWalletBalanceHistoryEntity{},
+		&entity.WalletTransactionEntity{},
 
 		// Market data entities
 		&entity.MexcSymbolEntity{},
@@ -35,10 +36,32 @@ func RunConsolidatedMigrations(db *gorm.DB, logger *zerolog.Logger) error {
 		&entity.OrderEntity{},
 		&entity.TransactionEntity{},
 		&entity.StatusEntity{},
+		&entity.TrailingStopEntity{},
+		&entity.OrderRetryEntity{},
 
 		// Auto-buy entities
 		&entity.AutoBuyRuleEntity{},
 		&entity.AutoBuyExecutionEntity{},
+		&entity.AutoBuySpendEntity{},
+
+		// AI assistant entities
+		&entity.AIConversationEntity{},
+		&entity.AIMessageEntity{},
+
+		// Event log entities
+		&entity.NewCoinEventLogEntity{},
+		&entity.ScheduledListingEntity{},
+		&entity.ProcessedWebhookEventEntity{},
+
+		// Outbound webhooks
+		&entity.WebhookEndpointEntity{},
+		&entity.WebhookDeliveryEntity{},
+
+		// Audit trail
+		&entity.AuditLogEntity{},
+
+		// Locks of scheduled jobs
+		&entity.JobLockEntity{},
 	}
 
 	// Run migrations in a single transaction
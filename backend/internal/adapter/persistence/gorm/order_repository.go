@@ -34,6 +34,7 @@ func (r *OrderRepository) toDomain(entity *OrderEntity) *model.Order {
 		ID:            entity.ID,
 		OrderID:       entity.OrderID,
 		ClientOrderID: entity.ClientOrderID,
+		UserID:        entity.UserID,
 		Symbol:        entity.Symbol,
 		Side:          model.OrderSide(entity.Side),
 		Type:          model.OrderType(entity.Type),
@@ -53,6 +54,7 @@ func (r *OrderRepository) toEntity(order *model.Order) *OrderEntity {
 		ID:            order.ID,
 		OrderID:       order.OrderID,
 		ClientOrderID: order.ClientOrderID,
+		UserID:        order.UserID,
 		Symbol:        order.Symbol,
 		Side:          string(order.Side),
 		Type:          string(order.Type),
@@ -155,6 +157,37 @@ func (r *OrderRepository) GetBySymbol(ctx context.Context, symbol string, limit,
 	return orders, nil
 }
 
+// GetBySymbolBefore retrieves a page of a user's orders for a symbol using keyset
+// pagination, so orders created between requests do not shift later pages
+func (r *OrderRepository) GetBySymbolBefore(ctx context.Context, userID, symbol string, cursor *model.OrderCursor, limit int) ([]*model.Order, error) {
+	var entities []OrderEntity
+	query := r.db.WithContext(ctx).Where("user_id = ? AND symbol = ?", userID, symbol)
+
+	if cursor != nil {
+		query = query.Where("created_at < ? OR (created_at = ? AND id < ?)", cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+	}
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	result := query.Order("created_at DESC").Order("id DESC").Find(&entities)
+	if result.Error != nil {
+		r.logger.Error().Err(result.Error).
+			Str("userID", userID).
+			Str("symbol", symbol).
+			Msg("Failed to get order page by symbol from database")
+		return nil, result.Error
+	}
+
+	orders := make([]*model.Order, len(entities))
+	for i, entity := range entities {
+		orders[i] = r.toDomain(&entity)
+	}
+
+	return orders, nil
+}
+
 // GetByStatus retrieves orders with a specific status
 func (r *OrderRepository) GetByStatus(ctx context.Context, status model.OrderStatus, limit, offset int) ([]*model.Order, error) {
 	var entities []OrderEntity
@@ -184,6 +217,63 @@ func (r *OrderRepository) GetByStatus(ctx context.Context, status model.OrderSta
 	return orders, nil
 }
 
+// Query retrieves a page of the orders matching filter and counts all matching orders
+func (r *OrderRepository) Query(ctx context.Context, filter model.OrderFilter) ([]*model.Order, int64, error) {
+	query := r.db.WithContext(ctx).Model(&OrderEntity{})
+
+	if filter.Symbol != "" {
+		query = query.Where("symbol = ?", filter.Symbol)
+	}
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		query = query.Where("status IN ?", statuses)
+	}
+	if !filter.CreatedFrom.IsZero() {
+		query = query.Where("created_at >= ?", filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		query = query.Where("created_at < ?", filter.CreatedTo)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error().Err(err).
+			Interface("filter", filter).
+			Msg("Failed to count filtered orders in database")
+		return nil, 0, err
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var entities []OrderEntity
+	result := query.Order("created_at DESC").Order("id DESC").Find(&entities)
+	if result.Error != nil {
+		r.logger.Error().Err(result.Error).
+			Interface("filter", filter).
+			Msg("Failed to query orders from database")
+		return nil, 0, result.Error
+	}
+
+	orders := make([]*model.Order, len(entities))
+	for i, entity := range entities {
+		orders[i] = r.toDomain(&entity)
+	}
+
+	return orders, total, nil
+}
+
 // Delete removes an order from the database
 func (r *OrderRepository) Delete(ctx context.Context, id string) error {
 	result := r.db.WithContext(ctx).Delete(&OrderEntity{}, "id = ?", id)
@@ -235,12 +325,6 @@ func (r *OrderRepository) GetByClientOrderID(ctx context.Context, clientOrderID
 
 // GetByUserID retrieves orders for a specific user with pagination
 func (r *OrderRepository) GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*model.Order, error) {
-	// Since the order model currently doesn't have a user association,
-	// this is a placeholder implementation to satisfy the interface
-	// Can be updated once user-order relationships are implemented
-	r.logger.Warn().
-		Str("userID", userID).
-		Msg("GetByUserID called but user-order association not implemented yet")
-
-	return []*model.Order{}, nil
+	orders, _, err := r.Query(ctx, model.OrderFilter{UserID: userID, Limit: limit, Offset: offset})
+	return orders, err
 }
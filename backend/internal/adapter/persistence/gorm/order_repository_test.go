@@ -0,0 +1,205 @@
+package gorm
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupOrderRepository(t *testing.T) *OrderRepository {
+	db, cleanup := setupTestDB(t)
+	t.Cleanup(cleanup)
+	require.NoError(t, db.AutoMigrate(&OrderEntity{}))
+
+	logger := zerolog.Nop()
+	return NewOrderRepository(db, &logger).(*OrderRepository)
+}
+
+func createOrder(t *testing.T, repo *OrderRepository, id, symbol string, createdAt time.Time) {
+	t.Helper()
+	createUserOrder(t, repo, "alice", id, symbol, createdAt)
+}
+
+func createUserOrder(t *testing.T, repo *OrderRepository, userID, id, symbol string, createdAt time.Time) {
+	t.Helper()
+	require.NoError(t, repo.Create(context.Background(), &model.Order{
+		ID:        id,
+		UserID:    userID,
+		Symbol:    symbol,
+		Side:      model.OrderSideBuy,
+		Type:      model.OrderTypeMarket,
+		Status:    model.OrderStatusFilled,
+		Quantity:  1,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}))
+}
+
+// nextPage mirrors how the trade usecase walks pages: it resumes after the last order returned
+func nextPage(t *testing.T, repo *OrderRepository, cursor *model.OrderCursor, limit int) ([]string, *model.OrderCursor) {
+	t.Helper()
+	orders, err := repo.GetBySymbolBefore(context.Background(), "alice", "BTCUSDT", cursor, limit)
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(orders))
+	for _, order := range orders {
+		ids = append(ids, order.ID)
+	}
+	if len(orders) == 0 {
+		return ids, nil
+	}
+	last := orders[len(orders)-1]
+	return ids, &model.OrderCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+}
+
+func TestOrderRepository_GetBySymbolBefore_StableAcrossInserts(t *testing.T) {
+	repo := setupOrderRepository(t)
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 6; i++ {
+		createOrder(t, repo, fmt.Sprintf("order-%d", i), "BTCUSDT", base.Add(time.Duration(i)*time.Minute))
+	}
+	// Same timestamp as order-4, ordered after it by ID
+	createOrder(t, repo, "order-4a", "BTCUSDT", base.Add(4*time.Minute))
+	createOrder(t, repo, "eth-order", "ETHUSDT", base.Add(3*time.Minute))
+	createUserOrder(t, repo, "bob", "bob-order", "BTCUSDT", base.Add(2*time.Minute))
+
+	first, cursor := nextPage(t, repo, nil, 3)
+	assert.Equal(t, []string{"order-5", "order-4a", "order-4"}, first)
+
+	// New orders arrive between requests; with offset paging they would shift order-4
+	// onto the second page again
+	createOrder(t, repo, "order-new-1", "BTCUSDT", base.Add(time.Hour))
+	createOrder(t, repo, "order-new-2", "BTCUSDT", base.Add(2*time.Hour))
+
+	second, cursor := nextPage(t, repo, cursor, 3)
+	assert.Equal(t, []string{"order-3", "order-2", "order-1"}, second)
+
+	third, _ := nextPage(t, repo, cursor, 3)
+	assert.Equal(t, []string{"order-0"}, third)
+
+	seen := map[string]bool{}
+	for _, id := range append(append(first, second...), third...) {
+		assert.False(t, seen[id], "duplicate order %s", id)
+		seen[id] = true
+	}
+	assert.Len(t, seen, 7)
+}
+
+func TestOrderCursorRoundTrip(t *testing.T) {
+	cursor := model.OrderCursor{CreatedAt: time.Date(2026, 3, 1, 12, 0, 0, 123456789, time.UTC), ID: "order:1"}
+
+	decoded, err := model.DecodeOrderCursor(cursor.Encode())
+	require.NoError(t, err)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, cursor.ID, decoded.ID)
+
+	_, err = model.DecodeOrderCursor("not-a-cursor")
+	assert.ErrorIs(t, err, model.ErrInvalidOrderCursor)
+}
+
+// orderIDs returns the IDs of orders in order
+func orderIDs(orders []*model.Order) []string {
+	ids := make([]string, 0, len(orders))
+	for _, order := range orders {
+		ids = append(ids, order.ID)
+	}
+	return ids
+}
+
+func TestOrderRepository_Query(t *testing.T) {
+	repo := setupOrderRepository(t)
+	ctx := context.Background()
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for i, order := range []struct {
+		id, symbol, user string
+		status           model.OrderStatus
+	}{
+		{"btc-new-alice", "BTCUSDT", "alice", model.OrderStatusNew},
+		{"btc-partial-alice", "BTCUSDT", "alice", model.OrderStatusPartiallyFilled},
+		{"btc-filled-alice", "BTCUSDT", "alice", model.OrderStatusFilled},
+		{"btc-new-bob", "BTCUSDT", "bob", model.OrderStatusNew},
+		{"eth-new-alice", "ETHUSDT", "alice", model.OrderStatusNew},
+		{"btc-canceled-alice", "BTCUSDT", "alice", model.OrderStatusCanceled},
+		{"btc-partial-alice-late", "BTCUSDT", "alice", model.OrderStatusPartiallyFilled},
+	} {
+		createdAt := base.Add(time.Duration(i) * time.Hour)
+		require.NoError(t, repo.Create(ctx, &model.Order{
+			ID:        order.id,
+			UserID:    order.user,
+			Symbol:    order.symbol,
+			Side:      model.OrderSideBuy,
+			Type:      model.OrderTypeLimit,
+			Status:    order.status,
+			Quantity:  1,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}))
+	}
+	open := []model.OrderStatus{model.OrderStatusNew, model.OrderStatusPartiallyFilled}
+
+	t.Run("combined filters", func(t *testing.T) {
+		orders, total, err := repo.Query(ctx, model.OrderFilter{
+			Symbol:      "BTCUSDT",
+			UserID:      "alice",
+			Statuses:    open,
+			CreatedFrom: base,
+			CreatedTo:   base.Add(6 * time.Hour),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, []string{"btc-partial-alice", "btc-new-alice"}, orderIDs(orders))
+		assert.Equal(t, "alice", orders[0].UserID)
+	})
+
+	t.Run("created range bounds", func(t *testing.T) {
+		// CreatedFrom is inclusive and CreatedTo exclusive
+		orders, total, err := repo.Query(ctx, model.OrderFilter{
+			CreatedFrom: base.Add(time.Hour),
+			CreatedTo:   base.Add(3 * time.Hour),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, []string{"btc-filled-alice", "btc-partial-alice"}, orderIDs(orders))
+	})
+
+	t.Run("count ignores pagination", func(t *testing.T) {
+		filter := model.OrderFilter{Symbol: "BTCUSDT", Statuses: open, Limit: 2}
+		first, total, err := repo.Query(ctx, filter)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), total)
+		assert.Equal(t, []string{"btc-partial-alice-late", "btc-new-bob"}, orderIDs(first))
+
+		filter.Offset = 2
+		second, total, err := repo.Query(ctx, filter)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), total)
+		assert.Equal(t, []string{"btc-partial-alice", "btc-new-alice"}, orderIDs(second))
+
+		filter.Offset = 4
+		beyond, total, err := repo.Query(ctx, filter)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), total)
+		assert.Empty(t, beyond)
+	})
+
+	t.Run("empty filter matches everything", func(t *testing.T) {
+		orders, total, err := repo.Query(ctx, model.OrderFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), total)
+		assert.Len(t, orders, 7)
+	})
+
+	t.Run("user orders", func(t *testing.T) {
+		orders, err := repo.GetByUserID(ctx, "bob", 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"btc-new-bob"}, orderIDs(orders))
+	})
+}
@@ -0,0 +1,87 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// DefaultSlowQueryThreshold is used when no slow query threshold is configured
+const DefaultSlowQueryThreshold = 200 * time.Millisecond
+
+// QueryLogger is a GORM logger that writes to zerolog. Queries taking longer than the
+// slow threshold are logged at warn level with their SQL and the rows affected; at the
+// Info log mode every query is logged at debug level.
+type QueryLogger struct {
+	logger        zerolog.Logger
+	level         gormlogger.LogLevel
+	slowThreshold time.Duration
+}
+
+// NewQueryLogger creates a QueryLogger. A zero slowThreshold disables slow query logging.
+func NewQueryLogger(logger zerolog.Logger, level gormlogger.LogLevel, slowThreshold time.Duration) *QueryLogger {
+	return &QueryLogger{
+		logger:        logger.With().Str("component", "gorm").Logger(),
+		level:         level,
+		slowThreshold: slowThreshold,
+	}
+}
+
+// LogMode returns a copy of the logger logging at level
+func (l *QueryLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	copied := *l
+	copied.level = level
+	return &copied
+}
+
+// Info logs a GORM info message
+func (l *QueryLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		l.logger.Info().Msg(fmt.Sprintf(msg, args...))
+	}
+}
+
+// Warn logs a GORM warning
+func (l *QueryLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		l.logger.Warn().Msg(fmt.Sprintf(msg, args...))
+	}
+}
+
+// Error logs a GORM error
+func (l *QueryLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		l.logger.Error().Msg(fmt.Sprintf(msg, args...))
+	}
+}
+
+// Trace logs a finished query. Failed queries are logged at error level, except for
+// record not found, and slow queries at warn level.
+func (l *QueryLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= gormlogger.Error:
+		sql, rows := fc()
+		l.logger.Error().Err(err).Str("sql", sql).Int64("rows", rows).Dur("elapsed", elapsed).Msg("Query failed")
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormlogger.Warn:
+		sql, rows := fc()
+		l.logger.Warn().
+			Str("sql", sql).
+			Int64("rows", rows).
+			Dur("elapsed", elapsed).
+			Dur("threshold", l.slowThreshold).
+			Msg("Slow query")
+	case l.level >= gormlogger.Info:
+		sql, rows := fc()
+		l.logger.Debug().Str("sql", sql).Int64("rows", rows).Dur("elapsed", elapsed).Msg("Query")
+	}
+}
@@ -0,0 +1,47 @@
+package gorm
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+type slowQueryRow struct {
+	ID   uint
+	Name string
+}
+
+func TestQueryLogger_LogsOnlySlowQueries(t *testing.T) {
+	var buf bytes.Buffer
+	queryLogger := NewQueryLogger(zerolog.New(&buf), gormlogger.Warn, 50*time.Millisecond)
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: queryLogger})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&slowQueryRow{}))
+
+	// Queries on the "slow" row sleep past the threshold before running
+	require.NoError(t, db.Callback().Query().Before("gorm:query").Register("test:sleep", func(tx *gorm.DB) {
+		if _, ok := tx.Statement.Settings.Load("test:slow"); ok {
+			time.Sleep(80 * time.Millisecond)
+		}
+	}))
+	require.NoError(t, db.Create(&slowQueryRow{Name: "fast"}).Error)
+	buf.Reset()
+
+	var rows []slowQueryRow
+	require.NoError(t, db.Where("name = ?", "fast").Find(&rows).Error)
+	assert.Empty(t, buf.String(), "a fast query is not logged")
+
+	require.NoError(t, db.Set("test:slow", true).Where("name = ?", "fast").Find(&rows).Error)
+	logged := buf.String()
+	assert.Contains(t, logged, `"level":"warn"`)
+	assert.Contains(t, logged, `"message":"Slow query"`)
+	assert.Contains(t, logged, "SELECT * FROM `slow_query_rows` WHERE name = \\\"fast\\\"")
+	assert.Contains(t, logged, `"rows":1`)
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
 	"github.com/google/uuid"
@@ -13,26 +14,6 @@ import (
 	"gorm.io/gorm"
 )
 
-// AIConversationEntity represents an AI conversation in the database
-type AIConversationEntity struct {
-	ID        string    `gorm:"primaryKey;type:varchar(50)"`
-	UserID    string    `gorm:"index;type:varchar(50)"`
-	Title     string    `gorm:"type:varchar(255)"`
-	Tags      string    `gorm:"type:text"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime"`
-}
-
-// AIMessageEntity represents an AI message in the database
-type AIMessageEntity struct {
-	ID             string `gorm:"primaryKey;type:varchar(50)"`
-	ConversationID string `gorm:"index;type:varchar(50)"`
-	Role           string `gorm:"type:varchar(20)"`
-	Content        string `gorm:"type:text"`
-	Timestamp      time.Time
-	Metadata       []byte `gorm:"type:json"`
-}
-
 // GormAIConversationRepository implements port.ConversationMemoryRepository using GORM
 type GormAIConversationRepository struct {
 	BaseRepository
@@ -66,7 +47,7 @@ func (r *GormAIConversationRepository) SaveConversation(ctx context.Context, con
 	}
 
 	// Create entity
-	entity := &AIConversationEntity{
+	record := &entity.AIConversationEntity{
 		ID:        conversation.ID,
 		UserID:    conversation.UserID,
 		Title:     conversation.Title,
@@ -76,25 +57,25 @@ func (r *GormAIConversationRepository) SaveConversation(ctx context.Context, con
 	}
 
 	// Save entity
-	return r.Upsert(ctx, entity, []string{"id"}, []string{
+	return r.Upsert(ctx, record, []string{"id"}, []string{
 		"user_id", "title", "tags", "updated_at",
 	})
 }
 
 // GetConversation retrieves a conversation by ID
 func (r *GormAIConversationRepository) GetConversation(ctx context.Context, id string) (*model.AIConversation, error) {
-	var entity AIConversationEntity
-	err := r.FindOne(ctx, &entity, "id = ?", id)
+	var record entity.AIConversationEntity
+	err := r.FindOne(ctx, &record, "id = ?", id)
 	if err != nil {
 		return nil, err
 	}
 
-	if entity.ID == "" {
-		return nil, nil // Not found
+	if record.ID == "" {
+		return nil, model.ErrConversationNotFound
 	}
 
 	// Get messages for this conversation
-	var messageEntities []AIMessageEntity
+	var messageEntities []entity.AIMessageEntity
 	err = r.GetDB(ctx).
 		Where("conversation_id = ?", id).
 		Order("timestamp ASC").
@@ -105,7 +86,7 @@ func (r *GormAIConversationRepository) GetConversation(ctx context.Context, id s
 	}
 
 	// Convert to domain model
-	conversation := r.toDomain(&entity)
+	conversation := r.toDomain(&record)
 	conversation.Messages = r.messagesToDomain(messageEntities)
 
 	return conversation, nil
@@ -113,22 +94,22 @@ func (r *GormAIConversationRepository) GetConversation(ctx context.Context, id s
 
 // ListConversations lists conversations for a user
 func (r *GormAIConversationRepository) ListConversations(ctx context.Context, userID string, limit, offset int) ([]*model.AIConversation, error) {
-	var entities []AIConversationEntity
+	var records []entity.AIConversationEntity
 	err := r.GetDB(ctx).
 		Where("user_id = ?", userID).
 		Order("updated_at DESC").
 		Limit(limit).
 		Offset(offset).
-		Find(&entities).Error
+		Find(&records).Error
 	if err != nil {
 		r.logger.Error().Err(err).Str("user_id", userID).Msg("Failed to list conversations")
 		return nil, err
 	}
 
 	// Convert to domain models
-	conversations := make([]*model.AIConversation, len(entities))
-	for i, entity := range entities {
-		conversations[i] = r.toDomain(&entity)
+	conversations := make([]*model.AIConversation, len(records))
+	for i, record := range records {
+		conversations[i] = r.toDomain(&record)
 	}
 
 	return conversations, nil
@@ -139,7 +120,7 @@ func (r *GormAIConversationRepository) SaveMessage(ctx context.Context, message
 	// Check if the conversation exists
 	var count int64
 	err := r.GetDB(ctx).
-		Model(&AIConversationEntity{}).
+		Model(&entity.AIConversationEntity{}).
 		Where("id = ?", message.ConversationID).
 		Count(&count).Error
 	if err != nil {
@@ -147,7 +128,7 @@ func (r *GormAIConversationRepository) SaveMessage(ctx context.Context, message
 		return err
 	}
 	if count == 0 {
-		return errors.New("conversation not found")
+		return model.ErrConversationNotFound
 	}
 
 	// If the message doesn't have an ID, generate one
@@ -172,7 +153,7 @@ func (r *GormAIConversationRepository) SaveMessage(ctx context.Context, message
 	}
 
 	// Create entity
-	entity := &AIMessageEntity{
+	record := &entity.AIMessageEntity{
 		ID:             message.ID,
 		ConversationID: message.ConversationID,
 		Role:           message.Role,
@@ -182,15 +163,15 @@ func (r *GormAIConversationRepository) SaveMessage(ctx context.Context, message
 	}
 
 	// Save entity
-	return r.Create(ctx, entity)
+	return r.Create(ctx, record)
 }
 
-// GetMessages retrieves messages for a conversation
+// GetMessages retrieves messages for a conversation, newest first
 func (r *GormAIConversationRepository) GetMessages(ctx context.Context, conversationID string, limit, offset int) ([]*model.AIMessage, error) {
 	// Check if the conversation exists
 	var count int64
 	err := r.GetDB(ctx).
-		Model(&AIConversationEntity{}).
+		Model(&entity.AIConversationEntity{}).
 		Where("id = ?", conversationID).
 		Count(&count).Error
 	if err != nil {
@@ -198,24 +179,24 @@ func (r *GormAIConversationRepository) GetMessages(ctx context.Context, conversa
 		return nil, err
 	}
 	if count == 0 {
-		return nil, errors.New("conversation not found")
+		return nil, model.ErrConversationNotFound
 	}
 
 	// Get messages
-	var entities []AIMessageEntity
+	var records []entity.AIMessageEntity
 	err = r.GetDB(ctx).
 		Where("conversation_id = ?", conversationID).
-		Order("timestamp ASC").
+		Order("timestamp DESC").
 		Limit(limit).
 		Offset(offset).
-		Find(&entities).Error
+		Find(&records).Error
 	if err != nil {
 		r.logger.Error().Err(err).Str("conversation_id", conversationID).Msg("Failed to get messages")
 		return nil, err
 	}
 
 	// Convert to domain models
-	messages := r.messagesToDomain(entities)
+	messages := r.messagesToDomain(records)
 
 	// Convert to pointer slice
 	result := make([]*model.AIMessage, len(messages))
@@ -231,12 +212,12 @@ func (r *GormAIConversationRepository) DeleteConversation(ctx context.Context, i
 	// Use a transaction to delete the conversation and its messages
 	return r.Transaction(ctx, func(tx *gorm.DB) error {
 		// Delete messages first (due to foreign key constraint)
-		if err := tx.Where("conversation_id = ?", id).Delete(&AIMessageEntity{}).Error; err != nil {
+		if err := tx.Where("conversation_id = ?", id).Delete(&entity.AIMessageEntity{}).Error; err != nil {
 			return err
 		}
 
 		// Delete the conversation
-		if err := tx.Delete(&AIConversationEntity{}, "id = ?", id).Error; err != nil {
+		if err := tx.Delete(&entity.AIConversationEntity{}, "id = ?", id).Error; err != nil {
 			return err
 		}
 
@@ -247,59 +228,59 @@ func (r *GormAIConversationRepository) DeleteConversation(ctx context.Context, i
 // Helper methods for entity conversion
 
 // toDomain converts a database entity to a domain model
-func (r *GormAIConversationRepository) toDomain(entity *AIConversationEntity) *model.AIConversation {
-	if entity == nil {
+func (r *GormAIConversationRepository) toDomain(record *entity.AIConversationEntity) *model.AIConversation {
+	if record == nil {
 		return nil
 	}
 
 	// Parse tags
 	var tags []string
-	if entity.Tags != "" {
-		if err := json.Unmarshal([]byte(entity.Tags), &tags); err != nil {
+	if record.Tags != "" {
+		if err := json.Unmarshal([]byte(record.Tags), &tags); err != nil {
 			r.logger.Error().Err(err).Msg("Failed to unmarshal conversation tags")
 		}
 	}
 
 	return &model.AIConversation{
-		ID:        entity.ID,
-		UserID:    entity.UserID,
-		Title:     entity.Title,
+		ID:        record.ID,
+		UserID:    record.UserID,
+		Title:     record.Title,
 		Tags:      tags,
 		Messages:  []model.AIMessage{}, // Will be populated separately
-		CreatedAt: entity.CreatedAt,
-		UpdatedAt: entity.UpdatedAt,
+		CreatedAt: record.CreatedAt,
+		UpdatedAt: record.UpdatedAt,
 	}
 }
 
 // messageToDomain converts a message entity to a domain model
-func (r *GormAIConversationRepository) messageToDomain(entity *AIMessageEntity) *model.AIMessage {
-	if entity == nil {
+func (r *GormAIConversationRepository) messageToDomain(record *entity.AIMessageEntity) *model.AIMessage {
+	if record == nil {
 		return nil
 	}
 
 	// Parse metadata
 	var metadata map[string]interface{}
-	if len(entity.Metadata) > 0 {
-		if err := json.Unmarshal(entity.Metadata, &metadata); err != nil {
+	if len(record.Metadata) > 0 {
+		if err := json.Unmarshal(record.Metadata, &metadata); err != nil {
 			r.logger.Error().Err(err).Msg("Failed to unmarshal message metadata")
 		}
 	}
 
 	return &model.AIMessage{
-		ID:             entity.ID,
-		ConversationID: entity.ConversationID,
-		Role:           entity.Role,
-		Content:        entity.Content,
-		Timestamp:      entity.Timestamp,
+		ID:             record.ID,
+		ConversationID: record.ConversationID,
+		Role:           record.Role,
+		Content:        record.Content,
+		Timestamp:      record.Timestamp,
 		Metadata:       metadata,
 	}
 }
 
 // messagesToDomain converts message entities to domain models
-func (r *GormAIConversationRepository) messagesToDomain(entities []AIMessageEntity) []model.AIMessage {
-	messages := make([]model.AIMessage, len(entities))
-	for i, entity := range entities {
-		msg := r.messageToDomain(&entity)
+func (r *GormAIConversationRepository) messagesToDomain(records []entity.AIMessageEntity) []model.AIMessage {
+	messages := make([]model.AIMessage, len(records))
+	for i, record := range records {
+		msg := r.messageToDomain(&record)
 		if msg != nil {
 			messages[i] = *msg
 		}
@@ -312,8 +293,8 @@ var _ port.ConversationMemoryRepository = (*GormAIConversationRepository)(nil)
 
 // GetMessage gets a message by ID
 func (r *GormAIConversationRepository) GetMessage(ctx context.Context, messageID string) (*model.AIMessage, error) {
-	var entity AIMessageEntity
-	result := r.db.Where("id = ?", messageID).First(&entity)
+	var record entity.AIMessageEntity
+	result := r.db.Where("id = ?", messageID).First(&record)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, errors.New("message not found")
@@ -321,12 +302,12 @@ func (r *GormAIConversationRepository) GetMessage(ctx context.Context, messageID
 		return nil, result.Error
 	}
 
-	return r.messageToDomain(&entity), nil
+	return r.messageToDomain(&record), nil
 }
 
 // DeleteMessage deletes a message
 func (r *GormAIConversationRepository) DeleteMessage(ctx context.Context, messageID string) error {
-	result := r.db.Where("id = ?", messageID).Delete(&AIMessageEntity{})
+	result := r.db.Where("id = ?", messageID).Delete(&entity.AIMessageEntity{})
 	if result.Error != nil {
 		return result.Error
 	}
@@ -0,0 +1,87 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newTestAIConversationRepository(t *testing.T) *GormAIConversationRepository {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.AIConversationEntity{}, &entity.AIMessageEntity{}))
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	return NewGormAIConversationRepository(db, &logger)
+}
+
+func TestGormAIConversationRepository_MessagesNewestFirst(t *testing.T) {
+	repo := newTestAIConversationRepository(t)
+	ctx := context.Background()
+
+	require.NoError(t, repo.SaveConversation(ctx, &model.AIConversation{ID: "conv-1", UserID: "user-1", Title: "BTC"}))
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i, content := range []string{"first", "second", "third"} {
+		require.NoError(t, repo.SaveMessage(ctx, &model.AIMessage{
+			ConversationID: "conv-1",
+			Role:           "user",
+			Content:        content,
+			Timestamp:      start.Add(time.Duration(i) * time.Minute),
+			Metadata:       map[string]interface{}{"turn": float64(i)},
+		}))
+	}
+
+	recent, err := repo.GetMessages(ctx, "conv-1", 2, 0)
+	require.NoError(t, err)
+	require.Len(t, recent, 2)
+	assert.Equal(t, "third", recent[0].Content)
+	assert.Equal(t, "second", recent[1].Content)
+	assert.Equal(t, float64(1), recent[1].Metadata["turn"])
+
+	conversation, err := repo.GetConversation(ctx, "conv-1")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", conversation.UserID)
+	assert.Len(t, conversation.Messages, 3)
+}
+
+func TestGormAIConversationRepository_NotFound(t *testing.T) {
+	repo := newTestAIConversationRepository(t)
+	ctx := context.Background()
+
+	_, err := repo.GetConversation(ctx, "missing")
+	assert.ErrorIs(t, err, model.ErrConversationNotFound)
+
+	err = repo.SaveMessage(ctx, &model.AIMessage{ConversationID: "missing", Content: "hi"})
+	assert.ErrorIs(t, err, model.ErrConversationNotFound)
+}
+
+func TestGormAIConversationRepository_ListAndDelete(t *testing.T) {
+	repo := newTestAIConversationRepository(t)
+	ctx := context.Background()
+
+	require.NoError(t, repo.SaveConversation(ctx, &model.AIConversation{ID: "conv-1", UserID: "user-1"}))
+	require.NoError(t, repo.SaveConversation(ctx, &model.AIConversation{ID: "conv-2", UserID: "user-1"}))
+	require.NoError(t, repo.SaveConversation(ctx, &model.AIConversation{ID: "conv-3", UserID: "user-2"}))
+	require.NoError(t, repo.SaveMessage(ctx, &model.AIMessage{ConversationID: "conv-1", Content: "hi"}))
+
+	conversations, err := repo.ListConversations(ctx, "user-1", 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, conversations, 2)
+
+	require.NoError(t, repo.DeleteConversation(ctx, "conv-1"))
+	_, err = repo.GetConversation(ctx, "conv-1")
+	assert.ErrorIs(t, err, model.ErrConversationNotFound)
+
+	var remaining int64
+	require.NoError(t, repo.db.Model(&entity.AIMessageEntity{}).Where("conversation_id = ?", "conv-1").Count(&remaining).Error)
+	assert.Zero(t, remaining)
+}
@@ -0,0 +1,174 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/logger"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+// auditVerifyBatchSize is the number of entries read per query while verifying
+const auditVerifyBatchSize = 500
+
+// auditAppendMu serializes appends across every GormAuditLogger in the process, so two
+// entries never chain to the same predecessor
+var auditAppendMu sync.Mutex
+
+// GormAuditLogger implements port.AuditLogger on the audit_log table
+type GormAuditLogger struct {
+	BaseRepository
+	now func() time.Time
+}
+
+// NewGormAuditLogger creates a new GormAuditLogger
+func NewGormAuditLogger(db *gorm.DB, logger *zerolog.Logger) *GormAuditLogger {
+	return &GormAuditLogger{
+		BaseRepository: NewBaseRepository(db, logger),
+		now:            time.Now,
+	}
+}
+
+var _ port.AuditLogger = (*GormAuditLogger)(nil)
+
+// Record appends entry to the trail, chained to the latest entry
+func (l *GormAuditLogger) Record(ctx context.Context, entry *model.AuditEntry) error {
+	if entry.Actor == "" {
+		entry.Actor = model.AuditActorSystem
+	}
+	if entry.RequestID == "" {
+		entry.RequestID = logger.RequestIDFromContext(ctx)
+	}
+	// Stored timestamps keep microseconds on every supported database
+	entry.Timestamp = l.now().UTC().Truncate(time.Microsecond)
+
+	details := ""
+	if len(entry.Details) == 0 {
+		// Stored as empty, which reads back as nil; hash it the same way
+		entry.Details = nil
+	} else {
+		data, err := json.Marshal(entry.Details)
+		if err != nil {
+			return fmt.Errorf("failed to marshal audit details: %w", err)
+		}
+		details = string(data)
+	}
+
+	auditAppendMu.Lock()
+	defer auditAppendMu.Unlock()
+
+	err := l.GetDB(ctx).Transaction(func(tx *gorm.DB) error {
+		var last entity.AuditLogEntity
+		err := tx.Order("sequence DESC").Limit(1).Take(&last).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			entry.Sequence, entry.PrevHash = 1, ""
+		case err != nil:
+			return err
+		default:
+			entry.Sequence, entry.PrevHash = last.Sequence+1, last.Hash
+		}
+		entry.Hash = entry.ComputeHash()
+
+		return tx.Create(&entity.AuditLogEntity{
+			Sequence:     entry.Sequence,
+			Actor:        entry.Actor,
+			Action:       string(entry.Action),
+			ResourceType: entry.ResourceType,
+			ResourceID:   entry.ResourceID,
+			Outcome:      string(entry.Outcome),
+			Details:      details,
+			RequestID:    entry.RequestID,
+			Timestamp:    entry.Timestamp,
+			PrevHash:     entry.PrevHash,
+			Hash:         entry.Hash,
+		}).Error
+	})
+	if err != nil {
+		l.logger.Error().Err(err).Str("action", string(entry.Action)).Msg("Failed to record audit entry")
+		return err
+	}
+	return nil
+}
+
+// Verify walks the trail in sequence order, recomputing every hash. It stops at the
+// first entry that was changed, removed or inserted out of order.
+func (l *GormAuditLogger) Verify(ctx context.Context) (*model.AuditVerification, error) {
+	result := &model.AuditVerification{Valid: true}
+	prevHash := ""
+	expected := int64(1)
+
+	for {
+		var records []entity.AuditLogEntity
+		err := l.GetDB(ctx).
+			Where("sequence >= ?", expected).
+			Order("sequence ASC").
+			Limit(auditVerifyBatchSize).
+			Find(&records).Error
+		if err != nil {
+			l.logger.Error().Err(err).Msg("Failed to read audit log")
+			return nil, err
+		}
+
+		for _, record := range records {
+			entry, err := auditEntryFromEntity(record)
+			if err != nil {
+				return auditChainBroken(result, record.Sequence, err.Error()), nil
+			}
+			switch {
+			case entry.Sequence != expected:
+				return auditChainBroken(result, expected, fmt.Sprintf("entry %d is missing", expected)), nil
+			case entry.PrevHash != prevHash:
+				return auditChainBroken(result, entry.Sequence, "previous hash does not match the preceding entry"), nil
+			case entry.ComputeHash() != entry.Hash:
+				return auditChainBroken(result, entry.Sequence, "entry hash does not match its contents"), nil
+			}
+			result.Checked++
+			prevHash = entry.Hash
+			expected++
+		}
+
+		if len(records) < auditVerifyBatchSize {
+			return result, nil
+		}
+	}
+}
+
+// auditChainBroken marks result as failing at sequence
+func auditChainBroken(result *model.AuditVerification, sequence int64, reason string) *model.AuditVerification {
+	result.Valid = false
+	result.BrokenAt = sequence
+	result.Reason = reason
+	return result
+}
+
+// auditEntryFromEntity converts a stored row back to an audit entry
+func auditEntryFromEntity(record entity.AuditLogEntity) (*model.AuditEntry, error) {
+	var details map[string]string
+	if record.Details != "" {
+		if err := json.Unmarshal([]byte(record.Details), &details); err != nil {
+			return nil, fmt.Errorf("entry details are not valid JSON: %w", err)
+		}
+	}
+	return &model.AuditEntry{
+		Sequence:     record.Sequence,
+		Actor:        record.Actor,
+		Action:       model.AuditAction(record.Action),
+		ResourceType: record.ResourceType,
+		ResourceID:   record.ResourceID,
+		Outcome:      model.AuditOutcome(record.Outcome),
+		Details:      details,
+		RequestID:    record.RequestID,
+		Timestamp:    record.Timestamp,
+		PrevHash:     record.PrevHash,
+		Hash:         record.Hash,
+	}, nil
+}
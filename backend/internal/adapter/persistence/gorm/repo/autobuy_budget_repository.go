@@ -0,0 +1,136 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+// GormAutoBuyBudgetTracker persists autobuy spend so budget caps survive restarts. It
+// implements usecase.BudgetTracker.
+type GormAutoBuyBudgetTracker struct {
+	BaseRepository
+}
+
+// NewGormAutoBuyBudgetTracker creates a new GormAutoBuyBudgetTracker
+func NewGormAutoBuyBudgetTracker(db *gorm.DB, logger *zerolog.Logger) *GormAutoBuyBudgetTracker {
+	return &GormAutoBuyBudgetTracker{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// Usage returns the total quote amount spent and the number of open positions
+func (r *GormAutoBuyBudgetTracker) Usage() (float64, int, error) {
+	ctx := context.Background()
+
+	var spent float64
+	if err := r.GetDB(ctx).Model(&entity.AutoBuySpendEntity{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&spent).Error; err != nil {
+		r.logger.Error().Err(err).Msg("Failed to sum autobuy spend")
+		return 0, 0, err
+	}
+
+	var open int64
+	if err := r.GetDB(ctx).Model(&entity.AutoBuySpendEntity{}).
+		Where("open = ?", true).
+		Count(&open).Error; err != nil {
+		r.logger.Error().Err(err).Msg("Failed to count open autobuy positions")
+		return 0, 0, err
+	}
+
+	return spent, int(open), nil
+}
+
+// RecordBuy records a completed buy as an open position
+func (r *GormAutoBuyBudgetTracker) RecordBuy(symbol string, amount, quantity float64) error {
+	e := &entity.AutoBuySpendEntity{
+		Symbol:    symbol,
+		Amount:    amount,
+		Quantity:  quantity,
+		Remaining: quantity,
+		Open:      true,
+	}
+	if err := r.Create(context.Background(), e); err != nil {
+		r.logger.Error().Err(err).Str("symbol", symbol).Msg("Failed to record autobuy spend")
+		return err
+	}
+	return nil
+}
+
+// RecordSell takes the sold quantity off the open positions for symbol, oldest first, and
+// closes each position that is sold off
+func (r *GormAutoBuyBudgetTracker) RecordSell(symbol string, quantity float64) error {
+	ctx := context.Background()
+
+	err := r.GetDB(ctx).Transaction(func(tx *gorm.DB) error {
+		var positions []entity.AutoBuySpendEntity
+		if err := tx.Where("symbol = ? AND open = ?", symbol, true).
+			Order("created_at ASC").
+			Find(&positions).Error; err != nil {
+			return err
+		}
+
+		for _, e := range positions {
+			if quantity <= 0 {
+				break
+			}
+			sold := math.Min(quantity, e.Remaining)
+			quantity -= sold
+			remaining := e.Remaining - sold
+			updates := map[string]interface{}{"remaining": remaining}
+			soldOff := model.AutoBuyPositionSoldOff(e.Quantity, remaining)
+			if soldOff {
+				now := time.Now()
+				updates["open"] = false
+				updates["closed_at"] = &now
+			}
+			if err := tx.Model(&e).Updates(updates).Error; err != nil {
+				return err
+			}
+			if !soldOff {
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Error().Err(err).Str("symbol", symbol).Msg("Failed to record autobuy sell")
+		return err
+	}
+	return nil
+}
+
+// ReleasePosition closes the oldest open position for symbol
+func (r *GormAutoBuyBudgetTracker) ReleasePosition(symbol string) error {
+	ctx := context.Background()
+
+	var e entity.AutoBuySpendEntity
+	err := r.GetDB(ctx).
+		Where("symbol = ? AND open = ?", symbol, true).
+		Order("created_at ASC").
+		First(&e).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		r.logger.Error().Err(err).Str("symbol", symbol).Msg("Failed to find open autobuy position")
+		return err
+	}
+
+	now := time.Now()
+	if err := r.GetDB(ctx).Model(&e).Updates(map[string]interface{}{
+		"open":      false,
+		"closed_at": &now,
+	}).Error; err != nil {
+		r.logger.Error().Err(err).Str("symbol", symbol).Msg("Failed to release autobuy position")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGormAutoBuyBudgetTracker_SurvivesRestart(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.AutoBuySpendEntity{}))
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+
+	tracker := NewGormAutoBuyBudgetTracker(db, &logger)
+	spent, open, err := tracker.Usage()
+	require.NoError(t, err)
+	assert.Zero(t, spent)
+	assert.Zero(t, open)
+
+	require.NoError(t, tracker.RecordBuy("AAAUSDT", 50, 1))
+	require.NoError(t, tracker.RecordBuy("BBBUSDT", 25.5, 2))
+
+	// A new tracker over the same database sees the recorded usage
+	restarted := NewGormAutoBuyBudgetTracker(db, &logger)
+	spent, open, err = restarted.Usage()
+	require.NoError(t, err)
+	assert.InDelta(t, 75.5, spent, 1e-9)
+	assert.Equal(t, 2, open)
+
+	// Releasing closes the position but keeps the spend
+	require.NoError(t, restarted.ReleasePosition("AAAUSDT"))
+	require.NoError(t, restarted.ReleasePosition("UNKNOWN"))
+	spent, open, err = restarted.Usage()
+	require.NoError(t, err)
+	assert.InDelta(t, 75.5, spent, 1e-9)
+	assert.Equal(t, 1, open)
+}
+
+func TestGormAutoBuyBudgetTracker_RecordSellClosesSoldOffPositions(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.AutoBuySpendEntity{}))
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	tracker := NewGormAutoBuyBudgetTracker(db, &logger)
+
+	require.NoError(t, tracker.RecordBuy("AAAUSDT", 50, 2))
+
+	// A partial sell keeps the position open
+	require.NoError(t, tracker.RecordSell("AAAUSDT", 1))
+	_, open, err := tracker.Usage()
+	require.NoError(t, err)
+	assert.Equal(t, 1, open)
+
+	// Selling the rest closes it, and sells of other symbols leave it alone
+	require.NoError(t, tracker.RecordSell("BBBUSDT", 5))
+	require.NoError(t, tracker.RecordSell("AAAUSDT", 1))
+	spent, open, err := tracker.Usage()
+	require.NoError(t, err)
+	assert.InDelta(t, 50, spent, 1e-9)
+	assert.Zero(t, open)
+}
@@ -0,0 +1,125 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+// DefaultEventLogMaxEvents is how many events the event log keeps unless configured otherwise
+const DefaultEventLogMaxEvents = 10000
+
+// GormEventStore implements port.EventStore on the new_coin_event_log table
+type GormEventStore struct {
+	BaseRepository
+	maxEvents int64
+}
+
+// NewGormEventStore creates a new GormEventStore
+func NewGormEventStore(db *gorm.DB, logger *zerolog.Logger) *GormEventStore {
+	return &GormEventStore{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// WithMaxEvents caps the log at the most recent maxEvents events; older events are
+// removed as new ones are appended. A non-positive maxEvents keeps every event.
+func (s *GormEventStore) WithMaxEvents(maxEvents int) *GormEventStore {
+	s.maxEvents = int64(maxEvents)
+	return s
+}
+
+// Append records the event and returns its offset
+func (s *GormEventStore) Append(ctx context.Context, event *model.NewCoinEvent) (int64, error) {
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	var data []byte
+	if event.Data != nil {
+		var err error
+		if data, err = json.Marshal(event.Data); err != nil {
+			return 0, fmt.Errorf("failed to marshal event data: %w", err)
+		}
+	}
+
+	record := &entity.NewCoinEventLogEntity{
+		EventID:   event.ID,
+		CoinID:    event.CoinID,
+		EventType: event.EventType,
+		OldStatus: string(event.OldStatus),
+		NewStatus: string(event.NewStatus),
+		Data:      data,
+		CreatedAt: event.CreatedAt,
+	}
+	if err := s.GetDB(ctx).Create(record).Error; err != nil {
+		s.logger.Error().Err(err).Str("eventID", event.ID).Msg("Failed to append event to log")
+		return 0, err
+	}
+
+	// The event is recorded, so failing to trim only delays it to the next append
+	if s.maxEvents > 0 && record.Offset > s.maxEvents {
+		err := s.GetDB(ctx).Where("log_offset <= ?", record.Offset-s.maxEvents).Delete(&entity.NewCoinEventLogEntity{}).Error
+		if err != nil {
+			s.logger.Error().Err(err).Int64("offset", record.Offset).Msg("Failed to trim event log")
+		}
+	}
+
+	return record.Offset, nil
+}
+
+// ReadFrom returns up to limit events starting at offset. A non-positive limit reads
+// the rest of the log.
+func (s *GormEventStore) ReadFrom(ctx context.Context, offset int64, limit int) ([]*model.StoredNewCoinEvent, error) {
+	query := s.GetDB(ctx).
+		Where("log_offset >= ?", offset).
+		Order("log_offset ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var records []entity.NewCoinEventLogEntity
+	if err := query.Find(&records).Error; err != nil {
+		s.logger.Error().Err(err).Int64("offset", offset).Msg("Failed to read event log")
+		return nil, err
+	}
+
+	events := make([]*model.StoredNewCoinEvent, 0, len(records))
+	for _, record := range records {
+		var data interface{}
+		if len(record.Data) > 0 {
+			if err := json.Unmarshal(record.Data, &data); err != nil {
+				s.logger.Error().Err(err).Int64("offset", record.Offset).Msg("Failed to unmarshal event data")
+			}
+		}
+
+		events = append(events, &model.StoredNewCoinEvent{
+			Offset: record.Offset,
+			Event: &model.NewCoinEvent{
+				ID:        record.EventID,
+				CoinID:    record.CoinID,
+				EventType: record.EventType,
+				OldStatus: model.Status(record.OldStatus),
+				NewStatus: model.Status(record.NewStatus),
+				Data:      data,
+				CreatedAt: record.CreatedAt,
+			},
+		})
+	}
+
+	return events, nil
+}
+
+// Ensure GormEventStore implements port.EventStore
+var _ port.EventStore = (*GormEventStore)(nil)
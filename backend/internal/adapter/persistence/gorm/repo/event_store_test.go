@@ -0,0 +1,72 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGormEventStore_AppendAndReadFrom(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.NewCoinEventLogEntity{}))
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	store := NewGormEventStore(db, &logger)
+	ctx := context.Background()
+
+	var offsets []int64
+	for _, status := range []model.Status{model.StatusExpected, model.StatusTrading, model.StatusFailed} {
+		offset, err := store.Append(ctx, &model.NewCoinEvent{
+			CoinID:    "coin-1",
+			EventType: "status_changed",
+			NewStatus: status,
+			Data:      map[string]interface{}{"symbol": "NEWUSDT"},
+		})
+		require.NoError(t, err)
+		offsets = append(offsets, offset)
+	}
+	assert.Less(t, offsets[0], offsets[1])
+	assert.Less(t, offsets[1], offsets[2])
+
+	all, err := store.ReadFrom(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, model.StatusExpected, all[0].Event.NewStatus)
+	assert.NotEmpty(t, all[0].Event.ID)
+	assert.Equal(t, map[string]interface{}{"symbol": "NEWUSDT"}, all[0].Event.Data)
+
+	tail, err := store.ReadFrom(ctx, offsets[1], 1)
+	require.NoError(t, err)
+	require.Len(t, tail, 1)
+	assert.Equal(t, offsets[1], tail[0].Offset)
+	assert.Equal(t, model.StatusTrading, tail[0].Event.NewStatus)
+}
+
+func TestGormEventStore_MaxEventsTrimsOldestEvents(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.NewCoinEventLogEntity{}))
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	store := NewGormEventStore(db, &logger).WithMaxEvents(2)
+	ctx := context.Background()
+
+	var offsets []int64
+	for _, status := range []model.Status{model.StatusExpected, model.StatusListed, model.StatusTrading} {
+		offset, err := store.Append(ctx, &model.NewCoinEvent{CoinID: "coin-1", EventType: "status_changed", NewStatus: status})
+		require.NoError(t, err)
+		offsets = append(offsets, offset)
+	}
+
+	all, err := store.ReadFrom(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, offsets[1], all[0].Offset)
+	assert.Equal(t, model.StatusTrading, all[1].Event.NewStatus)
+}
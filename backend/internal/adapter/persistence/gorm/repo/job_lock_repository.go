@@ -0,0 +1,83 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormJobLockRepository implements port.JobLockRepository using GORM. Every change is a
+// single conditional statement, so instances racing for a lock cannot both win it.
+// Expiry is judged by the clock of the instance asking, so lock TTLs must be well above
+// the clock skew between instances.
+type GormJobLockRepository struct {
+	BaseRepository
+	now func() time.Time
+}
+
+// NewGormJobLockRepository creates a new GormJobLockRepository
+func NewGormJobLockRepository(db *gorm.DB, logger *zerolog.Logger) *GormJobLockRepository {
+	return &GormJobLockRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+		now:            time.Now,
+	}
+}
+
+// Acquire takes over the lock when it is expired or already held by owner, or creates it
+// when no instance has held it yet
+func (r *GormJobLockRepository) Acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	now := r.now()
+	result := r.GetDB(ctx).Model(&entity.JobLockEntity{}).
+		Where("name = ? AND (owner = ? OR expires_at <= ?)", name, owner, now).
+		Updates(map[string]interface{}{"owner": owner, "expires_at": now.Add(ttl), "updated_at": now})
+	if result.Error != nil {
+		r.logger.Error().Err(result.Error).Str("lock", name).Msg("Failed to take over job lock")
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	result = r.GetDB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.JobLockEntity{
+		Name:      name,
+		Owner:     owner,
+		ExpiresAt: now.Add(ttl),
+		UpdatedAt: now,
+	})
+	if result.Error != nil {
+		r.logger.Error().Err(result.Error).Str("lock", name).Msg("Failed to create job lock")
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Renew extends the lock when owner still holds it
+func (r *GormJobLockRepository) Renew(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	now := r.now()
+	result := r.GetDB(ctx).Model(&entity.JobLockEntity{}).
+		Where("name = ? AND owner = ?", name, owner).
+		Updates(map[string]interface{}{"expires_at": now.Add(ttl), "updated_at": now})
+	if result.Error != nil {
+		r.logger.Error().Err(result.Error).Str("lock", name).Msg("Failed to renew job lock")
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Release deletes the lock when owner holds it
+func (r *GormJobLockRepository) Release(ctx context.Context, name, owner string) error {
+	err := r.GetDB(ctx).Where("name = ? AND owner = ?", name, owner).Delete(&entity.JobLockEntity{}).Error
+	if err != nil {
+		r.logger.Error().Err(err).Str("lock", name).Msg("Failed to release job lock")
+		return err
+	}
+	return nil
+}
+
+// Ensure GormJobLockRepository implements port.JobLockRepository
+var _ port.JobLockRepository = (*GormJobLockRepository)(nil)
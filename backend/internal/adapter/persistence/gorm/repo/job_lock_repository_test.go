@@ -0,0 +1,79 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGormJobLockRepository_TwoInstancesContend(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.JobLockEntity{}))
+	logger := zerolog.Nop()
+
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	// Two instances sharing the database
+	first := NewGormJobLockRepository(db, &logger)
+	first.now = clock
+	second := NewGormJobLockRepository(db, &logger)
+	second.now = clock
+	ttl := time.Minute
+
+	acquired, err := first.Acquire(ctx, "wallet sync", "instance-a", ttl)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	acquired, err = second.Acquire(ctx, "wallet sync", "instance-b", ttl)
+	require.NoError(t, err)
+	assert.False(t, acquired, "the lock is held by the first instance")
+	renewed, err := second.Renew(ctx, "wallet sync", "instance-b", ttl)
+	require.NoError(t, err)
+	assert.False(t, renewed)
+
+	// Other jobs have locks of their own
+	acquired, err = second.Acquire(ctx, "backup", "instance-b", ttl)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// The holder keeps the lock by acquiring it again or renewing it
+	now = now.Add(50 * time.Second)
+	acquired, err = first.Acquire(ctx, "wallet sync", "instance-a", ttl)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	now = now.Add(50 * time.Second)
+	renewed, err = first.Renew(ctx, "wallet sync", "instance-a", ttl)
+	require.NoError(t, err)
+	assert.True(t, renewed)
+	acquired, err = second.Acquire(ctx, "wallet sync", "instance-b", ttl)
+	require.NoError(t, err)
+	assert.False(t, acquired, "the renewed lock has not expired")
+
+	// The first instance dies: once its lock expires the second takes over
+	now = now.Add(ttl)
+	acquired, err = second.Acquire(ctx, "wallet sync", "instance-b", ttl)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	renewed, err = first.Renew(ctx, "wallet sync", "instance-a", ttl)
+	require.NoError(t, err)
+	assert.False(t, renewed, "the first instance lost the lock")
+
+	// Only the holder can release the lock
+	require.NoError(t, first.Release(ctx, "wallet sync", "instance-a"))
+	acquired, err = first.Acquire(ctx, "wallet sync", "instance-a", ttl)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+	require.NoError(t, second.Release(ctx, "wallet sync", "instance-b"))
+	acquired, err = first.Acquire(ctx, "wallet sync", "instance-a", ttl)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+}
@@ -0,0 +1,92 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+// GormOrderRetryRepository implements port.OrderRetryRepository using GORM
+type GormOrderRetryRepository struct {
+	BaseRepository
+}
+
+// NewGormOrderRetryRepository creates a new GormOrderRetryRepository
+func NewGormOrderRetryRepository(db *gorm.DB, logger *zerolog.Logger) *GormOrderRetryRepository {
+	return &GormOrderRetryRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// Save creates the retry or replaces its stored state
+func (r *GormOrderRetryRepository) Save(ctx context.Context, retry *model.OrderRetry) error {
+	e := &entity.OrderRetryEntity{
+		ID:            retry.ID,
+		UserID:        retry.Request.UserID,
+		Symbol:        retry.Request.Symbol,
+		Side:          string(retry.Request.Side),
+		Type:          string(retry.Request.Type),
+		Quantity:      retry.Request.Quantity,
+		Price:         retry.Request.Price,
+		TimeInForce:   string(retry.Request.TimeInForce),
+		Status:        string(retry.Status),
+		Attempts:      retry.Attempts,
+		NextAttemptAt: retry.NextAttemptAt,
+		LastError:     retry.LastError,
+		OrderID:       retry.OrderID,
+		CreatedAt:     retry.CreatedAt,
+		UpdatedAt:     retry.UpdatedAt,
+	}
+
+	if err := r.GetDB(ctx).Save(e).Error; err != nil {
+		r.logger.Error().Err(err).Str("id", retry.ID).Msg("Failed to save order retry")
+		return err
+	}
+	return nil
+}
+
+// ListDue returns up to limit pending retries due at or before now, the earliest first
+func (r *GormOrderRetryRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*model.OrderRetry, error) {
+	var entities []entity.OrderRetryEntity
+	err := r.GetDB(ctx).
+		Where("status = ? AND next_attempt_at <= ?", string(model.OrderRetryPending), now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&entities).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to list due order retries")
+		return nil, err
+	}
+
+	retries := make([]*model.OrderRetry, len(entities))
+	for i, e := range entities {
+		retries[i] = &model.OrderRetry{
+			ID: e.ID,
+			Request: model.OrderRequest{
+				UserID:      e.UserID,
+				Symbol:      e.Symbol,
+				Side:        model.OrderSide(e.Side),
+				Type:        model.OrderType(e.Type),
+				Quantity:    e.Quantity,
+				Price:       e.Price,
+				TimeInForce: model.TimeInForce(e.TimeInForce),
+			},
+			Status:        model.OrderRetryStatus(e.Status),
+			Attempts:      e.Attempts,
+			NextAttemptAt: e.NextAttemptAt,
+			LastError:     e.LastError,
+			OrderID:       e.OrderID,
+			CreatedAt:     e.CreatedAt,
+			UpdatedAt:     e.UpdatedAt,
+		}
+	}
+	return retries, nil
+}
+
+// Ensure GormOrderRetryRepository implements port.OrderRetryRepository
+var _ port.OrderRetryRepository = (*GormOrderRetryRepository)(nil)
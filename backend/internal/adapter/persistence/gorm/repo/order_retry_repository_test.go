@@ -0,0 +1,60 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGormOrderRetryRepository_ListDue(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.OrderRetryEntity{}))
+	logger := zerolog.Nop()
+	repo := NewGormOrderRetryRepository(db, &logger)
+
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	request := model.OrderRequest{UserID: "user-1", Symbol: "BTCUSDT", Side: model.OrderSideBuy, Type: model.OrderTypeLimit, Quantity: 0.5, Price: 64000, TimeInForce: model.TimeInForceGTC}
+	save := func(id string, status model.OrderRetryStatus, nextAttemptAt time.Time) {
+		require.NoError(t, repo.Save(ctx, &model.OrderRetry{
+			ID:            id,
+			Request:       request,
+			Status:        status,
+			Attempts:      1,
+			NextAttemptAt: nextAttemptAt,
+			LastError:     "request failed with status 429",
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}))
+	}
+	save("later", model.OrderRetryPending, now.Add(time.Minute))
+	save("due", model.OrderRetryPending, now)
+	save("overdue", model.OrderRetryPending, now.Add(-time.Minute))
+	save("placed", model.OrderRetrySucceeded, now.Add(-time.Hour))
+
+	due, err := repo.ListDue(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	assert.Equal(t, "overdue", due[0].ID)
+	assert.Equal(t, "due", due[1].ID)
+	assert.Equal(t, request, due[0].Request)
+	assert.Equal(t, "request failed with status 429", due[0].LastError)
+
+	// Saving again replaces the stored state
+	due[0].Status = model.OrderRetryFailed
+	due[0].Attempts = 5
+	require.NoError(t, repo.Save(ctx, due[0]))
+	due, err = repo.ListDue(ctx, now, 1)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, "due", due[0].ID)
+}
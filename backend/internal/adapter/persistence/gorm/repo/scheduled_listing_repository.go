@@ -0,0 +1,98 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormScheduledListingRepository implements port.ScheduledListingRepository using GORM
+type GormScheduledListingRepository struct {
+	BaseRepository
+}
+
+// NewGormScheduledListingRepository creates a new GormScheduledListingRepository
+func NewGormScheduledListingRepository(db *gorm.DB, logger *zerolog.Logger) *GormScheduledListingRepository {
+	return &GormScheduledListingRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// Save creates or replaces a scheduled listing
+func (r *GormScheduledListingRepository) Save(ctx context.Context, listing *model.ScheduledListing) error {
+	record := &entity.ScheduledListingEntity{
+		ID:          listing.ID,
+		Symbol:      listing.Symbol,
+		Title:       listing.Title,
+		URL:         listing.URL,
+		ListingTime: listing.ListingTime,
+		AlertAt:     listing.AlertAt,
+		FiredAt:     listing.FiredAt,
+		CreatedAt:   listing.CreatedAt,
+	}
+
+	err := r.GetDB(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(record).Error
+	if err != nil {
+		r.logger.Error().Err(err).Str("id", listing.ID).Msg("Failed to save scheduled listing")
+	}
+	return err
+}
+
+// Get returns the scheduled listing with the given ID, or nil when there is none
+func (r *GormScheduledListingRepository) Get(ctx context.Context, id string) (*model.ScheduledListing, error) {
+	var records []entity.ScheduledListingEntity
+	if err := r.GetDB(ctx).Where("id = ?", id).Limit(1).Find(&records).Error; err != nil {
+		r.logger.Error().Err(err).Str("id", id).Msg("Failed to get scheduled listing")
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	return scheduledListingToDomain(records[0]), nil
+}
+
+// Delete removes a scheduled listing
+func (r *GormScheduledListingRepository) Delete(ctx context.Context, id string) error {
+	err := r.GetDB(ctx).Where("id = ?", id).Delete(&entity.ScheduledListingEntity{}).Error
+	if err != nil {
+		r.logger.Error().Err(err).Str("id", id).Msg("Failed to delete scheduled listing")
+	}
+	return err
+}
+
+// GetPending returns the scheduled listings whose alert has not fired, ordered by alert time
+func (r *GormScheduledListingRepository) GetPending(ctx context.Context) ([]*model.ScheduledListing, error) {
+	var records []entity.ScheduledListingEntity
+	if err := r.GetDB(ctx).Where("fired_at IS NULL").Order("alert_at ASC").Find(&records).Error; err != nil {
+		r.logger.Error().Err(err).Msg("Failed to load scheduled listings")
+		return nil, err
+	}
+
+	listings := make([]*model.ScheduledListing, 0, len(records))
+	for _, record := range records {
+		listings = append(listings, scheduledListingToDomain(record))
+	}
+	return listings, nil
+}
+
+// scheduledListingToDomain converts a stored scheduled listing to the domain model
+func scheduledListingToDomain(record entity.ScheduledListingEntity) *model.ScheduledListing {
+	return &model.ScheduledListing{
+		ID:          record.ID,
+		Symbol:      record.Symbol,
+		Title:       record.Title,
+		URL:         record.URL,
+		ListingTime: record.ListingTime,
+		AlertAt:     record.AlertAt,
+		FiredAt:     record.FiredAt,
+		CreatedAt:   record.CreatedAt,
+	}
+}
+
+// Ensure GormScheduledListingRepository implements port.ScheduledListingRepository
+var _ port.ScheduledListingRepository = (*GormScheduledListingRepository)(nil)
@@ -0,0 +1,64 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGormScheduledListingRepository_SaveGetDelete(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.ScheduledListingEntity{}))
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	repo := NewGormScheduledListingRepository(db, &logger)
+	ctx := context.Background()
+
+	listingTime := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	later := &model.ScheduledListing{ID: "202", Symbol: "BAR", ListingTime: listingTime.Add(time.Hour), AlertAt: listingTime.Add(55 * time.Minute)}
+	sooner := &model.ScheduledListing{ID: "101", Symbol: "FOO", ListingTime: listingTime, AlertAt: listingTime.Add(-5 * time.Minute)}
+	require.NoError(t, repo.Save(ctx, later))
+	require.NoError(t, repo.Save(ctx, sooner))
+
+	// Saving the same ID again replaces the schedule
+	sooner.AlertAt = listingTime.Add(-10 * time.Minute)
+	require.NoError(t, repo.Save(ctx, sooner))
+
+	pending, err := repo.GetPending(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, "FOO", pending[0].Symbol)
+	assert.True(t, pending[0].AlertAt.Equal(listingTime.Add(-10*time.Minute)))
+
+	require.NoError(t, repo.Delete(ctx, "101"))
+	pending, err = repo.GetPending(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "BAR", pending[0].Symbol)
+
+	// A fired alert is kept but no longer pending
+	firedAt := listingTime.Add(55 * time.Minute)
+	later.FiredAt = &firedAt
+	require.NoError(t, repo.Save(ctx, later))
+	pending, err = repo.GetPending(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	stored, err := repo.Get(ctx, "202")
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	require.NotNil(t, stored.FiredAt)
+	assert.True(t, stored.FiredAt.Equal(firedAt))
+
+	missing, err := repo.Get(ctx, "101")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
@@ -0,0 +1,73 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+// GormTrailingStopRepository implements port.TrailingStopRepository using GORM
+type GormTrailingStopRepository struct {
+	BaseRepository
+}
+
+// NewGormTrailingStopRepository creates a new GormTrailingStopRepository
+func NewGormTrailingStopRepository(db *gorm.DB, logger *zerolog.Logger) *GormTrailingStopRepository {
+	return &GormTrailingStopRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// Save stores the trailing stop of a position, replacing its previous state
+func (r *GormTrailingStopRepository) Save(ctx context.Context, stop *model.TrailingStop) error {
+	e := &entity.TrailingStopEntity{
+		PositionID:   stop.PositionID,
+		Symbol:       stop.Symbol,
+		HighestPrice: stop.HighestPrice,
+		StopPrice:    stop.StopPrice,
+		UpdatedAt:    stop.UpdatedAt,
+	}
+
+	if err := r.Upsert(ctx, e, []string{"position_id"}, []string{"highest_price", "stop_price", "updated_at"}); err != nil {
+		r.logger.Error().Err(err).Str("position_id", stop.PositionID).Msg("Failed to save trailing stop")
+		return err
+	}
+	return nil
+}
+
+// GetAll returns every stored trailing stop
+func (r *GormTrailingStopRepository) GetAll(ctx context.Context) ([]*model.TrailingStop, error) {
+	var entities []entity.TrailingStopEntity
+	if err := r.GetDB(ctx).Find(&entities).Error; err != nil {
+		r.logger.Error().Err(err).Msg("Failed to get trailing stops")
+		return nil, err
+	}
+
+	stops := make([]*model.TrailingStop, len(entities))
+	for i, e := range entities {
+		stops[i] = &model.TrailingStop{
+			PositionID:   e.PositionID,
+			Symbol:       e.Symbol,
+			HighestPrice: e.HighestPrice,
+			StopPrice:    e.StopPrice,
+			UpdatedAt:    e.UpdatedAt,
+		}
+	}
+	return stops, nil
+}
+
+// Delete removes the trailing stop of a position
+func (r *GormTrailingStopRepository) Delete(ctx context.Context, positionID string) error {
+	if err := r.GetDB(ctx).Where("position_id = ?", positionID).Delete(&entity.TrailingStopEntity{}).Error; err != nil {
+		r.logger.Error().Err(err).Str("position_id", positionID).Msg("Failed to delete trailing stop")
+		return err
+	}
+	return nil
+}
+
+// Ensure GormTrailingStopRepository implements port.TrailingStopRepository
+var _ port.TrailingStopRepository = (*GormTrailingStopRepository)(nil)
@@ -0,0 +1,46 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGormTrailingStopRepository_SaveReplacesState(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.TrailingStopEntity{}))
+	logger := zerolog.Nop()
+	repo := NewGormTrailingStopRepository(db, &logger)
+
+	updatedAt := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.Save(ctx, &model.TrailingStop{PositionID: "pos-1", Symbol: "BTCUSDT", HighestPrice: 100, StopPrice: 95, UpdatedAt: updatedAt}))
+	require.NoError(t, repo.Save(ctx, &model.TrailingStop{PositionID: "pos-1", Symbol: "BTCUSDT", HighestPrice: 120, StopPrice: 114, UpdatedAt: updatedAt.Add(time.Minute)}))
+	require.NoError(t, repo.Save(ctx, &model.TrailingStop{PositionID: "pos-2", Symbol: "ETHUSDT", HighestPrice: 2000, StopPrice: 1900, UpdatedAt: updatedAt}))
+
+	stops, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, stops, 2)
+	byPosition := map[string]*model.TrailingStop{}
+	for _, stop := range stops {
+		byPosition[stop.PositionID] = stop
+	}
+	assert.Equal(t, 120.0, byPosition["pos-1"].HighestPrice)
+	assert.Equal(t, 114.0, byPosition["pos-1"].StopPrice)
+	assert.True(t, byPosition["pos-1"].UpdatedAt.Equal(updatedAt.Add(time.Minute)))
+
+	require.NoError(t, repo.Delete(ctx, "pos-1"))
+	stops, err = repo.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, stops, 1)
+	assert.Equal(t, "pos-2", stops[0].PositionID)
+}
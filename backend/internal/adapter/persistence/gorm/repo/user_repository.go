@@ -30,11 +30,12 @@ func NewUserRepository(db *gorm.DB, logger *zerolog.Logger) *UserRepository {
 func (r *UserRepository) Save(ctx context.Context, user *model.User) error {
 	// Convert domain model to entity
 	userEntity := &entity.UserEntity{
-		ID:        user.ID,
-		Email:     user.Email,
-		Name:      user.Name,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:            user.ID,
+		Email:         user.Email,
+		Name:          user.Name,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+		DeactivatedAt: user.DeactivatedAt,
 	}
 
 	// Save entity
@@ -60,11 +61,12 @@ func (r *UserRepository) GetByID(ctx context.Context, id string) (*model.User, e
 
 	// Convert entity to domain model
 	user := &model.User{
-		ID:        userEntity.ID,
-		Email:     userEntity.Email,
-		Name:      userEntity.Name,
-		CreatedAt: userEntity.CreatedAt,
-		UpdatedAt: userEntity.UpdatedAt,
+		ID:            userEntity.ID,
+		Email:         userEntity.Email,
+		Name:          userEntity.Name,
+		CreatedAt:     userEntity.CreatedAt,
+		UpdatedAt:     userEntity.UpdatedAt,
+		DeactivatedAt: userEntity.DeactivatedAt,
 	}
 
 	return user, nil
@@ -84,11 +86,12 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*model.U
 
 	// Convert entity to domain model
 	user := &model.User{
-		ID:        userEntity.ID,
-		Email:     userEntity.Email,
-		Name:      userEntity.Name,
-		CreatedAt: userEntity.CreatedAt,
-		UpdatedAt: userEntity.UpdatedAt,
+		ID:            userEntity.ID,
+		Email:         userEntity.Email,
+		Name:          userEntity.Name,
+		CreatedAt:     userEntity.CreatedAt,
+		UpdatedAt:     userEntity.UpdatedAt,
+		DeactivatedAt: userEntity.DeactivatedAt,
 	}
 
 	return user, nil
@@ -118,11 +121,12 @@ func (r *UserRepository) List(ctx context.Context) ([]*model.User, error) {
 	users := make([]*model.User, len(userEntities))
 	for i, userEntity := range userEntities {
 		users[i] = &model.User{
-			ID:        userEntity.ID,
-			Email:     userEntity.Email,
-			Name:      userEntity.Name,
-			CreatedAt: userEntity.CreatedAt,
-			UpdatedAt: userEntity.UpdatedAt,
+			ID:            userEntity.ID,
+			Email:         userEntity.Email,
+			Name:          userEntity.Name,
+			CreatedAt:     userEntity.CreatedAt,
+			UpdatedAt:     userEntity.UpdatedAt,
+			DeactivatedAt: userEntity.DeactivatedAt,
 		}
 	}
 
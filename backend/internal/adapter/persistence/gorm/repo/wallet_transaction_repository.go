@@ -0,0 +1,97 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+// GormWalletTransactionRepository implements port.WalletTransactionRepository using GORM
+type GormWalletTransactionRepository struct {
+	BaseRepository
+}
+
+// NewGormWalletTransactionRepository creates a new GormWalletTransactionRepository
+func NewGormWalletTransactionRepository(db *gorm.DB, logger *zerolog.Logger) *GormWalletTransactionRepository {
+	return &GormWalletTransactionRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// Save stores a wallet transaction. Saving a transaction again with the same ID updates
+// the fields an exchange may change later, such as the status of a pending transfer.
+func (r *GormWalletTransactionRepository) Save(ctx context.Context, tx *model.WalletTransaction) error {
+	if tx.ID == "" {
+		tx.ID = uuid.New().String()
+	}
+
+	e := &entity.WalletTransactionEntity{
+		ID:              tx.ID,
+		UserID:          tx.UserID,
+		WalletID:        tx.WalletID,
+		Type:            string(tx.Type),
+		Asset:           string(tx.Asset),
+		Amount:          tx.Amount,
+		PreviousBalance: tx.PreviousBalance,
+		NewBalance:      tx.NewBalance,
+		DetectedAt:      tx.DetectedAt,
+		ExternalID:      tx.ExternalID,
+		Status:          string(tx.Status),
+		Network:         tx.Network,
+		Address:         tx.Address,
+		TxID:            tx.TxID,
+		Fee:             tx.Fee,
+	}
+
+	if err := r.Upsert(ctx, e, []string{"id"}, []string{"status", "tx_id", "fee"}); err != nil {
+		r.logger.Error().Err(err).Str("wallet_id", tx.WalletID).Msg("Failed to save wallet transaction")
+		return err
+	}
+	return nil
+}
+
+// GetByWalletID returns the most recent transactions detected for a wallet
+func (r *GormWalletTransactionRepository) GetByWalletID(ctx context.Context, walletID string, limit, offset int) ([]*model.WalletTransaction, error) {
+	var entities []entity.WalletTransactionEntity
+	err := r.GetDB(ctx).
+		Where("wallet_id = ?", walletID).
+		Order("detected_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&entities).Error
+	if err != nil {
+		r.logger.Error().Err(err).Str("wallet_id", walletID).Msg("Failed to get wallet transactions")
+		return nil, err
+	}
+
+	txs := make([]*model.WalletTransaction, len(entities))
+	for i, e := range entities {
+		txs[i] = &model.WalletTransaction{
+			ID:              e.ID,
+			UserID:          e.UserID,
+			WalletID:        e.WalletID,
+			Type:            model.TransactionType(e.Type),
+			Asset:           model.Asset(e.Asset),
+			Amount:          e.Amount,
+			PreviousBalance: e.PreviousBalance,
+			NewBalance:      e.NewBalance,
+			DetectedAt:      e.DetectedAt,
+			ExternalID:      e.ExternalID,
+			Status:          model.TransactionStatus(e.Status),
+			Network:         e.Network,
+			Address:         e.Address,
+			TxID:            e.TxID,
+			Fee:             e.Fee,
+		}
+	}
+
+	return txs, nil
+}
+
+// Ensure GormWalletTransactionRepository implements port.WalletTransactionRepository
+var _ port.WalletTransactionRepository = (*GormWalletTransactionRepository)(nil)
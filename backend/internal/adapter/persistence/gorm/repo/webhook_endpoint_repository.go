@@ -0,0 +1,168 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+// GormWebhookEndpointRepository implements port.WebhookEndpointRepository using GORM
+type GormWebhookEndpointRepository struct {
+	BaseRepository
+}
+
+// NewGormWebhookEndpointRepository creates a new GormWebhookEndpointRepository
+func NewGormWebhookEndpointRepository(db *gorm.DB, logger *zerolog.Logger) *GormWebhookEndpointRepository {
+	return &GormWebhookEndpointRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// Save creates or updates an endpoint
+func (r *GormWebhookEndpointRepository) Save(ctx context.Context, endpoint *model.WebhookEndpoint) error {
+	e := &entity.WebhookEndpointEntity{
+		ID:         endpoint.ID,
+		UserID:     endpoint.UserID,
+		URL:        endpoint.URL,
+		Secret:     endpoint.Secret,
+		EventTypes: strings.Join(endpoint.EventTypes, ","),
+		Active:     endpoint.Active,
+		CreatedAt:  endpoint.CreatedAt,
+		UpdatedAt:  endpoint.UpdatedAt,
+	}
+	if err := r.GetDB(ctx).Save(e).Error; err != nil {
+		r.logger.Error().Err(err).Str("endpoint_id", endpoint.ID).Msg("Failed to save webhook endpoint")
+		return err
+	}
+	return nil
+}
+
+// GetByID returns the endpoint, or nil if there is none
+func (r *GormWebhookEndpointRepository) GetByID(ctx context.Context, id string) (*model.WebhookEndpoint, error) {
+	var e entity.WebhookEndpointEntity
+	if err := r.GetDB(ctx).Where("id = ?", id).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error().Err(err).Str("endpoint_id", id).Msg("Failed to get webhook endpoint")
+		return nil, err
+	}
+	return r.toDomain(&e), nil
+}
+
+// ListByUser returns the endpoints of a user, oldest first
+func (r *GormWebhookEndpointRepository) ListByUser(ctx context.Context, userID string) ([]*model.WebhookEndpoint, error) {
+	return r.list(ctx, r.GetDB(ctx).Where("user_id = ?", userID))
+}
+
+// ListActive returns the active endpoints of every user
+func (r *GormWebhookEndpointRepository) ListActive(ctx context.Context) ([]*model.WebhookEndpoint, error) {
+	return r.list(ctx, r.GetDB(ctx).Where("active = ?", true))
+}
+
+// Delete removes an endpoint
+func (r *GormWebhookEndpointRepository) Delete(ctx context.Context, id string) error {
+	if err := r.GetDB(ctx).Where("id = ?", id).Delete(&entity.WebhookEndpointEntity{}).Error; err != nil {
+		r.logger.Error().Err(err).Str("endpoint_id", id).Msg("Failed to delete webhook endpoint")
+		return err
+	}
+	return nil
+}
+
+func (r *GormWebhookEndpointRepository) list(ctx context.Context, query *gorm.DB) ([]*model.WebhookEndpoint, error) {
+	var entities []entity.WebhookEndpointEntity
+	if err := query.Order("created_at").Find(&entities).Error; err != nil {
+		r.logger.Error().Err(err).Msg("Failed to list webhook endpoints")
+		return nil, err
+	}
+	endpoints := make([]*model.WebhookEndpoint, len(entities))
+	for i := range entities {
+		endpoints[i] = r.toDomain(&entities[i])
+	}
+	return endpoints, nil
+}
+
+func (r *GormWebhookEndpointRepository) toDomain(e *entity.WebhookEndpointEntity) *model.WebhookEndpoint {
+	var eventTypes []string
+	if e.EventTypes != "" {
+		eventTypes = strings.Split(e.EventTypes, ",")
+	}
+	return &model.WebhookEndpoint{
+		ID:         e.ID,
+		UserID:     e.UserID,
+		URL:        e.URL,
+		Secret:     e.Secret,
+		EventTypes: eventTypes,
+		Active:     e.Active,
+		CreatedAt:  e.CreatedAt,
+		UpdatedAt:  e.UpdatedAt,
+	}
+}
+
+// GormWebhookDeliveryRepository implements port.WebhookDeliveryRepository using GORM
+type GormWebhookDeliveryRepository struct {
+	BaseRepository
+}
+
+// NewGormWebhookDeliveryRepository creates a new GormWebhookDeliveryRepository
+func NewGormWebhookDeliveryRepository(db *gorm.DB, logger *zerolog.Logger) *GormWebhookDeliveryRepository {
+	return &GormWebhookDeliveryRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// Save creates or updates a delivery record
+func (r *GormWebhookDeliveryRepository) Save(ctx context.Context, delivery *model.WebhookDelivery) error {
+	e := &entity.WebhookDeliveryEntity{
+		ID:          delivery.ID,
+		EndpointID:  delivery.EndpointID,
+		EventType:   delivery.EventType,
+		Status:      string(delivery.Status),
+		Attempts:    delivery.Attempts,
+		StatusCode:  delivery.StatusCode,
+		LastError:   delivery.LastError,
+		CreatedAt:   delivery.CreatedAt,
+		CompletedAt: delivery.CompletedAt,
+	}
+	if err := r.GetDB(ctx).Save(e).Error; err != nil {
+		r.logger.Error().Err(err).Str("delivery_id", delivery.ID).Msg("Failed to save webhook delivery")
+		return err
+	}
+	return nil
+}
+
+// ListByEndpoint returns the latest deliveries to an endpoint, newest first
+func (r *GormWebhookDeliveryRepository) ListByEndpoint(ctx context.Context, endpointID string, limit int) ([]*model.WebhookDelivery, error) {
+	var entities []entity.WebhookDeliveryEntity
+	if err := r.GetDB(ctx).Where("endpoint_id = ?", endpointID).Order("created_at DESC").Limit(limit).Find(&entities).Error; err != nil {
+		r.logger.Error().Err(err).Str("endpoint_id", endpointID).Msg("Failed to list webhook deliveries")
+		return nil, err
+	}
+	deliveries := make([]*model.WebhookDelivery, len(entities))
+	for i, e := range entities {
+		deliveries[i] = &model.WebhookDelivery{
+			ID:          e.ID,
+			EndpointID:  e.EndpointID,
+			EventType:   e.EventType,
+			Status:      model.WebhookDeliveryStatus(e.Status),
+			Attempts:    e.Attempts,
+			StatusCode:  e.StatusCode,
+			LastError:   e.LastError,
+			CreatedAt:   e.CreatedAt,
+			CompletedAt: e.CompletedAt,
+		}
+	}
+	return deliveries, nil
+}
+
+// Ensure the repositories implement their ports
+var (
+	_ port.WebhookEndpointRepository = (*GormWebhookEndpointRepository)(nil)
+	_ port.WebhookDeliveryRepository = (*GormWebhookDeliveryRepository)(nil)
+)
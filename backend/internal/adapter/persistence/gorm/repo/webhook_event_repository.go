@@ -0,0 +1,54 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormWebhookEventRepository implements port.WebhookEventRepository using GORM
+type GormWebhookEventRepository struct {
+	BaseRepository
+}
+
+// NewGormWebhookEventRepository creates a new GormWebhookEventRepository
+func NewGormWebhookEventRepository(db *gorm.DB, logger *zerolog.Logger) *GormWebhookEventRepository {
+	return &GormWebhookEventRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// Seen reports whether the event was recorded
+func (r *GormWebhookEventRepository) Seen(ctx context.Context, source, eventID string) (bool, error) {
+	var count int64
+	if err := r.GetDB(ctx).Model(&entity.ProcessedWebhookEventEntity{}).
+		Where("source = ? AND event_id = ?", source, eventID).
+		Count(&count).Error; err != nil {
+		r.logger.Error().Err(err).Str("source", source).Str("event_id", eventID).Msg("Failed to look up webhook event")
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Record marks the event as handled. Recording an event twice keeps the first record.
+func (r *GormWebhookEventRepository) Record(ctx context.Context, source, eventID, eventType string) error {
+	e := &entity.ProcessedWebhookEventEntity{
+		Source:      source,
+		EventID:     eventID,
+		EventType:   eventType,
+		ProcessedAt: time.Now(),
+	}
+	if err := r.GetDB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(e).Error; err != nil {
+		r.logger.Error().Err(err).Str("source", source).Str("event_id", eventID).Msg("Failed to record webhook event")
+		return err
+	}
+	return nil
+}
+
+// Ensure GormWebhookEventRepository implements port.WebhookEventRepository
+var _ port.WebhookEventRepository = (*GormWebhookEventRepository)(nil)
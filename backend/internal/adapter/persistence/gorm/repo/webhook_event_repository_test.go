@@ -0,0 +1,41 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGormWebhookEventRepository_RecordsEventsOnce(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.ProcessedWebhookEventEntity{}))
+	logger := zerolog.Nop()
+	repo := NewGormWebhookEventRepository(db, &logger)
+
+	seen, err := repo.Seen(ctx, "clerk", "msg_1")
+	require.NoError(t, err)
+	assert.False(t, seen)
+
+	require.NoError(t, repo.Record(ctx, "clerk", "msg_1", "user.created"))
+	require.NoError(t, repo.Record(ctx, "clerk", "msg_1", "user.created"), "recording twice is not an error")
+
+	seen, err = repo.Seen(ctx, "clerk", "msg_1")
+	require.NoError(t, err)
+	assert.True(t, seen)
+
+	seen, err = repo.Seen(ctx, "other", "msg_1")
+	require.NoError(t, err)
+	assert.False(t, seen, "event IDs are scoped to their source")
+
+	var count int64
+	require.NoError(t, db.Model(&entity.ProcessedWebhookEventEntity{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
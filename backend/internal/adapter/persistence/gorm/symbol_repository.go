@@ -75,11 +75,20 @@ func (r *SymbolRepository) GetByExchange(ctx context.Context, exchange string) (
 	return symbols, nil
 }
 
-// GetAll returns all available Symbols
+// GetAll returns all available Symbols, excluding soft-deleted ones
 func (r *SymbolRepository) GetAll(ctx context.Context) ([]*market.Symbol, error) {
+	return r.getAllSymbols(r.db.WithContext(ctx))
+}
+
+// GetAllIncludingDeleted returns all Symbols, including soft-deleted ones
+func (r *SymbolRepository) GetAllIncludingDeleted(ctx context.Context) ([]*market.Symbol, error) {
+	return r.getAllSymbols(r.db.WithContext(ctx).Unscoped())
+}
+
+func (r *SymbolRepository) getAllSymbols(db *gorm.DB) ([]*market.Symbol, error) {
 	var entities []SymbolEntity
 
-	result := r.db.WithContext(ctx).Find(&entities)
+	result := db.Find(&entities)
 	if result.Error != nil {
 		r.logger.Error().Err(result.Error).Msg("Failed to get all symbols")
 		return nil, fmt.Errorf("failed to get all symbols: %w", result.Error)
@@ -113,7 +122,40 @@ func (r *SymbolRepository) Update(ctx context.Context, symbol *market.Symbol) er
 	return nil
 }
 
-// Delete removes a Symbol
+// Upsert creates a Symbol or updates the existing one with the same symbol and exchange
+// in a single statement
+func (r *SymbolRepository) Upsert(ctx context.Context, symbol *market.Symbol) error {
+	entity := r.symbolToEntity(symbol)
+
+	result := r.db.WithContext(ctx).Clauses(symbolUpsert).Create(entity)
+	if result.Error != nil {
+		r.logger.Error().Err(result.Error).Str("symbol", symbol.Symbol).Msg("Failed to upsert symbol")
+		return fmt.Errorf("failed to upsert symbol: %w", result.Error)
+	}
+
+	r.logger.Debug().Str("symbol", symbol.Symbol).Str("exchange", symbol.Exchange).Msg("Symbol upserted successfully")
+	return nil
+}
+
+// UpsertMany upserts symbols in a single transaction and reports how many were created
+// and how many already existed
+func (r *SymbolRepository) UpsertMany(ctx context.Context, symbols []*market.Symbol) (created, updated int, err error) {
+	entities := make([]SymbolEntity, len(symbols))
+	for i, symbol := range symbols {
+		entities[i] = *r.symbolToEntity(symbol)
+	}
+
+	created, updated, err = upsertSymbols(r.db.WithContext(ctx), entities)
+	if err != nil {
+		r.logger.Error().Err(err).Int("count", len(symbols)).Msg("Failed to upsert symbols")
+		return 0, 0, fmt.Errorf("failed to upsert symbols: %w", err)
+	}
+
+	r.logger.Info().Int("created", created).Int("updated", updated).Msg("Symbols upserted successfully")
+	return created, updated, nil
+}
+
+// Delete soft-deletes a Symbol; the row is kept for the candles and orders referencing it
 func (r *SymbolRepository) Delete(ctx context.Context, symbol string) error {
 	result := r.db.WithContext(ctx).Where("symbol = ?", symbol).Delete(&SymbolEntity{})
 	if result.Error != nil {
@@ -130,6 +172,25 @@ func (r *SymbolRepository) Delete(ctx context.Context, symbol string) error {
 	return nil
 }
 
+// Restore reverts the soft-delete of a Symbol
+func (r *SymbolRepository) Restore(ctx context.Context, symbol string) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&SymbolEntity{}).
+		Where("symbol = ? AND deleted_at IS NOT NULL", symbol).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		r.logger.Error().Err(result.Error).Str("symbol", symbol).Msg("Failed to restore symbol")
+		return fmt.Errorf("failed to restore symbol: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.Warn().Str("symbol", symbol).Msg("No deleted symbol found to restore")
+		return fmt.Errorf("symbol not found: %s", symbol)
+	}
+
+	r.logger.Info().Str("symbol", symbol).Msg("Symbol restored successfully")
+	return nil
+}
+
 // Helper methods for entity conversion
 func (r *SymbolRepository) symbolToEntity(symbol *market.Symbol) *SymbolEntity {
 	return &SymbolEntity{
@@ -171,5 +232,6 @@ func (r *SymbolRepository) symbolToDomain(entity *SymbolEntity) *market.Symbol {
 		AllowedOrderTypes: allowedOrderTypes,
 		CreatedAt:         entity.CreatedAt,
 		UpdatedAt:         entity.UpdatedAt,
+		DeletedAt:         deletedAtToDomain(entity.DeletedAt),
 	}
 }
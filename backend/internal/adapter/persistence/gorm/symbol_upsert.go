@@ -0,0 +1,72 @@
+package gorm
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// symbolUpsertBatchSize is the number of symbols written per INSERT statement, well below
+// the bind variable limits of SQLite and PostgreSQL
+const symbolUpsertBatchSize = 500
+
+// symbolUpsert makes inserting an existing symbol update its exchange-provided columns.
+// CreatedAt and a soft-delete are left untouched.
+var symbolUpsert = clause.OnConflict{
+	Columns: []clause.Column{{Name: "symbol"}, {Name: "exchange"}},
+	DoUpdates: clause.AssignmentColumns([]string{
+		"base_asset", "quote_asset", "status",
+		"min_price", "max_price", "price_precision",
+		"min_qty", "max_qty", "qty_precision",
+		"allowed_order_types", "updated_at",
+	}),
+}
+
+// upsertSymbols writes entities in one transaction with one existence query and one
+// INSERT ... ON CONFLICT statement per batch. Entities repeating a symbol and exchange are
+// collapsed, the last one winning. Soft-deleted rows count as existing.
+func upsertSymbols(db *gorm.DB, entities []SymbolEntity) (created, updated int, err error) {
+	type symbolKey struct{ symbol, exchange string }
+
+	unique := make([]SymbolEntity, 0, len(entities))
+	index := make(map[symbolKey]int, len(entities))
+	for _, entity := range entities {
+		key := symbolKey{entity.Symbol, entity.Exchange}
+		if i, ok := index[key]; ok {
+			unique[i] = entity
+			continue
+		}
+		index[key] = len(unique)
+		unique = append(unique, entity)
+	}
+	if len(unique) == 0 {
+		return 0, 0, nil
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		names := make([]string, 0, len(unique))
+		for _, entity := range unique {
+			names = append(names, entity.Symbol)
+		}
+
+		var existing []SymbolEntity
+		if err := tx.Unscoped().Select("symbol", "exchange").Where("symbol IN ?", names).Find(&existing).Error; err != nil {
+			return fmt.Errorf("failed to read existing symbols: %w", err)
+		}
+		for _, entity := range existing {
+			if _, ok := index[symbolKey{entity.Symbol, entity.Exchange}]; ok {
+				updated++
+			}
+		}
+
+		if err := tx.Clauses(symbolUpsert).CreateInBatches(unique, symbolUpsertBatchSize).Error; err != nil {
+			return fmt.Errorf("failed to write symbols: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return len(unique) - updated, updated, nil
+}
@@ -0,0 +1,116 @@
+package gorm
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// countStatements counts every statement db sends to the database
+func countStatements(t *testing.T, db *gorm.DB) *int64 {
+	var count int64
+	inc := func(*gorm.DB) { atomic.AddInt64(&count, 1) }
+	require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:count_create", inc))
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:count_query", inc))
+	return &count
+}
+
+func testSymbols(n int, status string) []*market.Symbol {
+	symbols := make([]*market.Symbol, n)
+	for i := range symbols {
+		symbols[i] = &market.Symbol{
+			Symbol:     fmt.Sprintf("SYM%04dUSDT", i),
+			BaseAsset:  fmt.Sprintf("SYM%04d", i),
+			QuoteAsset: "USDT",
+			Exchange:   "mexc",
+			Status:     status,
+		}
+	}
+	return symbols
+}
+
+func TestUpsertMany_MatchesPerRowUpsert(t *testing.T) {
+	const n = 1200
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	loopDB, cleanupLoop := setupTestDB(t)
+	defer cleanupLoop()
+	loopRepo := NewMarketRepository(loopDB, &logger)
+	require.NoError(t, loopRepo.Upsert(ctx, testSymbols(1, "TRADING")[0]))
+	loopStatements := countStatements(t, loopDB)
+	for _, symbol := range testSymbols(n, "BREAK") {
+		require.NoError(t, loopRepo.Upsert(ctx, symbol))
+	}
+
+	batchDB, cleanupBatch := setupTestDB(t)
+	defer cleanupBatch()
+	batchRepo := NewMarketRepository(batchDB, &logger)
+	require.NoError(t, batchRepo.Upsert(ctx, testSymbols(1, "TRADING")[0]))
+	batchStatements := countStatements(t, batchDB)
+	created, updated, err := batchRepo.UpsertMany(ctx, testSymbols(n, "BREAK"))
+	require.NoError(t, err)
+
+	assert.Equal(t, n-1, created)
+	assert.Equal(t, 1, updated)
+
+	// One existence query plus one INSERT per batch of 500, against one per row
+	assert.Equal(t, int64(n), atomic.LoadInt64(loopStatements))
+	assert.Equal(t, int64(1+3), atomic.LoadInt64(batchStatements))
+
+	loopSymbols, err := loopRepo.GetAll(ctx)
+	require.NoError(t, err)
+	batchSymbols, err := batchRepo.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, batchSymbols, n)
+	require.Len(t, loopSymbols, n)
+	byName := make(map[string]*market.Symbol, n)
+	for _, symbol := range loopSymbols {
+		byName[symbol.Symbol] = symbol
+	}
+	for _, symbol := range batchSymbols {
+		want := byName[symbol.Symbol]
+		require.NotNil(t, want, symbol.Symbol)
+		assert.Equal(t, want.Status, symbol.Status)
+		assert.Equal(t, want.BaseAsset, symbol.BaseAsset)
+	}
+}
+
+func TestUpsertMany_CollapsesDuplicatesAndCountsDeleted(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	logger := zerolog.Nop()
+	repo := NewSymbolRepository(db, &logger)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Upsert(ctx, &market.Symbol{Symbol: "ETHUSDT", Exchange: "mexc", Status: "TRADING"}))
+	require.NoError(t, repo.Delete(ctx, "ETHUSDT"))
+
+	created, updated, err := repo.UpsertMany(ctx, []*market.Symbol{
+		{Symbol: "BTCUSDT", Exchange: "mexc", Status: "TRADING"},
+		{Symbol: "BTCUSDT", Exchange: "mexc", Status: "BREAK"},
+		{Symbol: "ETHUSDT", Exchange: "mexc", Status: "BREAK"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, created)
+	assert.Equal(t, 1, updated)
+
+	btc, err := repo.GetBySymbol(ctx, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, "BREAK", btc.Status)
+
+	// Upserting does not restore a soft-deleted symbol
+	_, err = repo.GetBySymbol(ctx, "ETHUSDT")
+	assert.Error(t, err)
+
+	created, updated, err = repo.UpsertMany(ctx, nil)
+	require.NoError(t, err)
+	assert.Zero(t, created+updated)
+}
@@ -0,0 +1,57 @@
+package gorm
+
+import (
+	"context"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+// UnitOfWork implements port.UnitOfWork using GORM transactions
+type UnitOfWork struct {
+	db     *gorm.DB
+	logger *zerolog.Logger
+}
+
+// NewUnitOfWork creates a new UnitOfWork
+func NewUnitOfWork(db *gorm.DB, logger *zerolog.Logger) port.UnitOfWork {
+	return &UnitOfWork{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Do runs fn in a transaction with repositories bound to it. The transaction is also
+// stored in the context passed to fn, for repositories that read it from there.
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context, repos port.TxRepositories) error) error {
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txCtx := context.WithValue(ctx, port.TxContextKey, tx)
+		return fn(txCtx, &txRepositories{db: tx, logger: u.logger})
+	})
+	if err != nil {
+		u.logger.Debug().Err(err).Msg("Unit of work rolled back")
+	}
+	return err
+}
+
+// txRepositories creates repositories on the transaction of a unit of work
+type txRepositories struct {
+	db     *gorm.DB
+	logger *zerolog.Logger
+}
+
+func (r *txRepositories) APICredentials() port.APICredentialRepository {
+	return NewAPICredentialRepository(r.db, r.logger)
+}
+
+func (r *txRepositories) Orders() port.OrderRepository {
+	return NewOrderRepository(r.db, r.logger)
+}
+
+func (r *txRepositories) Symbols() port.SymbolRepository {
+	return NewSymbolRepository(r.db, r.logger)
+}
+
+// Ensure UnitOfWork implements port.UnitOfWork
+var _ port.UnitOfWork = (*UnitOfWork)(nil)
@@ -0,0 +1,96 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupUnitOfWork(t *testing.T) (port.UnitOfWork, port.APICredentialRepository, *model.APICredential) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "uow.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.APICredentialEntity{}))
+
+	log := zerolog.Nop()
+	repo := NewAPICredentialRepository(db, &log)
+	old := model.NewAPICredential("user-1", "mexc", "old-key", "old-secret", "main")
+	require.NoError(t, repo.Save(context.Background(), old))
+
+	return NewUnitOfWork(db, &log), repo, old
+}
+
+// rotate saves a new credential and revokes old inside uow, failing with failWith after both writes
+func rotate(ctx context.Context, uow port.UnitOfWork, old *model.APICredential, failWith error) (*model.APICredential, error) {
+	replacement := model.NewAPICredential(old.UserID, old.Exchange, "new-key", "new-secret", old.Label)
+	return replacement, uow.Do(ctx, func(ctx context.Context, repos port.TxRepositories) error {
+		if err := repos.APICredentials().Save(ctx, replacement); err != nil {
+			return err
+		}
+		if err := repos.APICredentials().UpdateStatus(ctx, old.ID, model.APICredentialStatusRevoked); err != nil {
+			return err
+		}
+		return failWith
+	})
+}
+
+func TestUnitOfWork_CommitsAllWrites(t *testing.T) {
+	uow, repo, old := setupUnitOfWork(t)
+	ctx := context.Background()
+
+	replacement, err := rotate(ctx, uow, old, nil)
+	require.NoError(t, err)
+
+	saved, err := repo.GetByID(ctx, replacement.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "new-key", saved.APIKey)
+	revoked, err := repo.GetByID(ctx, old.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.APICredentialStatusRevoked, revoked.Status)
+}
+
+func TestUnitOfWork_ErrorRollsBackAllWrites(t *testing.T) {
+	uow, repo, old := setupUnitOfWork(t)
+	ctx := context.Background()
+	failure := errors.New("exchange rejected key")
+
+	replacement, err := rotate(ctx, uow, old, failure)
+	assert.ErrorIs(t, err, failure)
+
+	missing, err := repo.GetByID(ctx, replacement.ID)
+	require.NoError(t, err)
+	assert.Nil(t, missing, "new credential is not saved")
+	unchanged, err := repo.GetByID(ctx, old.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.APICredentialStatusActive, unchanged.Status, "old credential is not revoked")
+}
+
+func TestUnitOfWork_PanicRollsBack(t *testing.T) {
+	uow, repo, old := setupUnitOfWork(t)
+	ctx := context.Background()
+
+	assert.Panics(t, func() {
+		_ = uow.Do(ctx, func(ctx context.Context, repos port.TxRepositories) error {
+			if err := repos.APICredentials().UpdateStatus(ctx, old.ID, model.APICredentialStatusRevoked); err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	})
+
+	unchanged, err := repo.GetByID(ctx, old.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.APICredentialStatusActive, unchanged.Status)
+}
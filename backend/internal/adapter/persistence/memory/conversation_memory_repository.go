@@ -50,7 +50,7 @@ func (r *ConversationMemoryRepository) GetConversation(ctx context.Context, conv
 
 	conversation, exists := r.conversations[conversationID]
 	if !exists {
-		return nil, errors.New("conversation not found")
+		return nil, model.ErrConversationNotFound
 	}
 
 	return conversation, nil
@@ -94,7 +94,7 @@ func (r *ConversationMemoryRepository) DeleteConversation(ctx context.Context, c
 
 	// Check if conversation exists
 	if _, exists := r.conversations[conversationID]; !exists {
-		return errors.New("conversation not found")
+		return model.ErrConversationNotFound
 	}
 
 	// Delete conversation
@@ -114,7 +114,7 @@ func (r *ConversationMemoryRepository) SaveMessage(ctx context.Context, message
 
 	// Check if conversation exists
 	if _, exists := r.conversations[message.ConversationID]; !exists {
-		return errors.New("conversation not found")
+		return model.ErrConversationNotFound
 	}
 
 	// Initialize messages slice if it doesn't exist
@@ -139,7 +139,7 @@ func (r *ConversationMemoryRepository) GetMessages(ctx context.Context, conversa
 
 	// Check if conversation exists
 	if _, exists := r.conversations[conversationID]; !exists {
-		return nil, errors.New("conversation not found")
+		return nil, model.ErrConversationNotFound
 	}
 
 	// Get messages
@@ -74,6 +74,9 @@ func (p *SystemInfoProvider) GetSystemInfo(ctx context.Context) (*status.SystemI
 		TotalAllocatedMemory: memStats.TotalAlloc,
 		GCPauseTotal:        memStats.PauseTotalNs,
 		LastGCPause:         memStats.PauseNs[(memStats.NumGC+255)%256],
+		HeapInUse:           memStats.HeapInuse,
+		SysMemory:           memStats.Sys,
+		NumGC:               memStats.NumGC,
 	}, nil
 }
 
@@ -0,0 +1,64 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ENS registry deployment, identical on mainnet and the main testnets
+const ensRegistryAddress = "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e"
+
+// ENS function selectors
+const (
+	selectorResolver = "0x0178b8bf" // resolver(bytes32)
+	selectorAddr     = "0x3b3b57de" // addr(bytes32)
+)
+
+// ResolveENS resolves an ENS name to the address stored in its resolver
+func (p *EthereumProvider) ResolveENS(ctx context.Context, name string) (string, error) {
+	node := hexutil.Encode(ensNamehash(name))[2:]
+
+	resolverData, err := p.ethCall(ctx, ensRegistryAddress, selectorResolver+node)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up ENS resolver for %s: %w", name, err)
+	}
+	resolver := common.BytesToAddress(resolverData)
+	if resolver == (common.Address{}) {
+		return "", model.ErrENSNameNotFound
+	}
+
+	addrData, err := p.ethCall(ctx, resolver.Hex(), selectorAddr+node)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve ENS name %s: %w", name, err)
+	}
+	address := common.BytesToAddress(addrData)
+	if address == (common.Address{}) {
+		return "", model.ErrENSNameNotFound
+	}
+
+	return address.Hex(), nil
+}
+
+// ensNamehash computes the EIP-137 namehash of a name. Names are only lowercased, full
+// UTS-46 normalization is expected to happen client-side.
+func ensNamehash(name string) []byte {
+	node := make([]byte, 32)
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return node
+	}
+
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		labelHash := crypto.Keccak256([]byte(labels[i]))
+		node = crypto.Keccak256(node, labelHash)
+	}
+
+	return node
+}
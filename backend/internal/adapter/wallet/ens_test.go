@@ -0,0 +1,43 @@
+package wallet
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestENSNamehash(t *testing.T) {
+	assert.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000000000", hexutil.Encode(ensNamehash("")))
+	assert.Equal(t, "0x93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae", hexutil.Encode(ensNamehash("eth")))
+	assert.Equal(t, "0xde9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f", hexutil.Encode(ensNamehash("foo.eth")))
+	assert.Equal(t, ensNamehash("foo.eth"), ensNamehash("Foo.ETH"))
+}
+
+func TestEthereumProviderResolveENS(t *testing.T) {
+	const resolver = "0x4976fb03C32e5B8cfe2b6cCB31c09Ba78EBaBa41"
+
+	rpc := newMockRPCServer()
+	rpc.results[strings.ToLower(ensRegistryAddress)+":"+selectorResolver] = "0x000000000000000000000000" + strings.ToLower(resolver[2:])
+	rpc.results[strings.ToLower(resolver)+":"+selectorAddr] = "0x000000000000000000000000" + strings.ToLower(testHolder[2:])
+	server := httptest.NewServer(rpc)
+	defer server.Close()
+
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	provider := NewEthereumProvider(1, "Ethereum", server.URL, "https://etherscan.io", &logger).(*EthereumProvider)
+
+	address, err := provider.ResolveENS(context.Background(), "foo.eth")
+	require.NoError(t, err)
+	assert.Equal(t, testHolder, address)
+
+	// A name without a resolver is reported as not found
+	rpc.results[strings.ToLower(ensRegistryAddress)+":"+selectorResolver] = "0x" + strings.Repeat("0", 64)
+	_, err = provider.ResolveENS(context.Background(), "missing.eth")
+	assert.ErrorIs(t, err, model.ErrENSNameNotFound)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
@@ -16,23 +18,36 @@ import (
 	"github.com/rs/zerolog"
 )
 
-// EthereumProvider implements the Web3WalletProvider interface for Ethereum
+// EthereumProvider implements the Web3WalletProvider interface for Ethereum and other
+// EVM-compatible chains
 type EthereumProvider struct {
 	*BaseProvider
 	chainID  int64
 	network  string
 	rpcURL   string
 	explorer string
+
+	httpClient *http.Client
+	tokenMeta  map[string]tokenMetadata
+	metaMutex  sync.RWMutex
 }
 
 // NewEthereumProvider creates a new Ethereum wallet provider
 func NewEthereumProvider(chainID int64, network, rpcURL, explorer string, logger *zerolog.Logger) port.Web3WalletProvider {
+	return NewEVMProvider("Ethereum", chainID, network, rpcURL, explorer, logger)
+}
+
+// NewEVMProvider creates a wallet provider for an EVM-compatible chain such as BSC or Polygon.
+// Providers are registered by name, so each chain needs a distinct name.
+func NewEVMProvider(name string, chainID int64, network, rpcURL, explorer string, logger *zerolog.Logger) port.Web3WalletProvider {
 	return &EthereumProvider{
-		BaseProvider: NewBaseProvider("Ethereum", model.WalletTypeWeb3, logger),
+		BaseProvider: NewBaseProvider(name, model.WalletTypeWeb3, logger),
 		chainID:      chainID,
 		network:      network,
 		rpcURL:       rpcURL,
 		explorer:     explorer,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+		tokenMeta:    make(map[string]tokenMetadata),
 	}
 }
 
@@ -65,7 +80,7 @@ func (p *EthereumProvider) Connect(ctx context.Context, params map[string]interf
 		return nil, err
 	}
 	if !valid {
-		return nil, errors.New("invalid Ethereum address")
+		return nil, fmt.Errorf("invalid %s address", p.GetName())
 	}
 
 	// Create wallet
@@ -74,7 +89,7 @@ func (p *EthereumProvider) Connect(ctx context.Context, params map[string]interf
 	wallet.LastUpdated = time.Now()
 
 	// Set metadata
-	wallet.SetMetadata("Ethereum Wallet", "Connected via Web3", []string{"web3", "ethereum"})
+	wallet.SetMetadata(p.GetName()+" Wallet", "Connected via Web3", []string{"web3", strings.ToLower(p.GetName())})
 	wallet.Metadata.Network = p.network
 	wallet.Metadata.Address = address
 	wallet.Metadata.ChainID = p.chainID
@@ -99,7 +114,7 @@ func (p *EthereumProvider) Verify(ctx context.Context, address string, message s
 		return false, err
 	}
 	if !valid {
-		return false, errors.New("invalid Ethereum address")
+		return false, fmt.Errorf("invalid %s address", p.GetName())
 	}
 
 	// Verify signature
@@ -146,7 +161,7 @@ func (p *EthereumProvider) Verify(ctx context.Context, address string, message s
 func (p *EthereumProvider) GetBalance(ctx context.Context, wallet *model.Wallet) (*model.Wallet, error) {
 	// Check if wallet is an Ethereum wallet
 	if wallet.Type != model.WalletTypeWeb3 || wallet.Metadata.Network != p.network {
-		return nil, errors.New("not an Ethereum wallet")
+		return nil, fmt.Errorf("not a %s wallet", p.GetName())
 	}
 
 	// In a real implementation, we would query the Ethereum blockchain for the balance
@@ -166,6 +181,7 @@ func (p *EthereumProvider) Disconnect(ctx context.Context, walletID string) erro
 // IsValidAddress checks if an address is valid for this provider
 func (p *EthereumProvider) IsValidAddress(ctx context.Context, address string) (bool, error) {
 	// Check if the address is a valid Ethereum address
+	// All EVM chains share the Ethereum address format:
 	// Ethereum addresses are 42 characters long, starting with "0x" followed by 40 hexadecimal characters
 	if !common.IsHexAddress(address) {
 		return false, nil
@@ -177,5 +193,9 @@ func (p *EthereumProvider) IsValidAddress(ctx context.Context, address string) (
 	return match, nil
 }
 
-// Ensure EthereumProvider implements port.Web3WalletProvider
-var _ port.Web3WalletProvider = (*EthereumProvider)(nil)
+// Ensure EthereumProvider implements the Web3 provider capabilities
+var (
+	_ port.Web3WalletProvider   = (*EthereumProvider)(nil)
+	_ port.TokenBalanceProvider = (*EthereumProvider)(nil)
+	_ port.ENSResolver          = (*EthereumProvider)(nil)
+)
@@ -0,0 +1,225 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ERC-20 function selectors
+const (
+	selectorBalanceOf = "0x70a08231" // balanceOf(address)
+	selectorDecimals  = "0x313ce567" // decimals()
+	selectorSymbol    = "0x95d89b41" // symbol()
+)
+
+// tokenMetadata holds the immutable properties of a token contract
+type tokenMetadata struct {
+	symbol   string
+	decimals uint8
+}
+
+// rpcRequestID is shared by all JSON-RPC requests issued by EVM providers
+var rpcRequestID atomic.Int64
+
+// rpcRequest is a JSON-RPC 2.0 request
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int64         `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response
+type rpcResponse struct {
+	ID     int64           `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// GetTokenBalances returns the balances of the given ERC-20 contracts held by address,
+// normalized by each token's decimals
+func (p *EthereumProvider) GetTokenBalances(ctx context.Context, address string, tokenContracts []string) ([]model.TokenBalance, error) {
+	valid, err := p.IsValidAddress(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, fmt.Errorf("invalid %s address", p.GetName())
+	}
+
+	balances := make([]model.TokenBalance, 0, len(tokenContracts))
+	for _, contract := range tokenContracts {
+		if !common.IsHexAddress(contract) {
+			return nil, fmt.Errorf("invalid token contract address: %s", contract)
+		}
+
+		meta, err := p.getTokenMetadata(ctx, contract)
+		if err != nil {
+			return nil, err
+		}
+
+		raw, err := p.callUint(ctx, contract, selectorBalanceOf+encodeAddressArg(address))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get balance of %s: %w", contract, err)
+		}
+
+		balances = append(balances, model.TokenBalance{
+			Contract:   common.HexToAddress(contract).Hex(),
+			Symbol:     meta.symbol,
+			Decimals:   meta.decimals,
+			RawBalance: raw.String(),
+			Balance:    normalizeTokenAmount(raw, meta.decimals),
+		})
+	}
+
+	return balances, nil
+}
+
+// getTokenMetadata returns the symbol and decimals of a token, querying the contract only once
+func (p *EthereumProvider) getTokenMetadata(ctx context.Context, contract string) (tokenMetadata, error) {
+	key := strings.ToLower(contract)
+
+	p.metaMutex.RLock()
+	meta, ok := p.tokenMeta[key]
+	p.metaMutex.RUnlock()
+	if ok {
+		return meta, nil
+	}
+
+	decimals, err := p.callUint(ctx, contract, selectorDecimals)
+	if err != nil {
+		return tokenMetadata{}, fmt.Errorf("failed to get decimals of %s: %w", contract, err)
+	}
+	if !decimals.IsUint64() || decimals.Uint64() > 255 {
+		return tokenMetadata{}, fmt.Errorf("invalid decimals for %s: %s", contract, decimals)
+	}
+
+	symbolData, err := p.ethCall(ctx, contract, selectorSymbol)
+	if err != nil {
+		return tokenMetadata{}, fmt.Errorf("failed to get symbol of %s: %w", contract, err)
+	}
+
+	meta = tokenMetadata{
+		symbol:   decodeABIString(symbolData),
+		decimals: uint8(decimals.Uint64()),
+	}
+
+	p.metaMutex.Lock()
+	p.tokenMeta[key] = meta
+	p.metaMutex.Unlock()
+
+	return meta, nil
+}
+
+// callUint performs an eth_call and decodes the result as a uint256
+func (p *EthereumProvider) callUint(ctx context.Context, contract, data string) (*big.Int, error) {
+	result, err := p.ethCall(ctx, contract, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, errors.New("empty eth_call result")
+	}
+	if len(result) > 32 {
+		result = result[:32]
+	}
+	return new(big.Int).SetBytes(result), nil
+}
+
+// ethCall performs an eth_call against the latest block and returns the raw result bytes
+func (p *EthereumProvider) ethCall(ctx context.Context, contract, data string) ([]byte, error) {
+	call := map[string]string{
+		"to":   contract,
+		"data": data,
+	}
+
+	var result string
+	if err := p.rpcCall(ctx, "eth_call", []interface{}{call, "latest"}, &result); err != nil {
+		return nil, err
+	}
+
+	return hexutil.Decode(result)
+}
+
+// rpcCall sends a JSON-RPC request to the provider's RPC endpoint and decodes the result
+func (p *EthereumProvider) rpcCall(ctx context.Context, method string, params []interface{}, result interface{}) error {
+	body, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		ID:      rpcRequestID.Add(1),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal RPC request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.rpcURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create RPC request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send RPC request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("RPC request failed with status %d", resp.StatusCode)
+	}
+
+	var rpcResp rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return fmt.Errorf("failed to decode RPC response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("RPC error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+	}
+
+	return json.Unmarshal(rpcResp.Result, result)
+}
+
+// encodeAddressArg ABI-encodes an address as a 32-byte call argument without 0x prefix
+func encodeAddressArg(address string) string {
+	return hexutil.Encode(common.LeftPadBytes(common.HexToAddress(address).Bytes(), 32))[2:]
+}
+
+// decodeABIString decodes a string return value. Some older tokens return bytes32
+// instead of a dynamic string, so both encodings are accepted.
+func decodeABIString(data []byte) string {
+	if len(data) >= 64 {
+		offset := new(big.Int).SetBytes(data[:32])
+		// Compare against the remaining bytes so hostile offsets and lengths cannot overflow
+		if offset.IsUint64() && offset.Uint64() <= uint64(len(data))-32 {
+			start := offset.Uint64()
+			length := new(big.Int).SetBytes(data[start : start+32])
+			if length.IsUint64() && length.Uint64() <= uint64(len(data))-start-32 {
+				return string(data[start+32 : start+32+length.Uint64()])
+			}
+		}
+	}
+
+	return strings.TrimRight(string(data), "\x00")
+}
+
+// normalizeTokenAmount converts a raw token amount into a decimal value
+func normalizeTokenAmount(raw *big.Int, decimals uint8) float64 {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	value, _ := new(big.Float).Quo(new(big.Float).SetInt(raw), new(big.Float).SetInt(scale)).Float64()
+	return value
+}
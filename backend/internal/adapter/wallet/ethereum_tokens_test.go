@@ -0,0 +1,179 @@
+package wallet
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testHolder   = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+	testUSDC     = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+	testMKR      = "0x9f8F72aA9304c8B593d555F12eF6589cC3A579A2"
+	abiUSDCValue = "0x" + "0000000000000000000000000000000000000000000000000000000077359400" // 2000000000
+	abiDecimals6 = "0x" + "0000000000000000000000000000000000000000000000000000000000000006"
+	// dynamic string "USDC"
+	abiSymbolUSDC = "0x" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000004" +
+		"5553444300000000000000000000000000000000000000000000000000000000"
+	// 1.5 * 10^18
+	abiMKRValue   = "0x" + "00000000000000000000000000000000000000000000000014d1120d7b160000"
+	abiDecimals18 = "0x" + "0000000000000000000000000000000000000000000000000000000000000012"
+	// bytes32 "MKR"
+	abiSymbolMKR = "0x" + "4d4b520000000000000000000000000000000000000000000000000000000000"
+)
+
+// mockRPCServer serves canned eth_call results keyed by contract and selector
+type mockRPCServer struct {
+	mu      sync.Mutex
+	calls   map[string]int
+	results map[string]string
+}
+
+func newMockRPCServer() *mockRPCServer {
+	return &mockRPCServer{
+		calls: make(map[string]int),
+		results: map[string]string{
+			strings.ToLower(testUSDC) + ":" + selectorBalanceOf: abiUSDCValue,
+			strings.ToLower(testUSDC) + ":" + selectorDecimals:  abiDecimals6,
+			strings.ToLower(testUSDC) + ":" + selectorSymbol:    abiSymbolUSDC,
+			strings.ToLower(testMKR) + ":" + selectorBalanceOf:  abiMKRValue,
+			strings.ToLower(testMKR) + ":" + selectorDecimals:   abiDecimals18,
+			strings.ToLower(testMKR) + ":" + selectorSymbol:     abiSymbolMKR,
+		},
+	}
+}
+
+func (s *mockRPCServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     int64             `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Method != "eth_call" {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	var call struct {
+		To   string `json:"to"`
+		Data string `json:"data"`
+	}
+	_ = json.Unmarshal(req.Params[0], &call)
+
+	selector := call.Data[:10]
+	key := strings.ToLower(call.To) + ":" + selector
+
+	s.mu.Lock()
+	s.calls[selector]++
+	result, ok := s.results[key]
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if !ok {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"error":   map[string]interface{}{"code": -32000, "message": "execution reverted"},
+		})
+		return
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      req.ID,
+		"result":  result,
+	})
+}
+
+func (s *mockRPCServer) callCount(selector string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[selector]
+}
+
+func TestEthereumProviderGetTokenBalances(t *testing.T) {
+	rpc := newMockRPCServer()
+	server := httptest.NewServer(rpc)
+	defer server.Close()
+
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	provider := NewEthereumProvider(1, "Ethereum", server.URL, "https://etherscan.io", &logger).(*EthereumProvider)
+
+	balances, err := provider.GetTokenBalances(context.Background(), testHolder, []string{testUSDC, testMKR})
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+
+	assert.Equal(t, testUSDC, balances[0].Contract)
+	assert.Equal(t, "USDC", balances[0].Symbol)
+	assert.Equal(t, uint8(6), balances[0].Decimals)
+	assert.Equal(t, "2000000000", balances[0].RawBalance)
+	assert.InDelta(t, 2000.0, balances[0].Balance, 1e-9)
+
+	assert.Equal(t, "MKR", balances[1].Symbol)
+	assert.Equal(t, uint8(18), balances[1].Decimals)
+	assert.InDelta(t, 1.5, balances[1].Balance, 1e-9)
+
+	// Metadata is cached, balances are always fetched
+	_, err = provider.GetTokenBalances(context.Background(), testHolder, []string{testUSDC, testMKR})
+	require.NoError(t, err)
+	assert.Equal(t, 2, rpc.callCount(selectorDecimals))
+	assert.Equal(t, 2, rpc.callCount(selectorSymbol))
+	assert.Equal(t, 4, rpc.callCount(selectorBalanceOf))
+}
+
+func TestEthereumProviderGetTokenBalancesErrors(t *testing.T) {
+	rpc := newMockRPCServer()
+	server := httptest.NewServer(rpc)
+	defer server.Close()
+
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	provider := NewEthereumProvider(1, "Ethereum", server.URL, "https://etherscan.io", &logger).(*EthereumProvider)
+	ctx := context.Background()
+
+	_, err := provider.GetTokenBalances(ctx, "invalid_address", []string{testUSDC})
+	assert.Error(t, err)
+
+	_, err = provider.GetTokenBalances(ctx, testHolder, []string{"not-a-contract"})
+	assert.Error(t, err)
+
+	// Unknown contract reverts
+	_, err = provider.GetTokenBalances(ctx, testHolder, []string{"0x0000000000000000000000000000000000000001"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "execution reverted")
+}
+
+func TestEncodeAddressArg(t *testing.T) {
+	assert.Equal(t, "000000000000000000000000742d35cc6634c0532925a3b844bc454e4438f44e", encodeAddressArg(testHolder))
+}
+
+func TestDecodeABIString(t *testing.T) {
+	decode := func(hexData string) string {
+		data, err := hex.DecodeString(strings.TrimPrefix(hexData, "0x"))
+		require.NoError(t, err)
+		return decodeABIString(data)
+	}
+
+	assert.Equal(t, "USDC", decode(abiSymbolUSDC))
+
+	// A length near 2^64 wraps start+32+length around; it must fall back instead of panicking
+	overflowing := "0x" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"000000000000000000000000000000000000000000000000ffffffffffffffe0" +
+		"5553444300000000000000000000000000000000000000000000000000000000"
+	assert.NotPanics(t, func() { decode(overflowing) })
+
+	hugeOffset := "0x" +
+		"000000000000000000000000000000000000000000000000ffffffffffffffff" +
+		"0000000000000000000000000000000000000000000000000000000000000004"
+	assert.NotPanics(t, func() { decode(hugeOffset) })
+}
@@ -0,0 +1,77 @@
+package wallet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEVMProviders(t *testing.T) {
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+
+	testCases := []struct {
+		name     string
+		chainID  int64
+		rpcURL   string
+		explorer string
+	}{
+		{name: "BSC", chainID: 56, rpcURL: "https://bsc-dataseed.binance.org", explorer: "https://bscscan.com"},
+		{name: "Polygon", chainID: 137, rpcURL: "https://polygon-rpc.com", explorer: "https://polygonscan.com"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := NewEVMProvider(tc.name, tc.chainID, tc.name, tc.rpcURL, tc.explorer, &logger)
+
+			assert.Equal(t, tc.name, provider.GetName())
+			assert.Equal(t, model.WalletTypeWeb3, provider.GetType())
+			assert.Equal(t, tc.chainID, provider.GetChainID())
+			assert.Equal(t, tc.name, provider.GetNetwork())
+
+			valid, err := provider.IsValidAddress(context.Background(), "0x742d35Cc6634C0532925a3b844Bc454e4438f44e")
+			require.NoError(t, err)
+			assert.True(t, valid)
+
+			valid, err = provider.IsValidAddress(context.Background(), "bnb1grpf0955h0ykzq3ar5nmum7y6gdfl6lxfn46h2")
+			require.NoError(t, err)
+			assert.False(t, valid)
+
+			wallet, err := provider.Connect(context.Background(), map[string]interface{}{
+				"user_id": "user123",
+				"address": "0x742d35Cc6634C0532925a3b844Bc454e4438f44e",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.chainID, wallet.Metadata.ChainID)
+			assert.Equal(t, tc.name, wallet.Metadata.Network)
+			assert.Equal(t, tc.explorer, wallet.Metadata.Explorer)
+
+			// Wallets from another chain are rejected
+			other := model.NewWeb3Wallet("user123", "Ethereum", "0x742d35Cc6634C0532925a3b844Bc454e4438f44e")
+			_, err = provider.GetBalance(context.Background(), other)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestProviderRegistryWithEVMChains(t *testing.T) {
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	registry := NewProviderRegistry()
+
+	registry.RegisterProvider(NewEthereumProvider(1, "Ethereum", "https://mainnet.infura.io/v3/test_key", "https://etherscan.io", &logger))
+	registry.RegisterProvider(NewEVMProvider("BSC", 56, "BSC", "https://bsc-dataseed.binance.org", "https://bscscan.com", &logger))
+	registry.RegisterProvider(NewEVMProvider("Polygon", 137, "Polygon", "https://polygon-rpc.com", "https://polygonscan.com", &logger))
+
+	assert.Len(t, registry.GetAllWeb3Providers(), 3)
+
+	bsc, err := registry.GetWeb3Provider("BSC")
+	require.NoError(t, err)
+	assert.Equal(t, int64(56), bsc.GetChainID())
+
+	polygon, err := registry.GetWeb3Provider("Polygon")
+	require.NoError(t, err)
+	assert.Equal(t, int64(137), polygon.GetChainID())
+}
@@ -2,7 +2,10 @@ package wallet
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
@@ -13,17 +16,33 @@ import (
 // MEXCProvider implements the ExchangeWalletProvider interface for MEXC
 type MEXCProvider struct {
 	*BaseProvider
-	mexcClient port.MEXCClient
-	apiKey     string
-	apiSecret  string
+	mexcClient      port.MEXCClient
+	transactionRepo port.WalletTransactionRepository
+	apiKey          string
+	apiSecret       string
+}
+
+// MEXCProviderOption configures a MEXCProvider
+type MEXCProviderOption func(*MEXCProvider)
+
+// WithTransactionRepository stores the deposit and withdrawal history fetched by
+// SyncTransferHistory in repo
+func WithTransactionRepository(repo port.WalletTransactionRepository) MEXCProviderOption {
+	return func(p *MEXCProvider) {
+		p.transactionRepo = repo
+	}
 }
 
 // NewMEXCProvider creates a new MEXC wallet provider
-func NewMEXCProvider(mexcClient port.MEXCClient, logger *zerolog.Logger) port.ExchangeWalletProvider {
-	return &MEXCProvider{
+func NewMEXCProvider(mexcClient port.MEXCClient, logger *zerolog.Logger, options ...MEXCProviderOption) port.ExchangeWalletProvider {
+	p := &MEXCProvider{
 		BaseProvider: NewBaseProvider("MEXC", model.WalletTypeExchange, logger),
 		mexcClient:   mexcClient,
 	}
+	for _, option := range options {
+		option(p)
+	}
+	return p
 }
 
 // SetAPICredentials sets the API credentials for the exchange
@@ -125,5 +144,90 @@ func (p *MEXCProvider) IsValidAddress(ctx context.Context, address string) (bool
 	return false, errors.New("not applicable for exchange wallets")
 }
 
+// GetDepositHistory gets the account's deposits of asset between start and end. An empty
+// asset covers every asset.
+func (p *MEXCProvider) GetDepositHistory(ctx context.Context, asset string, start, end time.Time) ([]*model.WalletTransaction, error) {
+	history, err := p.capitalHistory()
+	if err != nil {
+		return nil, err
+	}
+	txs, err := history.GetDepositHistory(ctx, asset, start, end)
+	if err != nil {
+		p.logger.Error().Err(err).Str("asset", asset).Msg("Failed to get deposit history from MEXC")
+		return nil, err
+	}
+	return txs, nil
+}
+
+// GetWithdrawHistory gets the account's withdrawals of asset between start and end. An
+// empty asset covers every asset.
+func (p *MEXCProvider) GetWithdrawHistory(ctx context.Context, asset string, start, end time.Time) ([]*model.WalletTransaction, error) {
+	history, err := p.capitalHistory()
+	if err != nil {
+		return nil, err
+	}
+	txs, err := history.GetWithdrawHistory(ctx, asset, start, end)
+	if err != nil {
+		p.logger.Error().Err(err).Str("asset", asset).Msg("Failed to get withdrawal history from MEXC")
+		return nil, err
+	}
+	return txs, nil
+}
+
+// SyncTransferHistory fetches the wallet's deposits and withdrawals between start and end
+// and stores them in the transaction repository. Each transfer is stored under an ID
+// derived from the exchange's, so syncing overlapping ranges updates rather than
+// duplicates it. It returns the number of transfers stored.
+func (p *MEXCProvider) SyncTransferHistory(ctx context.Context, wallet *model.Wallet, start, end time.Time) (int, error) {
+	if p.transactionRepo == nil {
+		return 0, errors.New("no transaction repository configured for MEXC transfer history")
+	}
+	if wallet.Exchange != "MEXC" {
+		return 0, errors.New("not a MEXC wallet")
+	}
+
+	deposits, err := p.GetDepositHistory(ctx, "", start, end)
+	if err != nil {
+		return 0, err
+	}
+	withdrawals, err := p.GetWithdrawHistory(ctx, "", start, end)
+	if err != nil {
+		return 0, err
+	}
+
+	stored := 0
+	for _, tx := range append(deposits, withdrawals...) {
+		tx.ID = transferID(wallet.ID, tx)
+		tx.UserID = wallet.UserID
+		tx.WalletID = wallet.ID
+		if err := p.transactionRepo.Save(ctx, tx); err != nil {
+			return stored, fmt.Errorf("failed to store %s %s: %w", tx.Type, tx.ExternalID, err)
+		}
+		stored++
+	}
+
+	p.logger.Info().
+		Str("walletID", wallet.ID).
+		Int("deposits", len(deposits)).
+		Int("withdrawals", len(withdrawals)).
+		Msg("Synced MEXC transfer history")
+	return stored, nil
+}
+
+// capitalHistory returns the client as a capital history provider
+func (p *MEXCProvider) capitalHistory() (port.CapitalHistoryProvider, error) {
+	history, ok := p.mexcClient.(port.CapitalHistoryProvider)
+	if !ok {
+		return nil, fmt.Errorf("MEXC client %T cannot fetch deposit and withdrawal history", p.mexcClient)
+	}
+	return history, nil
+}
+
+// transferID derives a stable transaction ID for an exchange transfer into a wallet
+func transferID(walletID string, tx *model.WalletTransaction) string {
+	sum := sha256.Sum256([]byte(walletID + "|" + string(tx.Type) + "|" + tx.ExternalID))
+	return "mexc-" + hex.EncodeToString(sum[:16])
+}
+
 // Ensure MEXCProvider implements port.ExchangeWalletProvider
 var _ port.ExchangeWalletProvider = (*MEXCProvider)(nil)
@@ -0,0 +1,97 @@
+package wallet
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/entity"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/repo"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// capitalHistoryClient is a MEXC client reporting a fixed transfer history
+type capitalHistoryClient struct {
+	port.MEXCClient
+	deposits    []model.WalletTransaction
+	withdrawals []model.WalletTransaction
+}
+
+func (c *capitalHistoryClient) GetDepositHistory(ctx context.Context, asset string, start, end time.Time) ([]*model.WalletTransaction, error) {
+	return copyTransactions(c.deposits), nil
+}
+
+func (c *capitalHistoryClient) GetWithdrawHistory(ctx context.Context, asset string, start, end time.Time) ([]*model.WalletTransaction, error) {
+	return copyTransactions(c.withdrawals), nil
+}
+
+// copyTransactions returns fresh copies, as a client decodes a new response on every call
+func copyTransactions(txs []model.WalletTransaction) []*model.WalletTransaction {
+	copies := make([]*model.WalletTransaction, len(txs))
+	for i := range txs {
+		tx := txs[i]
+		copies[i] = &tx
+	}
+	return copies
+}
+
+func TestMEXCProvider_SyncTransferHistoryStoresTransfers(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.WalletTransactionEntity{}))
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	transactions := repo.NewGormWalletTransactionRepository(db, &logger)
+
+	client := &capitalHistoryClient{
+		deposits: []model.WalletTransaction{{
+			Type: model.TransactionTypeDeposit, Asset: "USDT", Amount: 150.5, DetectedAt: time.UnixMilli(1717000000000),
+			ExternalID: "0xabc123", TxID: "0xabc123", Network: "TRC20", Status: model.TransactionStatusPending,
+		}},
+		withdrawals: []model.WalletTransaction{{
+			Type: model.TransactionTypeWithdrawal, Asset: "BTC", Amount: 0.01, DetectedAt: time.UnixMilli(1717010000000),
+			ExternalID: "bb17a2d4", TxID: "0x789", Fee: 0.0002, Status: model.TransactionStatusCompleted,
+		}},
+	}
+	provider := NewMEXCProvider(client, &logger, WithTransactionRepository(transactions)).(*MEXCProvider)
+	wallet := &model.Wallet{ID: "wallet-1", UserID: "user-1", Exchange: "MEXC"}
+
+	stored, err := provider.SyncTransferHistory(context.Background(), wallet, time.Time{}, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored)
+
+	// Syncing an overlapping range updates the pending deposit instead of duplicating it
+	client.deposits[0].Status = model.TransactionStatusCompleted
+	_, err = provider.SyncTransferHistory(context.Background(), wallet, time.Time{}, time.Now())
+	require.NoError(t, err)
+
+	txs, err := transactions.GetByWalletID(context.Background(), "wallet-1", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+
+	withdrawal, deposit := txs[0], txs[1]
+	assert.Equal(t, model.TransactionTypeDeposit, deposit.Type)
+	assert.Equal(t, "user-1", deposit.UserID)
+	assert.Equal(t, model.TransactionStatusCompleted, deposit.Status)
+	assert.Equal(t, "0xabc123", deposit.TxID)
+	assert.Equal(t, "TRC20", deposit.Network)
+	assert.Equal(t, 150.5, deposit.Amount)
+
+	assert.Equal(t, model.TransactionTypeWithdrawal, withdrawal.Type)
+	assert.Equal(t, "bb17a2d4", withdrawal.ExternalID)
+	assert.Equal(t, 0.0002, withdrawal.Fee)
+	assert.NotEqual(t, deposit.ID, withdrawal.ID)
+}
+
+func TestMEXCProvider_SyncTransferHistoryRequiresRepository(t *testing.T) {
+	logger := zerolog.Nop()
+	provider := NewMEXCProvider(&capitalHistoryClient{}, &logger).(*MEXCProvider)
+
+	_, err := provider.SyncTransferHistory(context.Background(), &model.Wallet{ID: "wallet-1", Exchange: "MEXC"}, time.Time{}, time.Now())
+	assert.Error(t, err)
+}
@@ -0,0 +1,77 @@
+package apperror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// RequestIDHeader is the response header carrying the request correlation ID
+const RequestIDHeader = "X-Request-ID"
+
+// ErrTimeout is returned when an operation does not complete before its deadline
+var ErrTimeout = &AppError{StatusCode: http.StatusGatewayTimeout, Code: "TIMEOUT", Message: "Request timed out"}
+
+// ErrorEnvelope is the JSON body written for every API error
+type ErrorEnvelope struct {
+	Code      string      `json:"code"`
+	Message   string      `json:"message"`
+	Details   interface{} `json:"details,omitempty"`
+	RequestID string      `json:"requestId,omitempty"`
+}
+
+// FieldErrorer is implemented by validation errors that report problems per field
+type FieldErrorer interface {
+	error
+	FieldErrors() map[string]string
+}
+
+// Envelope converts the error into the standard envelope
+func (e *AppError) Envelope(requestID string) ErrorEnvelope {
+	return ErrorEnvelope{
+		Code:      e.Code,
+		Message:   e.Message,
+		Details:   e.Details,
+		RequestID: requestID,
+	}
+}
+
+// Resolve maps any error to an AppError. AppErrors, including wrapped sentinels such as
+// ErrNotFound, are returned as is; field validation errors become VALIDATION_ERROR;
+// deadlines become TIMEOUT; everything else is reported as an internal error.
+func Resolve(err error) *AppError {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	var fieldErr FieldErrorer
+	if errors.As(err, &fieldErr) {
+		return NewValidation("", fieldErr.FieldErrors(), err)
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &AppError{
+			StatusCode: ErrTimeout.StatusCode,
+			Code:       ErrTimeout.Code,
+			Message:    ErrTimeout.Message,
+			Err:        err,
+		}
+	}
+
+	return NewInternal(err)
+}
+
+// WriteError writes err as an ErrorEnvelope with the matching status code. The request ID
+// is taken from the X-Request-ID response header set by the correlation ID middleware.
+func WriteError(w http.ResponseWriter, err error) {
+	appErr := Resolve(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.StatusCode)
+
+	if encodeErr := json.NewEncoder(w).Encode(appErr.Envelope(w.Header().Get(RequestIDHeader))); encodeErr != nil {
+		w.Write([]byte(`{"code":"ENCODING_ERROR","message":"Failed to encode error response"}`))
+	}
+}
@@ -0,0 +1,113 @@
+package apperror_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fieldValidationError is a validation error reporting problems per field
+type fieldValidationError map[string]string
+
+func (e fieldValidationError) Error() string { return "invalid fields" }
+
+func (e fieldValidationError) FieldErrors() map[string]string { return e }
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    string
+		wantMessage string
+		wantDetails interface{}
+	}{
+		{
+			name:        "sentinel not found",
+			err:         apperror.ErrNotFound,
+			wantStatus:  http.StatusNotFound,
+			wantCode:    "NOT_FOUND",
+			wantMessage: "Resource not found",
+		},
+		{
+			name:        "wrapped sentinel",
+			err:         fmt.Errorf("loading order: %w", apperror.ErrConflict),
+			wantStatus:  http.StatusConflict,
+			wantCode:    "CONFLICT",
+			wantMessage: "Resource conflict",
+		},
+		{
+			name:        "invalid input with details",
+			err:         apperror.NewInvalid("Symbol is required", map[string]string{"symbol": "missing"}, nil),
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "INVALID_INPUT",
+			wantMessage: "Symbol is required",
+			wantDetails: map[string]interface{}{"symbol": "missing"},
+		},
+		{
+			name:        "field validation error",
+			err:         fieldValidationError{"price": "must be positive"},
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "VALIDATION_ERROR",
+			wantMessage: "Validation error",
+			wantDetails: map[string]interface{}{"price": "must be positive"},
+		},
+		{
+			name:        "external service",
+			err:         apperror.NewExternalService("MEXC", "Failed to get MEXC ticker", errors.New("connection refused")),
+			wantStatus:  http.StatusServiceUnavailable,
+			wantCode:    "EXTERNAL_SERVICE_ERROR",
+			wantMessage: "Failed to get MEXC ticker",
+		},
+		{
+			name:        "deadline exceeded",
+			err:         fmt.Errorf("query: %w", context.DeadlineExceeded),
+			wantStatus:  http.StatusGatewayTimeout,
+			wantCode:    "TIMEOUT",
+			wantMessage: "Request timed out",
+		},
+		{
+			name:        "plain error",
+			err:         errors.New("boom"),
+			wantStatus:  http.StatusInternalServerError,
+			wantCode:    "INTERNAL_ERROR",
+			wantMessage: "Internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			w.Header().Set(apperror.RequestIDHeader, "req-123")
+
+			apperror.WriteError(w, tt.err)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantCode, body["code"])
+			assert.Equal(t, tt.wantMessage, body["message"])
+			assert.Equal(t, "req-123", body["requestId"])
+			assert.Equal(t, tt.wantDetails, body["details"])
+		})
+	}
+}
+
+func TestWriteErrorOmitsMissingRequestID(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	apperror.WriteError(w, apperror.ErrUnauthorized)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.JSONEq(t, `{"code":"UNAUTHORIZED","message":"Unauthorized"}`, w.Body.String())
+}
@@ -1,7 +1,6 @@
 package apperror
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -210,15 +209,3 @@ func As(err error, target interface{}) bool {
 func Is(err, target error) bool {
 	return errors.Is(err, target)
 }
-
-// WriteError writes an error response to the http.ResponseWriter
-func WriteError(w http.ResponseWriter, err *AppError) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(err.StatusCode)
-
-	if encodeErr := json.NewEncoder(w).Encode(err.ToResponse()); encodeErr != nil {
-		// If encoding fails, write a simple error message
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(`{"error":{"code":"encoding_error","message":"Failed to encode error response"}}`))
-	}
-}
@@ -0,0 +1,339 @@
+package backtest
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+)
+
+// defaultPageSize is the number of candles fetched per repository query
+const defaultPageSize = 500
+
+// CandleSource feeds candles to a backtest in chronological order
+type CandleSource interface {
+	// Next returns the next candle, or false once the source is exhausted or has failed
+	Next() (*model.Kline, bool)
+	// Err returns the error that stopped the source, if any
+	Err() error
+}
+
+// Collect drains a source into a slice
+func Collect(source CandleSource) ([]*model.Kline, error) {
+	var candles []*model.Kline
+	for {
+		candle, ok := source.Next()
+		if !ok {
+			break
+		}
+		candles = append(candles, candle)
+	}
+	return candles, source.Err()
+}
+
+// SliceCandleSource serves candles from memory
+type SliceCandleSource struct {
+	candles []*model.Kline
+	index   int
+}
+
+// NewSliceCandleSource creates a source over an in-memory candle slice
+func NewSliceCandleSource(candles []*model.Kline) *SliceCandleSource {
+	return &SliceCandleSource{candles: candles}
+}
+
+// Next implements CandleSource
+func (s *SliceCandleSource) Next() (*model.Kline, bool) {
+	if s.index >= len(s.candles) {
+		return nil, false
+	}
+	candle := s.candles[s.index]
+	s.index++
+	return candle, true
+}
+
+// Err implements CandleSource
+func (s *SliceCandleSource) Err() error {
+	return nil
+}
+
+// RepositoryCandleSource pages candles out of the market repository
+type RepositoryCandleSource struct {
+	ctx      context.Context
+	repo     port.MarketRepository
+	symbol   string
+	exchange string
+	interval market.Interval
+	next     time.Time
+	end      time.Time
+	pageSize int
+	page     []*market.Candle
+	index    int
+	done     bool
+	err      error
+}
+
+// NewRepositoryCandleSource creates a source that reads candles opening between start and
+// end, fetching pageSize candles per query. pageSize defaults to 500 when not positive.
+func NewRepositoryCandleSource(ctx context.Context, repo port.MarketRepository, symbol, exchange string, interval market.Interval, start, end time.Time, pageSize int) *RepositoryCandleSource {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	return &RepositoryCandleSource{
+		ctx:      ctx,
+		repo:     repo,
+		symbol:   symbol,
+		exchange: exchange,
+		interval: interval,
+		next:     start,
+		end:      end,
+		pageSize: pageSize,
+	}
+}
+
+// Next implements CandleSource
+func (s *RepositoryCandleSource) Next() (*model.Kline, bool) {
+	if s.index >= len(s.page) {
+		if s.done || !s.fetchPage() {
+			return nil, false
+		}
+	}
+
+	candle := s.page[s.index]
+	s.index++
+	return candleToKline(candle), true
+}
+
+// Err implements CandleSource
+func (s *RepositoryCandleSource) Err() error {
+	return s.err
+}
+
+// fetchPage loads the next page of candles, returning false when none remain
+func (s *RepositoryCandleSource) fetchPage() bool {
+	if s.next.After(s.end) {
+		s.done = true
+		return false
+	}
+
+	page, err := s.repo.GetCandles(s.ctx, s.symbol, s.exchange, s.interval, s.next, s.end, s.pageSize)
+	if err != nil {
+		s.err = fmt.Errorf("failed to load candles: %w", err)
+		s.done = true
+		return false
+	}
+	if len(page) < s.pageSize {
+		s.done = true
+	}
+	if len(page) == 0 {
+		return false
+	}
+
+	s.page = page
+	s.index = 0
+	s.next = page[len(page)-1].OpenTime.Add(time.Nanosecond)
+	return true
+}
+
+// candleToKline converts a stored candle into the kline model used by strategies
+func candleToKline(candle *market.Candle) *model.Kline {
+	return &model.Kline{
+		Symbol:      candle.Symbol,
+		Interval:    model.KlineInterval(candle.Interval),
+		OpenTime:    candle.OpenTime,
+		CloseTime:   candle.CloseTime,
+		Open:        candle.Open,
+		High:        candle.High,
+		Low:         candle.Low,
+		Close:       candle.Close,
+		Volume:      candle.Volume,
+		QuoteVolume: candle.QuoteVolume,
+		TradeCount:  candle.TradeCount,
+		IsClosed:    candle.Complete,
+	}
+}
+
+// csvColumns are the columns a candle CSV must provide. close_time is optional and
+// defaults to one interval after open_time.
+var csvColumns = []string{"open_time", "open", "high", "low", "close", "volume"}
+
+// CSVCandleSource reads candles from CSV with a header row. Times are either Unix
+// milliseconds or RFC 3339.
+type CSVCandleSource struct {
+	reader   *csv.Reader
+	closer   io.Closer
+	symbol   string
+	interval model.KlineInterval
+	columns  map[string]int
+	line     int
+	err      error
+	done     bool
+}
+
+// NewCSVCandleSource creates a source reading CSV rows from r
+func NewCSVCandleSource(r io.Reader, symbol string, interval model.KlineInterval) (*CSVCandleSource, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range csvColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("CSV is missing required column %q", name)
+		}
+	}
+
+	return &CSVCandleSource{
+		reader:   reader,
+		symbol:   symbol,
+		interval: interval,
+		columns:  columns,
+		line:     1,
+	}, nil
+}
+
+// OpenCSVCandleSource opens a CSV file as a candle source. The file is closed once the
+// source is exhausted or fails.
+func OpenCSVCandleSource(path, symbol string, interval model.KlineInterval) (*CSVCandleSource, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open candle file: %w", err)
+	}
+
+	source, err := NewCSVCandleSource(file, symbol, interval)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	source.closer = file
+	return source, nil
+}
+
+// Next implements CandleSource
+func (s *CSVCandleSource) Next() (*model.Kline, bool) {
+	if s.done {
+		return nil, false
+	}
+
+	record, err := s.reader.Read()
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			s.err = fmt.Errorf("failed to read CSV: %w", err)
+		}
+		s.finish()
+		return nil, false
+	}
+	s.line++
+
+	candle, err := s.parseRecord(record)
+	if err != nil {
+		s.err = fmt.Errorf("line %d: %w", s.line, err)
+		s.finish()
+		return nil, false
+	}
+	return candle, true
+}
+
+// Err implements CandleSource
+func (s *CSVCandleSource) Err() error {
+	return s.err
+}
+
+// finish marks the source as exhausted and releases the underlying file
+func (s *CSVCandleSource) finish() {
+	s.done = true
+	if s.closer != nil {
+		s.closer.Close()
+		s.closer = nil
+	}
+}
+
+// parseRecord converts a CSV row into a kline
+func (s *CSVCandleSource) parseRecord(record []string) (*model.Kline, error) {
+	field := func(name string) string {
+		if i, ok := s.columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	openTime, err := parseCSVTime(field("open_time"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid open_time: %w", err)
+	}
+
+	values := make(map[string]float64, 5)
+	for _, name := range csvColumns[1:] {
+		value, err := strconv.ParseFloat(field(name), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		values[name] = value
+	}
+
+	closeTime := openTime
+	if duration := intervalDuration(s.interval); duration > 0 {
+		closeTime = openTime.Add(duration - time.Millisecond)
+	}
+	if raw := field("close_time"); raw != "" {
+		if closeTime, err = parseCSVTime(raw); err != nil {
+			return nil, fmt.Errorf("invalid close_time: %w", err)
+		}
+	}
+
+	return &model.Kline{
+		Symbol:    s.symbol,
+		Interval:  s.interval,
+		OpenTime:  openTime,
+		CloseTime: closeTime,
+		Open:      values["open"],
+		High:      values["high"],
+		Low:       values["low"],
+		Close:     values["close"],
+		Volume:    values["volume"],
+		IsClosed:  true,
+	}, nil
+}
+
+// parseCSVTime accepts Unix milliseconds or RFC 3339 timestamps
+func parseCSVTime(value string) (time.Time, error) {
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(ms).UTC(), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// intervalDuration returns the length of fixed-size kline intervals, or zero for
+// calendar-based intervals such as 1M
+func intervalDuration(interval model.KlineInterval) time.Duration {
+	switch interval {
+	case model.KlineInterval1w:
+		return 7 * 24 * time.Hour
+	case model.KlineInterval1M:
+		return 0
+	}
+	duration, err := time.ParseDuration(strings.Replace(string(interval), "d", "h", 1))
+	if err != nil {
+		return 0
+	}
+	if strings.HasSuffix(string(interval), "d") {
+		duration *= 24
+	}
+	return duration
+}
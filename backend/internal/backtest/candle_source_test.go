@@ -0,0 +1,126 @@
+package backtest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// candlesCSV holds the same series as makeCandles(100, 100, 105, 110, 110)
+const candlesCSV = `open_time,open,high,low,close,volume
+1704067200000,100,100,100,100,0
+1704070800000,100,100,100,100,0
+2024-01-01T02:00:00Z,105,105,105,105,0
+1704078000000,110,110,110,110,0
+1704081600000,110,110,110,110,0
+`
+
+func TestCSVAndSliceSourcesProduceIdenticalResults(t *testing.T) {
+	logger := zerolog.Nop()
+	service := NewService(&logger)
+	req := Request{Symbol: "BTCUSDT", InitialCapital: 1000, CommissionBps: 10, SlippageBps: 5}
+
+	fromSlice, err := service.Run(context.Background(), newScript(), NewSliceCandleSource(makeCandles(100, 100, 105, 110, 110)), req)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "candles.csv")
+	require.NoError(t, os.WriteFile(path, []byte(candlesCSV), 0o600))
+	csvSource, err := OpenCSVCandleSource(path, "BTCUSDT", model.KlineInterval1h)
+	require.NoError(t, err)
+
+	fromCSV, err := service.Run(context.Background(), newScript(), csvSource, req)
+	require.NoError(t, err)
+
+	assert.Equal(t, fromSlice.Trades, fromCSV.Trades)
+	assert.Equal(t, fromSlice.EquityCurve, fromCSV.EquityCurve)
+	assert.Equal(t, fromSlice.Summary, fromCSV.Summary)
+	assert.Equal(t, fromSlice.Metrics, fromCSV.Metrics)
+}
+
+func TestCSVCandleSourceErrors(t *testing.T) {
+	_, err := NewCSVCandleSource(strings.NewReader("open_time,open,close\n"), "BTCUSDT", model.KlineInterval1h)
+	assert.ErrorContains(t, err, "high")
+
+	source, err := NewCSVCandleSource(strings.NewReader(candlesCSV+"bad,1,1,1,1,1\n"), "BTCUSDT", model.KlineInterval1h)
+	require.NoError(t, err)
+
+	candles, err := Collect(source)
+	assert.Len(t, candles, 5)
+	assert.ErrorContains(t, err, "line 7")
+
+	logger := zerolog.Nop()
+	source, err = NewCSVCandleSource(strings.NewReader(candlesCSV+"bad,1,1,1,1,1\n"), "BTCUSDT", model.KlineInterval1h)
+	require.NoError(t, err)
+	_, err = NewService(&logger).Run(context.Background(), newScript(), source, Request{InitialCapital: 1000})
+	assert.Error(t, err)
+}
+
+// pagedMarketRepository serves candles from memory with the repository's BETWEEN/limit semantics
+type pagedMarketRepository struct {
+	port.MarketRepository
+	candles []*market.Candle
+	queries int
+	err     error
+}
+
+func (r *pagedMarketRepository) GetCandles(ctx context.Context, symbol, exchange string, interval market.Interval, start, end time.Time, limit int) ([]*market.Candle, error) {
+	r.queries++
+	if r.err != nil {
+		return nil, r.err
+	}
+	var page []*market.Candle
+	for _, candle := range r.candles {
+		if candle.OpenTime.Before(start) || candle.OpenTime.After(end) {
+			continue
+		}
+		page = append(page, candle)
+		if limit > 0 && len(page) == limit {
+			break
+		}
+	}
+	return page, nil
+}
+
+func TestRepositoryCandleSourcePages(t *testing.T) {
+	repo := &pagedMarketRepository{}
+	for _, kline := range makeCandles(1, 2, 3, 4, 5) {
+		repo.candles = append(repo.candles, &market.Candle{
+			Symbol:    kline.Symbol,
+			Interval:  market.Interval1h,
+			OpenTime:  kline.OpenTime,
+			CloseTime: kline.CloseTime,
+			Close:     kline.Close,
+			Complete:  true,
+		})
+	}
+
+	start := repo.candles[0].OpenTime
+	source := NewRepositoryCandleSource(context.Background(), repo, "BTCUSDT", "mexc", market.Interval1h, start, start.Add(24*time.Hour), 2)
+
+	candles, err := Collect(source)
+	require.NoError(t, err)
+	require.Len(t, candles, 5)
+	for i, candle := range candles {
+		assert.Equal(t, float64(i+1), candle.Close)
+		assert.Equal(t, model.KlineInterval1h, candle.Interval)
+		assert.True(t, candle.IsClosed)
+	}
+	// Pages of 2, 2 and a short final page of 1
+	assert.Equal(t, 3, repo.queries)
+
+	failing := &pagedMarketRepository{err: errors.New("db down")}
+	source = NewRepositoryCandleSource(context.Background(), failing, "BTCUSDT", "mexc", market.Interval1h, start, start.Add(time.Hour), 0)
+	_, err = Collect(source)
+	assert.ErrorContains(t, err, "db down")
+}
@@ -0,0 +1,132 @@
+package backtest
+
+import "math"
+
+// defaultPeriodsPerYear annualizes daily returns; crypto markets trade every day
+const defaultPeriodsPerYear = 365
+
+// Metrics are the risk-adjusted performance figures of a backtest
+type Metrics struct {
+	SharpeRatio  float64 `json:"sharpe_ratio"`  // Annualized mean excess return over its standard deviation
+	SortinoRatio float64 `json:"sortino_ratio"` // Annualized mean excess return over downside deviation
+	MaxDrawdown  float64 `json:"max_drawdown"`  // Largest peak-to-trough decline as a fraction of the peak
+	// MaxDrawdownDuration is the longest number of periods spent below a previous equity peak
+	MaxDrawdownDuration int     `json:"max_drawdown_duration"`
+	RoundTrips          int     `json:"round_trips"` // Number of closed buy/sell pairs
+	WinRate             float64 `json:"win_rate"`    // Share of round trips with positive PnL
+	GrossProfit         float64 `json:"gross_profit"`
+	GrossLoss           float64 `json:"gross_loss"` // Absolute sum of losing round trips
+	// ProfitFactor is GrossProfit / GrossLoss, zero when there are no losing round trips
+	ProfitFactor float64 `json:"profit_factor"`
+}
+
+// ComputeMetrics derives performance metrics from a per-period equity series and the PnL
+// of closed round trips. riskFreeRate is annual; periodsPerYear scales per-period
+// statistics to annual figures and defaults to 365 when zero.
+func ComputeMetrics(equity []float64, roundTripPnL []float64, riskFreeRate, periodsPerYear float64) Metrics {
+	if periodsPerYear <= 0 {
+		periodsPerYear = defaultPeriodsPerYear
+	}
+
+	var metrics Metrics
+	metrics.SharpeRatio, metrics.SortinoRatio = riskAdjustedReturns(periodReturns(equity), riskFreeRate/periodsPerYear, periodsPerYear)
+	metrics.MaxDrawdown, metrics.MaxDrawdownDuration = maxDrawdown(equity)
+
+	var wins int
+	for _, pnl := range roundTripPnL {
+		switch {
+		case pnl > 0:
+			wins++
+			metrics.GrossProfit += pnl
+		case pnl < 0:
+			metrics.GrossLoss -= pnl
+		}
+	}
+	metrics.RoundTrips = len(roundTripPnL)
+	if metrics.RoundTrips > 0 {
+		metrics.WinRate = float64(wins) / float64(metrics.RoundTrips)
+	}
+	if metrics.GrossLoss > 0 {
+		metrics.ProfitFactor = metrics.GrossProfit / metrics.GrossLoss
+	}
+
+	return metrics
+}
+
+// periodReturns converts an equity series into simple per-period returns
+func periodReturns(equity []float64) []float64 {
+	if len(equity) < 2 {
+		return nil
+	}
+	returns := make([]float64, 0, len(equity)-1)
+	for i := 1; i < len(equity); i++ {
+		if equity[i-1] == 0 {
+			returns = append(returns, 0)
+			continue
+		}
+		returns = append(returns, equity[i]/equity[i-1]-1)
+	}
+	return returns
+}
+
+// riskAdjustedReturns returns the annualized Sharpe and Sortino ratios of the returns in
+// excess of the per-period risk-free rate. Ratios with a zero denominator are reported as zero.
+func riskAdjustedReturns(returns []float64, periodRiskFree, periodsPerYear float64) (sharpe, sortino float64) {
+	n := float64(len(returns))
+	if n < 2 {
+		return 0, 0
+	}
+
+	var mean float64
+	for _, r := range returns {
+		mean += r - periodRiskFree
+	}
+	mean /= n
+
+	var variance, downside float64
+	for _, r := range returns {
+		excess := r - periodRiskFree
+		variance += (excess - mean) * (excess - mean)
+		if excess < 0 {
+			downside += excess * excess
+		}
+	}
+	stdDev := math.Sqrt(variance / (n - 1))
+	downsideDev := math.Sqrt(downside / n)
+	annualize := math.Sqrt(periodsPerYear)
+
+	if stdDev > 0 {
+		sharpe = mean / stdDev * annualize
+	}
+	if downsideDev > 0 {
+		sortino = mean / downsideDev * annualize
+	}
+	return sharpe, sortino
+}
+
+// maxDrawdown returns the largest fractional decline from a running peak and the longest
+// number of periods the equity stayed below a peak
+func maxDrawdown(equity []float64) (float64, int) {
+	var maxDD float64
+	var longest, current int
+	peak := math.Inf(-1)
+
+	for _, value := range equity {
+		if value >= peak {
+			peak = value
+			current = 0
+			continue
+		}
+		current++
+		if current > longest {
+			longest = current
+		}
+		if peak > 0 {
+			if dd := (peak - value) / peak; dd > maxDD {
+				maxDD = dd
+			}
+		}
+	}
+
+	return maxDD, longest
+}
@@ -0,0 +1,81 @@
+package backtest
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Returns of +10%, -10%, +10%, +10%: mean 0.05, sample std dev 0.1,
+// downside deviation sqrt(0.01/4) = 0.05
+var knownEquity = []float64{100, 110, 99, 108.9, 119.79}
+
+func TestComputeMetricsKnownCurve(t *testing.T) {
+	metrics := ComputeMetrics(knownEquity, nil, 0, 1)
+
+	assert.InDelta(t, 0.5, metrics.SharpeRatio, 1e-9)
+	assert.InDelta(t, 1.0, metrics.SortinoRatio, 1e-9)
+	assert.InDelta(t, 0.1, metrics.MaxDrawdown, 1e-9)
+	// Below the 110 peak at 99 and 108.9, recovered at 119.79
+	assert.Equal(t, 2, metrics.MaxDrawdownDuration)
+}
+
+func TestComputeMetricsAnnualizationAndRiskFreeRate(t *testing.T) {
+	// Four periods per year scale ratios by sqrt(4)
+	metrics := ComputeMetrics(knownEquity, nil, 0, 4)
+	assert.InDelta(t, 1.0, metrics.SharpeRatio, 1e-9)
+	assert.InDelta(t, 2.0, metrics.SortinoRatio, 1e-9)
+
+	// 4% annual is 1% per period: mean excess 0.04, std dev unchanged
+	metrics = ComputeMetrics(knownEquity, nil, 0.04, 4)
+	assert.InDelta(t, 0.8, metrics.SharpeRatio, 1e-9)
+	// Excess returns 0.09, -0.11, 0.09, 0.09: downside sqrt(0.0121/4) = 0.055
+	assert.InDelta(t, 0.04/0.055*2, metrics.SortinoRatio, 1e-9)
+}
+
+func TestComputeMetricsRoundTrips(t *testing.T) {
+	metrics := ComputeMetrics(knownEquity, []float64{10, -5, 20}, 0, 1)
+
+	assert.Equal(t, 3, metrics.RoundTrips)
+	assert.InDelta(t, 2.0/3.0, metrics.WinRate, 1e-9)
+	assert.InDelta(t, 30.0, metrics.GrossProfit, 1e-9)
+	assert.InDelta(t, 5.0, metrics.GrossLoss, 1e-9)
+	assert.InDelta(t, 6.0, metrics.ProfitFactor, 1e-9)
+
+	// No losing trades leaves the profit factor at zero rather than infinity
+	metrics = ComputeMetrics(knownEquity, []float64{10}, 0, 1)
+	assert.Zero(t, metrics.ProfitFactor)
+	assert.Equal(t, 1.0, metrics.WinRate)
+}
+
+func TestComputeMetricsDegenerateSeries(t *testing.T) {
+	metrics := ComputeMetrics([]float64{100}, nil, 0, 0)
+	assert.Zero(t, metrics.SharpeRatio)
+	assert.Zero(t, metrics.MaxDrawdown)
+
+	// Flat equity has no volatility
+	metrics = ComputeMetrics([]float64{100, 100, 100}, nil, 0, 0)
+	assert.Zero(t, metrics.SharpeRatio)
+	assert.Zero(t, metrics.SortinoRatio)
+	assert.False(t, math.IsNaN(metrics.SharpeRatio))
+}
+
+func TestRunPopulatesMetrics(t *testing.T) {
+	logger := zerolog.Nop()
+	service := NewService(&logger)
+	candles := makeCandles(100, 100, 105, 110, 110)
+
+	result, err := service.Run(context.Background(), newScript(), NewSliceCandleSource(candles), Request{InitialCapital: 1000})
+	require.NoError(t, err)
+
+	require.Len(t, result.Trades, 2)
+	assert.InDelta(t, 100.0, result.Trades[1].PnL, 1e-9)
+	assert.Equal(t, 1, result.Metrics.RoundTrips)
+	assert.Equal(t, 1.0, result.Metrics.WinRate)
+	assert.Zero(t, result.Metrics.MaxDrawdown)
+	assert.Greater(t, result.Metrics.SharpeRatio, 0.0)
+}
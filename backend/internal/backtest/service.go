@@ -0,0 +1,284 @@
+package backtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+)
+
+// bpsDivisor converts basis points into a fraction
+const bpsDivisor = 10000.0
+
+// ErrNoCandles is returned when a backtest is run without any candles
+var ErrNoCandles = errors.New("backtest requires at least one candle")
+
+// Request configures a single backtest run
+type Request struct {
+	Symbol         string  `json:"symbol"`
+	InitialCapital float64 `json:"initial_capital"`
+	// PositionSizePct is the share of available cash committed on each buy, in percent. Defaults to 100.
+	PositionSizePct float64 `json:"position_size_pct"`
+	// CommissionBps is charged on the notional of every simulated fill
+	CommissionBps float64 `json:"commission_bps"`
+	// SlippageBps moves every fill price against the trade by the given basis points
+	SlippageBps float64 `json:"slippage_bps"`
+	// SlippageTicks moves every fill price against the trade by a fixed number of ticks.
+	// When set it takes precedence over SlippageBps.
+	SlippageTicks int     `json:"slippage_ticks"`
+	TickSize      float64 `json:"tick_size"`
+	// RiskFreeRate is the annual risk-free rate used for Sharpe and Sortino ratios
+	RiskFreeRate float64 `json:"risk_free_rate"`
+	// PeriodsPerYear is the number of candles per year used to annualize ratios. Defaults to 365.
+	PeriodsPerYear float64 `json:"periods_per_year"`
+}
+
+// Trade is a simulated fill
+type Trade struct {
+	Time       time.Time       `json:"time"`
+	Side       model.OrderSide `json:"side"`
+	Quantity   float64         `json:"quantity"`
+	Price      float64         `json:"price"`      // Fill price including slippage
+	Commission float64         `json:"commission"` // Commission paid in the quote asset
+	Slippage   float64         `json:"slippage"`   // Cost of slippage in the quote asset
+	PnL        float64         `json:"pnl"`        // Realized PnL of the round trip, set on sells
+}
+
+// EquityPoint is the marked-to-market portfolio value at the close of a candle
+type EquityPoint struct {
+	Time   time.Time `json:"time"`
+	Equity float64   `json:"equity"`
+}
+
+// Summary aggregates the outcome of a backtest
+type Summary struct {
+	InitialCapital  float64 `json:"initial_capital"`
+	FinalEquity     float64 `json:"final_equity"`
+	TotalReturn     float64 `json:"total_return"` // Fractional return on initial capital
+	TradeCount      int     `json:"trade_count"`
+	TotalCommission float64 `json:"total_commission"`
+	TotalSlippage   float64 `json:"total_slippage"`
+}
+
+// Result is the output of a backtest run
+type Result struct {
+	Strategy    string        `json:"strategy"`
+	Symbol      string        `json:"symbol"`
+	Trades      []Trade       `json:"trades"`
+	EquityCurve []EquityPoint `json:"equity_curve"`
+	Summary     Summary       `json:"summary"`
+	Metrics     Metrics       `json:"metrics"`
+}
+
+// Service runs trading strategies against historical candles
+type Service struct {
+	logger *zerolog.Logger
+}
+
+// NewService creates a new backtest Service
+func NewService(logger *zerolog.Logger) *Service {
+	return &Service{logger: logger}
+}
+
+// Run replays candles from the source through the strategy and simulates long-only
+// market fills at the candle close, applying the request's commission and slippage to
+// every fill
+func (s *Service) Run(ctx context.Context, strategy port.Strategy, source CandleSource, req Request) (*Result, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	sim := newSimulator(req)
+	result := &Result{
+		Strategy:    strategy.Name(),
+		Symbol:      req.Symbol,
+		Trades:      []Trade{},
+		EquityCurve: []EquityPoint{},
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		candle, ok := source.Next()
+		if !ok {
+			break
+		}
+
+		switch strategy.OnCandle(candle) {
+		case model.SignalBuy:
+			if trade, ok := sim.buy(candle); ok {
+				result.Trades = append(result.Trades, trade)
+			}
+		case model.SignalSell:
+			if trade, ok := sim.sell(candle); ok {
+				result.Trades = append(result.Trades, trade)
+			}
+		}
+
+		result.EquityCurve = append(result.EquityCurve, EquityPoint{
+			Time:   candle.CloseTime,
+			Equity: sim.equity(candle.Close),
+		})
+	}
+
+	if err := source.Err(); err != nil {
+		return nil, err
+	}
+	if len(result.EquityCurve) == 0 {
+		return nil, ErrNoCandles
+	}
+
+	result.Summary = sim.summary(result.EquityCurve[len(result.EquityCurve)-1].Equity, len(result.Trades))
+
+	// Metrics start from the initial capital so the first candle's move counts as a period
+	equity := make([]float64, 0, len(result.EquityCurve)+1)
+	equity = append(equity, req.InitialCapital)
+	for _, point := range result.EquityCurve {
+		equity = append(equity, point.Equity)
+	}
+	result.Metrics = ComputeMetrics(equity, sim.roundTrips, req.RiskFreeRate, req.PeriodsPerYear)
+
+	s.logger.Debug().
+		Str("strategy", result.Strategy).
+		Str("symbol", req.Symbol).
+		Int("candles", len(result.EquityCurve)).
+		Int("trades", result.Summary.TradeCount).
+		Float64("return", result.Summary.TotalReturn).
+		Msg("Backtest completed")
+
+	return result, nil
+}
+
+// validateRequest checks that the request describes a runnable backtest
+func validateRequest(req Request) error {
+	if req.InitialCapital <= 0 {
+		return fmt.Errorf("initial capital must be positive, got %f", req.InitialCapital)
+	}
+	if req.PositionSizePct < 0 || req.PositionSizePct > 100 {
+		return fmt.Errorf("position size must be between 0 and 100 percent, got %f", req.PositionSizePct)
+	}
+	if req.CommissionBps < 0 || req.SlippageBps < 0 || req.SlippageTicks < 0 {
+		return errors.New("commission and slippage must not be negative")
+	}
+	if req.SlippageTicks > 0 && req.TickSize <= 0 {
+		return errors.New("tick size is required when slippage is given in ticks")
+	}
+	return nil
+}
+
+// simulator tracks cash and position for a single long-only backtest
+type simulator struct {
+	req             Request
+	cash            float64
+	quantity        float64
+	totalCommission float64
+	totalSlippage   float64
+	entryCost       float64   // Cash spent on the open position including commission
+	roundTrips      []float64 // Realized PnL of each closed position
+}
+
+func newSimulator(req Request) *simulator {
+	if req.PositionSizePct == 0 {
+		req.PositionSizePct = 100
+	}
+	return &simulator{req: req, cash: req.InitialCapital}
+}
+
+// slippagePerUnit returns the absolute price adjustment applied to a fill at price
+func (s *simulator) slippagePerUnit(price float64) float64 {
+	if s.req.SlippageTicks > 0 {
+		return float64(s.req.SlippageTicks) * s.req.TickSize
+	}
+	return price * s.req.SlippageBps / bpsDivisor
+}
+
+// buy opens a position with the configured share of cash, if flat
+func (s *simulator) buy(candle *model.Kline) (Trade, bool) {
+	if s.quantity > 0 || s.cash <= 0 {
+		return Trade{}, false
+	}
+
+	slip := s.slippagePerUnit(candle.Close)
+	price := candle.Close + slip
+	commissionRate := s.req.CommissionBps / bpsDivisor
+
+	// Size the order so that notional plus commission fits the budget
+	budget := s.cash * s.req.PositionSizePct / 100
+	quantity := budget / (price * (1 + commissionRate))
+	if quantity <= 0 {
+		return Trade{}, false
+	}
+	commission := quantity * price * commissionRate
+
+	s.cash -= quantity*price + commission
+	s.quantity = quantity
+	s.entryCost = quantity*price + commission
+	s.totalCommission += commission
+	s.totalSlippage += quantity * slip
+
+	return Trade{
+		Time:       candle.CloseTime,
+		Side:       model.OrderSideBuy,
+		Quantity:   quantity,
+		Price:      price,
+		Commission: commission,
+		Slippage:   quantity * slip,
+	}, true
+}
+
+// sell closes the open position, if any
+func (s *simulator) sell(candle *model.Kline) (Trade, bool) {
+	if s.quantity <= 0 {
+		return Trade{}, false
+	}
+
+	slip := s.slippagePerUnit(candle.Close)
+	price := candle.Close - slip
+	if price < 0 {
+		price = 0
+	}
+	quantity := s.quantity
+	commission := quantity * price * s.req.CommissionBps / bpsDivisor
+
+	proceeds := quantity*price - commission
+	pnl := proceeds - s.entryCost
+
+	s.cash += proceeds
+	s.quantity = 0
+	s.entryCost = 0
+	s.totalCommission += commission
+	s.totalSlippage += quantity * slip
+	s.roundTrips = append(s.roundTrips, pnl)
+
+	return Trade{
+		Time:       candle.CloseTime,
+		Side:       model.OrderSideSell,
+		Quantity:   quantity,
+		Price:      price,
+		Commission: commission,
+		Slippage:   quantity * slip,
+		PnL:        pnl,
+	}, true
+}
+
+// equity marks the portfolio to market at price
+func (s *simulator) equity(price float64) float64 {
+	return s.cash + s.quantity*price
+}
+
+// summary builds the run summary from the final equity
+func (s *simulator) summary(finalEquity float64, tradeCount int) Summary {
+	return Summary{
+		InitialCapital:  s.req.InitialCapital,
+		FinalEquity:     finalEquity,
+		TotalReturn:     (finalEquity - s.req.InitialCapital) / s.req.InitialCapital,
+		TradeCount:      tradeCount,
+		TotalCommission: s.totalCommission,
+		TotalSlippage:   s.totalSlippage,
+	}
+}
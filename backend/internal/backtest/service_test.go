@@ -0,0 +1,142 @@
+package backtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedStrategy emits a fixed signal at given candle indices
+type scriptedStrategy struct {
+	signals map[int]model.SignalType
+	index   int
+}
+
+func (s *scriptedStrategy) Name() string { return "scripted" }
+
+func (s *scriptedStrategy) OnCandle(candle *model.Kline) model.SignalType {
+	defer func() { s.index++ }()
+	if signal, ok := s.signals[s.index]; ok {
+		return signal
+	}
+	return model.SignalHold
+}
+
+// makeCandles builds hourly candles closing at the given prices
+func makeCandles(closes ...float64) []*model.Kline {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := make([]*model.Kline, len(closes))
+	for i, price := range closes {
+		openTime := start.Add(time.Duration(i) * time.Hour)
+		candles[i] = &model.Kline{
+			Symbol:    "BTCUSDT",
+			Interval:  model.KlineInterval1h,
+			OpenTime:  openTime,
+			CloseTime: openTime.Add(time.Hour - time.Millisecond),
+			Open:      price,
+			High:      price,
+			Low:       price,
+			Close:     price,
+		}
+	}
+	return candles
+}
+
+func newScript() *scriptedStrategy {
+	return &scriptedStrategy{signals: map[int]model.SignalType{
+		1: model.SignalBuy,
+		3: model.SignalSell,
+	}}
+}
+
+func TestRunWithoutCosts(t *testing.T) {
+	logger := zerolog.Nop()
+	service := NewService(&logger)
+	candles := makeCandles(100, 100, 105, 110, 110)
+
+	result, err := service.Run(context.Background(), newScript(), NewSliceCandleSource(candles), Request{Symbol: "BTCUSDT", InitialCapital: 1000})
+	require.NoError(t, err)
+
+	require.Len(t, result.Trades, 2)
+	assert.Equal(t, model.OrderSideBuy, result.Trades[0].Side)
+	assert.InDelta(t, 10.0, result.Trades[0].Quantity, 1e-9)
+	require.Len(t, result.EquityCurve, len(candles))
+	assert.InDelta(t, 1050.0, result.EquityCurve[2].Equity, 1e-9)
+	assert.InDelta(t, 1100.0, result.Summary.FinalEquity, 1e-9)
+	assert.InDelta(t, 0.1, result.Summary.TotalReturn, 1e-9)
+	assert.Zero(t, result.Summary.TotalCommission)
+	assert.Zero(t, result.Summary.TotalSlippage)
+}
+
+func TestRunCostsReduceReturns(t *testing.T) {
+	logger := zerolog.Nop()
+	service := NewService(&logger)
+	candles := makeCandles(100, 100, 105, 110, 110)
+
+	free, err := service.Run(context.Background(), newScript(), NewSliceCandleSource(candles), Request{InitialCapital: 1000})
+	require.NoError(t, err)
+
+	costly, err := service.Run(context.Background(), newScript(), NewSliceCandleSource(candles), Request{
+		InitialCapital: 1000,
+		CommissionBps:  10,
+		SlippageBps:    5,
+	})
+	require.NoError(t, err)
+
+	assert.Less(t, costly.Summary.FinalEquity, free.Summary.FinalEquity)
+	assert.Less(t, costly.Summary.TotalReturn, free.Summary.TotalReturn)
+	assert.Greater(t, costly.Summary.TotalCommission, 0.0)
+	assert.Greater(t, costly.Summary.TotalSlippage, 0.0)
+
+	// Buy fills above and sell fills below the close
+	assert.InDelta(t, 100.05, costly.Trades[0].Price, 1e-9)
+	assert.InDelta(t, 109.945, costly.Trades[1].Price, 1e-9)
+
+	// Equity curve reflects costs from the first fill onwards
+	for i := 1; i < len(candles); i++ {
+		assert.Less(t, costly.EquityCurve[i].Equity, free.EquityCurve[i].Equity)
+	}
+
+	// Cash accounting: final equity equals sell proceeds net of commission
+	sell := costly.Trades[1]
+	buy := costly.Trades[0]
+	expected := 1000 - (buy.Quantity*buy.Price + buy.Commission) + (sell.Quantity*sell.Price - sell.Commission)
+	assert.InDelta(t, expected, costly.Summary.FinalEquity, 1e-9)
+}
+
+func TestRunSlippageInTicks(t *testing.T) {
+	logger := zerolog.Nop()
+	service := NewService(&logger)
+	candles := makeCandles(100, 100, 105, 110, 110)
+
+	result, err := service.Run(context.Background(), newScript(), NewSliceCandleSource(candles), Request{
+		InitialCapital: 1000,
+		SlippageBps:    50, // ignored when ticks are set
+		SlippageTicks:  2,
+		TickSize:       0.01,
+	})
+	require.NoError(t, err)
+
+	assert.InDelta(t, 100.02, result.Trades[0].Price, 1e-9)
+	assert.InDelta(t, 109.98, result.Trades[1].Price, 1e-9)
+}
+
+func TestRunValidation(t *testing.T) {
+	logger := zerolog.Nop()
+	service := NewService(&logger)
+	ctx := context.Background()
+
+	_, err := service.Run(ctx, newScript(), NewSliceCandleSource(makeCandles(100)), Request{})
+	assert.Error(t, err)
+
+	_, err = service.Run(ctx, newScript(), NewSliceCandleSource(makeCandles(100)), Request{InitialCapital: 1000, SlippageTicks: 1})
+	assert.Error(t, err)
+
+	_, err = service.Run(ctx, newScript(), NewSliceCandleSource(nil), Request{InitialCapital: 1000})
+	assert.ErrorIs(t, err, ErrNoCandles)
+}
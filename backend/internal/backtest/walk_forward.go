@@ -0,0 +1,189 @@
+package backtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+)
+
+// StrategyParams are the tunable parameters of a strategy
+type StrategyParams map[string]interface{}
+
+// StrategyConstructor builds a fresh strategy instance from parameters
+type StrategyConstructor func(params StrategyParams) (port.Strategy, error)
+
+// WalkForwardRequest configures a walk-forward backtest. The candle series is split into
+// sequential folds of InSampleSize candles used for parameter selection followed by
+// OutOfSampleSize candles used for evaluation. Folds start StepSize candles apart.
+// Strategies implementing port.LookbackStrategy are warmed up on the candles before each
+// out-of-sample window; those candles are not scored.
+type WalkForwardRequest struct {
+	Request
+	InSampleSize    int `json:"in_sample_size"`
+	OutOfSampleSize int `json:"out_of_sample_size"`
+	// StepSize defaults to OutOfSampleSize, which makes out-of-sample windows contiguous
+	StepSize int `json:"step_size"`
+	// Candidates are the parameter sets evaluated on each in-sample window
+	Candidates []StrategyParams `json:"candidates"`
+}
+
+// Fold is the outcome of one in-sample/out-of-sample split
+type Fold struct {
+	Index            int            `json:"index"`
+	InSampleStart    time.Time      `json:"in_sample_start"`
+	InSampleEnd      time.Time      `json:"in_sample_end"`
+	OutOfSampleStart time.Time      `json:"out_of_sample_start"`
+	OutOfSampleEnd   time.Time      `json:"out_of_sample_end"`
+	WarmupCandles    int            `json:"warmup_candles"`
+	BestParams       StrategyParams `json:"best_params"`
+	InSampleReturn   float64        `json:"in_sample_return"`
+	OutOfSample      *Result        `json:"out_of_sample"`
+}
+
+// WalkForwardSummary aggregates the out-of-sample results of all folds
+type WalkForwardSummary struct {
+	FoldCount        int     `json:"fold_count"`
+	AverageReturn    float64 `json:"average_return"`    // Mean out-of-sample return per fold
+	CompoundedReturn float64 `json:"compounded_return"` // Out-of-sample returns compounded across folds
+	TradeCount       int     `json:"trade_count"`
+	TotalCommission  float64 `json:"total_commission"`
+	TotalSlippage    float64 `json:"total_slippage"`
+}
+
+// WalkForwardResult is the output of a walk-forward backtest
+type WalkForwardResult struct {
+	Symbol  string             `json:"symbol"`
+	Folds   []Fold             `json:"folds"`
+	Summary WalkForwardSummary `json:"summary"`
+}
+
+// RunWalkForward selects the best candidate parameters on each in-sample window and
+// evaluates them on the following out-of-sample window. The source is read fully before
+// the folds are built.
+func (s *Service) RunWalkForward(ctx context.Context, newStrategy StrategyConstructor, source CandleSource, req WalkForwardRequest) (*WalkForwardResult, error) {
+	if req.InSampleSize <= 0 || req.OutOfSampleSize <= 0 {
+		return nil, errors.New("in-sample and out-of-sample sizes must be positive")
+	}
+	if req.StepSize < 0 {
+		return nil, errors.New("step size must not be negative")
+	}
+	if len(req.Candidates) == 0 {
+		return nil, errors.New("at least one candidate parameter set is required")
+	}
+
+	candles, err := Collect(source)
+	if err != nil {
+		return nil, err
+	}
+
+	step := req.StepSize
+	if step == 0 {
+		step = req.OutOfSampleSize
+	}
+	window := req.InSampleSize + req.OutOfSampleSize
+	if len(candles) < window {
+		return nil, fmt.Errorf("walk-forward requires at least %d candles, got %d", window, len(candles))
+	}
+
+	result := &WalkForwardResult{
+		Symbol: req.Symbol,
+		Folds:  []Fold{},
+	}
+	compounded := 1.0
+
+	for start := 0; start+window <= len(candles); start += step {
+		outOfSampleStart := start + req.InSampleSize
+		inSample := candles[start:outOfSampleStart]
+		outOfSample := candles[outOfSampleStart : start+window]
+
+		best, bestReturn, err := s.selectParams(ctx, newStrategy, inSample, req)
+		if err != nil {
+			return nil, fmt.Errorf("fold %d: %w", len(result.Folds), err)
+		}
+
+		strategy, err := newStrategy(best)
+		if err != nil {
+			return nil, fmt.Errorf("fold %d: failed to create strategy: %w", len(result.Folds), err)
+		}
+		warmup := warmUp(strategy, candles[:outOfSampleStart])
+		evaluation, err := s.Run(ctx, strategy, NewSliceCandleSource(outOfSample), req.Request)
+		if err != nil {
+			return nil, fmt.Errorf("fold %d: %w", len(result.Folds), err)
+		}
+
+		result.Folds = append(result.Folds, Fold{
+			Index:            len(result.Folds),
+			InSampleStart:    inSample[0].OpenTime,
+			InSampleEnd:      inSample[len(inSample)-1].CloseTime,
+			OutOfSampleStart: outOfSample[0].OpenTime,
+			OutOfSampleEnd:   outOfSample[len(outOfSample)-1].CloseTime,
+			WarmupCandles:    warmup,
+			BestParams:       best,
+			InSampleReturn:   bestReturn,
+			OutOfSample:      evaluation,
+		})
+
+		summary := &result.Summary
+		summary.AverageReturn += evaluation.Summary.TotalReturn
+		summary.TradeCount += evaluation.Summary.TradeCount
+		summary.TotalCommission += evaluation.Summary.TotalCommission
+		summary.TotalSlippage += evaluation.Summary.TotalSlippage
+		compounded *= 1 + evaluation.Summary.TotalReturn
+	}
+
+	result.Summary.FoldCount = len(result.Folds)
+	result.Summary.AverageReturn /= float64(len(result.Folds))
+	result.Summary.CompoundedReturn = compounded - 1
+
+	s.logger.Debug().
+		Str("symbol", req.Symbol).
+		Int("folds", result.Summary.FoldCount).
+		Float64("compoundedReturn", result.Summary.CompoundedReturn).
+		Msg("Walk-forward backtest completed")
+
+	return result, nil
+}
+
+// warmUp feeds a LookbackStrategy the last Lookback() of the candles before its
+// evaluation window, discarding their signals, and returns how many it fed
+func warmUp(strategy port.Strategy, before []*model.Kline) int {
+	lookback, ok := strategy.(port.LookbackStrategy)
+	if !ok {
+		return 0
+	}
+
+	warmup := min(max(lookback.Lookback(), 0), len(before))
+	for _, candle := range before[len(before)-warmup:] {
+		strategy.OnCandle(candle)
+	}
+	return warmup
+}
+
+// selectParams runs every candidate on the in-sample candles and returns the one with
+// the highest total return. Ties keep the earliest candidate.
+func (s *Service) selectParams(ctx context.Context, newStrategy StrategyConstructor, candles []*model.Kline, req WalkForwardRequest) (StrategyParams, float64, error) {
+	var best StrategyParams
+	bestReturn := math.Inf(-1)
+
+	for _, params := range req.Candidates {
+		strategy, err := newStrategy(params)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to create strategy: %w", err)
+		}
+		result, err := s.Run(ctx, strategy, NewSliceCandleSource(candles), req.Request)
+		if err != nil {
+			return nil, 0, err
+		}
+		if result.Summary.TotalReturn > bestReturn {
+			best = params
+			bestReturn = result.Summary.TotalReturn
+		}
+	}
+
+	return best, bestReturn, nil
+}
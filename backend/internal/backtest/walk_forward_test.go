@@ -0,0 +1,155 @@
+package backtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingStrategy buys on its first candle when "buy" is set and records every candle it sees
+type recordingStrategy struct {
+	buy  bool
+	seen []*model.Kline
+}
+
+func (s *recordingStrategy) Name() string { return "recording" }
+
+func (s *recordingStrategy) OnCandle(candle *model.Kline) model.SignalType {
+	s.seen = append(s.seen, candle)
+	if s.buy && len(s.seen) == 1 {
+		return model.SignalBuy
+	}
+	return model.SignalHold
+}
+
+// lookbackStrategy is a recordingStrategy that asks for a warmup before it is evaluated
+type lookbackStrategy struct {
+	recordingStrategy
+	lookback int
+}
+
+func (s *lookbackStrategy) Lookback() int { return s.lookback }
+
+func TestRunWalkForwardFolds(t *testing.T) {
+	logger := zerolog.Nop()
+	service := NewService(&logger)
+
+	closes := make([]float64, 100)
+	for i := range closes {
+		closes[i] = 100 + float64(i)
+	}
+	candles := makeCandles(closes...)
+
+	var created []*recordingStrategy
+	newStrategy := func(params StrategyParams) (port.Strategy, error) {
+		strategy := &recordingStrategy{buy: params["buy"].(bool)}
+		created = append(created, strategy)
+		return strategy, nil
+	}
+
+	result, err := service.RunWalkForward(context.Background(), newStrategy, NewSliceCandleSource(candles), WalkForwardRequest{
+		Request:         Request{Symbol: "BTCUSDT", InitialCapital: 1000},
+		InSampleSize:    40,
+		OutOfSampleSize: 20,
+		StepSize:        20,
+		Candidates:      []StrategyParams{{"buy": false}, {"buy": true}},
+	})
+	require.NoError(t, err)
+
+	// Folds start at 0, 20 and 40; a fourth at 60 would overrun the series
+	require.Len(t, result.Folds, 3)
+	assert.Equal(t, 3, result.Summary.FoldCount)
+
+	// Two in-sample candidates and one out-of-sample evaluation per fold
+	require.Len(t, created, 9)
+	for i, fold := range result.Folds {
+		oosStart := i*20 + 40
+		evaluation := created[i*3+2]
+
+		require.Len(t, evaluation.seen, 20)
+		assert.Same(t, candles[oosStart], evaluation.seen[0])
+		assert.Same(t, candles[oosStart+19], evaluation.seen[19])
+		assert.Equal(t, candles[oosStart].OpenTime, fold.OutOfSampleStart)
+		assert.Equal(t, candles[i*20].OpenTime, fold.InSampleStart)
+
+		// Buying beats holding cash on a rising series
+		assert.Equal(t, true, fold.BestParams["buy"])
+		assert.Greater(t, fold.OutOfSample.Summary.TotalReturn, 0.0)
+	}
+	assert.Greater(t, result.Summary.CompoundedReturn, result.Summary.AverageReturn)
+}
+
+func TestRunWalkForwardDefaultsStepToOutOfSampleSize(t *testing.T) {
+	logger := zerolog.Nop()
+	service := NewService(&logger)
+	candles := makeCandles(make([]float64, 50)...)
+	for _, candle := range candles {
+		candle.Close = 100
+	}
+
+	newStrategy := func(params StrategyParams) (port.Strategy, error) {
+		return &recordingStrategy{}, nil
+	}
+
+	result, err := service.RunWalkForward(context.Background(), newStrategy, NewSliceCandleSource(candles), WalkForwardRequest{
+		Request:         Request{InitialCapital: 1000},
+		InSampleSize:    20,
+		OutOfSampleSize: 10,
+		Candidates:      []StrategyParams{{}},
+	})
+	require.NoError(t, err)
+	assert.Len(t, result.Folds, 3)
+
+	_, err = service.RunWalkForward(context.Background(), newStrategy, NewSliceCandleSource(candles[:25]), WalkForwardRequest{
+		Request:         Request{InitialCapital: 1000},
+		InSampleSize:    20,
+		OutOfSampleSize: 10,
+		Candidates:      []StrategyParams{{}},
+	})
+	assert.Error(t, err)
+}
+
+func TestRunWalkForwardWarmsUpOutOfSample(t *testing.T) {
+	logger := zerolog.Nop()
+	service := NewService(&logger)
+
+	closes := make([]float64, 60)
+	for i := range closes {
+		closes[i] = 100 + float64(i)
+	}
+	candles := makeCandles(closes...)
+
+	var created []*lookbackStrategy
+	newStrategy := func(params StrategyParams) (port.Strategy, error) {
+		strategy := &lookbackStrategy{recordingStrategy: recordingStrategy{buy: true}, lookback: 5}
+		created = append(created, strategy)
+		return strategy, nil
+	}
+
+	result, err := service.RunWalkForward(context.Background(), newStrategy, NewSliceCandleSource(candles), WalkForwardRequest{
+		Request:         Request{Symbol: "BTCUSDT", InitialCapital: 1000},
+		InSampleSize:    40,
+		OutOfSampleSize: 20,
+		Candidates:      []StrategyParams{{}},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Folds, 1)
+	require.Len(t, created, 2)
+
+	// The five candles before the window are seen first, then the window itself
+	evaluation := created[1]
+	require.Len(t, evaluation.seen, 25)
+	assert.Same(t, candles[35], evaluation.seen[0])
+	assert.Same(t, candles[40], evaluation.seen[5])
+	assert.Equal(t, 5, result.Folds[0].WarmupCandles)
+
+	// The buy on the first warmup candle is discarded and the warmup is not scored
+	outOfSample := result.Folds[0].OutOfSample
+	assert.Zero(t, outOfSample.Summary.TradeCount)
+	assert.Len(t, outOfSample.EquityCurve, 20)
+}
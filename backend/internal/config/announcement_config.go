@@ -0,0 +1,29 @@
+package config
+
+import "time"
+
+// AnnouncementParserConfig controls how MEXC listing announcements are turned into alerts
+type AnnouncementParserConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// PreListingLead is how long before the announced listing time the pre-listing alert fires
+	PreListingLead time.Duration `mapstructure:"pre_listing_lead"`
+	// PollInterval is the base delay between polls of the announcement pages
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	// Jitter adds a random delay of up to this duration to each poll interval
+	Jitter time.Duration `mapstructure:"jitter"`
+	// URLs are the announcement list pages that are polled
+	URLs []string `mapstructure:"urls"`
+}
+
+// GetDefaultAnnouncementParserConfig returns the default announcement parser configuration
+func GetDefaultAnnouncementParserConfig() AnnouncementParserConfig {
+	return AnnouncementParserConfig{
+		Enabled:        false,
+		PreListingLead: 5 * time.Minute,
+		PollInterval:   time.Minute,
+		Jitter:         10 * time.Second,
+		URLs: []string{
+			"https://www.mexc.com/api/operation/announcements?category=new-listings",
+		},
+	}
+}
@@ -0,0 +1,39 @@
+package config
+
+import "time"
+
+// AutobuyConfig controls the autobuyer of newly listed coins. Amounts are in the quote
+// currency of the listings.
+type AutobuyConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// DryRun records the buys the autobuyer would place without placing them
+	DryRun bool `mapstructure:"dry_run"`
+	// MinPrice and MaxPrice bound the listing prices that are bought
+	MinPrice float64 `mapstructure:"min_price"`
+	MaxPrice float64 `mapstructure:"max_price"`
+	// MinVolume is the smallest 24h volume a listing needs to be bought
+	MinVolume float64 `mapstructure:"min_volume"`
+	// Delay is how long to wait after a listing starts trading before buying it
+	Delay time.Duration `mapstructure:"delay"`
+	// MaxTotalBudget is the total amount that may be spent across all buys
+	MaxTotalBudget float64 `mapstructure:"max_total_budget"`
+	// MaxPerCoin is the most spent on a single listing; larger buys are scaled down
+	MaxPerCoin float64 `mapstructure:"max_per_coin"`
+	// MaxConcurrentPositions is how many autobought positions may be open at once
+	MaxConcurrentPositions int `mapstructure:"max_concurrent_positions"`
+}
+
+// GetDefaultAutobuyConfig returns the default autobuy configuration
+func GetDefaultAutobuyConfig() AutobuyConfig {
+	return AutobuyConfig{
+		Enabled:                false,
+		DryRun:                 true,
+		MinPrice:               0,
+		MaxPrice:               1000,
+		MinVolume:              0,
+		Delay:                  0,
+		MaxTotalBudget:         100,
+		MaxPerCoin:             20,
+		MaxConcurrentPositions: 5,
+	}
+}
@@ -13,16 +13,32 @@ import (
 
 // Config holds all configuration settings
 type Config struct {
-	LogLevel      string              `mapstructure:"log_level"`
-	ENV           string              `mapstructure:"env"`
-	Version       string              `mapstructure:"version"`
-	Notifications Notifications       `mapstructure:"notifications"`
-	Auth          Auth                `mapstructure:"auth"`
-	RateLimit     RateLimitConfig     `mapstructure:"rate_limit"`
-	CSRF          CSRFConfig          `mapstructure:"csrf"`
-	SecureHeaders SecureHeadersConfig `mapstructure:"secure_headers"`
+	LogLevel string `mapstructure:"log_level"`
+	// LogLevelOverrideFile persists a log level set at runtime across restarts
+	LogLevelOverrideFile string              `mapstructure:"log_level_override_file"`
+	ENV                  string              `mapstructure:"env"`
+	Version              string              `mapstructure:"version"`
+	Notifications        Notifications       `mapstructure:"notifications"`
+	Auth                 Auth                `mapstructure:"auth"`
+	RateLimit            RateLimitConfig     `mapstructure:"rate_limit"`
+	CSRF                 CSRFConfig          `mapstructure:"csrf"`
+	SecureHeaders        SecureHeadersConfig `mapstructure:"secure_headers"`
+	CORS                 CORSConfig          `mapstructure:"cors"`
+	// RequestLimits bounds request bodies and handler time per route group
+	RequestLimits RequestLimitsConfig `mapstructure:"request_limits"`
+	// ResponseCache sets the HTTP cache TTLs of read-heavy routes
+	ResponseCache ResponseCacheConfig `mapstructure:"response_cache"`
 	InfuraAPIKey  string              `mapstructure:"infura_api_key"`
-	Server        struct {
+	Trading       TradingConfig       `mapstructure:"trading"`
+	Web3          Web3Config          `mapstructure:"web3"`
+	Wallet        WalletConfig        `mapstructure:"wallet"`
+	// AnnouncementParser configures pre-listing alerts from MEXC announcements
+	AnnouncementParser AnnouncementParserConfig `mapstructure:"announcement_parser"`
+	// OutboundWebhooks configures delivery of bot events to user-registered endpoints
+	OutboundWebhooks OutboundWebhooksConfig `mapstructure:"outbound_webhooks"`
+	// Autobuy configures the autobuyer of newly listed coins and its spending caps
+	Autobuy AutobuyConfig `mapstructure:"autobuy"`
+	Server  struct {
 		Port               int           `mapstructure:"port"`
 		Host               string        `mapstructure:"host"`
 		ReadTimeout        time.Duration `mapstructure:"read_timeout"`
@@ -37,7 +53,16 @@ type Config struct {
 			TickerTTL    int `mapstructure:"ticker_ttl"`
 			CandleTTL    int `mapstructure:"candle_ttl"`
 			OrderbookTTL int `mapstructure:"orderbook_ttl"`
+			// SymbolTTL is how long symbol lookups are cached, zero disables the cache
+			SymbolTTL int `mapstructure:"symbol_ttl"`
 		} `mapstructure:"cache"`
+		// Stream configures the live ticker stream served to clients
+		Stream struct {
+			// TickerPollInterval is how often the streamed symbols are polled
+			TickerPollInterval time.Duration `mapstructure:"ticker_poll_interval"`
+			// TickerMaxRate caps the ticker events sent to one client per second
+			TickerMaxRate float64 `mapstructure:"ticker_max_rate"`
+		} `mapstructure:"stream"`
 	} `mapstructure:"market"`
 	MEXC struct {
 		APIKey     string `mapstructure:"api_key"`
@@ -45,10 +70,21 @@ type Config struct {
 		BaseURL    string `mapstructure:"base_url"`
 		WSBaseURL  string `mapstructure:"ws_base_url"`
 		UseTestnet bool   `mapstructure:"use_testnet"`
-		RateLimit  struct {
+		// TestnetBaseURL and TestnetWSBaseURL replace the production endpoints when
+		// UseTestnet is set
+		TestnetBaseURL   string `mapstructure:"testnet_base_url"`
+		TestnetWSBaseURL string `mapstructure:"testnet_ws_base_url"`
+		RateLimit        struct {
 			RequestsPerMinute int `mapstructure:"requests_per_minute"`
 			BurstSize         int `mapstructure:"burst_size"`
 		} `mapstructure:"rate_limit"`
+		// ExchangeInfoTTL is how long exchange info responses are cached
+		ExchangeInfoTTL time.Duration `mapstructure:"exchange_info_ttl"`
+		// CircuitBreaker makes MEXC calls fail fast after repeated outages
+		CircuitBreaker struct {
+			FailureThreshold int           `mapstructure:"failure_threshold"`
+			Cooldown         time.Duration `mapstructure:"cooldown"`
+		} `mapstructure:"circuit_breaker"`
 	} `mapstructure:"mexc"`
 	AI struct {
 		Provider     string  `mapstructure:"provider"`
@@ -62,6 +98,8 @@ type Config struct {
 		TopK         int32   `mapstructure:"top_k"`
 		MaxTokens    int32   `mapstructure:"max_tokens"`
 	} `mapstructure:"ai"`
+	// Scheduler configures the periodic jobs
+	Scheduler SchedulerConfig `mapstructure:"scheduler"`
 }
 
 // Auth holds authentication configuration
@@ -74,71 +112,34 @@ type Auth struct {
 	ClerkJWTTemplate  string        `mapstructure:"clerk_jwt_template"`
 	JWTSecret         string        `mapstructure:"jwt_secret"`
 	TokenDuration     time.Duration `mapstructure:"token_duration"`
+	// ClerkWebhookSecret is the whsec_ signing secret of the Clerk webhook endpoint; the
+	// endpoint is served only when it is set
+	ClerkWebhookSecret string `mapstructure:"clerk_webhook_secret"`
 }
 
-// Load loads configuration from file and environment variables
+// Load loads configuration from defaults, the config file and environment variables.
+// See Resolve for the precedence rules.
 func Load() (*Config, error) {
 	// First load .env file if it exists
 	_ = godotenv.Load() // ignore error if .env file doesn't exist
 
-	// Create a new viper instance
-	v := viper.New()
-
-	// Set default values
-	setDefaults(v)
-
-	// Load config from file
-	configFile := getConfigFilePath()
-	if configFile != "" {
-		v.SetConfigFile(configFile)
-		if err := v.ReadInConfig(); err != nil {
-			if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-				return nil, fmt.Errorf("error reading config file: %w", err)
-			}
-			// Config file not found, will use defaults and environment variables
-		}
-	}
-
-	// Override with environment variables
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-	v.AutomaticEnv()
-
-	// Unmarshal config
-	var config Config
-	if err := v.Unmarshal(&config); err != nil {
-		return nil, fmt.Errorf("unable to decode config: %w", err)
-	}
-
-	// Explicitly set MEXC API credentials from environment variables
-	// This is needed because the YAML variable substitution may not work properly
-	if apiKey := os.Getenv("MEXC_API_KEY"); apiKey != "" {
-		config.MEXC.APIKey = apiKey
-	}
-
-	if apiSecret := os.Getenv("MEXC_SECRET_KEY"); apiSecret != "" {
-		config.MEXC.APISecret = apiSecret
-	}
-
-	if baseURL := os.Getenv("MEXC_BASE_URL"); baseURL != "" {
-		config.MEXC.BaseURL = baseURL
-	}
-
-	if wsBaseURL := os.Getenv("MEXC_WEBSOCKET_URL"); wsBaseURL != "" {
-		config.MEXC.WSBaseURL = wsBaseURL
-	}
-
-	// Validate config
-	if err := validateConfig(&config); err != nil {
+	resolution, err := Resolve(ResolveOptions{})
+	if err != nil {
 		return nil, err
 	}
-
-	return &config, nil
+	return resolution.Config, nil
 }
 
 // Notifications holds notification configuration
 type Notifications struct {
 	Email   EmailNotification   `mapstructure:"email"`
 	Webhook WebhookNotification `mapstructure:"webhook"`
+	Trades  TradeNotification   `mapstructure:"trades"`
+}
+
+// TradeNotification holds the configuration of notifications about the user's orders
+type TradeNotification struct {
+	Enabled bool `mapstructure:"enabled"`
 }
 
 // EmailNotification holds email notification configuration
@@ -165,6 +166,20 @@ type WebhookNotification struct {
 	BatchSize int               `mapstructure:"batch_size"`
 }
 
+// OutboundWebhooksConfig holds the delivery settings of user-registered webhook endpoints
+type OutboundWebhooksConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// MaxAttempts is how often a delivery is tried before it is marked failed
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// InitialBackoff is the wait before the first retry; it doubles up to MaxBackoff
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
+	// Timeout bounds each delivery attempt
+	Timeout   time.Duration `mapstructure:"timeout"`
+	QueueSize int           `mapstructure:"queue_size"`
+	Workers   int           `mapstructure:"workers"`
+}
+
 // DatabaseConfig holds database configuration
 type DatabaseConfig struct {
 	Driver   string `mapstructure:"driver"`
@@ -175,7 +190,9 @@ type DatabaseConfig struct {
 	Password string `mapstructure:"password"`
 	Name     string `mapstructure:"name"`
 	SSLMode  string `mapstructure:"ssl_mode"`
-	Turso    struct {
+	// SlowQueryThreshold is how long a query may take before it is logged as slow
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"`
+	Turso              struct {
 		Enabled   bool   `mapstructure:"enabled"`
 		URL       string `mapstructure:"url"`
 		AuthToken string `mapstructure:"auth_token"`
@@ -191,11 +208,11 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.write_timeout", 30*time.Second)
 	v.SetDefault("server.idle_timeout", 60*time.Second)
 	v.SetDefault("server.frontend_url", "http://localhost:3000")
-	v.SetDefault("server.cors_allowed_origins", []string{"http://localhost:3000"})
 
 	// Environment defaults
 	v.SetDefault("env", "development")
 	v.SetDefault("log_level", "info")
+	v.SetDefault("log_level_override_file", "data/log_level_override.json")
 	v.SetDefault("version", "1.0.0")
 
 	// Auth defaults
@@ -216,6 +233,16 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("notifications.webhook.timeout", 10*time.Second)
 	v.SetDefault("notifications.webhook.batch_size", 1)
 
+	v.SetDefault("notifications.trades.enabled", true)
+
+	v.SetDefault("outbound_webhooks.enabled", false)
+	v.SetDefault("outbound_webhooks.max_attempts", 5)
+	v.SetDefault("outbound_webhooks.initial_backoff", time.Second)
+	v.SetDefault("outbound_webhooks.max_backoff", time.Minute)
+	v.SetDefault("outbound_webhooks.timeout", 10*time.Second)
+	v.SetDefault("outbound_webhooks.queue_size", 256)
+	v.SetDefault("outbound_webhooks.workers", 4)
+
 	// Database defaults
 	v.SetDefault("database.driver", "sqlite")
 	v.SetDefault("database.path", "./data/crypto_bot.db")
@@ -223,19 +250,47 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("database.port", 5432)
 	v.SetDefault("database.name", "crypto_bot")
 	v.SetDefault("database.ssl_mode", "disable")
+	v.SetDefault("database.slow_query_threshold", 200*time.Millisecond)
 	v.SetDefault("database.turso.enabled", false)
 
 	// Market defaults
 	v.SetDefault("market.cache.ticker_ttl", 300)   // 5 minutes in seconds
 	v.SetDefault("market.cache.candle_ttl", 900)   // 15 minutes in seconds
 	v.SetDefault("market.cache.orderbook_ttl", 30) // 30 seconds
+	v.SetDefault("market.cache.symbol_ttl", 600)   // 10 minutes
+	v.SetDefault("market.stream.ticker_poll_interval", 2*time.Second)
+	v.SetDefault("market.stream.ticker_max_rate", 2.0)
 
 	// MEXC defaults
-	v.SetDefault("mexc.base_url", "https://api.mexc.com")
-	v.SetDefault("mexc.ws_base_url", "wss://wbs.mexc.com/ws")
+	v.SetDefault("mexc.base_url", DefaultMEXCBaseURL)
+	v.SetDefault("mexc.ws_base_url", DefaultMEXCWSBaseURL)
 	v.SetDefault("mexc.use_testnet", false)
 	v.SetDefault("mexc.rate_limit.requests_per_minute", 1200)
 	v.SetDefault("mexc.rate_limit.burst_size", 10)
+	v.SetDefault("mexc.exchange_info_ttl", time.Hour)
+	v.SetDefault("mexc.circuit_breaker.failure_threshold", 5)
+	v.SetDefault("mexc.circuit_breaker.cooldown", 30*time.Second)
+
+	// Trading defaults
+	defaultTrading := GetDefaultTradingConfig()
+	v.SetDefault("trading.reconciliation.enabled", defaultTrading.Reconciliation.Enabled)
+	v.SetDefault("trading.reconciliation.interval", defaultTrading.Reconciliation.Interval)
+	v.SetDefault("trading.reconciliation.batch_size", defaultTrading.Reconciliation.BatchSize)
+	v.SetDefault("trading.guard.whitelist", defaultTrading.Guard.Whitelist)
+	v.SetDefault("trading.guard.blacklist", defaultTrading.Guard.Blacklist)
+	v.SetDefault("trading.guard.min_quote_volume_24h", defaultTrading.Guard.MinQuoteVolume24h)
+	v.SetDefault("trading.risk_limits.max_position_per_symbol", defaultTrading.RiskLimits.MaxPositionPerSymbol)
+	v.SetDefault("trading.risk_limits.max_total_exposure", defaultTrading.RiskLimits.MaxTotalExposure)
+	v.SetDefault("trading.risk_limits.max_daily_loss", defaultTrading.RiskLimits.MaxDailyLoss)
+	v.SetDefault("trading.trailing_stop.enabled", defaultTrading.TrailingStop.Enabled)
+	v.SetDefault("trading.trailing_stop.percent", defaultTrading.TrailingStop.Percent)
+	v.SetDefault("trading.trailing_stop.ticks", defaultTrading.TrailingStop.Ticks)
+	v.SetDefault("trading.trailing_stop.interval", defaultTrading.TrailingStop.Interval)
+	v.SetDefault("trading.order_retry.max_attempts", defaultTrading.OrderRetry.MaxAttempts)
+	v.SetDefault("trading.order_retry.initial_backoff", defaultTrading.OrderRetry.InitialBackoff)
+	v.SetDefault("trading.order_retry.max_backoff", defaultTrading.OrderRetry.MaxBackoff)
+	v.SetDefault("trading.order_retry.poll_interval", defaultTrading.OrderRetry.PollInterval)
+	v.SetDefault("trading.order_retry.batch_size", defaultTrading.OrderRetry.BatchSize)
 
 	// Rate limiting defaults
 	defaultRateLimit := GetDefaultRateLimitConfig()
@@ -292,6 +347,36 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("secure_headers.content_security_policy_report_only", defaultSecureHeaders.ContentSecurityPolicyReportOnly)
 	v.SetDefault("secure_headers.content_security_policy_report_uri", defaultSecureHeaders.ContentSecurityPolicyReportURI)
 
+	// CORS defaults; the allowed origins default to the frontend URL
+	defaultCORS := GetDefaultCORSConfig()
+	v.SetDefault("cors.allowed_methods", defaultCORS.AllowedMethods)
+	v.SetDefault("cors.allowed_headers", defaultCORS.AllowedHeaders)
+	v.SetDefault("cors.exposed_headers", defaultCORS.ExposedHeaders)
+	v.SetDefault("cors.allow_credentials", defaultCORS.AllowCredentials)
+	v.SetDefault("cors.max_age", defaultCORS.MaxAge)
+
+	// Request limit defaults
+	defaultRequestLimits := GetDefaultRequestLimitsConfig()
+	for group, limits := range map[string]RouteLimits{
+		"default": defaultRequestLimits.Default,
+		"orders":  defaultRequestLimits.Orders,
+	} {
+		v.SetDefault("request_limits."+group+".max_body_bytes", limits.MaxBodyBytes)
+		v.SetDefault("request_limits."+group+".read_timeout", limits.ReadTimeout)
+		v.SetDefault("request_limits."+group+".handler_timeout", limits.HandlerTimeout)
+	}
+
+	// Response cache defaults
+	defaultResponseCache := GetDefaultResponseCacheConfig()
+	v.SetDefault("response_cache.exchange_info", defaultResponseCache.ExchangeInfo)
+	v.SetDefault("response_cache.symbols", defaultResponseCache.Symbols)
+	v.SetDefault("response_cache.candles", defaultResponseCache.Candles)
+
+	// Scheduler defaults
+	defaultScheduler := GetDefaultSchedulerConfig()
+	v.SetDefault("scheduler.lock_enabled", defaultScheduler.LockEnabled)
+	v.SetDefault("scheduler.lock_ttl", defaultScheduler.LockTTL)
+
 	// AI defaults
 	v.SetDefault("ai.provider", "gemini")
 	v.SetDefault("ai.model", "gemini-pro")
@@ -302,25 +387,51 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("ai.top_k", 40)
 	v.SetDefault("ai.max_tokens", 1024)
 
+	// Wallet defaults
+	defaultWallet := GetDefaultWalletConfig()
+	v.SetDefault("wallet.sync.balance_change_threshold", defaultWallet.Sync.BalanceChangeThreshold)
+	v.SetDefault("wallet.sync.enabled", defaultWallet.Sync.Enabled)
+	v.SetDefault("wallet.sync.interval", defaultWallet.Sync.Interval)
+	v.SetDefault("wallet.sync.check_interval", defaultWallet.Sync.CheckInterval)
+	v.SetDefault("wallet.sync.jitter", defaultWallet.Sync.Jitter)
+	v.SetDefault("wallet.sync.max_backoff", defaultWallet.Sync.MaxBackoff)
+	v.SetDefault("wallet.valuation.quote_currency", defaultWallet.Valuation.QuoteCurrency)
+	v.SetDefault("wallet.valuation.exchange", defaultWallet.Valuation.Exchange)
+	v.SetDefault("wallet.valuation.bridge_assets", defaultWallet.Valuation.BridgeAssets)
+
+	// Announcement parser defaults
+	defaultAnnouncementParser := GetDefaultAnnouncementParserConfig()
+	v.SetDefault("announcement_parser.enabled", defaultAnnouncementParser.Enabled)
+	v.SetDefault("announcement_parser.pre_listing_lead", defaultAnnouncementParser.PreListingLead)
+	v.SetDefault("announcement_parser.poll_interval", defaultAnnouncementParser.PollInterval)
+	v.SetDefault("announcement_parser.jitter", defaultAnnouncementParser.Jitter)
+	v.SetDefault("announcement_parser.urls", defaultAnnouncementParser.URLs)
+
+	// Autobuy defaults
+	defaultAutobuy := GetDefaultAutobuyConfig()
+	v.SetDefault("autobuy.enabled", defaultAutobuy.Enabled)
+	v.SetDefault("autobuy.dry_run", defaultAutobuy.DryRun)
+	v.SetDefault("autobuy.min_price", defaultAutobuy.MinPrice)
+	v.SetDefault("autobuy.max_price", defaultAutobuy.MaxPrice)
+	v.SetDefault("autobuy.min_volume", defaultAutobuy.MinVolume)
+	v.SetDefault("autobuy.delay", defaultAutobuy.Delay)
+	v.SetDefault("autobuy.max_total_budget", defaultAutobuy.MaxTotalBudget)
+	v.SetDefault("autobuy.max_per_coin", defaultAutobuy.MaxPerCoin)
+	v.SetDefault("autobuy.max_concurrent_positions", defaultAutobuy.MaxConcurrentPositions)
+
 	// Web3 defaults
 	v.SetDefault("infura_api_key", "")
-}
-
-// validateConfig validates the configuration
-func validateConfig(cfg *Config) error {
-	// Validate server port
-	if cfg.Server.Port < 1 || cfg.Server.Port > 65535 {
-		return fmt.Errorf("invalid server port: %d", cfg.Server.Port)
-	}
-
-	// Validate required API keys in production
-	if cfg.ENV == "production" {
-		if cfg.MEXC.APIKey == "" || cfg.MEXC.APISecret == "" {
-			return fmt.Errorf("MEXC API credentials are required in production")
-		}
-	}
-
-	return nil
+	defaultWeb3 := GetDefaultWeb3Config()
+	v.SetDefault("web3.bsc.enabled", defaultWeb3.BSC.Enabled)
+	v.SetDefault("web3.bsc.chain_id", defaultWeb3.BSC.ChainID)
+	v.SetDefault("web3.bsc.network", defaultWeb3.BSC.Network)
+	v.SetDefault("web3.bsc.rpc_url", defaultWeb3.BSC.RPCURL)
+	v.SetDefault("web3.bsc.explorer", defaultWeb3.BSC.Explorer)
+	v.SetDefault("web3.polygon.enabled", defaultWeb3.Polygon.Enabled)
+	v.SetDefault("web3.polygon.chain_id", defaultWeb3.Polygon.ChainID)
+	v.SetDefault("web3.polygon.network", defaultWeb3.Polygon.Network)
+	v.SetDefault("web3.polygon.rpc_url", defaultWeb3.Polygon.RPCURL)
+	v.SetDefault("web3.polygon.explorer", defaultWeb3.Polygon.Explorer)
 }
 
 // getConfigFilePath determines the config file path
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"time"
+)
+
+// CORSConfig configures cross-origin resource sharing for the HTTP API
+type CORSConfig struct {
+	// AllowedOrigins lists the origins allowed to call the API. When empty, the legacy
+	// server.cors_allowed_origins and then server.frontend_url are used.
+	AllowedOrigins   []string      `mapstructure:"allowed_origins"`
+	AllowedMethods   []string      `mapstructure:"allowed_methods"`
+	AllowedHeaders   []string      `mapstructure:"allowed_headers"`
+	ExposedHeaders   []string      `mapstructure:"exposed_headers"`
+	AllowCredentials bool          `mapstructure:"allow_credentials"`
+	MaxAge           time.Duration `mapstructure:"max_age"`
+}
+
+// GetDefaultCORSConfig returns the default CORS configuration, which leaves the origins
+// to the frontend URL
+func GetDefaultCORSConfig() CORSConfig {
+	return CORSConfig{
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-Clerk-Auth-Token", "X-Request-Id"},
+		ExposedHeaders:   []string{"Link", "X-Request-Id"},
+		AllowCredentials: true,
+		MaxAge:           24 * time.Hour,
+	}
+}
+
+// ResolvedCORS returns the CORS configuration with its allowed origins filled in from
+// the legacy server settings when cors.allowed_origins is not set
+func (c *Config) ResolvedCORS() CORSConfig {
+	cors := c.CORS
+	if len(cors.AllowedOrigins) > 0 {
+		return cors
+	}
+	switch {
+	case len(c.Server.CORSAllowedOrigins) > 0:
+		cors.AllowedOrigins = c.Server.CORSAllowedOrigins
+	case c.Server.FrontendURL != "":
+		cors.AllowedOrigins = []string{c.Server.FrontendURL}
+	}
+	return cors
+}
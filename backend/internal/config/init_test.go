@@ -6,6 +6,8 @@ import (
 )
 
 func TestLoadConfig_ReturnsConfig(t *testing.T) {
+	// Auth is enabled by default, which requires a Clerk secret key
+	t.Setenv("CLERK_SECRET_KEY", "sk_test_config")
 	logger := zerolog.Nop()
 	cfg := LoadConfig(&logger)
 	if cfg == nil {
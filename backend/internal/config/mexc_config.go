@@ -0,0 +1,29 @@
+package config
+
+import "strings"
+
+// Production MEXC endpoints used when the configuration leaves them unset
+const (
+	DefaultMEXCBaseURL   = "https://api.mexc.com"
+	DefaultMEXCWSBaseURL = "wss://wbs.mexc.com/ws"
+)
+
+// MEXCEndpoints returns the REST and WebSocket base URLs every MEXC client should use.
+// With use_testnet the testnet endpoints are returned, and Validate guarantees they are
+// set; otherwise the configured production endpoints are used, falling back to the
+// defaults when empty or left as an unexpanded ${VAR} placeholder.
+func (c *Config) MEXCEndpoints() (restURL, wsURL string) {
+	if c.MEXC.UseTestnet {
+		return endpointOrDefault(c.MEXC.TestnetBaseURL, ""), endpointOrDefault(c.MEXC.TestnetWSBaseURL, "")
+	}
+	return endpointOrDefault(c.MEXC.BaseURL, DefaultMEXCBaseURL), endpointOrDefault(c.MEXC.WSBaseURL, DefaultMEXCWSBaseURL)
+}
+
+// endpointOrDefault returns value without a trailing slash, or fallback when value is unset
+func endpointOrDefault(value, fallback string) string {
+	value = strings.TrimSpace(value)
+	if value == "" || placeholderPattern.MatchString(value) {
+		return fallback
+	}
+	return strings.TrimRight(value, "/")
+}
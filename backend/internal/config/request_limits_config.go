@@ -0,0 +1,40 @@
+package config
+
+import (
+	"time"
+)
+
+// RouteLimits bounds the requests served by one group of routes. A zero value disables
+// the corresponding limit.
+type RouteLimits struct {
+	// MaxBodyBytes is the largest request body accepted; larger ones get 413
+	MaxBodyBytes int64 `mapstructure:"max_body_bytes"`
+	// ReadTimeout bounds how long the client may take to send the body
+	ReadTimeout time.Duration `mapstructure:"read_timeout"`
+	// HandlerTimeout bounds how long the handler may run before the request fails with 504
+	HandlerTimeout time.Duration `mapstructure:"handler_timeout"`
+}
+
+// RequestLimitsConfig holds the request limits of the HTTP API. Default applies to every
+// API route; the other groups layer tighter limits on top of it.
+type RequestLimitsConfig struct {
+	Default RouteLimits `mapstructure:"default"`
+	// Orders applies to order placement
+	Orders RouteLimits `mapstructure:"orders"`
+}
+
+// GetDefaultRequestLimitsConfig returns the default request limits
+func GetDefaultRequestLimitsConfig() RequestLimitsConfig {
+	return RequestLimitsConfig{
+		Default: RouteLimits{
+			MaxBodyBytes:   1 << 20,
+			ReadTimeout:    15 * time.Second,
+			HandlerTimeout: 30 * time.Second,
+		},
+		Orders: RouteLimits{
+			MaxBodyBytes:   16 << 10,
+			ReadTimeout:    5 * time.Second,
+			HandlerTimeout: 10 * time.Second,
+		},
+	}
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Source identifies where the resolved value of a config key came from
+type Source string
+
+const (
+	SourceDefault  Source = "default"
+	SourceFile     Source = "file"
+	SourceEnv      Source = "env"
+	SourceOverride Source = "override"
+)
+
+// envAliases are environment variables accepted in addition to the automatic KEY_NAME
+// form, checked in order after it
+var envAliases = map[string][]string{
+	"mexc.api_key":              {"MEXC_API_KEY"},
+	"mexc.api_secret":           {"MEXC_SECRET_KEY"},
+	"mexc.base_url":             {"MEXC_BASE_URL"},
+	"mexc.ws_base_url":          {"MEXC_WEBSOCKET_URL"},
+	"mexc.testnet_base_url":     {"MEXC_TESTNET_BASE_URL"},
+	"mexc.testnet_ws_base_url":  {"MEXC_TESTNET_WEBSOCKET_URL"},
+	"auth.clerk_secret_key":     {"CLERK_SECRET_KEY"},
+	"auth.clerk_webhook_secret": {"CLERK_WEBHOOK_SECRET"},
+}
+
+// ResolveOptions controls how Resolve builds the configuration
+type ResolveOptions struct {
+	// ConfigFile is the YAML file to read. When empty the CONFIG_FILE environment variable
+	// and the standard locations (./config.yaml, ./configs/config.yaml) are tried.
+	ConfigFile string
+	// Overrides are explicit values, typically from command-line flags, keyed by config
+	// key such as "server.port". They take precedence over every other source.
+	Overrides map[string]interface{}
+}
+
+// Resolution is a resolved configuration together with the source of every key
+type Resolution struct {
+	Config     *Config
+	ConfigFile string            // File that was read, empty if none
+	Sources    map[string]Source // Winning source per config key
+}
+
+// SourceOf returns the source the value of key was taken from
+func (r *Resolution) SourceOf(key string) Source {
+	if source, ok := r.Sources[strings.ToLower(key)]; ok {
+		return source
+	}
+	return SourceDefault
+}
+
+// Describe lists every key with its winning source, one per line in key order
+func (r *Resolution) Describe() string {
+	keys := make([]string, 0, len(r.Sources))
+	for key := range r.Sources {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, fmt.Sprintf("%s=%s", key, r.Sources[key]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Resolve builds the configuration by layering, from lowest to highest precedence:
+//
+//  1. built-in defaults
+//  2. the config file
+//  3. environment variables: the key upper-cased with dots replaced by underscores
+//     (SERVER_PORT for server.port), plus the aliases in envAliases
+//  4. explicit overrides from opts
+//
+// The resolved configuration is validated before it is returned.
+func Resolve(opts ResolveOptions) (*Resolution, error) {
+	v := viper.New()
+	setDefaults(v)
+
+	configFile := opts.ConfigFile
+	if configFile == "" {
+		configFile = getConfigFilePath()
+	}
+	if configFile != "" {
+		v.SetConfigFile(configFile)
+		if err := v.ReadInConfig(); err != nil {
+			if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+				return nil, fmt.Errorf("error reading config file %s: %w", configFile, err)
+			}
+			configFile = ""
+		}
+	}
+
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	for key, aliases := range envAliases {
+		names := append([]string{envName(key)}, aliases...)
+		if err := v.BindEnv(append([]string{key}, names...)...); err != nil {
+			return nil, fmt.Errorf("failed to bind environment for %s: %w", key, err)
+		}
+	}
+
+	for key, value := range opts.Overrides {
+		v.Set(key, value)
+	}
+
+	var config Config
+	if err := v.Unmarshal(&config); err != nil {
+		return nil, fmt.Errorf("unable to decode config: %w", err)
+	}
+
+	resolution := &Resolution{
+		Config:     &config,
+		ConfigFile: configFile,
+		Sources:    make(map[string]Source),
+	}
+	for _, key := range v.AllKeys() {
+		resolution.Sources[key] = sourceOf(v, key, opts.Overrides)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return resolution, nil
+}
+
+// sourceOf determines which layer supplied the value of key
+func sourceOf(v *viper.Viper, key string, overrides map[string]interface{}) Source {
+	for overrideKey := range overrides {
+		if strings.EqualFold(overrideKey, key) {
+			return SourceOverride
+		}
+	}
+	// Empty variables are ignored, matching viper
+	if os.Getenv(envName(key)) != "" {
+		return SourceEnv
+	}
+	for _, alias := range envAliases[key] {
+		if os.Getenv(alias) != "" {
+			return SourceEnv
+		}
+	}
+	if v.InConfig(key) {
+		return SourceFile
+	}
+	return SourceDefault
+}
+
+// envName returns the environment variable that sets key, e.g. SERVER_PORT for server.port
+func envName(key string) string {
+	return strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfigFile writes a YAML config file into a temporary directory
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+const resolverTestConfig = `
+server:
+  port: 9000
+log_level: "debug"
+auth:
+  clerk_secret_key: "sk_from_file"
+`
+
+func TestResolve_FileOverridesDefaults(t *testing.T) {
+	path := writeConfigFile(t, resolverTestConfig)
+
+	resolution, err := Resolve(ResolveOptions{ConfigFile: path})
+	require.NoError(t, err)
+
+	assert.Equal(t, path, resolution.ConfigFile)
+	assert.Equal(t, 9000, resolution.Config.Server.Port)
+	assert.Equal(t, SourceFile, resolution.SourceOf("server.port"))
+	assert.Equal(t, "debug", resolution.Config.LogLevel)
+	assert.Equal(t, "1.0.0", resolution.Config.Version)
+	assert.Equal(t, SourceDefault, resolution.SourceOf("version"))
+}
+
+func TestResolve_EnvOverridesFile(t *testing.T) {
+	path := writeConfigFile(t, resolverTestConfig)
+	t.Setenv("SERVER_PORT", "9100")
+	t.Setenv("CLERK_SECRET_KEY", "sk_from_env")
+
+	resolution, err := Resolve(ResolveOptions{ConfigFile: path})
+	require.NoError(t, err)
+
+	assert.Equal(t, 9100, resolution.Config.Server.Port)
+	assert.Equal(t, SourceEnv, resolution.SourceOf("server.port"))
+	assert.Equal(t, "sk_from_env", resolution.Config.Auth.ClerkSecretKey)
+	assert.Equal(t, SourceEnv, resolution.SourceOf("auth.clerk_secret_key"))
+	assert.Equal(t, SourceFile, resolution.SourceOf("log_level"))
+}
+
+func TestResolve_OverridesBeatEnv(t *testing.T) {
+	path := writeConfigFile(t, resolverTestConfig)
+	t.Setenv("SERVER_PORT", "9100")
+
+	resolution, err := Resolve(ResolveOptions{
+		ConfigFile: path,
+		Overrides:  map[string]interface{}{"server.port": 9200, "log_level": "warn"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 9200, resolution.Config.Server.Port)
+	assert.Equal(t, SourceOverride, resolution.SourceOf("server.port"))
+	assert.Equal(t, "warn", resolution.Config.LogLevel)
+	assert.Contains(t, resolution.Describe(), "server.port=override")
+}
+
+func TestResolve_ValidatesResult(t *testing.T) {
+	path := writeConfigFile(t, resolverTestConfig)
+
+	_, err := Resolve(ResolveOptions{
+		ConfigFile: path,
+		Overrides:  map[string]interface{}{"server.port": 0},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "server.port")
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"time"
+)
+
+// ResponseCacheConfig sets how long clients may reuse the responses of read-heavy routes
+// before revalidating them with their ETag. A zero TTL makes clients revalidate every time.
+type ResponseCacheConfig struct {
+	// ExchangeInfo applies to the MEXC exchange info
+	ExchangeInfo time.Duration `mapstructure:"exchange_info"`
+	// Symbols applies to the symbol list and symbol search
+	Symbols time.Duration `mapstructure:"symbols"`
+	// Candles applies to candle queries
+	Candles time.Duration `mapstructure:"candles"`
+}
+
+// GetDefaultResponseCacheConfig returns the default response cache TTLs
+func GetDefaultResponseCacheConfig() ResponseCacheConfig {
+	return ResponseCacheConfig{
+		ExchangeInfo: 5 * time.Minute,
+		Symbols:      time.Minute,
+		Candles:      10 * time.Second,
+	}
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"time"
+)
+
+// SchedulerConfig configures the scheduler of periodic jobs
+type SchedulerConfig struct {
+	// LockEnabled runs each job only on the instance holding its lock in the database, so
+	// replicas do not run the same job twice
+	LockEnabled bool `mapstructure:"lock_enabled"`
+	// LockTTL is how long a job lock outlives its holder, and so how long jobs pause when
+	// the holding instance dies
+	LockTTL time.Duration `mapstructure:"lock_ttl"`
+}
+
+// GetDefaultSchedulerConfig returns the default scheduler configuration
+func GetDefaultSchedulerConfig() SchedulerConfig {
+	return SchedulerConfig{
+		LockEnabled: true,
+		LockTTL:     time.Minute,
+	}
+}
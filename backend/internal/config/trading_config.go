@@ -0,0 +1,90 @@
+package config
+
+import (
+	"time"
+)
+
+// TradingConfig contains trading execution configuration
+type TradingConfig struct {
+	Reconciliation ReconciliationConfig `mapstructure:"reconciliation"`
+	Guard          TradeGuardConfig     `mapstructure:"guard"`
+	RiskLimits     RiskLimitsConfig     `mapstructure:"risk_limits"`
+	TrailingStop   TrailingStopConfig   `mapstructure:"trailing_stop"`
+	OrderRetry     OrderRetryConfig     `mapstructure:"order_retry"`
+}
+
+// ReconciliationConfig controls the loop that refreshes stale open orders from the exchange
+type ReconciliationConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	Interval  time.Duration `mapstructure:"interval"`   // Time between reconciliation passes
+	BatchSize int           `mapstructure:"batch_size"` // Maximum orders per status checked in one pass
+}
+
+// TradeGuardConfig restricts which symbols orders may be placed on
+type TradeGuardConfig struct {
+	Whitelist []string `mapstructure:"whitelist"` // Only these symbols may be traded when set
+	Blacklist []string `mapstructure:"blacklist"` // These symbols are never traded
+	// MinQuoteVolume24h is the minimum 24h volume, in the quote currency, of a tradable
+	// symbol; zero disables the check
+	MinQuoteVolume24h float64 `mapstructure:"min_quote_volume_24h"`
+}
+
+// Enabled reports whether the guard restricts anything
+func (c TradeGuardConfig) Enabled() bool {
+	return len(c.Whitelist) > 0 || len(c.Blacklist) > 0 || c.MinQuoteVolume24h > 0
+}
+
+// RiskLimitsConfig bounds the risk new buy orders may add, in the quote currency. Zero
+// disables a limit.
+type RiskLimitsConfig struct {
+	MaxPositionPerSymbol float64 `mapstructure:"max_position_per_symbol"` // Value held in one symbol
+	MaxTotalExposure     float64 `mapstructure:"max_total_exposure"`      // Value held across all symbols
+	MaxDailyLoss         float64 `mapstructure:"max_daily_loss"`          // Loss realized since 00:00 UTC that halts buys
+}
+
+// Enabled reports whether any limit is set
+func (c RiskLimitsConfig) Enabled() bool {
+	return c.MaxPositionPerSymbol > 0 || c.MaxTotalExposure > 0 || c.MaxDailyLoss > 0
+}
+
+// TrailingStopConfig controls the trailing stops kept on open long positions. The trail is
+// a percentage of the highest price, or a number of price ticks when Ticks is set.
+type TrailingStopConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Percent  float64       `mapstructure:"percent"`  // Distance below the highest price, in percent
+	Ticks    int           `mapstructure:"ticks"`    // Distance below the highest price, in price ticks
+	Interval time.Duration `mapstructure:"interval"` // Time between checks of the positions
+}
+
+// OrderRetryConfig controls how orders rejected with a transient exchange error, such as
+// rate limiting or maintenance, are placed again
+type OrderRetryConfig struct {
+	MaxAttempts    int           `mapstructure:"max_attempts"`    // Placements tried before an order is given up, including the first
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"` // Wait before the first retry, doubled after every retry
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`     // Longest wait between two retries
+	PollInterval   time.Duration `mapstructure:"poll_interval"`   // Time between checks for due retries
+	BatchSize      int           `mapstructure:"batch_size"`      // Maximum retries placed in one pass
+}
+
+// GetDefaultTradingConfig returns the default trading configuration
+func GetDefaultTradingConfig() TradingConfig {
+	return TradingConfig{
+		Reconciliation: ReconciliationConfig{
+			Enabled:   true,
+			Interval:  30 * time.Second,
+			BatchSize: 50,
+		},
+		TrailingStop: TrailingStopConfig{
+			Enabled:  false,
+			Percent:  5,
+			Interval: 15 * time.Second,
+		},
+		OrderRetry: OrderRetryConfig{
+			MaxAttempts:    5,
+			InitialBackoff: 5 * time.Second,
+			MaxBackoff:     5 * time.Minute,
+			PollInterval:   5 * time.Second,
+			BatchSize:      20,
+		},
+	}
+}
@@ -0,0 +1,242 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// placeholderPattern matches an unexpanded ${VAR} reference left in a YAML value
+var placeholderPattern = regexp.MustCompile(`^\$\{(\w+)\}$`)
+
+// FieldError describes a single invalid configuration setting
+type FieldError struct {
+	Field   string // Config key, e.g. "database.turso.url"
+	Message string
+}
+
+// Error implements error
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationErrors lists every problem found in a configuration
+type ValidationErrors []FieldError
+
+// Error implements error, listing one problem per line
+func (e ValidationErrors) Error() string {
+	lines := make([]string, 0, len(e)+1)
+	lines = append(lines, fmt.Sprintf("invalid configuration (%d problems):", len(e)))
+	for _, fieldErr := range e {
+		lines = append(lines, "  - "+fieldErr.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Validate checks the configuration for missing values and inconsistent combinations.
+// It returns ValidationErrors describing every problem, or nil if the configuration is
+// usable.
+func (c *Config) Validate() error {
+	var errs ValidationErrors
+	add := func(field, format string, args ...interface{}) {
+		errs = append(errs, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+	// require reports a missing value, naming the environment variable a placeholder refers to
+	require := func(field, value, condition string) {
+		if match := placeholderPattern.FindStringSubmatch(value); match != nil {
+			add(field, "is required when %s; set the %s environment variable", condition, match[1])
+		} else if strings.TrimSpace(value) == "" {
+			add(field, "is required when %s", condition)
+		}
+	}
+
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		add("server.port", "must be between 1 and 65535, got %d", c.Server.Port)
+	}
+
+	if c.ENV == "production" {
+		require("mexc.api_key", c.MEXC.APIKey, "env is production")
+		require("mexc.api_secret", c.MEXC.APISecret, "env is production")
+	}
+
+	// Browsers refuse credentialed responses to the * origin, and reflecting every origin
+	// instead would let any site make authenticated calls
+	if cors := c.ResolvedCORS(); cors.AllowCredentials {
+		for _, origin := range cors.AllowedOrigins {
+			if origin == "*" {
+				add("cors.allowed_origins", "must list explicit origins instead of * when cors.allow_credentials is true")
+				break
+			}
+		}
+	}
+
+	for _, group := range []struct {
+		name   string
+		limits RouteLimits
+	}{
+		{"default", c.RequestLimits.Default},
+		{"orders", c.RequestLimits.Orders},
+	} {
+		prefix := "request_limits." + group.name
+		if group.limits.MaxBodyBytes < 0 {
+			add(prefix+".max_body_bytes", "must not be negative, got %d", group.limits.MaxBodyBytes)
+		}
+		if group.limits.ReadTimeout < 0 {
+			add(prefix+".read_timeout", "must not be negative, got %s", group.limits.ReadTimeout)
+		}
+		if group.limits.HandlerTimeout < 0 {
+			add(prefix+".handler_timeout", "must not be negative, got %s", group.limits.HandlerTimeout)
+		}
+	}
+
+	if c.MEXC.UseTestnet {
+		require("mexc.testnet_base_url", c.MEXC.TestnetBaseURL, "mexc.use_testnet is true")
+		require("mexc.testnet_ws_base_url", c.MEXC.TestnetWSBaseURL, "mexc.use_testnet is true")
+	}
+
+	if c.Auth.Enabled {
+		switch c.Auth.Provider {
+		case "clerk":
+			require("auth.clerk_secret_key", c.Auth.ClerkSecretKey, "auth is enabled with the clerk provider")
+		case "jwt":
+			require("auth.jwt_secret", c.Auth.JWTSecret, "auth is enabled with the jwt provider")
+		default:
+			add("auth.provider", "must be clerk or jwt when auth is enabled, got %q", c.Auth.Provider)
+		}
+	}
+	if secret := c.Auth.ClerkWebhookSecret; secret != "" && !strings.HasPrefix(secret, "whsec_") {
+		add("auth.clerk_webhook_secret", "must be the whsec_ signing secret of the Clerk webhook endpoint")
+	}
+
+	switch c.Database.Driver {
+	case "sqlite":
+		require("database.path", c.Database.Path, "database.driver is sqlite")
+	default:
+		add("database.driver", "unsupported driver %q; supported drivers: sqlite", c.Database.Driver)
+	}
+	if c.Database.SlowQueryThreshold < 0 {
+		add("database.slow_query_threshold", "must not be negative, got %s", c.Database.SlowQueryThreshold)
+	}
+	if c.Database.Turso.Enabled {
+		require("database.turso.url", c.Database.Turso.URL, "database.turso.enabled is true")
+		require("database.turso.auth_token", c.Database.Turso.AuthToken, "database.turso.enabled is true")
+	}
+
+	if c.Notifications.Email.Enabled {
+		require("notifications.email.smtp_server", c.Notifications.Email.SMTPServer, "email notifications are enabled")
+		require("notifications.email.from_address", c.Notifications.Email.FromAddress, "email notifications are enabled")
+		if len(c.Notifications.Email.ToAddresses) == 0 {
+			add("notifications.email.to_addresses", "needs at least one address when email notifications are enabled")
+		}
+	}
+	if c.Notifications.Webhook.Enabled {
+		require("notifications.webhook.url", c.Notifications.Webhook.URL, "webhook notifications are enabled")
+	}
+
+	if c.Trading.Reconciliation.Enabled && c.Trading.Reconciliation.Interval <= 0 {
+		add("trading.reconciliation.interval", "must be positive when reconciliation is enabled")
+	}
+
+	if sync := c.Wallet.Sync; sync.Enabled {
+		if sync.Interval <= 0 {
+			add("wallet.sync.interval", "must be positive when wallet sync is enabled")
+		}
+		if sync.Jitter < 0 || sync.Jitter >= 1 {
+			add("wallet.sync.jitter", "must be at least 0 and below 1, got %g", sync.Jitter)
+		}
+		for userID, interval := range sync.UserIntervals {
+			if interval <= 0 {
+				add("wallet.sync.user_intervals."+userID, "must be positive, got %s", interval)
+			}
+		}
+	}
+
+	if c.Market.Stream.TickerPollInterval <= 0 {
+		add("market.stream.ticker_poll_interval", "must be positive, got %s", c.Market.Stream.TickerPollInterval)
+	}
+	if c.Market.Stream.TickerMaxRate <= 0 {
+		add("market.stream.ticker_max_rate", "must be positive, got %g", c.Market.Stream.TickerMaxRate)
+	}
+
+	if c.Trading.Guard.MinQuoteVolume24h < 0 {
+		add("trading.guard.min_quote_volume_24h", "must not be negative, got %g", c.Trading.Guard.MinQuoteVolume24h)
+	}
+	riskLimits := c.Trading.RiskLimits
+	if riskLimits.MaxPositionPerSymbol < 0 {
+		add("trading.risk_limits.max_position_per_symbol", "must not be negative, got %g", riskLimits.MaxPositionPerSymbol)
+	}
+	if riskLimits.MaxTotalExposure < 0 {
+		add("trading.risk_limits.max_total_exposure", "must not be negative, got %g", riskLimits.MaxTotalExposure)
+	}
+	if riskLimits.MaxDailyLoss < 0 {
+		add("trading.risk_limits.max_daily_loss", "must not be negative, got %g", riskLimits.MaxDailyLoss)
+	}
+	if trailing := c.Trading.TrailingStop; trailing.Enabled {
+		if trailing.Interval <= 0 {
+			add("trading.trailing_stop.interval", "must be positive when trailing stops are enabled")
+		}
+		if trailing.Percent < 0 || trailing.Percent >= 100 {
+			add("trading.trailing_stop.percent", "must be at least 0 and below 100, got %g", trailing.Percent)
+		}
+		if trailing.Ticks < 0 {
+			add("trading.trailing_stop.ticks", "must not be negative, got %d", trailing.Ticks)
+		}
+		if trailing.Percent <= 0 && trailing.Ticks <= 0 {
+			add("trading.trailing_stop", "needs percent or ticks when trailing stops are enabled")
+		}
+	}
+
+	if webhooks := c.OutboundWebhooks; webhooks.Enabled {
+		if webhooks.MaxAttempts <= 0 {
+			add("outbound_webhooks.max_attempts", "must be positive, got %d", webhooks.MaxAttempts)
+		}
+		if webhooks.InitialBackoff <= 0 {
+			add("outbound_webhooks.initial_backoff", "must be positive, got %s", webhooks.InitialBackoff)
+		}
+		if webhooks.MaxBackoff < webhooks.InitialBackoff {
+			add("outbound_webhooks.max_backoff", "must be at least initial_backoff, got %s", webhooks.MaxBackoff)
+		}
+		if webhooks.Timeout <= 0 {
+			add("outbound_webhooks.timeout", "must be positive, got %s", webhooks.Timeout)
+		}
+		if webhooks.QueueSize <= 0 {
+			add("outbound_webhooks.queue_size", "must be positive, got %d", webhooks.QueueSize)
+		}
+		if webhooks.Workers <= 0 {
+			add("outbound_webhooks.workers", "must be positive, got %d", webhooks.Workers)
+		}
+	}
+
+	if c.AnnouncementParser.Enabled && len(c.AnnouncementParser.URLs) == 0 {
+		add("announcement_parser.urls", "needs at least one URL when the announcement parser is enabled")
+	}
+
+	if autobuy := c.Autobuy; autobuy.Enabled {
+		if autobuy.MinPrice < 0 {
+			add("autobuy.min_price", "must not be negative, got %g", autobuy.MinPrice)
+		}
+		if autobuy.MaxPrice <= autobuy.MinPrice {
+			add("autobuy.max_price", "must be above min_price, got %g", autobuy.MaxPrice)
+		}
+		if autobuy.MinVolume < 0 {
+			add("autobuy.min_volume", "must not be negative, got %g", autobuy.MinVolume)
+		}
+		if autobuy.Delay < 0 {
+			add("autobuy.delay", "must not be negative, got %s", autobuy.Delay)
+		}
+		if autobuy.MaxTotalBudget <= 0 {
+			add("autobuy.max_total_budget", "must be positive when the autobuyer is enabled, got %g", autobuy.MaxTotalBudget)
+		}
+		if autobuy.MaxPerCoin <= 0 || autobuy.MaxPerCoin > autobuy.MaxTotalBudget {
+			add("autobuy.max_per_coin", "must be positive and at most max_total_budget, got %g", autobuy.MaxPerCoin)
+		}
+		if autobuy.MaxConcurrentPositions <= 0 {
+			add("autobuy.max_concurrent_positions", "must be positive when the autobuyer is enabled, got %d", autobuy.MaxConcurrentPositions)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
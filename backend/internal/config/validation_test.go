@@ -0,0 +1,195 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validConfig returns a configuration that passes validation
+func validConfig() *Config {
+	cfg := &Config{
+		ENV:     "development",
+		Trading: GetDefaultTradingConfig(),
+	}
+	cfg.Server.Port = 8080
+	cfg.Auth = Auth{Enabled: true, Provider: "clerk", ClerkSecretKey: "sk_test"}
+	cfg.Database.Driver = "sqlite"
+	cfg.Database.Path = "./data/test.db"
+	cfg.Market.Stream.TickerPollInterval = 2 * time.Second
+	cfg.Market.Stream.TickerMaxRate = 2
+	return cfg
+}
+
+// fieldsOf returns the config keys reported by a validation error
+func fieldsOf(t *testing.T, err error) []string {
+	t.Helper()
+	var errs ValidationErrors
+	require.True(t, errors.As(err, &errs), "expected ValidationErrors, got %v", err)
+	fields := make([]string, 0, len(errs))
+	for _, fieldErr := range errs {
+		fields = append(fields, fieldErr.Field)
+	}
+	return fields
+}
+
+func TestConfig_ValidateAcceptsValidConfig(t *testing.T) {
+	assert.NoError(t, validConfig().Validate())
+}
+
+func TestConfig_ValidateTursoRequiresURLAndToken(t *testing.T) {
+	cfg := validConfig()
+	cfg.Database.Turso.Enabled = true
+
+	err := cfg.Validate()
+	assert.ElementsMatch(t, []string{"database.turso.url", "database.turso.auth_token"}, fieldsOf(t, err))
+	assert.Contains(t, err.Error(), "database.turso.enabled is true")
+}
+
+func TestConfig_ValidateAuthRequiresProviderSecret(t *testing.T) {
+	cfg := validConfig()
+	cfg.Auth.ClerkSecretKey = ""
+	assert.Equal(t, []string{"auth.clerk_secret_key"}, fieldsOf(t, cfg.Validate()))
+
+	cfg.Auth.Provider = "jwt"
+	assert.Equal(t, []string{"auth.jwt_secret"}, fieldsOf(t, cfg.Validate()))
+
+	cfg.Auth.Provider = "oauth"
+	assert.Equal(t, []string{"auth.provider"}, fieldsOf(t, cfg.Validate()))
+
+	cfg.Auth.Enabled = false
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_ValidateNamesUnexpandedPlaceholder(t *testing.T) {
+	cfg := validConfig()
+	cfg.Auth.ClerkSecretKey = "${CLERK_SECRET_KEY}"
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "set the CLERK_SECRET_KEY environment variable")
+}
+
+func TestConfig_ValidateListsEveryProblem(t *testing.T) {
+	cfg := validConfig()
+	cfg.ENV = "production"
+	cfg.Server.Port = 0
+	cfg.Database.Driver = "mysql"
+	cfg.Notifications.Email.Enabled = true
+	cfg.Notifications.Webhook.Enabled = true
+	cfg.AnnouncementParser.Enabled = true
+
+	err := cfg.Validate()
+	assert.ElementsMatch(t, []string{
+		"server.port",
+		"mexc.api_key",
+		"mexc.api_secret",
+		"database.driver",
+		"notifications.email.smtp_server",
+		"notifications.email.from_address",
+		"notifications.email.to_addresses",
+		"notifications.webhook.url",
+		"announcement_parser.urls",
+	}, fieldsOf(t, err))
+	assert.Contains(t, err.Error(), "invalid configuration (9 problems)")
+}
+
+func TestConfig_ValidateAutobuyCaps(t *testing.T) {
+	cfg := validConfig()
+	cfg.Autobuy = GetDefaultAutobuyConfig()
+	cfg.Autobuy.Enabled = true
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Autobuy.MaxTotalBudget = 0
+	cfg.Autobuy.MaxConcurrentPositions = 0
+	cfg.Autobuy.MaxPrice = cfg.Autobuy.MinPrice
+	assert.ElementsMatch(t, []string{
+		"autobuy.max_price",
+		"autobuy.max_total_budget",
+		"autobuy.max_per_coin",
+		"autobuy.max_concurrent_positions",
+	}, fieldsOf(t, cfg.Validate()))
+
+	cfg.Autobuy.Enabled = false
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_ValidateTestnetRequiresEndpoints(t *testing.T) {
+	cfg := validConfig()
+	cfg.MEXC.UseTestnet = true
+	cfg.MEXC.TestnetBaseURL = "${MEXC_TESTNET_BASE_URL}"
+
+	err := cfg.Validate()
+	assert.ElementsMatch(t, []string{"mexc.testnet_base_url", "mexc.testnet_ws_base_url"}, fieldsOf(t, err))
+	assert.Contains(t, err.Error(), "set the MEXC_TESTNET_BASE_URL environment variable")
+
+	cfg.MEXC.TestnetBaseURL = "https://testnet.example.com"
+	cfg.MEXC.TestnetWSBaseURL = "wss://testnet.example.com/ws"
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_MEXCEndpoints(t *testing.T) {
+	cfg := validConfig()
+	cfg.MEXC.BaseURL = "${MEXC_BASE_URL}"
+	restURL, wsURL := cfg.MEXCEndpoints()
+	assert.Equal(t, DefaultMEXCBaseURL, restURL)
+	assert.Equal(t, DefaultMEXCWSBaseURL, wsURL)
+
+	cfg.MEXC.BaseURL = "https://proxy.example.com/"
+	restURL, _ = cfg.MEXCEndpoints()
+	assert.Equal(t, "https://proxy.example.com", restURL)
+
+	cfg.MEXC.UseTestnet = true
+	cfg.MEXC.TestnetBaseURL = "https://testnet.example.com"
+	cfg.MEXC.TestnetWSBaseURL = "wss://testnet.example.com/ws"
+	restURL, wsURL = cfg.MEXCEndpoints()
+	assert.Equal(t, "https://testnet.example.com", restURL)
+	assert.Equal(t, "wss://testnet.example.com/ws", wsURL)
+}
+
+func TestConfig_ValidateRejectsWildcardCORSWithCredentials(t *testing.T) {
+	cfg := validConfig()
+	cfg.CORS = GetDefaultCORSConfig()
+	cfg.CORS.AllowedOrigins = []string{"https://app.example.com", "*"}
+	assert.Equal(t, []string{"cors.allowed_origins"}, fieldsOf(t, cfg.Validate()))
+
+	// The legacy server setting is checked too
+	cfg.CORS.AllowedOrigins = nil
+	cfg.Server.CORSAllowedOrigins = []string{"*"}
+	assert.Equal(t, []string{"cors.allowed_origins"}, fieldsOf(t, cfg.Validate()))
+
+	cfg.CORS.AllowCredentials = false
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_ResolvedCORSDefaultsToFrontendURL(t *testing.T) {
+	cfg := validConfig()
+	cfg.Server.FrontendURL = "https://app.example.com"
+	assert.Equal(t, []string{"https://app.example.com"}, cfg.ResolvedCORS().AllowedOrigins)
+
+	cfg.CORS.AllowedOrigins = []string{"https://admin.example.com"}
+	assert.Equal(t, []string{"https://admin.example.com"}, cfg.ResolvedCORS().AllowedOrigins)
+}
+
+func TestConfig_ValidateRejectsNegativeRequestLimits(t *testing.T) {
+	cfg := validConfig()
+	cfg.RequestLimits = GetDefaultRequestLimitsConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.RequestLimits.Orders.MaxBodyBytes = -1
+	cfg.RequestLimits.Default.HandlerTimeout = -time.Second
+	assert.Equal(t, []string{"request_limits.default.handler_timeout", "request_limits.orders.max_body_bytes"}, fieldsOf(t, cfg.Validate()))
+}
+
+func TestConfig_ValidateWalletSyncSchedule(t *testing.T) {
+	cfg := validConfig()
+	cfg.Wallet = GetDefaultWalletConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.Wallet.Sync.Jitter = 1.5
+	cfg.Wallet.Sync.UserIntervals = map[string]time.Duration{"user-1": 0}
+	assert.ElementsMatch(t, []string{"wallet.sync.jitter", "wallet.sync.user_intervals.user-1"}, fieldsOf(t, cfg.Validate()))
+}
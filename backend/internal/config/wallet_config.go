@@ -0,0 +1,55 @@
+package config
+
+import "time"
+
+// WalletConfig contains wallet synchronization and valuation configuration
+type WalletConfig struct {
+	Sync      WalletSyncConfig      `mapstructure:"sync"`
+	Valuation WalletValuationConfig `mapstructure:"valuation"`
+}
+
+// WalletSyncConfig controls how often wallets are synced and how synced balances are
+// compared with the previous snapshot
+type WalletSyncConfig struct {
+	// BalanceChangeThreshold is the minimum relative change, in percent of the previous
+	// balance, reported as a deposit or withdrawal. New assets are always reported.
+	BalanceChangeThreshold float64 `mapstructure:"balance_change_threshold"`
+	// Enabled turns on the background sync of every user's wallets
+	Enabled  bool          `mapstructure:"enabled"`
+	Interval time.Duration `mapstructure:"interval"`
+	// UserIntervals overrides Interval for individual users, keyed by user ID
+	UserIntervals map[string]time.Duration `mapstructure:"user_intervals"`
+	// CheckInterval is how often the scheduler looks for users due a sync
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+	// Jitter spreads syncs by up to this fraction of the interval either way
+	Jitter float64 `mapstructure:"jitter"`
+	// MaxBackoff caps the delay between retries after consecutive sync failures
+	MaxBackoff time.Duration `mapstructure:"max_backoff"`
+}
+
+// WalletValuationConfig controls how portfolio holdings are converted into a single currency
+type WalletValuationConfig struct {
+	QuoteCurrency string `mapstructure:"quote_currency"`
+	Exchange      string `mapstructure:"exchange"` // Exchange whose tickers are used for pricing
+	// BridgeAssets are tried in order when an asset has no direct pair with the quote currency
+	BridgeAssets []string `mapstructure:"bridge_assets"`
+}
+
+// GetDefaultWalletConfig returns the default wallet configuration
+func GetDefaultWalletConfig() WalletConfig {
+	return WalletConfig{
+		Sync: WalletSyncConfig{
+			BalanceChangeThreshold: 0.1,
+			Enabled:                true,
+			Interval:               15 * time.Minute,
+			CheckInterval:          30 * time.Second,
+			Jitter:                 0.1,
+			MaxBackoff:             4 * time.Hour,
+		},
+		Valuation: WalletValuationConfig{
+			QuoteCurrency: "USDT",
+			Exchange:      "mexc",
+			BridgeAssets:  []string{"BTC", "ETH", "USDC"},
+		},
+	}
+}
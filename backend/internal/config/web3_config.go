@@ -0,0 +1,36 @@
+package config
+
+// Web3Config contains settings for the additional EVM chains supported by the wallet providers
+type Web3Config struct {
+	BSC     EVMChainConfig `mapstructure:"bsc"`
+	Polygon EVMChainConfig `mapstructure:"polygon"`
+}
+
+// EVMChainConfig describes how to reach an EVM-compatible chain
+type EVMChainConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	ChainID  int64  `mapstructure:"chain_id"`
+	Network  string `mapstructure:"network"`  // Network name stored on connected wallets
+	RPCURL   string `mapstructure:"rpc_url"`  // JSON-RPC endpoint
+	Explorer string `mapstructure:"explorer"` // Block explorer base URL
+}
+
+// GetDefaultWeb3Config returns the default EVM chain configuration
+func GetDefaultWeb3Config() Web3Config {
+	return Web3Config{
+		BSC: EVMChainConfig{
+			Enabled:  true,
+			ChainID:  56,
+			Network:  "BSC",
+			RPCURL:   "https://bsc-dataseed.binance.org",
+			Explorer: "https://bscscan.com",
+		},
+		Polygon: EVMChainConfig{
+			Enabled:  true,
+			ChainID:  137,
+			Network:  "Polygon",
+			RPCURL:   "https://polygon-rpc.com",
+			Explorer: "https://polygonscan.com",
+		},
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/factory"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/pkg/platform/mexc"
 	"github.com/rs/zerolog"
 	gormdb "gorm.io/gorm"
 )
@@ -21,8 +22,10 @@ type Container struct {
 	useCaseFactory    *factory.UseCaseFactory
 
 	// External services
-	mexcClient port.MEXCClient
-	eventBus   port.EventBus
+	mexcBreaker    *mexc.CircuitBreaker
+	mexcClient     port.MEXCClient
+	eventBus       port.ReplayableEventBus
+	domainEventBus port.DomainEventBus
 
 	// Repositories
 	orderRepository          port.OrderRepository
@@ -54,6 +57,13 @@ func NewContainer(cfg *config.Config, logger *zerolog.Logger, db *gormdb.DB) *Co
 	}
 }
 
+// WithMEXCCircuitBreaker makes the container's MEXC client share breaker. It must be
+// called before Initialize.
+func (c *Container) WithMEXCCircuitBreaker(breaker *mexc.CircuitBreaker) *Container {
+	c.mexcBreaker = breaker
+	return c
+}
+
 // Initialize initializes all dependencies
 func (c *Container) Initialize() error {
 	// Create transaction manager
@@ -111,11 +121,13 @@ func (c *Container) initializeRepositories() {
 // initializeExternalServices initializes external services
 func (c *Container) initializeExternalServices() {
 	// Create MEXC client
-	mexcFactory := factory.NewMEXCFactory(c.config, c.logger)
+	mexcFactory := factory.NewMEXCFactory(c.config, c.logger).WithMEXCCircuitBreaker(c.mexcBreaker)
 	c.mexcClient = mexcFactory.CreateMEXCClient()
 
 	// Create event bus
-	c.eventBus = delivery.NewInMemoryEventBus(*c.logger)
+	eventBus := delivery.NewInMemoryEventBus(*c.logger).WithEventStore(c.repositoryFactory.CreateEventStore())
+	c.eventBus = eventBus
+	c.domainEventBus = eventBus
 }
 
 // initializeUseCases initializes all use cases
@@ -232,7 +244,12 @@ func (c *Container) GetMEXCClient() port.MEXCClient {
 	return c.mexcClient
 }
 
-// GetEventBus returns the event bus
-func (c *Container) GetEventBus() port.EventBus {
+// GetEventBus returns the event bus, which records new coin events for replay
+func (c *Container) GetEventBus() port.ReplayableEventBus {
 	return c.eventBus
 }
+
+// GetDomainEventBus returns the topic-based domain event bus
+func (c *Container) GetDomainEventBus() port.DomainEventBus {
+	return c.domainEventBus
+}
@@ -12,9 +12,41 @@ type EventType string
 const (
 	// NewCoinTradableEvent signifies that a newly listed coin has become tradable.
 	NewCoinTradableEvent EventType = "NewCoinTradable"
+	// OrderPlacedEvent signifies that an order has been accepted by the exchange.
+	OrderPlacedEvent EventType = "OrderPlaced"
+	// OrderFilledEvent signifies that an order has been completely filled.
+	OrderFilledEvent EventType = "OrderFilled"
+	// OrderCanceledEvent signifies that an order has been canceled.
+	OrderCanceledEvent EventType = "OrderCanceled"
+	// RiskAlertEvent signifies that a risk assessment raised an alert.
+	RiskAlertEvent EventType = "RiskAlert"
+	// PreListingAlertEvent signifies that an announced listing is about to start trading.
+	PreListingAlertEvent EventType = "PreListingAlert"
+	// SymbolStatusChangedEvent signifies that the exchange changed a symbol's trading status.
+	SymbolStatusChangedEvent EventType = "SymbolStatusChanged"
+	// OrderFailedEvent signifies that an order could not be placed after all its retries.
+	OrderFailedEvent EventType = "OrderFailed"
+	// OrderRejectedEvent signifies that the exchange rejected an order it had accepted.
+	OrderRejectedEvent EventType = "OrderRejected"
+	// OrderExpiredEvent signifies that an order expired without being completely filled.
+	OrderExpiredEvent EventType = "OrderExpired"
 	// Add other event types here as needed...
 )
 
+// WebhookEventTypes are the event types users can receive at their webhook endpoints
+var WebhookEventTypes = []EventType{
+	OrderPlacedEvent,
+	OrderFilledEvent,
+	OrderCanceledEvent,
+	OrderRejectedEvent,
+	OrderExpiredEvent,
+	OrderFailedEvent,
+	NewCoinTradableEvent,
+	PreListingAlertEvent,
+	SymbolStatusChangedEvent,
+	RiskAlertEvent,
+}
+
 // DomainEvent represents a base interface for domain events.
 // It includes basic metadata common to all events.
 type DomainEvent interface {
@@ -90,3 +122,136 @@ func NewNewCoinTradable(coin *model.NewCoin, price *float64, volume *float64) *N
 
 // Ensure NewCoinTradable implements DomainEvent (compile-time check)
 var _ DomainEvent = (*NewCoinTradable)(nil)
+
+// SymbolStatusChanged represents the exchange changing a symbol's trading status, such as
+// a TRADING symbol entering a BREAK or being delisted
+type SymbolStatusChanged struct {
+	BaseEvent
+	Symbol    string `json:"symbol"`
+	OldStatus string `json:"old_status"`
+	NewStatus string `json:"new_status"`
+}
+
+// NewSymbolStatusChanged creates a new SymbolStatusChanged event.
+func NewSymbolStatusChanged(symbol, oldStatus, newStatus string) *SymbolStatusChanged {
+	return &SymbolStatusChanged{
+		BaseEvent: NewBaseEvent(SymbolStatusChangedEvent, symbol),
+		Symbol:    symbol,
+		OldStatus: oldStatus,
+		NewStatus: newStatus,
+	}
+}
+
+// Ensure SymbolStatusChanged implements DomainEvent (compile-time check)
+var _ DomainEvent = (*SymbolStatusChanged)(nil)
+
+// OrderPlaced represents an order accepted by the exchange
+type OrderPlaced struct {
+	BaseEvent
+	Order *model.Order `json:"order"`
+}
+
+// NewOrderPlaced creates a new OrderPlaced event.
+func NewOrderPlaced(order *model.Order) *OrderPlaced {
+	return &OrderPlaced{
+		BaseEvent: NewBaseEvent(OrderPlacedEvent, order.OrderID),
+		Order:     order,
+	}
+}
+
+// Ensure OrderPlaced implements DomainEvent (compile-time check)
+var _ DomainEvent = (*OrderPlaced)(nil)
+
+// OrderStatusChanged represents an order moving into a new status on the exchange
+type OrderStatusChanged struct {
+	BaseEvent
+	Order     *model.Order      `json:"order"`
+	OldStatus model.OrderStatus `json:"old_status"`
+	NewStatus model.OrderStatus `json:"new_status"`
+}
+
+// orderStatusEvents maps the terminal order statuses to the event published when an order
+// reaches them
+var orderStatusEvents = map[model.OrderStatus]EventType{
+	model.OrderStatusFilled:   OrderFilledEvent,
+	model.OrderStatusCanceled: OrderCanceledEvent,
+	model.OrderStatusRejected: OrderRejectedEvent,
+	model.OrderStatusExpired:  OrderExpiredEvent,
+}
+
+// NewOrderStatusChanged creates a new OrderStatusChanged event. The event type is
+// derived from the order's current status. It returns nil for statuses that are not
+// terminal, such as NEW or PARTIALLY_FILLED, which have no event.
+func NewOrderStatusChanged(order *model.Order, oldStatus model.OrderStatus) *OrderStatusChanged {
+	eventType, ok := orderStatusEvents[order.Status]
+	if !ok {
+		return nil
+	}
+
+	return &OrderStatusChanged{
+		BaseEvent: NewBaseEvent(eventType, order.OrderID),
+		Order:     order,
+		OldStatus: oldStatus,
+		NewStatus: order.Status,
+	}
+}
+
+// Ensure OrderStatusChanged implements DomainEvent (compile-time check)
+var _ DomainEvent = (*OrderStatusChanged)(nil)
+
+// OrderFailed represents an order request the exchange kept rejecting and that was given up
+type OrderFailed struct {
+	BaseEvent
+	Retry *model.OrderRetry `json:"retry"`
+}
+
+// NewOrderFailed creates a new OrderFailed event for a retry that was given up.
+func NewOrderFailed(retry *model.OrderRetry) *OrderFailed {
+	return &OrderFailed{
+		BaseEvent: NewBaseEvent(OrderFailedEvent, retry.ID),
+		Retry:     retry,
+	}
+}
+
+// Ensure OrderFailed implements DomainEvent (compile-time check)
+var _ DomainEvent = (*OrderFailed)(nil)
+
+// RiskAlertRaised represents a risk assessment that requires attention
+type RiskAlertRaised struct {
+	BaseEvent
+	Assessment *model.RiskAssessment `json:"assessment"`
+}
+
+// NewRiskAlertRaised creates a new RiskAlertRaised event for the given assessment.
+func NewRiskAlertRaised(assessment *model.RiskAssessment) *RiskAlertRaised {
+	return &RiskAlertRaised{
+		BaseEvent:  NewBaseEvent(RiskAlertEvent, assessment.ID),
+		Assessment: assessment,
+	}
+}
+
+// Ensure RiskAlertRaised implements DomainEvent (compile-time check)
+var _ DomainEvent = (*RiskAlertRaised)(nil)
+
+// PreListingAlert is emitted a configured lead time before an announced listing
+type PreListingAlert struct {
+	BaseEvent
+	Symbol      string    `json:"symbol"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url,omitempty"`
+	ListingTime time.Time `json:"listing_time"`
+}
+
+// NewPreListingAlert creates a new PreListingAlert event for a scheduled listing.
+func NewPreListingAlert(listing *model.ScheduledListing) *PreListingAlert {
+	return &PreListingAlert{
+		BaseEvent:   NewBaseEvent(PreListingAlertEvent, listing.Symbol),
+		Symbol:      listing.Symbol,
+		Title:       listing.Title,
+		URL:         listing.URL,
+		ListingTime: listing.ListingTime,
+	}
+}
+
+// Ensure PreListingAlert implements DomainEvent (compile-time check)
+var _ DomainEvent = (*PreListingAlert)(nil)
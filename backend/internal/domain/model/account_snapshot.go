@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// Sections of an account snapshot, as named in its Errors
+const (
+	AccountSnapshotWallets      = "wallets"
+	AccountSnapshotOpenOrders   = "open_orders"
+	AccountSnapshotRecentOrders = "recent_orders"
+)
+
+// AccountSnapshot combines a user's wallet balances, open orders and recent orders. A
+// section whose source failed is left empty and its error is reported in Errors.
+type AccountSnapshot struct {
+	UserID       string    `json:"user_id"`
+	Wallets      []*Wallet `json:"wallets"`
+	OpenOrders   []*Order  `json:"open_orders"`
+	RecentOrders []*Order  `json:"recent_orders"`
+	// Partial is true when at least one section could not be gathered
+	Partial bool `json:"partial"`
+	// Errors maps the sections that could not be gathered to the reason
+	Errors      map[string]string `json:"errors,omitempty"`
+	GeneratedAt time.Time         `json:"generated_at"`
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"errors"
 	"time"
 )
 
@@ -15,8 +16,13 @@ type AIMessage struct {
 	Content        string                 `json:"content"`
 	Timestamp      time.Time              `json:"timestamp"`
 	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+	// ToolCalls are the tools the model asks to run before it answers
+	ToolCalls []AIFunctionCall `json:"tool_calls,omitempty"`
 }
 
+// ErrConversationNotFound is returned when an AI conversation does not exist
+var ErrConversationNotFound = errors.New("conversation not found")
+
 // AIConversation represents a conversation with an AI
 type AIConversation struct {
 	ID        string      `json:"id"`
@@ -76,3 +82,12 @@ type AIEmbedding struct {
 	Vector     []float64 `json:"vector"`
 	CreatedAt  time.Time `json:"created_at"`
 }
+
+// AITradeSignal is a structured buy/sell/hold signal produced by the AI from recent market data
+type AITradeSignal struct {
+	Symbol      string     `json:"symbol"`
+	Action      SignalType `json:"action"`
+	Confidence  float64    `json:"confidence"` // 0 to 1
+	Rationale   string     `json:"rationale"`
+	GeneratedAt time.Time  `json:"generated_at"`
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AuditAction identifies an audited mutation
+type AuditAction string
+
+const (
+	AuditActionOrderPlace       AuditAction = "order.place"
+	AuditActionOrderCancel      AuditAction = "order.cancel"
+	AuditActionCredentialCreate AuditAction = "credential.create"
+	AuditActionCredentialUpdate AuditAction = "credential.update"
+	AuditActionCredentialDelete AuditAction = "credential.delete"
+	AuditActionCredentialRotate AuditAction = "credential.rotate"
+)
+
+// AuditOutcome records whether an audited mutation succeeded
+type AuditOutcome string
+
+const (
+	AuditOutcomeSuccess AuditOutcome = "success"
+	AuditOutcomeFailure AuditOutcome = "failure"
+)
+
+// AuditActorSystem is the actor of mutations not made on behalf of a user
+const AuditActorSystem = "system"
+
+// AuditEntry is one record of the audit trail. Entries form a hash chain: each entry's
+// hash covers its own fields and the hash of the entry before it, so changing or
+// removing a stored entry breaks the chain from that point on.
+type AuditEntry struct {
+	// Sequence numbers entries from 1 in the order they were recorded
+	Sequence     int64             `json:"sequence"`
+	Actor        string            `json:"actor"`
+	Action       AuditAction       `json:"action"`
+	ResourceType string            `json:"resource_type"`
+	ResourceID   string            `json:"resource_id,omitempty"`
+	Outcome      AuditOutcome      `json:"outcome"`
+	Details      map[string]string `json:"details,omitempty"`
+	RequestID    string            `json:"request_id,omitempty"`
+	Timestamp    time.Time         `json:"timestamp"`
+	PrevHash     string            `json:"prev_hash"`
+	Hash         string            `json:"hash"`
+}
+
+// ComputeHash returns the hash of the entry's fields chained to PrevHash
+func (e *AuditEntry) ComputeHash() string {
+	// Map keys are marshalled in sorted order, so the encoding is stable
+	details, _ := json.Marshal(e.Details)
+	fields := []string{
+		strconv.FormatInt(e.Sequence, 10),
+		e.Actor,
+		string(e.Action),
+		e.ResourceType,
+		e.ResourceID,
+		string(e.Outcome),
+		string(details),
+		e.RequestID,
+		e.Timestamp.UTC().Format(time.RFC3339Nano),
+		e.PrevHash,
+	}
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x1f")))
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditVerification is the result of checking the audit trail's hash chain
+type AuditVerification struct {
+	Valid bool `json:"valid"`
+	// Checked is the number of entries checked
+	Checked int `json:"checked"`
+	// BrokenAt is the sequence of the first entry failing the check, zero when valid
+	BrokenAt int64  `json:"broken_at,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
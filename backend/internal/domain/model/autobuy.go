@@ -81,3 +81,14 @@ type AutoBuyExecutionRepository interface {
 	GetBySymbol(ctx context.Context, symbol string, limit, offset int) ([]*AutoBuyExecution, error)
 	GetByTimeRange(ctx context.Context, from, to time.Time, limit, offset int) ([]*AutoBuyExecution, error)
 }
+
+// AutoBuyPositionDust is the fraction of an autobuy position that may remain unsold when
+// the position counts as closed. Exchanges round sells to their lot size, so selling a
+// whole position can leave a little of it behind.
+const AutoBuyPositionDust = 0.01
+
+// AutoBuyPositionSoldOff reports whether an autobuy position of bought quantity with
+// remaining quantity left unsold is closed
+func AutoBuyPositionSoldOff(bought, remaining float64) bool {
+	return remaining <= bought*AutoBuyPositionDust
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Decimal is an exact decimal number for prices and quantities. Arithmetic on float64
+// drifts, 0.57 * 100 being 56.99999999999999, which matters when rounding to a symbol's
+// tick and step sizes or comparing against its limits. Models keep float64 fields for
+// compatibility; convert at the boundaries with NewDecimalFromFloat and Float64.
+type Decimal struct {
+	value decimal.Decimal
+}
+
+// NewDecimalFromFloat returns the shortest decimal that converts back to f, so 0.1
+// becomes exactly 0.1
+func NewDecimalFromFloat(f float64) Decimal {
+	return Decimal{value: decimal.NewFromFloat(f)}
+}
+
+// ParseDecimal parses a decimal number such as "0.00012300", as MEXC reports prices
+func ParseDecimal(s string) (Decimal, error) {
+	value, err := decimal.NewFromString(s)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return Decimal{value: value}, nil
+}
+
+// Float64 returns the nearest float64
+func (d Decimal) Float64() float64 {
+	return d.value.InexactFloat64()
+}
+
+// String returns the number without trailing zeros, such as "0.123"
+func (d Decimal) String() string {
+	return d.value.String()
+}
+
+// Add returns d + other
+func (d Decimal) Add(other Decimal) Decimal {
+	return Decimal{value: d.value.Add(other.value)}
+}
+
+// Sub returns d - other
+func (d Decimal) Sub(other Decimal) Decimal {
+	return Decimal{value: d.value.Sub(other.value)}
+}
+
+// Mul returns d * other
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{value: d.value.Mul(other.value)}
+}
+
+// Div returns d / other, rounded to 16 decimal places when not exact. It panics when
+// other is zero.
+func (d Decimal) Div(other Decimal) Decimal {
+	return Decimal{value: d.value.Div(other.value)}
+}
+
+// Cmp returns -1, 0 or +1 when d is less than, equal to or greater than other
+func (d Decimal) Cmp(other Decimal) int {
+	return d.value.Cmp(other.value)
+}
+
+// LessThan reports whether d < other
+func (d Decimal) LessThan(other Decimal) bool {
+	return d.value.LessThan(other.value)
+}
+
+// IsPositive reports whether d > 0
+func (d Decimal) IsPositive() bool {
+	return d.value.IsPositive()
+}
+
+// RoundDownToStep floors d to a multiple of step, as quantities are rounded to a symbol's
+// step size. A non-positive step leaves d unchanged.
+func (d Decimal) RoundDownToStep(step Decimal) Decimal {
+	if !step.IsPositive() {
+		return d
+	}
+	return Decimal{value: d.value.Div(step.value).Floor().Mul(step.value)}
+}
+
+// RoundToStep rounds d to the nearest multiple of step, halves away from zero, as prices
+// are rounded to a symbol's tick size. A non-positive step leaves d unchanged.
+func (d Decimal) RoundToStep(step Decimal) Decimal {
+	if !step.IsPositive() {
+		return d
+	}
+	return Decimal{value: d.value.Div(step.value).Round(0).Mul(step.value)}
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecimal_ArithmeticDoesNotDrift(t *testing.T) {
+	// Variables, as constant expressions are evaluated exactly by the compiler
+	tenth, fifth, third, price, hundred := 0.1, 0.2, 0.3, 0.57, 100.0
+	// The float results are off by one unit in the last place
+	assert.NotEqual(t, 0.3, tenth+fifth)
+	assert.NotEqual(t, 57.0, price*hundred)
+	assert.NotEqual(t, 0.2, third-tenth)
+
+	assert.Equal(t, "0.3", NewDecimalFromFloat(tenth).Add(NewDecimalFromFloat(fifth)).String())
+	assert.Equal(t, 57.0, NewDecimalFromFloat(price).Mul(NewDecimalFromFloat(hundred)).Float64())
+	assert.Equal(t, 0.2, NewDecimalFromFloat(third).Sub(NewDecimalFromFloat(tenth)).Float64())
+	assert.Equal(t, "0.19", NewDecimalFromFloat(0.57).Div(NewDecimalFromFloat(3)).String())
+	assert.Equal(t, 0, NewDecimalFromFloat(price).Mul(NewDecimalFromFloat(hundred)).Cmp(NewDecimalFromFloat(57)))
+}
+
+func TestDecimal_RoundingFixesFloatDrift(t *testing.T) {
+	// 0.3 / 0.1 is 2.9999999999999996 in floats, flooring a whole step away
+	quantity, step := 0.3, 0.1
+	assert.Equal(t, 0.2, math.Floor(quantity/step)*step)
+	assert.Equal(t, 0.3, NewDecimalFromFloat(quantity).RoundDownToStep(NewDecimalFromFloat(step)).Float64())
+
+	// 1.005 / 0.01 is 100.49999999999999 in floats, rounding the half tick down
+	price, tick := 1.005, 0.01
+	assert.Equal(t, 1.0, math.Round(price/tick)*tick)
+	assert.Equal(t, 1.01, NewDecimalFromFloat(price).RoundToStep(NewDecimalFromFloat(tick)).Float64())
+}
+
+func TestDecimal_RoundToStep(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   float64
+		step    float64
+		down    float64
+		nearest float64
+	}{
+		{name: "exact multiple", value: 0.3, step: 0.1, down: 0.3, nearest: 0.3},
+		{name: "quantity step", value: 0.12399, step: 0.001, down: 0.123, nearest: 0.124},
+		{name: "BTC price", value: 65432.174, step: 0.01, down: 65432.17, nearest: 65432.17},
+		{name: "small cap price", value: 0.000012345, step: 0.0000001, down: 0.0000123, nearest: 0.0000123},
+		{name: "whole step", value: 12.7, step: 1, down: 12, nearest: 13},
+		{name: "no step", value: 0.12399, step: 0, down: 0.12399, nearest: 0.12399},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, step := NewDecimalFromFloat(tt.value), NewDecimalFromFloat(tt.step)
+			assert.Equal(t, tt.down, value.RoundDownToStep(step).Float64())
+			assert.Equal(t, tt.nearest, value.RoundToStep(step).Float64())
+		})
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	price, err := ParseDecimal("0.00012300")
+	require.NoError(t, err)
+	assert.Equal(t, "0.000123", price.String())
+	assert.Equal(t, 0.000123, price.Float64())
+	assert.True(t, price.IsPositive())
+
+	_, err = ParseDecimal("not a number")
+	assert.Error(t, err)
+}
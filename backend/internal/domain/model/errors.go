@@ -14,4 +14,5 @@ var (
 	ErrInsufficientFunds = errors.New("insufficient funds")
 	ErrWalletNotFound    = errors.New("wallet not found")
 	ErrCredentialNotFound = errors.New("credential not found")
+	ErrENSNameNotFound    = errors.New("ENS name not found")
 )
@@ -0,0 +1,108 @@
+package market
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// weekOffset aligns weekly buckets to Monday 00:00 UTC; the Unix epoch was a Thursday
+const weekOffset = 4 * 24 * time.Hour
+
+// Aggregate resamples candles of one interval into candles of the longer target
+// interval. Each target candle takes the open of its first candle, the close of its
+// last, the highest high, the lowest low and the summed volumes and trade counts.
+// Buckets are aligned to the Unix epoch, and weekly ones to Monday. A target candle is
+// complete only when all of its source candles are present and complete.
+//
+// The target duration must be a whole multiple of the source duration; calendar
+// intervals such as 1M cannot be aggregated. The input does not need to be sorted.
+func Aggregate(candles []*Candle, target Interval) ([]*Candle, error) {
+	if len(candles) == 0 {
+		return []*Candle{}, nil
+	}
+
+	source := candles[0].Interval
+	if err := CanAggregate(source, target); err != nil {
+		return nil, err
+	}
+	sourceDuration, targetDuration := source.Duration(), target.Duration()
+	perBucket := int(targetDuration / sourceDuration)
+
+	sorted := make([]*Candle, len(candles))
+	copy(sorted, candles)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].OpenTime.Before(sorted[j].OpenTime) })
+
+	var offset time.Duration
+	if target == Interval1w {
+		offset = weekOffset
+	}
+
+	result := make([]*Candle, 0, len(sorted)/perBucket+1)
+	var current *Candle
+	count := 0
+	for _, c := range sorted {
+		if c.Interval != source {
+			return nil, fmt.Errorf("cannot aggregate mixed intervals %s and %s", source, c.Interval)
+		}
+
+		openTime := bucketStart(c.OpenTime, targetDuration, offset)
+		if current == nil || !current.OpenTime.Equal(openTime) {
+			if current != nil {
+				current.Complete = current.Complete && count == perBucket
+			}
+			current = &Candle{
+				Symbol:    c.Symbol,
+				Exchange:  c.Exchange,
+				Interval:  target,
+				OpenTime:  openTime,
+				CloseTime: openTime.Add(targetDuration - time.Millisecond),
+				Open:      c.Open,
+				High:      c.High,
+				Low:       c.Low,
+				Complete:  true,
+			}
+			result = append(result, current)
+			count = 0
+		}
+
+		current.High = max(current.High, c.High)
+		current.Low = min(current.Low, c.Low)
+		current.Close = c.Close
+		current.Volume += c.Volume
+		current.QuoteVolume += c.QuoteVolume
+		current.TradeCount += c.TradeCount
+		current.Complete = current.Complete && c.Complete
+		count++
+	}
+	current.Complete = current.Complete && count == perBucket
+
+	return result, nil
+}
+
+// CanAggregate reports why candles of the source interval cannot be aggregated into the
+// target interval, or nil when they can
+func CanAggregate(source, target Interval) error {
+	sourceDuration, targetDuration := source.Duration(), target.Duration()
+	if sourceDuration == 0 {
+		return fmt.Errorf("cannot aggregate candles of interval %q", source)
+	}
+	if targetDuration == 0 {
+		return fmt.Errorf("cannot aggregate into interval %q", target)
+	}
+	if targetDuration < sourceDuration || targetDuration%sourceDuration != 0 {
+		return fmt.Errorf("target interval %s is not a multiple of source interval %s", target, source)
+	}
+	return nil
+}
+
+// bucketStart returns the start of the bucket of length size containing t, with buckets
+// aligned to the Unix epoch plus offset
+func bucketStart(t time.Time, size, offset time.Duration) time.Time {
+	since := t.Sub(time.Unix(0, 0).Add(offset))
+	start := since - since%size
+	if since < 0 && since%size != 0 {
+		start -= size
+	}
+	return time.Unix(0, 0).Add(offset + start).In(t.Location())
+}
@@ -0,0 +1,102 @@
+package market
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var aggregateBase = time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+// minuteCandles returns consecutive complete 1m candles starting at start, one per
+// {open, high, low, close, volume} row
+func minuteCandles(start time.Time, rows ...[5]float64) []*Candle {
+	candles := make([]*Candle, len(rows))
+	for i, row := range rows {
+		open := start.Add(time.Duration(i) * time.Minute)
+		candles[i] = &Candle{
+			Symbol: "BTCUSDT", Exchange: "mexc", Interval: Interval1m,
+			OpenTime: open, CloseTime: open.Add(time.Minute - time.Millisecond),
+			Open: row[0], High: row[1], Low: row[2], Close: row[3], Volume: row[4],
+			QuoteVolume: row[3] * row[4], TradeCount: 1, Complete: true,
+		}
+	}
+	return candles
+}
+
+func TestAggregate_1mTo5m(t *testing.T) {
+	candles := minuteCandles(aggregateBase,
+		[5]float64{100, 101, 99, 100.5, 1},
+		[5]float64{100.5, 103, 100, 102, 2},
+		[5]float64{102, 102.5, 98, 99, 3},
+		[5]float64{99, 100, 97.5, 98, 1},
+		[5]float64{98, 99, 98, 98.5, 4},
+		// Second bucket, only two of five minutes present
+		[5]float64{98.5, 105, 98.5, 104, 2},
+		[5]float64{104, 104, 101, 102, 1},
+	)
+	// Order must not matter
+	candles[0], candles[6] = candles[6], candles[0]
+
+	result, err := Aggregate(candles, Interval5m)
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+
+	first := result[0]
+	assert.Equal(t, Interval5m, first.Interval)
+	assert.Equal(t, aggregateBase, first.OpenTime)
+	assert.Equal(t, aggregateBase.Add(5*time.Minute-time.Millisecond), first.CloseTime)
+	assert.Equal(t, 100.0, first.Open)
+	assert.Equal(t, 103.0, first.High)
+	assert.Equal(t, 97.5, first.Low)
+	assert.Equal(t, 98.5, first.Close)
+	assert.Equal(t, 11.0, first.Volume)
+	assert.Equal(t, int64(5), first.TradeCount)
+	assert.True(t, first.Complete)
+
+	second := result[1]
+	assert.Equal(t, aggregateBase.Add(5*time.Minute), second.OpenTime)
+	assert.Equal(t, 98.5, second.Open)
+	assert.Equal(t, 105.0, second.High)
+	assert.Equal(t, 98.5, second.Low)
+	assert.Equal(t, 102.0, second.Close)
+	assert.Equal(t, 3.0, second.Volume)
+	assert.False(t, second.Complete, "bucket is missing three minutes")
+}
+
+func TestAggregate_AlignsBuckets(t *testing.T) {
+	// 10:03 and 10:04 belong to the 10:00 bucket, 10:05 starts the next one
+	candles := minuteCandles(aggregateBase.Add(3*time.Minute),
+		[5]float64{1, 1, 1, 1, 1}, [5]float64{1, 1, 1, 1, 1}, [5]float64{1, 1, 1, 1, 1})
+	result, err := Aggregate(candles, Interval5m)
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, aggregateBase, result[0].OpenTime)
+
+	// Weekly buckets start on Monday
+	result, err = Aggregate(minuteCandles(time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC), [5]float64{1, 1, 1, 1, 1}), Interval1w)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), result[0].OpenTime)
+}
+
+func TestAggregate_RejectsIncompatibleIntervals(t *testing.T) {
+	candles := minuteCandles(aggregateBase, [5]float64{1, 1, 1, 1, 1})
+	candles[0].Interval = Interval3m
+
+	_, err := Aggregate(candles, Interval5m)
+	assert.ErrorContains(t, err, "not a multiple")
+	_, err = Aggregate(candles, Interval1m)
+	assert.ErrorContains(t, err, "not a multiple")
+	_, err = Aggregate(candles, Interval1M)
+	assert.Error(t, err)
+
+	mixed := append(minuteCandles(aggregateBase, [5]float64{1, 1, 1, 1, 1}), candles...)
+	_, err = Aggregate(mixed, Interval15m)
+	assert.ErrorContains(t, err, "mixed intervals")
+
+	result, err := Aggregate(nil, Interval5m)
+	require.NoError(t, err)
+	assert.Empty(t, result)
+}
@@ -24,6 +24,30 @@ const (
 	Interval1M  Interval = "1M"
 )
 
+// intervalDurations holds the length of every fixed-size interval
+var intervalDurations = map[Interval]time.Duration{
+	Interval1m:  time.Minute,
+	Interval3m:  3 * time.Minute,
+	Interval5m:  5 * time.Minute,
+	Interval15m: 15 * time.Minute,
+	Interval30m: 30 * time.Minute,
+	Interval1h:  time.Hour,
+	Interval2h:  2 * time.Hour,
+	Interval4h:  4 * time.Hour,
+	Interval6h:  6 * time.Hour,
+	Interval8h:  8 * time.Hour,
+	Interval12h: 12 * time.Hour,
+	Interval1d:  24 * time.Hour,
+	Interval3d:  3 * 24 * time.Hour,
+	Interval1w:  7 * 24 * time.Hour,
+}
+
+// Duration returns the length of the interval, or zero for calendar-based intervals
+// such as 1M and unknown values
+func (i Interval) Duration() time.Duration {
+	return intervalDurations[i]
+}
+
 // Candle represents OHLCV (Open, High, Low, Close, Volume) data for a trading pair
 type Candle struct {
 	// Symbol is the trading pair identifier (e.g., "BTCUSDT")
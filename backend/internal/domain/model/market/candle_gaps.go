@@ -0,0 +1,58 @@
+package market
+
+import (
+	"fmt"
+	"time"
+)
+
+// CandleGap is a run of consecutive candles missing from a stored series
+type CandleGap struct {
+	// Start is the open time of the first missing candle
+	Start time.Time `json:"start"`
+	// End is the open time just after the last missing candle
+	End time.Time `json:"end"`
+	// Missing is the number of candles in the gap
+	Missing int `json:"missing"`
+}
+
+// FindCandleGaps returns the runs of candles missing from candles among those expected to
+// open between start and end inclusive. Open times are aligned to the interval as in
+// Aggregate. Candles outside the range or of another interval are ignored; calendar
+// intervals such as 1M are rejected.
+func FindCandleGaps(candles []*Candle, interval Interval, start, end time.Time) ([]CandleGap, error) {
+	step := interval.Duration()
+	if step == 0 {
+		return nil, fmt.Errorf("cannot find gaps in candles of interval %q", interval)
+	}
+	var offset time.Duration
+	if interval == Interval1w {
+		offset = weekOffset
+	}
+
+	present := make(map[int64]bool, len(candles))
+	for _, c := range candles {
+		if c.Interval == interval {
+			present[c.OpenTime.UnixMilli()] = true
+		}
+	}
+
+	gaps := []CandleGap{}
+	var current *CandleGap
+	openTime := bucketStart(start, step, offset)
+	if openTime.Before(start) {
+		openTime = openTime.Add(step)
+	}
+	for ; !openTime.After(end); openTime = openTime.Add(step) {
+		if present[openTime.UnixMilli()] {
+			current = nil
+			continue
+		}
+		if current == nil {
+			gaps = append(gaps, CandleGap{Start: openTime})
+			current = &gaps[len(gaps)-1]
+		}
+		current.End = openTime.Add(step)
+		current.Missing++
+	}
+	return gaps, nil
+}
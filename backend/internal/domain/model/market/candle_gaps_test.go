@@ -0,0 +1,51 @@
+package market
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// minuteSeries returns one-minute candles opening at start plus each of the given minutes
+func minuteSeries(start time.Time, minutes ...int) []*Candle {
+	candles := make([]*Candle, len(minutes))
+	for i, m := range minutes {
+		candles[i] = &Candle{Symbol: "BTCUSDT", Interval: Interval1m, OpenTime: start.Add(time.Duration(m) * time.Minute)}
+	}
+	return candles
+}
+
+func TestFindCandleGaps(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	// Minutes 3-4 and 8 are missing from 0..9
+	candles := minuteSeries(start, 0, 1, 2, 5, 6, 7, 9)
+
+	gaps, err := FindCandleGaps(candles, Interval1m, start, start.Add(9*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, []CandleGap{
+		{Start: start.Add(3 * time.Minute), End: start.Add(5 * time.Minute), Missing: 2},
+		{Start: start.Add(8 * time.Minute), End: start.Add(9 * time.Minute), Missing: 1},
+	}, gaps)
+}
+
+func TestFindCandleGaps_RangeEdges(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	candles := minuteSeries(start, 1, 2)
+
+	// The range starts mid-minute, so the first expected candle opens at minute 1; the
+	// missing tail up to the inclusive end is one gap
+	gaps, err := FindCandleGaps(candles, Interval1m, start.Add(30*time.Second), start.Add(4*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, []CandleGap{{Start: start.Add(3 * time.Minute), End: start.Add(5 * time.Minute), Missing: 2}}, gaps)
+
+	gaps, err = FindCandleGaps(candles, Interval1m, start.Add(time.Minute), start.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, gaps)
+}
+
+func TestFindCandleGaps_RejectsCalendarInterval(t *testing.T) {
+	_, err := FindCandleGaps(nil, Interval1M, time.Now().Add(-time.Hour), time.Now())
+	assert.Error(t, err)
+}
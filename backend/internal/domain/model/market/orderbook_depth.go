@@ -0,0 +1,93 @@
+package market
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrInvalidBucketSize is returned when depth is aggregated into buckets that are not positive
+var ErrInvalidBucketSize = errors.New("bucket size must be positive")
+
+// DepthLevel is the quantity resting in one price bucket of an order book side
+type DepthLevel struct {
+	// Price is the bucket's price: the bucket's lower bound for bids and its upper bound for
+	// asks, so no bucket crosses the spread
+	Price    float64 `json:"price"`
+	Quantity float64 `json:"quantity"`
+	// Cumulative is the quantity of this bucket and of every bucket nearer the spread
+	Cumulative float64 `json:"cumulative"`
+}
+
+// Depth is an order book aggregated into price buckets for a depth chart. Both sides are
+// ordered from the spread outwards.
+type Depth struct {
+	Symbol     string       `json:"symbol"`
+	Exchange   string       `json:"exchange,omitempty"`
+	BucketSize float64      `json:"bucketSize"`
+	Bids       []DepthLevel `json:"bids"`
+	Asks       []DepthLevel `json:"asks"`
+}
+
+// AggregateDepth sums the quantity of the order book's levels into price buckets of
+// bucketSize, rounding bid prices down and ask prices up to a multiple of it, and adds
+// the cumulative quantity from the spread outwards. Bucket prices are computed in decimal
+// arithmetic so they are exact multiples of bucketSize. Levels without quantity are
+// skipped, levels do not need to be sorted, and an empty side gives no buckets.
+func AggregateDepth(ob *OrderBook, bucketSize float64) (*Depth, error) {
+	if bucketSize <= 0 {
+		return nil, ErrInvalidBucketSize
+	}
+	depth := &Depth{BucketSize: bucketSize, Bids: []DepthLevel{}, Asks: []DepthLevel{}}
+	if ob == nil {
+		return depth, nil
+	}
+	depth.Symbol = ob.Symbol
+	depth.Exchange = ob.Exchange
+
+	size := decimal.NewFromFloat(bucketSize)
+	depth.Bids = aggregateSide(ob.Bids, size, decimal.Decimal.Floor, true)
+	depth.Asks = aggregateSide(ob.Asks, size, decimal.Decimal.Ceil, false)
+	return depth, nil
+}
+
+// aggregateSide buckets the levels of one side, rounding the number of buckets a price
+// spans with round, and orders the buckets from the spread outwards: highest first for
+// bids and lowest first for asks
+func aggregateSide(levels []OrderBookEntry, size decimal.Decimal, round func(decimal.Decimal) decimal.Decimal, descending bool) []DepthLevel {
+	quantities := make(map[string]decimal.Decimal)
+	prices := make(map[string]decimal.Decimal)
+	for _, level := range levels {
+		if level.Quantity <= 0 {
+			continue
+		}
+		price := round(decimal.NewFromFloat(level.Price).Div(size)).Mul(size)
+		key := price.String()
+		prices[key] = price
+		quantities[key] = quantities[key].Add(decimal.NewFromFloat(level.Quantity))
+	}
+
+	keys := make([]string, 0, len(prices))
+	for key := range prices {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if descending {
+			return prices[keys[i]].GreaterThan(prices[keys[j]])
+		}
+		return prices[keys[i]].LessThan(prices[keys[j]])
+	})
+
+	buckets := make([]DepthLevel, len(keys))
+	var cumulative decimal.Decimal
+	for i, key := range keys {
+		cumulative = cumulative.Add(quantities[key])
+		buckets[i] = DepthLevel{
+			Price:      prices[key].InexactFloat64(),
+			Quantity:   quantities[key].InexactFloat64(),
+			Cumulative: cumulative.InexactFloat64(),
+		}
+	}
+	return buckets
+}
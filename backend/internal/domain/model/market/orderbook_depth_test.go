@@ -0,0 +1,85 @@
+package market
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateDepth(t *testing.T) {
+	ob := &OrderBook{
+		Symbol:   "BTCUSDT",
+		Exchange: "mexc",
+		// Deliberately unsorted, with an empty level
+		Bids: []OrderBookEntry{
+			{Price: 99.5, Quantity: 1}, {Price: 100.4, Quantity: 2}, {Price: 100.1, Quantity: 3},
+			{Price: 97.2, Quantity: 4}, {Price: 98.9, Quantity: 0},
+		},
+		Asks: []OrderBookEntry{
+			{Price: 101.3, Quantity: 1.5}, {Price: 100.6, Quantity: 0.5}, {Price: 101, Quantity: 2}, {Price: 103.1, Quantity: 1},
+		},
+	}
+
+	depth, err := AggregateDepth(ob, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, "BTCUSDT", depth.Symbol)
+	assert.Equal(t, "mexc", depth.Exchange)
+	assert.Equal(t, 1.0, depth.BucketSize)
+	// Bids round down, best bucket first
+	assert.Equal(t, []DepthLevel{
+		{Price: 100, Quantity: 5, Cumulative: 5},
+		{Price: 99, Quantity: 1, Cumulative: 6},
+		{Price: 97, Quantity: 4, Cumulative: 10},
+	}, depth.Bids)
+	// Asks round up, best bucket first
+	assert.Equal(t, []DepthLevel{
+		{Price: 101, Quantity: 2.5, Cumulative: 2.5},
+		{Price: 102, Quantity: 1.5, Cumulative: 4},
+		{Price: 104, Quantity: 1, Cumulative: 5},
+	}, depth.Asks)
+}
+
+func TestAggregateDepth_FractionalBucketSize(t *testing.T) {
+	ob := &OrderBook{
+		Bids: []OrderBookEntry{{Price: 0.3, Quantity: 0.1}, {Price: 0.29, Quantity: 0.2}},
+		Asks: []OrderBookEntry{{Price: 0.31, Quantity: 0.1}, {Price: 0.4, Quantity: 0.2}},
+	}
+
+	depth, err := AggregateDepth(ob, 0.1)
+	require.NoError(t, err)
+
+	// 0.3 / 0.1 floors to 2 buckets in floats; the buckets must be exact multiples
+	assert.Equal(t, []DepthLevel{
+		{Price: 0.3, Quantity: 0.1, Cumulative: 0.1},
+		{Price: 0.2, Quantity: 0.2, Cumulative: 0.3},
+	}, depth.Bids)
+	assert.Equal(t, []DepthLevel{{Price: 0.4, Quantity: 0.3, Cumulative: 0.3}}, depth.Asks)
+}
+
+func TestAggregateDepth_EmptySides(t *testing.T) {
+	book := testOrderBook()
+	book.Asks = nil
+
+	depth, err := AggregateDepth(book, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []DepthLevel{
+		{Price: 100, Quantity: 3, Cumulative: 3},
+		{Price: 95, Quantity: 7, Cumulative: 10},
+	}, depth.Bids)
+	assert.NotNil(t, depth.Asks)
+	assert.Empty(t, depth.Asks)
+
+	depth, err = AggregateDepth(nil, 5)
+	require.NoError(t, err)
+	assert.Empty(t, depth.Bids)
+	assert.Empty(t, depth.Asks)
+}
+
+func TestAggregateDepth_InvalidBucketSize(t *testing.T) {
+	for _, size := range []float64{0, -1} {
+		_, err := AggregateDepth(testOrderBook(), size)
+		assert.ErrorIs(t, err, ErrInvalidBucketSize)
+	}
+}
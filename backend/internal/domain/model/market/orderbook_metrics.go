@@ -0,0 +1,75 @@
+package market
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrEmptyOrderBook is returned when an order book has no bids or no asks
+var ErrEmptyOrderBook = errors.New("order book has no bids or no asks")
+
+// OrderBookStats holds microstructure signals from the top levels of an order book
+type OrderBookStats struct {
+	Symbol   string `json:"symbol"`
+	Exchange string `json:"exchange,omitempty"`
+	// Depth is the number of levels per side the volumes were summed over
+	Depth   int     `json:"depth"`
+	BestBid float64 `json:"bestBid"`
+	BestAsk float64 `json:"bestAsk"`
+	// Spread is BestAsk - BestBid; SpreadPercent is the spread relative to MidPrice
+	Spread        float64 `json:"spread"`
+	SpreadPercent float64 `json:"spreadPercent"`
+	MidPrice      float64 `json:"midPrice"`
+	BidVolume     float64 `json:"bidVolume"`
+	AskVolume     float64 `json:"askVolume"`
+	// Imbalance is (BidVolume - AskVolume) / (BidVolume + AskVolume), from -1 (only asks)
+	// to 1 (only bids)
+	Imbalance float64 `json:"imbalance"`
+}
+
+// OrderBookMetrics computes the best prices, spread, mid price and bid/ask volume
+// imbalance over the top depth levels of each side. A depth of zero or less uses every
+// level. Levels do not need to be sorted.
+func OrderBookMetrics(ob *OrderBook, depth int) (*OrderBookStats, error) {
+	if ob == nil || len(ob.Bids) == 0 || len(ob.Asks) == 0 {
+		return nil, ErrEmptyOrderBook
+	}
+
+	bids := topLevels(ob.Bids, depth, func(a, b float64) bool { return a > b })
+	asks := topLevels(ob.Asks, depth, func(a, b float64) bool { return a < b })
+
+	stats := &OrderBookStats{
+		Symbol:   ob.Symbol,
+		Exchange: ob.Exchange,
+		Depth:    max(len(bids), len(asks)),
+		BestBid:  bids[0].Price,
+		BestAsk:  asks[0].Price,
+	}
+	stats.Spread = stats.BestAsk - stats.BestBid
+	stats.MidPrice = (stats.BestAsk + stats.BestBid) / 2
+	if stats.MidPrice != 0 {
+		stats.SpreadPercent = stats.Spread / stats.MidPrice * 100
+	}
+
+	for _, level := range bids {
+		stats.BidVolume += level.Quantity
+	}
+	for _, level := range asks {
+		stats.AskVolume += level.Quantity
+	}
+	if total := stats.BidVolume + stats.AskVolume; total > 0 {
+		stats.Imbalance = (stats.BidVolume - stats.AskVolume) / total
+	}
+	return stats, nil
+}
+
+// topLevels returns the first depth levels of a copy of levels ordered best price first
+func topLevels(levels []OrderBookEntry, depth int, better func(a, b float64) bool) []OrderBookEntry {
+	sorted := make([]OrderBookEntry, len(levels))
+	copy(sorted, levels)
+	sort.SliceStable(sorted, func(i, j int) bool { return better(sorted[i].Price, sorted[j].Price) })
+	if depth > 0 && depth < len(sorted) {
+		sorted = sorted[:depth]
+	}
+	return sorted
+}
@@ -0,0 +1,51 @@
+package market
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testOrderBook() *OrderBook {
+	return &OrderBook{
+		Symbol:   "BTCUSDT",
+		Exchange: "mexc",
+		// Deliberately unsorted
+		Bids: []OrderBookEntry{{Price: 99, Quantity: 2}, {Price: 100, Quantity: 3}, {Price: 98, Quantity: 5}},
+		Asks: []OrderBookEntry{{Price: 102, Quantity: 4}, {Price: 101, Quantity: 1}, {Price: 103, Quantity: 10}},
+	}
+}
+
+func TestOrderBookMetrics(t *testing.T) {
+	stats, err := OrderBookMetrics(testOrderBook(), 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, stats.Depth)
+	assert.Equal(t, 100.0, stats.BestBid)
+	assert.Equal(t, 101.0, stats.BestAsk)
+	assert.Equal(t, 1.0, stats.Spread)
+	assert.Equal(t, 100.5, stats.MidPrice)
+	assert.InDelta(t, 1/100.5*100, stats.SpreadPercent, 1e-12)
+	// Top two levels: bids 3+2, asks 1+4
+	assert.Equal(t, 5.0, stats.BidVolume)
+	assert.Equal(t, 5.0, stats.AskVolume)
+	assert.Equal(t, 0.0, stats.Imbalance)
+}
+
+func TestOrderBookMetrics_AllLevels(t *testing.T) {
+	stats, err := OrderBookMetrics(testOrderBook(), 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, stats.Depth)
+	assert.Equal(t, 10.0, stats.BidVolume)
+	assert.Equal(t, 15.0, stats.AskVolume)
+	assert.InDelta(t, -0.2, stats.Imbalance, 1e-12)
+}
+
+func TestOrderBookMetrics_EmptySide(t *testing.T) {
+	_, err := OrderBookMetrics(&OrderBook{Bids: []OrderBookEntry{{Price: 1, Quantity: 1}}}, 5)
+	assert.ErrorIs(t, err, ErrEmptyOrderBook)
+	_, err = OrderBookMetrics(nil, 5)
+	assert.ErrorIs(t, err, ErrEmptyOrderBook)
+}
@@ -83,6 +83,9 @@ type Symbol struct {
 
 	// UpdatedAt is when this symbol was last updated
 	UpdatedAt time.Time `json:"updatedAt"`
+
+	// DeletedAt is when this symbol was soft-deleted, nil while it is active
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
 }
 
 // SymbolInfo represents exchange info for a symbol, including status.
@@ -0,0 +1,23 @@
+package market
+
+// TypicalPrice returns the typical price (high + low + close) / 3 of a candle
+func TypicalPrice(c *Candle) float64 {
+	return (c.High + c.Low + c.Close) / 3
+}
+
+// VWAP returns the volume-weighted average of the typical prices of candles. It returns
+// zero when the candles carry no volume; nil candles are skipped.
+func VWAP(candles []*Candle) float64 {
+	var priceVolume, volume float64
+	for _, c := range candles {
+		if c == nil {
+			continue
+		}
+		priceVolume += TypicalPrice(c) * c.Volume
+		volume += c.Volume
+	}
+	if volume == 0 {
+		return 0
+	}
+	return priceVolume / volume
+}
@@ -0,0 +1,28 @@
+package market
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypicalPrice(t *testing.T) {
+	assert.Equal(t, 100.0, TypicalPrice(&Candle{High: 110, Low: 90, Close: 100}))
+}
+
+func TestVWAP(t *testing.T) {
+	candles := []*Candle{
+		{High: 12, Low: 9, Close: 9, Volume: 100},   // typical 10
+		{High: 13, Low: 11, Close: 12, Volume: 300}, // typical 12
+		nil,
+		{High: 16, Low: 12, Close: 14, Volume: 100}, // typical 14
+	}
+
+	// (10*100 + 12*300 + 14*100) / 500 = 6000 / 500
+	assert.Equal(t, 12.0, VWAP(candles))
+}
+
+func TestVWAP_NoVolume(t *testing.T) {
+	assert.Zero(t, VWAP(nil))
+	assert.Zero(t, VWAP([]*Candle{{High: 10, Low: 10, Close: 10}}))
+}
@@ -57,6 +57,37 @@ type NewCoinEvent struct {
 	CreatedAt time.Time   `json:"created_at"`
 }
 
+// NewCoinEventData is the data of a NewCoinEvent, naming the symbol of the coin so
+// consumers replaying the event log can look the coin up
+func NewCoinEventData(symbol string) map[string]interface{} {
+	return map[string]interface{}{"symbol": symbol}
+}
+
+// Symbol returns the symbol recorded in the event data, or "" when there is none
+func (e *NewCoinEvent) Symbol() string {
+	data, _ := e.Data.(map[string]interface{})
+	symbol, _ := data["symbol"].(string)
+	return symbol
+}
+
+// StoredNewCoinEvent is a NewCoinEvent together with its position in the event log
+type StoredNewCoinEvent struct {
+	Offset int64         `json:"offset"`
+	Event  *NewCoinEvent `json:"event"`
+}
+
+// ScheduledListing is a pre-listing alert waiting to fire ahead of an announced listing
+type ScheduledListing struct {
+	ID          string     `json:"id"` // Announcement ID, or symbol and listing time when absent
+	Symbol      string     `json:"symbol"`
+	Title       string     `json:"title"`
+	URL         string     `json:"url,omitempty"`
+	ListingTime time.Time  `json:"listing_time"`
+	AlertAt     time.Time  `json:"alert_at"`
+	FiredAt     *time.Time `json:"fired_at,omitempty"` // Set once the alert was published
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
 // NewCoinRepository defines the interface for new coin data persistence
 type NewCoinRepository interface {
 	// Create stores a new coin in the repository
@@ -1,6 +1,12 @@
 package model
 
-import "time"
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // OrderSide represents the side of an order (BUY or SELL)
 type OrderSide string
@@ -73,6 +79,26 @@ func (o *Order) IsComplete() bool {
 	}
 }
 
+// OpenOrderStatuses returns the statuses of orders still working on the exchange
+func OpenOrderStatuses() []OrderStatus {
+	return []OrderStatus{OrderStatusNew, OrderStatusPartiallyFilled}
+}
+
+// OrderFill is one execution of an order on the exchange. Commission is the fee of this
+// execution alone.
+type OrderFill struct {
+	TradeID         string    `json:"trade_id"`
+	OrderID         string    `json:"order_id"`
+	ClientOrderID   string    `json:"client_order_id"`
+	Symbol          string    `json:"symbol"`
+	Side            OrderSide `json:"side"`
+	Price           float64   `json:"price"`
+	Quantity        float64   `json:"quantity"`
+	Commission      float64   `json:"commission"`
+	CommissionAsset string    `json:"commission_asset"`
+	ExecutedAt      time.Time `json:"executed_at"`
+}
+
 // OrderRequest represents the data needed to place a new order
 type OrderRequest struct {
 	UserID      string      `json:"user_id"`
@@ -94,3 +120,58 @@ type PlaceOrderResponse struct {
 
 // OrderResponse is an alias for PlaceOrderResponse for interface compatibility
 type OrderResponse = PlaceOrderResponse
+
+// ErrInvalidOrderCursor is returned when an order history cursor cannot be decoded
+var ErrInvalidOrderCursor = errors.New("invalid order cursor")
+
+// OrderCursor marks a position in order history sorted newest first. The order ID breaks
+// ties between orders created at the same instant.
+type OrderCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// Encode returns the cursor as an opaque URL-safe token
+func (c OrderCursor) Encode() string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + ":" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeOrderCursor parses a token produced by OrderCursor.Encode
+func DecodeOrderCursor(token string) (*OrderCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidOrderCursor
+	}
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return nil, ErrInvalidOrderCursor
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidOrderCursor
+	}
+	return &OrderCursor{CreatedAt: time.Unix(0, unixNano).UTC(), ID: id}, nil
+}
+
+// OrderFilter selects orders by any combination of its fields. Zero-valued fields do not
+// filter, so an empty filter matches every order.
+type OrderFilter struct {
+	Symbol string
+	UserID string
+	// Statuses matches orders in any of the listed statuses
+	Statuses []OrderStatus
+	// CreatedFrom is inclusive and CreatedTo exclusive
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	// Limit and Offset page through the matching orders, newest first. A non-positive
+	// Limit returns all of them.
+	Limit  int
+	Offset int
+}
+
+// OrderPage is a page of order history with the token for the following page
+type OrderPage struct {
+	Orders     []*Order `json:"orders"`
+	NextCursor string   `json:"nextCursor,omitempty"`
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// OrderRetryStatus is the state of an order waiting to be placed again
+type OrderRetryStatus string
+
+// Order retry statuses
+const (
+	OrderRetryPending   OrderRetryStatus = "pending"
+	OrderRetrySucceeded OrderRetryStatus = "succeeded"
+	OrderRetryFailed    OrderRetryStatus = "failed"
+)
+
+// OrderRetry is an order request the exchange rejected with a transient error, such as
+// rate limiting or maintenance, kept to be placed again later
+type OrderRetry struct {
+	ID      string           `json:"id"`
+	Request OrderRequest     `json:"request"`
+	Status  OrderRetryStatus `json:"status"`
+	// Attempts counts the placements tried, including the one first rejected
+	Attempts      int       `json:"attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	LastError     string    `json:"last_error,omitempty"`
+	OrderID       string    `json:"order_id,omitempty"` // Exchange order ID once placed
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// PnLReport summarizes realized profit and loss for a symbol using FIFO lot matching
+type PnLReport struct {
+	Symbol            string    `json:"symbol"`
+	BoughtQuantity    float64   `json:"bought_quantity"`     // Total executed buy quantity
+	SoldQuantity      float64   `json:"sold_quantity"`       // Total executed sell quantity
+	AverageEntryPrice float64   `json:"average_entry_price"` // Volume-weighted average buy price
+	AverageExitPrice  float64   `json:"average_exit_price"`  // Volume-weighted average sell price
+	GrossRealizedPnL  float64   `json:"gross_realized_pnl"`  // Realized PnL before fees
+	RealizedFees      float64   `json:"realized_fees"`       // Fees attributable to matched quantity
+	RealizedPnL       float64   `json:"realized_pnl"`        // Realized PnL net of realized fees
+	TotalFees         float64   `json:"total_fees"`          // All fees paid on the included orders
+	OpenQuantity      float64   `json:"open_quantity"`       // Bought quantity not yet matched by sells
+	OpenCostBasis     float64   `json:"open_cost_basis"`     // Cost of the open quantity including its buy fees
+	UnmatchedSellQty  float64   `json:"unmatched_sell_qty"`  // Sell quantity with no prior buy to match
+	OrderCount        int       `json:"order_count"`         // Number of orders with executed quantity
+	GeneratedAt       time.Time `json:"generated_at"`
+}
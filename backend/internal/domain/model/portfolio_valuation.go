@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// AssetValuation is the value of a single asset held across a user's wallets
+type AssetValuation struct {
+	Asset    Asset    `json:"asset"`
+	Quantity float64  `json:"quantity"` // Total quantity across wallets
+	Price    float64  `json:"price"`    // Price of one unit in the quote currency
+	Value    float64  `json:"value"`    // Quantity * Price
+	Route    []string `json:"route"`    // Trading pairs used to derive the price, empty for the quote currency itself
+}
+
+// UnpricedAsset is an asset that could not be converted into the quote currency
+type UnpricedAsset struct {
+	Asset    Asset   `json:"asset"`
+	Quantity float64 `json:"quantity"`
+	Reason   string  `json:"reason"`
+}
+
+// PortfolioValuation is the value of a user's holdings expressed in a single quote currency
+type PortfolioValuation struct {
+	UserID        string           `json:"user_id"`
+	QuoteCurrency string           `json:"quote_currency"`
+	Assets        []AssetValuation `json:"assets"`
+	Unpriced      []UnpricedAsset  `json:"unpriced"`
+	TotalValue    float64          `json:"total_value"` // Sum of all priced asset values
+	ValuedAt      time.Time        `json:"valued_at"`
+}
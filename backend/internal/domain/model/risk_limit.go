@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// RiskLimitUtilization reports how much of each pre-trade risk limit is in use. A limit of
+// zero is disabled and its utilization is reported as zero.
+type RiskLimitUtilization struct {
+	// SymbolExposure maps each symbol with open positions to their value in the quote currency
+	SymbolExposure        map[string]float64 `json:"symbol_exposure"`
+	LargestPositionSymbol string             `json:"largest_position_symbol,omitempty"`
+	LargestPosition       float64            `json:"largest_position"`
+	MaxPositionPerSymbol  float64            `json:"max_position_per_symbol"`
+	PositionUtilization   float64            `json:"position_utilization"`
+
+	TotalExposure       float64 `json:"total_exposure"`
+	MaxTotalExposure    float64 `json:"max_total_exposure"`
+	ExposureUtilization float64 `json:"exposure_utilization"`
+
+	DailyRealizedLoss    float64 `json:"daily_realized_loss"`
+	MaxDailyLoss         float64 `json:"max_daily_loss"`
+	DailyLossUtilization float64 `json:"daily_loss_utilization"`
+	// BuysHalted is true once the daily loss limit was breached; it resets at 00:00 UTC
+	BuysHalted bool `json:"buys_halted"`
+
+	GeneratedAt time.Time `json:"generated_at"`
+}
@@ -52,6 +52,8 @@ type SystemStatus struct {
 	Version string `json:"version"`
 	// Uptime is how long the system has been running
 	Uptime string `json:"uptime"`
+	// UptimeSeconds is Uptime in seconds
+	UptimeSeconds float64 `json:"uptime_seconds"`
 	// StartedAt is when the system was started
 	StartedAt time.Time `json:"started_at"`
 	// Components contains the status of all system components
@@ -80,6 +82,12 @@ type SystemInfo struct {
 	GCPauseTotal uint64 `json:"gc_pause_total"`
 	// LastGCPause is the last GC pause time in nanoseconds
 	LastGCPause uint64 `json:"last_gc_pause"`
+	// HeapInUse is the memory in in-use heap spans in bytes
+	HeapInUse uint64 `json:"heap_in_use"`
+	// SysMemory is the memory obtained from the OS in bytes
+	SysMemory uint64 `json:"sys_memory"`
+	// NumGC is the number of completed GC cycles
+	NumGC uint32 `json:"num_gc"`
 }
 
 // ProcessControl represents a command to control a system process
@@ -165,7 +173,7 @@ func (c *ComponentStatus) SetError(err error) {
 // UpdateSystemStatus updates the overall system status based on component statuses
 func (s *SystemStatus) UpdateSystemStatus() {
 	s.LastUpdated = time.Now()
-	s.Uptime = time.Since(s.StartedAt).String()
+	s.SetUptime(s.LastUpdated)
 
 	// Determine overall status based on component statuses
 	overallStatus := StatusRunning
@@ -187,6 +195,13 @@ func (s *SystemStatus) UpdateSystemStatus() {
 	s.Status = overallStatus
 }
 
+// SetUptime sets the uptime as of now
+func (s *SystemStatus) SetUptime(now time.Time) {
+	uptime := now.Sub(s.StartedAt)
+	s.Uptime = uptime.String()
+	s.UptimeSeconds = uptime.Seconds()
+}
+
 // AddComponent adds a component to the system status
 func (s *SystemStatus) AddComponent(component *ComponentStatus) {
 	s.Components[component.Name] = component
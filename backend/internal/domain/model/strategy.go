@@ -0,0 +1,10 @@
+package model
+
+// SignalType is the action a trading strategy recommends for the latest candle
+type SignalType string
+
+const (
+	SignalBuy  SignalType = "BUY"
+	SignalSell SignalType = "SELL"
+	SignalHold SignalType = "HOLD"
+)
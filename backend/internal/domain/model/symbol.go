@@ -11,6 +11,8 @@ const (
 	SymbolStatusTrading SymbolStatus = "TRADING"
 	SymbolStatusHalt    SymbolStatus = "HALT"
 	SymbolStatusBreak   SymbolStatus = "BREAK"
+	// SymbolStatusDelisted marks a stored symbol the exchange no longer lists
+	SymbolStatusDelisted SymbolStatus = "DELISTED"
 )
 
 // Symbol represents a trading pair on an exchange
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// TrailingStop is the trailing stop of an open long position. The stop follows the highest
+// price seen since the position was first watched, at a fixed distance below it, and never
+// moves down.
+type TrailingStop struct {
+	PositionID   string    `json:"position_id"`
+	Symbol       string    `json:"symbol"`
+	HighestPrice float64   `json:"highest_price"`
+	StopPrice    float64   `json:"stop_price"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
@@ -12,6 +12,8 @@ type User struct {
 	Name      string
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	// DeactivatedAt is set once the user is deleted at the identity provider
+	DeactivatedAt *time.Time
 }
 
 // NewUser creates a new User
@@ -47,3 +49,8 @@ func (u *User) Update(name string) {
 
 	u.UpdatedAt = time.Now()
 }
+
+// IsActive reports whether the user has not been deactivated
+func (u *User) IsActive() bool {
+	return u.DeactivatedAt == nil
+}
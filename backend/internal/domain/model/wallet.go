@@ -259,3 +259,12 @@ func randomString(length int) string {
 	// This is a placeholder - in a real implementation, use a proper random string generator
 	return "abcdefgh"[:length]
 }
+
+// TokenBalance represents the balance of an ERC-20 style token held by an address
+type TokenBalance struct {
+	Contract   string  `json:"contract"`    // Token contract address
+	Symbol     string  `json:"symbol"`      // Token symbol reported by the contract
+	Decimals   uint8   `json:"decimals"`    // Number of decimals used by the token
+	RawBalance string  `json:"raw_balance"` // Balance in the token's smallest unit
+	Balance    float64 `json:"balance"`     // Balance normalized by decimals
+}
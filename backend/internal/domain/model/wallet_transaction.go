@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+)
+
+// WalletTransaction records a deposit or withdrawal, either detected as a balance change
+// while syncing a wallet or reported by the exchange's transfer history.
+// Type is TransactionTypeDeposit for increases and TransactionTypeWithdrawal for decreases.
+type WalletTransaction struct {
+	ID              string          `json:"id"`
+	UserID          string          `json:"user_id"`
+	WalletID        string          `json:"wallet_id"`
+	Type            TransactionType `json:"type"`
+	Asset           Asset           `json:"asset"`
+	Amount          float64         `json:"amount"`           // Absolute size of the change
+	PreviousBalance float64         `json:"previous_balance"` // Total balance before the sync
+	NewBalance      float64         `json:"new_balance"`      // Total balance after the sync
+	DetectedAt      time.Time       `json:"detected_at"`
+
+	// The fields below are only set for transfers reported by the exchange
+	ExternalID string            `json:"external_id,omitempty"` // Exchange's identifier of the transfer
+	Status     TransactionStatus `json:"status,omitempty"`
+	Network    string            `json:"network,omitempty"`
+	Address    string            `json:"address,omitempty"`
+	TxID       string            `json:"tx_id,omitempty"` // On-chain transaction hash
+	Fee        float64           `json:"fee,omitempty"`
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"net/netip"
+	"time"
+)
+
+// WebhookEndpoint is an HTTPS endpoint of a user that receives bot events
+type WebhookEndpoint struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+	URL    string `json:"url"`
+	// Secret signs the payloads delivered to the endpoint
+	Secret string `json:"-"`
+	// EventTypes limits the events delivered; empty means every event
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Accepts reports whether events of eventType are delivered to the endpoint
+func (e *WebhookEndpoint) Accepts(eventType string) bool {
+	if len(e.EventTypes) == 0 {
+		return true
+	}
+	for _, accepted := range e.EventTypes {
+		if accepted == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPublicWebhookAddress reports whether webhooks may be delivered to ip. Loopback,
+// private, link-local, multicast and unspecified addresses are refused so a registered
+// URL cannot reach the bot's own host or network.
+func IsPublicWebhookAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsValid() &&
+		!ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified()
+}
+
+// WebhookDeliveryStatus is the outcome of delivering an event to an endpoint
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery records the delivery of one event to one endpoint
+type WebhookDelivery struct {
+	ID          string                `json:"id"`
+	EndpointID  string                `json:"endpoint_id"`
+	EventType   string                `json:"event_type"`
+	Status      WebhookDeliveryStatus `json:"status"`
+	Attempts    int                   `json:"attempts"`
+	StatusCode  int                   `json:"status_code,omitempty"`
+	LastError   string                `json:"last_error,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+	CompletedAt *time.Time            `json:"completed_at,omitempty"`
+}
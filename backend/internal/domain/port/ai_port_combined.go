@@ -30,12 +30,19 @@ type AIService interface {
 	GenerateEmbedding(ctx context.Context, text string) (*model.AIEmbedding, error)
 }
 
+// AIStreamingService is implemented by AI services that can stream a response as it is
+// generated. onDelta is called with each new piece of content in order; returning an
+// error from it aborts the generation. The complete message is returned at the end.
+type AIStreamingService interface {
+	ChatWithHistoryStream(ctx context.Context, messages []model.AIMessage, tradingContext map[string]interface{}, onDelta func(delta string) error) (*model.AIMessage, error)
+}
+
 // ConversationMemoryRepository defines the interface for conversation memory repositories
 type ConversationMemoryRepository interface {
 	// SaveConversation saves a conversation
 	SaveConversation(ctx context.Context, conversation *model.AIConversation) error
 
-	// GetConversation gets a conversation by ID
+	// GetConversation gets a conversation by ID, returning model.ErrConversationNotFound when it does not exist
 	GetConversation(ctx context.Context, conversationID string) (*model.AIConversation, error)
 
 	// ListConversations lists conversations for a user
@@ -47,7 +54,7 @@ type ConversationMemoryRepository interface {
 	// SaveMessage saves a message
 	SaveMessage(ctx context.Context, message *model.AIMessage) error
 
-	// GetMessages gets messages for a conversation
+	// GetMessages gets messages for a conversation, newest first
 	GetMessages(ctx context.Context, conversationID string, limit, offset int) ([]*model.AIMessage, error)
 
 	// GetMessage gets a message by ID
@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+)
+
+// AuditLogger keeps a tamper-evident trail of trade and credential mutations
+type AuditLogger interface {
+	// Record appends entry to the trail, assigning its sequence, timestamp and hashes
+	Record(ctx context.Context, entry *model.AuditEntry) error
+	// Verify checks the whole trail's hash chain
+	Verify(ctx context.Context) (*model.AuditVerification, error)
+}
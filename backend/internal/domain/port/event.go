@@ -13,3 +13,24 @@ type EventRepository interface {
 	// GetEvents retrieves events for a specific coin
 	GetEvents(ctx context.Context, coinID string, limit, offset int) ([]*model.NewCoinEvent, error)
 }
+
+// EventStore is an append-only log of published NewCoinEvents that subscribers can replay
+type EventStore interface {
+	// Append records the event and returns the offset assigned to it
+	Append(ctx context.Context, event *model.NewCoinEvent) (int64, error)
+	// ReadFrom returns up to limit events with an offset greater than or equal to offset,
+	// in offset order
+	ReadFrom(ctx context.Context, offset int64, limit int) ([]*model.StoredNewCoinEvent, error)
+}
+
+// ScheduledListingRepository persists pending pre-listing alerts so they survive restarts
+type ScheduledListingRepository interface {
+	// Save creates or replaces a scheduled listing
+	Save(ctx context.Context, listing *model.ScheduledListing) error
+	// Get returns the scheduled listing with the given ID, or nil when there is none
+	Get(ctx context.Context, id string) (*model.ScheduledListing, error)
+	// Delete removes a scheduled listing once it is no longer needed
+	Delete(ctx context.Context, id string) error
+	// GetPending returns the scheduled listings whose alert has not fired, ordered by alert time
+	GetPending(ctx context.Context) ([]*model.ScheduledListing, error)
+}
@@ -1,6 +1,11 @@
 package port
 
-import "github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+import (
+	"context"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+)
 
 // EventBus defines the interface for an event bus system
 type EventBus interface {
@@ -13,3 +18,34 @@ type EventBus interface {
 	// Unsubscribe removes a listener
 	Unsubscribe(listener func(*model.NewCoinEvent))
 }
+
+// ReplayableEventBus is an EventBus that records events before fan-out so that
+// subscribers started later can catch up
+type ReplayableEventBus interface {
+	EventBus
+
+	// SubscribeFrom replays stored events from offset to the listener and then keeps it
+	// subscribed to new events
+	SubscribeFrom(ctx context.Context, offset int64, listener func(*model.NewCoinEvent)) error
+}
+
+// Subscription is a handle to an active topic subscription
+type Subscription interface {
+	// Unsubscribe stops delivery to the subscriber. It is safe to call more than once.
+	Unsubscribe()
+}
+
+// DomainEventPublisher publishes domain events
+type DomainEventPublisher interface {
+	// PublishEvent delivers the event to every subscriber of its type
+	PublishEvent(evt event.DomainEvent)
+}
+
+// DomainEventBus routes domain events to subscribers by event type
+type DomainEventBus interface {
+	DomainEventPublisher
+
+	// SubscribeTopics registers a handler for the given event types. With no types the
+	// handler receives every event.
+	SubscribeTopics(handler func(event.DomainEvent), topics ...event.EventType) Subscription
+}
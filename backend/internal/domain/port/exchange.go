@@ -0,0 +1,61 @@
+package port
+
+import (
+	"context"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+)
+
+// ExchangeMarketData retrieves public market data from an exchange
+type ExchangeMarketData interface {
+	// GetExchangeInfo retrieves information about all symbols on the exchange
+	GetExchangeInfo(ctx context.Context) (*model.ExchangeInfo, error)
+
+	// GetSymbolInfo retrieves detailed information about a trading symbol
+	GetSymbolInfo(ctx context.Context, symbol string) (*model.SymbolInfo, error)
+
+	// GetMarketData retrieves the 24h ticker of a symbol
+	GetMarketData(ctx context.Context, symbol string) (*model.Ticker, error)
+
+	// GetKlines retrieves the latest candles of a symbol
+	GetKlines(ctx context.Context, symbol string, interval model.KlineInterval, limit int) ([]*model.Kline, error)
+
+	// GetOrderBook retrieves the order book of a symbol
+	GetOrderBook(ctx context.Context, symbol string, depth int) (*model.OrderBook, error)
+}
+
+// ExchangeTrading places and tracks orders and reads the account on an exchange
+type ExchangeTrading interface {
+	// GetAccount retrieves the account balances
+	GetAccount(ctx context.Context) (*model.Wallet, error)
+
+	PlaceOrder(ctx context.Context, symbol string, side model.OrderSide, orderType model.OrderType, quantity float64, price float64, timeInForce model.TimeInForce) (*model.Order, error)
+	CancelOrder(ctx context.Context, symbol string, orderID string) error
+	GetOrderStatus(ctx context.Context, symbol string, orderID string) (*model.Order, error)
+	GetOpenOrders(ctx context.Context, symbol string) ([]*model.Order, error)
+	GetOrderHistory(ctx context.Context, symbol string, limit, offset int) ([]*model.Order, error)
+}
+
+// ExchangeClient is the exchange-agnostic client the trading and market services use
+type ExchangeClient interface {
+	ExchangeMarketData
+	ExchangeTrading
+}
+
+// ExchangeRegistry looks up exchange clients by exchange name, e.g. "mexc"
+type ExchangeRegistry interface {
+	// Register adds an exchange that supports market data and trading
+	Register(name string, client ExchangeClient)
+
+	// RegisterMarketData adds an exchange that only serves market data
+	RegisterMarketData(name string, client ExchangeMarketData)
+
+	// Client returns the trading client of an exchange
+	Client(name string) (ExchangeClient, error)
+
+	// MarketData returns the market data client of an exchange
+	MarketData(name string) (ExchangeMarketData, error)
+
+	// Names lists the registered exchanges in alphabetical order
+	Names() []string
+}
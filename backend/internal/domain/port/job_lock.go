@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+// JobLockRepository grants named locks to one owner at a time, so a scheduled job runs on
+// a single instance when several are deployed. A lock expires once its TTL passes without
+// being renewed, letting another instance take over from one that died.
+type JobLockRepository interface {
+	// Acquire takes the lock for owner for ttl when it is free, expired or already held by
+	// owner, and reports whether owner now holds it
+	Acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error)
+	// Renew extends owner's lock by ttl, reporting false when owner no longer holds it
+	Renew(ctx context.Context, name, owner string, ttl time.Duration) (bool, error)
+	// Release frees the lock when owner holds it
+	Release(ctx context.Context, name, owner string) error
+}
@@ -18,14 +18,27 @@ type SymbolRepository interface {
 	// GetByExchange returns all Symbols from a specific exchange
 	GetByExchange(ctx context.Context, exchange string) ([]*market.Symbol, error)
 
-	// GetAll returns all available Symbols
+	// GetAll returns all available Symbols, excluding soft-deleted ones
 	GetAll(ctx context.Context) ([]*market.Symbol, error)
 
+	// GetAllIncludingDeleted returns all Symbols, including soft-deleted ones
+	GetAllIncludingDeleted(ctx context.Context) ([]*market.Symbol, error)
+
 	// Update updates an existing Symbol
 	Update(ctx context.Context, symbol *market.Symbol) error
 
-	// Delete removes a Symbol
+	// Upsert creates a Symbol or updates the existing one with the same symbol and exchange
+	Upsert(ctx context.Context, symbol *market.Symbol) error
+
+	// UpsertMany upserts symbols in a single transaction and reports how many were created
+	// and how many already existed
+	UpsertMany(ctx context.Context, symbols []*market.Symbol) (created, updated int, err error)
+
+	// Delete soft-deletes a Symbol, keeping its row for the history that references it
 	Delete(ctx context.Context, symbol string) error
+
+	// Restore reverts the soft-delete of a Symbol
+	Restore(ctx context.Context, symbol string) error
 }
 
 // MarketRepository defines methods for storing and retrieving market data
@@ -54,6 +67,10 @@ type MarketRepository interface {
 	// GetCandles retrieves candles for a symbol within a time range
 	GetCandles(ctx context.Context, symbol, exchange string, interval market.Interval, start, end time.Time, limit int) ([]*market.Candle, error)
 
+	// GetResampledCandles reads source candles within a time range and aggregates them
+	// into candles of the longer target interval
+	GetResampledCandles(ctx context.Context, symbol, exchange string, source, target market.Interval, start, end time.Time) ([]*market.Candle, error)
+
 	// GetLatestCandle retrieves the most recent candle for a symbol and interval
 	GetLatestCandle(ctx context.Context, symbol, exchange string, interval market.Interval) (*market.Candle, error)
 
@@ -68,4 +85,9 @@ type MarketRepository interface {
 
 	// GetOrderBook retrieves the order book for a symbol
 	GetOrderBook(ctx context.Context, symbol, exchange string, depth int) (*market.OrderBook, error)
+
+	// SearchSymbols finds symbols whose pair, base asset or quote asset contains query,
+	// ignoring case. Exact matches rank before prefix matches, which rank before other
+	// substring matches.
+	SearchSymbols(ctx context.Context, query string, limit int) ([]*market.Symbol, error)
 }
@@ -2,18 +2,19 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
 )
 
-// MEXCClient defines the interface for interacting with MEXC exchange API
+// MEXCClient defines the interface for interacting with MEXC exchange API. Beyond the
+// exchange-agnostic ExchangeClient it exposes MEXC's listing and schedule data.
 type MEXCClient interface {
+	ExchangeClient
+
 	// GetNewListings retrieves information about newly listed coins
 	GetNewListings(ctx context.Context) ([]*model.NewCoin, error)
 
-	// GetSymbolInfo retrieves detailed information about a trading symbol
-	GetSymbolInfo(ctx context.Context, symbol string) (*model.SymbolInfo, error) // Changed return type
-
 	// GetSymbolStatus checks if a symbol is currently tradeable
 	GetSymbolStatus(ctx context.Context, symbol string) (model.Status, error)
 
@@ -22,25 +23,27 @@ type MEXCClient interface {
 
 	// GetSymbolConstraints retrieves trading constraints for a symbol
 	GetSymbolConstraints(ctx context.Context, symbol string) (*model.SymbolConstraints, error)
+}
 
-	// GetExchangeInfo retrieves information about all symbols on the exchange
-	GetExchangeInfo(ctx context.Context) (*model.ExchangeInfo, error) // Assuming model.ExchangeInfo exists or needs creation
-
-	// GetMarketData retrieves ticker data (added based on market_data_service usage)
-	GetMarketData(ctx context.Context, symbol string) (*model.Ticker, error)
-
-	// GetKlines retrieves candle data (added based on market_data_service usage)
-	GetKlines(ctx context.Context, symbol string, interval model.KlineInterval, limit int) ([]*model.Kline, error)
+// KlineHistoryProvider retrieves historical candle data for a time range
+type KlineHistoryProvider interface {
+	// GetKlinesRange retrieves at most limit klines opening between start and end, oldest first
+	GetKlinesRange(ctx context.Context, symbol string, interval model.KlineInterval, start, end time.Time, limit int) ([]*model.Kline, error)
+}
 
-	// GetOrderBook retrieves order book data (added based on market_data_service usage)
-	GetOrderBook(ctx context.Context, symbol string, depth int) (*model.OrderBook, error)
+// TickerBatchProvider retrieves the tickers of many symbols at once
+type TickerBatchProvider interface {
+	// GetTickers retrieves the 24h tickers of symbols, leaving out unknown ones
+	GetTickers(ctx context.Context, symbols []string) ([]*model.Ticker, error)
+}
 
-	// GetAccount retrieves account information from MEXC (added back from old MexcAPI)
-	GetAccount(ctx context.Context) (*model.Wallet, error)
-	// Trading Methods (merged from MexcAPI)
-	PlaceOrder(ctx context.Context, symbol string, side model.OrderSide, orderType model.OrderType, quantity float64, price float64, timeInForce model.TimeInForce) (*model.Order, error)
-	CancelOrder(ctx context.Context, symbol string, orderID string) error
-	GetOrderStatus(ctx context.Context, symbol string, orderID string) (*model.Order, error)
-	GetOpenOrders(ctx context.Context, symbol string) ([]*model.Order, error)
-	GetOrderHistory(ctx context.Context, symbol string, limit, offset int) ([]*model.Order, error)
+// CapitalHistoryProvider retrieves the deposits and withdrawals of an exchange account.
+// The transactions returned carry no user or wallet; ExternalID identifies each transfer.
+type CapitalHistoryProvider interface {
+	// GetDepositHistory retrieves deposits of asset made between start and end. An empty
+	// asset covers every asset, and zero times leave the range to the exchange's default.
+	GetDepositHistory(ctx context.Context, asset string, start, end time.Time) ([]*model.WalletTransaction, error)
+	// GetWithdrawHistory retrieves withdrawals of asset applied for between start and end,
+	// with the same defaults as GetDepositHistory
+	GetWithdrawHistory(ctx context.Context, asset string, start, end time.Time) ([]*model.WalletTransaction, error)
 }
@@ -0,0 +1,9 @@
+package port
+
+import "github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+
+// OrderEventPublisher defines the interface for publishing order status transitions
+type OrderEventPublisher interface {
+	// PublishOrderEvent notifies subscribers that an order changed status
+	PublishOrderEvent(evt *event.OrderStatusChanged)
+}
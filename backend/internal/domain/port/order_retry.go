@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+)
+
+// OrderRetryRepository persists order requests waiting to be placed again, so they survive
+// restarts
+type OrderRetryRepository interface {
+	// Save creates the retry or replaces its stored state
+	Save(ctx context.Context, retry *model.OrderRetry) error
+	// ListDue returns up to limit pending retries due at or before now, the earliest first
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*model.OrderRetry, error)
+}
@@ -13,10 +13,18 @@ type OrderRepository interface {
 	Create(ctx context.Context, order *model.Order) error
 	GetByID(ctx context.Context, id string) (*model.Order, error)
 	GetByClientOrderID(ctx context.Context, clientOrderID string) (*model.Order, error)
+	// GetByOrderID returns the order with the given exchange order ID, or nil when there is none
+	GetByOrderID(ctx context.Context, orderID string) (*model.Order, error)
 	Update(ctx context.Context, order *model.Order) error
 	GetBySymbol(ctx context.Context, symbol string, limit, offset int) ([]*model.Order, error)
+	// GetBySymbolBefore returns up to limit orders of a user for a symbol, newest first, that
+	// come strictly after the cursor position. A nil cursor starts at the newest order.
+	GetBySymbolBefore(ctx context.Context, userID, symbol string, cursor *model.OrderCursor, limit int) ([]*model.Order, error)
 	GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*model.Order, error)
 	GetByStatus(ctx context.Context, status model.OrderStatus, limit, offset int) ([]*model.Order, error)
+	// Query returns the page of orders matching filter, newest first, together with the
+	// number of matching orders regardless of the page.
+	Query(ctx context.Context, filter model.OrderFilter) ([]*model.Order, int64, error)
 	Count(ctx context.Context, filters map[string]interface{}) (int64, error)
 	Delete(ctx context.Context, id string) error
 }
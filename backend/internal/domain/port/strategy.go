@@ -0,0 +1,20 @@
+package port
+
+import "github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+
+// Strategy produces trading signals from a stream of closed candles
+type Strategy interface {
+	// Name returns the registered name of the strategy
+	Name() string
+	// OnCandle processes the next closed candle and returns the resulting signal
+	OnCandle(candle *model.Kline) model.SignalType
+}
+
+// LookbackStrategy is a Strategy that needs a number of candles before it can signal, such
+// as the slow period of a moving-average crossover
+type LookbackStrategy interface {
+	Strategy
+	// Lookback returns how many candles the strategy must see before its next candle can
+	// produce a signal
+	Lookback() int
+}
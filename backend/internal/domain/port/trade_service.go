@@ -25,4 +25,7 @@ type TradeService interface {
 
 	// CalculateRequiredQuantity calculates the required quantity for an order based on amount
 	CalculateRequiredQuantity(ctx context.Context, symbol string, side model.OrderSide, amount float64) (float64, error)
+
+	// CalculateQuantityForRisk sizes an order so that hitting stopPrice loses riskPct percent of account equity
+	CalculateQuantityForRisk(ctx context.Context, symbol string, side model.OrderSide, riskPct, stopPrice float64) (float64, error)
 }
@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+)
+
+// TrailingStopRepository persists the state of trailing stops so they survive restarts
+type TrailingStopRepository interface {
+	Save(ctx context.Context, stop *model.TrailingStop) error
+	// GetAll returns every stored trailing stop
+	GetAll(ctx context.Context) ([]*model.TrailingStop, error)
+	Delete(ctx context.Context, positionID string) error
+}
@@ -15,3 +15,18 @@ type TransactionManager interface {
 	// WithTransaction executes the given function within a transaction
 	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
+
+// TxRepositories provides repositories whose operations all run in the transaction of a
+// unit of work
+type TxRepositories interface {
+	APICredentials() APICredentialRepository
+	Orders() OrderRepository
+	Symbols() SymbolRepository
+}
+
+// UnitOfWork runs operations spanning several repositories atomically
+type UnitOfWork interface {
+	// Do runs fn in a transaction. The transaction commits when fn returns nil and rolls
+	// back when it returns an error or panics.
+	Do(ctx context.Context, fn func(ctx context.Context, repos TxRepositories) error) error
+}
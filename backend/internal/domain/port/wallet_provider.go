@@ -51,3 +51,16 @@ type Web3WalletProvider interface {
 	// SignMessage signs a message with the wallet's private key
 	SignMessage(ctx context.Context, message string) (string, error)
 }
+
+// TokenBalanceProvider is implemented by Web3 providers that can read token balances
+type TokenBalanceProvider interface {
+	// GetTokenBalances returns the balances of the given token contracts held by address
+	GetTokenBalances(ctx context.Context, address string, tokenContracts []string) ([]model.TokenBalance, error)
+}
+
+// ENSResolver resolves Ethereum Name Service names to addresses
+type ENSResolver interface {
+	// ResolveENS returns the address a name such as "vitalik.eth" points to, or
+	// model.ErrENSNameNotFound when the name has no address record
+	ResolveENS(ctx context.Context, name string) (string, error)
+}
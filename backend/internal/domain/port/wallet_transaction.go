@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+)
+
+// WalletTransactionRepository persists balance changes detected during wallet syncs and
+// transfers reported by exchanges
+type WalletTransactionRepository interface {
+	Save(ctx context.Context, tx *model.WalletTransaction) error
+	GetByWalletID(ctx context.Context, walletID string, limit, offset int) ([]*model.WalletTransaction, error)
+}
+
+// BalanceChangeNotifier notifies users about detected deposits and withdrawals
+type BalanceChangeNotifier interface {
+	NotifyBalanceChange(ctx context.Context, tx *model.WalletTransaction) error
+}
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+)
+
+// WebhookEndpointRepository stores the webhook endpoints users register
+type WebhookEndpointRepository interface {
+	Save(ctx context.Context, endpoint *model.WebhookEndpoint) error
+	// GetByID returns the endpoint, or nil if there is none
+	GetByID(ctx context.Context, id string) (*model.WebhookEndpoint, error)
+	ListByUser(ctx context.Context, userID string) ([]*model.WebhookEndpoint, error)
+	// ListActive returns the active endpoints of every user
+	ListActive(ctx context.Context) ([]*model.WebhookEndpoint, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// WebhookDeliveryRepository records the status of webhook deliveries
+type WebhookDeliveryRepository interface {
+	Save(ctx context.Context, delivery *model.WebhookDelivery) error
+	// ListByEndpoint returns the latest deliveries to an endpoint, newest first
+	ListByEndpoint(ctx context.Context, endpointID string, limit int) ([]*model.WebhookDelivery, error)
+}
@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+)
+
+// WebhookEventRepository remembers the inbound webhook events already handled, so
+// deliveries the sender retries or replays are applied only once
+type WebhookEventRepository interface {
+	// Seen reports whether the event of source with eventID was handled
+	Seen(ctx context.Context, source, eventID string) (bool, error)
+	// Record marks the event as handled
+	Record(ctx context.Context, source, eventID, eventType string) error
+}
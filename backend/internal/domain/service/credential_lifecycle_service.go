@@ -14,6 +14,7 @@ import (
 // CredentialLifecycleService handles the lifecycle of API credentials
 type CredentialLifecycleService struct {
 	credentialRepo    port.APICredentialRepository
+	unitOfWork        port.UnitOfWork
 	encryptionService crypto.EncryptionService
 	validationService *CredentialValidationService
 	errorService      *CredentialErrorService
@@ -24,6 +25,7 @@ type CredentialLifecycleService struct {
 // NewCredentialLifecycleService creates a new CredentialLifecycleService
 func NewCredentialLifecycleService(
 	credentialRepo port.APICredentialRepository,
+	unitOfWork port.UnitOfWork,
 	encryptionService crypto.EncryptionService,
 	validationService *CredentialValidationService,
 	errorService *CredentialErrorService,
@@ -32,6 +34,7 @@ func NewCredentialLifecycleService(
 ) *CredentialLifecycleService {
 	return &CredentialLifecycleService{
 		credentialRepo:    credentialRepo,
+		unitOfWork:        unitOfWork,
 		encryptionService: encryptionService,
 		validationService: validationService,
 		errorService:      errorService,
@@ -429,20 +432,25 @@ func (s *CredentialLifecycleService) RotateCredential(ctx context.Context, id, n
 		UpdatedAt:    newCredential.UpdatedAt,
 	}
 
-	// Save the new credential
-	if err := s.credentialRepo.Save(ctx, encryptedCredential); err != nil {
+	// Save the new credential and revoke the old one atomically, so a failure cannot leave
+	// both active or neither
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context, repos port.TxRepositories) error {
+		if err := repos.APICredentials().Save(ctx, encryptedCredential); err != nil {
+			return fmt.Errorf("failed to save rotated credential: %w", err)
+		}
+		if err := repos.APICredentials().UpdateStatus(ctx, existingCredential.ID, model.APICredentialStatusRevoked); err != nil {
+			return fmt.Errorf("failed to revoke old credential: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
 		s.loggingService.LogCredentialCreate(ctx, newCredential, time.Since(startTime), err)
 		return nil, s.errorService.HandleError(ctx, err, newCredential.ID, newCredential.UserID, newCredential.Exchange)
 	}
 
-	// Update the status of the old credential to revoked
 	oldStatus := existingCredential.Status
 	existingCredential.Status = model.APICredentialStatusRevoked
-	if err := s.credentialRepo.UpdateStatus(ctx, existingCredential.ID, model.APICredentialStatusRevoked); err != nil {
-		s.logger.Error().Err(err).Str("id", existingCredential.ID).Msg("Failed to update old credential status to revoked")
-	} else {
-		s.loggingService.LogCredentialStatusChange(ctx, existingCredential, oldStatus, time.Since(startTime), nil)
-	}
+	s.loggingService.LogCredentialStatusChange(ctx, existingCredential, oldStatus, time.Since(startTime), nil)
 
 	// Log the operation
 	s.loggingService.LogCredentialCreate(ctx, newCredential, time.Since(startTime), nil)
@@ -18,7 +18,7 @@ type MarketDataService struct {
 	marketRepo  port.MarketRepository
 	symbolRepo  port.SymbolRepository
 	cache       port.MarketCache
-	mexcClient  port.MEXCClient // Changed mexcAPI to mexcClient
+	mexcClient  port.ExchangeClient
 	logger      *zerolog.Logger
 	refreshLock sync.Mutex
 }
@@ -28,7 +28,7 @@ func NewMarketDataService(
 	marketRepo port.MarketRepository,
 	symbolRepo port.SymbolRepository,
 	cache port.MarketCache,
-	mexcClient port.MEXCClient, // Changed mexcAPI to mexcClient
+	mexcClient port.ExchangeClient,
 	logger *zerolog.Logger,
 ) *MarketDataService {
 	return &MarketDataService{
@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// Default order retry settings used when non-positive values are supplied
+const (
+	defaultOrderRetryMaxAttempts    = 5
+	defaultOrderRetryInitialBackoff = 5 * time.Second
+	defaultOrderRetryMaxBackoff     = 5 * time.Minute
+	defaultOrderRetryPollInterval   = 5 * time.Second
+	defaultOrderRetryBatchSize      = 20
+)
+
+// ErrOrderQueuedForRetry is returned, wrapping the exchange error, when an order was
+// rejected with a transient error and queued to be placed again
+var ErrOrderQueuedForRetry = errors.New("order queued for retry")
+
+// OrderRetryOptions configures an OrderRetryQueue. Non-positive values use the defaults.
+type OrderRetryOptions struct {
+	MaxAttempts    int           // Placements tried before an order is given up, including the first
+	InitialBackoff time.Duration // Wait before the first retry, doubled after every retry
+	MaxBackoff     time.Duration // Longest wait between two retries
+	PollInterval   time.Duration // Time between checks for due retries
+	BatchSize      int           // Maximum retries placed in one pass
+}
+
+// IsRetryableOrderError reports whether an order was rejected with a transient exchange
+// error, such as rate limiting or maintenance, so the same order may be placed again.
+// Errors that do not say they are retryable are not, as the order may have reached the
+// exchange.
+func IsRetryableOrderError(err error) bool {
+	var retryable interface{ IsRetryable() bool }
+	return errors.As(err, &retryable) && retryable.IsRetryable()
+}
+
+// OrderRetryQueue places orders through a TradeService and keeps the orders rejected with a
+// transient exchange error to place them again with exponential backoff. An order still
+// rejected after MaxAttempts, or rejected with a permanent error on a retry, is marked
+// failed and an OrderFailed event is published.
+type OrderRetryQueue struct {
+	port.TradeService
+	repo     port.OrderRetryRepository
+	events   port.DomainEventPublisher
+	options  OrderRetryOptions
+	logger   *zerolog.Logger
+	now      func() time.Time
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+	running  bool
+	mutex    sync.Mutex
+}
+
+// NewOrderRetryQueue creates a new OrderRetryQueue placing orders through trades. The event
+// publisher may be nil when no one is interested in failed orders.
+func NewOrderRetryQueue(
+	trades port.TradeService,
+	repo port.OrderRetryRepository,
+	events port.DomainEventPublisher,
+	options OrderRetryOptions,
+	logger *zerolog.Logger,
+) *OrderRetryQueue {
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = defaultOrderRetryMaxAttempts
+	}
+	if options.InitialBackoff <= 0 {
+		options.InitialBackoff = defaultOrderRetryInitialBackoff
+	}
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = defaultOrderRetryMaxBackoff
+	}
+	if options.PollInterval <= 0 {
+		options.PollInterval = defaultOrderRetryPollInterval
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultOrderRetryBatchSize
+	}
+
+	return &OrderRetryQueue{
+		TradeService: trades,
+		repo:         repo,
+		events:       events,
+		options:      options,
+		logger:       logger,
+		now:          time.Now,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// PlaceOrder places the order. When the exchange rejects it with a transient error the
+// order is queued to be placed again and the returned error wraps both
+// ErrOrderQueuedForRetry and the exchange error. Other errors are returned as they are.
+func (q *OrderRetryQueue) PlaceOrder(ctx context.Context, request *model.OrderRequest) (*model.OrderResponse, error) {
+	response, err := q.TradeService.PlaceOrder(ctx, request)
+	if err == nil || !IsRetryableOrderError(err) || q.options.MaxAttempts < 2 {
+		return response, err
+	}
+
+	now := q.now()
+	retry := &model.OrderRetry{
+		ID:            uuid.New().String(),
+		Request:       *request,
+		Status:        model.OrderRetryPending,
+		Attempts:      1,
+		NextAttemptAt: now.Add(q.backoff(1)),
+		LastError:     err.Error(),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if saveErr := q.repo.Save(ctx, retry); saveErr != nil {
+		q.logger.Error().Err(saveErr).
+			Str("symbol", request.Symbol).
+			Msg("Failed to queue order for retry")
+		return nil, err
+	}
+
+	q.logger.Warn().Err(err).
+		Str("retryID", retry.ID).
+		Str("symbol", request.Symbol).
+		Time("nextAttemptAt", retry.NextAttemptAt).
+		Msg("Order rejected with a transient error, queued for retry")
+	return nil, fmt.Errorf("%w: %w", ErrOrderQueuedForRetry, err)
+}
+
+// Start starts the retry loop. The loop stops when ctx is canceled or Stop is called.
+func (q *OrderRetryQueue) Start(ctx context.Context) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if q.running {
+		q.logger.Warn().Msg("Order retry queue is already running")
+		return
+	}
+
+	q.running = true
+	q.stopChan = make(chan struct{})
+	q.wg.Add(1)
+
+	go q.run(ctx)
+
+	q.logger.Info().
+		Dur("pollInterval", q.options.PollInterval).
+		Int("maxAttempts", q.options.MaxAttempts).
+		Msg("Order retry queue started")
+}
+
+// Stop stops the retry loop and waits for the current pass to finish
+func (q *OrderRetryQueue) Stop() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if !q.running {
+		return
+	}
+
+	close(q.stopChan)
+	q.wg.Wait()
+	q.running = false
+
+	q.logger.Info().Msg("Order retry queue stopped")
+}
+
+// run executes retry passes until stopped
+func (q *OrderRetryQueue) run(ctx context.Context) {
+	defer q.wg.Done()
+
+	ticker := time.NewTicker(q.options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.stopChan:
+			return
+		case <-ticker.C:
+			if _, err := q.RetryDue(ctx); err != nil {
+				q.logger.Error().Err(err).Msg("Order retry pass failed")
+			}
+		}
+	}
+}
+
+// RetryDue places again up to BatchSize orders whose retry is due and returns the number
+// placed successfully
+func (q *OrderRetryQueue) RetryDue(ctx context.Context) (int, error) {
+	retries, err := q.repo.ListDue(ctx, q.now(), q.options.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	placed := 0
+	for _, retry := range retries {
+		if ctx.Err() != nil {
+			return placed, ctx.Err()
+		}
+		if q.retry(ctx, retry) {
+			placed++
+		}
+	}
+	return placed, nil
+}
+
+// retry places a queued order once more, records the outcome, and reports whether the
+// order was placed
+func (q *OrderRetryQueue) retry(ctx context.Context, retry *model.OrderRetry) bool {
+	request := retry.Request
+	response, err := q.TradeService.PlaceOrder(ctx, &request)
+	retry.Attempts++
+	retry.UpdatedAt = q.now()
+
+	switch {
+	case err == nil:
+		retry.Status = model.OrderRetrySucceeded
+		retry.LastError = ""
+		if response != nil {
+			retry.OrderID = response.OrderID
+		}
+		q.logger.Info().
+			Str("retryID", retry.ID).
+			Str("orderID", retry.OrderID).
+			Int("attempts", retry.Attempts).
+			Msg("Queued order placed")
+	case IsRetryableOrderError(err) && retry.Attempts < q.options.MaxAttempts:
+		retry.LastError = err.Error()
+		retry.NextAttemptAt = retry.UpdatedAt.Add(q.backoff(retry.Attempts))
+	default:
+		retry.Status = model.OrderRetryFailed
+		retry.LastError = err.Error()
+		q.logger.Error().Err(err).
+			Str("retryID", retry.ID).
+			Str("symbol", retry.Request.Symbol).
+			Int("attempts", retry.Attempts).
+			Msg("Giving up on queued order")
+	}
+
+	if saveErr := q.repo.Save(ctx, retry); saveErr != nil {
+		q.logger.Error().Err(saveErr).Str("retryID", retry.ID).Msg("Failed to save order retry")
+	}
+	if retry.Status == model.OrderRetryFailed && q.events != nil {
+		q.events.PublishEvent(event.NewOrderFailed(retry))
+	}
+	return err == nil
+}
+
+// backoff returns the wait after the given number of attempts: InitialBackoff doubled for
+// every attempt after the first, up to MaxBackoff
+func (q *OrderRetryQueue) backoff(attempts int) time.Duration {
+	wait := q.options.InitialBackoff
+	for i := 1; i < attempts && wait < q.options.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, q.options.MaxBackoff)
+}
+
+// Ensure OrderRetryQueue implements port.TradeService
+var _ port.TradeService = (*OrderRetryQueue)(nil)
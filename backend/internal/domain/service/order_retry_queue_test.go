@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/pkg/platform/mexc"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedTrades answers order placements with the scripted errors in turn, then accepts
+type scriptedTrades struct {
+	port.TradeService
+	errs  []error
+	calls int
+}
+
+func (s *scriptedTrades) PlaceOrder(ctx context.Context, request *model.OrderRequest) (*model.OrderResponse, error) {
+	s.calls++
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		return nil, fmt.Errorf("failed to place order: %w", err)
+	}
+	return &model.OrderResponse{Order: model.Order{OrderID: "mexc-1", Symbol: request.Symbol}, IsSuccess: true}, nil
+}
+
+// memoryRetries keeps order retries in memory
+type memoryRetries struct {
+	retries map[string]model.OrderRetry
+}
+
+func (m *memoryRetries) Save(ctx context.Context, retry *model.OrderRetry) error {
+	m.retries[retry.ID] = *retry
+	return nil
+}
+
+func (m *memoryRetries) ListDue(ctx context.Context, now time.Time, limit int) ([]*model.OrderRetry, error) {
+	var due []*model.OrderRetry
+	for _, retry := range m.retries {
+		if retry.Status == model.OrderRetryPending && !retry.NextAttemptAt.After(now) && len(due) < limit {
+			retry := retry
+			due = append(due, &retry)
+		}
+	}
+	return due, nil
+}
+
+// publishedEvents records every published event
+type publishedEvents struct {
+	events []event.DomainEvent
+}
+
+func (p *publishedEvents) PublishEvent(evt event.DomainEvent) {
+	p.events = append(p.events, evt)
+}
+
+func newTestOrderRetryQueue(trades port.TradeService) (*OrderRetryQueue, *memoryRetries, *publishedEvents, *time.Time) {
+	logger := zerolog.Nop()
+	retries := &memoryRetries{retries: map[string]model.OrderRetry{}}
+	events := &publishedEvents{}
+	queue := NewOrderRetryQueue(trades, retries, events, OrderRetryOptions{
+		MaxAttempts:    3,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+	}, &logger)
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	queue.now = func() time.Time { return now }
+	return queue, retries, events, &now
+}
+
+var testOrderRequest = model.OrderRequest{
+	UserID:   "user-1",
+	Symbol:   "BTCUSDT",
+	Side:     model.OrderSideBuy,
+	Type:     model.OrderTypeMarket,
+	Quantity: 0.5,
+}
+
+func TestOrderRetryQueue_RetriesUntilPlaced(t *testing.T) {
+	ctx := context.Background()
+	rateLimited := &mexc.APIError{StatusCode: 429, Code: 429, Message: "Too many requests"}
+	maintenance := &mexc.APIError{StatusCode: 503}
+	trades := &scriptedTrades{errs: []error{rateLimited, maintenance}}
+	queue, retries, events, now := newTestOrderRetryQueue(trades)
+
+	request := testOrderRequest
+	_, err := queue.PlaceOrder(ctx, &request)
+	require.ErrorIs(t, err, ErrOrderQueuedForRetry)
+	require.ErrorAs(t, err, new(*mexc.APIError))
+	require.Len(t, retries.retries, 1)
+
+	// Nothing is due before the backoff has passed
+	placed, err := queue.RetryDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, placed)
+	assert.Equal(t, 1, trades.calls)
+
+	// The first retry hits maintenance and waits twice as long before the next
+	*now = now.Add(time.Second)
+	placed, err = queue.RetryDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, placed)
+	for _, retry := range retries.retries {
+		assert.Equal(t, model.OrderRetryPending, retry.Status)
+		assert.Equal(t, 2, retry.Attempts)
+		assert.Equal(t, now.Add(2*time.Second), retry.NextAttemptAt)
+	}
+
+	*now = now.Add(2 * time.Second)
+	placed, err = queue.RetryDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, placed)
+	assert.Equal(t, 3, trades.calls)
+	for _, retry := range retries.retries {
+		assert.Equal(t, model.OrderRetrySucceeded, retry.Status)
+		assert.Equal(t, 3, retry.Attempts)
+		assert.Equal(t, "mexc-1", retry.OrderID)
+		assert.Equal(t, testOrderRequest, retry.Request)
+	}
+	assert.Empty(t, events.events)
+}
+
+func TestOrderRetryQueue_NonRetryableErrorIsNotQueued(t *testing.T) {
+	ctx := context.Background()
+	insufficient := &mexc.APIError{StatusCode: 400, Code: 30004, Message: "Insufficient position"}
+	trades := &scriptedTrades{errs: []error{insufficient, errors.New("connection reset")}}
+	queue, retries, _, _ := newTestOrderRetryQueue(trades)
+
+	request := testOrderRequest
+	_, err := queue.PlaceOrder(ctx, &request)
+	require.ErrorAs(t, err, new(*mexc.APIError))
+	assert.NotErrorIs(t, err, ErrOrderQueuedForRetry)
+
+	// Errors that do not say they are transient may have reached the exchange
+	_, err = queue.PlaceOrder(ctx, &request)
+	assert.NotErrorIs(t, err, ErrOrderQueuedForRetry)
+	assert.Empty(t, retries.retries)
+	assert.Equal(t, 2, trades.calls)
+}
+
+func TestOrderRetryQueue_GivesUpAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	rateLimited := &mexc.APIError{StatusCode: 429}
+	trades := &scriptedTrades{errs: []error{rateLimited, rateLimited, rateLimited}}
+	queue, retries, events, now := newTestOrderRetryQueue(trades)
+
+	request := testOrderRequest
+	_, err := queue.PlaceOrder(ctx, &request)
+	require.ErrorIs(t, err, ErrOrderQueuedForRetry)
+
+	for i := 0; i < 3; i++ {
+		*now = now.Add(time.Minute)
+		_, err = queue.RetryDue(ctx)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 3, trades.calls, "a failed order is not retried again")
+	require.Len(t, events.events, 1)
+	failed, ok := events.events[0].(*event.OrderFailed)
+	require.True(t, ok)
+	assert.Equal(t, model.OrderRetryFailed, failed.Retry.Status)
+	assert.Equal(t, 3, failed.Retry.Attempts)
+	assert.Contains(t, failed.Retry.LastError, "request failed with status 429")
+	assert.Equal(t, model.OrderRetryFailed, retries.retries[failed.Retry.ID].Status)
+}
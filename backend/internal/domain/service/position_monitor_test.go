@@ -169,6 +169,24 @@ func (m *MockTradeUseCase) CalculateRequiredQuantity(ctx context.Context, symbol
 	return args.Get(0).(float64), args.Error(1)
 }
 
+// CalculateQuantityForRisk implements the usecase.TradeUseCase interface
+func (m *MockTradeUseCase) CalculateQuantityForRisk(ctx context.Context, symbol string, side model.OrderSide, riskPct, stopPrice float64) (float64, error) {
+	args := m.Called(ctx, symbol, side, riskPct, stopPrice)
+	if args.Get(0) == nil {
+		return 0, args.Error(1)
+	}
+	return args.Get(0).(float64), args.Error(1)
+}
+
+// GetPnLReport implements the usecase.TradeUseCase interface
+func (m *MockTradeUseCase) GetPnLReport(ctx context.Context, userID, symbol string) (*model.PnLReport, error) {
+	args := m.Called(ctx, userID, symbol)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.PnLReport), args.Error(1)
+}
+
 // CancelOrder implements the usecase.TradeUseCase interface
 func (m *MockTradeUseCase) CancelOrder(ctx context.Context, symbol, orderID string) error {
 	args := m.Called(ctx, symbol, orderID)
@@ -202,6 +220,15 @@ func (m *MockTradeUseCase) GetOrderHistory(ctx context.Context, symbol string, l
 	return args.Get(0).([]*model.Order), args.Error(1)
 }
 
+// GetOrderHistoryPage implements the usecase.TradeUseCase interface
+func (m *MockTradeUseCase) GetOrderHistoryPage(ctx context.Context, userID, symbol, cursor string, limit int) (*model.OrderPage, error) {
+	args := m.Called(ctx, userID, symbol, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.OrderPage), args.Error(1)
+}
+
 func (m *MockTradeUseCase) PlaceOrder(ctx context.Context, req model.OrderRequest) (*model.Order, error) {
 	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
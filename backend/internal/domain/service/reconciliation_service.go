@@ -0,0 +1,380 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+)
+
+// Default reconciliation settings used when non-positive values are supplied
+const (
+	defaultReconciliationInterval  = 30 * time.Second
+	defaultReconciliationBatchSize = 50
+)
+
+// ReconciliationService periodically refreshes locally open orders from the exchange
+// so that order status does not go stale after a restart or a missed update
+type ReconciliationService struct {
+	mexcClient port.ExchangeClient
+	orderRepo  port.OrderRepository
+	publisher  port.OrderEventPublisher
+	logger     *zerolog.Logger
+	interval   time.Duration
+	batchSize  int
+	stopChan   chan struct{}
+	wg         sync.WaitGroup
+	running    bool
+	mutex      sync.Mutex
+
+	// orderLocks serializes the read-modify-write of each order between the poller and the
+	// pushed updates, keyed by exchange order ID
+	orderLocksMu sync.Mutex
+	orderLocks   map[string]*orderLock
+}
+
+// orderLock is a per-order mutex, removed once no one holds or waits for it
+type orderLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// NewReconciliationService creates a new ReconciliationService. The publisher may be nil
+// when no one is interested in order transitions.
+func NewReconciliationService(
+	mexcClient port.ExchangeClient,
+	orderRepo port.OrderRepository,
+	publisher port.OrderEventPublisher,
+	interval time.Duration,
+	batchSize int,
+	logger *zerolog.Logger,
+) *ReconciliationService {
+	if interval <= 0 {
+		interval = defaultReconciliationInterval
+	}
+	if batchSize <= 0 {
+		batchSize = defaultReconciliationBatchSize
+	}
+
+	return &ReconciliationService{
+		mexcClient: mexcClient,
+		orderRepo:  orderRepo,
+		publisher:  publisher,
+		logger:     logger,
+		interval:   interval,
+		batchSize:  batchSize,
+		stopChan:   make(chan struct{}),
+		orderLocks: make(map[string]*orderLock),
+	}
+}
+
+// lockOrder locks the order with the given exchange order ID and returns the unlock function
+func (s *ReconciliationService) lockOrder(orderID string) func() {
+	s.orderLocksMu.Lock()
+	lock, ok := s.orderLocks[orderID]
+	if !ok {
+		lock = &orderLock{}
+		s.orderLocks[orderID] = lock
+	}
+	lock.refs++
+	s.orderLocksMu.Unlock()
+
+	lock.mu.Lock()
+	return func() {
+		lock.mu.Unlock()
+		s.orderLocksMu.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(s.orderLocks, orderID)
+		}
+		s.orderLocksMu.Unlock()
+	}
+}
+
+// Start starts the reconciliation loop. The loop stops when ctx is canceled or Stop is called.
+func (s *ReconciliationService) Start(ctx context.Context) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.running {
+		s.logger.Warn().Msg("Order reconciliation is already running")
+		return
+	}
+
+	s.running = true
+	s.stopChan = make(chan struct{})
+	s.wg.Add(1)
+
+	go s.run(ctx)
+
+	s.logger.Info().
+		Dur("interval", s.interval).
+		Int("batchSize", s.batchSize).
+		Msg("Order reconciliation started")
+}
+
+// Stop stops the reconciliation loop and waits for the current pass to finish
+func (s *ReconciliationService) Stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.running {
+		return
+	}
+
+	close(s.stopChan)
+	s.wg.Wait()
+	s.running = false
+
+	s.logger.Info().Msg("Order reconciliation stopped")
+}
+
+// run executes reconciliation passes until stopped
+func (s *ReconciliationService) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+			if _, err := s.ReconcileOnce(ctx); err != nil {
+				s.logger.Error().Err(err).Msg("Order reconciliation pass failed")
+			}
+		}
+	}
+}
+
+// ReconcileOnce checks up to batchSize NEW and PARTIALLY_FILLED orders against the exchange
+// and returns the number of orders whose status changed
+func (s *ReconciliationService) ReconcileOnce(ctx context.Context) (int, error) {
+	changed := 0
+
+	for _, status := range []model.OrderStatus{model.OrderStatusNew, model.OrderStatusPartiallyFilled} {
+		orders, err := s.orderRepo.GetByStatus(ctx, status, s.batchSize, 0)
+		if err != nil {
+			return changed, err
+		}
+
+		for _, order := range orders {
+			if ctx.Err() != nil {
+				return changed, ctx.Err()
+			}
+			if s.reconcileOrder(ctx, order) {
+				changed++
+			}
+		}
+	}
+
+	if changed > 0 {
+		s.logger.Info().Int("changed", changed).Msg("Reconciled stale orders")
+	}
+
+	return changed, nil
+}
+
+// reconcileOrder refreshes a single order and reports whether its status changed
+func (s *ReconciliationService) reconcileOrder(ctx context.Context, local *model.Order) bool {
+	remote, err := s.mexcClient.GetOrderStatus(ctx, local.Symbol, local.OrderID)
+	if err != nil {
+		s.logger.Warn().Err(err).
+			Str("symbol", local.Symbol).
+			Str("orderID", local.OrderID).
+			Msg("Failed to fetch order status during reconciliation")
+		return false
+	}
+	if remote == nil || !orderChanged(local, remote) {
+		return false
+	}
+
+	// Re-read under the lock, a pushed fill may have changed the order since it was listed
+	unlock := s.lockOrder(local.OrderID)
+	defer unlock()
+	current, err := s.orderRepo.GetByOrderID(ctx, local.OrderID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("orderID", local.OrderID).Msg("Failed to reload order during reconciliation")
+		return false
+	}
+	if current == nil {
+		return false
+	}
+
+	return s.applyOrderUpdate(ctx, current, remote)
+}
+
+// ConsumeOrderUpdates applies the order updates and fills pushed by the exchange, such as
+// those emitted by the user data stream, until ctx is canceled or both channels are closed.
+// fills may be nil. Both are applied in turn, so an update and a fill of the same order do
+// not overwrite each other.
+func (s *ReconciliationService) ConsumeOrderUpdates(ctx context.Context, updates <-chan *model.Order, fills <-chan *model.OrderFill) {
+	for updates != nil || fills != nil {
+		select {
+		case <-ctx.Done():
+			return
+		case remote, ok := <-updates:
+			if !ok {
+				updates = nil
+				continue
+			}
+			if remote != nil {
+				s.HandleOrderUpdate(ctx, remote)
+			}
+		case fill, ok := <-fills:
+			if !ok {
+				fills = nil
+				continue
+			}
+			if fill != nil {
+				s.ApplyFill(ctx, fill)
+			}
+		}
+	}
+}
+
+// ApplyFill adds the commission of one execution to the stored order and persists it. A fill
+// carries the fee of that execution alone, so the order's commission is the running total
+// of its fills. It reports whether the order was updated.
+func (s *ReconciliationService) ApplyFill(ctx context.Context, fill *model.OrderFill) bool {
+	if fill.Commission <= 0 {
+		return false
+	}
+
+	unlock := s.lockOrder(fill.OrderID)
+	defer unlock()
+	local, err := s.findLocalOrder(ctx, &model.Order{
+		OrderID:       fill.OrderID,
+		ClientOrderID: fill.ClientOrderID,
+		Symbol:        fill.Symbol,
+	})
+	if err != nil {
+		s.logger.Error().Err(err).
+			Str("symbol", fill.Symbol).
+			Str("orderID", fill.OrderID).
+			Msg("Failed to look up order for pushed fill")
+		return false
+	}
+	if local == nil {
+		s.logger.Debug().
+			Str("symbol", fill.Symbol).
+			Str("orderID", fill.OrderID).
+			Msg("Ignoring fill of unknown order")
+		return false
+	}
+	if local.Commission > 0 && local.CommissionAsset != "" && local.CommissionAsset != fill.CommissionAsset {
+		s.logger.Warn().
+			Str("orderID", local.OrderID).
+			Str("commissionAsset", local.CommissionAsset).
+			Str("fillCommissionAsset", fill.CommissionAsset).
+			Msg("Not adding a fill's commission paid in another asset")
+		return false
+	}
+
+	updated := *local
+	updated.Commission += fill.Commission
+	updated.CommissionAsset = fill.CommissionAsset
+	updated.UpdatedAt = time.Now()
+	if err := s.orderRepo.Update(ctx, &updated); err != nil {
+		s.logger.Error().Err(err).Str("orderID", local.OrderID).Msg("Failed to add fill commission to order")
+		return false
+	}
+	return true
+}
+
+// HandleOrderUpdate finds the stored order matching a pushed update and applies the update.
+// It reports whether the status changed.
+func (s *ReconciliationService) HandleOrderUpdate(ctx context.Context, remote *model.Order) bool {
+	unlock := s.lockOrder(remote.OrderID)
+	defer unlock()
+	local, err := s.findLocalOrder(ctx, remote)
+	if err != nil {
+		s.logger.Error().Err(err).
+			Str("symbol", remote.Symbol).
+			Str("orderID", remote.OrderID).
+			Msg("Failed to look up order for pushed update")
+		return false
+	}
+	if local == nil {
+		s.logger.Debug().
+			Str("symbol", remote.Symbol).
+			Str("orderID", remote.OrderID).
+			Msg("Ignoring update for unknown order")
+		return false
+	}
+
+	return s.applyOrderUpdate(ctx, local, remote)
+}
+
+// findLocalOrder looks an order up by client order ID and falls back to its exchange order ID
+func (s *ReconciliationService) findLocalOrder(ctx context.Context, remote *model.Order) (*model.Order, error) {
+	if remote.ClientOrderID != "" {
+		order, err := s.orderRepo.GetByClientOrderID(ctx, remote.ClientOrderID)
+		if err != nil {
+			return nil, err
+		}
+		if order != nil {
+			return order, nil
+		}
+	}
+
+	if remote.OrderID == "" {
+		return nil, nil
+	}
+	return s.orderRepo.GetByOrderID(ctx, remote.OrderID)
+}
+
+// applyOrderUpdate merges an exchange order update into the stored order, persists it and
+// publishes an event when the order reached a terminal status. It reports whether the
+// status changed. The caller must hold the order's lock and pass the order as stored.
+func (s *ReconciliationService) applyOrderUpdate(ctx context.Context, local, remote *model.Order) bool {
+	if !orderChanged(local, remote) {
+		return false
+	}
+
+	oldStatus := local.Status
+	updated := *local
+	updated.Status = remote.Status
+	updated.ExecutedQty = remote.ExecutedQty
+	if remote.AvgFillPrice > 0 {
+		updated.AvgFillPrice = remote.AvgFillPrice
+	}
+	// The commission is the total of the order's fills, see ApplyFill
+	updated.UpdatedAt = time.Now()
+
+	if err := s.orderRepo.Update(ctx, &updated); err != nil {
+		s.logger.Error().Err(err).Str("orderID", local.OrderID).Msg("Failed to update reconciled order")
+		return false
+	}
+
+	if oldStatus == updated.Status {
+		return false
+	}
+
+	s.logger.Info().
+		Str("orderID", updated.OrderID).
+		Str("symbol", updated.Symbol).
+		Str("oldStatus", string(oldStatus)).
+		Str("newStatus", string(updated.Status)).
+		Msg("Order status changed")
+
+	if s.publisher != nil {
+		if evt := event.NewOrderStatusChanged(&updated, oldStatus); evt != nil {
+			s.publisher.PublishOrderEvent(evt)
+		}
+	}
+
+	return true
+}
+
+// orderChanged reports whether an exchange update moves the order's status or executed quantity
+func orderChanged(local, remote *model.Order) bool {
+	return remote.Status != local.Status || remote.ExecutedQty != local.ExecutedQty
+}
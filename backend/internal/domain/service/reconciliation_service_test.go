@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingOrderPublisher collects published order events
+type recordingOrderPublisher struct {
+	events []*event.OrderStatusChanged
+}
+
+func (p *recordingOrderPublisher) PublishOrderEvent(evt *event.OrderStatusChanged) {
+	p.events = append(p.events, evt)
+}
+
+// TestReconcileOnceNewToFilled tests that a NEW order filled on the exchange is updated and published
+func TestReconcileOnceNewToFilled(t *testing.T) {
+	mockClient := new(MockMexcClient)
+	mockOrderRepo := new(MockOrderRepository)
+	publisher := &recordingOrderPublisher{}
+	logger := zerolog.Nop()
+
+	service := NewReconciliationService(mockClient, mockOrderRepo, publisher, time.Minute, 10, &logger)
+
+	ctx := context.Background()
+	localOrder := &model.Order{
+		ID:       "internal1",
+		OrderID:  "order1",
+		Symbol:   "BTCUSDT",
+		Side:     model.OrderSideBuy,
+		Status:   model.OrderStatusNew,
+		Quantity: 0.5,
+	}
+	remoteOrder := &model.Order{
+		OrderID:      "order1",
+		Symbol:       "BTCUSDT",
+		Status:       model.OrderStatusFilled,
+		Quantity:     0.5,
+		ExecutedQty:  0.5,
+		AvgFillPrice: 50000.0,
+	}
+
+	mockOrderRepo.On("GetByStatus", ctx, model.OrderStatusNew, 10, 0).Return([]*model.Order{localOrder}, nil)
+	mockOrderRepo.On("GetByStatus", ctx, model.OrderStatusPartiallyFilled, 10, 0).Return([]*model.Order{}, nil)
+	mockOrderRepo.On("GetByOrderID", ctx, "order1").Return(localOrder, nil)
+	mockClient.On("GetOrderStatus", ctx, "BTCUSDT", "order1").Return(remoteOrder, nil)
+	mockOrderRepo.On("Update", ctx, mock.MatchedBy(func(o *model.Order) bool {
+		return o.ID == "internal1" && o.Status == model.OrderStatusFilled && o.ExecutedQty == 0.5 && o.AvgFillPrice == 50000.0
+	})).Return(nil)
+
+	changed, err := service.ReconcileOnce(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, changed)
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, event.OrderFilledEvent, publisher.events[0].Type())
+	assert.Equal(t, model.OrderStatusNew, publisher.events[0].OldStatus)
+	assert.Equal(t, model.OrderStatusFilled, publisher.events[0].NewStatus)
+	mockClient.AssertExpectations(t)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+// TestReconcileOnceUnchangedAndErrors tests that unchanged orders and exchange errors emit nothing
+func TestReconcileOnceUnchangedAndErrors(t *testing.T) {
+	mockClient := new(MockMexcClient)
+	mockOrderRepo := new(MockOrderRepository)
+	publisher := &recordingOrderPublisher{}
+	logger := zerolog.Nop()
+
+	service := NewReconciliationService(mockClient, mockOrderRepo, publisher, 0, 0, &logger)
+
+	ctx := context.Background()
+	unchanged := &model.Order{OrderID: "order1", Symbol: "BTCUSDT", Status: model.OrderStatusNew}
+	failing := &model.Order{OrderID: "order2", Symbol: "ETHUSDT", Status: model.OrderStatusPartiallyFilled}
+
+	mockOrderRepo.On("GetByStatus", ctx, model.OrderStatusNew, defaultReconciliationBatchSize, 0).Return([]*model.Order{unchanged}, nil)
+	mockOrderRepo.On("GetByStatus", ctx, model.OrderStatusPartiallyFilled, defaultReconciliationBatchSize, 0).Return([]*model.Order{failing}, nil)
+	mockClient.On("GetOrderStatus", ctx, "BTCUSDT", "order1").Return(&model.Order{OrderID: "order1", Status: model.OrderStatusNew}, nil)
+	mockClient.On("GetOrderStatus", ctx, "ETHUSDT", "order2").Return(nil, errors.New("exchange unavailable"))
+
+	changed, err := service.ReconcileOnce(ctx)
+
+	require.NoError(t, err)
+	assert.Zero(t, changed)
+	assert.Empty(t, publisher.events)
+	mockOrderRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+// TestReconcileOncePublishesTerminalStatusesOnly tests that a partial fill publishes nothing
+// while a rejection publishes its own event instead of a fill
+func TestReconcileOncePublishesTerminalStatusesOnly(t *testing.T) {
+	mockClient := new(MockMexcClient)
+	mockOrderRepo := new(MockOrderRepository)
+	publisher := &recordingOrderPublisher{}
+	logger := zerolog.Nop()
+
+	service := NewReconciliationService(mockClient, mockOrderRepo, publisher, 0, 0, &logger)
+
+	ctx := context.Background()
+	partial := &model.Order{OrderID: "order1", Symbol: "BTCUSDT", Status: model.OrderStatusNew, Quantity: 1}
+	rejected := &model.Order{OrderID: "order2", Symbol: "ETHUSDT", Status: model.OrderStatusNew, Quantity: 1}
+
+	mockOrderRepo.On("GetByStatus", ctx, model.OrderStatusNew, defaultReconciliationBatchSize, 0).Return([]*model.Order{partial, rejected}, nil)
+	mockOrderRepo.On("GetByStatus", ctx, model.OrderStatusPartiallyFilled, defaultReconciliationBatchSize, 0).Return([]*model.Order{}, nil)
+	mockClient.On("GetOrderStatus", ctx, "BTCUSDT", "order1").Return(&model.Order{OrderID: "order1", Status: model.OrderStatusPartiallyFilled, ExecutedQty: 0.5}, nil)
+	mockClient.On("GetOrderStatus", ctx, "ETHUSDT", "order2").Return(&model.Order{OrderID: "order2", Status: model.OrderStatusRejected}, nil)
+	mockOrderRepo.On("GetByOrderID", ctx, "order1").Return(partial, nil)
+	mockOrderRepo.On("GetByOrderID", ctx, "order2").Return(rejected, nil)
+	mockOrderRepo.On("Update", ctx, mock.Anything).Return(nil)
+
+	changed, err := service.ReconcileOnce(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, changed)
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, event.OrderRejectedEvent, publisher.events[0].Type())
+	assert.Equal(t, "order2", publisher.events[0].Order.OrderID)
+}
+
+// TestReconciliationServiceStopsOnContextCancel tests that the loop exits when the context is canceled
+func TestReconciliationServiceStopsOnContextCancel(t *testing.T) {
+	logger := zerolog.Nop()
+	service := NewReconciliationService(new(MockMexcClient), new(MockOrderRepository), nil, time.Hour, 10, &logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	service.Start(ctx)
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		service.Stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("reconciliation service did not stop after context cancellation")
+	}
+}
+
+// TestConsumeOrderUpdatesAppliesPushedFill tests that a streamed fill is matched and applied immediately
+func TestConsumeOrderUpdatesAppliesPushedFill(t *testing.T) {
+	mockClient := new(MockMexcClient)
+	mockOrderRepo := new(MockOrderRepository)
+	publisher := &recordingOrderPublisher{}
+	logger := zerolog.Nop()
+
+	service := NewReconciliationService(mockClient, mockOrderRepo, publisher, time.Minute, 10, &logger)
+
+	ctx := context.Background()
+	localOrder := &model.Order{
+		ID:      "internal1",
+		OrderID: "order1",
+		Symbol:  "BTCUSDT",
+		Status:  model.OrderStatusNew,
+	}
+	pushed := &model.Order{
+		OrderID:      "order1",
+		Symbol:       "BTCUSDT",
+		Status:       model.OrderStatusFilled,
+		ExecutedQty:  0.5,
+		AvgFillPrice: 50000.0,
+	}
+	unknown := &model.Order{
+		OrderID:       "order2",
+		ClientOrderID: "client2",
+		Symbol:        "BTCUSDT",
+		Status:        model.OrderStatusFilled,
+	}
+
+	mockOrderRepo.On("GetByOrderID", ctx, "order1").Return(localOrder, nil)
+	mockOrderRepo.On("GetByClientOrderID", ctx, "client2").Return(nil, nil)
+	mockOrderRepo.On("GetByOrderID", ctx, "order2").Return(nil, nil)
+	mockOrderRepo.On("Update", ctx, mock.MatchedBy(func(o *model.Order) bool {
+		return o.ID == "internal1" && o.Status == model.OrderStatusFilled
+	})).Return(nil).Once()
+
+	updates := make(chan *model.Order, 2)
+	updates <- pushed
+	updates <- unknown
+	close(updates)
+
+	service.ConsumeOrderUpdates(ctx, updates, nil)
+
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, "order1", publisher.events[0].Order.OrderID)
+	mockClient.AssertNotCalled(t, "GetOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+// TestConsumeOrderUpdatesAddsFillCommissions tests that the commission of each pushed fill
+// is added to the order's total rather than replacing it
+func TestConsumeOrderUpdatesAddsFillCommissions(t *testing.T) {
+	mockClient := new(MockMexcClient)
+	mockOrderRepo := new(MockOrderRepository)
+	logger := zerolog.Nop()
+
+	service := NewReconciliationService(mockClient, mockOrderRepo, &recordingOrderPublisher{}, time.Minute, 10, &logger)
+
+	ctx := context.Background()
+	stored := &model.Order{
+		ID:            "internal1",
+		OrderID:       "order1",
+		ClientOrderID: "client1",
+		Symbol:        "BTCUSDT",
+		Status:        model.OrderStatusPartiallyFilled,
+	}
+	mockOrderRepo.On("GetByClientOrderID", ctx, "client1").Return(stored, nil)
+	mockOrderRepo.On("Update", ctx, mock.AnythingOfType("*model.Order")).
+		Run(func(args mock.Arguments) { *stored = *args.Get(1).(*model.Order) }).
+		Return(nil)
+
+	fill := func(commission float64, asset string) *model.OrderFill {
+		return &model.OrderFill{OrderID: "order1", ClientOrderID: "client1", Symbol: "BTCUSDT", Commission: commission, CommissionAsset: asset}
+	}
+	fills := make(chan *model.OrderFill, 4)
+	fills <- fill(0.01, "USDT")
+	fills <- fill(0.02, "USDT")
+	fills <- fill(0.5, "MX") // Not added to a total kept in USDT
+	fills <- fill(0, "USDT")
+	close(fills)
+
+	service.ConsumeOrderUpdates(ctx, nil, fills)
+
+	assert.InDelta(t, 0.03, stored.Commission, 1e-12)
+	assert.Equal(t, "USDT", stored.CommissionAsset)
+	mockOrderRepo.AssertNumberOfCalls(t, "Update", 2)
+}
+
+// memoryOrderRepository stores orders by exchange order ID and hands out copies, like a database
+type memoryOrderRepository struct {
+	port.OrderRepository
+	mu     sync.Mutex
+	orders map[string]model.Order
+}
+
+func (r *memoryOrderRepository) GetByStatus(ctx context.Context, status model.OrderStatus, limit, offset int) ([]*model.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var orders []*model.Order
+	for _, order := range r.orders {
+		if order.Status == status {
+			order := order
+			orders = append(orders, &order)
+		}
+	}
+	return orders, nil
+}
+
+func (r *memoryOrderRepository) GetByClientOrderID(ctx context.Context, clientOrderID string) (*model.Order, error) {
+	return nil, nil
+}
+
+func (r *memoryOrderRepository) GetByOrderID(ctx context.Context, orderID string) (*model.Order, error) {
+	r.mu.Lock()
+	order, ok := r.orders[orderID]
+	r.mu.Unlock()
+	// Widen the window between read and write so unserialized updates would collide
+	time.Sleep(time.Millisecond)
+	if !ok {
+		return nil, nil
+	}
+	return &order, nil
+}
+
+func (r *memoryOrderRepository) Update(ctx context.Context, order *model.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.orders[order.OrderID] = *order
+	return nil
+}
+
+// TestReconciliationSerializesPollerAndFills tests that polled status updates and pushed fills
+// of the same order do not overwrite each other
+func TestReconciliationSerializesPollerAndFills(t *testing.T) {
+	mockClient := new(MockMexcClient)
+	repo := &memoryOrderRepository{orders: map[string]model.Order{
+		"order1": {OrderID: "order1", Symbol: "BTCUSDT", Status: model.OrderStatusNew, Quantity: 1},
+	}}
+	logger := zerolog.Nop()
+	service := NewReconciliationService(mockClient, repo, nil, time.Minute, 10, &logger)
+
+	ctx := context.Background()
+	mockClient.On("GetOrderStatus", ctx, "BTCUSDT", "order1").
+		Return(&model.Order{OrderID: "order1", Status: model.OrderStatusPartiallyFilled, ExecutedQty: 0.5}, nil).Once()
+	mockClient.On("GetOrderStatus", ctx, "BTCUSDT", "order1").
+		Return(&model.Order{OrderID: "order1", Status: model.OrderStatusPartiallyFilled, ExecutedQty: 0.5}, nil)
+
+	fills := make(chan *model.OrderFill, 20)
+	for i := 0; i < 20; i++ {
+		fills <- &model.OrderFill{OrderID: "order1", Symbol: "BTCUSDT", Commission: 0.01, CommissionAsset: "USDT"}
+	}
+	close(fills)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		service.ConsumeOrderUpdates(ctx, nil, fills)
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			_, _ = service.ReconcileOnce(ctx)
+		}
+	}()
+	wg.Wait()
+
+	stored := repo.orders["order1"]
+	assert.InDelta(t, 0.2, stored.Commission, 1e-9)
+	assert.Equal(t, model.OrderStatusPartiallyFilled, stored.Status)
+	assert.Empty(t, service.orderLocks)
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+)
+
+// ErrRiskLimitExceeded is matched by every RiskLimitError
+var ErrRiskLimitExceeded = errors.New("risk limit exceeded")
+
+// Limits enforced by the risk manager
+const (
+	RiskLimitPositionSize  = "max_position_per_symbol"
+	RiskLimitTotalExposure = "max_total_exposure"
+	RiskLimitDailyLoss     = "max_daily_loss"
+)
+
+// closedPositionsPageSize is the number of closed positions read per query when summing
+// the day's realized loss
+const closedPositionsPageSize = 500
+
+// RiskLimitError reports an order rejected because it would breach a risk limit
+type RiskLimitError struct {
+	Symbol string
+	Limit  string
+	// Value is the position size, exposure or realized loss the order would lead to, and
+	// Max the configured limit
+	Value float64
+	Max   float64
+}
+
+// Error returns the error message
+func (e *RiskLimitError) Error() string {
+	switch e.Limit {
+	case RiskLimitDailyLoss:
+		return fmt.Sprintf("buying %s is halted: daily realized loss %f reached limit %f", e.Symbol, e.Value, e.Max)
+	case RiskLimitPositionSize:
+		return fmt.Sprintf("order for %s exceeds maximum position size: %f above limit %f", e.Symbol, e.Value, e.Max)
+	case RiskLimitTotalExposure:
+		return fmt.Sprintf("order for %s exceeds maximum total exposure: %f above limit %f", e.Symbol, e.Value, e.Max)
+	default:
+		return fmt.Sprintf("order for %s exceeds %s: %f above limit %f", e.Symbol, e.Limit, e.Value, e.Max)
+	}
+}
+
+// Unwrap allows errors.Is(err, ErrRiskLimitExceeded) to match limit rejections
+func (e *RiskLimitError) Unwrap() error {
+	return ErrRiskLimitExceeded
+}
+
+// RiskPositionSource provides the open positions and the recently closed positions of the account
+type RiskPositionSource interface {
+	GetOpenPositions(ctx context.Context) ([]*model.Position, error)
+	GetClosedPositions(ctx context.Context, from, to time.Time, limit, offset int) ([]*model.Position, error)
+}
+
+// RiskLimits are the limits a RiskManager enforces, in the quote currency. Zero disables a limit.
+type RiskLimits struct {
+	MaxPositionPerSymbol float64
+	MaxTotalExposure     float64
+	MaxDailyLoss         float64
+}
+
+// RiskManager checks buy orders against the account's risk limits before they are placed:
+// the value held in one symbol, the value held across all symbols, and the loss realized
+// since 00:00 UTC. Once the daily loss limit is reached buys stay halted for the rest of
+// the day, even if later closes recover part of the loss. Sells are never refused as they
+// reduce risk.
+type RiskManager struct {
+	limits    RiskLimits
+	positions RiskPositionSource
+	tickers   TickerSource
+	now       func() time.Time
+
+	mu sync.Mutex
+	// haltedDay is the UTC day buys were halted on, zero when they are not
+	haltedDay time.Time
+}
+
+// NewRiskManager creates a RiskManager. tickers prices market orders, which carry no price
+// of their own.
+func NewRiskManager(limits RiskLimits, positions RiskPositionSource, tickers TickerSource) *RiskManager {
+	return &RiskManager{
+		limits:    limits,
+		positions: positions,
+		tickers:   tickers,
+		now:       time.Now,
+	}
+}
+
+// Check returns a *RiskLimitError if placing request would breach a risk limit. The check
+// fails closed: if positions or prices cannot be read the order is refused with that error.
+func (m *RiskManager) Check(ctx context.Context, request *model.OrderRequest) error {
+	if request.Side != model.OrderSideBuy {
+		return nil
+	}
+
+	if m.limits.MaxDailyLoss > 0 {
+		loss, halted, err := m.dailyLoss(ctx)
+		if err != nil {
+			return fmt.Errorf("cannot check daily loss limit: %w", err)
+		}
+		if halted {
+			return &RiskLimitError{Symbol: request.Symbol, Limit: RiskLimitDailyLoss, Value: loss, Max: m.limits.MaxDailyLoss}
+		}
+	}
+
+	if m.limits.MaxPositionPerSymbol <= 0 && m.limits.MaxTotalExposure <= 0 {
+		return nil
+	}
+
+	exposure, err := m.exposure(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot check exposure limits: %w", err)
+	}
+	notional, err := m.orderValue(ctx, request)
+	if err != nil {
+		return fmt.Errorf("cannot check exposure limits: %w", err)
+	}
+
+	if m.limits.MaxPositionPerSymbol > 0 {
+		position := exposure[strings.ToUpper(request.Symbol)] + notional
+		if position > m.limits.MaxPositionPerSymbol {
+			return &RiskLimitError{Symbol: request.Symbol, Limit: RiskLimitPositionSize, Value: position, Max: m.limits.MaxPositionPerSymbol}
+		}
+	}
+	if m.limits.MaxTotalExposure > 0 {
+		total := notional
+		for _, value := range exposure {
+			total += value
+		}
+		if total > m.limits.MaxTotalExposure {
+			return &RiskLimitError{Symbol: request.Symbol, Limit: RiskLimitTotalExposure, Value: total, Max: m.limits.MaxTotalExposure}
+		}
+	}
+	return nil
+}
+
+// Utilization reports the current use of every limit
+func (m *RiskManager) Utilization(ctx context.Context) (*model.RiskLimitUtilization, error) {
+	exposure, err := m.exposure(ctx)
+	if err != nil {
+		return nil, err
+	}
+	loss, halted, err := m.dailyLoss(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	utilization := &model.RiskLimitUtilization{
+		SymbolExposure:       exposure,
+		MaxPositionPerSymbol: m.limits.MaxPositionPerSymbol,
+		MaxTotalExposure:     m.limits.MaxTotalExposure,
+		DailyRealizedLoss:    loss,
+		MaxDailyLoss:         m.limits.MaxDailyLoss,
+		BuysHalted:           halted,
+		GeneratedAt:          m.now(),
+	}
+	for symbol, value := range exposure {
+		utilization.TotalExposure += value
+		if value > utilization.LargestPosition {
+			utilization.LargestPosition = value
+			utilization.LargestPositionSymbol = symbol
+		}
+	}
+	utilization.PositionUtilization = ratio(utilization.LargestPosition, m.limits.MaxPositionPerSymbol)
+	utilization.ExposureUtilization = ratio(utilization.TotalExposure, m.limits.MaxTotalExposure)
+	utilization.DailyLossUtilization = ratio(loss, m.limits.MaxDailyLoss)
+	return utilization, nil
+}
+
+// exposure returns the value of the open positions by upper-cased symbol
+func (m *RiskManager) exposure(ctx context.Context) (map[string]float64, error) {
+	positions, err := m.positions.GetOpenPositions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exposure := make(map[string]float64)
+	for _, position := range positions {
+		price := position.CurrentPrice
+		if price <= 0 {
+			price = position.EntryPrice
+		}
+		exposure[strings.ToUpper(position.Symbol)] += math.Abs(position.Quantity * price)
+	}
+	return exposure, nil
+}
+
+// orderValue returns the value of the order in the quote currency, pricing market orders
+// at the latest ticker
+func (m *RiskManager) orderValue(ctx context.Context, request *model.OrderRequest) (float64, error) {
+	if request.Price > 0 {
+		return request.Quantity * request.Price, nil
+	}
+	if m.tickers == nil {
+		return 0, fmt.Errorf("no ticker source to price %s", request.Symbol)
+	}
+	ticker, err := m.tickers.GetTicker(ctx, request.Symbol)
+	if err != nil {
+		return 0, err
+	}
+	return request.Quantity * ticker.Price, nil
+}
+
+// dailyLoss returns the loss realized since 00:00 UTC and whether buys are halted for the
+// day, halting them when the loss has reached the limit
+func (m *RiskManager) dailyLoss(ctx context.Context) (float64, bool, error) {
+	now := m.now().UTC()
+	day := now.Truncate(24 * time.Hour)
+
+	var pnl float64
+	for offset := 0; ; offset += closedPositionsPageSize {
+		closed, err := m.positions.GetClosedPositions(ctx, day, now, closedPositionsPageSize, offset)
+		if err != nil {
+			return 0, false, err
+		}
+		for _, position := range closed {
+			pnl += position.PnL
+		}
+		if len(closed) < closedPositionsPageSize {
+			break
+		}
+	}
+	loss := math.Max(0, -pnl)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.limits.MaxDailyLoss > 0 && loss >= m.limits.MaxDailyLoss {
+		m.haltedDay = day
+	}
+	return loss, m.haltedDay.Equal(day), nil
+}
+
+// ratio returns value as a fraction of limit, or zero when the limit is disabled
+func ratio(value, limit float64) float64 {
+	if limit <= 0 {
+		return 0
+	}
+	return value / limit
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubRiskPositions serves fixed open positions and the closed positions within the
+// requested range
+type stubRiskPositions struct {
+	open   []*model.Position
+	closed []*model.Position
+}
+
+func (s *stubRiskPositions) GetOpenPositions(ctx context.Context) ([]*model.Position, error) {
+	return s.open, nil
+}
+
+func (s *stubRiskPositions) GetClosedPositions(ctx context.Context, from, to time.Time, limit, offset int) ([]*model.Position, error) {
+	var closed []*model.Position
+	for _, position := range s.closed {
+		if !position.ClosedAt.Before(from) && !position.ClosedAt.After(to) {
+			closed = append(closed, position)
+		}
+	}
+	if offset >= len(closed) {
+		return nil, nil
+	}
+	closed = closed[offset:]
+	if len(closed) > limit {
+		closed = closed[:limit]
+	}
+	return closed, nil
+}
+
+func closedPosition(pnl float64, closedAt time.Time) *model.Position {
+	return &model.Position{Symbol: "BTCUSDT", Status: model.PositionStatusClosed, PnL: pnl, ClosedAt: &closedAt}
+}
+
+func newRiskManagedTradeService(risk *RiskManager) (*MexcTradeService, *stubExchange) {
+	logger := zerolog.Nop()
+	exchange := &stubExchange{}
+	return NewMexcTradeService(exchange, nil, nil, nil, &logger).WithRiskManager(risk), exchange
+}
+
+func TestRiskManager_RejectsBuyAboveMaxExposure(t *testing.T) {
+	positions := &stubRiskPositions{open: []*model.Position{
+		{Symbol: "BTCUSDT", Quantity: 0.1, EntryPrice: 50000, CurrentPrice: 60000}, // 6,000
+		{Symbol: "ETHUSDT", Quantity: 1, EntryPrice: 2500},                         // 2,500
+	}}
+	tickers := stubTickerSource{"SOLUSDT": {Symbol: "SOLUSDT", Price: 100}}
+	risk := NewRiskManager(RiskLimits{MaxTotalExposure: 10000}, positions, tickers)
+	service, exchange := newRiskManagedTradeService(risk)
+
+	// A market order is priced at the ticker: 20 * 100 brings exposure to 10,500
+	_, err := service.PlaceOrder(context.Background(), &model.OrderRequest{
+		Symbol: "SOLUSDT", Side: model.OrderSideBuy, Type: model.OrderTypeMarket, Quantity: 20,
+	})
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrRiskLimitExceeded)
+	var limitErr *RiskLimitError
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, RiskLimitTotalExposure, limitErr.Limit)
+	assert.Equal(t, 10500.0, limitErr.Value)
+	assert.Empty(t, exchange.placed, "rejected before reaching the exchange")
+
+	assert.NoError(t, risk.Check(context.Background(), &model.OrderRequest{
+		Symbol: "SOLUSDT", Side: model.OrderSideBuy, Type: model.OrderTypeLimit, Quantity: 10, Price: 100,
+	}))
+	assert.NoError(t, risk.Check(context.Background(), &model.OrderRequest{
+		Symbol: "BTCUSDT", Side: model.OrderSideSell, Type: model.OrderTypeLimit, Quantity: 1, Price: 60000,
+	}), "sells reduce exposure and are never refused")
+}
+
+func TestRiskManager_RejectsBuyAboveMaxPositionPerSymbol(t *testing.T) {
+	positions := &stubRiskPositions{open: []*model.Position{
+		{Symbol: "BTCUSDT", Quantity: 0.05, CurrentPrice: 60000}, // 3,000
+	}}
+	risk := NewRiskManager(RiskLimits{MaxPositionPerSymbol: 5000}, positions, nil)
+
+	err := risk.Check(context.Background(), &model.OrderRequest{
+		Symbol: "btcusdt", Side: model.OrderSideBuy, Type: model.OrderTypeLimit, Quantity: 0.05, Price: 60000,
+	})
+
+	var limitErr *RiskLimitError
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, RiskLimitPositionSize, limitErr.Limit)
+	assert.Equal(t, 6000.0, limitErr.Value)
+
+	// Other symbols are sized on their own
+	assert.NoError(t, risk.Check(context.Background(), &model.OrderRequest{
+		Symbol: "ETHUSDT", Side: model.OrderSideBuy, Type: model.OrderTypeLimit, Quantity: 1, Price: 2500,
+	}))
+	// A market order that cannot be priced is refused
+	assert.Error(t, risk.Check(context.Background(), &model.OrderRequest{
+		Symbol: "ETHUSDT", Side: model.OrderSideBuy, Type: model.OrderTypeMarket, Quantity: 1,
+	}))
+}
+
+func TestRiskManager_DailyLossHaltsBuys(t *testing.T) {
+	now := time.Date(2024, 5, 10, 15, 0, 0, 0, time.UTC)
+	positions := &stubRiskPositions{closed: []*model.Position{
+		closedPosition(-700, now.Add(-3*time.Hour)),
+		closedPosition(200, now.Add(-2*time.Hour)),
+		closedPosition(-5000, now.Add(-24*time.Hour)), // yesterday's loss does not count
+	}}
+	risk := NewRiskManager(RiskLimits{MaxDailyLoss: 1000}, positions, nil)
+	risk.now = func() time.Time { return now }
+	service, exchange := newRiskManagedTradeService(risk)
+	buy := &model.OrderRequest{Symbol: "ETHUSDT", Side: model.OrderSideBuy, Type: model.OrderTypeLimit, Quantity: 1, Price: 2500}
+
+	require.NoError(t, risk.Check(context.Background(), buy), "500 net loss is within the limit")
+
+	positions.closed = append(positions.closed, closedPosition(-600, now.Add(-time.Hour)))
+	_, err := service.PlaceOrder(context.Background(), buy)
+
+	var limitErr *RiskLimitError
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, RiskLimitDailyLoss, limitErr.Limit)
+	assert.Equal(t, 1100.0, limitErr.Value)
+	assert.Empty(t, exchange.placed)
+
+	// Buys stay halted for the day even after a profitable close
+	positions.closed = append(positions.closed, closedPosition(400, now.Add(-time.Minute)))
+	assert.ErrorIs(t, risk.Check(context.Background(), buy), ErrRiskLimitExceeded)
+	assert.NoError(t, risk.Check(context.Background(), &model.OrderRequest{
+		Symbol: "ETHUSDT", Side: model.OrderSideSell, Type: model.OrderTypeLimit, Quantity: 1, Price: 2500,
+	}))
+
+	utilization, err := risk.Utilization(context.Background())
+	require.NoError(t, err)
+	assert.True(t, utilization.BuysHalted)
+	assert.Equal(t, 700.0, utilization.DailyRealizedLoss)
+	assert.InDelta(t, 0.7, utilization.DailyLossUtilization, 1e-9)
+
+	// The halt lifts at the start of the next UTC day
+	risk.now = func() time.Time { return now.Add(12 * time.Hour) }
+	assert.NoError(t, risk.Check(context.Background(), buy))
+}
+
+func TestRiskManager_Utilization(t *testing.T) {
+	positions := &stubRiskPositions{open: []*model.Position{
+		{Symbol: "BTCUSDT", Quantity: 0.1, CurrentPrice: 60000},
+		{Symbol: "ETHUSDT", Quantity: 1, EntryPrice: 2000},
+		{Symbol: "ETHUSDT", Quantity: 1, CurrentPrice: 2000},
+	}}
+	risk := NewRiskManager(RiskLimits{MaxPositionPerSymbol: 8000, MaxTotalExposure: 20000}, positions, nil)
+
+	utilization, err := risk.Utilization(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"BTCUSDT": 6000, "ETHUSDT": 4000}, utilization.SymbolExposure)
+	assert.Equal(t, "BTCUSDT", utilization.LargestPositionSymbol)
+	assert.InDelta(t, 0.75, utilization.PositionUtilization, 1e-9)
+	assert.Equal(t, 10000.0, utilization.TotalExposure)
+	assert.InDelta(t, 0.5, utilization.ExposureUtilization, 1e-9)
+	assert.Zero(t, utilization.DailyLossUtilization, "a disabled limit reports no utilization")
+	assert.False(t, utilization.BuysHalted)
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+)
+
+// ErrSymbolNotAllowed is matched by every TradeGuardError
+var ErrSymbolNotAllowed = errors.New("trading symbol not allowed")
+
+// Reasons a trade guard rejects a symbol
+const (
+	GuardReasonBlacklisted    = "blacklisted"
+	GuardReasonNotWhitelisted = "not_whitelisted"
+	GuardReasonLowVolume      = "low_volume"
+	GuardReasonNotTrading     = "not_trading"
+)
+
+// TradeGuardError reports an order rejected because its symbol may not be traded
+type TradeGuardError struct {
+	Symbol string
+	Reason string
+	// Volume and MinVolume are the symbol's 24h quote volume and the required minimum,
+	// set when Reason is GuardReasonLowVolume
+	Volume    float64
+	MinVolume float64
+	// Status is the symbol's exchange status, set when Reason is GuardReasonNotTrading
+	Status string
+}
+
+// Error returns the error message
+func (e *TradeGuardError) Error() string {
+	switch e.Reason {
+	case GuardReasonBlacklisted:
+		return fmt.Sprintf("trading %s is not allowed: symbol is blacklisted", e.Symbol)
+	case GuardReasonNotWhitelisted:
+		return fmt.Sprintf("trading %s is not allowed: symbol is not whitelisted", e.Symbol)
+	case GuardReasonLowVolume:
+		return fmt.Sprintf("trading %s is not allowed: 24h volume %f is below minimum %f", e.Symbol, e.Volume, e.MinVolume)
+	case GuardReasonNotTrading:
+		return fmt.Sprintf("trading %s is not allowed: symbol status is %s", e.Symbol, e.Status)
+	default:
+		return fmt.Sprintf("trading %s is not allowed: %s", e.Symbol, e.Reason)
+	}
+}
+
+// Unwrap allows errors.Is(err, ErrSymbolNotAllowed) to match guard rejections
+func (e *TradeGuardError) Unwrap() error {
+	return ErrSymbolNotAllowed
+}
+
+// TickerSource provides the latest 24h ticker of a symbol
+type TickerSource interface {
+	GetTicker(ctx context.Context, symbol string) (*market.Ticker, error)
+}
+
+// TradeGuard decides which symbols may be traded. A blacklisted symbol is always refused;
+// when a whitelist is set only its symbols are allowed; and when a minimum volume is set
+// symbols trading less than it over 24h, in the quote currency, are refused.
+type TradeGuard struct {
+	whitelist      map[string]bool
+	blacklist      map[string]bool
+	minQuoteVolume float64
+	tickers        TickerSource
+}
+
+// NewTradeGuard creates a TradeGuard. Symbols match case-insensitively. tickers may be
+// nil when minQuoteVolume is zero.
+func NewTradeGuard(whitelist, blacklist []string, minQuoteVolume float64, tickers TickerSource) *TradeGuard {
+	return &TradeGuard{
+		whitelist:      symbolSet(whitelist),
+		blacklist:      symbolSet(blacklist),
+		minQuoteVolume: minQuoteVolume,
+		tickers:        tickers,
+	}
+}
+
+// Check returns a *TradeGuardError if symbol may not be traded. The volume check fails
+// closed: if the ticker cannot be read the symbol is refused with that error.
+func (g *TradeGuard) Check(ctx context.Context, symbol string) error {
+	key := strings.ToUpper(symbol)
+	if g.blacklist[key] {
+		return &TradeGuardError{Symbol: symbol, Reason: GuardReasonBlacklisted}
+	}
+	if len(g.whitelist) > 0 && !g.whitelist[key] {
+		return &TradeGuardError{Symbol: symbol, Reason: GuardReasonNotWhitelisted}
+	}
+	if g.minQuoteVolume <= 0 {
+		return nil
+	}
+
+	if g.tickers == nil {
+		return fmt.Errorf("cannot check 24h volume of %s: no ticker source", symbol)
+	}
+	ticker, err := g.tickers.GetTicker(ctx, symbol)
+	if err != nil {
+		return fmt.Errorf("cannot check 24h volume of %s: %w", symbol, err)
+	}
+	// Tickers report volume in the base asset
+	volume := ticker.Volume * ticker.Price
+	if volume < g.minQuoteVolume {
+		return &TradeGuardError{Symbol: symbol, Reason: GuardReasonLowVolume, Volume: volume, MinVolume: g.minQuoteVolume}
+	}
+	return nil
+}
+
+// CheckStatus returns a *TradeGuardError if the exchange does not trade symbol, such as
+// during a BREAK or HALT or after it was delisted. Symbols without a known status are
+// allowed.
+func (g *TradeGuard) CheckStatus(symbol *market.Symbol) error {
+	if symbol.Status != "" && symbol.Status != string(model.SymbolStatusTrading) {
+		return &TradeGuardError{Symbol: symbol.Symbol, Reason: GuardReasonNotTrading, Status: symbol.Status}
+	}
+	return nil
+}
+
+// symbolSet returns the upper-cased symbols as a set
+func symbolSet(symbols []string) map[string]bool {
+	set := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		if symbol = strings.TrimSpace(symbol); symbol != "" {
+			set[strings.ToUpper(symbol)] = true
+		}
+	}
+	return set
+}
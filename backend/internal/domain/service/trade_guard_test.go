@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubTickerSource serves fixed tickers by symbol
+type stubTickerSource map[string]*market.Ticker
+
+func (s stubTickerSource) GetTicker(ctx context.Context, symbol string) (*market.Ticker, error) {
+	ticker, ok := s[symbol]
+	if !ok {
+		return nil, errors.New("ticker not found")
+	}
+	return ticker, nil
+}
+
+func newGuardedTradeService(guard *TradeGuard) (*MexcTradeService, *stubExchange) {
+	logger := zerolog.Nop()
+	exchange := &stubExchange{}
+	return NewMexcTradeService(exchange, nil, nil, nil, &logger).WithTradeGuard(guard), exchange
+}
+
+func guardedOrder(symbol string) *model.OrderRequest {
+	return &model.OrderRequest{Symbol: symbol, Side: model.OrderSideBuy, Type: model.OrderTypeMarket, Quantity: 1}
+}
+
+func TestTradeGuard_RejectsBlacklistedSymbol(t *testing.T) {
+	service, exchange := newGuardedTradeService(NewTradeGuard(nil, []string{"scamusdt"}, 0, nil))
+
+	_, err := service.PlaceOrder(context.Background(), guardedOrder("SCAMUSDT"))
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrSymbolNotAllowed)
+	var guardErr *TradeGuardError
+	require.True(t, errors.As(err, &guardErr))
+	assert.Equal(t, GuardReasonBlacklisted, guardErr.Reason)
+	assert.Empty(t, exchange.placed, "rejected before reaching the exchange")
+}
+
+func TestTradeGuard_RejectsSymbolBelowVolumeThreshold(t *testing.T) {
+	tickers := stubTickerSource{
+		"THINUSDT": {Symbol: "THINUSDT", Price: 0.5, Volume: 10000},   // 5,000 quote volume
+		"DEEPUSDT": {Symbol: "DEEPUSDT", Price: 2, Volume: 1_000_000}, // 2,000,000 quote volume
+	}
+	guard := NewTradeGuard(nil, nil, 100_000, tickers)
+	service, exchange := newGuardedTradeService(guard)
+
+	_, err := service.PlaceOrder(context.Background(), guardedOrder("THINUSDT"))
+
+	var guardErr *TradeGuardError
+	require.True(t, errors.As(err, &guardErr))
+	assert.Equal(t, GuardReasonLowVolume, guardErr.Reason)
+	assert.Equal(t, 5000.0, guardErr.Volume)
+	assert.Equal(t, 100_000.0, guardErr.MinVolume)
+	assert.Empty(t, exchange.placed)
+
+	assert.NoError(t, guard.Check(context.Background(), "DEEPUSDT"))
+	// An unreadable ticker refuses the symbol rather than letting it through
+	assert.Error(t, guard.Check(context.Background(), "UNKNOWNUSDT"))
+}
+
+func TestTradeGuard_Whitelist(t *testing.T) {
+	guard := NewTradeGuard([]string{"BTCUSDT", " ethusdt "}, nil, 0, nil)
+
+	assert.NoError(t, guard.Check(context.Background(), "BTCUSDT"))
+	assert.NoError(t, guard.Check(context.Background(), "ETHUSDT"))
+
+	var guardErr *TradeGuardError
+	require.True(t, errors.As(guard.Check(context.Background(), "DOGEUSDT"), &guardErr))
+	assert.Equal(t, GuardReasonNotWhitelisted, guardErr.Reason)
+}
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
 	"github.com/rs/zerolog"
 )
@@ -17,20 +19,54 @@ var (
 	ErrOrderNotFound       = errors.New("order not found")
 	ErrInsufficientBalance = errors.New("insufficient balance for the order")
 	ErrSymbolNotSupported  = errors.New("trading symbol not supported")
+	ErrInvalidRiskParams   = errors.New("invalid risk sizing parameters")
 )
 
-// MexcTradeService implements the TradeService interface for the MEXC exchange
+// Symbol filter names, matching the exchange filter types
+const (
+	FilterLotSize     = "LOT_SIZE"
+	FilterMinNotional = "MIN_NOTIONAL"
+)
+
+// OrderFilterError reports an order rejected locally for violating a symbol trading filter.
+// Err is set when the filter could not be checked at all, such as a market order without a ticker.
+type OrderFilterError struct {
+	Symbol string
+	Filter string
+	Value  float64
+	Limit  float64
+	Err    error
+}
+
+// Error returns the error message
+func (e *OrderFilterError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("order for %s cannot be checked against %s filter: %v", e.Symbol, e.Filter, e.Err)
+	}
+	return fmt.Sprintf("order for %s violates %s filter: %f is below minimum %f", e.Symbol, e.Filter, e.Value, e.Limit)
+}
+
+// Unwrap allows errors.Is(err, ErrInvalidOrderRequest) to match filter violations
+func (e *OrderFilterError) Unwrap() error {
+	return ErrInvalidOrderRequest
+}
+
+// MexcTradeService implements the TradeService interface. It was written for MEXC but
+// trades through any ExchangeClient.
 type MexcTradeService struct {
-	mexcClient    port.MEXCClient // Changed from mexcAPI to mexcClient
+	mexcClient    port.ExchangeClient
 	marketService *MarketDataService
 	symbolRepo    port.SymbolRepository
 	orderRepo     port.OrderRepository
+	guard         *TradeGuard
+	risk          *RiskManager
+	events        port.DomainEventPublisher
 	logger        *zerolog.Logger
 }
 
 // NewMexcTradeService creates a new MexcTradeService
 func NewMexcTradeService(
-	mexcClient port.MEXCClient, // Changed from mexcAPI to mexcClient
+	mexcClient port.ExchangeClient,
 	marketService *MarketDataService,
 	symbolRepo port.SymbolRepository,
 	orderRepo port.OrderRepository,
@@ -45,6 +81,31 @@ func NewMexcTradeService(
 	}
 }
 
+// WithTradeGuard makes PlaceOrder refuse orders on symbols the guard does not allow
+func (s *MexcTradeService) WithTradeGuard(guard *TradeGuard) *MexcTradeService {
+	s.guard = guard
+	return s
+}
+
+// WithRiskManager makes PlaceOrder refuse orders that would breach the manager's risk limits
+func (s *MexcTradeService) WithRiskManager(risk *RiskManager) *MexcTradeService {
+	s.risk = risk
+	return s
+}
+
+// WithEventPublisher makes the service announce orders it places, sees filled, or cancels
+func (s *MexcTradeService) WithEventPublisher(events port.DomainEventPublisher) *MexcTradeService {
+	s.events = events
+	return s
+}
+
+// publish announces evt when an event publisher is set
+func (s *MexcTradeService) publish(evt event.DomainEvent) {
+	if s.events != nil {
+		s.events.PublishEvent(evt)
+	}
+}
+
 // PlaceOrder creates and submits a new order to the MEXC exchange
 func (s *MexcTradeService) PlaceOrder(ctx context.Context, request *model.OrderRequest) (*model.OrderResponse, error) {
 	// Validate request
@@ -52,18 +113,45 @@ func (s *MexcTradeService) PlaceOrder(ctx context.Context, request *model.OrderR
 		return nil, ErrInvalidOrderRequest
 	}
 
+	// Refuse symbols that may not be traded before anything reaches the exchange
+	if s.guard != nil {
+		if err := s.guard.Check(ctx, request.Symbol); err != nil {
+			s.logger.Warn().Err(err).Str("symbol", request.Symbol).Msg("Order rejected by trade guard")
+			return nil, err
+		}
+	}
+	if s.risk != nil {
+		if err := s.risk.Check(ctx, request); err != nil {
+			s.logger.Warn().Err(err).Str("symbol", request.Symbol).Msg("Order rejected by risk limits")
+			return nil, err
+		}
+	}
+
 	// Check if symbol exists
 	symbol, err := s.symbolRepo.GetBySymbol(ctx, request.Symbol)
 	if err != nil || symbol == nil {
 		s.logger.Error().Err(err).Str("symbol", request.Symbol).Msg("Symbol not found")
 		return nil, ErrSymbolNotSupported
 	}
+	if s.guard != nil {
+		if err := s.guard.CheckStatus(symbol); err != nil {
+			s.logger.Warn().Err(err).Str("symbol", request.Symbol).Msg("Order rejected by trade guard")
+			return nil, err
+		}
+	}
 
 	// For limit orders, verify price is set
 	if request.Type == model.OrderTypeLimit && request.Price == 0 {
 		return nil, errors.New("limit orders require a price")
 	}
 
+	// Round to the symbol filters so the exchange does not reject the order
+	quantity, price, err := s.applySymbolFilters(ctx, symbol, request)
+	if err != nil {
+		s.logger.Warn().Err(err).Str("symbol", request.Symbol).Msg("Order rejected by symbol filters")
+		return nil, err
+	}
+
 	// Place order with the exchange
 	timeInForce := model.TimeInForceGTC // Default for limit orders
 	if request.Type == model.OrderTypeMarket {
@@ -76,20 +164,25 @@ func (s *MexcTradeService) PlaceOrder(ctx context.Context, request *model.OrderR
 		request.Symbol,
 		request.Side,
 		request.Type,
-		request.Quantity,
-		request.Price,
+		quantity,
+		price,
 		timeInForce,
 	)
 	if err != nil {
 		s.logger.Error().Err(err).Str("symbol", request.Symbol).
 			Str("side", string(request.Side)).
 			Str("type", string(request.Type)).
-			Float64("quantity", request.Quantity).
-			Float64("price", request.Price).
+			Float64("quantity", quantity).
+			Float64("price", price).
 			Msg("Failed to place order")
 		return nil, fmt.Errorf("failed to place order: %w", err)
 	}
 
+	// The exchange does not know our users
+	if order.UserID == "" {
+		order.UserID = request.UserID
+	}
+
 	// Save order to database
 	err = s.orderRepo.Create(ctx, order)
 	if err != nil {
@@ -97,6 +190,12 @@ func (s *MexcTradeService) PlaceOrder(ctx context.Context, request *model.OrderR
 		// We continue because the order was placed successfully on the exchange
 	}
 
+	s.publish(event.NewOrderPlaced(order))
+	if order.Status == model.OrderStatusFilled {
+		// Market orders are usually filled as they are placed
+		s.publish(event.NewOrderStatusChanged(order, model.OrderStatusNew))
+	}
+
 	// Create and return OrderResponse
 	response := &model.OrderResponse{
 		Order:     *order,
@@ -106,6 +205,42 @@ func (s *MexcTradeService) PlaceOrder(ctx context.Context, request *model.OrderR
 	return response, nil
 }
 
+// applySymbolFilters rounds the order quantity down to the step size and the price to the
+// tick size, then checks the LOT_SIZE and MIN_NOTIONAL filters of the symbol
+func (s *MexcTradeService) applySymbolFilters(ctx context.Context, symbol *market.Symbol, request *model.OrderRequest) (float64, float64, error) {
+	quantity := roundDownToStep(request.Quantity, symbol.StepSize)
+	price := request.Price
+	if request.Type == model.OrderTypeLimit {
+		price = roundToStep(price, symbol.TickSize)
+	}
+
+	if quantity <= 0 || quantity < symbol.MinQty {
+		return 0, 0, &OrderFilterError{Symbol: request.Symbol, Filter: FilterLotSize, Value: quantity, Limit: symbol.MinQty}
+	}
+
+	if symbol.MinNotional > 0 {
+		notionalPrice := price
+		if request.Type == model.OrderTypeMarket {
+			// Market orders carry no price, so estimate the notional from the current ticker
+			ticker, err := s.marketService.RefreshTicker(ctx, request.Symbol)
+			if err != nil || ticker == nil {
+				if err == nil {
+					err = errors.New("no ticker available")
+				}
+				return 0, 0, &OrderFilterError{Symbol: request.Symbol, Filter: FilterMinNotional, Limit: symbol.MinNotional, Err: err}
+			}
+			notionalPrice = ticker.Price
+		}
+
+		notional := model.NewDecimalFromFloat(quantity).Mul(model.NewDecimalFromFloat(notionalPrice))
+		if notional.LessThan(model.NewDecimalFromFloat(symbol.MinNotional)) {
+			return 0, 0, &OrderFilterError{Symbol: request.Symbol, Filter: FilterMinNotional, Value: notional.Float64(), Limit: symbol.MinNotional}
+		}
+	}
+
+	return quantity, price, nil
+}
+
 // CancelOrder cancels an existing order
 func (s *MexcTradeService) CancelOrder(ctx context.Context, symbol, orderID string) error {
 	// Verify order exists
@@ -129,6 +264,7 @@ func (s *MexcTradeService) CancelOrder(ctx context.Context, symbol, orderID stri
 	}
 
 	// Update order status in database
+	oldStatus := order.Status
 	order.Status = model.OrderStatusCanceled
 	order.UpdatedAt = time.Now()
 	err = s.orderRepo.Update(ctx, order)
@@ -137,6 +273,8 @@ func (s *MexcTradeService) CancelOrder(ctx context.Context, symbol, orderID stri
 		// We continue because the order was canceled successfully on the exchange
 	}
 
+	s.publish(event.NewOrderStatusChanged(order, oldStatus))
+
 	return nil
 }
 
@@ -159,6 +297,9 @@ func (s *MexcTradeService) GetOrderStatus(ctx context.Context, symbol, orderID s
 	// Update order in database
 	if localOrder != nil {
 		// Update existing order
+		if order.UserID == "" {
+			order.UserID = localOrder.UserID
+		}
 		err = s.orderRepo.Update(ctx, order)
 	} else {
 		// Save new order
@@ -170,6 +311,13 @@ func (s *MexcTradeService) GetOrderStatus(ctx context.Context, symbol, orderID s
 		// Continue because we still want to return the order from the exchange
 	}
 
+	// Fills, cancellations, rejections and expiries seen on the exchange are announced
+	if localOrder != nil && localOrder.Status != order.Status {
+		if evt := event.NewOrderStatusChanged(order, localOrder.Status); evt != nil {
+			s.publish(evt)
+		}
+	}
+
 	return order, nil
 }
 
@@ -177,23 +325,17 @@ func (s *MexcTradeService) GetOrderStatus(ctx context.Context, symbol, orderID s
 func (s *MexcTradeService) GetOpenOrders(ctx context.Context, symbol string) ([]*model.Order, error) {
 	// Implement this method to get open orders from MEXC API
 	// For now, we'll just return open orders from our database
-	limit := 100
-	offset := 0
-	orders, err := s.orderRepo.GetBySymbol(ctx, symbol, limit, offset)
+	orders, _, err := s.orderRepo.Query(ctx, model.OrderFilter{
+		Symbol:   symbol,
+		Statuses: model.OpenOrderStatuses(),
+		Limit:    100,
+	})
 	if err != nil {
 		s.logger.Error().Err(err).Str("symbol", symbol).Msg("Failed to get open orders")
 		return nil, fmt.Errorf("failed to get open orders: %w", err)
 	}
 
-	// Filter orders by status
-	openOrders := make([]*model.Order, 0)
-	for _, order := range orders {
-		if order.Status == model.OrderStatusNew || order.Status == model.OrderStatusPartiallyFilled {
-			openOrders = append(openOrders, order)
-		}
-	}
-
-	return openOrders, nil
+	return orders, nil
 }
 
 // GetOrderHistory retrieves historical orders for a symbol
@@ -242,3 +384,108 @@ func (s *MexcTradeService) CalculateRequiredQuantity(ctx context.Context, symbol
 
 	return quantity, nil
 }
+
+// CalculateQuantityForRisk sizes a position so that a move from the current price
+// to stopPrice loses riskPct percent of the account equity. The result is rounded
+// down to the symbol step size and rejected when it falls below the minimum quantity.
+func (s *MexcTradeService) CalculateQuantityForRisk(ctx context.Context, symbol string, side model.OrderSide, riskPct, stopPrice float64) (float64, error) {
+	if riskPct <= 0 || riskPct > 100 {
+		return 0, fmt.Errorf("%w: risk percentage must be in (0, 100], got %f", ErrInvalidRiskParams, riskPct)
+	}
+	if stopPrice <= 0 {
+		return 0, fmt.Errorf("%w: stop price must be positive", ErrInvalidRiskParams)
+	}
+
+	symbolInfo, err := s.symbolRepo.GetBySymbol(ctx, symbol)
+	if err != nil || symbolInfo == nil {
+		return 0, ErrSymbolNotSupported
+	}
+
+	ticker, err := s.marketService.RefreshTicker(ctx, symbol)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get ticker: %w", err)
+	}
+	price := ticker.Price
+	if price <= 0 {
+		return 0, errors.New("invalid price from ticker")
+	}
+
+	// Distance to the stop must be on the losing side of the entry
+	var stopDistance float64
+	switch side {
+	case model.OrderSideBuy:
+		stopDistance = price - stopPrice
+	case model.OrderSideSell:
+		stopDistance = stopPrice - price
+	default:
+		return 0, fmt.Errorf("%w: unsupported order side %s", ErrInvalidRiskParams, side)
+	}
+	if stopDistance <= 0 {
+		return 0, fmt.Errorf("%w: stop price %f is not below/above entry %f for %s", ErrInvalidRiskParams, stopPrice, price, side)
+	}
+
+	equity, err := s.accountEquity(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	riskAmount := equity * riskPct / 100
+	quantity := roundDownToStep(riskAmount/stopDistance, symbolInfo.StepSize)
+
+	if symbolInfo.MaxQty > 0 && quantity > symbolInfo.MaxQty {
+		quantity = roundDownToStep(symbolInfo.MaxQty, symbolInfo.StepSize)
+	}
+
+	if quantity <= 0 || quantity < symbolInfo.MinQty {
+		return 0, fmt.Errorf("calculated quantity %f is below minimum allowed %f", quantity, symbolInfo.MinQty)
+	}
+
+	s.logger.Debug().
+		Str("symbol", symbol).
+		Str("side", string(side)).
+		Float64("equity", equity).
+		Float64("riskPct", riskPct).
+		Float64("stopPrice", stopPrice).
+		Float64("quantity", quantity).
+		Msg("Calculated risk-based quantity")
+
+	return quantity, nil
+}
+
+// accountEquity returns the total USD value of the exchange wallet
+func (s *MexcTradeService) accountEquity(ctx context.Context) (float64, error) {
+	wallet, err := s.mexcClient.GetAccount(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get account: %w", err)
+	}
+	if wallet == nil {
+		return 0, ErrInsufficientBalance
+	}
+
+	equity := wallet.TotalUSDValue
+	if equity <= 0 {
+		// Fall back to summing balances when the total has not been computed
+		for _, balance := range wallet.Balances {
+			if balance != nil {
+				equity += balance.USDValue
+			}
+		}
+	}
+	if equity <= 0 {
+		return 0, ErrInsufficientBalance
+	}
+
+	return equity, nil
+}
+
+// roundDownToStep floors value to a multiple of step in exact decimal arithmetic. A
+// non-positive step leaves value unchanged.
+func roundDownToStep(value, step float64) float64 {
+	return model.NewDecimalFromFloat(value).RoundDownToStep(model.NewDecimalFromFloat(step)).Float64()
+}
+
+// roundToStep rounds value to the nearest multiple of step in exact decimal arithmetic. A
+// non-positive step leaves value unchanged.
+func roundToStep(value, step float64) float64 {
+	return model.NewDecimalFromFloat(value).RoundToStep(model.NewDecimalFromFloat(step)).Float64()
+}
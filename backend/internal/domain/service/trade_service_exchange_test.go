@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubExchange is a non-MEXC exchange that fills every order it receives
+type stubExchange struct {
+	port.ExchangeClient
+	placed []string
+}
+
+func (e *stubExchange) PlaceOrder(ctx context.Context, symbol string, side model.OrderSide, orderType model.OrderType, quantity float64, price float64, timeInForce model.TimeInForce) (*model.Order, error) {
+	e.placed = append(e.placed, symbol)
+	return &model.Order{
+		OrderID:     "stub-1",
+		Symbol:      symbol,
+		Side:        side,
+		Type:        orderType,
+		Quantity:    quantity,
+		Price:       price,
+		TimeInForce: timeInForce,
+		Status:      model.OrderStatusFilled,
+	}, nil
+}
+
+func TestTradeService_WorksWithAnyExchangeClient(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+	exchange := &stubExchange{}
+	orderRepo := new(MockOrderRepository)
+	symbolRepo := new(MockSymbolRepository)
+	marketService := &MarketDataService{
+		marketRepo: &mockMarketRepoWrapper{new(MockTradeMarketDataService)},
+		symbolRepo: symbolRepo,
+		logger:     &logger,
+	}
+	service := NewMexcTradeService(exchange, marketService, symbolRepo, orderRepo, &logger)
+
+	symbolRepo.On("GetBySymbol", ctx, "ETHUSDT").Return(&market.Symbol{Symbol: "ETHUSDT", BaseAsset: "ETH", QuoteAsset: "USDT"}, nil)
+	orderRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+	result, err := service.PlaceOrder(ctx, &model.OrderRequest{
+		Symbol:   "ETHUSDT",
+		Side:     model.OrderSideBuy,
+		Type:     model.OrderTypeLimit,
+		Quantity: 1,
+		Price:    2500,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "stub-1", result.Order.OrderID)
+	assert.Equal(t, model.OrderStatusFilled, result.Order.Status)
+	assert.Equal(t, []string{"ETHUSDT"}, exchange.placed)
+	orderRepo.AssertExpectations(t)
+}
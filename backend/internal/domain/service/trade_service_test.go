@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
 	"github.com/rs/zerolog"
@@ -212,6 +213,14 @@ func (m *MockOrderRepository) GetByClientOrderID(ctx context.Context, clientOrde
 	return args.Get(0).(*model.Order), args.Error(1)
 }
 
+func (m *MockOrderRepository) GetByOrderID(ctx context.Context, orderID string) (*model.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Order), args.Error(1)
+}
+
 func (m *MockOrderRepository) GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*model.Order, error) {
 	args := m.Called(ctx, userID, limit, offset)
 	if args.Get(0) == nil {
@@ -220,6 +229,22 @@ func (m *MockOrderRepository) GetByUserID(ctx context.Context, userID string, li
 	return args.Get(0).([]*model.Order), args.Error(1)
 }
 
+func (m *MockOrderRepository) Query(ctx context.Context, filter model.OrderFilter) ([]*model.Order, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*model.Order), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockOrderRepository) GetBySymbolBefore(ctx context.Context, userID, symbol string, cursor *model.OrderCursor, limit int) ([]*model.Order, error) {
+	args := m.Called(ctx, userID, symbol, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Order), args.Error(1)
+}
+
 func (m *MockOrderRepository) GetByStatus(ctx context.Context, status model.OrderStatus, limit, offset int) ([]*model.Order, error) {
 	args := m.Called(ctx, status, limit, offset)
 	if args.Get(0) == nil {
@@ -258,6 +283,29 @@ func (m *MockSymbolRepository) Delete(ctx context.Context, id string) error {
 	return args.Error(0)
 }
 
+func (m *MockSymbolRepository) GetAllIncludingDeleted(ctx context.Context) ([]*market.Symbol, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*market.Symbol), args.Error(1)
+}
+
+func (m *MockSymbolRepository) Restore(ctx context.Context, symbol string) error {
+	args := m.Called(ctx, symbol)
+	return args.Error(0)
+}
+
+func (m *MockSymbolRepository) Upsert(ctx context.Context, symbol *market.Symbol) error {
+	args := m.Called(ctx, symbol)
+	return args.Error(0)
+}
+
+func (m *MockSymbolRepository) UpsertMany(ctx context.Context, symbols []*market.Symbol) (int, int, error) {
+	args := m.Called(ctx, symbols)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
 func (m *MockSymbolRepository) GetByID(ctx context.Context, id string) (*market.Symbol, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -641,6 +689,39 @@ func TestGetOrderStatus(t *testing.T) {
 	mockOrderRepo.AssertExpectations(t)
 }
 
+func TestGetOrderStatusPublishesTerminalStatusChanges(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	for _, status := range []model.OrderStatus{model.OrderStatusCanceled, model.OrderStatusExpired, model.OrderStatusPartiallyFilled} {
+		mockClient := new(MockMexcClient)
+		mockOrderRepo := new(MockOrderRepository)
+		events := &publishedEvents{}
+		service := NewMexcTradeService(mockClient, nil, new(MockSymbolRepository), mockOrderRepo, &logger).
+			WithEventPublisher(events)
+
+		local := &model.Order{Symbol: "BTCUSDT", OrderID: "order123", UserID: "user-1", Status: model.OrderStatusNew}
+		remote := &model.Order{Symbol: "BTCUSDT", OrderID: "order123", Status: status}
+		mockOrderRepo.On("GetByID", ctx, "order123").Return(local, nil)
+		mockClient.On("GetOrderStatus", ctx, "BTCUSDT", "order123").Return(remote, nil)
+		mockOrderRepo.On("Update", ctx, mock.Anything).Return(nil)
+
+		_, err := service.GetOrderStatus(ctx, "BTCUSDT", "order123")
+		require.NoError(t, err)
+
+		// Partial fills have no event; every terminal status does
+		if status == model.OrderStatusPartiallyFilled {
+			assert.Empty(t, events.events)
+			continue
+		}
+		require.Len(t, events.events, 1, status)
+		changed := events.events[0].(*event.OrderStatusChanged)
+		assert.Equal(t, model.OrderStatusNew, changed.OldStatus)
+		assert.Equal(t, status, changed.NewStatus)
+		assert.Equal(t, "user-1", changed.Order.UserID)
+	}
+}
+
 // TestCalculateRequiredQuantity tests the CalculateRequiredQuantity method
 func TestCalculateRequiredQuantity(t *testing.T) {
 	// Create mocks
@@ -748,6 +829,10 @@ func (w *mockMarketRepoWrapper) GetCandles(ctx context.Context, symbol, exchange
 	return nil, nil
 }
 
+func (w *mockMarketRepoWrapper) GetResampledCandles(ctx context.Context, symbol, exchange string, source, target market.Interval, start, end time.Time) ([]*market.Candle, error) {
+	return nil, nil
+}
+
 func (w *mockMarketRepoWrapper) GetLatestCandle(ctx context.Context, symbol, exchange string, interval market.Interval) (*market.Candle, error) {
 	return nil, nil
 }
@@ -769,6 +854,10 @@ func (w *mockMarketRepoWrapper) GetOrderBook(ctx context.Context, symbol, exchan
 	}, nil
 }
 
+func (w *mockMarketRepoWrapper) SearchSymbols(ctx context.Context, query string, limit int) ([]*market.Symbol, error) {
+	return []*market.Symbol{}, nil
+}
+
 func (w *mockMarketRepoWrapper) GetTickersBySymbol(ctx context.Context, symbol string, limit int) ([]*market.Ticker, error) {
 	return nil, nil
 }
@@ -804,11 +893,14 @@ func TestGetOpenOrders(t *testing.T) {
 	// Setup test data
 	ctx := context.Background()
 	symbol := "BTC-USDT"
-	limit := 100
-	offset := 0
+	filter := model.OrderFilter{
+		Symbol:   symbol,
+		Statuses: []model.OrderStatus{model.OrderStatusNew, model.OrderStatusPartiallyFilled},
+		Limit:    100,
+	}
 
-	// Create a slice of orders with different statuses
-	allOrders := []*model.Order{
+	// The repository returns only the orders in the requested statuses
+	openOrders := []*model.Order{
 		{
 			OrderID:   "order1",
 			Symbol:    symbol,
@@ -821,22 +913,10 @@ func TestGetOpenOrders(t *testing.T) {
 			Status:    model.OrderStatusPartiallyFilled,
 			CreatedAt: time.Now(),
 		},
-		{
-			OrderID:   "order3",
-			Symbol:    symbol,
-			Status:    model.OrderStatusFilled,
-			CreatedAt: time.Now(),
-		},
-		{
-			OrderID:   "order4",
-			Symbol:    symbol,
-			Status:    model.OrderStatusCanceled,
-			CreatedAt: time.Now(),
-		},
 	}
 
 	// Setup expectations
-	mockOrderRepo.On("GetBySymbol", ctx, symbol, limit, offset).Return(allOrders, nil)
+	mockOrderRepo.On("Query", ctx, filter).Return(openOrders, int64(2), nil)
 
 	// Call the method
 	orders, err := service.GetOpenOrders(ctx, symbol)
@@ -851,7 +931,28 @@ func TestGetOpenOrders(t *testing.T) {
 		assert.True(t, order.Status == model.OrderStatusNew || order.Status == model.OrderStatusPartiallyFilled)
 	}
 
-	// Verify expectations were met
+	// Verify expectations were met; the database filters by status, so the symbol's
+	// full history is never fetched
+	mockOrderRepo.AssertExpectations(t)
+	mockOrderRepo.AssertNotCalled(t, "GetBySymbol", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestGetOpenOrdersNone tests that a symbol without open orders yields an empty list
+func TestGetOpenOrdersNone(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepository)
+	logger := zerolog.Nop()
+	service := NewMexcTradeService(new(MockMexcClient), nil, new(MockSymbolRepository), mockOrderRepo, &logger)
+
+	ctx := context.Background()
+	mockOrderRepo.On("Query", ctx, mock.MatchedBy(func(filter model.OrderFilter) bool {
+		return filter.Symbol == "ETHUSDT" && assert.ElementsMatch(t, model.OpenOrderStatuses(), filter.Statuses)
+	})).Return([]*model.Order{}, int64(0), nil)
+
+	orders, err := service.GetOpenOrders(ctx, "ETHUSDT")
+
+	require.NoError(t, err)
+	assert.NotNil(t, orders)
+	assert.Empty(t, orders)
 	mockOrderRepo.AssertExpectations(t)
 }
 
@@ -882,12 +983,10 @@ func TestGetOpenOrdersError(t *testing.T) {
 	// Setup test data
 	ctx := context.Background()
 	symbol := "BTC-USDT"
-	limit := 100
-	offset := 0
 	expectedError := errors.New("database error")
 
 	// Setup expectations
-	mockOrderRepo.On("GetBySymbol", ctx, symbol, limit, offset).Return(nil, expectedError)
+	mockOrderRepo.On("Query", ctx, mock.AnythingOfType("model.OrderFilter")).Return(nil, int64(0), expectedError)
 
 	// Call the method
 	orders, err := service.GetOpenOrders(ctx, symbol)
@@ -1008,3 +1107,247 @@ func TestGetOrderHistoryError(t *testing.T) {
 	// Verify expectations were met
 	mockOrderRepo.AssertExpectations(t)
 }
+
+// TestCalculateQuantityForRisk tests risk-based position sizing
+func TestCalculateQuantityForRisk(t *testing.T) {
+	ctx := context.Background()
+	symbol := "BTC-USDT"
+	logger := zerolog.Nop()
+
+	newService := func(symbolInfo *market.Symbol, wallet *model.Wallet) (*MexcTradeService, *MockMexcClient) {
+		mockClient := new(MockMexcClient)
+		mockSymbolRepo := new(MockSymbolRepository)
+		mockCache := new(MockMarketCache)
+
+		mockCache.On("GetTicker", ctx, "mexc", symbol).Return(&market.Ticker{
+			Exchange: "MEXC",
+			Symbol:   symbol,
+			Price:    50000.0,
+		}, true)
+		mockSymbolRepo.On("GetBySymbol", ctx, symbol).Return(symbolInfo, nil)
+		mockClient.On("GetAccount", ctx).Return(wallet, nil)
+
+		marketService := &MarketDataService{
+			symbolRepo: mockSymbolRepo,
+			logger:     &logger,
+			mexcClient: mockClient,
+			cache:      mockCache,
+		}
+
+		return NewMexcTradeService(mockClient, marketService, mockSymbolRepo, new(MockOrderRepository), &logger), mockClient
+	}
+
+	t.Run("rounds down to step size", func(t *testing.T) {
+		symbolInfo := &market.Symbol{Symbol: symbol, MinQty: 0.001, StepSize: 0.001}
+		wallet := &model.Wallet{TotalUSDValue: 10000.0}
+		service, mockClient := newService(symbolInfo, wallet)
+
+		// Risk 1% of $10,000 = $100 over a $3,000 stop distance = 0.0333... BTC
+		quantity, err := service.CalculateQuantityForRisk(ctx, symbol, model.OrderSideBuy, 1, 47000.0)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0.033, quantity)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("sums balances when total is missing", func(t *testing.T) {
+		symbolInfo := &market.Symbol{Symbol: symbol, MinQty: 0.01, StepSize: 0.01}
+		wallet := &model.Wallet{Balances: map[model.Asset]*model.Balance{
+			model.AssetUSDT: {Asset: model.AssetUSDT, USDValue: 15000.0},
+			model.AssetBTC:  {Asset: model.AssetBTC, USDValue: 5000.0},
+		}}
+		service, _ := newService(symbolInfo, wallet)
+
+		// Risk 2% of $20,000 = $400 over a $1,000 stop distance = 0.4 BTC
+		quantity, err := service.CalculateQuantityForRisk(ctx, symbol, model.OrderSideSell, 2, 51000.0)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0.4, quantity)
+	})
+
+	t.Run("rejects quantity below min qty", func(t *testing.T) {
+		symbolInfo := &market.Symbol{Symbol: symbol, MinQty: 0.01, StepSize: 0.001}
+		wallet := &model.Wallet{TotalUSDValue: 1000.0}
+		service, _ := newService(symbolInfo, wallet)
+
+		// Risk 1% of $1,000 = $10 over a $5,000 stop distance = 0.002 BTC
+		quantity, err := service.CalculateQuantityForRisk(ctx, symbol, model.OrderSideBuy, 1, 45000.0)
+
+		require.Error(t, err)
+		assert.Zero(t, quantity)
+		assert.Contains(t, err.Error(), "below minimum allowed")
+	})
+
+	t.Run("rejects stop on the wrong side of entry", func(t *testing.T) {
+		symbolInfo := &market.Symbol{Symbol: symbol, MinQty: 0.001, StepSize: 0.001}
+		service, _ := newService(symbolInfo, &model.Wallet{TotalUSDValue: 10000.0})
+
+		_, err := service.CalculateQuantityForRisk(ctx, symbol, model.OrderSideBuy, 1, 52000.0)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidRiskParams)
+	})
+}
+
+// TestRoundDownToStep tests step-size rounding
+func TestRoundDownToStep(t *testing.T) {
+	assert.Equal(t, 0.123, roundDownToStep(0.12399, 0.001))
+	assert.Equal(t, 0.3, roundDownToStep(0.3, 0.1))
+	assert.Equal(t, 12.0, roundDownToStep(12.7, 1))
+	assert.Equal(t, 0.12399, roundDownToStep(0.12399, 0))
+	assert.Equal(t, 1.01, roundToStep(1.005, 0.01), "half a tick rounds up although 1.005/0.01 is below 100.5 in floats")
+}
+
+// TestPlaceOrderAppliesSymbolFilters tests step/tick rounding and min notional checks in PlaceOrder
+func TestPlaceOrderAppliesSymbolFilters(t *testing.T) {
+	ctx := context.Background()
+	symbol := "BTC-USDT"
+	logger := zerolog.Nop()
+
+	symbolInfo := &market.Symbol{
+		Symbol:      symbol,
+		BaseAsset:   "BTC",
+		QuoteAsset:  "USDT",
+		MinQty:      0.0001,
+		StepSize:    0.0001,
+		TickSize:    0.01,
+		MinNotional: 5.0,
+	}
+
+	t.Run("rounds quantity down and price to tick", func(t *testing.T) {
+		mockClient := new(MockMexcClient)
+		mockOrderRepo := new(MockOrderRepository)
+		mockSymbolRepo := new(MockSymbolRepository)
+		service := NewMexcTradeService(mockClient, &MarketDataService{logger: &logger}, mockSymbolRepo, mockOrderRepo, &logger)
+
+		order := &model.Order{Symbol: symbol, OrderID: "123", Quantity: 0.0012, Price: 50000.12, Status: model.OrderStatusNew}
+
+		mockSymbolRepo.On("GetBySymbol", ctx, symbol).Return(symbolInfo, nil)
+		mockClient.On("PlaceOrder", ctx, symbol, model.OrderSideBuy, model.OrderTypeLimit, 0.0012, 50000.12, model.TimeInForceGTC).Return(order, nil)
+		mockOrderRepo.On("Create", ctx, order).Return(nil)
+
+		result, err := service.PlaceOrder(ctx, &model.OrderRequest{
+			Symbol:   symbol,
+			Side:     model.OrderSideBuy,
+			Type:     model.OrderTypeLimit,
+			Quantity: 0.00129,
+			Price:    50000.1234,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "123", result.OrderID)
+		mockClient.AssertExpectations(t)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects undersized notional", func(t *testing.T) {
+		mockClient := new(MockMexcClient)
+		mockSymbolRepo := new(MockSymbolRepository)
+		service := NewMexcTradeService(mockClient, &MarketDataService{logger: &logger}, mockSymbolRepo, new(MockOrderRepository), &logger)
+
+		mockSymbolRepo.On("GetBySymbol", ctx, symbol).Return(symbolInfo, nil)
+
+		result, err := service.PlaceOrder(ctx, &model.OrderRequest{
+			Symbol:   symbol,
+			Side:     model.OrderSideBuy,
+			Type:     model.OrderTypeLimit,
+			Quantity: 0.0001,
+			Price:    40000.0,
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrInvalidOrderRequest)
+
+		var filterErr *OrderFilterError
+		require.ErrorAs(t, err, &filterErr)
+		assert.Equal(t, FilterMinNotional, filterErr.Filter)
+		assert.Equal(t, 5.0, filterErr.Limit)
+		mockClient.AssertNotCalled(t, "PlaceOrder")
+	})
+
+	t.Run("accepts notional exactly at the minimum", func(t *testing.T) {
+		mockClient := new(MockMexcClient)
+		mockOrderRepo := new(MockOrderRepository)
+		mockSymbolRepo := new(MockSymbolRepository)
+		service := NewMexcTradeService(mockClient, &MarketDataService{logger: &logger}, mockSymbolRepo, mockOrderRepo, &logger)
+
+		// 0.57 * 100 is 56.99999999999999 in floats and would fail the minimum
+		minimumInfo := *symbolInfo
+		minimumInfo.StepSize = 0.01
+		minimumInfo.MinQty = 0.01
+		minimumInfo.MinNotional = 57
+		order := &model.Order{Symbol: symbol, OrderID: "124", Quantity: 0.57, Price: 100, Status: model.OrderStatusNew}
+
+		mockSymbolRepo.On("GetBySymbol", ctx, symbol).Return(&minimumInfo, nil)
+		mockClient.On("PlaceOrder", ctx, symbol, model.OrderSideBuy, model.OrderTypeLimit, 0.57, 100.0, model.TimeInForceGTC).Return(order, nil)
+		mockOrderRepo.On("Create", ctx, order).Return(nil)
+
+		result, err := service.PlaceOrder(ctx, &model.OrderRequest{
+			Symbol:   symbol,
+			Side:     model.OrderSideBuy,
+			Type:     model.OrderTypeLimit,
+			Quantity: 0.57,
+			Price:    100,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "124", result.OrderID)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("rejects market order when the ticker is unavailable", func(t *testing.T) {
+		mockClient := new(MockMexcClient)
+		mockSymbolRepo := new(MockSymbolRepository)
+		mockMarketData := new(MockTradeMarketDataService)
+		mockCache := new(MockMarketCache)
+		marketService := &MarketDataService{
+			marketRepo: &mockMarketRepoWrapper{mockMarketData},
+			logger:     &logger,
+			mexcClient: mockClient,
+			cache:      mockCache,
+		}
+		service := NewMexcTradeService(mockClient, marketService, mockSymbolRepo, new(MockOrderRepository), &logger)
+
+		mockSymbolRepo.On("GetBySymbol", ctx, symbol).Return(symbolInfo, nil)
+		mockCache.On("GetTicker", ctx, "mexc", symbol).Return(nil, false)
+		mockClient.On("GetMarketData", ctx, symbol).Return(nil, errors.New("exchange unavailable"))
+		mockMarketData.On("RefreshTicker", ctx, symbol).Return(nil, errors.New("no stored ticker"))
+
+		result, err := service.PlaceOrder(ctx, &model.OrderRequest{
+			Symbol:   symbol,
+			Side:     model.OrderSideBuy,
+			Type:     model.OrderTypeMarket,
+			Quantity: 0.001,
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrInvalidOrderRequest)
+
+		var filterErr *OrderFilterError
+		require.ErrorAs(t, err, &filterErr)
+		assert.Equal(t, FilterMinNotional, filterErr.Filter)
+		assert.Error(t, filterErr.Err)
+		mockClient.AssertNotCalled(t, "PlaceOrder")
+	})
+
+	t.Run("rejects quantity rounded below min qty", func(t *testing.T) {
+		mockSymbolRepo := new(MockSymbolRepository)
+		service := NewMexcTradeService(new(MockMexcClient), &MarketDataService{logger: &logger}, mockSymbolRepo, new(MockOrderRepository), &logger)
+
+		mockSymbolRepo.On("GetBySymbol", ctx, symbol).Return(symbolInfo, nil)
+
+		_, err := service.PlaceOrder(ctx, &model.OrderRequest{
+			Symbol:   symbol,
+			Side:     model.OrderSideBuy,
+			Type:     model.OrderTypeLimit,
+			Quantity: 0.00009,
+			Price:    50000.0,
+		})
+
+		var filterErr *OrderFilterError
+		require.ErrorAs(t, err, &filterErr)
+		assert.Equal(t, FilterLotSize, filterErr.Filter)
+	})
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/rs/zerolog"
+)
+
+// TrailDistance is how far a trailing stop stays below the highest price: Ticks of the
+// symbol's price increments when set, otherwise Percent of the highest price.
+type TrailDistance struct {
+	Percent float64
+	Ticks   int
+}
+
+// TrailingStopManager keeps a trailing stop on every open long position. Each pass reads
+// the latest ticker of the position's symbol, raises the stop when the price makes a new
+// high, and sells the position at market once the price retraces to the stop. Stops are
+// persisted, so a restart resumes from the highest price already seen.
+type TrailingStopManager struct {
+	positionUC usecase.PositionUseCase
+	tradeUC    usecase.TradeUseCase
+	tickers    TickerSource
+	symbols    port.SymbolRepository
+	stops      port.TrailingStopRepository
+	distance   TrailDistance
+	interval   time.Duration
+	logger     *zerolog.Logger
+	now        func() time.Time
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewTrailingStopManager creates a TrailingStopManager that checks positions every interval.
+// Exits are placed through tradeUC. symbols provides tick sizes and may be nil when the
+// distance is a percentage.
+func NewTrailingStopManager(
+	positionUC usecase.PositionUseCase,
+	tradeUC usecase.TradeUseCase,
+	tickers TickerSource,
+	symbols port.SymbolRepository,
+	stops port.TrailingStopRepository,
+	distance TrailDistance,
+	interval time.Duration,
+	logger *zerolog.Logger,
+) *TrailingStopManager {
+	return &TrailingStopManager{
+		positionUC: positionUC,
+		tradeUC:    tradeUC,
+		tickers:    tickers,
+		symbols:    symbols,
+		stops:      stops,
+		distance:   distance,
+		interval:   interval,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// Start checks the positions every interval until Stop is called or ctx ends
+func (m *TrailingStopManager) Start(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.cancel != nil {
+		return fmt.Errorf("trailing stop manager is already running")
+	}
+
+	ctx, m.cancel = context.WithCancel(ctx)
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := m.Check(ctx); err != nil {
+					m.logger.Error().Err(err).Msg("Failed to check trailing stops")
+				}
+			}
+		}
+	}()
+
+	m.logger.Info().Dur("interval", m.interval).Msg("Trailing stop manager started")
+	return nil
+}
+
+// Stop stops checking the positions and waits for a running check to finish
+func (m *TrailingStopManager) Stop() {
+	m.mu.Lock()
+	cancel := m.cancel
+	m.cancel = nil
+	m.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		m.wg.Wait()
+		m.logger.Info().Msg("Trailing stop manager stopped")
+	}
+}
+
+// Check runs one pass over the open positions. A position whose price cannot be read is
+// skipped until the next pass, and the stops of positions no longer open are removed.
+func (m *TrailingStopManager) Check(ctx context.Context) error {
+	positions, err := m.positionUC.GetOpenPositions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get open positions: %w", err)
+	}
+	stored, err := m.stops.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get trailing stops: %w", err)
+	}
+
+	stops := make(map[string]*model.TrailingStop, len(stored))
+	for _, stop := range stored {
+		stops[stop.PositionID] = stop
+	}
+
+	open := make(map[string]bool, len(positions))
+	for _, position := range positions {
+		if position.Side != model.PositionSideLong {
+			continue
+		}
+		open[position.ID] = true
+		if err := m.checkPosition(ctx, position, stops[position.ID]); err != nil {
+			m.logger.Error().Err(err).
+				Str("positionId", position.ID).
+				Str("symbol", position.Symbol).
+				Msg("Failed to check trailing stop")
+		}
+	}
+
+	for positionID := range stops {
+		if open[positionID] {
+			continue
+		}
+		if err := m.stops.Delete(ctx, positionID); err != nil {
+			m.logger.Error().Err(err).Str("positionId", positionID).Msg("Failed to remove trailing stop of closed position")
+		}
+	}
+	return nil
+}
+
+// checkPosition moves the stop of one position up to its new high, or exits the position
+// when the price has fallen to the stop
+func (m *TrailingStopManager) checkPosition(ctx context.Context, position *model.Position, stop *model.TrailingStop) error {
+	ticker, err := m.tickers.GetTicker(ctx, position.Symbol)
+	if err != nil {
+		return fmt.Errorf("failed to get ticker: %w", err)
+	}
+	price := ticker.Price
+	if price <= 0 {
+		return fmt.Errorf("ticker of %s has no price", position.Symbol)
+	}
+
+	if stop == nil {
+		stop = &model.TrailingStop{PositionID: position.ID, Symbol: position.Symbol}
+	}
+
+	if price > stop.HighestPrice {
+		distance, err := m.trailDistance(ctx, position.Symbol, price)
+		if err != nil {
+			return err
+		}
+		stop.HighestPrice = price
+		// The stop only ever moves up
+		if next := price - distance; next > stop.StopPrice {
+			stop.StopPrice = next
+		}
+		stop.UpdatedAt = m.now()
+		if err := m.stops.Save(ctx, stop); err != nil {
+			return fmt.Errorf("failed to save trailing stop: %w", err)
+		}
+		return nil
+	}
+
+	if price <= stop.StopPrice {
+		return m.exit(ctx, position, stop, price)
+	}
+	return nil
+}
+
+// exit sells the position at market and closes it
+func (m *TrailingStopManager) exit(ctx context.Context, position *model.Position, stop *model.TrailingStop, price float64) error {
+	m.logger.Info().
+		Str("positionId", position.ID).
+		Str("symbol", position.Symbol).
+		Float64("highestPrice", stop.HighestPrice).
+		Float64("stopPrice", stop.StopPrice).
+		Float64("currentPrice", price).
+		Msg("Trailing stop triggered")
+
+	order, err := m.tradeUC.PlaceOrder(ctx, model.OrderRequest{
+		Symbol:   position.Symbol,
+		Side:     model.OrderSideSell,
+		Type:     model.OrderTypeMarket,
+		Quantity: position.Quantity,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to place trailing stop exit: %w", err)
+	}
+
+	if _, err := m.positionUC.ClosePosition(ctx, position.ID, price, []string{order.ID}); err != nil {
+		return fmt.Errorf("failed to close position after trailing stop exit: %w", err)
+	}
+	if err := m.stops.Delete(ctx, position.ID); err != nil {
+		// The closed position's stop is removed on the next pass
+		m.logger.Warn().Err(err).Str("positionId", position.ID).Msg("Failed to remove trailing stop")
+	}
+
+	m.logger.Info().
+		Str("positionId", position.ID).
+		Str("symbol", position.Symbol).
+		Float64("exitPrice", price).
+		Msg("Position closed by trailing stop")
+	return nil
+}
+
+// trailDistance returns the distance between the stop and a high of price
+func (m *TrailingStopManager) trailDistance(ctx context.Context, symbol string, price float64) (float64, error) {
+	if m.distance.Ticks <= 0 {
+		return price * m.distance.Percent / 100, nil
+	}
+	if m.symbols == nil {
+		return 0, fmt.Errorf("no symbol repository to read the tick size of %s", symbol)
+	}
+	info, err := m.symbols.GetBySymbol(ctx, strings.ToUpper(symbol))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get tick size of %s: %w", symbol, err)
+	}
+	if info == nil || info.TickSize <= 0 {
+		return 0, fmt.Errorf("unknown tick size of %s", symbol)
+	}
+	return float64(m.distance.Ticks) * info.TickSize, nil
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubTrailingPositions serves open positions and records the ones closed
+type stubTrailingPositions struct {
+	usecase.PositionUseCase
+	open   []*model.Position
+	closed map[string]float64
+}
+
+func (s *stubTrailingPositions) GetOpenPositions(ctx context.Context) ([]*model.Position, error) {
+	return s.open, nil
+}
+
+func (s *stubTrailingPositions) ClosePosition(ctx context.Context, id string, exitPrice float64, exitOrderIDs []string) (*model.Position, error) {
+	if s.closed == nil {
+		s.closed = make(map[string]float64)
+	}
+	s.closed[id] = exitPrice
+	return &model.Position{ID: id, Status: model.PositionStatusClosed}, nil
+}
+
+// stubTrailingTrades records the orders placed
+type stubTrailingTrades struct {
+	usecase.TradeUseCase
+	orders []model.OrderRequest
+}
+
+func (s *stubTrailingTrades) PlaceOrder(ctx context.Context, req model.OrderRequest) (*model.Order, error) {
+	s.orders = append(s.orders, req)
+	return &model.Order{ID: "exit-1"}, nil
+}
+
+// memTrailingStops keeps trailing stops in memory
+type memTrailingStops map[string]*model.TrailingStop
+
+func (m memTrailingStops) Save(ctx context.Context, stop *model.TrailingStop) error {
+	saved := *stop
+	m[stop.PositionID] = &saved
+	return nil
+}
+
+func (m memTrailingStops) GetAll(ctx context.Context) ([]*model.TrailingStop, error) {
+	stops := make([]*model.TrailingStop, 0, len(m))
+	for _, stop := range m {
+		saved := *stop
+		stops = append(stops, &saved)
+	}
+	return stops, nil
+}
+
+func (m memTrailingStops) Delete(ctx context.Context, positionID string) error {
+	delete(m, positionID)
+	return nil
+}
+
+// stubTickSizes serves the tick size of every symbol
+type stubTickSizes struct {
+	port.SymbolRepository
+	tickSize float64
+}
+
+func (s stubTickSizes) GetBySymbol(ctx context.Context, symbol string) (*market.Symbol, error) {
+	return &market.Symbol{Symbol: symbol, TickSize: s.tickSize}, nil
+}
+
+func newTestTrailingStopManager(positions *stubTrailingPositions, trades *stubTrailingTrades, tickers stubTickerSource, symbols port.SymbolRepository, stops memTrailingStops, distance TrailDistance) *TrailingStopManager {
+	logger := zerolog.Nop()
+	return NewTrailingStopManager(positions, trades, tickers, symbols, stops, distance, 0, &logger)
+}
+
+func longPosition() *model.Position {
+	return &model.Position{ID: "pos-1", Symbol: "BTCUSDT", Side: model.PositionSideLong, Status: model.PositionStatusOpen, EntryPrice: 100, Quantity: 0.5}
+}
+
+func TestTrailingStopManager_ExitsWhenPriceRetracesPastStop(t *testing.T) {
+	ctx := context.Background()
+	positions := &stubTrailingPositions{open: []*model.Position{longPosition()}}
+	trades := &stubTrailingTrades{}
+	tickers := stubTickerSource{}
+	stops := memTrailingStops{}
+	manager := newTestTrailingStopManager(positions, trades, tickers, nil, stops, TrailDistance{Percent: 5})
+
+	for _, step := range []struct {
+		price    float64
+		wantStop float64
+	}{
+		{price: 100, wantStop: 95},
+		{price: 110, wantStop: 104.5},
+		{price: 120, wantStop: 114},
+		{price: 116, wantStop: 114}, // a retrace above the stop leaves it in place
+		{price: 118, wantStop: 114}, // as does a rise below the high
+	} {
+		tickers["BTCUSDT"] = &market.Ticker{Symbol: "BTCUSDT", Price: step.price}
+		require.NoError(t, manager.Check(ctx))
+		require.Contains(t, stops, "pos-1")
+		assert.InDelta(t, step.wantStop, stops["pos-1"].StopPrice, 1e-9, "stop at price %v", step.price)
+		assert.Empty(t, trades.orders, "no exit at price %v", step.price)
+	}
+	assert.Equal(t, 120.0, stops["pos-1"].HighestPrice)
+
+	tickers["BTCUSDT"] = &market.Ticker{Symbol: "BTCUSDT", Price: 113.9}
+	require.NoError(t, manager.Check(ctx))
+
+	require.Len(t, trades.orders, 1)
+	assert.Equal(t, model.OrderRequest{Symbol: "BTCUSDT", Side: model.OrderSideSell, Type: model.OrderTypeMarket, Quantity: 0.5}, trades.orders[0])
+	assert.Equal(t, map[string]float64{"pos-1": 113.9}, positions.closed)
+	assert.NotContains(t, stops, "pos-1", "the stop of an exited position is removed")
+}
+
+func TestTrailingStopManager_ResumesFromPersistedState(t *testing.T) {
+	ctx := context.Background()
+	stops := memTrailingStops{}
+	tickers := stubTickerSource{"BTCUSDT": {Symbol: "BTCUSDT", Price: 120}}
+	positions := &stubTrailingPositions{open: []*model.Position{longPosition()}}
+	trades := &stubTrailingTrades{}
+
+	before := newTestTrailingStopManager(positions, trades, tickers, nil, stops, TrailDistance{Percent: 5})
+	require.NoError(t, before.Check(ctx))
+
+	// A new manager, as after a restart, keeps trailing from the stored high
+	after := newTestTrailingStopManager(positions, trades, tickers, nil, stops, TrailDistance{Percent: 5})
+	tickers["BTCUSDT"] = &market.Ticker{Symbol: "BTCUSDT", Price: 115}
+	require.NoError(t, after.Check(ctx))
+	assert.Empty(t, trades.orders)
+
+	tickers["BTCUSDT"] = &market.Ticker{Symbol: "BTCUSDT", Price: 114}
+	require.NoError(t, after.Check(ctx))
+	require.Len(t, trades.orders, 1)
+	assert.Equal(t, 114.0, positions.closed["pos-1"])
+}
+
+func TestTrailingStopManager_TrailsByTicks(t *testing.T) {
+	ctx := context.Background()
+	short := &model.Position{ID: "pos-2", Symbol: "ETHUSDT", Side: model.PositionSideShort, Quantity: 1}
+	positions := &stubTrailingPositions{open: []*model.Position{longPosition(), short}}
+	tickers := stubTickerSource{
+		"BTCUSDT": {Symbol: "BTCUSDT", Price: 100},
+		"ETHUSDT": {Symbol: "ETHUSDT", Price: 2000},
+	}
+	stops := memTrailingStops{"pos-gone": {PositionID: "pos-gone", Symbol: "SOLUSDT", HighestPrice: 150, StopPrice: 140}}
+	manager := newTestTrailingStopManager(positions, &stubTrailingTrades{}, tickers, stubTickSizes{tickSize: 0.5}, stops, TrailDistance{Ticks: 4})
+
+	require.NoError(t, manager.Check(ctx))
+
+	require.Contains(t, stops, "pos-1")
+	assert.Equal(t, 98.0, stops["pos-1"].StopPrice)
+	assert.NotContains(t, stops, "pos-2", "short positions are not trailed")
+	assert.NotContains(t, stops, "pos-gone", "stops of positions no longer open are removed")
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+)
+
+// ErrTWAPGuardBandBreached is returned when a TWAP execution is aborted because the price
+// moved further from its starting price than the guard band allows
+var ErrTWAPGuardBandBreached = errors.New("price moved beyond the TWAP guard band")
+
+// TWAPOrder is a large market order to execute as a time-weighted average price
+type TWAPOrder struct {
+	UserID   string
+	Symbol   string
+	Side     model.OrderSide
+	Quantity float64       // Target quantity, rounded down to the symbol's step size
+	Slices   int           // Number of child orders the quantity is split into
+	Duration time.Duration // Time the child orders are spread over
+	// GuardBandPercent aborts the execution when the price moves more than this percentage
+	// away from the price before the first child order. Zero disables the guard band.
+	GuardBandPercent float64
+}
+
+// TWAPStatus is the outcome of a TWAP execution
+type TWAPStatus string
+
+const (
+	TWAPStatusCompleted TWAPStatus = "COMPLETED" // Every child order was placed
+	TWAPStatusAborted   TWAPStatus = "ABORTED"   // The price left the guard band
+	TWAPStatusFailed    TWAPStatus = "FAILED"    // A child order or a price lookup failed
+)
+
+// TWAPResult reports the child orders a TWAP execution placed
+type TWAPResult struct {
+	Symbol         string
+	Side           model.OrderSide
+	Status         TWAPStatus
+	TargetQuantity float64
+	PlacedQuantity float64
+	ReferencePrice float64 // Price before the first child order, zero without a guard band
+	Slices         []float64
+	Orders         []*model.OrderResponse
+	LastPrice      float64 // Price at the last guard band check
+	StartedAt      time.Time
+	FinishedAt     time.Time
+}
+
+// TWAPExecutor executes large orders as a time-weighted average price: the quantity is
+// split into equal child market orders, rounded to the symbol's step size, which are placed
+// through a TradeService at even intervals. Before every child order the price is checked
+// against the guard band, and the execution stops when it has moved too far.
+type TWAPExecutor struct {
+	trades  port.TradeService
+	tickers TickerSource
+	symbols port.SymbolRepository
+	logger  *zerolog.Logger
+	now     func() time.Time
+	sleep   func(ctx context.Context, d time.Duration) error
+}
+
+// NewTWAPExecutor creates a TWAPExecutor placing child orders through trades. tickers may
+// be nil when no order uses a guard band.
+func NewTWAPExecutor(
+	trades port.TradeService,
+	tickers TickerSource,
+	symbols port.SymbolRepository,
+	logger *zerolog.Logger,
+) *TWAPExecutor {
+	return &TWAPExecutor{
+		trades:  trades,
+		tickers: tickers,
+		symbols: symbols,
+		logger:  logger,
+		now:     time.Now,
+		sleep:   sleepContext,
+	}
+}
+
+// Execute places the child orders of order, waiting Duration/Slices between them, and
+// blocks until the last one is placed. When the execution stops early, because the guard
+// band was breached, a child order failed or ctx ended, the result reports the child
+// orders already placed alongside the error. A breach of the guard band wraps
+// ErrTWAPGuardBandBreached.
+func (e *TWAPExecutor) Execute(ctx context.Context, order TWAPOrder) (*TWAPResult, error) {
+	if order.Quantity <= 0 {
+		return nil, fmt.Errorf("TWAP quantity must be positive")
+	}
+	if order.Slices <= 0 {
+		return nil, fmt.Errorf("TWAP needs at least one slice")
+	}
+	if order.GuardBandPercent > 0 && e.tickers == nil {
+		return nil, fmt.Errorf("no ticker source to check the TWAP guard band")
+	}
+
+	symbol := strings.ToUpper(order.Symbol)
+	info, err := e.symbols.GetBySymbol(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get symbol %s: %w", symbol, err)
+	}
+	if info == nil {
+		return nil, fmt.Errorf("unknown symbol %s", symbol)
+	}
+
+	slices := TWAPSlices(order.Quantity, order.Slices, info.StepSize)
+	if len(slices) == 0 {
+		return nil, fmt.Errorf("TWAP quantity %v is below the step size %v of %s", order.Quantity, info.StepSize, symbol)
+	}
+
+	result := &TWAPResult{
+		Symbol:    symbol,
+		Side:      order.Side,
+		Slices:    slices,
+		StartedAt: e.now(),
+	}
+	for _, slice := range slices {
+		result.TargetQuantity = model.NewDecimalFromFloat(result.TargetQuantity).Add(model.NewDecimalFromFloat(slice)).Float64()
+	}
+	interval := order.Duration / time.Duration(len(slices))
+
+	e.logger.Info().
+		Str("symbol", symbol).
+		Str("side", string(order.Side)).
+		Float64("quantity", result.TargetQuantity).
+		Int("slices", len(slices)).
+		Dur("interval", interval).
+		Msg("Starting TWAP execution")
+
+	err = e.execute(ctx, order, result, interval)
+	result.FinishedAt = e.now()
+	switch {
+	case err == nil:
+		result.Status = TWAPStatusCompleted
+	case errors.Is(err, ErrTWAPGuardBandBreached):
+		result.Status = TWAPStatusAborted
+	default:
+		result.Status = TWAPStatusFailed
+	}
+
+	logEvent := e.logger.Info()
+	if err != nil {
+		logEvent = e.logger.Warn().Err(err)
+	}
+	logEvent.Str("symbol", symbol).
+		Str("status", string(result.Status)).
+		Float64("placedQuantity", result.PlacedQuantity).
+		Float64("targetQuantity", result.TargetQuantity).
+		Int("orders", len(result.Orders)).
+		Msg("TWAP execution finished")
+	return result, err
+}
+
+// execute places the slices of result in turn, recording each placed order in result
+func (e *TWAPExecutor) execute(ctx context.Context, order TWAPOrder, result *TWAPResult, interval time.Duration) error {
+	for i, quantity := range result.Slices {
+		if i > 0 {
+			if err := e.sleep(ctx, interval); err != nil {
+				return err
+			}
+		}
+		if err := e.checkGuardBand(ctx, order, result); err != nil {
+			return err
+		}
+
+		response, err := e.trades.PlaceOrder(ctx, &model.OrderRequest{
+			UserID:   order.UserID,
+			Symbol:   result.Symbol,
+			Side:     order.Side,
+			Type:     model.OrderTypeMarket,
+			Quantity: quantity,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to place TWAP slice %d of %d: %w", i+1, len(result.Slices), err)
+		}
+		result.Orders = append(result.Orders, response)
+		result.PlacedQuantity = model.NewDecimalFromFloat(result.PlacedQuantity).Add(model.NewDecimalFromFloat(quantity)).Float64()
+	}
+	return nil
+}
+
+// checkGuardBand reads the price and fails when it is outside the guard band around the
+// reference price, which the first check sets
+func (e *TWAPExecutor) checkGuardBand(ctx context.Context, order TWAPOrder, result *TWAPResult) error {
+	if order.GuardBandPercent <= 0 {
+		return nil
+	}
+	ticker, err := e.tickers.GetTicker(ctx, result.Symbol)
+	if err != nil {
+		return fmt.Errorf("failed to get ticker: %w", err)
+	}
+	if ticker == nil || ticker.Price <= 0 {
+		return fmt.Errorf("ticker of %s has no price", result.Symbol)
+	}
+
+	result.LastPrice = ticker.Price
+	if result.ReferencePrice == 0 {
+		result.ReferencePrice = ticker.Price
+		return nil
+	}
+	moved := math.Abs(ticker.Price-result.ReferencePrice) / result.ReferencePrice * 100
+	if moved > order.GuardBandPercent {
+		return fmt.Errorf("%w: %s moved %.2f%% from %v to %v, more than %v%%",
+			ErrTWAPGuardBandBreached, result.Symbol, moved, result.ReferencePrice, ticker.Price, order.GuardBandPercent)
+	}
+	return nil
+}
+
+// TWAPSlices splits quantity, rounded down to a multiple of step, into at most slices
+// quantities that are multiples of step and differ by at most one step. The slices sum to
+// the rounded quantity exactly; there are fewer of them when the quantity holds fewer
+// steps than slices. A non-positive step splits the quantity evenly.
+func TWAPSlices(quantity float64, slices int, step float64) []float64 {
+	if quantity <= 0 || slices <= 0 {
+		return nil
+	}
+	total := model.NewDecimalFromFloat(quantity)
+	count := model.NewDecimalFromFloat(float64(slices))
+
+	if step <= 0 {
+		slice := total.Div(count)
+		result := make([]float64, slices)
+		placed := model.NewDecimalFromFloat(0)
+		for i := 0; i < slices-1; i++ {
+			result[i] = slice.Float64()
+			placed = placed.Add(slice)
+		}
+		// The last slice takes what the division did not spread
+		result[slices-1] = total.Sub(placed).Float64()
+		return result
+	}
+
+	stepSize := model.NewDecimalFromFloat(step)
+	steps := int64(math.Round(total.RoundDownToStep(stepSize).Div(stepSize).Float64()))
+	if steps < int64(slices) {
+		slices = int(steps)
+	}
+	result := make([]float64, slices)
+	for i := range result {
+		// The first steps % slices slices take one step more
+		n := steps / int64(slices)
+		if int64(i) < steps%int64(slices) {
+			n++
+		}
+		result[i] = stepSize.Mul(model.NewDecimalFromFloat(float64(n))).Float64()
+	}
+	return result
+}
+
+// sleepContext waits for d or until ctx ends
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTrades records the orders placed
+type recordingTrades struct {
+	port.TradeService
+	orders []model.OrderRequest
+}
+
+func (r *recordingTrades) PlaceOrder(ctx context.Context, request *model.OrderRequest) (*model.OrderResponse, error) {
+	r.orders = append(r.orders, *request)
+	return &model.OrderResponse{Order: model.Order{Symbol: request.Symbol, Quantity: request.Quantity}, IsSuccess: true}, nil
+}
+
+// stubStepSizes serves the step size of every symbol
+type stubStepSizes struct {
+	port.SymbolRepository
+	stepSize float64
+}
+
+func (s stubStepSizes) GetBySymbol(ctx context.Context, symbol string) (*market.Symbol, error) {
+	return &market.Symbol{Symbol: symbol, StepSize: s.stepSize}, nil
+}
+
+// newTestTWAPExecutor returns an executor that does not wait between slices but calls
+// onSleep with the number of slices placed so far, and the waits it was asked for
+func newTestTWAPExecutor(trades port.TradeService, tickers TickerSource, stepSize float64, onSleep func(placed int)) (*TWAPExecutor, *[]time.Duration) {
+	logger := zerolog.Nop()
+	executor := NewTWAPExecutor(trades, tickers, stubStepSizes{stepSize: stepSize}, &logger)
+	var waits []time.Duration
+	executor.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		if onSleep != nil {
+			onSleep(len(waits))
+		}
+		return nil
+	}
+	return executor, &waits
+}
+
+func sumQuantities(quantities []float64) float64 {
+	sum := model.NewDecimalFromFloat(0)
+	for _, quantity := range quantities {
+		sum = sum.Add(model.NewDecimalFromFloat(quantity))
+	}
+	return sum.Float64()
+}
+
+func TestTWAPSlices(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity float64
+		slices   int
+		step     float64
+		want     []float64
+	}{
+		{name: "even split", quantity: 1, slices: 4, step: 0.001, want: []float64{0.25, 0.25, 0.25, 0.25}},
+		{name: "remainder spread one step at a time", quantity: 1, slices: 3, step: 0.001, want: []float64{0.334, 0.333, 0.333}},
+		{name: "quantity rounded down to step", quantity: 0.30049, slices: 3, step: 0.001, want: []float64{0.1, 0.1, 0.1}},
+		{name: "fewer steps than slices", quantity: 0.3, slices: 5, step: 0.1, want: []float64{0.1, 0.1, 0.1}},
+		{name: "below one step", quantity: 0.05, slices: 5, step: 0.1, want: []float64{}},
+		{name: "no step", quantity: 1, slices: 3, step: 0, want: []float64{0.3333333333333333, 0.3333333333333333, 0.3333333333333334}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slices := TWAPSlices(tt.quantity, tt.slices, tt.step)
+			assert.Equal(t, tt.want, slices)
+		})
+	}
+}
+
+func TestTWAPSlices_SumToTarget(t *testing.T) {
+	for _, slices := range []int{1, 2, 3, 7, 10, 13} {
+		quantities := TWAPSlices(12.345, slices, 0.001)
+		assert.Len(t, quantities, slices)
+		assert.Equal(t, 12.345, sumQuantities(quantities), "%d slices", slices)
+	}
+}
+
+func TestTWAPExecutor_PlacesEverySlice(t *testing.T) {
+	trades := &recordingTrades{}
+	tickers := stubTickerSource{"BTCUSDT": {Symbol: "BTCUSDT", Price: 100}}
+	executor, waits := newTestTWAPExecutor(trades, tickers, 0.001, nil)
+
+	result, err := executor.Execute(context.Background(), TWAPOrder{
+		UserID:           "user-1",
+		Symbol:           "btcusdt",
+		Side:             model.OrderSideBuy,
+		Quantity:         2,
+		Slices:           3,
+		Duration:         30 * time.Minute,
+		GuardBandPercent: 1,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, TWAPStatusCompleted, result.Status)
+	assert.Equal(t, 2.0, result.TargetQuantity)
+	assert.Equal(t, 2.0, result.PlacedQuantity)
+	assert.Equal(t, 100.0, result.ReferencePrice)
+	assert.Len(t, result.Orders, 3)
+	assert.Equal(t, []time.Duration{10 * time.Minute, 10 * time.Minute}, *waits)
+
+	require.Len(t, trades.orders, 3)
+	var placed []float64
+	for _, order := range trades.orders {
+		assert.Equal(t, "user-1", order.UserID)
+		assert.Equal(t, "BTCUSDT", order.Symbol)
+		assert.Equal(t, model.OrderSideBuy, order.Side)
+		assert.Equal(t, model.OrderTypeMarket, order.Type)
+		placed = append(placed, order.Quantity)
+	}
+	assert.Equal(t, []float64{0.667, 0.667, 0.666}, placed)
+	assert.Equal(t, 2.0, sumQuantities(placed))
+}
+
+func TestTWAPExecutor_GuardBandBreachHaltsSlices(t *testing.T) {
+	trades := &recordingTrades{}
+	tickers := stubTickerSource{"BTCUSDT": {Symbol: "BTCUSDT", Price: 100}}
+	executor, _ := newTestTWAPExecutor(trades, tickers, 0.01, func(placed int) {
+		// The price drifts within the band, then jumps out of it
+		prices := map[int]float64{1: 101.5, 2: 97.9}
+		tickers["BTCUSDT"] = &market.Ticker{Symbol: "BTCUSDT", Price: prices[placed]}
+	})
+
+	result, err := executor.Execute(context.Background(), TWAPOrder{
+		Symbol:           "BTCUSDT",
+		Side:             model.OrderSideSell,
+		Quantity:         1,
+		Slices:           4,
+		Duration:         time.Hour,
+		GuardBandPercent: 2,
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrTWAPGuardBandBreached))
+
+	require.NotNil(t, result)
+	assert.Equal(t, TWAPStatusAborted, result.Status)
+	assert.Len(t, trades.orders, 2, "no slice is placed after the breach")
+	assert.Len(t, result.Orders, 2)
+	assert.Equal(t, 0.5, result.PlacedQuantity)
+	assert.Equal(t, 1.0, result.TargetQuantity)
+	assert.Equal(t, 100.0, result.ReferencePrice)
+	assert.Equal(t, 97.9, result.LastPrice)
+}
+
+func TestTWAPExecutor_ReportsPartialsWhenASliceFails(t *testing.T) {
+	trades := &scriptedTrades{}
+	executor, _ := newTestTWAPExecutor(trades, nil, 0.1, func(placed int) {
+		if placed == 1 {
+			trades.errs = []error{errors.New("insufficient balance")}
+		}
+	})
+
+	result, err := executor.Execute(context.Background(), TWAPOrder{
+		Symbol:   "BTCUSDT",
+		Side:     model.OrderSideBuy,
+		Quantity: 0.9,
+		Slices:   3,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "slice 2 of 3")
+	assert.False(t, errors.Is(err, ErrTWAPGuardBandBreached))
+
+	assert.Equal(t, TWAPStatusFailed, result.Status)
+	assert.Equal(t, 2, trades.calls)
+	assert.Len(t, result.Orders, 1)
+	assert.Equal(t, 0.3, result.PlacedQuantity)
+}
+
+func TestTWAPExecutor_RejectsInvalidOrders(t *testing.T) {
+	executor, _ := newTestTWAPExecutor(&recordingTrades{}, nil, 0.1, nil)
+
+	for name, order := range map[string]TWAPOrder{
+		"no quantity":                {Symbol: "BTCUSDT", Quantity: 0, Slices: 2},
+		"no slices":                  {Symbol: "BTCUSDT", Quantity: 1, Slices: 0},
+		"below the step size":        {Symbol: "BTCUSDT", Quantity: 0.05, Slices: 2},
+		"guard band without tickers": {Symbol: "BTCUSDT", Quantity: 1, Slices: 2, GuardBandPercent: 1},
+	} {
+		_, err := executor.Execute(context.Background(), order)
+		assert.Error(t, err, name)
+	}
+}
@@ -149,3 +149,59 @@ func (s *UserService) EnsureUserExists(ctx context.Context, id, email, name stri
 	// User doesn't exist, create new user
 	return s.CreateUser(ctx, id, email, name)
 }
+
+// SyncUser creates or updates a user from the identity provider's record, reactivating a
+// user that was deactivated
+func (s *UserService) SyncUser(ctx context.Context, id, email, name string) (*model.User, error) {
+	if id == "" {
+		return nil, errors.New("user ID is required")
+	}
+	if email == "" {
+		return nil, errors.New("email is required")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, model.ErrInvalidUserID) {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		user = model.NewUser(id, email, name)
+	} else {
+		user.Email = email
+		user.Name = name
+		user.DeactivatedAt = nil
+		user.UpdatedAt = time.Now()
+	}
+
+	if err := s.userRepo.Save(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+	return user, nil
+}
+
+// DeactivateUser marks a user as deleted at the identity provider. The record is kept so
+// the user's orders and history stay intact; an unknown user is ignored.
+func (s *UserService) DeactivateUser(ctx context.Context, id string) error {
+	if id == "" {
+		return errors.New("user ID is required")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidUserID) {
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.IsActive() {
+		return nil
+	}
+
+	now := time.Now()
+	user.DeactivatedAt = &now
+	user.UpdatedAt = now
+	if err := s.userRepo.Save(ctx, user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+	return nil
+}
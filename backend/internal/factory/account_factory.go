@@ -49,3 +49,8 @@ func (f *AccountFactory) CreateAccountHandler(mexcClient port.MEXCClient) *handl
 	// Create handler
 	return handler.NewAccountHandler(accountUseCase, f.logger)
 }
+
+// CreateAccountSnapshotUseCase creates the use case behind the account snapshot endpoint
+func (f *AccountFactory) CreateAccountSnapshotUseCase(trades usecase.TradeUseCase) *usecase.AccountSnapshotUseCase {
+	return usecase.NewAccountSnapshotUseCase(f.CreateAccountRepository(), trades, f.logger)
+}
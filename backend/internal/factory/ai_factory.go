@@ -3,27 +3,40 @@ package factory
 import (
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/delivery/http/handler"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/gateway/ai"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/repo"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/memory"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/pkg/platform/mexc"
 	"github.com/rs/zerolog"
+	"gorm.io/gorm"
 )
 
 // AIFactory creates AI-related components
 type AIFactory struct {
-	config *config.Config
-	logger zerolog.Logger
+	config      *config.Config
+	logger      zerolog.Logger
+	db          *gorm.DB
+	mexcBreaker *mexc.CircuitBreaker
 }
 
-// NewAIFactory creates a new AIFactory
-func NewAIFactory(config *config.Config, logger zerolog.Logger) *AIFactory {
+// NewAIFactory creates a new AIFactory. Conversations are persisted in db when it is
+// not nil and kept in memory otherwise.
+func NewAIFactory(config *config.Config, logger zerolog.Logger, db *gorm.DB) *AIFactory {
 	return &AIFactory{
 		config: config,
 		logger: logger.With().Str("component", "ai_factory").Logger(),
+		db:     db,
 	}
 }
 
+// WithMEXCCircuitBreaker makes the MEXC clients created afterwards share breaker
+func (f *AIFactory) WithMEXCCircuitBreaker(breaker *mexc.CircuitBreaker) *AIFactory {
+	f.mexcBreaker = breaker
+	return f
+}
+
 // CreateAIService creates an AIService based on the configuration
 func (f *AIFactory) CreateAIService() (port.AIService, error) {
 	// Always use stub service for now until we fix the Gemini service
@@ -32,8 +45,9 @@ func (f *AIFactory) CreateAIService() (port.AIService, error) {
 
 // CreateConversationMemoryRepository creates a ConversationMemoryRepository
 func (f *AIFactory) CreateConversationMemoryRepository() port.ConversationMemoryRepository {
-	// For now, we'll use an in-memory repository
-	// In a real implementation, this would use a database
+	if f.db != nil {
+		return repo.NewGormAIConversationRepository(f.db, &f.logger)
+	}
 	return memory.NewConversationMemoryRepository(f.logger)
 }
 
@@ -55,8 +69,19 @@ func (f *AIFactory) CreateAIUsecase() (*usecase.AIUsecase, error) {
 	conversationMemoryRepo := f.CreateConversationMemoryRepository()
 	embeddingRepo := f.CreateEmbeddingRepository()
 
-	// Create usecase
-	return usecase.NewAIUsecase(aiService, conversationMemoryRepo, embeddingRepo, f.logger), nil
+	// Create usecase with live market tools the model can call
+	mexcClient := NewMEXCClientWithBreaker(f.config, f.mexcBreaker, &f.logger)
+	return usecase.NewAIUsecase(aiService, conversationMemoryRepo, embeddingRepo, f.logger).
+		WithTools(usecase.NewMarketTools(mexcClient)...), nil
+}
+
+// CreateAISignalUsecase creates an AISignalUsecase reading market data from marketRepo
+func (f *AIFactory) CreateAISignalUsecase(marketRepo port.MarketRepository) (*usecase.AISignalUsecase, error) {
+	aiService, err := f.CreateAIService()
+	if err != nil {
+		return nil, err
+	}
+	return usecase.NewAISignalUsecase(aiService, marketRepo, "mexc", f.logger), nil
 }
 
 // CreateAIHandler creates an AIHandler
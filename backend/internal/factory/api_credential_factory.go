@@ -44,8 +44,12 @@ func (f *APICredentialFactory) CreateAPICredentialHandler() *handler.APICredenti
 	// Create repository
 	repository := f.CreateAPICredentialRepository()
 
-	// Create use case
-	useCase := usecase.NewAPICredentialUseCase(repository, f.logger)
+	// Create use case, recording credential changes in the audit trail
+	useCase := usecase.NewAuditedAPICredentialUseCase(
+		usecase.NewAPICredentialUseCase(repository, f.logger),
+		repo.NewGormAuditLogger(f.db, f.logger),
+		f.logger,
+	)
 
 	// Create handler
 	return handler.NewAPICredentialHandler(useCase, f.logger)
@@ -69,14 +73,17 @@ func NewAPICredentialManagerFactory(cfg *config.Config, logger *zerolog.Logger,
 
 // CreateAPICredentialManagerService creates an API credential manager service
 func (f *APICredentialManagerFactory) CreateAPICredentialManagerService(
-	repo port.APICredentialRepository,
+	credentialRepo port.APICredentialRepository,
 	encryptionSvc crypto.EncryptionService,
 	providerRegistry *wallet.ProviderRegistry,
 ) usecase.APICredentialManagerService {
-	return usecase.NewAPICredentialManagerService(
-		repo,
+	service := usecase.NewAPICredentialManagerService(
+		credentialRepo,
 		encryptionSvc,
 		providerRegistry,
 		f.logger,
 	)
+
+	// Record credential changes, including rotation, in the audit trail
+	return usecase.NewAuditedAPICredentialManagerService(service, repo.NewGormAuditLogger(f.db, f.logger), f.logger)
 }
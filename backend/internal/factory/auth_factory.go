@@ -1,6 +1,7 @@
 package factory
 
 import (
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/delivery/http/handler"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/http/middleware"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/repo"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/service"
@@ -40,6 +41,13 @@ func (f *AuthFactory) CreateAuthService(secretKey string) (service.AuthServiceIn
 }
 
 
+// CreateClerkWebhookHandler creates the receiver of Clerk's user lifecycle webhooks
+func (f *AuthFactory) CreateClerkWebhookHandler(secret string) (*handler.ClerkWebhookHandler, error) {
+	users := service.NewUserService(f.CreateUserRepository())
+	events := repo.NewGormWebhookEventRepository(f.db, f.logger)
+	return handler.NewClerkWebhookHandler(secret, users, events, f.logger)
+}
+
 // CreateAuthMiddleware creates an authentication middleware
 func (f *AuthFactory) CreateAuthMiddleware(secret string) middleware.AuthMiddleware {
 	authService, _ := f.CreateAuthService(secret)
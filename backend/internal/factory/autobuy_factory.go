@@ -1,8 +1,12 @@
 package factory
 
 import (
+	"time"
+
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/delivery/http/handler"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/notification"
 	gormrepo "github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/repo"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
@@ -17,6 +21,10 @@ type AutoBuyFactory struct {
 	db            *gormdb.DB
 	marketFactory *MarketFactory
 	tradeFactory  *TradeFactory
+
+	orders         usecase.AutobuyOrderPlacer
+	alerts         *notification.AlertNotifier
+	autobuyService *usecase.AutobuyService
 }
 
 // NewAutoBuyFactory creates a new AutoBuyFactory
@@ -36,6 +44,18 @@ func NewAutoBuyFactory(
 	}
 }
 
+// WithTradeUseCase makes the autobuyer place its orders through orders
+func (f *AutoBuyFactory) WithTradeUseCase(orders usecase.AutobuyOrderPlacer) *AutoBuyFactory {
+	f.orders = orders
+	return f
+}
+
+// WithAlertNotifier makes the autobuyer raise its notifications as alerts on alerts
+func (f *AutoBuyFactory) WithAlertNotifier(alerts *notification.AlertNotifier) *AutoBuyFactory {
+	f.alerts = alerts
+	return f
+}
+
 // CreateAutoBuyRuleRepository creates a repository for auto-buy rules
 func (f *AutoBuyFactory) CreateAutoBuyRuleRepository() port.AutoBuyRuleRepository {
 	return gormrepo.NewAutoBuyRuleRepository(f.db, f.logger.With().Str("repository", "auto_buy_rule").Logger())
@@ -68,6 +88,55 @@ func (f *AutoBuyFactory) CreateAutoBuyUseCase() usecase.AutoBuyUseCase {
 	)
 }
 
+// CreateBudgetTracker creates the tracker of the new-listing autobuyer's spend, persisted so
+// the budget caps survive restarts
+func (f *AutoBuyFactory) CreateBudgetTracker() usecase.BudgetTracker {
+	logger := f.logger.With().Str("repository", "auto_buy_budget").Logger()
+	return repo.NewGormAutoBuyBudgetTracker(f.db, &logger)
+}
+
+// CreateAutobuyService creates the autobuyer of newly listed coins. It is created once, so
+// the HTTP handler and the release of closed positions share its budget and dry-run history.
+// Its orders go through the trade use case set with WithTradeUseCase and are checked
+// against the pre-trade risk limits.
+func (f *AutoBuyFactory) CreateAutobuyService() *usecase.AutobuyService {
+	if f.autobuyService == nil {
+		logger := f.logger.With().Str("component", "autobuy_service").Logger()
+		marketData := f.marketFactory.CreateMarketDataService()
+		alerts := f.alerts
+		if alerts == nil {
+			alerts = notification.NewAlertNotifier(f.logger, 100)
+		}
+		f.autobuyService = usecase.NewAutobuyService(
+			usecase.StaticAutoBuyConfig{Config: f.autobuyConfig()},
+			usecase.NewAutobuyCoinRepository(NewRepositoryFactory(f.db, f.logger, f.config).CreateNewCoinRepository(), &logger),
+			usecase.NewAutobuyMarketData(marketData),
+			usecase.NewAutobuyRisk(f.tradeFactory.CreateRiskManager(marketData)),
+			usecase.NewAutobuyTrader(f.orders),
+			notification.NewAutobuyNotifier(alerts, &logger),
+			f.CreateBudgetTracker(),
+			&logger,
+		)
+	}
+	return f.autobuyService
+}
+
+// autobuyConfig converts the autobuy section of the configuration for the autobuyer
+func (f *AutoBuyFactory) autobuyConfig() usecase.AutoBuyConfig {
+	cfg := f.config.Autobuy
+	return usecase.AutoBuyConfig{
+		Enabled:                cfg.Enabled,
+		MinPrice:               cfg.MinPrice,
+		MaxPrice:               cfg.MaxPrice,
+		MinVolume:              cfg.MinVolume,
+		DelaySeconds:           int(cfg.Delay / time.Second),
+		MaxTotalBudget:         cfg.MaxTotalBudget,
+		MaxPerCoin:             cfg.MaxPerCoin,
+		MaxConcurrentPositions: cfg.MaxConcurrentPositions,
+		DryRun:                 cfg.DryRun,
+	}
+}
+
 // CreateSymbolRepository creates a symbol repository
 func (f *AutoBuyFactory) CreateSymbolRepository() port.SymbolRepository {
 	// TODO: implement actual repository when needed
@@ -92,8 +161,10 @@ func (f *AutoBuyFactory) CreateRiskService() port.RiskService {
 	return nil
 }
 
-// CreateAutoBuyHandler creates the HTTP handler for auto-buy functionality
+// CreateAutoBuyHandler creates the HTTP handler for auto-buy functionality, serving the
+// dry-run history of the new-listing autobuyer
 func (f *AutoBuyFactory) CreateAutoBuyHandler() *handler.AutoBuyHandler {
 	autoBuyUseCase := f.CreateAutoBuyUseCase()
-	return handler.NewAutoBuyHandler(autoBuyUseCase, f.logger)
+	return handler.NewAutoBuyHandler(autoBuyUseCase, f.logger).
+		WithSimulatedBuys(f.CreateAutobuyService())
 }
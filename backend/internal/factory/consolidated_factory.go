@@ -11,6 +11,7 @@ import (
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/service"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/pkg/platform/mexc"
 	"github.com/rs/zerolog"
 	gormdb "gorm.io/gorm"
 )
@@ -21,13 +22,15 @@ type ConsolidatedFactory struct {
 	logger     *zerolog.Logger
 	cfg        *config.Config
 	mexcClient port.MEXCClient
+	breaker    *mexc.CircuitBreaker
 	txManager  port.TransactionManager
 }
 
 // NewConsolidatedFactory creates a new ConsolidatedFactory
 func NewConsolidatedFactory(db *gormdb.DB, logger *zerolog.Logger, cfg *config.Config) *ConsolidatedFactory {
 	// Create MEXC client
-	mexcClient := NewMEXCClient(cfg, logger)
+	breaker := NewMEXCCircuitBreaker(cfg, logger)
+	mexcClient := NewMEXCClientWithBreaker(cfg, breaker, logger)
 
 	// Create transaction manager
 	txManager := gorm.NewTransactionManager(db, logger)
@@ -37,15 +40,32 @@ func NewConsolidatedFactory(db *gormdb.DB, logger *zerolog.Logger, cfg *config.C
 		logger:     logger,
 		cfg:        cfg,
 		mexcClient: mexcClient,
+		breaker:    breaker,
 		txManager:  txManager,
 	}
 }
 
+// WithMEXCCircuitBreaker makes the factory's MEXC client share breaker, which the health
+// check then reports. A nil breaker keeps the factory's own.
+func (f *ConsolidatedFactory) WithMEXCCircuitBreaker(breaker *mexc.CircuitBreaker) *ConsolidatedFactory {
+	if breaker == nil {
+		return f
+	}
+	f.breaker = breaker
+	f.mexcClient = NewMEXCClientWithBreaker(f.cfg, breaker, f.logger)
+	return f
+}
+
 // GetMEXCClient returns the MEXC client
 func (f *ConsolidatedFactory) GetMEXCClient() port.MEXCClient {
 	return f.mexcClient
 }
 
+// GetMEXCCircuitBreaker returns the circuit breaker guarding the MEXC client
+func (f *ConsolidatedFactory) GetMEXCCircuitBreaker() *mexc.CircuitBreaker {
+	return f.breaker
+}
+
 // GetWalletRepository returns a wallet repository
 func (f *ConsolidatedFactory) GetWalletRepository() port.WalletRepository {
 	return repo.NewConsolidatedWalletRepository(f.db, f.logger)
@@ -2,13 +2,17 @@ package factory
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/cache/standard"
+	exchangeGateway "github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/gateway/exchange"
 	mexcGateway "github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/gateway/mexc"
 	gormAdapter "github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/service"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/pkg/platform/binance"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/pkg/platform/mexc"
 	"github.com/rs/zerolog"
 	"gorm.io/gorm"
@@ -21,6 +25,8 @@ type MarketFactory struct {
 	db           *gorm.DB
 	cacheFactory *CacheFactory
 	baseService  *service.MarketDataService
+	symbolRepo   port.SymbolRepository
+	mexcBreaker  *mexc.CircuitBreaker
 }
 
 // NewMarketFactory creates a new MarketFactory
@@ -33,11 +39,30 @@ func NewMarketFactory(cfg *config.Config, logger *zerolog.Logger, db *gorm.DB) *
 	}
 }
 
+// WithMEXCCircuitBreaker makes the MEXC clients created afterwards share breaker
+func (f *MarketFactory) WithMEXCCircuitBreaker(breaker *mexc.CircuitBreaker) *MarketFactory {
+	f.mexcBreaker = breaker
+	return f
+}
+
 // CreateMarketRepository creates a market data repository
 func (f *MarketFactory) CreateMarketRepository() (port.MarketRepository, port.SymbolRepository) {
 	repo := gormAdapter.NewMarketRepository(f.db, f.logger)
 	// GORM MarketRepository implements both interfaces
-	return repo, repo
+	return repo, f.sharedSymbolRepository(repo)
+}
+
+// sharedSymbolRepository returns the symbol repository used by everything the factory
+// creates. Symbol lookups are cached for market.cache.symbol_ttl seconds, and sharing
+// the cache lets a write through any component invalidate it for all.
+func (f *MarketFactory) sharedSymbolRepository(repo port.SymbolRepository) port.SymbolRepository {
+	if f.symbolRepo == nil {
+		f.symbolRepo = repo
+		if ttl := f.cfg.Market.Cache.SymbolTTL; ttl > 0 {
+			f.symbolRepo = standard.NewCachedSymbolRepository(repo, time.Duration(ttl)*time.Second)
+		}
+	}
+	return f.symbolRepo
 }
 
 // CreateMarketCache creates a market data cache
@@ -58,17 +83,30 @@ func (f *MarketFactory) CreateMarketDataUseCase() (*usecase.MarketDataUseCase, e
 	cache := f.CreateMarketCache()
 
 	uc := usecase.NewMarketDataUseCase(marketRepo, symbolRepo, cache, f.logger)
+	if history, ok := f.CreateMEXCClient().(port.KlineHistoryProvider); ok {
+		uc.WithKlineHistory(history)
+	}
 	return uc, nil
 }
 
+// CreateMarketSyncUseCase creates the use case that refreshes symbols and tickers on demand
+func (f *MarketFactory) CreateMarketSyncUseCase() *usecase.MarketSyncUseCase {
+	marketRepo, symbolRepo := f.CreateMarketRepository()
+	return usecase.NewMarketSyncUseCase(f.CreateMEXCClient(), symbolRepo, marketRepo, f.logger)
+}
+
 // CreateMEXCClient creates a MEXC API client
 func (f *MarketFactory) CreateMEXCClient() port.MEXCClient {
-	// Get API credentials from config
-	apiKey := f.cfg.MEXC.APIKey
-	apiSecret := f.cfg.MEXC.APISecret
+	return NewMEXCClientWithBreaker(f.cfg, f.mexcBreaker, f.logger)
+}
 
-	// Create the MEXC client
-	return mexc.NewClient(apiKey, apiSecret, f.logger)
+// CreateExchangeRegistry creates the registry of the supported exchanges. Binance is
+// registered for market data only.
+func (f *MarketFactory) CreateExchangeRegistry() port.ExchangeRegistry {
+	registry := exchangeGateway.NewRegistry()
+	registry.Register("mexc", f.CreateMEXCClient())
+	registry.RegisterMarketData(binance.ExchangeName, binance.NewClient(binance.DefaultBaseURL, f.logger))
+	return registry
 }
 
 // CreateMEXCGateway creates a MEXC gateway
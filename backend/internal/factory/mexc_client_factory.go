@@ -7,7 +7,29 @@ import (
 	"github.com/rs/zerolog"
 )
 
-// NewMEXCClient creates a new MEXC client
+// NewMEXCClient creates a new MEXC client targeting the configured production or
+// testnet endpoint, guarded by its own circuit breaker
 func NewMEXCClient(cfg *config.Config, logger *zerolog.Logger) port.MEXCClient {
-	return mexc.NewClient(cfg.MEXC.APIKey, cfg.MEXC.APISecret, logger)
+	return NewMEXCClientWithBreaker(cfg, nil, logger)
+}
+
+// NewMEXCClientWithBreaker creates a MEXC client guarded by breaker, which may be shared
+// with other clients and reported by the health check. A nil breaker gives the client
+// its own.
+func NewMEXCClientWithBreaker(cfg *config.Config, breaker *mexc.CircuitBreaker, logger *zerolog.Logger) port.MEXCClient {
+	if breaker == nil {
+		breaker = NewMEXCCircuitBreaker(cfg, logger)
+	}
+	baseURL, _ := cfg.MEXCEndpoints()
+	client := mexc.NewClientWithBaseURL(cfg.MEXC.APIKey, cfg.MEXC.APISecret, baseURL, logger,
+		mexc.WithExchangeInfoTTL(cfg.MEXC.ExchangeInfoTTL))
+	return mexc.NewCircuitBreakerClient(client, breaker)
+}
+
+// NewMEXCCircuitBreaker creates a circuit breaker from the MEXC configuration
+func NewMEXCCircuitBreaker(cfg *config.Config, logger *zerolog.Logger) *mexc.CircuitBreaker {
+	return mexc.NewCircuitBreaker(mexc.CircuitBreakerConfig{
+		FailureThreshold: cfg.MEXC.CircuitBreaker.FailureThreshold,
+		Cooldown:         cfg.MEXC.CircuitBreaker.Cooldown,
+	}, logger)
 }
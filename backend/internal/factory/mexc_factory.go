@@ -9,8 +9,9 @@ import (
 
 // MEXCFactory creates MEXC-related components
 type MEXCFactory struct {
-	cfg    *config.Config
-	logger *zerolog.Logger
+	cfg     *config.Config
+	logger  *zerolog.Logger
+	breaker *mexc.CircuitBreaker
 }
 
 // NewMEXCFactory creates a new MEXCFactory
@@ -21,11 +22,25 @@ func NewMEXCFactory(cfg *config.Config, logger *zerolog.Logger) *MEXCFactory {
 	}
 }
 
+// WithMEXCCircuitBreaker makes the MEXC clients created afterwards share breaker
+func (f *MEXCFactory) WithMEXCCircuitBreaker(breaker *mexc.CircuitBreaker) *MEXCFactory {
+	f.breaker = breaker
+	return f
+}
+
 // CreateMEXCClient creates a MEXC client
 func (f *MEXCFactory) CreateMEXCClient() port.MEXCClient {
-	apiKey := f.cfg.MEXC.APIKey
-	apiSecret := f.cfg.MEXC.APISecret
+	f.logger.Debug().Bool("testnet", f.cfg.MEXC.UseTestnet).Msg("Creating MEXC client")
+	return NewMEXCClientWithBreaker(f.cfg, f.breaker, f.logger)
+}
 
-	f.logger.Debug().Msg("Creating MEXC client")
-	return mexc.NewClient(apiKey, apiSecret, f.logger)
+// CreateAnnouncementParser creates a MEXC announcement parser that persists scheduled
+// listings in store and publishes pre-listing alerts on bus
+func (f *MEXCFactory) CreateAnnouncementParser(store port.ScheduledListingRepository, bus port.DomainEventBus) *mexc.AnnouncementParser {
+	return mexc.NewAnnouncementParser(mexc.AnnouncementParserConfig{
+		PreListingLead: f.cfg.AnnouncementParser.PreListingLead,
+		PollInterval:   f.cfg.AnnouncementParser.PollInterval,
+		Jitter:         f.cfg.AnnouncementParser.Jitter,
+		URLs:           f.cfg.AnnouncementParser.URLs,
+	}, store, bus, f.logger)
 }
@@ -36,6 +36,11 @@ func (f *RepositoryFactory) CreateWalletRepository() port.WalletRepository {
 	return repo.NewConsolidatedWalletRepository(f.db, f.logger)
 }
 
+// CreateWalletTransactionRepository creates a WalletTransactionRepository
+func (f *RepositoryFactory) CreateWalletTransactionRepository() port.WalletTransactionRepository {
+	return repo.NewGormWalletTransactionRepository(f.db, f.logger)
+}
+
 // CreateNewCoinRepository creates a NewCoinRepository
 func (f *RepositoryFactory) CreateNewCoinRepository() port.NewCoinRepository {
 	// TODO: implement actual repository when needed
@@ -48,6 +53,17 @@ func (f *RepositoryFactory) CreateEventRepository() port.EventRepository {
 	return nil
 }
 
+// CreateEventStore creates the EventStore backing event bus replay, capped at the most
+// recent repo.DefaultEventLogMaxEvents events
+func (f *RepositoryFactory) CreateEventStore() port.EventStore {
+	return repo.NewGormEventStore(f.db, f.logger).WithMaxEvents(repo.DefaultEventLogMaxEvents)
+}
+
+// CreateScheduledListingRepository creates a ScheduledListingRepository
+func (f *RepositoryFactory) CreateScheduledListingRepository() port.ScheduledListingRepository {
+	return repo.NewGormScheduledListingRepository(f.db, f.logger)
+}
+
 // CreateTickerRepository creates a TickerRepository
 func (f *RepositoryFactory) CreateTickerRepository() port.TickerRepository {
 	// TODO: implement actual repository when needed
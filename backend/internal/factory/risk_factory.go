@@ -459,6 +459,11 @@ func (m *mockOrderRepository) GetByClientOrderID(ctx context.Context, clientOrde
 	return &model.Order{ID: "mock-order", ClientOrderID: clientOrderID, Symbol: "BTCUSDT"}, nil
 }
 
+func (m *mockOrderRepository) GetByOrderID(ctx context.Context, orderID string) (*model.Order, error) {
+	m.logger.Debug().Str("orderID", orderID).Msg("Mock: Getting order by exchange order ID")
+	return &model.Order{ID: "mock-order", OrderID: orderID, Symbol: "BTCUSDT"}, nil
+}
+
 func (m *mockOrderRepository) Update(ctx context.Context, order *model.Order) error {
 	m.logger.Debug().Str("id", order.ID).Msg("Mock: Updating order")
 	return nil
@@ -474,6 +479,16 @@ func (m *mockOrderRepository) GetByUserID(ctx context.Context, userID string, li
 	return []*model.Order{}, nil
 }
 
+func (m *mockOrderRepository) Query(ctx context.Context, filter model.OrderFilter) ([]*model.Order, int64, error) {
+	m.logger.Debug().Interface("filter", filter).Msg("Mock: Querying orders")
+	return []*model.Order{}, 0, nil
+}
+
+func (m *mockOrderRepository) GetBySymbolBefore(ctx context.Context, userID, symbol string, cursor *model.OrderCursor, limit int) ([]*model.Order, error) {
+	m.logger.Debug().Str("userID", userID).Str("symbol", symbol).Msg("Mock: Getting order page by symbol")
+	return []*model.Order{}, nil
+}
+
 func (m *mockOrderRepository) GetByStatus(ctx context.Context, status model.OrderStatus, limit, offset int) ([]*model.Order, error) {
 	m.logger.Debug().Str("status", string(status)).Msg("Mock: Getting orders by status")
 	return []*model.Order{}, nil
@@ -1,12 +1,19 @@
 package factory
 
 import (
+	"context"
+
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/delivery/http/handler"
+	gatewaynotification "github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/gateway/notification"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/notification"
 	persistence "github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/repo"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/service"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/pkg/platform/mexc/rest"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/pkg/platform/mexc/websocket"
 	"github.com/rs/zerolog"
 	"gorm.io/gorm"
 )
@@ -16,6 +23,9 @@ type TradeFactory struct {
 	config *config.Config
 	logger *zerolog.Logger
 	db     *gorm.DB
+
+	riskManager *service.RiskManager
+	events      port.DomainEventPublisher
 }
 
 // NewTradeFactory creates a new TradeFactory
@@ -27,6 +37,12 @@ func NewTradeFactory(cfg *config.Config, logger *zerolog.Logger, db *gorm.DB) *T
 	}
 }
 
+// WithEventPublisher makes the trade services created afterwards publish order events
+func (f *TradeFactory) WithEventPublisher(events port.DomainEventPublisher) *TradeFactory {
+	f.events = events
+	return f
+}
+
 // CreateTradeService creates a new implementation of the TradeService
 func (f *TradeFactory) CreateTradeService(
 	mexcClient port.MEXCClient,
@@ -35,13 +51,138 @@ func (f *TradeFactory) CreateTradeService(
 	orderRepo port.OrderRepository,
 ) port.TradeService {
 	// Create the trade service with necessary dependencies
-	return service.NewMexcTradeService(
+	tradeService := service.NewMexcTradeService(
 		mexcClient,
 		marketDataService,
 		symbolRepo,
 		orderRepo,
 		f.logger,
 	)
+
+	// The guard always refuses symbols the exchange does not trade; the symbol lists and
+	// the minimum volume are optional
+	guardCfg := f.config.Trading.Guard
+	tradeService.WithTradeGuard(service.NewTradeGuard(
+		guardCfg.Whitelist,
+		guardCfg.Blacklist,
+		guardCfg.MinQuoteVolume24h,
+		marketDataService,
+	))
+	if f.config.Trading.RiskLimits.Enabled() {
+		tradeService.WithRiskManager(f.CreateRiskManager(marketDataService))
+	}
+	if f.events != nil {
+		tradeService.WithEventPublisher(f.events)
+	}
+	return tradeService
+}
+
+// CreateTradeNotifier creates the subscriber notifying users about their orders. The
+// notifications are stored in notifications and delivered through the notification service.
+func (f *TradeFactory) CreateTradeNotifier(events port.DomainEventBus, notifications port.NotificationRepository) *notification.TradeNotifier {
+	logger := f.logger.With().Str("component", "trade_notifier").Logger()
+	userNotifications := notification.NewUserNotificationService(notifications, &logger, gatewaynotification.NewConsoleNotificationService(&logger))
+	return notification.NewTradeNotifier(events, userNotifications, &logger)
+}
+
+// CreateRiskManager creates the manager of the pre-trade risk limits. It is created once,
+// so the trade service and the limits endpoint share its daily loss halt.
+func (f *TradeFactory) CreateRiskManager(tickers service.TickerSource) *service.RiskManager {
+	if f.riskManager == nil {
+		limits := f.config.Trading.RiskLimits
+		f.riskManager = service.NewRiskManager(
+			service.RiskLimits{
+				MaxPositionPerSymbol: limits.MaxPositionPerSymbol,
+				MaxTotalExposure:     limits.MaxTotalExposure,
+				MaxDailyLoss:         limits.MaxDailyLoss,
+			},
+			persistence.NewPositionRepository(f.db),
+			tickers,
+		)
+	}
+	return f.riskManager
+}
+
+// CreateReconciliationService creates the background service that refreshes stale open orders
+func (f *TradeFactory) CreateReconciliationService(
+	mexcClient port.MEXCClient,
+	orderRepo port.OrderRepository,
+	publisher port.OrderEventPublisher,
+) *service.ReconciliationService {
+	reconciliationCfg := f.config.Trading.Reconciliation
+	logger := f.logger.With().Str("component", "order_reconciliation").Logger()
+	return service.NewReconciliationService(
+		mexcClient,
+		orderRepo,
+		publisher,
+		reconciliationCfg.Interval,
+		reconciliationCfg.BatchSize,
+		&logger,
+	)
+}
+
+// CreateOrderRetryQueue creates the queue that places trades' orders and retries those
+// rejected with a transient exchange error
+func (f *TradeFactory) CreateOrderRetryQueue(trades port.TradeService) *service.OrderRetryQueue {
+	retryCfg := f.config.Trading.OrderRetry
+	logger := f.logger.With().Str("component", "order_retry").Logger()
+	return service.NewOrderRetryQueue(
+		trades,
+		repo.NewGormOrderRetryRepository(f.db, &logger),
+		f.events,
+		service.OrderRetryOptions{
+			MaxAttempts:    retryCfg.MaxAttempts,
+			InitialBackoff: retryCfg.InitialBackoff,
+			MaxBackoff:     retryCfg.MaxBackoff,
+			PollInterval:   retryCfg.PollInterval,
+			BatchSize:      retryCfg.BatchSize,
+		},
+		&logger,
+	)
+}
+
+// CreateTrailingStopManager creates the background service that trails stops on open long
+// positions and exits them through tradeUC
+func (f *TradeFactory) CreateTrailingStopManager(
+	positionUC usecase.PositionUseCase,
+	tradeUC usecase.TradeUseCase,
+	tickers service.TickerSource,
+	symbolRepo port.SymbolRepository,
+) *service.TrailingStopManager {
+	trailingCfg := f.config.Trading.TrailingStop
+	logger := f.logger.With().Str("component", "trailing_stop").Logger()
+	return service.NewTrailingStopManager(
+		positionUC,
+		tradeUC,
+		tickers,
+		symbolRepo,
+		repo.NewGormTrailingStopRepository(f.db, &logger),
+		service.TrailDistance{Percent: trailingCfg.Percent, Ticks: trailingCfg.Ticks},
+		trailingCfg.Interval,
+		&logger,
+	)
+}
+
+// CreateUserDataStream creates the private MEXC stream that pushes order and balance updates
+func (f *TradeFactory) CreateUserDataStream() *websocket.UserDataStream {
+	baseURL, wsURL := f.config.MEXCEndpoints()
+	restClient := rest.NewClient(f.config.MEXC.APIKey, f.config.MEXC.APISecret, rest.WithBaseURL(baseURL+"/api/v3"))
+	logger := f.logger.With().Str("component", "user_data_stream").Logger()
+	return websocket.NewUserDataStreamWithURL(restClient, wsURL, &logger)
+}
+
+// StartOrderStreaming connects the user data stream and feeds its order updates and fills
+// into the reconciliation service so they are applied without waiting for the next poll
+func (f *TradeFactory) StartOrderStreaming(
+	ctx context.Context,
+	stream *websocket.UserDataStream,
+	reconciliation *service.ReconciliationService,
+) error {
+	if err := stream.Start(ctx); err != nil {
+		return err
+	}
+	go reconciliation.ConsumeOrderUpdates(ctx, stream.Orders(), stream.Fills())
+	return nil
 }
 
 // CreateTradeUseCase creates a new TradeUseCase implementation
@@ -54,7 +195,7 @@ func (f *TradeFactory) CreateTradeUseCase(
 	txManager port.TransactionManager,
 ) usecase.TradeUseCase {
 	// Create the trade use case with necessary dependencies
-	return usecase.NewTradeUseCase(
+	tradeUseCase := usecase.NewTradeUseCase(
 		mexcClient,
 		orderRepo,
 		symbolRepo,
@@ -63,12 +204,15 @@ func (f *TradeFactory) CreateTradeUseCase(
 		txManager,
 		f.logger.With().Str("component", "trade_usecase").Logger(),
 	)
+
+	// Record order placement and cancellation in the audit trail
+	return usecase.NewAuditedTradeUseCase(tradeUseCase, repo.NewGormAuditLogger(f.db, f.logger), f.logger)
 }
 
 // CreateTradeHandler creates a new TradeHandler for HTTP API
 func (f *TradeFactory) CreateTradeHandler(tradeUseCase usecase.TradeUseCase) *handler.TradeHandler {
 	// Create the trade handler with the use case
-	return handler.NewTradeHandler(tradeUseCase, f.logger)
+	return handler.NewTradeHandler(tradeUseCase, f.logger).WithOrderLimits(f.config.RequestLimits.Orders)
 }
 
 // CreateOrderRepository creates a repository for order persistence
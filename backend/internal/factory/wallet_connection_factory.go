@@ -1,6 +1,7 @@
 package factory
 
 import (
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/repo"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/wallet"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
@@ -30,7 +31,11 @@ func (f *WalletConnectionFactory) CreateProviderRegistry(mexcClient port.MEXCCli
 	registry := wallet.NewProviderRegistry()
 
 	// Register MEXC provider
-	mexcProvider := wallet.NewMEXCProvider(mexcClient, f.logger)
+	mexcProvider := wallet.NewMEXCProvider(
+		mexcClient,
+		f.logger,
+		wallet.WithTransactionRepository(repo.NewGormWalletTransactionRepository(f.db, f.logger)),
+	)
 	registry.RegisterProvider(mexcProvider)
 
 	// Register Ethereum provider
@@ -43,9 +48,36 @@ func (f *WalletConnectionFactory) CreateProviderRegistry(mexcClient port.MEXCCli
 	)
 	registry.RegisterProvider(ethereumProvider)
 
+	// Register additional EVM chain providers
+	for _, provider := range f.CreateEVMChainProviders() {
+		registry.RegisterProvider(provider)
+	}
+
 	return registry
 }
 
+// CreateEVMChainProviders creates wallet providers for the enabled EVM chains (BSC, Polygon)
+func (f *WalletConnectionFactory) CreateEVMChainProviders() []port.Web3WalletProvider {
+	chains := []config.EVMChainConfig{f.cfg.Web3.BSC, f.cfg.Web3.Polygon}
+
+	providers := make([]port.Web3WalletProvider, 0, len(chains))
+	for _, chain := range chains {
+		if !chain.Enabled {
+			continue
+		}
+		providers = append(providers, wallet.NewEVMProvider(
+			chain.Network,
+			chain.ChainID,
+			chain.Network,
+			chain.RPCURL,
+			chain.Explorer,
+			f.logger,
+		))
+	}
+
+	return providers
+}
+
 // CreateWalletConnectionService creates a wallet connection service
 func (f *WalletConnectionFactory) CreateWalletConnectionService(
 	providerRegistry *wallet.ProviderRegistry,
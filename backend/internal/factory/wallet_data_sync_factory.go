@@ -1,6 +1,8 @@
 package factory
 
 import (
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/notification"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/repo"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/wallet"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
@@ -31,10 +33,37 @@ func (f *WalletDataSyncFactory) CreateWalletDataSyncService(
 	apiCredentialManager usecase.APICredentialManagerService,
 	providerRegistry *wallet.ProviderRegistry,
 ) usecase.WalletDataSyncService {
+	transactionRepo := repo.NewGormWalletTransactionRepository(f.db, f.logger)
+	notifier := notification.NewBalanceChangeNotifier(repo.NewGormNotificationRepository(f.db, f.logger), f.logger)
+
 	return usecase.NewWalletDataSyncService(
 		walletRepo,
 		apiCredentialManager,
 		providerRegistry,
+		transactionRepo,
+		notifier,
+		f.cfg.Wallet.Sync.BalanceChangeThreshold,
+		f.logger,
+	)
+}
+
+// CreateWalletSyncScheduler creates the scheduler that periodically syncs every user's wallets
+func (f *WalletDataSyncFactory) CreateWalletSyncScheduler(
+	syncService usecase.WalletDataSyncService,
+	apiCredentialManager usecase.APICredentialManagerService,
+) *usecase.WalletSyncScheduler {
+	syncConfig := f.cfg.Wallet.Sync
+	return usecase.NewWalletSyncScheduler(
+		syncService,
+		repo.NewUserRepository(f.db, f.logger),
+		apiCredentialManager,
+		usecase.WalletSyncScheduleConfig{
+			Interval:      syncConfig.Interval,
+			UserIntervals: syncConfig.UserIntervals,
+			CheckInterval: syncConfig.CheckInterval,
+			Jitter:        syncConfig.Jitter,
+			MaxBackoff:    syncConfig.MaxBackoff,
+		},
 		f.logger,
 	)
 }
@@ -2,19 +2,22 @@ package factory
 
 import (
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/delivery/http/handler"
+	gormAdapter "github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/repo"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/pkg/platform/mexc"
 	"github.com/rs/zerolog"
 	"gorm.io/gorm"
 )
 
 // WalletFactory creates wallet-related components
 type WalletFactory struct {
-	cfg    *config.Config
-	logger *zerolog.Logger
-	db     *gorm.DB
+	cfg         *config.Config
+	logger      *zerolog.Logger
+	db          *gorm.DB
+	mexcBreaker *mexc.CircuitBreaker
 }
 
 // NewWalletFactory creates a new WalletFactory
@@ -26,6 +29,12 @@ func NewWalletFactory(cfg *config.Config, logger *zerolog.Logger, db *gorm.DB) *
 	}
 }
 
+// WithMEXCCircuitBreaker makes the MEXC clients created afterwards share breaker
+func (f *WalletFactory) WithMEXCCircuitBreaker(breaker *mexc.CircuitBreaker) *WalletFactory {
+	f.mexcBreaker = breaker
+	return f
+}
+
 // CreateWalletRepository creates a wallet repository
 func (f *WalletFactory) CreateWalletRepository() port.WalletRepository {
 	return repo.NewConsolidatedWalletRepository(f.db, f.logger)
@@ -40,12 +49,25 @@ func (f *WalletFactory) CreateWalletService(mexcClient port.MEXCClient) usecase.
 // CreateWalletServiceWithClient creates a wallet service with the provided MEXC client
 func (f *WalletFactory) CreateWalletServiceWithClient() usecase.WalletService {
 	// Create MEXC client
-	mexcClient := NewMEXCClient(f.cfg, f.logger)
+	mexcClient := NewMEXCClientWithBreaker(f.cfg, f.mexcBreaker, f.logger)
 
 	// Create wallet service
 	return f.CreateWalletService(mexcClient)
 }
 
+// CreatePortfolioValuationService creates a service that values wallet holdings in the configured quote currency
+func (f *WalletFactory) CreatePortfolioValuationService() usecase.PortfolioValuationService {
+	valuationCfg := f.cfg.Wallet.Valuation
+	return usecase.NewPortfolioValuationService(
+		f.CreateWalletRepository(),
+		gormAdapter.NewMarketRepository(f.db, f.logger),
+		valuationCfg.Exchange,
+		valuationCfg.QuoteCurrency,
+		valuationCfg.BridgeAssets,
+		f.logger,
+	)
+}
+
 // CreateWalletHandler creates a wallet handler
 func (f *WalletFactory) CreateWalletHandler(walletService usecase.WalletService) *handler.WalletHandler {
 	return handler.NewWalletHandler(walletService, f.logger)
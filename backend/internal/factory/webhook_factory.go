@@ -0,0 +1,68 @@
+package factory
+
+import (
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/delivery/http/handler"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/notification"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm/repo"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/config"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+)
+
+// WebhookFactory creates the components delivering bot events to user-registered endpoints
+type WebhookFactory struct {
+	cfg    *config.Config
+	logger *zerolog.Logger
+	db     *gorm.DB
+}
+
+// NewWebhookFactory creates a new WebhookFactory instance
+func NewWebhookFactory(cfg *config.Config, logger *zerolog.Logger, db *gorm.DB) *WebhookFactory {
+	return &WebhookFactory{
+		cfg:    cfg,
+		logger: logger,
+		db:     db,
+	}
+}
+
+// CreateWebhookEndpointRepository creates the webhook endpoint repository
+func (f *WebhookFactory) CreateWebhookEndpointRepository() port.WebhookEndpointRepository {
+	return repo.NewGormWebhookEndpointRepository(f.db, f.logger)
+}
+
+// CreateWebhookDeliveryRepository creates the webhook delivery repository
+func (f *WebhookFactory) CreateWebhookDeliveryRepository() port.WebhookDeliveryRepository {
+	return repo.NewGormWebhookDeliveryRepository(f.db, f.logger)
+}
+
+// CreateWebhookEndpointHandler creates the handler users manage their endpoints with
+func (f *WebhookFactory) CreateWebhookEndpointHandler() *handler.WebhookEndpointHandler {
+	useCase := usecase.NewWebhookEndpointUseCase(
+		f.CreateWebhookEndpointRepository(),
+		f.CreateWebhookDeliveryRepository(),
+		f.logger,
+	)
+	return handler.NewWebhookEndpointHandler(useCase, f.logger)
+}
+
+// CreateWebhookDispatcher creates a dispatcher delivering the events published on events
+func (f *WebhookFactory) CreateWebhookDispatcher(events port.DomainEventBus) *notification.WebhookDispatcher {
+	settings := f.cfg.OutboundWebhooks
+	return notification.NewWebhookDispatcher(
+		events,
+		f.CreateWebhookEndpointRepository(),
+		f.CreateWebhookDeliveryRepository(),
+		notification.WebhookDispatcherOptions{
+			MaxAttempts:    settings.MaxAttempts,
+			InitialBackoff: settings.InitialBackoff,
+			MaxBackoff:     settings.MaxBackoff,
+			Timeout:        settings.Timeout,
+			QueueSize:      settings.QueueSize,
+			Workers:        settings.Workers,
+		},
+		f.logger,
+	)
+}
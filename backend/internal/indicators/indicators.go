@@ -0,0 +1,163 @@
+// Package indicators computes technical indicators over price series for strategies,
+// backtests and the AI signal endpoint.
+//
+// Every indicator returns slices aligned with its input: element i is the value after
+// input i, and positions still inside the warmup period hold NaN. Each call allocates
+// only its output slices and, for SMA, a window of period values, so indicators can be
+// recomputed cheaply over long backtests. A period below 1, or a series shorter than the
+// warmup, yields only NaN.
+//
+// MovingAverage and RSICalculator compute the same indicators one value at a time for
+// strategies that see candles as they close.
+package indicators
+
+import (
+	"math"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+)
+
+// Closes returns the close prices of klines
+func Closes(klines []*model.Kline) []float64 {
+	closes := make([]float64, len(klines))
+	for i, k := range klines {
+		closes[i] = k.Close
+	}
+	return closes
+}
+
+// SMA returns the simple moving average over period values. The first period-1 values
+// are NaN.
+func SMA(values []float64, period int) []float64 {
+	out := nans(len(values))
+	if period < 1 || len(values) < period {
+		return out
+	}
+
+	sma := NewSMA(period)
+	for i, v := range values {
+		if avg, ok := sma.Add(v); ok {
+			out[i] = avg
+		}
+	}
+	return out
+}
+
+// EMA returns the exponential moving average with smoothing 2/(period+1), seeded with the
+// SMA of the first period values. The first period-1 values are NaN. Leading NaNs in
+// values are skipped, so EMA can be applied to the output of another indicator.
+func EMA(values []float64, period int) []float64 {
+	out := nans(len(values))
+	emaInto(out, values, period)
+	return out
+}
+
+// RSI returns Wilder's relative strength index over period price changes. The first
+// period values are NaN. A flat series has an RSI of 50.
+func RSI(values []float64, period int) []float64 {
+	out := nans(len(values))
+	if period < 1 || len(values) <= period {
+		return out
+	}
+
+	rsi := NewRSICalculator(period)
+	for i, v := range values {
+		if value, ok := rsi.Add(v); ok {
+			out[i] = value
+		}
+	}
+	return out
+}
+
+// MACDResult holds the MACD line (fast EMA - slow EMA), its signal line (EMA of the MACD
+// line) and the histogram (MACD - signal)
+type MACDResult struct {
+	MACD      []float64
+	Signal    []float64
+	Histogram []float64
+}
+
+// MACD computes the moving average convergence divergence, conventionally with periods
+// 12, 26 and 9. The MACD line starts at index slow-1 and the signal line and histogram at
+// slow+signal-2.
+func MACD(values []float64, fast, slow, signal int) MACDResult {
+	result := MACDResult{
+		MACD:      nans(len(values)),
+		Signal:    nans(len(values)),
+		Histogram: nans(len(values)),
+	}
+	if fast < 1 || slow <= fast || signal < 1 {
+		return result
+	}
+
+	// The histogram slice holds the slow EMA until it is needed
+	emaInto(result.MACD, values, fast)
+	emaInto(result.Histogram, values, slow)
+	for i := range values {
+		result.MACD[i] -= result.Histogram[i]
+		result.Histogram[i] = math.NaN()
+	}
+	// The fast EMA is defined before the slow one; trim it to the slow warmup
+	for i := 0; i < slow-1 && i < len(values); i++ {
+		result.MACD[i] = math.NaN()
+	}
+
+	emaInto(result.Signal, result.MACD, signal)
+	for i := range values {
+		result.Histogram[i] = result.MACD[i] - result.Signal[i]
+	}
+	return result
+}
+
+// BollingerBandsResult holds the middle band (SMA) and the bands k population standard
+// deviations above and below it
+type BollingerBandsResult struct {
+	Upper  []float64
+	Middle []float64
+	Lower  []float64
+}
+
+// BollingerBands computes Bollinger Bands over period values with a width of k standard
+// deviations, conventionally 20 and 2. The first period-1 values are NaN.
+func BollingerBands(values []float64, period int, k float64) BollingerBandsResult {
+	result := BollingerBandsResult{
+		Upper:  nans(len(values)),
+		Middle: SMA(values, period),
+		Lower:  nans(len(values)),
+	}
+	if period < 1 {
+		return result
+	}
+
+	for i := period - 1; i < len(values); i++ {
+		mean := result.Middle[i]
+		var variance float64
+		for _, v := range values[i-period+1 : i+1] {
+			variance += (v - mean) * (v - mean)
+		}
+		width := k * math.Sqrt(variance/float64(period))
+		result.Upper[i] = mean + width
+		result.Lower[i] = mean - width
+	}
+	return result
+}
+
+// emaInto writes the EMA of values into dst, which must be filled with NaN and have the
+// same length. NaNs in values are skipped.
+func emaInto(dst, values []float64, period int) {
+	ema := NewEMA(period)
+	for i, v := range values {
+		if avg, ok := ema.Add(v); ok {
+			dst[i] = avg
+		}
+	}
+}
+
+// nans returns a slice of n NaNs
+func nans(n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = math.NaN()
+	}
+	return out
+}
@@ -0,0 +1,142 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wilderCloses is the worked RSI example from Wilder's "New Concepts in Technical
+// Trading Systems", as reproduced by StockCharts
+var wilderCloses = []float64{
+	44.34, 44.09, 44.15, 43.61, 44.33, 44.83, 45.10, 45.42, 45.84, 46.08,
+	45.89, 46.03, 45.61, 46.28, 46.28, 46.00, 46.03, 46.41, 46.22, 45.64,
+}
+
+// linear returns 1, 2, ..., n. On a series rising by one per step an EMA of period p
+// lags the price by (p-1)/2.
+func linear(n int) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = float64(i + 1)
+	}
+	return values
+}
+
+// assertSeries checks want against got, where NaN in want marks a warmup position
+func assertSeries(t *testing.T, want, got []float64, delta float64) {
+	t.Helper()
+	require.Len(t, got, len(want))
+	for i := range want {
+		if math.IsNaN(want[i]) {
+			assert.True(t, math.IsNaN(got[i]), "index %d: want NaN, got %v", i, got[i])
+			continue
+		}
+		assert.InDelta(t, want[i], got[i], delta, "index %d", i)
+	}
+}
+
+var nan = math.NaN()
+
+// warmup returns n NaNs followed by values
+func warmup(n int, values ...float64) []float64 {
+	return append(nans(n), values...)
+}
+
+func TestSMA(t *testing.T) {
+	assertSeries(t, []float64{nan, nan, 2, 3, 4, 5}, SMA(linear(6), 3), 1e-12)
+	assertSeries(t, []float64{nan, nan}, SMA(linear(2), 3), 0)
+	assertSeries(t, []float64{nan, nan}, SMA(linear(2), 0), 0)
+}
+
+func TestEMA(t *testing.T) {
+	assertSeries(t, []float64{nan, nan, 2, 3, 4, 5, 6}, EMA(linear(7), 3), 1e-12)
+
+	// Seeded with the SMA, then smoothed with alpha = 2/(3+1)
+	assertSeries(t, []float64{nan, nan, 2, 6, 3.5}, EMA([]float64{1, 2, 3, 10, 1}, 3), 1e-12)
+
+	// Leading NaNs are skipped
+	assertSeries(t, []float64{nan, nan, 2.5, 3.5}, EMA([]float64{nan, 2, 3, 4}, 2), 1e-12)
+}
+
+func TestRSI(t *testing.T) {
+	rsi := RSI(wilderCloses, 14)
+
+	// Published values, which round intermediate averages to two decimals
+	assertSeries(t, warmup(14, 70.53, 66.32, 66.55, 69.41, 66.36, 57.97), rsi, 0.1)
+
+	flat := RSI([]float64{5, 5, 5}, 2)
+	assert.Equal(t, 50.0, flat[2])
+	rising := RSI(linear(4), 2)
+	assert.Equal(t, 100.0, rising[3])
+}
+
+func TestRSICalculator(t *testing.T) {
+	calc := NewRSICalculator(2)
+
+	var values []float64
+	for _, price := range []float64{10, 9, 8, 9, 10, 11, 10, 9} {
+		if value, ok := calc.Add(price); ok {
+			values = append(values, value)
+		}
+	}
+
+	// Period 2 with Wilder smoothing, worked by hand
+	assert.InDeltaSlice(t, []float64{0, 50, 75, 87.5, 43.75, 21.875}, values, 1e-9)
+}
+
+func TestMovingAverageStream(t *testing.T) {
+	sma := NewSMA(2)
+	_, ok := sma.Add(1)
+	assert.False(t, ok)
+	_, ok = sma.Add(math.NaN())
+	assert.False(t, ok, "NaN values are ignored")
+	avg, ok := sma.Add(3)
+	assert.True(t, ok)
+	assert.Equal(t, 2.0, avg)
+	avg, _ = sma.Add(7)
+	assert.Equal(t, 5.0, avg)
+
+	_, ok = NewEMA(0).Add(1)
+	assert.False(t, ok)
+}
+
+func TestMACD(t *testing.T) {
+	// Fast and slow EMAs lag by 1 and 2.5, so the MACD line is a constant 1.5
+	result := MACD(linear(10), 3, 6, 2)
+	assertSeries(t, []float64{nan, nan, nan, nan, nan, 1.5, 1.5, 1.5, 1.5, 1.5}, result.MACD, 1e-12)
+	assertSeries(t, []float64{nan, nan, nan, nan, nan, nan, 1.5, 1.5, 1.5, 1.5}, result.Signal, 1e-12)
+	assertSeries(t, []float64{nan, nan, nan, nan, nan, nan, 0, 0, 0, 0}, result.Histogram, 1e-12)
+
+	result = MACD(wilderCloses, 3, 6, 4)
+	assert.True(t, math.IsNaN(result.Signal[7]))
+	assert.InDeltaSlice(t, []float64{0.124953, 0.086770, -0.063549}, result.MACD[17:], 1e-6)
+	assert.InDeltaSlice(t, []float64{0.128608, 0.111873, 0.041704}, result.Signal[17:], 1e-6)
+	assert.InDelta(t, -0.063549-0.041704, result.Histogram[19], 1e-6)
+
+	invalid := MACD(linear(10), 6, 3, 2)
+	assert.True(t, math.IsNaN(invalid.MACD[9]))
+}
+
+func TestBollingerBands(t *testing.T) {
+	bands := BollingerBands(linear(5), 3, 2)
+
+	// Population standard deviation of three consecutive integers is sqrt(2/3)
+	width := 2 * math.Sqrt(2.0/3.0)
+	assertSeries(t, []float64{nan, nan, 2, 3, 4}, bands.Middle, 1e-12)
+	assertSeries(t, []float64{nan, nan, 2 + width, 3 + width, 4 + width}, bands.Upper, 1e-12)
+	assertSeries(t, []float64{nan, nan, 2 - width, 3 - width, 4 - width}, bands.Lower, 1e-12)
+
+	bands = BollingerBands(wilderCloses, 5, 2)
+	assert.InDelta(t, 46.06, bands.Middle[19], 1e-9)
+	assert.InDelta(t, 46.573030, bands.Upper[19], 1e-6)
+	assert.InDelta(t, 45.546970, bands.Lower[19], 1e-6)
+}
+
+func TestCloses(t *testing.T) {
+	klines := []*model.Kline{{Close: 1.5}, {Close: 2.5}}
+	assert.Equal(t, []float64{1.5, 2.5}, Closes(klines))
+}
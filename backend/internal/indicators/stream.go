@@ -0,0 +1,117 @@
+package indicators
+
+import "math"
+
+// MovingAverage computes a simple or exponential moving average one value at a time, for
+// strategies that see candles as they close. The series functions are built on it, so both
+// produce the same values.
+type MovingAverage struct {
+	period      int
+	exponential bool
+	window      []float64
+	next        int
+	sum         float64
+	count       int
+	value       float64
+}
+
+// NewSMA creates a streaming simple moving average over period values
+func NewSMA(period int) *MovingAverage {
+	return &MovingAverage{period: period, window: make([]float64, max(period, 0))}
+}
+
+// NewEMA creates a streaming exponential moving average with smoothing 2/(period+1),
+// seeded with the SMA of the first period values
+func NewEMA(period int) *MovingAverage {
+	return &MovingAverage{period: period, exponential: true}
+}
+
+// Add feeds the next value and returns the average once period values have been seen.
+// NaN values are ignored, so an average can follow another indicator's warmup.
+func (m *MovingAverage) Add(value float64) (float64, bool) {
+	if m.period < 1 || math.IsNaN(value) {
+		return 0, false
+	}
+	m.count++
+
+	if m.exponential {
+		if m.count > m.period {
+			alpha := 2 / float64(m.period+1)
+			m.value = value*alpha + m.value*(1-alpha)
+			return m.value, true
+		}
+		m.sum += value
+		if m.count < m.period {
+			return 0, false
+		}
+		m.value = m.sum / float64(m.period)
+		return m.value, true
+	}
+
+	m.sum += value - m.window[m.next]
+	m.window[m.next] = value
+	m.next = (m.next + 1) % m.period
+	if m.count < m.period {
+		return 0, false
+	}
+	m.value = m.sum / float64(m.period)
+	return m.value, true
+}
+
+// RSICalculator computes Wilder's relative strength index one close at a time
+type RSICalculator struct {
+	period    int
+	lastClose float64
+	count     int
+	avgGain   float64
+	avgLoss   float64
+}
+
+// NewRSICalculator creates a streaming RSI over period price changes
+func NewRSICalculator(period int) *RSICalculator {
+	return &RSICalculator{period: period}
+}
+
+// Add feeds the next close and returns the RSI once period price changes have been seen.
+// The first averages are simple means; later ones use Wilder's smoothing. A flat series
+// has an RSI of 50. NaN closes are ignored.
+func (r *RSICalculator) Add(close float64) (float64, bool) {
+	if r.period < 1 || math.IsNaN(close) {
+		return 0, false
+	}
+	r.count++
+	if r.count == 1 {
+		r.lastClose = close
+		return 0, false
+	}
+
+	change := close - r.lastClose
+	r.lastClose = close
+	gain, loss := 0.0, 0.0
+	if change > 0 {
+		gain = change
+	} else {
+		loss = -change
+	}
+
+	changes := r.count - 1
+	n := float64(r.period)
+	if changes <= r.period {
+		r.avgGain += gain / n
+		r.avgLoss += loss / n
+		if changes < r.period {
+			return 0, false
+		}
+	} else {
+		r.avgGain = (r.avgGain*(n-1) + gain) / n
+		r.avgLoss = (r.avgLoss*(n-1) + loss) / n
+	}
+
+	switch {
+	case r.avgGain == 0 && r.avgLoss == 0:
+		return 50, true
+	case r.avgLoss == 0:
+		return 100, true
+	}
+	return 100 - 100/(1+r.avgGain/r.avgLoss), true
+}
@@ -0,0 +1,393 @@
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	gormdb "gorm.io/gorm"
+)
+
+// ErrIrreversibleMigration is returned when rolling back a migration that has no down SQL
+var ErrIrreversibleMigration = errors.New("migration has no down SQL")
+
+// ErrChecksumMismatch is returned when the up SQL of an applied migration has changed
+// since it was applied
+var ErrChecksumMismatch = errors.New("migration checksum mismatch")
+
+// migrationFilePattern matches migration files such as 0003_add_orders_index.up.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is one versioned schema change with the SQL that applies and reverts it
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Checksum returns the hex SHA-256 of the up SQL, recorded when the migration is applied
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.Up))
+	return hex.EncodeToString(sum[:])
+}
+
+// MigrationStatus reports whether a migration has been applied
+type MigrationStatus struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"appliedAt,omitempty"`
+	// Modified is set when an applied migration's up SQL no longer matches its checksum
+	Modified bool `json:"modified,omitempty"`
+}
+
+// SchemaMigration is a row of the schema_migrations table, one per applied migration
+type SchemaMigration struct {
+	Version   int64  `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"not null"`
+	Checksum  string `gorm:"size:64"`
+	AppliedAt time.Time
+}
+
+// TableName sets the table name for SchemaMigration
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// MigrationManager applies and reverts versioned SQL migrations, recording the applied
+// versions in the schema_migrations table. Each migration runs in its own transaction
+// together with its schema_migrations update.
+type MigrationManager struct {
+	db         *gormdb.DB
+	migrations []Migration
+	logger     *zerolog.Logger
+}
+
+// NewMigrationManager creates a manager for migrations, which are sorted by version.
+// Versions must be positive and unique.
+func NewMigrationManager(db *gormdb.DB, migrations []Migration, logger *zerolog.Logger) (*MigrationManager, error) {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	for i, migration := range sorted {
+		if migration.Version <= 0 {
+			return nil, fmt.Errorf("migration %q has invalid version %d", migration.Name, migration.Version)
+		}
+		if i > 0 && sorted[i-1].Version == migration.Version {
+			return nil, fmt.Errorf("duplicate migration version %d", migration.Version)
+		}
+	}
+
+	return &MigrationManager{
+		db:         db,
+		migrations: sorted,
+		logger:     logger,
+	}, nil
+}
+
+// LoadMigrations reads migrations from dir in fsys. Files are named
+// <version>_<name>.up.sql and <version>_<name>.down.sql; the down file is optional.
+func LoadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migration directory: %w", err)
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d is used by %q and %q", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// RunMigrations applies every pending migration in version order and stops at the first
+// failure. Nothing is applied when an already applied migration has been modified.
+func (m *MigrationManager) RunMigrations() error {
+	applied, err := m.verified()
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err := m.apply(migration); err != nil {
+			return err
+		}
+		count++
+	}
+
+	m.logger.Info().Int("applied", count).Msg("Database migrations are up to date")
+	return nil
+}
+
+// DryRun writes the SQL of every pending migration to w, in the order RunMigrations would
+// execute it, without applying anything
+func (m *MigrationManager) DryRun(w io.Writer) error {
+	applied, err := m.verified()
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "-- %d_%s\n%s\n\n", migration.Version, migration.Name, strings.TrimSpace(migration.Up)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Verify checks that no applied migration has been modified since it was applied
+func (m *MigrationManager) Verify() error {
+	_, err := m.verified()
+	return err
+}
+
+// Rollback reverts the most recently applied steps migrations, newest first
+func (m *MigrationManager) Rollback(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("rollback steps must be positive, got %d", steps)
+	}
+
+	applied, err := m.appliedMigrations()
+	if err != nil {
+		return err
+	}
+	if steps > len(applied) {
+		steps = len(applied)
+	}
+	return m.revert(applied[len(applied)-steps:])
+}
+
+// RollbackTo reverts every applied migration newer than version, leaving version as the
+// latest applied one. Version zero reverts everything.
+func (m *MigrationManager) RollbackTo(version int64) error {
+	if version < 0 {
+		return fmt.Errorf("rollback target version must not be negative, got %d", version)
+	}
+	if version > 0 && m.find(version) == nil {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+
+	applied, err := m.appliedMigrations()
+	if err != nil {
+		return err
+	}
+	start := sort.Search(len(applied), func(i int) bool { return applied[i].Version > version })
+	return m.revert(applied[start:])
+}
+
+// Status lists every known migration in version order with whether it has been applied
+func (m *MigrationManager) Status() ([]MigrationStatus, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if row, ok := applied[migration.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.Modified = row.Checksum != "" && row.Checksum != migration.Checksum()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// CurrentVersion returns the highest applied version, or zero when none is applied
+func (m *MigrationManager) CurrentVersion() (int64, error) {
+	if err := m.ensureVersionTable(); err != nil {
+		return 0, err
+	}
+	var version int64
+	err := m.db.Model(&SchemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// apply runs the up SQL of a migration and records it
+func (m *MigrationManager) apply(migration Migration) error {
+	err := m.db.Transaction(func(tx *gormdb.DB) error {
+		if err := tx.Exec(migration.Up).Error; err != nil {
+			return err
+		}
+		return tx.Create(&SchemaMigration{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Checksum:  migration.Checksum(),
+			AppliedAt: time.Now().UTC(),
+		}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	m.logger.Info().Int64("version", migration.Version).Str("name", migration.Name).Msg("Applied migration")
+	return nil
+}
+
+// revert runs the down SQL of the given applied migrations, newest first. All of them
+// are checked for down SQL before anything is reverted.
+func (m *MigrationManager) revert(migrations []Migration) error {
+	for _, migration := range migrations {
+		if migration.Down == "" {
+			return fmt.Errorf("cannot roll back migration %d_%s: %w", migration.Version, migration.Name, ErrIrreversibleMigration)
+		}
+	}
+
+	for i := len(migrations) - 1; i >= 0; i-- {
+		migration := migrations[i]
+		err := m.db.Transaction(func(tx *gormdb.DB) error {
+			if err := tx.Exec(migration.Down).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&SchemaMigration{}, migration.Version).Error
+		})
+		if err != nil {
+			return fmt.Errorf("failed to roll back migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		m.logger.Info().Int64("version", migration.Version).Str("name", migration.Name).Msg("Rolled back migration")
+	}
+	return nil
+}
+
+// appliedMigrations returns the applied migrations in version order. Versions recorded
+// in the database but unknown to the manager are an error, since they cannot be reverted.
+func (m *MigrationManager) appliedMigrations() ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]Migration, 0, len(applied))
+	for version := range applied {
+		migration := m.find(version)
+		if migration == nil {
+			return nil, fmt.Errorf("applied migration version %d is not known to the migration manager", version)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// verified returns the schema_migrations rows keyed by version after checking every
+// applied migration against its recorded checksum. Rows applied before checksums were
+// recorded are backfilled with the current checksum.
+func (m *MigrationManager) verified() (map[int64]SchemaMigration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	for version, row := range applied {
+		migration := m.find(version)
+		if migration == nil {
+			continue
+		}
+		checksum := migration.Checksum()
+		if row.Checksum == "" {
+			if err := m.db.Model(&SchemaMigration{}).Where("version = ?", version).Update("checksum", checksum).Error; err != nil {
+				return nil, fmt.Errorf("failed to record checksum of migration %d_%s: %w", version, migration.Name, err)
+			}
+			row.Checksum = checksum
+			applied[version] = row
+			continue
+		}
+		if row.Checksum != checksum {
+			return nil, fmt.Errorf("migration %d_%s was modified after it was applied (recorded %s, now %s): %w",
+				version, migration.Name, row.Checksum, checksum, ErrChecksumMismatch)
+		}
+	}
+	return applied, nil
+}
+
+// applied returns the schema_migrations rows keyed by version
+func (m *MigrationManager) applied() (map[int64]SchemaMigration, error) {
+	if err := m.ensureVersionTable(); err != nil {
+		return nil, err
+	}
+
+	var rows []SchemaMigration
+	if err := m.db.Order("version").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	applied := make(map[int64]SchemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// ensureVersionTable creates the schema_migrations table when missing
+func (m *MigrationManager) ensureVersionTable() error {
+	if err := m.db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+// find returns the migration with the given version, or nil
+func (m *MigrationManager) find(version int64) *Migration {
+	i := sort.Search(len(m.migrations), func(i int) bool { return m.migrations[i].Version >= version })
+	if i < len(m.migrations) && m.migrations[i].Version == version {
+		return &m.migrations[i]
+	}
+	return nil
+}
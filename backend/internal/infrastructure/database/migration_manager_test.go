@@ -0,0 +1,196 @@
+package database
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	gormdb "gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var testMigrations = []Migration{
+	{
+		Version: 2,
+		Name:    "add_orders_symbol_index",
+		Up:      "CREATE INDEX idx_orders_symbol ON orders(symbol);",
+		Down:    "DROP INDEX idx_orders_symbol;",
+	},
+	{
+		Version: 1,
+		Name:    "create_orders",
+		Up:      "CREATE TABLE orders (id TEXT PRIMARY KEY, symbol TEXT NOT NULL);",
+		Down:    "DROP TABLE orders;",
+	},
+}
+
+func setupMigrationDB(t *testing.T) *gormdb.DB {
+	db, err := gormdb.Open(sqlite.Open(filepath.Join(t.TempDir(), "migrations.db")), &gormdb.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func newTestMigrationManager(t *testing.T, db *gormdb.DB, migrations []Migration) *MigrationManager {
+	log := zerolog.Nop()
+	manager, err := NewMigrationManager(db, migrations, &log)
+	require.NoError(t, err)
+	return manager
+}
+
+func appliedVersions(t *testing.T, db *gormdb.DB) []int64 {
+	var versions []int64
+	require.NoError(t, db.Model(&SchemaMigration{}).Order("version").Pluck("version", &versions).Error)
+	return versions
+}
+
+func TestMigrationManager_RunMigrationsAndRollback(t *testing.T) {
+	db := setupMigrationDB(t)
+	manager := newTestMigrationManager(t, db, testMigrations)
+
+	require.NoError(t, manager.RunMigrations())
+	assert.Equal(t, []int64{1, 2}, appliedVersions(t, db))
+	assert.True(t, db.Migrator().HasIndex("orders", "idx_orders_symbol"))
+
+	// Running again applies nothing
+	require.NoError(t, manager.RunMigrations())
+	assert.Equal(t, []int64{1, 2}, appliedVersions(t, db))
+
+	require.NoError(t, manager.Rollback(1))
+	assert.Equal(t, []int64{1}, appliedVersions(t, db))
+	assert.True(t, db.Migrator().HasTable("orders"))
+	assert.False(t, db.Migrator().HasIndex("orders", "idx_orders_symbol"))
+
+	version, err := manager.CurrentVersion()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), version)
+
+	statuses, err := manager.Status()
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "create_orders", statuses[0].Name)
+	assert.True(t, statuses[0].Applied)
+	assert.NotNil(t, statuses[0].AppliedAt)
+	assert.False(t, statuses[1].Applied)
+	assert.Nil(t, statuses[1].AppliedAt)
+}
+
+func TestMigrationManager_RollbackTo(t *testing.T) {
+	db := setupMigrationDB(t)
+	manager := newTestMigrationManager(t, db, testMigrations)
+	require.NoError(t, manager.RunMigrations())
+
+	require.NoError(t, manager.RollbackTo(2))
+	assert.Equal(t, []int64{1, 2}, appliedVersions(t, db))
+
+	require.NoError(t, manager.RollbackTo(0))
+	assert.Empty(t, appliedVersions(t, db))
+	assert.False(t, db.Migrator().HasTable("orders"))
+
+	assert.Error(t, manager.RollbackTo(7))
+}
+
+func TestMigrationManager_RollbackRequiresDownSQL(t *testing.T) {
+	db := setupMigrationDB(t)
+	migrations := []Migration{
+		{Version: 1, Name: "create_orders", Up: testMigrations[1].Up, Down: testMigrations[1].Down},
+		{Version: 2, Name: "seed_orders", Up: "INSERT INTO orders (id, symbol) VALUES ('1', 'BTCUSDT');"},
+	}
+	manager := newTestMigrationManager(t, db, migrations)
+	require.NoError(t, manager.RunMigrations())
+
+	err := manager.RollbackTo(0)
+	assert.ErrorIs(t, err, ErrIrreversibleMigration)
+	assert.Equal(t, []int64{1, 2}, appliedVersions(t, db), "nothing is reverted")
+}
+
+func TestMigrationManager_FailedMigrationIsNotRecorded(t *testing.T) {
+	db := setupMigrationDB(t)
+	migrations := append([]Migration{{Version: 3, Name: "broken", Up: "CREATE TABLE orders (id TEXT);"}}, testMigrations...)
+	manager := newTestMigrationManager(t, db, migrations)
+
+	err := manager.RunMigrations()
+	assert.ErrorContains(t, err, "3_broken")
+	assert.Equal(t, []int64{1, 2}, appliedVersions(t, db))
+}
+
+func TestNewMigrationManager_RejectsDuplicateVersions(t *testing.T) {
+	log := zerolog.Nop()
+	_, err := NewMigrationManager(setupMigrationDB(t), append(testMigrations, Migration{Version: 1, Name: "again"}), &log)
+	assert.ErrorContains(t, err, "duplicate migration version 1")
+}
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/0002_add_index.up.sql":     {Data: []byte("CREATE INDEX idx ON orders(symbol);")},
+		"sql/0002_add_index.down.sql":   {Data: []byte("DROP INDEX idx;")},
+		"sql/0001_create_orders.up.sql": {Data: []byte("CREATE TABLE orders (id TEXT);")},
+		"sql/README.md":                 {Data: []byte("ignored")},
+		"sql/0003_orphan_down.down.sql": {Data: []byte("DROP TABLE x;")},
+		"other/0004_elsewhere.up.sql":   {Data: []byte("SELECT 1;")},
+	}
+
+	_, err := LoadMigrations(fsys, "sql")
+	assert.ErrorContains(t, err, "3_orphan_down has no up file")
+
+	delete(fsys, "sql/0003_orphan_down.down.sql")
+	migrations, err := LoadMigrations(fsys, "sql")
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	assert.Equal(t, Migration{Version: 1, Name: "create_orders", Up: "CREATE TABLE orders (id TEXT);"}, migrations[0])
+	assert.Equal(t, "DROP INDEX idx;", migrations[1].Down)
+}
+
+func TestMigrationManager_DetectsModifiedMigration(t *testing.T) {
+	db := setupMigrationDB(t)
+	require.NoError(t, newTestMigrationManager(t, db, testMigrations[1:]).RunMigrations())
+
+	modified := []Migration{
+		{Version: 1, Name: "create_orders", Up: "CREATE TABLE orders (id TEXT PRIMARY KEY, symbol TEXT, side TEXT);", Down: "DROP TABLE orders;"},
+		testMigrations[0],
+	}
+	manager := newTestMigrationManager(t, db, modified)
+
+	assert.ErrorIs(t, manager.Verify(), ErrChecksumMismatch)
+	err := manager.RunMigrations()
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+	assert.ErrorContains(t, err, "1_create_orders")
+	assert.Equal(t, []int64{1}, appliedVersions(t, db), "pending migrations are not applied")
+
+	statuses, err := manager.Status()
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Modified)
+	assert.False(t, statuses[1].Modified)
+}
+
+func TestMigrationManager_BackfillsMissingChecksums(t *testing.T) {
+	db := setupMigrationDB(t)
+	manager := newTestMigrationManager(t, db, testMigrations)
+	require.NoError(t, manager.RunMigrations())
+	require.NoError(t, db.Model(&SchemaMigration{}).Where("1 = 1").Update("checksum", "").Error)
+
+	require.NoError(t, manager.Verify())
+
+	var row SchemaMigration
+	require.NoError(t, db.First(&row, 1).Error)
+	assert.Equal(t, testMigrations[1].Checksum(), row.Checksum)
+}
+
+func TestMigrationManager_DryRunAppliesNothing(t *testing.T) {
+	db := setupMigrationDB(t)
+	require.NoError(t, newTestMigrationManager(t, db, testMigrations[1:]).RunMigrations())
+	manager := newTestMigrationManager(t, db, testMigrations)
+
+	var out strings.Builder
+	require.NoError(t, manager.DryRun(&out))
+
+	assert.Equal(t, "-- 2_add_orders_symbol_index\n"+testMigrations[0].Up+"\n\n", out.String())
+	assert.Equal(t, []int64{1}, appliedVersions(t, db))
+	assert.False(t, db.Migrator().HasIndex("orders", "idx_orders_symbol"))
+}
@@ -0,0 +1,130 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// DefaultStopTimeout is how long a hook may take to stop when it sets no timeout of its own
+const DefaultStopTimeout = 10 * time.Second
+
+// ErrStopTimeout is returned for a hook that did not stop within its timeout
+var ErrStopTimeout = errors.New("lifecycle hook did not stop in time")
+
+// Hook is a component started and stopped with the application. Either function may be nil.
+type Hook struct {
+	// Name identifies the hook in logs and errors
+	Name string
+	// OnStart starts the component. It must not block once the component is running.
+	OnStart func(ctx context.Context) error
+	// OnStop stops the component, returning once its work is flushed
+	OnStop func(ctx context.Context) error
+	// StopTimeout bounds OnStop; zero uses the manager's timeout
+	StopTimeout time.Duration
+}
+
+// LifecycleManager starts hooks in registration order and stops them in reverse, so a
+// component is stopped before the components it depends on. Each stop runs under its own
+// timeout: a hook that blocks is logged and abandoned, and the remaining hooks still stop.
+type LifecycleManager struct {
+	stopTimeout time.Duration
+	logger      *zerolog.Logger
+
+	mu      sync.Mutex
+	hooks   []Hook
+	started int
+}
+
+// NewLifecycleManager creates a manager whose hooks get stopTimeout to stop unless they
+// set their own. A non-positive stopTimeout uses DefaultStopTimeout.
+func NewLifecycleManager(stopTimeout time.Duration, logger *zerolog.Logger) *LifecycleManager {
+	if stopTimeout <= 0 {
+		stopTimeout = DefaultStopTimeout
+	}
+	return &LifecycleManager{stopTimeout: stopTimeout, logger: logger}
+}
+
+// Append registers a hook. Hooks appended after Start are not started.
+func (m *LifecycleManager) Append(hook Hook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, hook)
+}
+
+// Start runs the start hooks in registration order. When one fails, the hooks already
+// started are stopped in reverse order and the start error is returned.
+func (m *LifecycleManager) Start(ctx context.Context) error {
+	m.mu.Lock()
+	hooks := m.hooks[m.started:]
+	m.mu.Unlock()
+
+	for _, hook := range hooks {
+		if hook.OnStart != nil {
+			if err := hook.OnStart(ctx); err != nil {
+				startErr := fmt.Errorf("failed to start %s: %w", hook.Name, err)
+				if stopErr := m.Stop(context.Background()); stopErr != nil {
+					return errors.Join(startErr, stopErr)
+				}
+				return startErr
+			}
+		}
+		m.mu.Lock()
+		m.started++
+		m.mu.Unlock()
+		m.logger.Info().Str("hook", hook.Name).Msg("Started")
+	}
+	return nil
+}
+
+// Stop runs the stop hooks of the started hooks in reverse registration order and
+// returns every failure and timeout joined together. A hook that exceeds its timeout is
+// left running in the background. Cancelling ctx cuts every remaining timeout short.
+func (m *LifecycleManager) Stop(ctx context.Context) error {
+	m.mu.Lock()
+	hooks := m.hooks[:m.started]
+	m.started = 0
+	m.mu.Unlock()
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := m.stop(ctx, hooks[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// stop runs the stop hook of one component under its timeout
+func (m *LifecycleManager) stop(ctx context.Context, hook Hook) error {
+	if hook.OnStop == nil {
+		return nil
+	}
+	timeout := hook.StopTimeout
+	if timeout <= 0 {
+		timeout = m.stopTimeout
+	}
+	stopCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	started := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- hook.OnStop(stopCtx) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			m.logger.Error().Err(err).Str("hook", hook.Name).Msg("Failed to stop")
+			return fmt.Errorf("failed to stop %s: %w", hook.Name, err)
+		}
+		m.logger.Info().Str("hook", hook.Name).Dur("took", time.Since(started)).Msg("Stopped")
+		return nil
+	case <-stopCtx.Done():
+		m.logger.Warn().Str("hook", hook.Name).Dur("timeout", timeout).Msg("Hook blocked shutdown and was abandoned")
+		return fmt.Errorf("%s: %w", hook.Name, ErrStopTimeout)
+	}
+}
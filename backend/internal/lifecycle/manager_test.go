@@ -0,0 +1,110 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder collects the order in which hooks run
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) record(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) hook(name string) Hook {
+	return Hook{
+		Name:    name,
+		OnStart: func(ctx context.Context) error { r.record("start " + name); return nil },
+		OnStop:  func(ctx context.Context) error { r.record("stop " + name); return nil },
+	}
+}
+
+func newTestManager(timeout time.Duration) *LifecycleManager {
+	logger := zerolog.Nop()
+	return NewLifecycleManager(timeout, &logger)
+}
+
+func TestLifecycleManager_StopsInReverseOrder(t *testing.T) {
+	rec := &recorder{}
+	m := newTestManager(time.Second)
+	m.Append(rec.hook("market sync"))
+	m.Append(rec.hook("reconciliation"))
+	m.Append(rec.hook("notifications"))
+
+	require.NoError(t, m.Start(context.Background()))
+	require.NoError(t, m.Stop(context.Background()))
+
+	assert.Equal(t, []string{
+		"start market sync", "start reconciliation", "start notifications",
+		"stop notifications", "stop reconciliation", "stop market sync",
+	}, rec.events)
+
+	// Stopping again is a no-op
+	require.NoError(t, m.Stop(context.Background()))
+	assert.Len(t, rec.events, 6)
+}
+
+func TestLifecycleManager_SlowHookDoesNotStallTheRest(t *testing.T) {
+	rec := &recorder{}
+	release := make(chan struct{})
+	defer close(release)
+
+	m := newTestManager(time.Second)
+	m.Append(rec.hook("first"))
+	m.Append(Hook{
+		Name:        "slow",
+		StopTimeout: 20 * time.Millisecond,
+		OnStop: func(ctx context.Context) error {
+			// Ignores its context, as a stuck component would
+			<-release
+			return nil
+		},
+	})
+	m.Append(rec.hook("last"))
+	require.NoError(t, m.Start(context.Background()))
+
+	began := time.Now()
+	err := m.Stop(context.Background())
+	assert.Less(t, time.Since(began), 500*time.Millisecond)
+
+	assert.ErrorIs(t, err, ErrStopTimeout)
+	assert.ErrorContains(t, err, "slow")
+	assert.Equal(t, []string{"start first", "start last", "stop last", "stop first"}, rec.events)
+}
+
+func TestLifecycleManager_FailedStartStopsStartedHooks(t *testing.T) {
+	rec := &recorder{}
+	m := newTestManager(time.Second)
+	m.Append(rec.hook("database"))
+	m.Append(Hook{Name: "exchange", OnStart: func(ctx context.Context) error { return errors.New("unreachable") }})
+	m.Append(rec.hook("never"))
+
+	err := m.Start(context.Background())
+	assert.ErrorContains(t, err, "failed to start exchange: unreachable")
+	assert.Equal(t, []string{"start database", "stop database"}, rec.events)
+}
+
+func TestLifecycleManager_CollectsStopErrors(t *testing.T) {
+	rec := &recorder{}
+	m := newTestManager(time.Second)
+	m.Append(rec.hook("first"))
+	m.Append(Hook{Name: "broken", OnStop: func(ctx context.Context) error { return errors.New("flush failed") }})
+	require.NoError(t, m.Start(context.Background()))
+
+	err := m.Stop(context.Background())
+	assert.ErrorContains(t, err, "failed to stop broken: flush failed")
+	assert.Equal(t, []string{"start first", "stop first"}, rec.events)
+}
@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+)
+
+// RequestIDField is the log field carrying the request correlation id
+const RequestIDField = "request_id"
+
+type (
+	requestIDKey struct{}
+	loggerKey    struct{}
+)
+
+// fallback is used by FromContext when no logger is stored in the context
+var fallback = zerolog.New(os.Stdout).With().Timestamp().Logger()
+
+// WithRequestID stores the request correlation id in the context
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request correlation id stored in the context, if any
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// WithContext stores a request-scoped logger in the context
+func WithContext(ctx context.Context, l *zerolog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext returns the request-scoped logger stored in the context. Without one it
+// returns a default logger that still carries the request id when the context has one.
+func FromContext(ctx context.Context) *zerolog.Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(loggerKey{}).(*zerolog.Logger); ok && l != nil {
+			return l
+		}
+	}
+	return Enrich(ctx, &fallback)
+}
+
+// Enrich returns base with the request id from the context attached, so components that
+// hold their own logger still emit the correlation id. base is returned unchanged when
+// the context carries no request id.
+func Enrich(ctx context.Context, base *zerolog.Logger) *zerolog.Logger {
+	requestID := RequestIDFromContext(ctx)
+	if requestID == "" {
+		return base
+	}
+	l := base.With().Str(RequestIDField, requestID).Logger()
+	return &l
+}
@@ -1,7 +1,12 @@
 package logger
 
 import (
+	"bytes"
+	"context"
+	"strings"
 	"testing"
+
+	"github.com/rs/zerolog"
 )
 
 func TestNewLogger_NotNil(t *testing.T) {
@@ -10,3 +15,25 @@ func TestNewLogger_NotNil(t *testing.T) {
 		t.Error("NewLogger() returned nil")
 	}
 }
+
+func TestFromContext_WithoutLoggerCarriesRequestID(t *testing.T) {
+	ctx := WithRequestID(context.Background(), "req-1")
+	var buf bytes.Buffer
+	base := zerolog.New(&buf)
+
+	Enrich(ctx, &base).Info().Msg("hello")
+	if !strings.Contains(buf.String(), `"request_id":"req-1"`) {
+		t.Errorf("log line missing request id: %s", buf.String())
+	}
+	if FromContext(context.Background()) == nil {
+		t.Error("FromContext returned nil without a stored logger")
+	}
+}
+
+func TestFromContext_ReturnsStoredLogger(t *testing.T) {
+	stored := zerolog.Nop()
+	ctx := WithContext(context.Background(), &stored)
+	if FromContext(ctx) != &stored {
+		t.Error("FromContext did not return the stored logger")
+	}
+}
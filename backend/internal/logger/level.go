@@ -0,0 +1,187 @@
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// ErrInvalidLevel is returned for a log level name that is not recognised
+var ErrInvalidLevel = errors.New("invalid log level")
+
+// ParseLevel parses a log level name such as "debug" or "warn"
+func ParseLevel(level string) (zerolog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "trace":
+		return zerolog.TraceLevel, nil
+	case "debug":
+		return zerolog.DebugLevel, nil
+	case "info":
+		return zerolog.InfoLevel, nil
+	case "warn", "warning":
+		return zerolog.WarnLevel, nil
+	case "error":
+		return zerolog.ErrorLevel, nil
+	case "fatal":
+		return zerolog.FatalLevel, nil
+	case "panic":
+		return zerolog.PanicLevel, nil
+	default:
+		return zerolog.NoLevel, fmt.Errorf("%w: %q", ErrInvalidLevel, level)
+	}
+}
+
+// LevelStatus describes the active log level and where it comes from
+type LevelStatus struct {
+	Level      string `json:"level"`
+	Configured string `json:"configured"`
+	// Override is the level set at runtime, empty when there is none
+	Override string `json:"override,omitempty"`
+}
+
+// LevelController changes the global log level at runtime. An override is persisted to
+// a file, so it is reapplied when the configuration is reloaded or the process restarts,
+// until it is cleared.
+type LevelController struct {
+	// path is where the override is persisted; empty keeps it in memory only
+	path string
+
+	mu         sync.Mutex
+	configured zerolog.Level
+	override   *zerolog.Level
+}
+
+// NewLevelController creates a controller persisting overrides to path
+func NewLevelController(path string) *LevelController {
+	return &LevelController{path: path, configured: zerolog.InfoLevel}
+}
+
+// Reload applies the configured level unless an override is persisted, in which case the
+// override stays in effect. An invalid configured level falls back to info.
+func (c *LevelController) Reload(configured string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	level, err := ParseLevel(configured)
+	if err != nil {
+		level = zerolog.InfoLevel
+	}
+	c.configured = level
+
+	override, loadErr := c.load()
+	if loadErr == nil {
+		c.override = override
+	}
+	c.apply()
+	return errors.Join(err, loadErr)
+}
+
+// SetOverride switches to level and persists the override
+func (c *LevelController) SetOverride(level string) error {
+	parsed, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.save(&parsed); err != nil {
+		return err
+	}
+	c.override = &parsed
+	c.apply()
+	return nil
+}
+
+// ClearOverride removes the override and returns to the configured level
+func (c *LevelController) ClearOverride() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.save(nil); err != nil {
+		return err
+	}
+	c.override = nil
+	c.apply()
+	return nil
+}
+
+// Status returns the active, configured and override levels
+func (c *LevelController) Status() LevelStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	status := LevelStatus{
+		Level:      zerolog.GlobalLevel().String(),
+		Configured: c.configured.String(),
+	}
+	if c.override != nil {
+		status.Override = c.override.String()
+	}
+	return status
+}
+
+// apply sets the global level; callers hold mu
+func (c *LevelController) apply() {
+	if c.override != nil {
+		zerolog.SetGlobalLevel(*c.override)
+		return
+	}
+	zerolog.SetGlobalLevel(c.configured)
+}
+
+// persistedLevel is the file format of a persisted override
+type persistedLevel struct {
+	Level string `json:"level"`
+}
+
+// load reads the persisted override, returning nil when there is none
+func (c *LevelController) load() (*zerolog.Level, error) {
+	if c.path == "" {
+		return c.override, nil
+	}
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log level override: %w", err)
+	}
+	var persisted persistedLevel
+	if err := json.Unmarshal(data, &persisted); err != nil {
+		return nil, fmt.Errorf("failed to decode log level override: %w", err)
+	}
+	level, err := ParseLevel(persisted.Level)
+	if err != nil {
+		return nil, err
+	}
+	return &level, nil
+}
+
+// save persists level, removing the file for nil
+func (c *LevelController) save(level *zerolog.Level) error {
+	if c.path == "" {
+		return nil
+	}
+	if level == nil {
+		if err := os.Remove(c.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove log level override: %w", err)
+		}
+		return nil
+	}
+	data, err := json.Marshal(persistedLevel{Level: level.String()})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("failed to persist log level override: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to persist log level override: %w", err)
+	}
+	return nil
+}
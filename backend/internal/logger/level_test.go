@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// restoreGlobalLevel resets the global level once the test is done
+func restoreGlobalLevel(t *testing.T) {
+	previous := zerolog.GlobalLevel()
+	t.Cleanup(func() { zerolog.SetGlobalLevel(previous) })
+}
+
+func TestLevelController_OverrideEmitsSuppressedDebugLines(t *testing.T) {
+	restoreGlobalLevel(t)
+	var buf bytes.Buffer
+	log := zerolog.New(&buf)
+
+	levels := NewLevelController(filepath.Join(t.TempDir(), "log_level.json"))
+	if err := levels.Reload("info"); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	log.Debug().Msg("before override")
+	if buf.Len() != 0 {
+		t.Fatalf("debug line emitted at info level: %s", buf.String())
+	}
+
+	if err := levels.SetOverride("debug"); err != nil {
+		t.Fatalf("SetOverride: %v", err)
+	}
+	log.Debug().Msg("after override")
+	if !strings.Contains(buf.String(), "after override") {
+		t.Fatalf("debug line missing after override: %q", buf.String())
+	}
+
+	status := levels.Status()
+	if status.Level != "debug" || status.Configured != "info" || status.Override != "debug" {
+		t.Errorf("unexpected status %+v", status)
+	}
+}
+
+func TestLevelController_OverrideSurvivesReload(t *testing.T) {
+	restoreGlobalLevel(t)
+	path := filepath.Join(t.TempDir(), "nested", "log_level.json")
+
+	if err := NewLevelController(path).SetOverride("warn"); err != nil {
+		t.Fatalf("SetOverride: %v", err)
+	}
+
+	// A new controller, as after a restart, reapplies the persisted override
+	levels := NewLevelController(path)
+	if err := levels.Reload("debug"); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if zerolog.GlobalLevel() != zerolog.WarnLevel {
+		t.Errorf("level after reload = %s, want warn", zerolog.GlobalLevel())
+	}
+
+	// Clearing the override returns to the configured level, also after a reload
+	if err := levels.ClearOverride(); err != nil {
+		t.Fatalf("ClearOverride: %v", err)
+	}
+	if err := levels.Reload("error"); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if zerolog.GlobalLevel() != zerolog.ErrorLevel {
+		t.Errorf("level after clearing = %s, want error", zerolog.GlobalLevel())
+	}
+}
+
+func TestLevelController_RejectsInvalidLevel(t *testing.T) {
+	restoreGlobalLevel(t)
+	levels := NewLevelController("")
+	if err := levels.Reload("info"); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	if err := levels.SetOverride("verbose"); !errors.Is(err, ErrInvalidLevel) {
+		t.Errorf("SetOverride(verbose) error = %v, want ErrInvalidLevel", err)
+	}
+	if zerolog.GlobalLevel() != zerolog.InfoLevel {
+		t.Errorf("level changed to %s by an invalid override", zerolog.GlobalLevel())
+	}
+}
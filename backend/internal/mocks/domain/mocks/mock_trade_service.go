@@ -14,6 +14,34 @@ type TradeService struct {
 	mock.Mock
 }
 
+// CalculateQuantityForRisk provides a mock function with given fields: ctx, symbol, side, riskPct, stopPrice
+func (_m *TradeService) CalculateQuantityForRisk(ctx context.Context, symbol string, side model.OrderSide, riskPct float64, stopPrice float64) (float64, error) {
+	ret := _m.Called(ctx, symbol, side, riskPct, stopPrice)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CalculateQuantityForRisk")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.OrderSide, float64, float64) (float64, error)); ok {
+		return rf(ctx, symbol, side, riskPct, stopPrice)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.OrderSide, float64, float64) float64); ok {
+		r0 = rf(ctx, symbol, side, riskPct, stopPrice)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.OrderSide, float64, float64) error); ok {
+		r1 = rf(ctx, symbol, side, riskPct, stopPrice)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CalculateRequiredQuantity provides a mock function with given fields: ctx, symbol, side, amount
 func (_m *TradeService) CalculateRequiredQuantity(ctx context.Context, symbol string, side model.OrderSide, amount float64) (float64, error) {
 	ret := _m.Called(ctx, symbol, side, amount)
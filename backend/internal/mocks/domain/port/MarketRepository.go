@@ -106,6 +106,36 @@ func (_m *MarketRepository) GetCandles(ctx context.Context, symbol string, excha
 	return r0, r1
 }
 
+// GetResampledCandles provides a mock function with given fields: ctx, symbol, exchange, source, target, start, end
+func (_m *MarketRepository) GetResampledCandles(ctx context.Context, symbol string, exchange string, source market.Interval, target market.Interval, start time.Time, end time.Time) ([]*market.Candle, error) {
+	ret := _m.Called(ctx, symbol, exchange, source, target, start, end)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetResampledCandles")
+	}
+
+	var r0 []*market.Candle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, market.Interval, market.Interval, time.Time, time.Time) ([]*market.Candle, error)); ok {
+		return rf(ctx, symbol, exchange, source, target, start, end)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, market.Interval, market.Interval, time.Time, time.Time) []*market.Candle); ok {
+		r0 = rf(ctx, symbol, exchange, source, target, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*market.Candle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, market.Interval, market.Interval, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, symbol, exchange, source, target, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLatestCandle provides a mock function with given fields: ctx, symbol, exchange, interval
 func (_m *MarketRepository) GetLatestCandle(ctx context.Context, symbol string, exchange string, interval market.Interval) (*market.Candle, error) {
 	ret := _m.Called(ctx, symbol, exchange, interval)
@@ -196,6 +226,36 @@ func (_m *MarketRepository) GetOrderBook(ctx context.Context, symbol string, exc
 	return r0, r1
 }
 
+// SearchSymbols provides a mock function with given fields: ctx, query, limit
+func (_m *MarketRepository) SearchSymbols(ctx context.Context, query string, limit int) ([]*market.Symbol, error) {
+	ret := _m.Called(ctx, query, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchSymbols")
+	}
+
+	var r0 []*market.Symbol
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]*market.Symbol, error)); ok {
+		return rf(ctx, query, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []*market.Symbol); ok {
+		r0 = rf(ctx, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*market.Symbol)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTicker provides a mock function with given fields: ctx, symbol, exchange
 func (_m *MarketRepository) GetTicker(ctx context.Context, symbol string, exchange string) (*market.Ticker, error) {
 	ret := _m.Called(ctx, symbol, exchange)
@@ -138,6 +138,36 @@ func (_m *OrderRepository) GetByID(ctx context.Context, id string) (*model.Order
 	return r0, r1
 }
 
+// GetByOrderID provides a mock function with given fields: ctx, orderID
+func (_m *OrderRepository) GetByOrderID(ctx context.Context, orderID string) (*model.Order, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByOrderID")
+	}
+
+	var r0 *model.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Order, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Order); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByStatus provides a mock function with given fields: ctx, status, limit, offset
 func (_m *OrderRepository) GetByStatus(ctx context.Context, status model.OrderStatus, limit int, offset int) ([]*model.Order, error) {
 	ret := _m.Called(ctx, status, limit, offset)
@@ -198,6 +228,36 @@ func (_m *OrderRepository) GetBySymbol(ctx context.Context, symbol string, limit
 	return r0, r1
 }
 
+// GetBySymbolBefore provides a mock function with given fields: ctx, userID, symbol, cursor, limit
+func (_m *OrderRepository) GetBySymbolBefore(ctx context.Context, userID string, symbol string, cursor *model.OrderCursor, limit int) ([]*model.Order, error) {
+	ret := _m.Called(ctx, userID, symbol, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBySymbolBefore")
+	}
+
+	var r0 []*model.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *model.OrderCursor, int) ([]*model.Order, error)); ok {
+		return rf(ctx, userID, symbol, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *model.OrderCursor, int) []*model.Order); ok {
+		r0 = rf(ctx, userID, symbol, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *model.OrderCursor, int) error); ok {
+		r1 = rf(ctx, userID, symbol, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByUserID provides a mock function with given fields: ctx, userID, limit, offset
 func (_m *OrderRepository) GetByUserID(ctx context.Context, userID string, limit int, offset int) ([]*model.Order, error) {
 	ret := _m.Called(ctx, userID, limit, offset)
@@ -228,6 +288,43 @@ func (_m *OrderRepository) GetByUserID(ctx context.Context, userID string, limit
 	return r0, r1
 }
 
+// Query provides a mock function with given fields: ctx, filter
+func (_m *OrderRepository) Query(ctx context.Context, filter model.OrderFilter) ([]*model.Order, int64, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Query")
+	}
+
+	var r0 []*model.Order
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.OrderFilter) ([]*model.Order, int64, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.OrderFilter) []*model.Order); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.OrderFilter) int64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.OrderFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Update provides a mock function with given fields: ctx, order
 func (_m *OrderRepository) Update(ctx context.Context, order *model.Order) error {
 	ret := _m.Called(ctx, order)
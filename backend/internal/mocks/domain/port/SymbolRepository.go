@@ -80,6 +80,36 @@ func (_m *SymbolRepository) GetAll(ctx context.Context) ([]*market.Symbol, error
 	return r0, r1
 }
 
+// GetAllIncludingDeleted provides a mock function with given fields: ctx
+func (_m *SymbolRepository) GetAllIncludingDeleted(ctx context.Context) ([]*market.Symbol, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllIncludingDeleted")
+	}
+
+	var r0 []*market.Symbol
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*market.Symbol, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*market.Symbol); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*market.Symbol)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByExchange provides a mock function with given fields: ctx, exchange
 func (_m *SymbolRepository) GetByExchange(ctx context.Context, exchange string) ([]*market.Symbol, error) {
 	ret := _m.Called(ctx, exchange)
@@ -140,6 +170,24 @@ func (_m *SymbolRepository) GetBySymbol(ctx context.Context, symbol string) (*ma
 	return r0, r1
 }
 
+// Restore provides a mock function with given fields: ctx, symbol
+func (_m *SymbolRepository) Restore(ctx context.Context, symbol string) error {
+	ret := _m.Called(ctx, symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, symbol)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, symbol
 func (_m *SymbolRepository) Update(ctx context.Context, symbol *market.Symbol) error {
 	ret := _m.Called(ctx, symbol)
@@ -158,6 +206,59 @@ func (_m *SymbolRepository) Update(ctx context.Context, symbol *market.Symbol) e
 	return r0
 }
 
+// Upsert provides a mock function with given fields: ctx, symbol
+func (_m *SymbolRepository) Upsert(ctx context.Context, symbol *market.Symbol) error {
+	ret := _m.Called(ctx, symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *market.Symbol) error); ok {
+		r0 = rf(ctx, symbol)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertMany provides a mock function with given fields: ctx, symbols
+func (_m *SymbolRepository) UpsertMany(ctx context.Context, symbols []*market.Symbol) (int, int, error) {
+	ret := _m.Called(ctx, symbols)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertMany")
+	}
+
+	var r0 int
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*market.Symbol) (int, int, error)); ok {
+		return rf(ctx, symbols)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*market.Symbol) int); ok {
+		r0 = rf(ctx, symbols)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*market.Symbol) int); ok {
+		r1 = rf(ctx, symbols)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []*market.Symbol) error); ok {
+		r2 = rf(ctx, symbols)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewSymbolRepository creates a new instance of SymbolRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewSymbolRepository(t interface {
@@ -0,0 +1,111 @@
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// BudgetTracker is an autogenerated mock type for the BudgetTracker type
+type BudgetTracker struct {
+	mock.Mock
+}
+
+// RecordBuy provides a mock function with given fields: symbol, amount, quantity
+func (_m *BudgetTracker) RecordBuy(symbol string, amount float64, quantity float64) error {
+	ret := _m.Called(symbol, amount, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordBuy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, float64, float64) error); ok {
+		r0 = rf(symbol, amount, quantity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordSell provides a mock function with given fields: symbol, quantity
+func (_m *BudgetTracker) RecordSell(symbol string, quantity float64) error {
+	ret := _m.Called(symbol, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordSell")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, float64) error); ok {
+		r0 = rf(symbol, quantity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReleasePosition provides a mock function with given fields: symbol
+func (_m *BudgetTracker) ReleasePosition(symbol string) error {
+	ret := _m.Called(symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleasePosition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(symbol)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Usage provides a mock function with no fields
+func (_m *BudgetTracker) Usage() (float64, int, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Usage")
+	}
+
+	var r0 float64
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (float64, int, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() float64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func() int); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func() error); ok {
+		r2 = rf()
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewBudgetTracker creates a new instance of BudgetTracker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBudgetTracker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BudgetTracker {
+	mock := &BudgetTracker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -76,6 +76,15 @@ func (m *MockMarketRepository) GetCandles(ctx context.Context, symbol, exchange
 	return args.Get(0).([]*market.Candle), args.Error(1)
 }
 
+// GetResampledCandles mocks the GetResampledCandles method
+func (m *MockMarketRepository) GetResampledCandles(ctx context.Context, symbol, exchange string, source, target market.Interval, start, end time.Time) ([]*market.Candle, error) {
+	args := m.Called(ctx, symbol, exchange, source, target, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*market.Candle), args.Error(1)
+}
+
 // GetLatestCandle mocks the GetLatestCandle method
 func (m *MockMarketRepository) GetLatestCandle(ctx context.Context, symbol, exchange string, interval market.Interval) (*market.Candle, error) {
 	args := m.Called(ctx, symbol, exchange, interval)
@@ -117,3 +126,12 @@ func (m *MockMarketRepository) GetOrderBook(ctx context.Context, symbol, exchang
 	}
 	return args.Get(0).(*market.OrderBook), args.Error(1)
 }
+
+// SearchSymbols mocks the SearchSymbols method
+func (m *MockMarketRepository) SearchSymbols(ctx context.Context, query string, limit int) ([]*market.Symbol, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*market.Symbol), args.Error(1)
+}
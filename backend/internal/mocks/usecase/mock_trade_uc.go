@@ -56,10 +56,29 @@ func (m *MockTradeUseCase) GetOrderHistory(ctx context.Context, symbol string, l
 	}, nil
 }
 
+// GetOrderHistoryPage gets a single page of order history for a symbol
+func (m *MockTradeUseCase) GetOrderHistoryPage(ctx context.Context, userID, symbol, cursor string, limit int) (*model.OrderPage, error) {
+	orders, err := m.GetOrderHistory(ctx, symbol, limit, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &model.OrderPage{Orders: orders}, nil
+}
+
 // CalculateRequiredQuantity calculates the required quantity for an order based on amount in quote currency
 func (m *MockTradeUseCase) CalculateRequiredQuantity(ctx context.Context, symbol string, side model.OrderSide, amount float64) (float64, error) {
 	return amount, nil
 }
 
+// CalculateQuantityForRisk calculates the quantity that risks riskPct percent of equity if stopPrice is hit
+func (m *MockTradeUseCase) CalculateQuantityForRisk(ctx context.Context, symbol string, side model.OrderSide, riskPct, stopPrice float64) (float64, error) {
+	return riskPct, nil
+}
+
+// GetPnLReport returns an empty PnL report for the symbol
+func (m *MockTradeUseCase) GetPnLReport(ctx context.Context, userID, symbol string) (*model.PnLReport, error) {
+	return &model.PnLReport{Symbol: symbol}, nil
+}
+
 // Ensure MockTradeUseCase implements TradeUseCase
 var _ TradeUseCase = (*MockTradeUseCase)(nil)
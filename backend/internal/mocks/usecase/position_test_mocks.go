@@ -83,6 +83,14 @@ func (m *PositionMockMarketRepository) GetCandles(ctx context.Context, symbol, e
 	return args.Get(0).([]*market.Candle), args.Error(1)
 }
 
+func (m *PositionMockMarketRepository) GetResampledCandles(ctx context.Context, symbol, exchange string, source, target market.Interval, start, end time.Time) ([]*market.Candle, error) {
+	args := m.Called(ctx, symbol, exchange, source, target, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*market.Candle), args.Error(1)
+}
+
 func (m *PositionMockMarketRepository) PurgeOldData(ctx context.Context, olderThan time.Time) error {
 	args := m.Called(ctx, olderThan)
 	return args.Error(0)
@@ -142,6 +150,29 @@ func (m *PositionMockSymbolRepository) Delete(ctx context.Context, symbol string
 	return args.Error(0)
 }
 
+func (m *PositionMockSymbolRepository) GetAllIncludingDeleted(ctx context.Context) ([]*market.Symbol, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*market.Symbol), args.Error(1)
+}
+
+func (m *PositionMockSymbolRepository) Restore(ctx context.Context, symbol string) error {
+	args := m.Called(ctx, symbol)
+	return args.Error(0)
+}
+
+func (m *PositionMockSymbolRepository) Upsert(ctx context.Context, symbol *market.Symbol) error {
+	args := m.Called(ctx, symbol)
+	return args.Error(0)
+}
+
+func (m *PositionMockSymbolRepository) UpsertMany(ctx context.Context, symbols []*market.Symbol) (int, int, error) {
+	args := m.Called(ctx, symbols)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
 type PositionMockRepository struct {
 	mock.Mock
 }
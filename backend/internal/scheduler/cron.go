@@ -0,0 +1,214 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule decides when a job runs
+type Schedule interface {
+	// Next returns the first run time after t, or the zero time when the job never runs again
+	Next(t time.Time) time.Time
+}
+
+// intervalSchedule runs a job at a fixed interval
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+// Every returns a schedule running a job every interval, counted from the previous run
+func Every(interval time.Duration) Schedule {
+	return intervalSchedule{interval: interval}
+}
+
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(s.interval)
+}
+
+// cronField is the range of values one field of a cron expression may take
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var (
+	secondField = cronField{name: "second", min: 0, max: 59}
+	minuteField = cronField{name: "minute", min: 0, max: 59}
+	hourField   = cronField{name: "hour", min: 0, max: 23}
+	dayField    = cronField{name: "day of month", min: 1, max: 31}
+	monthField  = cronField{name: "month", min: 1, max: 12}
+	// 7 is accepted for Sunday as well as 0
+	weekdayField = cronField{name: "day of week", min: 0, max: 7}
+)
+
+// cronDescriptors are the shorthands accepted in place of an expression
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// cronSchedule runs a job at the times matching a cron expression. Each field is a bit
+// set of the values it matches.
+type cronSchedule struct {
+	seconds, minutes, hours, days, months, weekdays uint64
+	// anyDay and anyWeekday record an unrestricted field: when both day fields are
+	// restricted a day matching either runs the job, as in standard cron
+	anyDay, anyWeekday bool
+}
+
+// ParseCron parses a cron expression of five fields, minute hour day-of-month month
+// day-of-week, or of six fields with a leading second. A field is *, a number, a range
+// a-b, or a comma-separated list of these, each optionally with a step /n. Descriptors
+// such as @hourly and @daily are accepted too. Times are matched in the location of the
+// time passed to Next.
+func ParseCron(expr string) (Schedule, error) {
+	spec := strings.TrimSpace(expr)
+	if descriptor, ok := cronDescriptors[strings.ToLower(spec)]; ok {
+		spec = descriptor
+	}
+
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("cron expression %q must have 5 or 6 fields, got %d", expr, len(fields))
+	}
+
+	schedule := &cronSchedule{anyDay: fields[3] == "*", anyWeekday: fields[5] == "*"}
+	for i, target := range []struct {
+		field cronField
+		bits  *uint64
+	}{
+		{secondField, &schedule.seconds},
+		{minuteField, &schedule.minutes},
+		{hourField, &schedule.hours},
+		{dayField, &schedule.days},
+		{monthField, &schedule.months},
+		{weekdayField, &schedule.weekdays},
+	} {
+		bits, err := parseCronField(fields[i], target.field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		*target.bits = bits
+	}
+	if schedule.weekdays&(1<<7) != 0 {
+		schedule.weekdays |= 1
+	}
+	return schedule, nil
+}
+
+// parseCronField returns the bit set of the values a field matches
+func parseCronField(spec string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(spec, ",") {
+		rangeSpec, step := part, 1
+		if slash := strings.IndexByte(part, '/'); slash >= 0 {
+			rangeSpec = part[:slash]
+			n, err := strconv.Atoi(part[slash+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field %q", field.name, part)
+			}
+			step = n
+		}
+
+		low, high := field.min, field.max
+		switch {
+		case rangeSpec == "*":
+		case strings.Contains(rangeSpec, "-"):
+			bounds := strings.SplitN(rangeSpec, "-", 2)
+			var err error
+			if low, err = parseCronValue(bounds[0], field); err != nil {
+				return 0, err
+			}
+			if high, err = parseCronValue(bounds[1], field); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("%s range %q is reversed", field.name, rangeSpec)
+			}
+		default:
+			value, err := parseCronValue(rangeSpec, field)
+			if err != nil {
+				return 0, err
+			}
+			low = value
+			// A single value with a step, such as 5/15, runs from the value to the maximum
+			high = value
+			if step > 1 {
+				high = field.max
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			bits |= 1 << value
+		}
+	}
+	return bits, nil
+}
+
+// parseCronValue parses one number of a field and checks it is within the field's range
+func parseCronValue(spec string, field cronField) (int, error) {
+	value, err := strconv.Atoi(spec)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", field.name, spec)
+	}
+	if value < field.min || value > field.max {
+		return 0, fmt.Errorf("%s %d is outside %d-%d", field.name, value, field.min, field.max)
+	}
+	return value, nil
+}
+
+// cronSearchYears bounds the search for the next run, so an expression that never
+// matches, such as the 30th of February, does not loop forever
+const cronSearchYears = 5
+
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	// Runs are on whole seconds, strictly after t
+	t = t.Truncate(time.Second).Add(time.Second)
+	limit := t.Year() + cronSearchYears
+
+	for t.Year() <= limit {
+		if s.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hours&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minutes&(1<<uint(t.Minute())) == 0 {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		if s.seconds&(1<<uint(t.Second())) == 0 {
+			t = t.Add(time.Second)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchesDay reports whether the day of t matches the day-of-month and day-of-week fields
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	day := s.days&(1<<uint(t.Day())) != 0
+	weekday := s.weekdays&(1<<uint(t.Weekday())) != 0
+	if !s.anyDay && !s.anyWeekday {
+		return day || weekday
+	}
+	return day && weekday
+}
@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron_Next(t *testing.T) {
+	// A Friday
+	from := time.Date(2024, 5, 10, 12, 34, 56, 500_000_000, time.UTC)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "* * * * * *", want: time.Date(2024, 5, 10, 12, 34, 57, 0, time.UTC)},
+		{expr: "*/15 * * * * *", want: time.Date(2024, 5, 10, 12, 35, 0, 0, time.UTC)},
+		{expr: "* * * * *", want: time.Date(2024, 5, 10, 12, 35, 0, 0, time.UTC)},
+		{expr: "30 2 * * *", want: time.Date(2024, 5, 11, 2, 30, 0, 0, time.UTC)},
+		{expr: "0 9-17/4 * * 1-5", want: time.Date(2024, 5, 10, 13, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * 0", want: time.Date(2024, 5, 12, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * 7", want: time.Date(2024, 5, 12, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1,15 * *", want: time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)},
+		// With both day fields restricted either one matches: the 1st, or any Monday
+		{expr: "0 0 1 * 1", want: time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{expr: "@hourly", want: time.Date(2024, 5, 10, 13, 0, 0, 0, time.UTC)},
+		{expr: "@monthly", want: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 30 2 *", want: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := ParseCron(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, schedule.Next(from))
+		})
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@sometimes",
+	} {
+		_, err := ParseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
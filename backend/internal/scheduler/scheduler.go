@@ -0,0 +1,351 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+)
+
+// DefaultLockTTL is the job lock TTL used when a non-positive one is supplied
+const DefaultLockTTL = time.Minute
+
+// ErrSchedulerRunning is returned when jobs are added to, or started on, a running scheduler
+var ErrSchedulerRunning = errors.New("scheduler is already running")
+
+// JobFunc is the work of a job. The context is cancelled when the scheduler stops.
+type JobFunc func(ctx context.Context) error
+
+// JobStatus reports the runs of a job
+type JobStatus struct {
+	Name    string `json:"name"`
+	Running bool   `json:"running"`
+	Runs    int    `json:"runs"`
+	Failed  int    `json:"failed"`
+	Skipped int    `json:"skipped"` // Runs skipped because the previous run had not finished
+	// LockedOut counts the runs left to the instance holding the job's lock
+	LockedOut int       `json:"locked_out"`
+	LastRun   time.Time `json:"last_run,omitempty"`
+	NextRun   time.Time `json:"next_run,omitempty"`
+	// LastError is the error or panic of the latest run, empty when it succeeded
+	LastError string `json:"last_error,omitempty"`
+}
+
+// job is a named job and its status
+type job struct {
+	schedule Schedule
+	run      JobFunc
+	status   JobStatus
+}
+
+// Scheduler runs named jobs in-process on cron or fixed-interval schedules. A run that is
+// due while the previous run of the same job is still going is skipped rather than
+// overlapping it. A job that panics is recovered and logged, and keeps its schedule.
+//
+// With a lock repository, a job only runs on the instance holding its lock, so replicas
+// sharing a database do not run it twice. The holder keeps the lock across runs by taking
+// it again at every run and renewing it while a run lasts; when the holder dies its lock
+// expires and the next instance whose run falls due takes over.
+type Scheduler struct {
+	logger *zerolog.Logger
+	now    func() time.Time
+	// after returns a channel receiving once d has passed, replaced in tests
+	after func(d time.Duration) <-chan time.Time
+
+	locks   port.JobLockRepository
+	owner   string
+	lockTTL time.Duration
+
+	mu     sync.Mutex
+	jobs   map[string]*job
+	cancel context.CancelFunc
+	// loops tracks the goroutine scheduling each job, runs the jobs in progress
+	loops sync.WaitGroup
+	runs  sync.WaitGroup
+}
+
+// New creates a Scheduler without jobs
+func New(logger *zerolog.Logger) *Scheduler {
+	return &Scheduler{
+		logger: logger,
+		now:    time.Now,
+		after:  time.After,
+		jobs:   make(map[string]*job),
+	}
+}
+
+// WithLock makes jobs run only on the instance holding their lock in locks. owner
+// identifies this instance and must differ between instances. ttl is how long a lock
+// outlives its last renewal, and so how long jobs pause when the holder dies.
+func (s *Scheduler) WithLock(locks port.JobLockRepository, owner string, ttl time.Duration) *Scheduler {
+	if ttl <= 0 {
+		ttl = DefaultLockTTL
+	}
+	s.locks = locks
+	s.owner = owner
+	s.lockTTL = ttl
+	return s
+}
+
+// AddCron adds a job run at the times matching a cron expression, see ParseCron
+func (s *Scheduler) AddCron(name, expr string, run JobFunc) error {
+	schedule, err := ParseCron(expr)
+	if err != nil {
+		return err
+	}
+	return s.Add(name, schedule, run)
+}
+
+// AddInterval adds a job run every interval, the first run an interval after Start
+func (s *Scheduler) AddInterval(name string, interval time.Duration, run JobFunc) error {
+	if interval <= 0 {
+		return fmt.Errorf("interval of job %q must be positive", name)
+	}
+	return s.Add(name, Every(interval), run)
+}
+
+// Add adds a job on schedule. Job names are unique, and jobs must be added before Start.
+func (s *Scheduler) Add(name string, schedule Schedule, run JobFunc) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return ErrSchedulerRunning
+	}
+	if _, exists := s.jobs[name]; exists {
+		return fmt.Errorf("job %q is already scheduled", name)
+	}
+	s.jobs[name] = &job{schedule: schedule, run: run, status: JobStatus{Name: name}}
+	return nil
+}
+
+// Start schedules the jobs until Stop is called or ctx is done
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return ErrSchedulerRunning
+	}
+	ctx, s.cancel = context.WithCancel(ctx)
+	for name, j := range s.jobs {
+		s.loops.Add(1)
+		go s.loop(ctx, name, j)
+	}
+
+	s.logger.Info().Int("jobs", len(s.jobs)).Msg("Job scheduler started")
+	return nil
+}
+
+// Stop stops scheduling runs, cancels the context of the runs in progress and waits for
+// them to return, or for ctx to be done. The job locks held are then released, so another
+// instance takes over without waiting for them to expire.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.cancel = nil
+	s.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+	done := make(chan struct{})
+	go func() {
+		s.loops.Wait()
+		s.runs.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return fmt.Errorf("job scheduler did not stop: %w", ctx.Err())
+	}
+
+	if s.locks != nil {
+		for _, name := range s.jobNames() {
+			if err := s.locks.Release(ctx, name, s.owner); err != nil {
+				s.logger.Warn().Err(err).Str("job", name).Msg("Failed to release job lock")
+			}
+		}
+	}
+	s.logger.Info().Msg("Job scheduler stopped")
+	return nil
+}
+
+// Jobs returns the status of every job, sorted by name
+func (s *Scheduler) Jobs() []JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]JobStatus, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		statuses = append(statuses, j.status)
+	}
+	sort.Slice(statuses, func(i, k int) bool { return statuses[i].Name < statuses[k].Name })
+	return statuses
+}
+
+// jobNames returns the names of the jobs
+func (s *Scheduler) jobNames() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.jobs))
+	for name := range s.jobs {
+		names = append(names, name)
+	}
+	return names
+}
+
+// loop waits for each run time of a job and starts the run, unless the previous run is
+// still going
+func (s *Scheduler) loop(ctx context.Context, name string, j *job) {
+	defer s.loops.Done()
+
+	next := j.schedule.Next(s.now())
+	for {
+		if next.IsZero() {
+			s.logger.Warn().Str("job", name).Msg("Job has no further runs scheduled")
+			return
+		}
+		s.mu.Lock()
+		j.status.NextRun = next
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.after(next.Sub(s.now())):
+		}
+
+		s.mu.Lock()
+		if j.status.Running {
+			j.status.Skipped++
+			s.mu.Unlock()
+			s.logger.Warn().Str("job", name).Msg("Skipping job run, the previous run has not finished")
+		} else {
+			j.status.Running = true
+			s.runs.Add(1)
+			s.mu.Unlock()
+			go s.execute(ctx, name, j)
+		}
+
+		// A loop held up past further run times resumes from now rather than catching up
+		next = j.schedule.Next(next)
+		if now := s.now(); next.Before(now) {
+			next = j.schedule.Next(now)
+		}
+	}
+}
+
+// execute runs a job once, recovering a panic, and records the outcome. A job whose lock
+// is held by another instance is not run.
+func (s *Scheduler) execute(ctx context.Context, name string, j *job) {
+	defer s.runs.Done()
+
+	ctx, unlock, locked := s.lock(ctx, name)
+	if !locked {
+		s.mu.Lock()
+		j.status.Running = false
+		j.status.LockedOut++
+		s.mu.Unlock()
+		return
+	}
+	defer unlock()
+
+	started := s.now()
+	s.mu.Lock()
+	j.status.LastRun = started
+	s.mu.Unlock()
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				s.logger.Error().
+					Str("job", name).
+					Interface("panic", r).
+					Bytes("stack", debug.Stack()).
+					Msg("Job panicked")
+				err = fmt.Errorf("job panicked: %v", r)
+			}
+		}()
+		return j.run(ctx)
+	}()
+
+	s.mu.Lock()
+	j.status.Running = false
+	j.status.Runs++
+	j.status.LastError = ""
+	if err != nil {
+		j.status.Failed++
+		j.status.LastError = err.Error()
+	}
+	s.mu.Unlock()
+
+	if err != nil {
+		s.logger.Error().Err(err).Str("job", name).Msg("Job failed")
+		return
+	}
+	s.logger.Debug().Str("job", name).Dur("duration", s.now().Sub(started)).Msg("Job finished")
+}
+
+// lock takes the lock of a job for a run, and renews it every third of the TTL until the
+// returned unlock is called. The lock is kept after the run. The returned context is
+// cancelled when the lock is lost to another instance. Without a lock repository every
+// run goes ahead.
+func (s *Scheduler) lock(ctx context.Context, name string) (context.Context, func(), bool) {
+	if s.locks == nil {
+		return ctx, func() {}, true
+	}
+	acquired, err := s.locks.Acquire(ctx, name, s.owner, s.lockTTL)
+	if err != nil {
+		s.logger.Error().Err(err).Str("job", name).Msg("Failed to acquire job lock, skipping run")
+		return nil, nil, false
+	}
+	if !acquired {
+		s.logger.Debug().Str("job", name).Msg("Job lock is held by another instance, skipping run")
+		return nil, nil, false
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		ticker := time.NewTicker(s.lockTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			held, err := s.locks.Renew(ctx, name, s.owner, s.lockTTL)
+			if err != nil {
+				// The lock stays valid until it expires; the next tick tries again
+				s.logger.Warn().Err(err).Str("job", name).Msg("Failed to renew job lock")
+				continue
+			}
+			if !held {
+				s.logger.Error().Str("job", name).Msg("Lost job lock to another instance, cancelling run")
+				cancel()
+				return
+			}
+		}
+	}()
+
+	return ctx, func() {
+		close(done)
+		<-renewed
+		cancel()
+	}, true
+}
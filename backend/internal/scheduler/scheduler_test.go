@@ -0,0 +1,324 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// manualTimer is a wait the scheduler asked the manual clock for
+type manualTimer struct {
+	wait time.Duration
+	ch   chan time.Time
+}
+
+// manualClock moves only when a test fires the wait the scheduler is blocked on
+type manualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers chan manualTimer
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	c.timers <- manualTimer{wait: d, ch: ch}
+	return ch
+}
+
+// next returns the wait the scheduler asks for next
+func (c *manualClock) next(t *testing.T) manualTimer {
+	select {
+	case timer := <-c.timers:
+		return timer
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not wait for its next run")
+		return manualTimer{}
+	}
+}
+
+// fire moves the clock to the end of timer's wait and wakes the scheduler
+func (c *manualClock) fire(timer manualTimer) {
+	c.mu.Lock()
+	c.now = c.now.Add(timer.wait)
+	now := c.now
+	c.mu.Unlock()
+	timer.ch <- now
+}
+
+func newTestScheduler() (*Scheduler, *manualClock) {
+	logger := zerolog.Nop()
+	clock := &manualClock{
+		now:    time.Date(2024, 5, 10, 12, 0, 0, 500_000_000, time.UTC),
+		timers: make(chan manualTimer, 16),
+	}
+	s := New(&logger)
+	s.now = clock.Now
+	s.after = clock.After
+	return s, clock
+}
+
+func stopScheduler(t *testing.T, s *Scheduler) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, s.Stop(ctx))
+}
+
+func TestScheduler_PerSecondCronFiresEverySecond(t *testing.T) {
+	s, clock := newTestScheduler()
+	runs := make(chan time.Time, 10)
+	require.NoError(t, s.AddCron("tick", "* * * * * *", func(ctx context.Context) error {
+		runs <- clock.Now()
+		return nil
+	}))
+	require.NoError(t, s.Start(context.Background()))
+	defer stopScheduler(t, s)
+
+	// Run for five seconds from half a second past the start
+	var waits []time.Duration
+	var ran []time.Time
+	end := clock.Now().Add(5 * time.Second)
+	for {
+		timer := clock.next(t)
+		if clock.Now().Add(timer.wait).After(end) {
+			break
+		}
+		waits = append(waits, timer.wait)
+		clock.fire(timer)
+		ran = append(ran, <-runs)
+		// Let the run finish, so the next one is not skipped as overlapping
+		require.Eventually(t, func() bool { return s.Jobs()[0].Runs == len(ran) }, time.Second, time.Millisecond)
+	}
+
+	assert.Equal(t, []time.Duration{500 * time.Millisecond, time.Second, time.Second, time.Second, time.Second}, waits)
+	assert.Len(t, ran, 5)
+	assert.Equal(t, time.Date(2024, 5, 10, 12, 0, 1, 0, time.UTC), ran[0])
+	assert.Equal(t, time.Date(2024, 5, 10, 12, 0, 5, 0, time.UTC), ran[4])
+	assert.Equal(t, 0, s.Jobs()[0].Skipped)
+}
+
+func TestScheduler_SkipsOverlappingRuns(t *testing.T) {
+	s, clock := newTestScheduler()
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+	require.NoError(t, s.AddInterval("sync", time.Minute, func(ctx context.Context) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}))
+	require.NoError(t, s.Start(context.Background()))
+	defer stopScheduler(t, s)
+
+	clock.fire(clock.next(t))
+	<-started
+	// Two more runs fall due while the first is still going
+	clock.fire(clock.next(t))
+	clock.fire(clock.next(t))
+	timer := clock.next(t)
+
+	status := s.Jobs()[0]
+	assert.True(t, status.Running)
+	assert.Equal(t, 2, status.Skipped)
+	assert.Equal(t, 0, status.Runs)
+	assert.Len(t, started, 0)
+
+	close(release)
+	assert.Eventually(t, func() bool { return !s.Jobs()[0].Running }, time.Second, time.Millisecond)
+	clock.fire(timer)
+	<-started
+	assert.Eventually(t, func() bool { return s.Jobs()[0].Runs == 2 }, time.Second, time.Millisecond)
+	assert.Equal(t, 2, s.Jobs()[0].Skipped)
+}
+
+func TestScheduler_RecoversPanickingJob(t *testing.T) {
+	s, clock := newTestScheduler()
+	calls := 0
+	require.NoError(t, s.AddInterval("purge", time.Hour, func(ctx context.Context) error {
+		calls++
+		if calls == 1 {
+			panic("boom")
+		}
+		return errors.New("still failing")
+	}))
+	require.NoError(t, s.Start(context.Background()))
+	defer stopScheduler(t, s)
+
+	clock.fire(clock.next(t))
+	timer := clock.next(t)
+	require.Eventually(t, func() bool { return s.Jobs()[0].Runs == 1 }, time.Second, time.Millisecond)
+	status := s.Jobs()[0]
+	assert.Equal(t, 1, status.Failed)
+	assert.Contains(t, status.LastError, "boom")
+
+	// The job keeps its schedule after panicking
+	clock.fire(timer)
+	require.Eventually(t, func() bool { return s.Jobs()[0].Runs == 2 }, time.Second, time.Millisecond)
+	assert.Equal(t, "still failing", s.Jobs()[0].LastError)
+}
+
+func TestScheduler_StopWaitsForRunningJobs(t *testing.T) {
+	s, clock := newTestScheduler()
+	started := make(chan struct{})
+	finished := false
+	require.NoError(t, s.AddInterval("backup", time.Minute, func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		finished = true
+		return ctx.Err()
+	}))
+	require.NoError(t, s.Start(context.Background()))
+
+	clock.fire(clock.next(t))
+	<-started
+	stopScheduler(t, s)
+	assert.True(t, finished, "Stop returns once the running job has")
+
+	// A job that ignores its context makes Stop give up at the deadline
+	s, clock = newTestScheduler()
+	block := make(chan struct{})
+	defer close(block)
+	require.NoError(t, s.AddInterval("stuck", time.Minute, func(ctx context.Context) error {
+		<-block
+		return nil
+	}))
+	require.NoError(t, s.Start(context.Background()))
+	clock.fire(clock.next(t))
+	assert.Eventually(t, func() bool { return s.Jobs()[0].Running }, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, s.Stop(ctx), context.DeadlineExceeded)
+}
+
+func TestScheduler_AddValidatesJobs(t *testing.T) {
+	s, _ := newTestScheduler()
+	noop := func(ctx context.Context) error { return nil }
+
+	require.NoError(t, s.AddInterval("sync", time.Minute, noop))
+	assert.Error(t, s.AddInterval("sync", time.Hour, noop), "names are unique")
+	assert.Error(t, s.AddInterval("zero", 0, noop))
+	assert.Error(t, s.AddCron("bad", "* * *", noop))
+
+	require.NoError(t, s.Start(context.Background()))
+	defer stopScheduler(t, s)
+	assert.ErrorIs(t, s.AddInterval("late", time.Minute, noop), ErrSchedulerRunning)
+	assert.ErrorIs(t, s.Start(context.Background()), ErrSchedulerRunning)
+}
+
+// memoryLocks is a job lock table shared by the schedulers of a test
+type memoryLocks struct {
+	mu    sync.Mutex
+	now   time.Time
+	locks map[string]memoryLock
+}
+
+type memoryLock struct {
+	owner     string
+	expiresAt time.Time
+}
+
+func (m *memoryLocks) Acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if lock, held := m.locks[name]; held && lock.owner != owner && m.now.Before(lock.expiresAt) {
+		return false, nil
+	}
+	m.locks[name] = memoryLock{owner: owner, expiresAt: m.now.Add(ttl)}
+	return true, nil
+}
+
+func (m *memoryLocks) Renew(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if lock, held := m.locks[name]; !held || lock.owner != owner {
+		return false, nil
+	}
+	m.locks[name] = memoryLock{owner: owner, expiresAt: m.now.Add(ttl)}
+	return true, nil
+}
+
+func (m *memoryLocks) Release(ctx context.Context, name, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if lock, held := m.locks[name]; held && lock.owner == owner {
+		delete(m.locks, name)
+	}
+	return nil
+}
+
+func (m *memoryLocks) advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = m.now.Add(d)
+}
+
+func TestScheduler_OnlyLockHolderRunsJob(t *testing.T) {
+	locks := &memoryLocks{now: time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC), locks: map[string]memoryLock{}}
+	runs := make(chan string, 10)
+	// Two instances with the same job, sharing the lock table
+	newInstance := func(owner string) (*Scheduler, *manualClock) {
+		s, clock := newTestScheduler()
+		s.WithLock(locks, owner, 5*time.Minute)
+		require.NoError(t, s.AddInterval("wallet sync", time.Minute, func(ctx context.Context) error {
+			runs <- owner
+			return nil
+		}))
+		require.NoError(t, s.Start(context.Background()))
+		return s, clock
+	}
+	first, firstClock := newInstance("instance-a")
+	second, secondClock := newInstance("instance-b")
+	defer stopScheduler(t, second)
+
+	// runOnce fires the due run of an instance and waits for it to finish or be locked out
+	runOnce := func(s *Scheduler, clock *manualClock) {
+		before := s.Jobs()[0]
+		clock.fire(clock.next(t))
+		require.Eventually(t, func() bool {
+			status := s.Jobs()[0]
+			return status.Runs+status.LockedOut == before.Runs+before.LockedOut+1
+		}, time.Second, time.Millisecond)
+	}
+
+	for i := 0; i < 3; i++ {
+		runOnce(first, firstClock)
+		runOnce(second, secondClock)
+		locks.advance(time.Minute)
+	}
+	assert.Equal(t, 3, first.Jobs()[0].Runs)
+	assert.Equal(t, 0, second.Jobs()[0].Runs)
+	assert.Equal(t, 3, second.Jobs()[0].LockedOut)
+
+	// The first instance dies without releasing its lock; the second takes over once the
+	// lock expires
+	locks.advance(5 * time.Minute)
+	runOnce(second, secondClock)
+	assert.Equal(t, 1, second.Jobs()[0].Runs)
+	runOnce(first, firstClock)
+	assert.Equal(t, 1, first.Jobs()[0].LockedOut)
+
+	// Stopping an instance hands the lock over straight away
+	stopScheduler(t, second)
+	runOnce(first, firstClock)
+	assert.Equal(t, 4, first.Jobs()[0].Runs)
+	stopScheduler(t, first)
+
+	close(runs)
+	var owners []string
+	for owner := range runs {
+		owners = append(owners, owner)
+	}
+	assert.Equal(t, []string{"instance-a", "instance-a", "instance-a", "instance-b", "instance-a"}, owners)
+}
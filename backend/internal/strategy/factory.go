@@ -0,0 +1,112 @@
+package strategy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+)
+
+// Params are the configuration values passed to a strategy constructor
+type Params map[string]interface{}
+
+// Constructor builds a strategy from parameters
+type Constructor func(params Params) (port.Strategy, error)
+
+// Factory creates strategies by registered name
+type Factory struct {
+	mu           sync.RWMutex
+	constructors map[string]Constructor
+}
+
+// NewStrategyFactory creates a factory with the built-in strategies registered
+func NewStrategyFactory() *Factory {
+	f := &Factory{constructors: make(map[string]Constructor)}
+	f.Register(MACrossoverName, NewMACrossover)
+	f.Register(RSIName, NewRSIStrategy)
+	return f
+}
+
+// Register adds or replaces the constructor for a strategy name
+func (f *Factory) Register(name string, constructor Constructor) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.constructors[strings.ToLower(name)] = constructor
+}
+
+// Create builds the named strategy from params
+func (f *Factory) Create(name string, params map[string]interface{}) (port.Strategy, error) {
+	f.mu.RLock()
+	constructor, ok := f.constructors[strings.ToLower(name)]
+	f.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy: %s", name)
+	}
+	return constructor(params)
+}
+
+// Names returns the registered strategy names in alphabetical order
+func (f *Factory) Names() []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	names := make([]string, 0, len(f.constructors))
+	for name := range f.constructors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// intParam reads an integer parameter, accepting the float64 values produced by JSON decoding
+func intParam(params Params, key string, defaultValue int) (int, error) {
+	raw, ok := params[key]
+	if !ok || raw == nil {
+		return defaultValue, nil
+	}
+	switch v := raw.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != float64(int(v)) {
+			return 0, fmt.Errorf("parameter %s must be an integer, got %v", key, v)
+		}
+		return int(v), nil
+	default:
+		return 0, fmt.Errorf("parameter %s must be an integer, got %T", key, raw)
+	}
+}
+
+// floatParam reads a numeric parameter
+func floatParam(params Params, key string, defaultValue float64) (float64, error) {
+	raw, ok := params[key]
+	if !ok || raw == nil {
+		return defaultValue, nil
+	}
+	switch v := raw.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	default:
+		return 0, fmt.Errorf("parameter %s must be a number, got %T", key, raw)
+	}
+}
+
+// stringParam reads a string parameter
+func stringParam(params Params, key string, defaultValue string) (string, error) {
+	raw, ok := params[key]
+	if !ok || raw == nil {
+		return defaultValue, nil
+	}
+	v, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("parameter %s must be a string, got %T", key, raw)
+	}
+	return v, nil
+}
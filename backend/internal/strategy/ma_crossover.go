@@ -0,0 +1,105 @@
+package strategy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/indicators"
+)
+
+// MACrossoverName is the factory name of the moving-average crossover strategy
+const MACrossoverName = "ma_crossover"
+
+// MAType selects how moving averages are computed
+type MAType string
+
+const (
+	MATypeSMA MAType = "sma"
+	MATypeEMA MAType = "ema"
+)
+
+// MACrossover buys when the fast moving average crosses above the slow one and sells
+// when it crosses below
+type MACrossover struct {
+	fast     *indicators.MovingAverage
+	slow     *indicators.MovingAverage
+	lookback int
+	prevDiff float64
+	havePrev bool
+}
+
+// NewMACrossover creates an MA crossover strategy. Supported params are fast_period
+// (default 9), slow_period (default 21) and ma_type ("sma" or "ema", default "sma").
+func NewMACrossover(params Params) (port.Strategy, error) {
+	fastPeriod, err := intParam(params, "fast_period", 9)
+	if err != nil {
+		return nil, err
+	}
+	slowPeriod, err := intParam(params, "slow_period", 21)
+	if err != nil {
+		return nil, err
+	}
+	maType, err := stringParam(params, "ma_type", string(MATypeSMA))
+	if err != nil {
+		return nil, err
+	}
+
+	if fastPeriod < 1 || slowPeriod < 1 {
+		return nil, fmt.Errorf("moving average periods must be positive, got fast=%d slow=%d", fastPeriod, slowPeriod)
+	}
+	if fastPeriod >= slowPeriod {
+		return nil, fmt.Errorf("fast period (%d) must be shorter than slow period (%d)", fastPeriod, slowPeriod)
+	}
+
+	typ := MAType(strings.ToLower(maType))
+	if typ != MATypeSMA && typ != MATypeEMA {
+		return nil, fmt.Errorf("unsupported moving average type: %s", maType)
+	}
+
+	newAverage := indicators.NewSMA
+	if typ == MATypeEMA {
+		newAverage = indicators.NewEMA
+	}
+	return &MACrossover{
+		fast:     newAverage(fastPeriod),
+		slow:     newAverage(slowPeriod),
+		lookback: slowPeriod,
+	}, nil
+}
+
+// Name implements port.Strategy
+func (s *MACrossover) Name() string {
+	return MACrossoverName
+}
+
+// Lookback implements port.LookbackStrategy. A crossover compares the averages of two
+// candles, so the slow average must be ready one candle early.
+func (s *MACrossover) Lookback() int {
+	return s.lookback
+}
+
+// OnCandle implements port.Strategy
+func (s *MACrossover) OnCandle(candle *model.Kline) model.SignalType {
+	fast, fastReady := s.fast.Add(candle.Close)
+	slow, slowReady := s.slow.Add(candle.Close)
+	if !fastReady || !slowReady {
+		return model.SignalHold
+	}
+
+	diff := fast - slow
+	signal := model.SignalHold
+	if s.havePrev {
+		switch {
+		case s.prevDiff <= 0 && diff > 0:
+			signal = model.SignalBuy
+		case s.prevDiff >= 0 && diff < 0:
+			signal = model.SignalSell
+		}
+	}
+
+	s.prevDiff = diff
+	s.havePrev = true
+	return signal
+}
@@ -0,0 +1,101 @@
+package strategy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/backtest"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// crossoverSeries rises through the slow average at index 4 and falls through it at index 6
+var crossoverSeries = []float64{10, 10, 10, 10, 14, 14, 6, 6}
+
+func makeKlines(closes []float64) []*model.Kline {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	klines := make([]*model.Kline, len(closes))
+	for i, price := range closes {
+		openTime := start.Add(time.Duration(i) * time.Hour)
+		klines[i] = &model.Kline{
+			Symbol:    "BTCUSDT",
+			Interval:  model.KlineInterval1h,
+			OpenTime:  openTime,
+			CloseTime: openTime.Add(time.Hour - time.Millisecond),
+			Open:      price,
+			High:      price,
+			Low:       price,
+			Close:     price,
+		}
+	}
+	return klines
+}
+
+func signalsFor(strategy port.Strategy, closes []float64) []model.SignalType {
+	signals := make([]model.SignalType, 0, len(closes))
+	for _, kline := range makeKlines(closes) {
+		signals = append(signals, strategy.OnCandle(kline))
+	}
+	return signals
+}
+
+func TestMACrossoverSignals(t *testing.T) {
+	h, b, s := model.SignalHold, model.SignalBuy, model.SignalSell
+	expected := []model.SignalType{h, h, h, h, b, h, s, h}
+
+	for _, maType := range []string{"sma", "ema"} {
+		t.Run(maType, func(t *testing.T) {
+			strategy, err := NewStrategyFactory().Create(MACrossoverName, map[string]interface{}{
+				"fast_period": 2,
+				"slow_period": float64(4), // as decoded from JSON
+				"ma_type":     maType,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, MACrossoverName, strategy.Name())
+			// The first signal can come on the candle after the lookback
+			assert.Equal(t, 4, strategy.(port.LookbackStrategy).Lookback())
+			assert.Equal(t, expected, signalsFor(strategy, crossoverSeries))
+		})
+	}
+}
+
+func TestMACrossoverValidation(t *testing.T) {
+	factory := NewStrategyFactory()
+
+	_, err := factory.Create(MACrossoverName, map[string]interface{}{"fast_period": 10, "slow_period": 5})
+	assert.Error(t, err)
+	_, err = factory.Create(MACrossoverName, map[string]interface{}{"fast_period": 0})
+	assert.Error(t, err)
+	_, err = factory.Create(MACrossoverName, map[string]interface{}{"ma_type": "wma"})
+	assert.Error(t, err)
+	_, err = factory.Create(MACrossoverName, map[string]interface{}{"fast_period": 2.5})
+	assert.Error(t, err)
+	_, err = factory.Create("unknown", nil)
+	assert.Error(t, err)
+
+	strategy, err := factory.Create("MA_CROSSOVER", nil)
+	require.NoError(t, err)
+	assert.NotNil(t, strategy)
+}
+
+func TestMACrossoverBacktest(t *testing.T) {
+	strategy, err := NewStrategyFactory().Create(MACrossoverName, map[string]interface{}{"fast_period": 2, "slow_period": 4})
+	require.NoError(t, err)
+
+	logger := zerolog.Nop()
+	source := backtest.NewSliceCandleSource(makeKlines(crossoverSeries))
+	result, err := backtest.NewService(&logger).Run(context.Background(), strategy, source, backtest.Request{InitialCapital: 1000})
+	require.NoError(t, err)
+
+	// Bought at 14 and sold at 6
+	require.Len(t, result.Trades, 2)
+	assert.Equal(t, model.OrderSideBuy, result.Trades[0].Side)
+	assert.Equal(t, 14.0, result.Trades[0].Price)
+	assert.Equal(t, model.OrderSideSell, result.Trades[1].Side)
+	assert.Equal(t, 6.0, result.Trades[1].Price)
+	assert.Less(t, result.Summary.TotalReturn, 0.0)
+}
@@ -0,0 +1,88 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/indicators"
+)
+
+// RSIName is the factory name of the RSI mean-reversion strategy
+const RSIName = "rsi"
+
+// RSIStrategy buys when RSI crosses up through the oversold threshold and sells when it
+// crosses down through the overbought threshold
+type RSIStrategy struct {
+	rsi        *indicators.RSICalculator
+	lookback   int
+	oversold   float64
+	overbought float64
+	prev       float64
+	havePrev   bool
+}
+
+// NewRSIStrategy creates an RSI strategy. Supported params are period (default 14, must
+// be greater than 1), oversold (default 30) and overbought (default 70), with
+// 0 < oversold < overbought < 100.
+func NewRSIStrategy(params Params) (port.Strategy, error) {
+	period, err := intParam(params, "period", 14)
+	if err != nil {
+		return nil, err
+	}
+	oversold, err := floatParam(params, "oversold", 30)
+	if err != nil {
+		return nil, err
+	}
+	overbought, err := floatParam(params, "overbought", 70)
+	if err != nil {
+		return nil, err
+	}
+
+	if period <= 1 {
+		return nil, fmt.Errorf("RSI period must be greater than 1, got %d", period)
+	}
+	if oversold <= 0 || oversold >= overbought || overbought >= 100 {
+		return nil, fmt.Errorf("RSI thresholds must satisfy 0 < oversold < overbought < 100, got oversold=%g overbought=%g", oversold, overbought)
+	}
+
+	return &RSIStrategy{
+		rsi:        indicators.NewRSICalculator(period),
+		lookback:   period + 1,
+		oversold:   oversold,
+		overbought: overbought,
+	}, nil
+}
+
+// Name implements port.Strategy
+func (s *RSIStrategy) Name() string {
+	return RSIName
+}
+
+// Lookback implements port.LookbackStrategy. The first RSI needs period+1 closes and a
+// signal compares it with the next one.
+func (s *RSIStrategy) Lookback() int {
+	return s.lookback
+}
+
+// OnCandle implements port.Strategy
+func (s *RSIStrategy) OnCandle(candle *model.Kline) model.SignalType {
+	value, ready := s.rsi.Add(candle.Close)
+	if !ready {
+		return model.SignalHold
+	}
+
+	signal := model.SignalHold
+	if s.havePrev {
+		switch {
+		case s.prev < s.oversold && value >= s.oversold:
+			signal = model.SignalBuy
+		case s.prev > s.overbought && value <= s.overbought:
+			signal = model.SignalSell
+		}
+	}
+
+	s.prev = value
+	s.havePrev = true
+	return signal
+}
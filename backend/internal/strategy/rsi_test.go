@@ -0,0 +1,49 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rsiSeries bottoms at index 2 and tops at index 5
+var rsiSeries = []float64{10, 9, 8, 9, 10, 11, 10, 9}
+
+func TestRSIStrategySignals(t *testing.T) {
+	strategy, err := NewStrategyFactory().Create("rsi", map[string]interface{}{
+		"period":     2,
+		"oversold":   30,
+		"overbought": 70.0,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, RSIName, strategy.Name())
+	assert.Equal(t, 3, strategy.(port.LookbackStrategy).Lookback())
+
+	h, b, s := model.SignalHold, model.SignalBuy, model.SignalSell
+	// RSI: -, -, 0, 50 (up through 30), 75, 87.5, 43.75 (down through 70), 21.875
+	assert.Equal(t, []model.SignalType{h, h, h, b, h, h, s, h}, signalsFor(strategy, rsiSeries))
+}
+
+func TestRSIStrategyValidation(t *testing.T) {
+	factory := NewStrategyFactory()
+
+	invalid := []map[string]interface{}{
+		{"period": 1},
+		{"oversold": 0},
+		{"oversold": 70, "overbought": 30},
+		{"oversold": 50, "overbought": 50},
+		{"overbought": 100},
+		{"period": "14"},
+	}
+	for _, params := range invalid {
+		_, err := factory.Create(RSIName, params)
+		assert.Error(t, err, "params %v", params)
+	}
+
+	_, err := factory.Create(RSIName, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{MACrossoverName, RSIName}, factory.Names())
+}
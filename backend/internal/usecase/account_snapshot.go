@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+)
+
+// Defaults of the account snapshot
+const (
+	DefaultAccountSnapshotTimeout = 5 * time.Second
+	defaultSnapshotRecentOrders   = 20
+)
+
+// AccountSnapshotUseCase gathers everything an account overview shows in one call
+type AccountSnapshotUseCase struct {
+	walletRepo   port.WalletRepository
+	trades       TradeUseCase
+	timeout      time.Duration
+	recentOrders int
+	logger       *zerolog.Logger
+}
+
+// NewAccountSnapshotUseCase creates an AccountSnapshotUseCase. Wallet balances are read
+// from walletRepo, which the wallet sync keeps current, and orders from trades.
+func NewAccountSnapshotUseCase(walletRepo port.WalletRepository, trades TradeUseCase, logger *zerolog.Logger) *AccountSnapshotUseCase {
+	return &AccountSnapshotUseCase{
+		walletRepo:   walletRepo,
+		trades:       trades,
+		timeout:      DefaultAccountSnapshotTimeout,
+		recentOrders: defaultSnapshotRecentOrders,
+		logger:       logger,
+	}
+}
+
+// snapshotSection is the outcome of gathering one section of a snapshot
+type snapshotSection struct {
+	name    string
+	wallets []*model.Wallet
+	orders  []*model.Order
+	err     error
+}
+
+// GetSnapshot gathers the user's wallets, open orders and recent orders concurrently. A
+// section that fails, or is still pending when ctx or the snapshot timeout expires, is
+// reported in the snapshot's Errors while the other sections are still returned.
+func (uc *AccountSnapshotUseCase) GetSnapshot(ctx context.Context, userID string) *model.AccountSnapshot {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	gatherers := map[string]func(ctx context.Context) snapshotSection{
+		model.AccountSnapshotWallets: func(ctx context.Context) snapshotSection {
+			wallets, err := uc.walletRepo.GetWalletsByUserID(ctx, userID)
+			return snapshotSection{wallets: wallets, err: err}
+		},
+		model.AccountSnapshotOpenOrders: func(ctx context.Context) snapshotSection {
+			orders, err := uc.trades.GetOpenOrders(ctx, "")
+			return snapshotSection{orders: orders, err: err}
+		},
+		model.AccountSnapshotRecentOrders: func(ctx context.Context) snapshotSection {
+			orders, err := uc.trades.GetOrderHistory(ctx, "", uc.recentOrders, 0)
+			return snapshotSection{orders: orders, err: err}
+		},
+	}
+
+	// Buffered so a section finishing after the deadline does not block
+	results := make(chan snapshotSection, len(gatherers))
+	for name, gather := range gatherers {
+		go func(name string, gather func(ctx context.Context) snapshotSection) {
+			section := gather(ctx)
+			section.name = name
+			results <- section
+		}(name, gather)
+	}
+
+	snapshot := &model.AccountSnapshot{
+		UserID:       userID,
+		Wallets:      []*model.Wallet{},
+		OpenOrders:   []*model.Order{},
+		RecentOrders: []*model.Order{},
+	}
+	pending := make(map[string]bool, len(gatherers))
+	for name := range gatherers {
+		pending[name] = true
+	}
+
+	for len(pending) > 0 {
+		select {
+		case section := <-results:
+			delete(pending, section.name)
+			if section.err != nil {
+				uc.failSection(snapshot, userID, section.name, section.err)
+				continue
+			}
+			switch section.name {
+			case model.AccountSnapshotWallets:
+				if section.wallets != nil {
+					snapshot.Wallets = section.wallets
+				}
+			case model.AccountSnapshotOpenOrders:
+				if section.orders != nil {
+					snapshot.OpenOrders = section.orders
+				}
+			case model.AccountSnapshotRecentOrders:
+				if section.orders != nil {
+					snapshot.RecentOrders = section.orders
+				}
+			}
+		case <-ctx.Done():
+			for name := range pending {
+				uc.failSection(snapshot, userID, name, ctx.Err())
+			}
+			pending = nil
+		}
+	}
+
+	snapshot.GeneratedAt = time.Now()
+	return snapshot
+}
+
+// failSection marks a section of the snapshot as not gathered
+func (uc *AccountSnapshotUseCase) failSection(snapshot *model.AccountSnapshot, userID, name string, err error) {
+	uc.logger.Warn().Err(err).Str("userID", userID).Str("section", name).Msg("Failed to gather account snapshot section")
+	if snapshot.Errors == nil {
+		snapshot.Errors = make(map[string]string)
+	}
+	snapshot.Errors[name] = err.Error()
+	snapshot.Partial = true
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSnapshotWallets returns fixed wallets for a user
+type stubSnapshotWallets struct {
+	port.WalletRepository
+	wallets []*model.Wallet
+	err     error
+}
+
+func (r *stubSnapshotWallets) GetWalletsByUserID(ctx context.Context, userID string) ([]*model.Wallet, error) {
+	return r.wallets, r.err
+}
+
+// stubSnapshotTrades returns fixed orders, optionally blocking open orders until the
+// context ends
+type stubSnapshotTrades struct {
+	TradeUseCase
+	open       []*model.Order
+	openErr    error
+	blockOpen  bool
+	history    []*model.Order
+	historyErr error
+	limit      int
+}
+
+func (s *stubSnapshotTrades) GetOpenOrders(ctx context.Context, symbol string) ([]*model.Order, error) {
+	if s.blockOpen {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return s.open, s.openErr
+}
+
+func (s *stubSnapshotTrades) GetOrderHistory(ctx context.Context, symbol string, limit, offset int) ([]*model.Order, error) {
+	s.limit = limit
+	return s.history, s.historyErr
+}
+
+func newTestAccountSnapshot(wallets *stubSnapshotWallets, trades *stubSnapshotTrades) *AccountSnapshotUseCase {
+	logger := zerolog.Nop()
+	return NewAccountSnapshotUseCase(wallets, trades, &logger)
+}
+
+func TestAccountSnapshot_GathersEverySection(t *testing.T) {
+	trades := &stubSnapshotTrades{
+		open:    []*model.Order{{OrderID: "open-1"}},
+		history: []*model.Order{{OrderID: "filled-1"}, {OrderID: "filled-2"}},
+	}
+	uc := newTestAccountSnapshot(&stubSnapshotWallets{wallets: []*model.Wallet{{ID: "wallet-1"}}}, trades)
+
+	snapshot := uc.GetSnapshot(context.Background(), "user-1")
+
+	assert.False(t, snapshot.Partial)
+	assert.Empty(t, snapshot.Errors)
+	assert.Equal(t, "user-1", snapshot.UserID)
+	require.Len(t, snapshot.Wallets, 1)
+	require.Len(t, snapshot.OpenOrders, 1)
+	assert.Len(t, snapshot.RecentOrders, 2)
+	assert.Equal(t, defaultSnapshotRecentOrders, trades.limit)
+}
+
+func TestAccountSnapshot_FailingSourceIsFlagged(t *testing.T) {
+	trades := &stubSnapshotTrades{
+		openErr: errors.New("exchange unavailable"),
+		history: []*model.Order{{OrderID: "filled-1"}},
+	}
+	uc := newTestAccountSnapshot(&stubSnapshotWallets{wallets: []*model.Wallet{{ID: "wallet-1"}}}, trades)
+
+	snapshot := uc.GetSnapshot(context.Background(), "user-1")
+
+	assert.True(t, snapshot.Partial)
+	assert.Equal(t, map[string]string{model.AccountSnapshotOpenOrders: "exchange unavailable"}, snapshot.Errors)
+	assert.NotNil(t, snapshot.OpenOrders, "a failed section is empty, not null")
+	assert.Empty(t, snapshot.OpenOrders)
+	require.Len(t, snapshot.Wallets, 1)
+	assert.Equal(t, "wallet-1", snapshot.Wallets[0].ID)
+	require.Len(t, snapshot.RecentOrders, 1)
+}
+
+func TestAccountSnapshot_SlowSourceTimesOut(t *testing.T) {
+	trades := &stubSnapshotTrades{blockOpen: true, history: []*model.Order{{OrderID: "filled-1"}}}
+	uc := newTestAccountSnapshot(&stubSnapshotWallets{}, trades)
+	uc.timeout = 50 * time.Millisecond
+
+	started := time.Now()
+	snapshot := uc.GetSnapshot(context.Background(), "user-1")
+
+	assert.Less(t, time.Since(started), time.Second)
+	assert.True(t, snapshot.Partial)
+	assert.Contains(t, snapshot.Errors[model.AccountSnapshotOpenOrders], context.DeadlineExceeded.Error())
+	assert.NotContains(t, snapshot.Errors, model.AccountSnapshotRecentOrders)
+	assert.Len(t, snapshot.RecentOrders, 1)
+	assert.NotNil(t, snapshot.Wallets)
+}
@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/wallet"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
 	"github.com/rs/zerolog"
 )
@@ -22,22 +25,46 @@ type AddressValidatorService interface {
 
 	// GetSupportedNetworks returns a list of supported networks
 	GetSupportedNetworks(ctx context.Context) ([]string, error)
+
+	// ResolveAddress resolves ENS names to addresses; other input is returned unchanged.
+	// The flag reports whether the input was an ENS name.
+	ResolveAddress(ctx context.Context, address string) (string, bool, error)
+}
+
+// ensProviderName is the provider used to resolve ENS names
+const ensProviderName = "Ethereum"
+
+// ethereumMainnetChainID is the only chain ENS names are resolved on
+const ethereumMainnetChainID = 1
+
+// defaultENSCacheTTL is how long resolved ENS names are cached
+const defaultENSCacheTTL = 10 * time.Minute
+
+// ensCacheEntry is a cached ENS resolution
+type ensCacheEntry struct {
+	address   string
+	expiresAt time.Time
 }
 
 // AddressInfo contains information about a wallet address
 type AddressInfo struct {
-	Network     string `json:"network"`      // Network name (e.g., "Ethereum", "Bitcoin")
-	Address     string `json:"address"`      // The wallet address
-	IsValid     bool   `json:"is_valid"`     // Whether the address is valid
-	AddressType string `json:"address_type"` // Type of address (e.g., "EOA", "Contract", "P2PKH")
-	ChainID     int64  `json:"chain_id"`     // Chain ID for the network
-	Explorer    string `json:"explorer"`     // Block explorer URL
+	Network     string `json:"network"`            // Network name (e.g., "Ethereum", "Bitcoin")
+	Address     string `json:"address"`            // The wallet address
+	IsValid     bool   `json:"is_valid"`           // Whether the address is valid
+	AddressType string `json:"address_type"`       // Type of address (e.g., "EOA", "Contract", "P2PKH")
+	ChainID     int64  `json:"chain_id"`           // Chain ID for the network
+	Explorer    string `json:"explorer"`           // Block explorer URL
+	ENSName     string `json:"ens_name,omitempty"` // ENS name the address was resolved from
+	IsENS       bool   `json:"is_ens"`             // Whether the input was an ENS name
 }
 
 // addressValidatorService implements the AddressValidatorService interface
 type addressValidatorService struct {
 	providerRegistry *wallet.ProviderRegistry
 	logger           *zerolog.Logger
+	ensCache         map[string]ensCacheEntry
+	ensCacheTTL      time.Duration
+	ensMutex         sync.RWMutex
 }
 
 // NewAddressValidatorService creates a new AddressValidatorService
@@ -48,6 +75,8 @@ func NewAddressValidatorService(
 	return &addressValidatorService{
 		providerRegistry: providerRegistry,
 		logger:           logger,
+		ensCache:         make(map[string]ensCacheEntry),
+		ensCacheTTL:      defaultENSCacheTTL,
 	}
 }
 
@@ -66,6 +95,18 @@ func (s *addressValidatorService) ValidateAddress(ctx context.Context, network,
 		return false, fmt.Errorf("unsupported network: %s", network)
 	}
 
+	// Resolve ENS names before validating; other networks validate the name as-is
+	if network == ensProviderName && isENSName(address) {
+		resolved, _, err := s.ResolveAddress(ctx, address)
+		if errors.Is(err, model.ErrENSNameNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		address = resolved
+	}
+
 	// Validate the address using the provider
 	return provider.IsValidAddress(ctx, address)
 }
@@ -85,6 +126,18 @@ func (s *addressValidatorService) GetAddressInfo(ctx context.Context, network, a
 		return nil, fmt.Errorf("unsupported network: %s", network)
 	}
 
+	// Resolve ENS names before validating; other networks validate the name as-is
+	var ensName string
+	if network == ensProviderName && isENSName(address) {
+		resolved, _, err := s.ResolveAddress(ctx, address)
+		if err != nil {
+			s.logger.Error().Err(err).Str("name", address).Msg("Failed to resolve ENS name")
+			return nil, err
+		}
+		ensName = address
+		address = resolved
+	}
+
 	// Validate the address using the provider
 	isValid, err := provider.IsValidAddress(ctx, address)
 	if err != nil {
@@ -97,6 +150,8 @@ func (s *addressValidatorService) GetAddressInfo(ctx context.Context, network, a
 		Network: network,
 		Address: address,
 		IsValid: isValid,
+		ENSName: ensName,
+		IsENS:   ensName != "",
 	}
 
 	// Add additional information based on the network
@@ -147,6 +202,54 @@ func (s *addressValidatorService) GetSupportedNetworks(ctx context.Context) ([]s
 	return networks, nil
 }
 
+// ResolveAddress resolves an ENS name to its address using the Ethereum provider, which
+// must be on mainnet. Raw addresses are returned unchanged. Resolutions are cached for
+// ensCacheTTL.
+func (s *addressValidatorService) ResolveAddress(ctx context.Context, address string) (string, bool, error) {
+	address = strings.TrimSpace(address)
+	if !isENSName(address) {
+		return address, false, nil
+	}
+
+	name := strings.ToLower(address)
+
+	s.ensMutex.RLock()
+	entry, ok := s.ensCache[name]
+	s.ensMutex.RUnlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.address, true, nil
+	}
+
+	provider, err := s.providerRegistry.GetProvider(ensProviderName)
+	if err != nil {
+		return "", true, errors.New("ENS resolution is not available")
+	}
+	resolver, ok := provider.(port.ENSResolver)
+	if !ok {
+		return "", true, errors.New("ENS resolution is not available")
+	}
+	if chain, ok := provider.(interface{ GetChainID() int64 }); ok && chain.GetChainID() != ethereumMainnetChainID {
+		return "", true, errors.New("ENS resolution is only available on Ethereum mainnet")
+	}
+
+	resolved, err := resolver.ResolveENS(ctx, name)
+	if err != nil {
+		return "", true, err
+	}
+
+	s.ensMutex.Lock()
+	s.ensCache[name] = ensCacheEntry{address: resolved, expiresAt: time.Now().Add(s.ensCacheTTL)}
+	s.ensMutex.Unlock()
+
+	s.logger.Debug().Str("name", name).Str("address", resolved).Msg("Resolved ENS name")
+	return resolved, true, nil
+}
+
+// isENSName reports whether the input looks like an ENS name rather than an address
+func isENSName(address string) bool {
+	return strings.HasSuffix(strings.ToLower(strings.TrimSpace(address)), ".eth")
+}
+
 // determineBitcoinAddressType determines the type of Bitcoin address
 func determineBitcoinAddressType(address string) string {
 	// P2PKH addresses start with 1
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/wallet"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
@@ -209,3 +210,128 @@ func TestAddressValidatorService_DetermineBitcoinAddressType(t *testing.T) {
 	invalidAddress := "invalid_address"
 	assert.Equal(t, "Unknown", determineBitcoinAddressType(invalidAddress))
 }
+
+// AVMockENSProvider is a Web3 provider mock that also resolves ENS names
+type AVMockENSProvider struct {
+	AVMockWeb3Provider
+}
+
+func (m *AVMockENSProvider) ResolveENS(ctx context.Context, name string) (string, error) {
+	args := m.Called(ctx, name)
+	return args.String(0), args.Error(1)
+}
+
+func TestAddressValidatorService_ResolveENS(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	resolvedAddress := "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045"
+
+	providerRegistry := wallet.NewProviderRegistry()
+	mockProvider := new(AVMockENSProvider)
+	mockProvider.On("GetName").Return("Ethereum")
+	mockProvider.On("GetChainID").Return(int64(1))
+	mockProvider.On("ResolveENS", ctx, "vitalik.eth").Return(resolvedAddress, nil).Once()
+	mockProvider.On("ResolveENS", ctx, "missing.eth").Return("", model.ErrENSNameNotFound)
+	mockProvider.On("IsValidAddress", ctx, resolvedAddress).Return(true, nil)
+	providerRegistry.RegisterProvider(mockProvider)
+	service := NewAddressValidatorService(providerRegistry, &logger)
+
+	t.Run("valid name", func(t *testing.T) {
+		address, isENS, err := service.ResolveAddress(ctx, "Vitalik.eth")
+		require.NoError(t, err)
+		assert.True(t, isENS)
+		assert.Equal(t, resolvedAddress, address)
+
+		// Served from cache, ResolveENS is only expected once
+		valid, err := service.ValidateAddress(ctx, "Ethereum", "vitalik.eth")
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		info, err := service.GetAddressInfo(ctx, "Ethereum", "vitalik.eth")
+		require.NoError(t, err)
+		assert.True(t, info.IsENS)
+		assert.Equal(t, "vitalik.eth", info.ENSName)
+		assert.Equal(t, resolvedAddress, info.Address)
+	})
+
+	t.Run("non-existent name", func(t *testing.T) {
+		_, isENS, err := service.ResolveAddress(ctx, "missing.eth")
+		assert.True(t, isENS)
+		assert.ErrorIs(t, err, model.ErrENSNameNotFound)
+
+		valid, err := service.ValidateAddress(ctx, "Ethereum", "missing.eth")
+		require.NoError(t, err)
+		assert.False(t, valid)
+	})
+
+	t.Run("raw hex address", func(t *testing.T) {
+		address, isENS, err := service.ResolveAddress(ctx, "0x742d35Cc6634C0532925a3b844Bc454e4438f44e")
+		require.NoError(t, err)
+		assert.False(t, isENS)
+		assert.Equal(t, "0x742d35Cc6634C0532925a3b844Bc454e4438f44e", address)
+	})
+
+	mockProvider.AssertExpectations(t)
+}
+
+func TestAddressValidatorService_ENSCacheExpires(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+
+	providerRegistry := wallet.NewProviderRegistry()
+	mockProvider := new(AVMockENSProvider)
+	mockProvider.On("GetName").Return("Ethereum")
+	mockProvider.On("GetChainID").Return(int64(1))
+	mockProvider.On("ResolveENS", ctx, "vitalik.eth").Return("0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045", nil).Twice()
+	providerRegistry.RegisterProvider(mockProvider)
+	service := NewAddressValidatorService(providerRegistry, &logger).(*addressValidatorService)
+	service.ensCacheTTL = time.Millisecond
+
+	_, _, err := service.ResolveAddress(ctx, "vitalik.eth")
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	_, _, err = service.ResolveAddress(ctx, "vitalik.eth")
+	require.NoError(t, err)
+
+	mockProvider.AssertExpectations(t)
+}
+
+func TestAddressValidatorService_ENSOnlyOnMainnet(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+
+	t.Run("other network", func(t *testing.T) {
+		providerRegistry := wallet.NewProviderRegistry()
+		ethereum := new(AVMockENSProvider)
+		ethereum.On("GetName").Return("Ethereum")
+		polygon := new(AVMockWeb3Provider)
+		polygon.On("GetName").Return("Polygon")
+		polygon.On("IsValidAddress", ctx, "vitalik.eth").Return(false, nil)
+		providerRegistry.RegisterProvider(ethereum)
+		providerRegistry.RegisterProvider(polygon)
+		service := NewAddressValidatorService(providerRegistry, &logger)
+
+		valid, err := service.ValidateAddress(ctx, "Polygon", "vitalik.eth")
+		require.NoError(t, err)
+		assert.False(t, valid)
+
+		info, err := service.GetAddressInfo(ctx, "Polygon", "vitalik.eth")
+		require.NoError(t, err)
+		assert.False(t, info.IsENS)
+		ethereum.AssertNotCalled(t, "ResolveENS", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ethereum testnet", func(t *testing.T) {
+		providerRegistry := wallet.NewProviderRegistry()
+		sepolia := new(AVMockENSProvider)
+		sepolia.On("GetName").Return("Ethereum")
+		sepolia.On("GetChainID").Return(int64(11155111))
+		providerRegistry.RegisterProvider(sepolia)
+		service := NewAddressValidatorService(providerRegistry, &logger)
+
+		_, isENS, err := service.ResolveAddress(ctx, "vitalik.eth")
+		assert.True(t, isENS)
+		assert.Error(t, err)
+		sepolia.AssertNotCalled(t, "ResolveENS", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// Market context gathered for each signal request
+const (
+	signalCandleInterval = market.Interval1h
+	signalCandleCount    = 24
+)
+
+// ErrNoSignalMarketData is returned when neither a ticker nor candles exist for the symbol
+var ErrNoSignalMarketData = errors.New("no market data available for symbol")
+
+// AISignalErrorKind classifies why a signal could not be produced from the model
+type AISignalErrorKind string
+
+const (
+	// AISignalModelError means the AI service call itself failed
+	AISignalModelError AISignalErrorKind = "model_error"
+	// AISignalMalformedResponse means the model answered with something that is not a valid signal
+	AISignalMalformedResponse AISignalErrorKind = "malformed_response"
+)
+
+// AISignalError reports a failure to obtain a signal from the AI model
+type AISignalError struct {
+	Kind   AISignalErrorKind
+	Symbol string
+	Err    error
+}
+
+// Error returns the error message
+func (e *AISignalError) Error() string {
+	return fmt.Sprintf("AI signal for %s failed (%s): %v", e.Symbol, e.Kind, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *AISignalError) Unwrap() error {
+	return e.Err
+}
+
+// AISignalUsecase asks the AI for a trade signal based on recent market data
+type AISignalUsecase struct {
+	aiService  port.AIService
+	marketRepo port.MarketRepository
+	exchange   string
+	logger     zerolog.Logger
+}
+
+// NewAISignalUsecase creates a new AISignalUsecase
+func NewAISignalUsecase(aiService port.AIService, marketRepo port.MarketRepository, exchange string, logger zerolog.Logger) *AISignalUsecase {
+	return &AISignalUsecase{
+		aiService:  aiService,
+		marketRepo: marketRepo,
+		exchange:   exchange,
+		logger:     logger.With().Str("component", "ai_signal_usecase").Logger(),
+	}
+}
+
+// GenerateSignal gathers the latest ticker and hourly candles for symbol, prompts the
+// model and parses its JSON answer into a signal. Model failures and unusable answers
+// are returned as *AISignalError.
+func (uc *AISignalUsecase) GenerateSignal(ctx context.Context, symbol string) (*model.AITradeSignal, error) {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+
+	ticker, err := uc.marketRepo.GetTicker(ctx, symbol, uc.exchange)
+	if err != nil {
+		uc.logger.Debug().Err(err).Str("symbol", symbol).Msg("No ticker for signal prompt")
+		ticker = nil
+	}
+
+	end := time.Now()
+	start := end.Add(-signalCandleCount * time.Hour)
+	candles, err := uc.marketRepo.GetCandles(ctx, symbol, uc.exchange, signalCandleInterval, start, end, signalCandleCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get candles: %w", err)
+	}
+	if ticker == nil && len(candles) == 0 {
+		return nil, ErrNoSignalMarketData
+	}
+
+	prompt := buildSignalPrompt(symbol, ticker, candles)
+	reply, err := uc.aiService.ChatWithHistory(ctx, []model.AIMessage{{
+		ID:        uuid.NewString(),
+		Role:      "user",
+		Content:   prompt,
+		Timestamp: time.Now(),
+	}}, map[string]interface{}{"symbol": symbol, "purpose": "trade_signal"})
+	if err != nil {
+		return nil, &AISignalError{Kind: AISignalModelError, Symbol: symbol, Err: err}
+	}
+	if reply == nil {
+		return nil, &AISignalError{Kind: AISignalModelError, Symbol: symbol, Err: errors.New("empty model response")}
+	}
+
+	signal, err := parseSignalResponse(symbol, reply.Content)
+	if err != nil {
+		uc.logger.Warn().Err(err).Str("symbol", symbol).Str("content", reply.Content).Msg("Malformed AI signal response")
+		return nil, &AISignalError{Kind: AISignalMalformedResponse, Symbol: symbol, Err: err}
+	}
+
+	uc.logger.Info().
+		Str("symbol", symbol).
+		Str("action", string(signal.Action)).
+		Float64("confidence", signal.Confidence).
+		Msg("Generated AI trade signal")
+
+	return signal, nil
+}
+
+// buildSignalPrompt describes the market data and the expected JSON answer
+func buildSignalPrompt(symbol string, ticker *market.Ticker, candles []*market.Candle) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "You are a crypto trading assistant. Analyse the market data for %s and give a trade signal.\n", symbol)
+
+	if ticker != nil {
+		fmt.Fprintf(&b, "\nLatest ticker: price=%g high24h=%g low24h=%g volume=%g change=%g%%\n",
+			ticker.Price, ticker.High24h, ticker.Low24h, ticker.Volume, ticker.PercentChange)
+	}
+	if len(candles) > 0 {
+		fmt.Fprintf(&b, "\nLast %d %s candles (open_time, open, high, low, close, volume):\n", len(candles), signalCandleInterval)
+		for _, c := range candles {
+			fmt.Fprintf(&b, "%s, %g, %g, %g, %g, %g\n", c.OpenTime.UTC().Format(time.RFC3339), c.Open, c.High, c.Low, c.Close, c.Volume)
+		}
+	}
+
+	b.WriteString("\nRespond with only a JSON object of the form ")
+	b.WriteString(`{"action": "BUY" | "SELL" | "HOLD", "confidence": <number between 0 and 1>, "rationale": "<one or two sentences>"}`)
+	return b.String()
+}
+
+// parseSignalResponse extracts the signal JSON object from the model's answer. Models
+// often wrap JSON in prose or code fences, so the outermost braces are used.
+func parseSignalResponse(symbol, content string) (*model.AITradeSignal, error) {
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start < 0 || end <= start {
+		return nil, errors.New("response contains no JSON object")
+	}
+
+	var raw struct {
+		Action     string   `json:"action"`
+		Confidence *float64 `json:"confidence"`
+		Rationale  string   `json:"rationale"`
+	}
+	if err := json.Unmarshal([]byte(content[start:end+1]), &raw); err != nil {
+		return nil, fmt.Errorf("invalid signal JSON: %w", err)
+	}
+
+	action := model.SignalType(strings.ToUpper(strings.TrimSpace(raw.Action)))
+	switch action {
+	case model.SignalBuy, model.SignalSell, model.SignalHold:
+	default:
+		return nil, fmt.Errorf("unknown action %q", raw.Action)
+	}
+	if raw.Confidence == nil || *raw.Confidence < 0 || *raw.Confidence > 1 {
+		return nil, errors.New("confidence must be a number between 0 and 1")
+	}
+
+	return &model.AITradeSignal{
+		Symbol:      symbol,
+		Action:      action,
+		Confidence:  *raw.Confidence,
+		Rationale:   strings.TrimSpace(raw.Rationale),
+		GeneratedAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SGAIService answers every chat with canned content and records the prompt
+type SGAIService struct {
+	port.AIService
+	content string
+	err     error
+	prompt  string
+}
+
+func (s *SGAIService) ChatWithHistory(ctx context.Context, messages []model.AIMessage, tradingContext map[string]interface{}) (*model.AIMessage, error) {
+	if len(messages) > 0 {
+		s.prompt = messages[len(messages)-1].Content
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &model.AIMessage{Role: "assistant", Content: s.content}, nil
+}
+
+// SGMarketRepository serves a fixed ticker and candle set
+type SGMarketRepository struct {
+	port.MarketRepository
+	ticker  *market.Ticker
+	candles []*market.Candle
+}
+
+func (r *SGMarketRepository) GetTicker(ctx context.Context, symbol, exchange string) (*market.Ticker, error) {
+	if r.ticker == nil {
+		return nil, errors.New("not found")
+	}
+	return r.ticker, nil
+}
+
+func (r *SGMarketRepository) GetCandles(ctx context.Context, symbol, exchange string, interval market.Interval, start, end time.Time, limit int) ([]*market.Candle, error) {
+	return r.candles, nil
+}
+
+func newSignalTestUsecase(ai *SGAIService) *AISignalUsecase {
+	repo := &SGMarketRepository{
+		ticker: &market.Ticker{Symbol: "BTCUSDT", Price: 65000, High24h: 66000, Low24h: 64000},
+		candles: []*market.Candle{
+			{Symbol: "BTCUSDT", OpenTime: time.Now().Add(-2 * time.Hour), Open: 64000, High: 65500, Low: 63900, Close: 65200, Volume: 10},
+		},
+	}
+	return NewAISignalUsecase(ai, repo, "mexc", zerolog.Nop())
+}
+
+func TestAISignalUsecase_ParsesCannedJSON(t *testing.T) {
+	ai := &SGAIService{content: "Here is my view:\n```json\n{\"action\": \"buy\", \"confidence\": 0.72, \"rationale\": \"Higher lows on rising volume.\"}\n```"}
+	uc := newSignalTestUsecase(ai)
+
+	signal, err := uc.GenerateSignal(context.Background(), "btcusdt")
+	require.NoError(t, err)
+	assert.Equal(t, "BTCUSDT", signal.Symbol)
+	assert.Equal(t, model.SignalBuy, signal.Action)
+	assert.InDelta(t, 0.72, signal.Confidence, 1e-9)
+	assert.Equal(t, "Higher lows on rising volume.", signal.Rationale)
+
+	assert.Contains(t, ai.prompt, "BTCUSDT")
+	assert.Contains(t, ai.prompt, "price=65000")
+	assert.Contains(t, ai.prompt, "65200")
+}
+
+func TestAISignalUsecase_MalformedResponse(t *testing.T) {
+	cases := map[string]string{
+		"no json":           "I think you should buy.",
+		"invalid json":      `{"action": "BUY", "confidence": }`,
+		"unknown action":    `{"action": "MOON", "confidence": 0.5, "rationale": "x"}`,
+		"missing conf":      `{"action": "SELL", "rationale": "x"}`,
+		"conf out of range": `{"action": "SELL", "confidence": 7, "rationale": "x"}`,
+	}
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			uc := newSignalTestUsecase(&SGAIService{content: content})
+
+			_, err := uc.GenerateSignal(context.Background(), "BTCUSDT")
+			var signalErr *AISignalError
+			require.ErrorAs(t, err, &signalErr)
+			assert.Equal(t, AISignalMalformedResponse, signalErr.Kind)
+		})
+	}
+}
+
+func TestAISignalUsecase_ModelError(t *testing.T) {
+	modelErr := errors.New("quota exceeded")
+	uc := newSignalTestUsecase(&SGAIService{err: modelErr})
+
+	_, err := uc.GenerateSignal(context.Background(), "BTCUSDT")
+	var signalErr *AISignalError
+	require.ErrorAs(t, err, &signalErr)
+	assert.Equal(t, AISignalModelError, signalErr.Kind)
+	assert.ErrorIs(t, err, modelErr)
+}
+
+func TestAISignalUsecase_NoMarketData(t *testing.T) {
+	uc := NewAISignalUsecase(&SGAIService{content: "{}"}, &SGMarketRepository{}, "mexc", zerolog.Nop())
+
+	_, err := uc.GenerateSignal(context.Background(), "NOPEUSDT")
+	assert.ErrorIs(t, err, ErrNoSignalMarketData)
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/google/uuid"
+)
+
+// maxToolIterations bounds how many rounds of tool calls the model may request before
+// it has to answer
+const maxToolIterations = 4
+
+// ErrToolIterationsExceeded is returned when the model keeps requesting tools past maxToolIterations
+var ErrToolIterationsExceeded = errors.New("AI exceeded the maximum number of tool call rounds")
+
+// AITool is a function the AI model can call during a chat to fetch live data
+type AITool struct {
+	Name        string
+	Description string
+	// Parameters maps each parameter name to its description
+	Parameters map[string]string
+	Execute    func(ctx context.Context, params map[string]interface{}) (interface{}, error)
+}
+
+// WithTools makes tools available to the model in Chat and ChatStream
+func (uc *AIUsecase) WithTools(tools ...AITool) *AIUsecase {
+	if uc.tools == nil {
+		uc.tools = make(map[string]AITool, len(tools))
+	}
+	for _, tool := range tools {
+		uc.tools[tool.Name] = tool
+	}
+	return uc
+}
+
+// withToolSpecs adds the registered tool descriptions to the trading context passed to the model
+func (uc *AIUsecase) withToolSpecs(tradingContext map[string]interface{}) map[string]interface{} {
+	if len(uc.tools) == 0 {
+		return tradingContext
+	}
+
+	specs := make([]map[string]interface{}, 0, len(uc.tools))
+	for _, tool := range uc.tools {
+		specs = append(specs, map[string]interface{}{
+			"name":        tool.Name,
+			"description": tool.Description,
+			"parameters":  tool.Parameters,
+		})
+	}
+
+	extended := make(map[string]interface{}, len(tradingContext)+1)
+	for k, v := range tradingContext {
+		extended[k] = v
+	}
+	extended["tools"] = specs
+	return extended
+}
+
+// completeWithTools generates a reply and, while the model asks for tools, executes them
+// and generates again with the tool results appended to the history. Results of every
+// executed tool are attached to the final reply's function_calls metadata.
+func (uc *AIUsecase) completeWithTools(ctx context.Context, history []model.AIMessage, generate func(history []model.AIMessage) (*model.AIMessage, error)) (*model.AIMessage, error) {
+	results := make(map[string]interface{})
+
+	for round := 0; ; round++ {
+		response, err := generate(history)
+		if err != nil {
+			return nil, err
+		}
+		if len(response.ToolCalls) == 0 || len(uc.tools) == 0 {
+			if len(results) > 0 {
+				if response.Metadata == nil {
+					response.Metadata = make(map[string]interface{})
+				}
+				response.Metadata["function_calls"] = results
+			}
+			return response, nil
+		}
+		if round >= maxToolIterations {
+			return nil, ErrToolIterationsExceeded
+		}
+
+		history = append(history, *response)
+		for _, call := range response.ToolCalls {
+			toolResponse := uc.runTool(ctx, call)
+			results[call.Name] = toolResponse.Result
+
+			content, err := json.Marshal(toolResponse)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode result of tool %s: %w", call.Name, err)
+			}
+			history = append(history, model.AIMessage{
+				ID:             uuid.New().String(),
+				ConversationID: response.ConversationID,
+				Role:           "tool",
+				Content:        string(content),
+				Timestamp:      time.Now(),
+				Metadata:       map[string]interface{}{"tool": call.Name},
+			})
+		}
+	}
+}
+
+// runTool executes a tool call. Failures are reported to the model as an error result
+// so it can recover instead of aborting the chat.
+func (uc *AIUsecase) runTool(ctx context.Context, call model.AIFunctionCall) model.AIFunctionResponse {
+	tool, ok := uc.tools[call.Name]
+	if !ok {
+		return model.AIFunctionResponse{Name: call.Name, Result: map[string]string{"error": "unknown tool"}}
+	}
+
+	result, err := tool.Execute(ctx, call.Parameters)
+	if err != nil {
+		uc.logger.Warn().Err(err).Str("tool", call.Name).Msg("AI tool call failed")
+		return model.AIFunctionResponse{Name: call.Name, Result: map[string]string{"error": err.Error()}}
+	}
+
+	uc.logger.Debug().Str("tool", call.Name).Msg("Executed AI tool call")
+	return model.AIFunctionResponse{Name: call.Name, Result: result}
+}
+
+// NewMarketTools returns the get_price tool backed by the MEXC client. There is no balance
+// tool: the client signs with the bot's own key, and the chat must not expose that account.
+func NewMarketTools(client port.MEXCClient) []AITool {
+	return []AITool{
+		{
+			Name:        "get_price",
+			Description: "Get the latest price and 24h statistics of a trading pair",
+			Parameters:  map[string]string{"symbol": "Trading pair, e.g. BTCUSDT"},
+			Execute: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+				symbol, _ := params["symbol"].(string)
+				if symbol == "" {
+					return nil, errors.New("symbol parameter is required")
+				}
+				ticker, err := client.GetMarketData(ctx, strings.ToUpper(symbol))
+				if err != nil {
+					return nil, err
+				}
+				return map[string]interface{}{
+					"symbol":               ticker.Symbol,
+					"price":                ticker.LastPrice,
+					"price_change_percent": ticker.PriceChangePercent,
+					"high_24h":             ticker.HighPrice,
+					"low_24h":              ticker.LowPrice,
+					"volume_24h":           ticker.Volume,
+				}, nil
+			},
+		},
+	}
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TLConversationRepository keeps conversations and messages in memory
+type TLConversationRepository struct {
+	port.ConversationMemoryRepository
+	conversations map[string]*model.AIConversation
+	messages      map[string][]*model.AIMessage
+}
+
+func newTLConversationRepository() *TLConversationRepository {
+	return &TLConversationRepository{
+		conversations: make(map[string]*model.AIConversation),
+		messages:      make(map[string][]*model.AIMessage),
+	}
+}
+
+func (r *TLConversationRepository) SaveConversation(ctx context.Context, conversation *model.AIConversation) error {
+	r.conversations[conversation.ID] = conversation
+	return nil
+}
+
+func (r *TLConversationRepository) GetConversation(ctx context.Context, conversationID string) (*model.AIConversation, error) {
+	conversation, ok := r.conversations[conversationID]
+	if !ok {
+		return nil, model.ErrConversationNotFound
+	}
+	return conversation, nil
+}
+
+func (r *TLConversationRepository) SaveMessage(ctx context.Context, message *model.AIMessage) error {
+	r.messages[message.ConversationID] = append(r.messages[message.ConversationID], message)
+	return nil
+}
+
+func (r *TLConversationRepository) GetMessages(ctx context.Context, conversationID string, limit, offset int) ([]*model.AIMessage, error) {
+	stored := r.messages[conversationID]
+	newestFirst := make([]*model.AIMessage, 0, len(stored))
+	for i := len(stored) - 1; i >= 0 && len(newestFirst) < limit; i-- {
+		newestFirst = append(newestFirst, stored[i])
+	}
+	return newestFirst, nil
+}
+
+// TLMEXCClient serves a fixed price and counts ticker requests
+type TLMEXCClient struct {
+	port.MEXCClient
+	price   float64
+	tickers []string
+}
+
+func (c *TLMEXCClient) GetMarketData(ctx context.Context, symbol string) (*model.Ticker, error) {
+	c.tickers = append(c.tickers, symbol)
+	return &model.Ticker{Symbol: symbol, LastPrice: c.price}, nil
+}
+
+// TLToolModel asks for a tool on its first call and answers from the tool result afterwards
+type TLToolModel struct {
+	port.AIService
+	call     model.AIFunctionCall
+	calls    int
+	contexts []map[string]interface{}
+	always   bool
+}
+
+func (m *TLToolModel) ChatWithHistory(ctx context.Context, messages []model.AIMessage, tradingContext map[string]interface{}) (*model.AIMessage, error) {
+	m.calls++
+	m.contexts = append(m.contexts, tradingContext)
+	last := messages[len(messages)-1]
+	if m.always || last.Role != "tool" {
+		return &model.AIMessage{Role: "assistant", ToolCalls: []model.AIFunctionCall{m.call}}, nil
+	}
+
+	var result model.AIFunctionResponse
+	if err := json.Unmarshal([]byte(last.Content), &result); err != nil {
+		return nil, err
+	}
+	data := result.Result.(map[string]interface{})
+	if errMsg, ok := data["error"]; ok {
+		return &model.AIMessage{Role: "assistant", Content: fmt.Sprintf("Tool failed: %v", errMsg)}, nil
+	}
+	return &model.AIMessage{Role: "assistant", Content: fmt.Sprintf("%s is trading at %v", data["symbol"], data["price"])}, nil
+}
+
+func newToolTestUsecase(ai port.AIService, client port.MEXCClient) (*AIUsecase, *TLConversationRepository) {
+	repo := newTLConversationRepository()
+	uc := NewAIUsecase(ai, repo, nil, zerolog.Nop()).WithTools(NewMarketTools(client)...)
+	return uc, repo
+}
+
+func TestAIUsecase_ToolCallExecutedAndAnswered(t *testing.T) {
+	client := &TLMEXCClient{price: 64250.5}
+	ai := &TLToolModel{call: model.AIFunctionCall{Name: "get_price", Parameters: map[string]interface{}{"symbol": "btcusdt"}}}
+	uc, repo := newToolTestUsecase(ai, client)
+
+	reply, err := uc.Chat(context.Background(), "user-1", "What is BTC at?", "", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"BTCUSDT"}, client.tickers)
+	assert.Equal(t, 2, ai.calls)
+	assert.Equal(t, "BTCUSDT is trading at 64250.5", reply.Content)
+
+	results := reply.Metadata["function_calls"].(map[string]interface{})
+	assert.Equal(t, 64250.5, results["get_price"].(map[string]interface{})["price"])
+
+	tools := ai.contexts[0]["tools"].([]map[string]interface{})
+	assert.Len(t, tools, 1)
+
+	// Only the user message and the final answer are persisted
+	stored := repo.messages[reply.ConversationID]
+	require.Len(t, stored, 2)
+	assert.Equal(t, "assistant", stored[1].Role)
+	assert.Equal(t, reply.Content, stored[1].Content)
+}
+
+func TestAIUsecase_NoBalanceTool(t *testing.T) {
+	// The MEXC client reads the bot's own account, which chat users must not see
+	for _, tool := range NewMarketTools(&TLMEXCClient{}) {
+		assert.NotEqual(t, "get_balance", tool.Name)
+	}
+}
+
+func TestAIUsecase_UnknownToolReportedToModel(t *testing.T) {
+	ai := &TLToolModel{call: model.AIFunctionCall{Name: "place_order"}}
+	uc, _ := newToolTestUsecase(ai, &TLMEXCClient{})
+
+	reply, err := uc.Chat(context.Background(), "user-1", "Buy BTC", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Tool failed: unknown tool", reply.Content)
+}
+
+func TestAIUsecase_ToolIterationsBounded(t *testing.T) {
+	ai := &TLToolModel{call: model.AIFunctionCall{Name: "get_price", Parameters: map[string]interface{}{"symbol": "BTCUSDT"}}, always: true}
+	uc, _ := newToolTestUsecase(ai, &TLMEXCClient{price: 1})
+
+	_, err := uc.Chat(context.Background(), "user-1", "loop", "", nil)
+	assert.True(t, errors.Is(err, ErrToolIterationsExceeded))
+	assert.Equal(t, maxToolIterations+1, ai.calls)
+}
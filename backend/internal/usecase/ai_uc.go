@@ -11,11 +11,15 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// chatHistoryLimit is the number of most recent messages sent to the model as context
+const chatHistoryLimit = 10
+
 // AIUsecase handles AI-related operations
 type AIUsecase struct {
 	aiService              port.AIService
 	conversationMemoryRepo port.ConversationMemoryRepository
 	embeddingRepo          port.EmbeddingRepository
+	tools                  map[string]AITool
 	logger                 zerolog.Logger
 }
 
@@ -36,22 +40,42 @@ func NewAIUsecase(
 
 // Chat sends a message to the AI and returns a response
 func (uc *AIUsecase) Chat(ctx context.Context, userID, message, conversationID string, tradingContext map[string]interface{}) (*model.AIMessage, error) {
-	// Create a new conversation if conversationID is empty
-	if conversationID == "" {
-		conversation := &model.AIConversation{
-			ID:        uuid.New().String(),
-			UserID:    userID,
-			Title:     generateTitle(message),
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
+	tradingContext = uc.withToolSpecs(tradingContext)
+	return uc.chat(ctx, userID, message, conversationID, func(history []model.AIMessage) (*model.AIMessage, error) {
+		return uc.aiService.ChatWithHistory(ctx, history, tradingContext)
+	})
+}
+
+// ChatStream sends a message to the AI and passes the response to onDelta as it is
+// generated. AI services that cannot stream deliver the whole response as one delta.
+// The conversation is persisted exactly as for Chat.
+func (uc *AIUsecase) ChatStream(ctx context.Context, userID, message, conversationID string, tradingContext map[string]interface{}, onDelta func(delta string) error) (*model.AIMessage, error) {
+	tradingContext = uc.withToolSpecs(tradingContext)
+	return uc.chat(ctx, userID, message, conversationID, func(history []model.AIMessage) (*model.AIMessage, error) {
+		if streamer, ok := uc.aiService.(port.AIStreamingService); ok {
+			return streamer.ChatWithHistoryStream(ctx, history, tradingContext, onDelta)
 		}
 
-		if err := uc.conversationMemoryRepo.SaveConversation(ctx, conversation); err != nil {
-			uc.logger.Error().Err(err).Msg("Failed to save new conversation")
+		response, err := uc.aiService.ChatWithHistory(ctx, history, tradingContext)
+		if err != nil {
 			return nil, err
 		}
+		// Tool-call rounds are not part of the answer the user sees
+		if len(response.ToolCalls) == 0 {
+			if err := onDelta(response.Content); err != nil {
+				return nil, err
+			}
+		}
+		return response, nil
+	})
+}
 
-		conversationID = conversation.ID
+// chat stores the user message, generates a reply from the conversation history and
+// stores the reply
+func (uc *AIUsecase) chat(ctx context.Context, userID, message, conversationID string, generate func(history []model.AIMessage) (*model.AIMessage, error)) (*model.AIMessage, error) {
+	conversationID, err := uc.ensureConversation(ctx, userID, conversationID, message)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create user message
@@ -69,27 +93,34 @@ func (uc *AIUsecase) Chat(ctx context.Context, userID, message, conversationID s
 		return nil, err
 	}
 
-	// Get conversation history
-	messages, err := uc.conversationMemoryRepo.GetMessages(ctx, conversationID, 10, 0)
+	// Get the most recent turns, which the repository returns newest first
+	messages, err := uc.conversationMemoryRepo.GetMessages(ctx, conversationID, chatHistoryLimit, 0)
 	if err != nil {
 		uc.logger.Error().Err(err).Msg("Failed to get conversation history")
 		return nil, err
 	}
 
-	// Convert messages to AIMessage slice
+	// Convert messages to a chronological AIMessage slice
 	aiMessages := make([]model.AIMessage, len(messages))
 	for i, msg := range messages {
-		aiMessages[i] = *msg
+		aiMessages[len(messages)-1-i] = *msg
 	}
 
-	// Send message to AI with history and trading context
-	response, err := uc.aiService.ChatWithHistory(ctx, aiMessages, tradingContext)
+	// Send message to AI with history and trading context, running any tools it asks for
+	response, err := uc.completeWithTools(ctx, aiMessages, generate)
 	if err != nil {
 		uc.logger.Error().Err(err).Msg("Failed to get AI response")
 		return nil, err
 	}
 
-	// Save AI response
+	// Save AI response in this conversation
+	if response.ID == "" {
+		response.ID = uuid.New().String()
+	}
+	response.ConversationID = conversationID
+	if response.Role == "" {
+		response.Role = "assistant"
+	}
 	if err := uc.conversationMemoryRepo.SaveMessage(ctx, response); err != nil {
 		uc.logger.Error().Err(err).Msg("Failed to save AI response")
 		// Don't return error here, we still want to return the response to the user
@@ -107,6 +138,39 @@ func (uc *AIUsecase) Chat(ctx context.Context, userID, message, conversationID s
 	return response, nil
 }
 
+// ensureConversation returns the id of the conversation to continue. A new conversation
+// is created when conversationID is empty or unknown; a conversation owned by another
+// user is rejected.
+func (uc *AIUsecase) ensureConversation(ctx context.Context, userID, conversationID, message string) (string, error) {
+	if conversationID != "" {
+		conversation, err := uc.conversationMemoryRepo.GetConversation(ctx, conversationID)
+		switch {
+		case err == nil && conversation.UserID != userID:
+			return "", errors.New("unauthorized access to conversation")
+		case err == nil:
+			return conversationID, nil
+		case !errors.Is(err, model.ErrConversationNotFound):
+			uc.logger.Error().Err(err).Str("conversation_id", conversationID).Msg("Failed to load conversation")
+			return "", err
+		}
+	} else {
+		conversationID = uuid.New().String()
+	}
+
+	conversation := &model.AIConversation{
+		ID:        conversationID,
+		UserID:    userID,
+		Title:     generateTitle(message),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := uc.conversationMemoryRepo.SaveConversation(ctx, conversation); err != nil {
+		uc.logger.Error().Err(err).Msg("Failed to save new conversation")
+		return "", err
+	}
+	return conversationID, nil
+}
+
 // GetConversation retrieves a conversation by ID
 func (uc *AIUsecase) GetConversation(ctx context.Context, userID, conversationID string) (*model.AIConversation, error) {
 	conversation, err := uc.conversationMemoryRepo.GetConversation(ctx, conversationID)
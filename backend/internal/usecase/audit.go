@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+)
+
+// Resource types recorded in the audit trail
+const (
+	auditResourceOrder      = "order"
+	auditResourceCredential = "credential"
+)
+
+// auditRecorder records the outcome of a mutation in the audit trail. A failure to
+// record is logged rather than returned: the mutation has already happened and its
+// result must still reach the caller.
+type auditRecorder struct {
+	audit  port.AuditLogger
+	logger *zerolog.Logger
+}
+
+// record appends entry with the outcome of err
+func (r auditRecorder) record(ctx context.Context, entry *model.AuditEntry, err error) {
+	entry.Outcome = model.AuditOutcomeSuccess
+	if err != nil {
+		entry.Outcome = model.AuditOutcomeFailure
+		if entry.Details == nil {
+			entry.Details = map[string]string{}
+		}
+		entry.Details["error"] = err.Error()
+	}
+	if recordErr := r.audit.Record(ctx, entry); recordErr != nil {
+		r.logger.Error().Err(recordErr).
+			Str("action", string(entry.Action)).
+			Str("resourceId", entry.ResourceID).
+			Msg("Failed to record audit entry")
+	}
+}
+
+// credentialAuditDetails describes a credential without its key or secret
+func credentialAuditDetails(credential *model.APICredential) map[string]string {
+	if credential == nil {
+		return nil
+	}
+	return map[string]string{"exchange": credential.Exchange, "label": credential.Label}
+}
+
+// auditedTradeUseCase records order placement and cancellation in the audit trail
+type auditedTradeUseCase struct {
+	TradeUseCase
+	auditRecorder
+}
+
+// NewAuditedTradeUseCase wraps inner so that order placement and cancellation are audited
+func NewAuditedTradeUseCase(inner TradeUseCase, audit port.AuditLogger, logger *zerolog.Logger) TradeUseCase {
+	return &auditedTradeUseCase{TradeUseCase: inner, auditRecorder: auditRecorder{audit: audit, logger: logger}}
+}
+
+// PlaceOrder places the order and audits the attempt
+func (uc *auditedTradeUseCase) PlaceOrder(ctx context.Context, req model.OrderRequest) (*model.Order, error) {
+	order, err := uc.TradeUseCase.PlaceOrder(ctx, req)
+
+	entry := &model.AuditEntry{
+		Actor:        req.UserID,
+		Action:       model.AuditActionOrderPlace,
+		ResourceType: auditResourceOrder,
+		Details: map[string]string{
+			"symbol":   req.Symbol,
+			"side":     string(req.Side),
+			"type":     string(req.Type),
+			"quantity": strconv.FormatFloat(req.Quantity, 'f', -1, 64),
+			"price":    strconv.FormatFloat(req.Price, 'f', -1, 64),
+		},
+	}
+	if order != nil {
+		entry.ResourceID = order.OrderID
+	}
+	uc.record(ctx, entry, err)
+	return order, err
+}
+
+// CancelOrder cancels the order and audits the attempt
+func (uc *auditedTradeUseCase) CancelOrder(ctx context.Context, symbol, orderID string) error {
+	err := uc.TradeUseCase.CancelOrder(ctx, symbol, orderID)
+	uc.record(ctx, &model.AuditEntry{
+		Action:       model.AuditActionOrderCancel,
+		ResourceType: auditResourceOrder,
+		ResourceID:   orderID,
+		Details:      map[string]string{"symbol": symbol},
+	}, err)
+	return err
+}
+
+// auditedAPICredentialUseCase records credential changes in the audit trail
+type auditedAPICredentialUseCase struct {
+	APICredentialUseCase
+	auditRecorder
+}
+
+// NewAuditedAPICredentialUseCase wraps inner so that credential changes are audited
+func NewAuditedAPICredentialUseCase(inner APICredentialUseCase, audit port.AuditLogger, logger *zerolog.Logger) APICredentialUseCase {
+	return &auditedAPICredentialUseCase{APICredentialUseCase: inner, auditRecorder: auditRecorder{audit: audit, logger: logger}}
+}
+
+// CreateCredential creates the credential and audits the attempt
+func (uc *auditedAPICredentialUseCase) CreateCredential(ctx context.Context, credential *model.APICredential) error {
+	err := uc.APICredentialUseCase.CreateCredential(ctx, credential)
+	uc.record(ctx, &model.AuditEntry{
+		Actor:        credential.UserID,
+		Action:       model.AuditActionCredentialCreate,
+		ResourceType: auditResourceCredential,
+		ResourceID:   credential.ID,
+		Details:      credentialAuditDetails(credential),
+	}, err)
+	return err
+}
+
+// UpdateCredential updates the credential and audits the attempt
+func (uc *auditedAPICredentialUseCase) UpdateCredential(ctx context.Context, credential *model.APICredential) error {
+	err := uc.APICredentialUseCase.UpdateCredential(ctx, credential)
+	uc.record(ctx, &model.AuditEntry{
+		Actor:        credential.UserID,
+		Action:       model.AuditActionCredentialUpdate,
+		ResourceType: auditResourceCredential,
+		ResourceID:   credential.ID,
+		Details:      credentialAuditDetails(credential),
+	}, err)
+	return err
+}
+
+// DeleteCredential deletes the credential and audits the attempt. The credential is
+// read first so the entry names its owner.
+func (uc *auditedAPICredentialUseCase) DeleteCredential(ctx context.Context, id string) error {
+	credential, _ := uc.APICredentialUseCase.GetCredential(ctx, id)
+	err := uc.APICredentialUseCase.DeleteCredential(ctx, id)
+	uc.record(ctx, credentialAuditEntry(model.AuditActionCredentialDelete, id, credential), err)
+	return err
+}
+
+// auditedCredentialManager records credential changes made through the credential
+// manager, including rotation, in the audit trail
+type auditedCredentialManager struct {
+	APICredentialManagerService
+	auditRecorder
+}
+
+// NewAuditedAPICredentialManagerService wraps inner so that credential changes are audited
+func NewAuditedAPICredentialManagerService(inner APICredentialManagerService, audit port.AuditLogger, logger *zerolog.Logger) APICredentialManagerService {
+	return &auditedCredentialManager{APICredentialManagerService: inner, auditRecorder: auditRecorder{audit: audit, logger: logger}}
+}
+
+// CreateCredential creates the credential and audits the attempt
+func (s *auditedCredentialManager) CreateCredential(ctx context.Context, userID, exchange, apiKey, apiSecret, label string) (*model.APICredential, error) {
+	credential, err := s.APICredentialManagerService.CreateCredential(ctx, userID, exchange, apiKey, apiSecret, label)
+	entry := &model.AuditEntry{
+		Actor:        userID,
+		Action:       model.AuditActionCredentialCreate,
+		ResourceType: auditResourceCredential,
+		Details:      map[string]string{"exchange": exchange, "label": label},
+	}
+	if credential != nil {
+		entry.ResourceID = credential.ID
+	}
+	s.record(ctx, entry, err)
+	return credential, err
+}
+
+// UpdateCredential updates the credential and audits the attempt
+func (s *auditedCredentialManager) UpdateCredential(ctx context.Context, id, apiKey, apiSecret, label string) (*model.APICredential, error) {
+	credential, err := s.APICredentialManagerService.UpdateCredential(ctx, id, apiKey, apiSecret, label)
+	s.record(ctx, s.credentialEntry(ctx, model.AuditActionCredentialUpdate, id, credential), err)
+	return credential, err
+}
+
+// DeleteCredential deletes the credential and audits the attempt
+func (s *auditedCredentialManager) DeleteCredential(ctx context.Context, id string) error {
+	// Read before deleting so the entry names the owner
+	credential, _ := s.APICredentialManagerService.GetCredential(ctx, id)
+	err := s.APICredentialManagerService.DeleteCredential(ctx, id)
+	s.record(ctx, credentialAuditEntry(model.AuditActionCredentialDelete, id, credential), err)
+	return err
+}
+
+// RotateCredential rotates the credential's key and secret and audits the attempt
+func (s *auditedCredentialManager) RotateCredential(ctx context.Context, id string, newAPIKey, newAPISecret string) (*model.APICredential, error) {
+	credential, err := s.APICredentialManagerService.RotateCredential(ctx, id, newAPIKey, newAPISecret)
+	s.record(ctx, s.credentialEntry(ctx, model.AuditActionCredentialRotate, id, credential), err)
+	return credential, err
+}
+
+// credentialEntry builds the entry for a change to credential id, looking the credential
+// up when the change did not return it
+func (s *auditedCredentialManager) credentialEntry(ctx context.Context, action model.AuditAction, id string, credential *model.APICredential) *model.AuditEntry {
+	if credential == nil {
+		credential, _ = s.APICredentialManagerService.GetCredential(ctx, id)
+	}
+	return credentialAuditEntry(action, id, credential)
+}
+
+// credentialAuditEntry builds the entry for a change to credential id, attributed to its
+// owner when the credential is known
+func credentialAuditEntry(action model.AuditAction, id string, credential *model.APICredential) *model.AuditEntry {
+	entry := &model.AuditEntry{
+		Action:       action,
+		ResourceType: auditResourceCredential,
+		ResourceID:   id,
+		Details:      credentialAuditDetails(credential),
+	}
+	if credential != nil {
+		entry.Actor = credential.UserID
+	}
+	return entry
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingAudit keeps the entries recorded
+type recordingAudit struct {
+	entries []*model.AuditEntry
+}
+
+func (a *recordingAudit) Record(ctx context.Context, entry *model.AuditEntry) error {
+	a.entries = append(a.entries, entry)
+	return nil
+}
+
+func (a *recordingAudit) Verify(ctx context.Context) (*model.AuditVerification, error) {
+	return &model.AuditVerification{Valid: true}, nil
+}
+
+// stubTradeUseCase places orders with a fixed result
+type stubTradeUseCase struct {
+	TradeUseCase
+	order *model.Order
+	err   error
+}
+
+func (s *stubTradeUseCase) PlaceOrder(ctx context.Context, req model.OrderRequest) (*model.Order, error) {
+	return s.order, s.err
+}
+
+func (s *stubTradeUseCase) CancelOrder(ctx context.Context, symbol, orderID string) error {
+	return s.err
+}
+
+// stubCredentialManager rotates credentials owned by user-1
+type stubCredentialManager struct {
+	APICredentialManagerService
+}
+
+func (s *stubCredentialManager) RotateCredential(ctx context.Context, id string, newAPIKey, newAPISecret string) (*model.APICredential, error) {
+	return &model.APICredential{ID: id, UserID: "user-1", Exchange: "MEXC", APIKey: newAPIKey, APISecret: newAPISecret}, nil
+}
+
+func TestAuditedTradeUseCase_RecordsOrderPlacement(t *testing.T) {
+	audit := &recordingAudit{}
+	logger := zerolog.Nop()
+	uc := NewAuditedTradeUseCase(&stubTradeUseCase{order: &model.Order{OrderID: "o-1"}}, audit, &logger)
+
+	_, err := uc.PlaceOrder(context.Background(), model.OrderRequest{
+		UserID: "user-1", Symbol: "BTCUSDT", Side: model.OrderSideBuy, Type: model.OrderTypeLimit, Quantity: 0.5, Price: 65000,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, audit.entries, 1)
+	entry := audit.entries[0]
+	assert.Equal(t, "user-1", entry.Actor)
+	assert.Equal(t, model.AuditActionOrderPlace, entry.Action)
+	assert.Equal(t, "o-1", entry.ResourceID)
+	assert.Equal(t, model.AuditOutcomeSuccess, entry.Outcome)
+	assert.Equal(t, "0.5", entry.Details["quantity"])
+	assert.Equal(t, "65000", entry.Details["price"])
+}
+
+func TestAuditedTradeUseCase_RecordsFailedCancellation(t *testing.T) {
+	audit := &recordingAudit{}
+	logger := zerolog.Nop()
+	uc := NewAuditedTradeUseCase(&stubTradeUseCase{err: errors.New("unknown order")}, audit, &logger)
+
+	err := uc.CancelOrder(context.Background(), "BTCUSDT", "o-2")
+	require.EqualError(t, err, "unknown order")
+
+	require.Len(t, audit.entries, 1)
+	entry := audit.entries[0]
+	assert.Equal(t, model.AuditActionOrderCancel, entry.Action)
+	assert.Equal(t, "o-2", entry.ResourceID)
+	assert.Equal(t, model.AuditOutcomeFailure, entry.Outcome)
+	assert.Equal(t, "unknown order", entry.Details["error"])
+}
+
+func TestAuditedCredentialManager_RecordsRotationWithoutSecrets(t *testing.T) {
+	audit := &recordingAudit{}
+	logger := zerolog.Nop()
+	svc := NewAuditedAPICredentialManagerService(&stubCredentialManager{}, audit, &logger)
+
+	_, err := svc.RotateCredential(context.Background(), "cred-1", "new-key", "new-secret")
+	require.NoError(t, err)
+
+	require.Len(t, audit.entries, 1)
+	entry := audit.entries[0]
+	assert.Equal(t, "user-1", entry.Actor)
+	assert.Equal(t, model.AuditActionCredentialRotate, entry.Action)
+	assert.Equal(t, "cred-1", entry.ResourceID)
+	for _, value := range entry.Details {
+		assert.NotContains(t, []string{"new-key", "new-secret"}, value)
+	}
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+)
+
+// AutobuyUserID is the user the new-listing autobuyer places its orders for
+const AutobuyUserID = "autobuy"
+
+// BuyNewListings subscribes to NewCoinTradable events on events and autobuys each coin
+// that starts trading
+func (s *AutobuyService) BuyNewListings(events port.DomainEventBus) port.Subscription {
+	return events.SubscribeTopics(s.handleNewCoinTradable, event.NewCoinTradableEvent)
+}
+
+// handleNewCoinTradable autobuys the coin of a NewCoinTradable event. Skipped coins are
+// the normal case, so they are only logged at debug level.
+func (s *AutobuyService) handleNewCoinTradable(evt event.DomainEvent) {
+	tradable, ok := evt.(*event.NewCoinTradable)
+	if !ok {
+		return
+	}
+	if err := s.HandleNewCoinEvent(*tradable); err != nil {
+		s.log().Debug().Err(err).Str("symbol", tradable.Symbol).Msg("New listing not autobought")
+	}
+}
+
+// StaticAutoBuyConfig serves a fixed autobuy configuration
+type StaticAutoBuyConfig struct {
+	Config AutoBuyConfig
+}
+
+// LoadAutoBuyConfig returns a copy of the configuration
+func (c StaticAutoBuyConfig) LoadAutoBuyConfig() (*AutoBuyConfig, error) {
+	config := c.Config
+	return &config, nil
+}
+
+// autobuyCoinRepository tracks autobuy processing on the stored new coins
+type autobuyCoinRepository struct {
+	repo   port.NewCoinRepository
+	logger *zerolog.Logger
+}
+
+// NewAutobuyCoinRepository records which new coins were autobought in repo
+func NewAutobuyCoinRepository(repo port.NewCoinRepository, logger *zerolog.Logger) NewCoinRepository {
+	return &autobuyCoinRepository{repo: repo, logger: logger}
+}
+
+// IsProcessedForAutobuy reports whether symbol was already autobought. A coin that cannot
+// be looked up counts as processed so a storage failure cannot cause a second buy.
+func (r *autobuyCoinRepository) IsProcessedForAutobuy(symbol string) bool {
+	coin, err := r.repo.GetBySymbol(context.Background(), symbol)
+	if err != nil {
+		r.logger.Error().Err(err).Str("symbol", symbol).Msg("Failed to look up new coin for autobuy")
+		return true
+	}
+	return coin != nil && coin.IsProcessedForAutobuy
+}
+
+// MarkAsProcessed records that symbol was autobought
+func (r *autobuyCoinRepository) MarkAsProcessed(symbol string) error {
+	ctx := context.Background()
+	coin, err := r.repo.GetBySymbol(ctx, symbol)
+	if err != nil {
+		return fmt.Errorf("failed to get coin: %w", err)
+	}
+	if coin == nil {
+		return fmt.Errorf("coin not found: %s", symbol)
+	}
+
+	coin.IsProcessedForAutobuy = true
+	coin.UpdatedAt = time.Now()
+	return r.repo.Update(ctx, coin)
+}
+
+// AutobuyTickerSource provides the latest ticker of a symbol
+type AutobuyTickerSource interface {
+	GetTicker(ctx context.Context, symbol string) (*market.Ticker, error)
+}
+
+// autobuyMarketData reads prices and volumes from the latest tickers
+type autobuyMarketData struct {
+	tickers AutobuyTickerSource
+}
+
+// NewAutobuyMarketData prices new listings from the latest tickers
+func NewAutobuyMarketData(tickers AutobuyTickerSource) MarketDataService {
+	return &autobuyMarketData{tickers: tickers}
+}
+
+// GetMarketData returns the latest price and 24h volume of symbol
+func (m *autobuyMarketData) GetMarketData(symbol string) (float64, float64, error) {
+	ticker, err := m.tickers.GetTicker(context.Background(), symbol)
+	if err != nil {
+		return 0, 0, err
+	}
+	if ticker == nil {
+		return 0, 0, fmt.Errorf("no ticker for %s", symbol)
+	}
+	return ticker.Price, ticker.Volume, nil
+}
+
+// AutobuyRiskChecker checks an order against the pre-trade risk limits
+type AutobuyRiskChecker interface {
+	Check(ctx context.Context, request *model.OrderRequest) error
+}
+
+// autobuyRisk checks autobuy orders against the pre-trade risk limits
+type autobuyRisk struct {
+	checker AutobuyRiskChecker
+}
+
+// NewAutobuyRisk checks autobuy orders with checker
+func NewAutobuyRisk(checker AutobuyRiskChecker) RiskUsecase {
+	return &autobuyRisk{checker: checker}
+}
+
+// CheckRisk checks the market buy the order parameters describe
+func (r *autobuyRisk) CheckRisk(order OrderParameters) error {
+	request := autobuyOrderRequest(order)
+	return r.checker.Check(context.Background(), &request)
+}
+
+// AutobuyOrderPlacer places orders
+type AutobuyOrderPlacer interface {
+	PlaceOrder(ctx context.Context, req model.OrderRequest) (*model.Order, error)
+}
+
+// autobuyTrader places the autobuyer's market buys
+type autobuyTrader struct {
+	orders AutobuyOrderPlacer
+}
+
+// NewAutobuyTrader places autobuy orders through orders
+func NewAutobuyTrader(orders AutobuyOrderPlacer) TradeUsecase {
+	return &autobuyTrader{orders: orders}
+}
+
+// ExecuteMarketBuy places a market buy of the order quantity
+func (t *autobuyTrader) ExecuteMarketBuy(order OrderParameters) error {
+	_, err := t.orders.PlaceOrder(context.Background(), autobuyOrderRequest(order))
+	return err
+}
+
+// autobuyOrderRequest converts order parameters to a market buy for the autobuy user
+func autobuyOrderRequest(order OrderParameters) model.OrderRequest {
+	return model.OrderRequest{
+		UserID:   AutobuyUserID,
+		Symbol:   order.Symbol,
+		Side:     model.OrderSideBuy,
+		Type:     model.OrderTypeMarket,
+		Quantity: order.Quantity,
+	}
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"math"
+	"sync"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+)
+
+// InMemoryBudgetTracker is a BudgetTracker that keeps usage in memory. Usage is lost on
+// restart, so production wiring should use a persistent tracker.
+type InMemoryBudgetTracker struct {
+	mu            sync.Mutex
+	spent         float64
+	openPositions map[string][]inMemoryPosition
+}
+
+// inMemoryPosition is an open autobuy position and the quantity of it left unsold
+type inMemoryPosition struct {
+	bought    float64
+	remaining float64
+}
+
+// NewInMemoryBudgetTracker creates an empty InMemoryBudgetTracker
+func NewInMemoryBudgetTracker() *InMemoryBudgetTracker {
+	return &InMemoryBudgetTracker{openPositions: make(map[string][]inMemoryPosition)}
+}
+
+// Usage implements BudgetTracker
+func (t *InMemoryBudgetTracker) Usage() (float64, int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	open := 0
+	for _, positions := range t.openPositions {
+		open += len(positions)
+	}
+	return t.spent, open, nil
+}
+
+// RecordBuy implements BudgetTracker
+func (t *InMemoryBudgetTracker) RecordBuy(symbol string, amount, quantity float64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.spent += amount
+	t.openPositions[symbol] = append(t.openPositions[symbol], inMemoryPosition{bought: quantity, remaining: quantity})
+	return nil
+}
+
+// RecordSell implements BudgetTracker
+func (t *InMemoryBudgetTracker) RecordSell(symbol string, quantity float64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	positions := t.openPositions[symbol]
+	for len(positions) > 0 && quantity > 0 {
+		sold := math.Min(quantity, positions[0].remaining)
+		positions[0].remaining -= sold
+		quantity -= sold
+		if !model.AutoBuyPositionSoldOff(positions[0].bought, positions[0].remaining) {
+			break
+		}
+		positions = positions[1:]
+	}
+	t.setOpen(symbol, positions)
+	return nil
+}
+
+// ReleasePosition implements BudgetTracker
+func (t *InMemoryBudgetTracker) ReleasePosition(symbol string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if positions := t.openPositions[symbol]; len(positions) > 0 {
+		t.setOpen(symbol, positions[1:])
+	}
+	return nil
+}
+
+// setOpen stores the open positions of symbol, dropping the symbol when none are left
+func (t *InMemoryBudgetTracker) setOpen(symbol string, positions []inMemoryPosition) {
+	if len(positions) == 0 {
+		delete(t.openPositions, symbol)
+		return
+	}
+	t.openPositions[symbol] = positions
+}
@@ -30,3 +30,17 @@ type TradeUsecase interface {
 type NotificationService interface {
 	Notify(message string)
 }
+
+// BudgetTracker records how much of the autobuy budget has been committed so caps
+// survive restarts
+type BudgetTracker interface {
+	// Usage returns the total quote amount spent and the number of open positions
+	Usage() (spent float64, openPositions int, err error)
+	// RecordBuy records a completed buy of quantity of symbol for amount quote as an open position
+	RecordBuy(symbol string, amount, quantity float64) error
+	// RecordSell takes a sold quantity of symbol off its open positions, oldest first, and
+	// closes each position that is sold off. Spent budget is not refunded.
+	RecordSell(symbol string, quantity float64) error
+	// ReleasePosition marks the open position for symbol as closed. Spent budget is not refunded.
+	ReleasePosition(symbol string) error
+}
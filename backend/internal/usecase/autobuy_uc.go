@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
@@ -20,6 +21,7 @@ var (
 	ErrInvalidRuleParameters    = errors.New("invalid auto-buy rule parameters")
 	ErrMarketConditionNotMet    = errors.New("market condition not met for auto-buy")
 	ErrRiskLimitExceededAutoBuy = errors.New("risk limit exceeded for auto-buy")
+	ErrAutoBuyBudgetExceeded    = errors.New("auto-buy budget cap reached")
 )
 
 // AutoBuyUseCase defines the interface for automatic buying use cases
@@ -60,8 +62,35 @@ type AutoBuyConfig struct {
 	MaxPrice     float64
 	MinVolume    float64
 	DelaySeconds int
+
+	// Spending guardrails, zero disables a cap
+	MaxTotalBudget         float64 // Total quote amount that may be spent across all buys
+	MaxPerCoin             float64 // Maximum quote amount spent on a single coin; larger orders are scaled down
+	MaxConcurrentPositions int     // Maximum number of open autobuy positions
+
+	// DryRun records intended buys instead of placing orders
+	DryRun bool
 }
 
+// SimulatedBuy is a buy the autobuyer would have placed in dry-run mode
+type SimulatedBuy struct {
+	Symbol      string    `json:"symbol"`
+	Quantity    float64   `json:"quantity"`
+	SignalPrice float64   `json:"signal_price"` // Price when the listing was evaluated
+	FillPrice   float64   `json:"fill_price"`   // Latest price at the time the order would have been placed
+	Cost        float64   `json:"cost"`         // Quantity * FillPrice
+	Volume      float64   `json:"volume"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// SimulatedBuyProvider exposes the buys recorded in dry-run mode
+type SimulatedBuyProvider interface {
+	GetSimulatedBuys() []SimulatedBuy
+}
+
+// maxSimulatedBuys bounds the dry-run history kept in memory
+const maxSimulatedBuys = 500
+
 // OrderParameters contains details for a trade order
 type OrderParameters struct {
 	Symbol   string
@@ -80,9 +109,18 @@ type AutobuyService struct {
 	riskUsecase         RiskUsecase
 	tradeUsecase        TradeUsecase
 	notificationService NotificationService
+	budgetTracker       BudgetTracker
+	logger              *zerolog.Logger
+
+	// budgetMu serializes the budget check and the buy it guards
+	budgetMu sync.Mutex
+
+	simulatedMu   sync.RWMutex
+	simulatedBuys []SimulatedBuy
 }
 
-// NewAutobuyService creates a new instance of AutobuyService
+// NewAutobuyService creates a new instance of AutobuyService. A nil budget tracker keeps
+// usage in memory only.
 func NewAutobuyService(
 	cl ConfigLoader,
 	repo NewCoinRepository,
@@ -90,7 +128,12 @@ func NewAutobuyService(
 	ru RiskUsecase,
 	tu TradeUsecase,
 	ns NotificationService,
+	bt BudgetTracker,
+	logger *zerolog.Logger,
 ) *AutobuyService {
+	if bt == nil {
+		bt = NewInMemoryBudgetTracker()
+	}
 	return &AutobuyService{
 		configLoader:        cl,
 		newCoinRepository:   repo,
@@ -98,6 +141,8 @@ func NewAutobuyService(
 		riskUsecase:         ru,
 		tradeUsecase:        tu,
 		notificationService: ns,
+		budgetTracker:       bt,
+		logger:              logger,
 	}
 }
 
@@ -159,11 +204,30 @@ func (s *AutobuyService) HandleNewCoinEvent(evt event.NewCoinTradable) error {
 		time.Sleep(time.Duration(config.DelaySeconds) * time.Second)
 	}
 
+	s.budgetMu.Lock()
+	defer s.budgetMu.Unlock()
+
+	// Enforce spending caps
+	orderParams, err = s.applyBudget(config, orderParams)
+	if err != nil {
+		s.log().Warn().Err(err).Str("symbol", evt.Symbol).Msg("Autobuy refused by spending guardrail")
+		return err
+	}
+
+	if config.DryRun {
+		return s.simulateBuy(orderParams)
+	}
+
 	// Execute buy
 	if err := s.tradeUsecase.ExecuteMarketBuy(orderParams); err != nil {
 		return fmt.Errorf("failed to execute market buy: %w", err)
 	}
 
+	// Record spend before anything else can fail so a restart cannot overspend
+	if err := s.tracker().RecordBuy(evt.Symbol, orderParams.Price*orderParams.Quantity, orderParams.Quantity); err != nil {
+		s.log().Error().Err(err).Str("symbol", evt.Symbol).Msg("Failed to record autobuy spend")
+	}
+
 	// Mark as processed
 	if err := s.newCoinRepository.MarkAsProcessed(evt.Symbol); err != nil {
 		return fmt.Errorf("failed to mark as processed: %w", err)
@@ -175,6 +239,150 @@ func (s *AutobuyService) HandleNewCoinEvent(evt event.NewCoinTradable) error {
 	return nil
 }
 
+// simulateBuy records the buy that would have been placed, priced at the latest market
+// price. Dry runs neither consume budget nor mark the coin as processed, so enabling
+// live mode later still buys it.
+func (s *AutobuyService) simulateBuy(params OrderParameters) error {
+	s.simulatedMu.Lock()
+	defer s.simulatedMu.Unlock()
+
+	for _, existing := range s.simulatedBuys {
+		if existing.Symbol == params.Symbol {
+			return fmt.Errorf("symbol %s already simulated for autobuy", params.Symbol)
+		}
+	}
+
+	fillPrice := params.Price
+	if latest, _, err := s.marketDataService.GetMarketData(params.Symbol); err != nil {
+		s.log().Warn().Err(err).Str("symbol", params.Symbol).Msg("Failed to refresh price for dry-run fill, using signal price")
+	} else if latest > 0 {
+		fillPrice = latest
+	}
+
+	buy := SimulatedBuy{
+		Symbol:      params.Symbol,
+		Quantity:    params.Quantity,
+		SignalPrice: params.Price,
+		FillPrice:   fillPrice,
+		Cost:        params.Quantity * fillPrice,
+		Volume:      params.Volume,
+		Timestamp:   time.Now(),
+	}
+	s.simulatedBuys = append(s.simulatedBuys, buy)
+	if len(s.simulatedBuys) > maxSimulatedBuys {
+		s.simulatedBuys = s.simulatedBuys[len(s.simulatedBuys)-maxSimulatedBuys:]
+	}
+
+	s.log().Info().
+		Str("symbol", buy.Symbol).
+		Float64("quantity", buy.Quantity).
+		Float64("fillPrice", buy.FillPrice).
+		Float64("cost", buy.Cost).
+		Msg("Dry run: autobuy order not placed")
+	s.notificationService.Notify(fmt.Sprintf("[dry run] Would auto-buy %s at price %f", buy.Symbol, buy.FillPrice))
+
+	return nil
+}
+
+// GetSimulatedBuys returns the buys recorded in dry-run mode, oldest first
+func (s *AutobuyService) GetSimulatedBuys() []SimulatedBuy {
+	s.simulatedMu.RLock()
+	defer s.simulatedMu.RUnlock()
+
+	buys := make([]SimulatedBuy, len(s.simulatedBuys))
+	copy(buys, s.simulatedBuys)
+	return buys
+}
+
+// applyBudget checks the spending caps and scales the order down to MaxPerCoin. It
+// returns ErrAutoBuyBudgetExceeded when the total budget or position count would be exceeded.
+func (s *AutobuyService) applyBudget(config *AutoBuyConfig, params OrderParameters) (OrderParameters, error) {
+	if config.MaxPerCoin > 0 && params.Price > 0 && params.Price*params.Quantity > config.MaxPerCoin {
+		params.Quantity = config.MaxPerCoin / params.Price
+	}
+
+	if config.MaxTotalBudget <= 0 && config.MaxConcurrentPositions <= 0 {
+		return params, nil
+	}
+
+	spent, openPositions, err := s.tracker().Usage()
+	if err != nil {
+		return params, fmt.Errorf("failed to load autobuy budget usage: %w", err)
+	}
+
+	if config.MaxConcurrentPositions > 0 && openPositions >= config.MaxConcurrentPositions {
+		return params, fmt.Errorf("%w: %d of %d concurrent positions open",
+			ErrAutoBuyBudgetExceeded, openPositions, config.MaxConcurrentPositions)
+	}
+
+	cost := params.Price * params.Quantity
+	if config.MaxTotalBudget > 0 && spent+cost > config.MaxTotalBudget {
+		return params, fmt.Errorf("%w: spending %f would exceed total budget %f (already spent %f)",
+			ErrAutoBuyBudgetExceeded, cost, config.MaxTotalBudget, spent)
+	}
+
+	return params, nil
+}
+
+// ReleasePosition marks the autobuy position in symbol as closed, freeing a slot under
+// MaxConcurrentPositions
+func (s *AutobuyService) ReleasePosition(symbol string) error {
+	s.budgetMu.Lock()
+	defer s.budgetMu.Unlock()
+
+	return s.tracker().ReleasePosition(symbol)
+}
+
+// RecordSell takes a sold quantity of symbol off the open autobuy positions and frees the
+// slot of each position that is sold off
+func (s *AutobuyService) RecordSell(symbol string, quantity float64) error {
+	s.budgetMu.Lock()
+	defer s.budgetMu.Unlock()
+
+	return s.tracker().RecordSell(symbol, quantity)
+}
+
+// ReleaseOnSell subscribes to order fills on events and takes each filled sell off the
+// autobuy positions in its symbol, which is how positions are closed, whether by hand, a
+// stop or a take-profit. A position is released once it is sold off, so selling part of
+// it keeps its slot. Without it open positions are only ever added, and once
+// MaxConcurrentPositions is reached every later buy is refused.
+func (s *AutobuyService) ReleaseOnSell(events port.DomainEventBus) port.Subscription {
+	return events.SubscribeTopics(s.handleOrderFilled, event.OrderFilledEvent)
+}
+
+// handleOrderFilled records the quantity sold by a filled sell order
+func (s *AutobuyService) handleOrderFilled(evt event.DomainEvent) {
+	changed, ok := evt.(*event.OrderStatusChanged)
+	if !ok || changed.Order == nil || changed.Order.Side != model.OrderSideSell {
+		return
+	}
+	sold := changed.Order.ExecutedQty
+	if sold <= 0 {
+		sold = changed.Order.Quantity
+	}
+	if err := s.RecordSell(changed.Order.Symbol, sold); err != nil {
+		s.log().Error().Err(err).Str("symbol", changed.Order.Symbol).Msg("Failed to release autobuy position")
+	}
+}
+
+// tracker returns the budget tracker, creating an in-memory one when none was configured
+func (s *AutobuyService) tracker() BudgetTracker {
+	if s.budgetTracker == nil {
+		s.budgetTracker = NewInMemoryBudgetTracker()
+	}
+	return s.budgetTracker
+}
+
+// log returns the service logger or a no-op logger when none was configured
+func (s *AutobuyService) log() *zerolog.Logger {
+	if s.logger == nil {
+		nop := zerolog.Nop()
+		return &nop
+	}
+	return s.logger
+}
+
 // autoBuyUseCase implements the AutoBuyUseCase interface
 type autoBuyUseCase struct {
 	autoRuleRepo      port.AutoBuyRuleRepository
@@ -1,12 +1,14 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
 )
 
 // Mocks and dummy implementations
@@ -212,3 +214,317 @@ func TestAutobuyService_Success(t *testing.T) {
 	// Allow any optional delay to pass
 	time.Sleep(10 * time.Millisecond)
 }
+
+// perSymbolNewCoinRepository tracks processing state for each symbol separately
+type perSymbolNewCoinRepository struct {
+	processed map[string]bool
+}
+
+func (m *perSymbolNewCoinRepository) IsProcessedForAutobuy(symbol string) bool {
+	return m.processed[symbol]
+}
+
+func (m *perSymbolNewCoinRepository) MarkAsProcessed(symbol string) error {
+	m.processed[symbol] = true
+	return nil
+}
+
+// countingTradeUsecase records every executed buy
+type countingTradeUsecase struct {
+	orders []OrderParameters
+}
+
+func (m *countingTradeUsecase) ExecuteMarketBuy(order OrderParameters) error {
+	m.orders = append(m.orders, order)
+	return nil
+}
+
+func newBudgetTestService(config *AutoBuyConfig, trade *countingTradeUsecase, tracker BudgetTracker) *AutobuyService {
+	return NewAutobuyService(
+		&mockConfigLoader{config: config},
+		&perSymbolNewCoinRepository{processed: make(map[string]bool)},
+		&mockMarketDataService{price: 50, volume: 600},
+		&mockRiskUsecase{riskOk: true},
+		trade,
+		&mockNotificationService{},
+		tracker,
+		nil,
+	)
+}
+
+func newTradableEvent(symbol string) event.NewCoinTradable {
+	price := 50.0
+	volume := 600.0
+	return *event.NewNewCoinTradable(createTestNewCoin(symbol, "USDT"), &price, &volume)
+}
+
+func TestAutobuyService_MaxConcurrentPositions(t *testing.T) {
+	config := &AutoBuyConfig{
+		Enabled:                true,
+		MinPrice:               10,
+		MaxPrice:               200,
+		MinVolume:              500,
+		MaxConcurrentPositions: 2,
+	}
+	trade := &countingTradeUsecase{}
+	tracker := NewInMemoryBudgetTracker()
+	service := newBudgetTestService(config, trade, tracker)
+
+	if err := service.HandleNewCoinEvent(newTradableEvent("AAAUSDT")); err != nil {
+		t.Fatalf("first buy failed: %v", err)
+	}
+	if err := service.HandleNewCoinEvent(newTradableEvent("BBBUSDT")); err != nil {
+		t.Fatalf("second buy failed: %v", err)
+	}
+
+	err := service.HandleNewCoinEvent(newTradableEvent("CCCUSDT"))
+	if !errors.Is(err, ErrAutoBuyBudgetExceeded) {
+		t.Fatalf("expected third buy to be rejected with ErrAutoBuyBudgetExceeded, got %v", err)
+	}
+	if len(trade.orders) != 2 {
+		t.Errorf("expected 2 executed buys, got %d", len(trade.orders))
+	}
+
+	// Closing a position frees a slot
+	if err := tracker.ReleasePosition("AAAUSDT"); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.HandleNewCoinEvent(newTradableEvent("CCCUSDT")); err != nil {
+		t.Errorf("expected buy after release to succeed, got %v", err)
+	}
+}
+
+// fillEventBus delivers published events synchronously to its one handler
+type fillEventBus struct {
+	handler func(event.DomainEvent)
+	topics  []event.EventType
+}
+
+func (b *fillEventBus) PublishEvent(evt event.DomainEvent) {
+	for _, topic := range b.topics {
+		if topic == evt.Type() {
+			b.handler(evt)
+		}
+	}
+}
+
+func (b *fillEventBus) SubscribeTopics(handler func(event.DomainEvent), topics ...event.EventType) port.Subscription {
+	b.handler = handler
+	b.topics = topics
+	return fillSubscription{}
+}
+
+type fillSubscription struct{}
+
+func (fillSubscription) Unsubscribe() {}
+
+func TestAutobuyService_ReleaseOnSell(t *testing.T) {
+	config := &AutoBuyConfig{
+		Enabled:                true,
+		MinPrice:               10,
+		MaxPrice:               200,
+		MinVolume:              500,
+		MaxConcurrentPositions: 1,
+	}
+	tracker := NewInMemoryBudgetTracker()
+	service := newBudgetTestService(config, &countingTradeUsecase{}, tracker)
+	bus := &fillEventBus{}
+	service.ReleaseOnSell(bus)
+
+	if err := service.HandleNewCoinEvent(newTradableEvent("AAAUSDT")); err != nil {
+		t.Fatalf("first buy failed: %v", err)
+	}
+
+	// A filled buy or a cancelled sell leaves the position open
+	bus.PublishEvent(event.NewOrderStatusChanged(&model.Order{Symbol: "AAAUSDT", Side: model.OrderSideBuy, Status: model.OrderStatusFilled}, model.OrderStatusNew))
+	bus.PublishEvent(event.NewOrderStatusChanged(&model.Order{Symbol: "AAAUSDT", Side: model.OrderSideSell, Status: model.OrderStatusCanceled}, model.OrderStatusNew))
+	if _, open, _ := tracker.Usage(); open != 1 {
+		t.Fatalf("expected 1 open position, got %d", open)
+	}
+	if err := service.HandleNewCoinEvent(newTradableEvent("BBBUSDT")); !errors.Is(err, ErrAutoBuyBudgetExceeded) {
+		t.Fatalf("expected buy to be refused while the position is open, got %v", err)
+	}
+
+	// Selling part of the position keeps it open
+	bus.PublishEvent(event.NewOrderStatusChanged(&model.Order{Symbol: "AAAUSDT", Side: model.OrderSideSell, Status: model.OrderStatusFilled, Quantity: 0.5, ExecutedQty: 0.5}, model.OrderStatusNew))
+	if _, open, _ := tracker.Usage(); open != 1 {
+		t.Fatalf("expected a partial sell to keep the position open, %d open", open)
+	}
+	if err := service.HandleNewCoinEvent(newTradableEvent("BBBUSDT")); !errors.Is(err, ErrAutoBuyBudgetExceeded) {
+		t.Fatalf("expected buy to be refused while part of the position is open, got %v", err)
+	}
+
+	bus.PublishEvent(event.NewOrderStatusChanged(&model.Order{Symbol: "AAAUSDT", Side: model.OrderSideSell, Status: model.OrderStatusFilled, Quantity: 0.5, ExecutedQty: 0.5}, model.OrderStatusNew))
+	if _, open, _ := tracker.Usage(); open != 0 {
+		t.Fatalf("expected selling the rest to close the position, %d still open", open)
+	}
+	if err := service.HandleNewCoinEvent(newTradableEvent("BBBUSDT")); err != nil {
+		t.Errorf("expected buy after the sell to succeed, got %v", err)
+	}
+}
+
+func TestInMemoryBudgetTracker_RecordSellClosesSoldOffPositions(t *testing.T) {
+	tracker := NewInMemoryBudgetTracker()
+	for _, quantity := range []float64{2, 3} {
+		if err := tracker.RecordBuy("AAAUSDT", 10, quantity); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The sell closes the older position and takes the rest off the newer one
+	if err := tracker.RecordSell("AAAUSDT", 4); err != nil {
+		t.Fatal(err)
+	}
+	if _, open, _ := tracker.Usage(); open != 1 {
+		t.Fatalf("expected 1 open position, got %d", open)
+	}
+
+	// Lot-size rounding leaves dust behind, which still closes the position
+	if err := tracker.RecordSell("AAAUSDT", 0.99); err != nil {
+		t.Fatal(err)
+	}
+	if _, open, _ := tracker.Usage(); open != 0 {
+		t.Fatalf("expected the sold-off position to close, %d open", open)
+	}
+}
+
+func TestAutobuyService_BuyNewListings(t *testing.T) {
+	config := &AutoBuyConfig{
+		Enabled:   true,
+		MinPrice:  10,
+		MaxPrice:  200,
+		MinVolume: 500,
+	}
+	trade := &countingTradeUsecase{}
+	service := newBudgetTestService(config, trade, NewInMemoryBudgetTracker())
+	bus := &fillEventBus{}
+	service.BuyNewListings(bus)
+
+	evt := newTradableEvent("AAAUSDT")
+	bus.PublishEvent(&evt)
+	bus.PublishEvent(&evt)
+
+	if len(trade.orders) != 1 {
+		t.Fatalf("expected the listing to be bought once, got %d buys", len(trade.orders))
+	}
+	if trade.orders[0].Symbol != "AAAUSDT" {
+		t.Errorf("expected a buy of AAAUSDT, got %s", trade.orders[0].Symbol)
+	}
+}
+
+// recordingOrderPlacer records the orders placed through it
+type recordingOrderPlacer struct {
+	requests []model.OrderRequest
+}
+
+func (p *recordingOrderPlacer) PlaceOrder(ctx context.Context, req model.OrderRequest) (*model.Order, error) {
+	p.requests = append(p.requests, req)
+	return &model.Order{Symbol: req.Symbol}, nil
+}
+
+func TestAutobuyTrader_PlacesMarketBuysForTheAutobuyUser(t *testing.T) {
+	placer := &recordingOrderPlacer{}
+	trader := NewAutobuyTrader(placer)
+
+	if err := trader.ExecuteMarketBuy(OrderParameters{Symbol: "AAAUSDT", Price: 50, Quantity: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(placer.requests) != 1 {
+		t.Fatalf("expected 1 order, got %d", len(placer.requests))
+	}
+	req := placer.requests[0]
+	if req.UserID != AutobuyUserID || req.Side != model.OrderSideBuy || req.Type != model.OrderTypeMarket || req.Quantity != 2 {
+		t.Errorf("unexpected order request %+v", req)
+	}
+}
+
+func TestAutobuyService_BudgetCaps(t *testing.T) {
+	config := &AutoBuyConfig{
+		Enabled:        true,
+		MinPrice:       10,
+		MaxPrice:       200,
+		MinVolume:      500,
+		MaxPerCoin:     20,
+		MaxTotalBudget: 50,
+	}
+	trade := &countingTradeUsecase{}
+	tracker := NewInMemoryBudgetTracker()
+
+	// Budget already partly spent before a restart
+	if err := tracker.RecordBuy("OLDUSDT", 20, 1); err != nil {
+		t.Fatal(err)
+	}
+	service := newBudgetTestService(config, trade, tracker)
+
+	if err := service.HandleNewCoinEvent(newTradableEvent("AAAUSDT")); err != nil {
+		t.Fatalf("first buy failed: %v", err)
+	}
+	// The default quantity of 1 at price 50 is scaled down to the per-coin cap
+	if cost := trade.orders[0].Price * trade.orders[0].Quantity; cost != 20 {
+		t.Errorf("expected order cost capped at 20, got %f", cost)
+	}
+
+	err := service.HandleNewCoinEvent(newTradableEvent("BBBUSDT"))
+	if !errors.Is(err, ErrAutoBuyBudgetExceeded) {
+		t.Fatalf("expected buy beyond total budget to be rejected, got %v", err)
+	}
+
+	spent, open, _ := tracker.Usage()
+	if spent != 40 || open != 2 {
+		t.Errorf("expected spent=40 open=2, got spent=%f open=%d", spent, open)
+	}
+}
+
+func TestAutobuyService_DryRun(t *testing.T) {
+	config := &AutoBuyConfig{
+		Enabled:   true,
+		MinPrice:  10,
+		MaxPrice:  200,
+		MinVolume: 500,
+		DryRun:    true,
+	}
+	repo := &perSymbolNewCoinRepository{processed: make(map[string]bool)}
+	trade := &mockTradeUsecase{}
+	tracker := NewInMemoryBudgetTracker()
+	service := NewAutobuyService(
+		&mockConfigLoader{config: config},
+		repo,
+		&mockMarketDataService{price: 50, volume: 600},
+		&mockRiskUsecase{riskOk: true},
+		trade,
+		&mockNotificationService{},
+		tracker,
+		nil,
+	)
+
+	if err := service.HandleNewCoinEvent(newTradableEvent("AAAUSDT")); err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+
+	if trade.executed {
+		t.Error("dry run must not place a real order")
+	}
+	if repo.processed["AAAUSDT"] {
+		t.Error("dry run must not mark the coin as processed")
+	}
+	if spent, open, _ := tracker.Usage(); spent != 0 || open != 0 {
+		t.Errorf("dry run must not consume budget, got spent=%f open=%d", spent, open)
+	}
+
+	buys := service.GetSimulatedBuys()
+	if len(buys) != 1 {
+		t.Fatalf("expected 1 simulated buy, got %d", len(buys))
+	}
+	if buys[0].Symbol != "AAAUSDT" || buys[0].FillPrice != 50 || buys[0].Cost != 50 {
+		t.Errorf("unexpected simulated buy: %+v", buys[0])
+	}
+
+	// The same listing is only simulated once
+	if err := service.HandleNewCoinEvent(newTradableEvent("AAAUSDT")); err == nil {
+		t.Error("expected repeated dry run for the same symbol to be rejected")
+	}
+	if len(service.GetSimulatedBuys()) != 1 {
+		t.Error("repeated dry run must not record another buy")
+	}
+}
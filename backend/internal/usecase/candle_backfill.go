@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+)
+
+// backfillPageSize is the number of klines requested per exchange call while backfilling;
+// MEXC returns at most 1000
+const backfillPageSize = 1000
+
+// ErrBackfillUnavailable is returned by BackfillCandles when no exchange client is set
+var ErrBackfillUnavailable = errors.New("candle backfill requires an exchange client")
+
+// CandleBackfillResult summarises a candle backfill
+type CandleBackfillResult struct {
+	// Gaps are the gaps found before backfilling
+	Gaps []market.CandleGap `json:"gaps"`
+	// Filled is the number of candles fetched from the exchange and stored
+	Filled int `json:"filled"`
+	// Missing is the number of candles the exchange had no data for
+	Missing int `json:"missing"`
+}
+
+// DetectCandleGaps returns the runs of candles missing from the stored series among those
+// expected to open between start and end
+func (uc *MarketDataUseCase) DetectCandleGaps(ctx context.Context, symbol, exchange string, interval market.Interval, start, end time.Time) ([]market.CandleGap, error) {
+	candles, err := uc.marketRepo.GetCandles(ctx, symbol, exchange, interval, start, end, 0)
+	if err != nil {
+		return nil, err
+	}
+	return market.FindCandleGaps(candles, interval, start, end)
+}
+
+// BackfillCandles detects the gaps in the stored series between start and end, fetches
+// the missing candles from the exchange and upserts them. Only candles opening inside a
+// gap are stored, so candles already present are never overwritten.
+func (uc *MarketDataUseCase) BackfillCandles(ctx context.Context, symbol, exchange string, interval market.Interval, start, end time.Time) (*CandleBackfillResult, error) {
+	if uc.klineHistory == nil {
+		return nil, ErrBackfillUnavailable
+	}
+
+	gaps, err := uc.DetectCandleGaps(ctx, symbol, exchange, interval, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CandleBackfillResult{Gaps: gaps}
+	for _, gap := range gaps {
+		filled, err := uc.backfillGap(ctx, symbol, exchange, interval, gap)
+		result.Filled += filled
+		if err != nil {
+			return nil, err
+		}
+		result.Missing += gap.Missing - filled
+	}
+
+	uc.logger.Info().
+		Str("exchange", exchange).
+		Str("symbol", symbol).
+		Str("interval", string(interval)).
+		Int("gaps", len(gaps)).
+		Int("filled", result.Filled).
+		Int("missing", result.Missing).
+		Msg("Backfilled candles")
+	return result, nil
+}
+
+// backfillGap fetches the candles of one gap page by page and stores them, returning how
+// many were stored
+func (uc *MarketDataUseCase) backfillGap(ctx context.Context, symbol, exchange string, interval market.Interval, gap market.CandleGap) (int, error) {
+	filled := 0
+	cursor := gap.Start
+	for cursor.Before(gap.End) {
+		klines, err := uc.klineHistory.GetKlinesRange(ctx, symbol, model.KlineInterval(interval), cursor, gap.End.Add(-time.Millisecond), backfillPageSize)
+		if err != nil {
+			return filled, fmt.Errorf("failed to fetch %s %s candles from %s: %w", symbol, interval, cursor.Format(time.RFC3339), err)
+		}
+
+		candles := make([]*market.Candle, 0, len(klines))
+		next := cursor
+		for _, k := range klines {
+			if k.OpenTime.Before(cursor) || !k.OpenTime.Before(gap.End) {
+				continue
+			}
+			candles = append(candles, klineToCandle(k, symbol, exchange, interval))
+			if !k.OpenTime.Before(next) {
+				next = k.OpenTime.Add(interval.Duration())
+			}
+		}
+		if len(candles) == 0 {
+			break
+		}
+
+		if err := uc.marketRepo.SaveCandles(ctx, candles); err != nil {
+			return filled, err
+		}
+		filled += len(candles)
+		cursor = next
+	}
+	return filled, nil
+}
+
+// klineToCandle converts an exchange kline to a candle. A kline that has not closed yet
+// is incomplete.
+func klineToCandle(k *model.Kline, symbol, exchange string, interval market.Interval) *market.Candle {
+	return &market.Candle{
+		Symbol:      symbol,
+		Exchange:    exchange,
+		Interval:    interval,
+		OpenTime:    k.OpenTime,
+		CloseTime:   k.CloseTime,
+		Open:        k.Open,
+		High:        k.High,
+		Low:         k.Low,
+		Close:       k.Close,
+		Volume:      k.Volume,
+		QuoteVolume: k.QuoteVolume,
+		TradeCount:  k.TradeCount,
+		Complete:    k.CloseTime.Before(time.Now()),
+	}
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// klineRange is one GetKlinesRange call
+type klineRange struct {
+	start, end time.Time
+}
+
+// fakeKlineHistory serves one-minute klines for every minute from first to last, with
+// the close price set to 1000 plus the minute, and records the ranges requested
+type fakeKlineHistory struct {
+	first, last time.Time
+	requests    []klineRange
+	err         error
+}
+
+func (f *fakeKlineHistory) GetKlinesRange(ctx context.Context, symbol string, interval model.KlineInterval, start, end time.Time, limit int) ([]*model.Kline, error) {
+	f.requests = append(f.requests, klineRange{start, end})
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	var klines []*model.Kline
+	for open := f.first; !open.After(f.last) && len(klines) < limit; open = open.Add(time.Minute) {
+		if open.Before(start) || open.After(end) {
+			continue
+		}
+		minute := float64(open.Sub(f.first) / time.Minute)
+		klines = append(klines, &model.Kline{
+			Symbol:    symbol,
+			Interval:  interval,
+			OpenTime:  open,
+			CloseTime: open.Add(time.Minute - time.Millisecond),
+			Open:      1000 + minute,
+			High:      1000 + minute,
+			Low:       1000 + minute,
+			Close:     1000 + minute,
+			Volume:    1,
+		})
+	}
+	return klines, nil
+}
+
+// seedPunchedSeries stores minutes 0..9 from start except 3, 4 and 8, with close prices
+// that differ from the fake exchange's
+func seedPunchedSeries(t *testing.T, uc *MarketDataUseCase, start time.Time) {
+	var candles []*market.Candle
+	for minute := 0; minute < 10; minute++ {
+		if minute == 3 || minute == 4 || minute == 8 {
+			continue
+		}
+		open := start.Add(time.Duration(minute) * time.Minute)
+		candles = append(candles, &market.Candle{
+			Symbol: "BTCUSDT", Exchange: "mexc", Interval: market.Interval1m,
+			OpenTime: open, CloseTime: open.Add(time.Minute - time.Millisecond),
+			Close: float64(minute), Volume: 1, Complete: true,
+		})
+	}
+	require.NoError(t, uc.marketRepo.SaveCandles(context.Background(), candles))
+}
+
+func TestDetectCandleGaps_FindsPunchedHoles(t *testing.T) {
+	uc, _ := setupCandleImport(t)
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	seedPunchedSeries(t, uc, start)
+
+	gaps, err := uc.DetectCandleGaps(context.Background(), "BTCUSDT", "mexc", market.Interval1m, start, start.Add(9*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, gaps, 2)
+	assert.True(t, gaps[0].Start.Equal(start.Add(3*time.Minute)))
+	assert.True(t, gaps[0].End.Equal(start.Add(5*time.Minute)))
+	assert.Equal(t, 2, gaps[0].Missing)
+	assert.True(t, gaps[1].Start.Equal(start.Add(8*time.Minute)))
+	assert.Equal(t, 1, gaps[1].Missing)
+}
+
+func TestBackfillCandles_FillsOnlyMissingIntervals(t *testing.T) {
+	uc, _ := setupCandleImport(t)
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	seedPunchedSeries(t, uc, start)
+	history := &fakeKlineHistory{first: start, last: start.Add(9 * time.Minute)}
+	uc.WithKlineHistory(history)
+	ctx := context.Background()
+
+	result, err := uc.BackfillCandles(ctx, "BTCUSDT", "mexc", market.Interval1m, start, start.Add(9*time.Minute))
+	require.NoError(t, err)
+	assert.Len(t, result.Gaps, 2)
+	assert.Equal(t, 3, result.Filled)
+	assert.Zero(t, result.Missing)
+
+	// The exchange was asked for the two gaps only
+	require.Len(t, history.requests, 2)
+	assert.True(t, history.requests[0].start.Equal(start.Add(3*time.Minute)))
+	assert.True(t, history.requests[0].end.Before(start.Add(5*time.Minute)))
+	assert.True(t, history.requests[1].start.Equal(start.Add(8*time.Minute)))
+
+	candles, err := uc.marketRepo.GetCandles(ctx, "BTCUSDT", "mexc", market.Interval1m, start, start.Add(9*time.Minute), 0)
+	require.NoError(t, err)
+	require.Len(t, candles, 10)
+	for minute, c := range candles {
+		want := float64(minute)
+		if minute == 3 || minute == 4 || minute == 8 {
+			want = 1000 + float64(minute)
+		}
+		assert.Equal(t, want, c.Close, "minute %d", minute)
+	}
+
+	gaps, err := uc.DetectCandleGaps(ctx, "BTCUSDT", "mexc", market.Interval1m, start, start.Add(9*time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, gaps)
+}
+
+func TestBackfillCandles_ReportsCandlesTheExchangeLacks(t *testing.T) {
+	uc, _ := setupCandleImport(t)
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	seedPunchedSeries(t, uc, start)
+	// The exchange has no data after minute 3
+	uc.WithKlineHistory(&fakeKlineHistory{first: start, last: start.Add(3 * time.Minute)})
+
+	result, err := uc.BackfillCandles(context.Background(), "BTCUSDT", "mexc", market.Interval1m, start, start.Add(9*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Filled)
+	assert.Equal(t, 2, result.Missing)
+}
+
+func TestBackfillCandles_Errors(t *testing.T) {
+	uc, _ := setupCandleImport(t)
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	_, err := uc.BackfillCandles(context.Background(), "BTCUSDT", "mexc", market.Interval1m, start, start.Add(time.Hour))
+	assert.ErrorIs(t, err, ErrBackfillUnavailable)
+
+	upstream := errors.New("exchange unavailable")
+	uc.WithKlineHistory(&fakeKlineHistory{err: upstream})
+	_, err = uc.BackfillCandles(context.Background(), "BTCUSDT", "mexc", market.Interval1m, start, start.Add(time.Hour))
+	assert.ErrorIs(t, err, upstream)
+}
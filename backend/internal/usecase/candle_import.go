@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+)
+
+// candleImportBatchSize is the number of candles upserted per SaveCandles call
+const candleImportBatchSize = 500
+
+// candleImportColumns are the columns a candle CSV must provide. close_time, quote_volume
+// and trade_count are optional.
+var candleImportColumns = []string{"open_time", "open", "high", "low", "close", "volume"}
+
+// ImportLineError describes a CSV row that could not be imported
+type ImportLineError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// CandleImportResult summarises a candle CSV import
+type CandleImportResult struct {
+	// Imported is the number of candles upserted
+	Imported int `json:"imported"`
+	// Skipped is the number of rows rejected; each has an entry in Errors
+	Skipped int `json:"skipped"`
+	// Duplicates is the number of rows that repeated the open time of the previous row.
+	// The last of them wins.
+	Duplicates int               `json:"duplicates"`
+	Errors     []ImportLineError `json:"errors,omitempty"`
+}
+
+// ImportCandlesCSV reads candles from CSV with a header row and upserts them in batches,
+// so importing the same file twice leaves a single copy of every candle. Times are Unix
+// milliseconds or RFC 3339, matching the candle export. Open times must not decrease:
+// malformed rows and rows opening before their predecessor are skipped and reported by
+// line number. A missing header or a storage failure aborts the import; batches saved
+// before the failure are kept.
+func (uc *MarketDataUseCase) ImportCandlesCSV(ctx context.Context, r io.Reader, symbol, exchange string, interval market.Interval) (*CandleImportResult, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range candleImportColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("CSV is missing required column %q", name)
+		}
+	}
+
+	result := &CandleImportResult{}
+	batch := make([]*market.Candle, 0, candleImportBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := uc.marketRepo.SaveCandles(ctx, batch); err != nil {
+			return fmt.Errorf("failed to save candles: %w", err)
+		}
+		result.Imported += len(batch)
+		batch = make([]*market.Candle, 0, candleImportBatchSize)
+		return nil
+	}
+	skip := func(line int, err error) {
+		result.Skipped++
+		result.Errors = append(result.Errors, ImportLineError{Line: line, Message: err.Error()})
+	}
+
+	var previous time.Time
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return result, fmt.Errorf("failed to read CSV: %w", err)
+			}
+			skip(parseErr.StartLine, parseErr.Err)
+			continue
+		}
+
+		candle, err := parseImportRecord(record, columns, symbol, exchange, interval)
+		if err != nil {
+			skip(line, err)
+			continue
+		}
+
+		switch {
+		case !previous.IsZero() && candle.OpenTime.Before(previous):
+			skip(line, fmt.Errorf("open_time %s is before the previous row", candle.OpenTime.Format(time.RFC3339)))
+			continue
+		case len(batch) > 0 && candle.OpenTime.Equal(previous):
+			batch[len(batch)-1] = candle
+			result.Duplicates++
+			continue
+		case candle.OpenTime.Equal(previous):
+			// The previous row was already saved with the last batch; saving again upserts it
+			result.Duplicates++
+			result.Imported--
+		}
+		previous = candle.OpenTime
+
+		batch = append(batch, candle)
+		if len(batch) == candleImportBatchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return result, err
+	}
+
+	uc.logger.Info().
+		Str("exchange", exchange).
+		Str("symbol", symbol).
+		Str("interval", string(interval)).
+		Int("imported", result.Imported).
+		Int("skipped", result.Skipped).
+		Int("duplicates", result.Duplicates).
+		Msg("Imported candles from CSV")
+	return result, nil
+}
+
+// parseImportRecord converts a CSV row into a candle
+func parseImportRecord(record []string, columns map[string]int, symbol, exchange string, interval market.Interval) (*market.Candle, error) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	openTime, err := parseImportTime(field("open_time"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid open_time: %w", err)
+	}
+
+	values := make(map[string]float64, len(candleImportColumns)-1)
+	for _, name := range candleImportColumns[1:] {
+		value, err := strconv.ParseFloat(field(name), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", name, field(name))
+		}
+		values[name] = value
+	}
+	if values["low"] > values["high"] {
+		return nil, fmt.Errorf("low %g is above high %g", values["low"], values["high"])
+	}
+
+	candle := &market.Candle{
+		Symbol:    symbol,
+		Exchange:  exchange,
+		Interval:  interval,
+		OpenTime:  openTime,
+		CloseTime: openTime,
+		Open:      values["open"],
+		High:      values["high"],
+		Low:       values["low"],
+		Close:     values["close"],
+		Volume:    values["volume"],
+		Complete:  true,
+	}
+	if duration := interval.Duration(); duration > 0 {
+		candle.CloseTime = openTime.Add(duration - time.Millisecond)
+	}
+	if raw := field("close_time"); raw != "" {
+		if candle.CloseTime, err = parseImportTime(raw); err != nil {
+			return nil, fmt.Errorf("invalid close_time: %w", err)
+		}
+	}
+	if raw := field("quote_volume"); raw != "" {
+		if candle.QuoteVolume, err = strconv.ParseFloat(raw, 64); err != nil {
+			return nil, fmt.Errorf("invalid quote_volume %q", raw)
+		}
+	}
+	if raw := field("trade_count"); raw != "" {
+		if candle.TradeCount, err = strconv.ParseInt(raw, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid trade_count %q", raw)
+		}
+	}
+	return candle, nil
+}
+
+// parseImportTime accepts Unix milliseconds or RFC 3339 timestamps
+func parseImportTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("is empty")
+	}
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(ms).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not RFC 3339 or Unix milliseconds", value)
+	}
+	return t.UTC(), nil
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	gormrepo "github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/persistence/gorm"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupCandleImport(t *testing.T) (*MarketDataUseCase, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&gormrepo.CandleEntity{}))
+
+	log := zerolog.Nop()
+	repo := gormrepo.NewMarketRepository(db, &log)
+	return NewMarketDataUseCase(repo, nil, nil, &log), db
+}
+
+func loadImportedCandles(t *testing.T, uc *MarketDataUseCase) []*market.Candle {
+	start := time.UnixMilli(0).UTC()
+	end := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles, err := uc.marketRepo.GetCandles(context.Background(), "BTCUSDT", "mexc", market.Interval1m, start, end, 100)
+	require.NoError(t, err)
+	return candles
+}
+
+func TestImportCandlesCSV_ValidFile(t *testing.T) {
+	uc, _ := setupCandleImport(t)
+	csv := strings.Join([]string{
+		"open_time,open,high,low,close,volume,quote_volume,trade_count",
+		"1700000000000,100,110,95,105,12.5,1300,42",
+		"1700000060000,105,108,101,102,8,820,30",
+		"2023-11-14T22:15:00Z,102,104,100,103,4,410,11",
+	}, "\n")
+
+	result, err := uc.ImportCandlesCSV(context.Background(), strings.NewReader(csv), "BTCUSDT", "mexc", market.Interval1m)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Imported)
+	assert.Zero(t, result.Skipped)
+	assert.Empty(t, result.Errors)
+
+	candles := loadImportedCandles(t, uc)
+	require.Len(t, candles, 3)
+	assert.True(t, candles[0].OpenTime.Equal(time.UnixMilli(1700000000000)))
+	assert.True(t, candles[0].CloseTime.Equal(time.UnixMilli(1700000059999)))
+	assert.Equal(t, 105.0, candles[0].Close)
+	assert.Equal(t, 1300.0, candles[0].QuoteVolume)
+	assert.Equal(t, int64(42), candles[0].TradeCount)
+	assert.True(t, candles[2].OpenTime.Equal(time.Date(2023, 11, 14, 22, 15, 0, 0, time.UTC)))
+}
+
+func TestImportCandlesCSV_MalformedRow(t *testing.T) {
+	uc, _ := setupCandleImport(t)
+	csv := strings.Join([]string{
+		"open_time,open,high,low,close,volume",
+		"1700000000000,100,110,95,105,12.5",
+		"1700000060000,abc,108,101,102,8",
+		"1699999940000,99,101,98,100,3",
+		"1700000120000,102,104,100,103,4",
+	}, "\n")
+
+	result, err := uc.ImportCandlesCSV(context.Background(), strings.NewReader(csv), "BTCUSDT", "mexc", market.Interval1m)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 2, result.Skipped)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, 3, result.Errors[0].Line)
+	assert.Contains(t, result.Errors[0].Message, "invalid open")
+	assert.Equal(t, 4, result.Errors[1].Line)
+	assert.Contains(t, result.Errors[1].Message, "before the previous row")
+
+	assert.Len(t, loadImportedCandles(t, uc), 2)
+}
+
+func TestImportCandlesCSV_DuplicateRowsAreUpserted(t *testing.T) {
+	uc, db := setupCandleImport(t)
+	csv := strings.Join([]string{
+		"open_time,open,high,low,close,volume",
+		"1700000000000,100,110,95,105,12.5",
+		"1700000000000,100,112,95,107,13",
+		"1700000060000,105,108,101,102,8",
+	}, "\n")
+
+	result, err := uc.ImportCandlesCSV(context.Background(), strings.NewReader(csv), "BTCUSDT", "mexc", market.Interval1m)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 1, result.Duplicates)
+	assert.Zero(t, result.Skipped)
+
+	// Importing the same file again updates the stored rows instead of adding new ones
+	_, err = uc.ImportCandlesCSV(context.Background(), strings.NewReader(csv), "BTCUSDT", "mexc", market.Interval1m)
+	require.NoError(t, err)
+
+	var count int64
+	require.NoError(t, db.Model(&gormrepo.CandleEntity{}).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+
+	candles := loadImportedCandles(t, uc)
+	require.Len(t, candles, 2)
+	assert.Equal(t, 107.0, candles[0].Close, "the last duplicate row wins")
+}
+
+func TestImportCandlesCSV_MissingColumn(t *testing.T) {
+	uc, _ := setupCandleImport(t)
+
+	_, err := uc.ImportCandlesCSV(context.Background(), strings.NewReader("open_time,open,high,low,close\n"), "BTCUSDT", "mexc", market.Interval1m)
+	assert.ErrorContains(t, err, `missing required column "volume"`)
+}
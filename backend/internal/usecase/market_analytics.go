@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+)
+
+// vwapPageSize is the number of candles read per page while computing a VWAP
+const vwapPageSize = 1000
+
+// VWAPResult is the volume-weighted average price of a symbol over a time range
+type VWAPResult struct {
+	Symbol   string          `json:"symbol"`
+	Exchange string          `json:"exchange"`
+	Interval market.Interval `json:"interval"`
+	From     time.Time       `json:"from"`
+	To       time.Time       `json:"to"`
+	Candles  int             `json:"candles"`
+	Volume   float64         `json:"volume"`
+	VWAP     float64         `json:"vwap"`
+}
+
+// GetOrderBookMetrics computes spread and imbalance signals over the top depth levels of
+// the latest stored order book
+func (uc *MarketDataUseCase) GetOrderBookMetrics(ctx context.Context, exchange, symbol string, depth int) (*market.OrderBookStats, error) {
+	orderBook, err := uc.marketRepo.GetOrderBook(ctx, symbol, exchange, depth)
+	if err != nil {
+		return nil, err
+	}
+	return market.OrderBookMetrics(orderBook, depth)
+}
+
+// GetVWAP computes the VWAP of the stored candles opening between from and to. The
+// candles are streamed, so long ranges are not held in memory. A range without candles,
+// or whose candles carry no volume, is a not-found error.
+func (uc *MarketDataUseCase) GetVWAP(ctx context.Context, exchange, symbol string, interval market.Interval, from, to time.Time) (*VWAPResult, error) {
+	result := &VWAPResult{Symbol: symbol, Exchange: exchange, Interval: interval, From: from, To: to}
+
+	var priceVolume float64
+	err := uc.StreamCandles(ctx, exchange, symbol, interval, from, to, vwapPageSize, func(candles []*market.Candle) error {
+		for _, c := range candles {
+			priceVolume += market.TypicalPrice(c) * c.Volume
+			result.Volume += c.Volume
+		}
+		result.Candles += len(candles)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Volume == 0 {
+		what := "no candles"
+		if result.Candles > 0 {
+			what = "no traded volume"
+		}
+		return nil, apperror.NewNotFound(
+			fmt.Sprintf("VWAP: %s for %s %s between %s and %s", what, symbol, interval, from.Format(time.RFC3339), to.Format(time.RFC3339)),
+			nil, nil)
+	}
+
+	result.VWAP = priceVolume / result.Volume
+	return result, nil
+}
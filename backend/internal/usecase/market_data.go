@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
@@ -15,6 +16,9 @@ type MarketDataUseCase struct {
 	symbolRepo port.SymbolRepository
 	cache      port.MarketCache
 	logger     *zerolog.Logger
+	// klineHistory fetches missing candles from the exchange; backfilling is unavailable
+	// without it
+	klineHistory port.KlineHistoryProvider
 }
 
 // NewMarketDataUseCase creates a new MarketDataUseCase
@@ -32,6 +36,12 @@ func NewMarketDataUseCase(
 	}
 }
 
+// WithKlineHistory sets the exchange client BackfillCandles fetches missing candles from
+func (uc *MarketDataUseCase) WithKlineHistory(provider port.KlineHistoryProvider) *MarketDataUseCase {
+	uc.klineHistory = provider
+	return uc
+}
+
 // GetLatestTickers returns the latest tickers from cache or database
 func (uc *MarketDataUseCase) GetLatestTickers(ctx context.Context) ([]market.Ticker, error) {
 	// Try to get from cache first
@@ -194,6 +204,44 @@ func (uc *MarketDataUseCase) GetCandles(
 	return result, nil
 }
 
+// StreamCandles reads the candles opening between start and end from the database in
+// pages of pageSize, oldest first, and passes each page to fn. Only one page is held in
+// memory at a time. Streaming stops at the first error returned by fn.
+func (uc *MarketDataUseCase) StreamCandles(ctx context.Context, exchange, symbol string, interval market.Interval, start, end time.Time, pageSize int, fn func([]*market.Candle) error) error {
+	if pageSize <= 0 {
+		return fmt.Errorf("page size must be positive, got %d", pageSize)
+	}
+
+	next := start
+	pages := 0
+	for !next.After(end) {
+		page, err := uc.marketRepo.GetCandles(ctx, symbol, exchange, interval, next, end, pageSize)
+		if err != nil {
+			return err
+		}
+		if len(page) == 0 {
+			break
+		}
+		pages++
+
+		if err := fn(page); err != nil {
+			return err
+		}
+		if len(page) < pageSize {
+			break
+		}
+		next = page[len(page)-1].OpenTime.Add(time.Nanosecond)
+	}
+
+	uc.logger.Debug().
+		Str("exchange", exchange).
+		Str("symbol", symbol).
+		Str("interval", string(interval)).
+		Int("pages", pages).
+		Msg("Streamed candles from database")
+	return nil
+}
+
 // GetAllSymbols returns all available trading symbols
 func (uc *MarketDataUseCase) GetAllSymbols(ctx context.Context) ([]market.Symbol, error) {
 	// Try to get from cache first
@@ -217,6 +265,22 @@ func (uc *MarketDataUseCase) GetAllSymbols(ctx context.Context) ([]market.Symbol
 	return result, nil
 }
 
+// SearchSymbols returns symbols matching query, best matches first
+func (uc *MarketDataUseCase) SearchSymbols(ctx context.Context, query string, limit int) ([]market.Symbol, error) {
+	symbols, err := uc.marketRepo.SearchSymbols(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]market.Symbol, len(symbols))
+	for i, symbol := range symbols {
+		result[i] = *symbol
+	}
+
+	uc.logger.Debug().Str("query", query).Int("count", len(result)).Msg("Searched symbols")
+	return result, nil
+}
+
 // GetSymbolInfo returns detailed information for a specific trading symbol
 func (uc *MarketDataUseCase) GetSymbolInfo(ctx context.Context, symbol string) (*market.Symbol, error) {
 	// We'll fetch directly from the database since we don't have a specific cache method for symbols
@@ -70,6 +70,14 @@ func (m *MockMarketRepository) GetCandles(ctx context.Context, symbol, exchange
 	return args.Get(0).([]*market.Candle), args.Error(1)
 }
 
+func (m *MockMarketRepository) GetResampledCandles(ctx context.Context, symbol, exchange string, source, target market.Interval, start, end time.Time) ([]*market.Candle, error) {
+	args := m.Called(ctx, symbol, exchange, source, target, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*market.Candle), args.Error(1)
+}
+
 func (m *MockMarketRepository) GetLatestCandle(ctx context.Context, symbol, exchange string, interval market.Interval) (*market.Candle, error) {
 	args := m.Called(ctx, symbol, exchange, interval)
 	if args.Get(0) == nil {
@@ -105,6 +113,15 @@ func (m *MockMarketRepository) GetOrderBook(ctx context.Context, symbol, exchang
 	return args.Get(0).(*market.OrderBook), args.Error(1)
 }
 
+// SearchSymbols mocks the SearchSymbols method
+func (m *MockMarketRepository) SearchSymbols(ctx context.Context, query string, limit int) ([]*market.Symbol, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*market.Symbol), args.Error(1)
+}
+
 type MockSymbolRepository struct {
 	mock.Mock
 }
@@ -145,6 +162,29 @@ func (m *MockSymbolRepository) Delete(ctx context.Context, symbol string) error
 	return args.Error(0)
 }
 
+func (m *MockSymbolRepository) GetAllIncludingDeleted(ctx context.Context) ([]*market.Symbol, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*market.Symbol), args.Error(1)
+}
+
+func (m *MockSymbolRepository) Restore(ctx context.Context, symbol string) error {
+	args := m.Called(ctx, symbol)
+	return args.Error(0)
+}
+
+func (m *MockSymbolRepository) Upsert(ctx context.Context, symbol *market.Symbol) error {
+	args := m.Called(ctx, symbol)
+	return args.Error(0)
+}
+
+func (m *MockSymbolRepository) UpsertMany(ctx context.Context, symbols []*market.Symbol) (int, int, error) {
+	args := m.Called(ctx, symbols)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
 type MockMarketCache struct {
 	mock.Mock
 }
@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+)
+
+// syncExchange is the exchange market data is synced from
+const syncExchange = "mexc"
+
+// ErrSyncInProgress is returned when a sync is requested while another one is running
+var ErrSyncInProgress = &apperror.AppError{
+	StatusCode: http.StatusConflict,
+	Code:       "SYNC_IN_PROGRESS",
+	Message:    "A market data sync is already running",
+}
+
+// SymbolSyncResult summarizes a symbol sync
+type SymbolSyncResult struct {
+	Fetched int
+	Created int
+	Updated int
+	// StatusChanged counts the stored symbols whose trading status changed, including
+	// those marked delisted
+	StatusChanged int
+	Duration      time.Duration
+}
+
+// TickerSyncResult summarizes a ticker sync
+type TickerSyncResult struct {
+	Symbols  int
+	Saved    int
+	Failed   int
+	Duration time.Duration
+}
+
+// MarketSyncUseCase refreshes the stored symbols and tickers from the exchange on demand
+type MarketSyncUseCase struct {
+	client     port.MEXCClient
+	symbolRepo port.SymbolRepository
+	marketRepo port.MarketRepository
+	events     port.DomainEventPublisher
+	logger     *zerolog.Logger
+
+	// running guards against overlapping syncs
+	running sync.Mutex
+}
+
+// NewMarketSyncUseCase creates a MarketSyncUseCase
+func NewMarketSyncUseCase(client port.MEXCClient, symbolRepo port.SymbolRepository, marketRepo port.MarketRepository, logger *zerolog.Logger) *MarketSyncUseCase {
+	return &MarketSyncUseCase{
+		client:     client,
+		symbolRepo: symbolRepo,
+		marketRepo: marketRepo,
+		logger:     logger,
+	}
+}
+
+// WithEventPublisher makes symbol syncs publish a SymbolStatusChanged event for every
+// stored symbol whose trading status changed
+func (uc *MarketSyncUseCase) WithEventPublisher(events port.DomainEventPublisher) *MarketSyncUseCase {
+	uc.events = events
+	return uc
+}
+
+// SyncSymbols fetches the exchange's symbols and upserts them in one transaction. Stored
+// symbols the exchange no longer lists are marked delisted, and status changes of stored
+// symbols are published.
+func (uc *MarketSyncUseCase) SyncSymbols(ctx context.Context) (*SymbolSyncResult, error) {
+	if !uc.running.TryLock() {
+		return nil, ErrSyncInProgress
+	}
+	defer uc.running.Unlock()
+
+	started := time.Now()
+	info, err := uc.client.GetExchangeInfo(ctx)
+	if err != nil {
+		return nil, apperror.NewExternalService("MEXC", "Failed to get exchange info", err)
+	}
+
+	stored, err := uc.symbolRepo.GetByExchange(ctx, syncExchange)
+	if err != nil {
+		return nil, apperror.NewInternal(err)
+	}
+	previous := make(map[string]string, len(stored))
+	for _, symbol := range stored {
+		previous[symbol.Symbol] = symbol.Status
+	}
+
+	now := time.Now()
+	symbols := make([]*market.Symbol, 0, len(info.Symbols))
+	listed := make(map[string]bool, len(info.Symbols))
+	for _, s := range info.Symbols {
+		symbols = append(symbols, symbolFromInfo(s, now))
+		listed[s.Symbol] = true
+	}
+	fetched := len(symbols)
+	for _, symbol := range stored {
+		if listed[symbol.Symbol] || symbol.Status == string(model.SymbolStatusDelisted) {
+			continue
+		}
+		delisted := *symbol
+		delisted.Status = string(model.SymbolStatusDelisted)
+		delisted.UpdatedAt = now
+		symbols = append(symbols, &delisted)
+	}
+
+	created, updated, err := uc.symbolRepo.UpsertMany(ctx, symbols)
+	if err != nil {
+		return nil, apperror.NewInternal(err)
+	}
+
+	result := &SymbolSyncResult{
+		Fetched: fetched,
+		Created: created,
+		Updated: updated,
+	}
+	for _, symbol := range symbols {
+		oldStatus, ok := previous[symbol.Symbol]
+		if !ok || oldStatus == symbol.Status {
+			continue
+		}
+		result.StatusChanged++
+		uc.logger.Warn().
+			Str("symbol", symbol.Symbol).
+			Str("oldStatus", oldStatus).
+			Str("newStatus", symbol.Status).
+			Msg("Symbol status changed")
+		if uc.events != nil {
+			uc.events.PublishEvent(event.NewSymbolStatusChanged(symbol.Symbol, oldStatus, symbol.Status))
+		}
+	}
+
+	result.Duration = time.Since(started)
+	uc.logger.Info().
+		Int("fetched", result.Fetched).
+		Int("created", created).
+		Int("updated", updated).
+		Int("statusChanged", result.StatusChanged).
+		Dur("took", result.Duration).
+		Msg("Symbol sync completed")
+	return result, nil
+}
+
+// SyncTickers fetches a fresh ticker for every stored symbol that is trading and saves
+// it. A symbol whose ticker cannot be fetched or saved is counted as failed and skipped.
+// Tickers are fetched in one batch when the client supports it.
+func (uc *MarketSyncUseCase) SyncTickers(ctx context.Context) (*TickerSyncResult, error) {
+	if !uc.running.TryLock() {
+		return nil, ErrSyncInProgress
+	}
+	defer uc.running.Unlock()
+
+	started := time.Now()
+	symbols, err := uc.symbolRepo.GetByExchange(ctx, syncExchange)
+	if err != nil {
+		return nil, apperror.NewInternal(err)
+	}
+	trading := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		if symbol.Status == string(model.SymbolStatusTrading) {
+			trading = append(trading, symbol.Symbol)
+		}
+	}
+
+	tickers, err := uc.fetchTickers(ctx, trading)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TickerSyncResult{Symbols: len(trading), Failed: len(trading) - len(tickers)}
+	for _, ticker := range tickers {
+		if err := uc.marketRepo.SaveTicker(ctx, tickerFromModel(ticker)); err != nil {
+			uc.logger.Warn().Err(err).Str("symbol", ticker.Symbol).Msg("Failed to save ticker")
+			result.Failed++
+			continue
+		}
+		result.Saved++
+	}
+
+	result.Duration = time.Since(started)
+	uc.logger.Info().
+		Int("symbols", result.Symbols).
+		Int("saved", result.Saved).
+		Int("failed", result.Failed).
+		Dur("took", result.Duration).
+		Msg("Ticker sync completed")
+	return result, nil
+}
+
+// fetchTickers fetches the tickers of symbols, leaving out the ones that fail
+func (uc *MarketSyncUseCase) fetchTickers(ctx context.Context, symbols []string) ([]*model.Ticker, error) {
+	if batch, ok := uc.client.(port.TickerBatchProvider); ok && len(symbols) > 0 {
+		tickers, err := batch.GetTickers(ctx, symbols)
+		if err != nil {
+			return nil, apperror.NewExternalService("MEXC", "Failed to get tickers", err)
+		}
+		return tickers, nil
+	}
+
+	tickers := make([]*model.Ticker, 0, len(symbols))
+	for _, symbol := range symbols {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		ticker, err := uc.client.GetMarketData(ctx, symbol)
+		if err != nil {
+			uc.logger.Warn().Err(err).Str("symbol", symbol).Msg("Failed to fetch ticker")
+			continue
+		}
+		tickers = append(tickers, ticker)
+	}
+	return tickers, nil
+}
+
+// symbolFromInfo converts exchange symbol information for storage
+func symbolFromInfo(info model.SymbolInfo, now time.Time) *market.Symbol {
+	orderTypes := info.OrderTypes
+	if len(orderTypes) == 0 {
+		orderTypes = []string{"LIMIT", "MARKET"}
+	}
+	return &market.Symbol{
+		Symbol:            info.Symbol,
+		BaseAsset:         info.BaseAsset,
+		QuoteAsset:        info.QuoteAsset,
+		Status:            info.Status,
+		Exchange:          syncExchange,
+		PricePrecision:    info.PricePrecision,
+		QtyPrecision:      info.QuantityPrecision,
+		AllowedOrderTypes: orderTypes,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+}
+
+// tickerFromModel converts an exchange ticker for storage
+func tickerFromModel(t *model.Ticker) *market.Ticker {
+	updated := t.Timestamp
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+	return &market.Ticker{
+		Symbol:        t.Symbol,
+		Exchange:      syncExchange,
+		Price:         t.LastPrice,
+		Volume:        t.Volume,
+		High24h:       t.HighPrice,
+		Low24h:        t.LowPrice,
+		PriceChange:   t.PriceChange,
+		PercentChange: t.PriceChangePercent,
+		LastUpdated:   updated,
+	}
+}
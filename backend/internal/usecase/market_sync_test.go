@@ -0,0 +1,135 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/service"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/usecase"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listedExchange lists the configured symbols and fills every order placed
+type listedExchange struct {
+	port.MEXCClient
+	symbols []model.SymbolInfo
+	placed  int
+}
+
+func (e *listedExchange) GetExchangeInfo(ctx context.Context) (*model.ExchangeInfo, error) {
+	return &model.ExchangeInfo{Symbols: e.symbols}, nil
+}
+
+func (e *listedExchange) PlaceOrder(ctx context.Context, symbol string, side model.OrderSide, orderType model.OrderType, quantity float64, price float64, timeInForce model.TimeInForce) (*model.Order, error) {
+	e.placed++
+	return &model.Order{OrderID: "mexc-1", Symbol: symbol, Side: side, Type: orderType, Status: model.OrderStatusFilled, Quantity: quantity}, nil
+}
+
+// storedSymbols keeps symbols in memory by name
+type storedSymbols struct {
+	port.SymbolRepository
+	symbols map[string]*market.Symbol
+}
+
+func (s *storedSymbols) UpsertMany(ctx context.Context, symbols []*market.Symbol) (int, int, error) {
+	created := 0
+	for _, symbol := range symbols {
+		if _, ok := s.symbols[symbol.Symbol]; !ok {
+			created++
+		}
+		stored := *symbol
+		s.symbols[symbol.Symbol] = &stored
+	}
+	return created, len(symbols) - created, nil
+}
+
+func (s *storedSymbols) GetByExchange(ctx context.Context, exchange string) ([]*market.Symbol, error) {
+	var symbols []*market.Symbol
+	for _, symbol := range s.symbols {
+		stored := *symbol
+		symbols = append(symbols, &stored)
+	}
+	return symbols, nil
+}
+
+func (s *storedSymbols) GetBySymbol(ctx context.Context, symbol string) (*market.Symbol, error) {
+	if stored, ok := s.symbols[symbol]; ok {
+		return stored, nil
+	}
+	return nil, errors.New("symbol not found")
+}
+
+// discardedOrders stores nothing
+type discardedOrders struct {
+	port.OrderRepository
+}
+
+func (discardedOrders) Create(ctx context.Context, order *model.Order) error { return nil }
+
+// recordedEvents records every published event
+type recordedEvents struct {
+	events []event.DomainEvent
+}
+
+func (r *recordedEvents) PublishEvent(evt event.DomainEvent) {
+	r.events = append(r.events, evt)
+}
+
+func TestMarketSyncUseCase_BreakPublishesEventAndBlocksOrders(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+	exchange := &listedExchange{symbols: []model.SymbolInfo{
+		{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT", Status: "TRADING"},
+		{Symbol: "OLDUSDT", BaseAsset: "OLD", QuoteAsset: "USDT", Status: "TRADING"},
+	}}
+	symbols := &storedSymbols{symbols: map[string]*market.Symbol{}}
+	events := &recordedEvents{}
+	uc := usecase.NewMarketSyncUseCase(exchange, symbols, nil, &logger).WithEventPublisher(events)
+	trades := service.NewMexcTradeService(exchange, nil, symbols, discardedOrders{}, &logger).
+		WithTradeGuard(service.NewTradeGuard(nil, nil, 0, nil))
+	buy := &model.OrderRequest{Symbol: "BTCUSDT", Side: model.OrderSideBuy, Type: model.OrderTypeMarket, Quantity: 0.5}
+
+	result, err := uc.SyncSymbols(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.StatusChanged, "new symbols have no previous status")
+	assert.Empty(t, events.events)
+	_, err = trades.PlaceOrder(ctx, buy)
+	require.NoError(t, err)
+
+	// BTCUSDT enters a break and OLDUSDT is no longer listed
+	exchange.symbols = []model.SymbolInfo{{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT", Status: "BREAK"}}
+	result, err = uc.SyncSymbols(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Fetched)
+	assert.Equal(t, 2, result.StatusChanged)
+	assert.Equal(t, string(model.SymbolStatusBreak), symbols.symbols["BTCUSDT"].Status)
+	assert.Equal(t, string(model.SymbolStatusDelisted), symbols.symbols["OLDUSDT"].Status)
+
+	require.Len(t, events.events, 2)
+	changed, ok := events.events[0].(*event.SymbolStatusChanged)
+	require.True(t, ok)
+	assert.Equal(t, event.SymbolStatusChangedEvent, changed.Type())
+	assert.Equal(t, "BTCUSDT", changed.Symbol)
+	assert.Equal(t, "TRADING", changed.OldStatus)
+	assert.Equal(t, "BREAK", changed.NewStatus)
+
+	_, err = trades.PlaceOrder(ctx, buy)
+	var guardErr *service.TradeGuardError
+	require.ErrorAs(t, err, &guardErr)
+	assert.Equal(t, service.GuardReasonNotTrading, guardErr.Reason)
+	assert.Equal(t, "BREAK", guardErr.Status)
+	assert.Equal(t, 1, exchange.placed, "the order on the symbol in a break never reaches the exchange")
+
+	// Later syncs do not report the same change again
+	result, err = uc.SyncSymbols(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.StatusChanged)
+	assert.Len(t, events.events, 2)
+}
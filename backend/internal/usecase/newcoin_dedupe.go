@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+)
+
+// listingDeduper remembers the last status published for each listing, so polling the
+// same listing repeatedly emits a NewCoinEvent only once per status change. It holds one
+// entry per symbol however often the status changes.
+type listingDeduper struct {
+	mu        sync.Mutex
+	published map[string]model.Status
+}
+
+// newListingDeduper creates an empty listingDeduper
+func newListingDeduper() *listingDeduper {
+	return &listingDeduper{published: make(map[string]model.Status)}
+}
+
+// claim reports whether status differs from the last status published for symbol and
+// records it as published
+func (d *listingDeduper) claim(symbol string, status model.Status) bool {
+	key := strings.ToUpper(symbol)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if last, ok := d.published[key]; ok && last == status {
+		return false
+	}
+	d.published[key] = status
+	return true
+}
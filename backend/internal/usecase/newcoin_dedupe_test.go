@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// NDListingClient serves a fixed set of listings from GetNewListings
+type NDListingClient struct {
+	port.MEXCClient
+	listings []*model.NewCoin
+}
+
+func (c *NDListingClient) GetNewListings(ctx context.Context) ([]*model.NewCoin, error) {
+	// Return copies so the use case cannot mutate the served listings
+	coins := make([]*model.NewCoin, 0, len(c.listings))
+	for _, listing := range c.listings {
+		coin := *listing
+		coins = append(coins, &coin)
+	}
+	return coins, nil
+}
+
+// NDNewCoinRepository keeps coins in memory keyed by symbol
+type NDNewCoinRepository struct {
+	port.NewCoinRepository
+	coins map[string]model.NewCoin
+}
+
+func (r *NDNewCoinRepository) GetBySymbol(ctx context.Context, symbol string) (*model.NewCoin, error) {
+	coin, ok := r.coins[symbol]
+	if !ok {
+		return nil, nil
+	}
+	return &coin, nil
+}
+
+func (r *NDNewCoinRepository) Save(ctx context.Context, coin *model.NewCoin) error {
+	r.coins[coin.Symbol] = *coin
+	return nil
+}
+
+func (r *NDNewCoinRepository) Update(ctx context.Context, coin *model.NewCoin) error {
+	r.coins[coin.Symbol] = *coin
+	return nil
+}
+
+// NDEventRepository discards saved events
+type NDEventRepository struct {
+	port.EventRepository
+}
+
+func (r *NDEventRepository) SaveEvent(ctx context.Context, event *model.NewCoinEvent) error {
+	return nil
+}
+
+// NDEventBus records published events
+type NDEventBus struct {
+	port.EventBus
+	mu     sync.Mutex
+	events []*model.NewCoinEvent
+}
+
+func (b *NDEventBus) Publish(event *model.NewCoinEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events = append(b.events, event)
+}
+
+// NDDomainEvents records published domain events
+type NDDomainEvents struct {
+	events []event.DomainEvent
+}
+
+func (e *NDDomainEvents) PublishEvent(evt event.DomainEvent) {
+	e.events = append(e.events, evt)
+}
+
+func newDedupeTestUseCase(t *testing.T, listings ...*model.NewCoin) (*NewCoinUseCase, *NDListingClient, *NDNewCoinRepository, *NDEventBus) {
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	client := &NDListingClient{listings: listings}
+	repo := &NDNewCoinRepository{coins: map[string]model.NewCoin{}}
+	bus := &NDEventBus{}
+	return NewNewCoinUseCase(repo, &NDEventRepository{}, bus, client, &logger), client, repo, bus
+}
+
+func TestNewCoinUseCase_DetectNewCoins_RepeatedPollEmitsOnce(t *testing.T) {
+	uc, _, _, bus := newDedupeTestUseCase(t, &model.NewCoin{Symbol: "NEWUSDT", Status: model.StatusExpected})
+
+	require.NoError(t, uc.DetectNewCoins())
+	require.NoError(t, uc.DetectNewCoins())
+
+	require.Len(t, bus.events, 1)
+	assert.Equal(t, "new_coin_detected", bus.events[0].EventType)
+	assert.Equal(t, model.StatusExpected, bus.events[0].NewStatus)
+}
+
+func TestNewCoinUseCase_DetectNewCoins_StatusChangeEmitsNewEvent(t *testing.T) {
+	uc, client, repo, bus := newDedupeTestUseCase(t, &model.NewCoin{Symbol: "NEWUSDT", Status: model.StatusExpected})
+
+	require.NoError(t, uc.DetectNewCoins())
+
+	client.listings[0].Status = model.StatusTrading
+	require.NoError(t, uc.DetectNewCoins())
+	require.NoError(t, uc.DetectNewCoins())
+
+	require.Len(t, bus.events, 2)
+	assert.Equal(t, "status_changed", bus.events[1].EventType)
+	assert.Equal(t, model.StatusExpected, bus.events[1].OldStatus)
+	assert.Equal(t, model.StatusTrading, bus.events[1].NewStatus)
+	assert.Equal(t, model.StatusTrading, repo.coins["NEWUSDT"].Status)
+	assert.NotNil(t, repo.coins["NEWUSDT"].BecameTradableAt)
+}
+
+func TestNewCoinUseCase_DetectNewCoins_FlappingListingIsRepublished(t *testing.T) {
+	uc, client, repo, bus := newDedupeTestUseCase(t, &model.NewCoin{Symbol: "NEWUSDT", Status: model.StatusTrading})
+
+	// Stored state says expected, so the poll is a real EXPECTED->TRADING transition
+	repo.coins["NEWUSDT"] = model.NewCoin{ID: "coin-1", Symbol: "NEWUSDT", Status: model.StatusExpected}
+	require.NoError(t, uc.DetectNewCoins())
+	require.NoError(t, uc.DetectNewCoins())
+	require.Len(t, bus.events, 1)
+
+	// The listing flaps back and forth; each change of the published status is announced
+	client.listings[0].Status = model.StatusExpected
+	require.NoError(t, uc.DetectNewCoins())
+	client.listings[0].Status = model.StatusTrading
+	require.NoError(t, uc.DetectNewCoins())
+
+	require.Len(t, bus.events, 3)
+	assert.Equal(t, model.StatusExpected, bus.events[1].NewStatus)
+	assert.Equal(t, model.StatusExpected, bus.events[2].OldStatus)
+	assert.Equal(t, model.StatusTrading, bus.events[2].NewStatus)
+	assert.Equal(t, model.StatusTrading, repo.coins["NEWUSDT"].Status)
+}
+
+func TestListingDeduper_ClaimsEachStatusChangeOnce(t *testing.T) {
+	d := newListingDeduper()
+
+	assert.True(t, d.claim("newusdt", model.StatusExpected))
+	assert.False(t, d.claim("NEWUSDT", model.StatusExpected))
+	assert.True(t, d.claim("NEWUSDT", model.StatusTrading))
+	assert.False(t, d.claim("NEWUSDT", model.StatusTrading))
+
+	// A listing flapping back is published again, and only one entry is kept per symbol
+	assert.True(t, d.claim("NEWUSDT", model.StatusExpected))
+	assert.True(t, d.claim("NEWUSDT", model.StatusTrading))
+	assert.Len(t, d.published, 1)
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+)
+
+// DefaultTradableReplayWindow is how far back the tradable relay replays listing events
+// on start. Coins that started trading earlier are no longer new listings.
+const DefaultTradableReplayWindow = time.Hour
+
+// TradableRelay announces the coins that start trading as NewCoinTradable domain events,
+// which is what the autobuyer listens for. It follows the listing events of the event
+// log and replays the recent ones on start, so a coin that started trading while nothing
+// was subscribed, such as just before a restart, is still announced.
+type TradableRelay struct {
+	events port.ReplayableEventBus
+	coins  port.NewCoinRepository
+	domain port.DomainEventPublisher
+	window time.Duration
+	logger *zerolog.Logger
+	now    func() time.Time
+
+	mu       sync.Mutex
+	listener func(*model.NewCoinEvent)
+}
+
+// NewTradableRelay creates a relay replaying the listing events of the last window. A
+// non-positive window uses DefaultTradableReplayWindow.
+func NewTradableRelay(events port.ReplayableEventBus, coins port.NewCoinRepository, domain port.DomainEventPublisher, window time.Duration, logger *zerolog.Logger) *TradableRelay {
+	if window <= 0 {
+		window = DefaultTradableReplayWindow
+	}
+	return &TradableRelay{
+		events: events,
+		coins:  coins,
+		domain: domain,
+		window: window,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Start replays the recorded listing events of the last window and keeps relaying new
+// ones until Stop is called
+func (r *TradableRelay) Start(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.listener != nil {
+		return fmt.Errorf("tradable relay is already running")
+	}
+
+	since := r.now().Add(-r.window)
+	listener := func(evt *model.NewCoinEvent) {
+		r.relay(ctx, evt, since)
+	}
+	if err := r.events.SubscribeFrom(ctx, 0, listener); err != nil {
+		return fmt.Errorf("failed to replay listing events: %w", err)
+	}
+	r.listener = listener
+
+	r.logger.Info().Dur("window", r.window).Msg("Tradable relay started")
+	return nil
+}
+
+// Stop stops relaying listing events
+func (r *TradableRelay) Stop() {
+	r.mu.Lock()
+	listener := r.listener
+	r.listener = nil
+	r.mu.Unlock()
+
+	if listener != nil {
+		r.events.Unsubscribe(listener)
+		r.logger.Info().Msg("Tradable relay stopped")
+	}
+}
+
+// relay publishes NewCoinTradable for a listing event moving a coin into trading after since
+func (r *TradableRelay) relay(ctx context.Context, evt *model.NewCoinEvent, since time.Time) {
+	if evt.NewStatus != model.StatusTrading || evt.OldStatus == model.StatusTrading || evt.CreatedAt.Before(since) {
+		return
+	}
+
+	symbol := evt.Symbol()
+	if symbol == "" {
+		r.logger.Warn().Str("event_id", evt.ID).Msg("Listing event names no symbol")
+		return
+	}
+	coin, err := r.coins.GetBySymbol(ctx, symbol)
+	if err != nil {
+		r.logger.Error().Err(err).Str("symbol", symbol).Msg("Failed to look up tradable coin")
+		return
+	}
+	if coin == nil {
+		r.logger.Warn().Str("symbol", symbol).Msg("Tradable coin not found")
+		return
+	}
+
+	r.domain.PublishEvent(event.NewNewCoinTradable(coin, nil, nil))
+}
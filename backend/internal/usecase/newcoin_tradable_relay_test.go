@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TRReplayBus keeps every published event and delivers it to the listeners synchronously
+type TRReplayBus struct {
+	port.EventBus
+	mu        sync.Mutex
+	stored    []*model.NewCoinEvent
+	listeners []func(*model.NewCoinEvent)
+}
+
+func (b *TRReplayBus) Publish(evt *model.NewCoinEvent) {
+	b.mu.Lock()
+	b.stored = append(b.stored, evt)
+	listeners := append([]func(*model.NewCoinEvent){}, b.listeners...)
+	b.mu.Unlock()
+	for _, listener := range listeners {
+		listener(evt)
+	}
+}
+
+func (b *TRReplayBus) SubscribeFrom(ctx context.Context, offset int64, listener func(*model.NewCoinEvent)) error {
+	b.mu.Lock()
+	stored := append([]*model.NewCoinEvent{}, b.stored[offset:]...)
+	b.listeners = append(b.listeners, listener)
+	b.mu.Unlock()
+	for _, evt := range stored {
+		listener(evt)
+	}
+	return nil
+}
+
+func (b *TRReplayBus) Unsubscribe(listener func(*model.NewCoinEvent)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.listeners = nil
+}
+
+func tradableSymbols(events []event.DomainEvent) []string {
+	symbols := make([]string, 0, len(events))
+	for _, evt := range events {
+		if tradable, ok := evt.(*event.NewCoinTradable); ok {
+			symbols = append(symbols, tradable.Symbol)
+		}
+	}
+	return symbols
+}
+
+func TestTradableRelay_ReplaysRecentTradableListings(t *testing.T) {
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	coins := &NDNewCoinRepository{coins: map[string]model.NewCoin{}}
+	for _, symbol := range []string{"OLDUSDT", "NEWUSDT", "SOONUSDT", "LIVEUSDT"} {
+		coins.coins[symbol] = model.NewCoin{Symbol: symbol, Status: model.StatusTrading}
+	}
+
+	bus := &TRReplayBus{}
+	bus.Publish(&model.NewCoinEvent{ID: "1", NewStatus: model.StatusTrading, Data: model.NewCoinEventData("OLDUSDT"), CreatedAt: now.Add(-2 * time.Hour)})
+	bus.Publish(&model.NewCoinEvent{ID: "2", OldStatus: model.StatusExpected, NewStatus: model.StatusTrading, Data: model.NewCoinEventData("NEWUSDT"), CreatedAt: now.Add(-10 * time.Minute)})
+	bus.Publish(&model.NewCoinEvent{ID: "3", NewStatus: model.StatusExpected, Data: model.NewCoinEventData("SOONUSDT"), CreatedAt: now.Add(-5 * time.Minute)})
+	bus.Publish(&model.NewCoinEvent{ID: "4", NewStatus: model.StatusTrading, CreatedAt: now.Add(-time.Minute)})
+
+	domain := &NDDomainEvents{}
+	relay := NewTradableRelay(bus, coins, domain, time.Hour, &logger)
+	relay.now = func() time.Time { return now }
+
+	// Only the recorded listing that started trading within the window is replayed
+	require.NoError(t, relay.Start(context.Background()))
+	assert.Equal(t, []string{"NEWUSDT"}, tradableSymbols(domain.events))
+	assert.Error(t, relay.Start(context.Background()))
+
+	// New listings are relayed as they are published, until the relay stops
+	bus.Publish(&model.NewCoinEvent{ID: "5", OldStatus: model.StatusListed, NewStatus: model.StatusTrading, Data: model.NewCoinEventData("LIVEUSDT"), CreatedAt: now})
+	relay.Stop()
+	bus.Publish(&model.NewCoinEvent{ID: "6", NewStatus: model.StatusTrading, Data: model.NewCoinEventData("LIVEUSDT"), CreatedAt: now})
+	assert.Equal(t, []string{"NEWUSDT", "LIVEUSDT"}, tradableSymbols(domain.events))
+}
+
+func TestTradableRelay_DetectedListingIsAnnouncedOnce(t *testing.T) {
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	client := &NDListingClient{listings: []*model.NewCoin{{Symbol: "NEWUSDT", Status: model.StatusExpected}}}
+	coins := &NDNewCoinRepository{coins: map[string]model.NewCoin{}}
+	bus := &TRReplayBus{}
+	uc := NewNewCoinUseCase(coins, &NDEventRepository{}, bus, client, &logger)
+
+	domain := &NDDomainEvents{}
+	relay := NewTradableRelay(bus, coins, domain, time.Hour, &logger)
+	require.NoError(t, relay.Start(context.Background()))
+	defer relay.Stop()
+
+	require.NoError(t, uc.DetectNewCoins())
+	assert.Empty(t, domain.events)
+
+	client.listings[0].Status = model.StatusTrading
+	require.NoError(t, uc.DetectNewCoins())
+	require.NoError(t, uc.DetectNewCoins())
+
+	require.Len(t, domain.events, 1)
+	assert.Equal(t, []string{"NEWUSDT"}, tradableSymbols(domain.events))
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
@@ -18,6 +19,10 @@ type NewCoinUseCase struct {
 	eventBus  port.EventBus // Added EventBus
 	mexc      port.MEXCClient
 	logger    *zerolog.Logger
+
+	// detectMu serializes polls so concurrent detections cannot both publish a transition
+	detectMu sync.Mutex
+	dedupe   *listingDeduper
 }
 
 // NewNewCoinUseCase creates a new NewCoinUseCase instance
@@ -28,11 +33,17 @@ func NewNewCoinUseCase(repo port.NewCoinRepository, eventRepo port.EventReposito
 		eventBus:  eventBus, // Initialize EventBus
 		mexc:      mexc,
 		logger:    logger,
+		dedupe:    newListingDeduper(),
 	}
 }
 
-// DetectNewCoins checks for newly listed coins on MEXC
+// DetectNewCoins checks for newly listed coins on MEXC. The stored coin is the prior state:
+// an event is emitted only for coins not seen before and for actual status changes, and
+// each symbol status transition is published at most once.
 func (uc *NewCoinUseCase) DetectNewCoins() error {
+	uc.detectMu.Lock()
+	defer uc.detectMu.Unlock()
+
 	ctx := context.Background()
 	coins, err := uc.mexc.GetNewListings(ctx)
 	if err != nil {
@@ -53,6 +64,10 @@ func (uc *NewCoinUseCase) DetectNewCoins() error {
 				uc.logger.Error().Err(err).Str("symbol", coin.Symbol).Msg("Failed to create new coin")
 				continue
 			}
+			if !uc.dedupe.claim(coin.Symbol, coin.Status) {
+				uc.logger.Debug().Str("symbol", coin.Symbol).Msg("Skipping already published listing")
+				continue
+			}
 
 			// Create and emit event
 			event := &model.NewCoinEvent{
@@ -60,6 +75,7 @@ func (uc *NewCoinUseCase) DetectNewCoins() error {
 				CoinID:    coin.ID,
 				EventType: "new_coin_detected",
 				NewStatus: coin.Status,
+				Data:      model.NewCoinEventData(coin.Symbol),
 				CreatedAt: time.Now(),
 			}
 			if err := uc.eventRepo.SaveEvent(ctx, event); err != nil { // Use eventRepo.SaveEvent
@@ -82,6 +98,10 @@ func (uc *NewCoinUseCase) DetectNewCoins() error {
 					uc.logger.Error().Err(err).Str("symbol", coin.Symbol).Msg("Failed to update coin")
 					continue
 				}
+				if !uc.dedupe.claim(coin.Symbol, coin.Status) {
+					uc.logger.Debug().Str("symbol", coin.Symbol).Msg("Skipping already published status change")
+					continue
+				}
 
 				// Create and emit status change event
 				event := &model.NewCoinEvent{
@@ -90,6 +110,7 @@ func (uc *NewCoinUseCase) DetectNewCoins() error {
 					EventType: "status_changed",
 					OldStatus: oldStatus,
 					NewStatus: coin.Status,
+					Data:      model.NewCoinEventData(coin.Symbol),
 					CreatedAt: time.Now(),
 				}
 				if err := uc.eventRepo.SaveEvent(ctx, event); err != nil { // Use eventRepo.SaveEvent
@@ -133,6 +154,7 @@ func (uc *NewCoinUseCase) UpdateCoinStatus(coinID string, newStatus model.Status
 		EventType: "status_changed",
 		OldStatus: oldStatus,
 		NewStatus: newStatus,
+		Data:      model.NewCoinEventData(coin.Symbol),
 		CreatedAt: time.Now(),
 	}
 	if err := uc.eventRepo.SaveEvent(ctx, event); err != nil { // Use eventRepo.SaveEvent
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// OHOrderRepository serves orders sorted newest first and records the requested user and limit
+type OHOrderRepository struct {
+	port.OrderRepository
+	orders    []*model.Order
+	lastUser  string
+	lastLimit int
+}
+
+func (r *OHOrderRepository) GetBySymbolBefore(ctx context.Context, userID, symbol string, cursor *model.OrderCursor, limit int) ([]*model.Order, error) {
+	r.lastUser = userID
+	r.lastLimit = limit
+	var result []*model.Order
+	for _, order := range r.orders {
+		if cursor != nil && !order.CreatedAt.Before(cursor.CreatedAt) {
+			continue
+		}
+		if len(result) < limit {
+			result = append(result, order)
+		}
+	}
+	return result, nil
+}
+
+func TestTradeUseCase_GetOrderHistoryPage(t *testing.T) {
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	repo := &OHOrderRepository{}
+	for i := 4; i >= 0; i-- {
+		repo.orders = append(repo.orders, &model.Order{ID: string(rune('a' + i)), CreatedAt: base.Add(time.Duration(i) * time.Minute)})
+	}
+	uc := NewTradeUseCase(nil, repo, nil, nil, nil, nil, zerolog.Nop())
+
+	first, err := uc.GetOrderHistoryPage(context.Background(), "alice", "BTCUSDT", "", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", repo.lastUser)
+	assert.Equal(t, 3, repo.lastLimit)
+	require.Len(t, first.Orders, 2)
+	assert.Equal(t, "e", first.Orders[0].ID)
+	require.NotEmpty(t, first.NextCursor)
+
+	second, err := uc.GetOrderHistoryPage(context.Background(), "alice", "BTCUSDT", first.NextCursor, 3)
+	require.NoError(t, err)
+	assert.Len(t, second.Orders, 3)
+	assert.Equal(t, "c", second.Orders[0].ID)
+	assert.Empty(t, second.NextCursor)
+
+	_, err = uc.GetOrderHistoryPage(context.Background(), "alice", "BTCUSDT", "%%%", 2)
+	assert.ErrorIs(t, err, model.ErrInvalidOrderCursor)
+}
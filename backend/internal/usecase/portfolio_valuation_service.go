@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+)
+
+// PortfolioValuationService values a user's wallet holdings in a single quote currency
+type PortfolioValuationService interface {
+	// ValuePortfolio aggregates the balances of all of the user's wallets and prices them
+	// in the configured quote currency. Assets without a price route are reported in
+	// Unpriced and excluded from the total.
+	ValuePortfolio(ctx context.Context, userID string) (*model.PortfolioValuation, error)
+}
+
+// portfolioValuationService implements PortfolioValuationService
+type portfolioValuationService struct {
+	walletRepo    port.WalletRepository
+	marketRepo    port.MarketRepository
+	exchange      string
+	quoteCurrency string
+	bridgeAssets  []string
+	logger        *zerolog.Logger
+}
+
+// NewPortfolioValuationService creates a new PortfolioValuationService. bridgeAssets are
+// tried in order when an asset has no direct pair with the quote currency.
+func NewPortfolioValuationService(
+	walletRepo port.WalletRepository,
+	marketRepo port.MarketRepository,
+	exchange string,
+	quoteCurrency string,
+	bridgeAssets []string,
+	logger *zerolog.Logger,
+) PortfolioValuationService {
+	bridges := make([]string, 0, len(bridgeAssets))
+	for _, asset := range bridgeAssets {
+		bridges = append(bridges, strings.ToUpper(asset))
+	}
+
+	return &portfolioValuationService{
+		walletRepo:    walletRepo,
+		marketRepo:    marketRepo,
+		exchange:      exchange,
+		quoteCurrency: strings.ToUpper(quoteCurrency),
+		bridgeAssets:  bridges,
+		logger:        logger,
+	}
+}
+
+// ValuePortfolio implements PortfolioValuationService
+func (s *portfolioValuationService) ValuePortfolio(ctx context.Context, userID string) (*model.PortfolioValuation, error) {
+	wallets, err := s.walletRepo.GetWalletsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallets: %w", err)
+	}
+
+	tickers, err := s.marketRepo.GetAllTickers(ctx, s.exchange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tickers: %w", err)
+	}
+
+	prices := make(map[string]float64, len(tickers))
+	for _, ticker := range tickers {
+		if ticker != nil && ticker.Price > 0 {
+			prices[strings.ToUpper(ticker.Symbol)] = ticker.Price
+		}
+	}
+
+	valuation := &model.PortfolioValuation{
+		UserID:        userID,
+		QuoteCurrency: s.quoteCurrency,
+		Assets:        []model.AssetValuation{},
+		Unpriced:      []model.UnpricedAsset{},
+		ValuedAt:      time.Now(),
+	}
+
+	for asset, quantity := range aggregateHoldings(wallets) {
+		price, route, ok := s.priceInQuote(string(asset), prices)
+		if !ok {
+			valuation.Unpriced = append(valuation.Unpriced, model.UnpricedAsset{
+				Asset:    asset,
+				Quantity: quantity,
+				Reason:   fmt.Sprintf("no price route to %s", s.quoteCurrency),
+			})
+			continue
+		}
+
+		value := quantity * price
+		valuation.Assets = append(valuation.Assets, model.AssetValuation{
+			Asset:    asset,
+			Quantity: quantity,
+			Price:    price,
+			Value:    value,
+			Route:    route,
+		})
+		valuation.TotalValue += value
+	}
+
+	sort.Slice(valuation.Assets, func(i, j int) bool {
+		return valuation.Assets[i].Value > valuation.Assets[j].Value
+	})
+	sort.Slice(valuation.Unpriced, func(i, j int) bool {
+		return valuation.Unpriced[i].Asset < valuation.Unpriced[j].Asset
+	})
+
+	s.logger.Debug().
+		Str("userID", userID).
+		Str("quote", s.quoteCurrency).
+		Float64("total", valuation.TotalValue).
+		Int("unpriced", len(valuation.Unpriced)).
+		Msg("Valued portfolio")
+
+	return valuation, nil
+}
+
+// priceInQuote returns the price of one unit of asset in the quote currency and the
+// trading pairs used to derive it. A direct pair is preferred; otherwise the asset is
+// routed through the first bridge asset that has pairs on both legs.
+func (s *portfolioValuationService) priceInQuote(asset string, prices map[string]float64) (float64, []string, bool) {
+	asset = strings.ToUpper(asset)
+	if asset == s.quoteCurrency {
+		return 1, []string{}, true
+	}
+
+	if price, symbol, ok := pairPrice(asset, s.quoteCurrency, prices); ok {
+		return price, []string{symbol}, true
+	}
+
+	for _, bridge := range s.bridgeAssets {
+		if bridge == asset || bridge == s.quoteCurrency {
+			continue
+		}
+		first, firstSymbol, ok := pairPrice(asset, bridge, prices)
+		if !ok {
+			continue
+		}
+		second, secondSymbol, ok := pairPrice(bridge, s.quoteCurrency, prices)
+		if !ok {
+			continue
+		}
+		return first * second, []string{firstSymbol, secondSymbol}, true
+	}
+
+	return 0, nil, false
+}
+
+// pairPrice returns the price of base in quote using either the BASEQUOTE pair or the
+// inverse of the QUOTEBASE pair
+func pairPrice(base, quote string, prices map[string]float64) (float64, string, bool) {
+	if price, ok := prices[base+quote]; ok {
+		return price, base + quote, true
+	}
+	if price, ok := prices[quote+base]; ok {
+		return 1 / price, quote + base, true
+	}
+	return 0, "", false
+}
+
+// aggregateHoldings sums the non-zero balances of each asset across wallets
+func aggregateHoldings(wallets []*model.Wallet) map[model.Asset]float64 {
+	holdings := make(map[model.Asset]float64)
+	for _, wallet := range wallets {
+		if wallet == nil {
+			continue
+		}
+		for asset, amount := range snapshotBalances(wallet.Balances) {
+			if amount > 0 {
+				holdings[model.Asset(strings.ToUpper(string(asset)))] += amount
+			}
+		}
+	}
+	return holdings
+}
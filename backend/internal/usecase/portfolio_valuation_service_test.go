@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/market"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// PVMockMarketRepository mocks the ticker lookups used by portfolio valuation
+type PVMockMarketRepository struct {
+	port.MarketRepository
+	mock.Mock
+}
+
+func (m *PVMockMarketRepository) GetAllTickers(ctx context.Context, exchange string) ([]*market.Ticker, error) {
+	args := m.Called(ctx, exchange)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*market.Ticker), args.Error(1)
+}
+
+func newTestPortfolioValuationService(t *testing.T, wallets []*model.Wallet, tickers []*market.Ticker) PortfolioValuationService {
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	walletRepo := new(MockWalletRepository)
+	marketRepo := new(PVMockMarketRepository)
+
+	walletRepo.On("GetWalletsByUserID", mock.Anything, "user123").Return(wallets, nil)
+	marketRepo.On("GetAllTickers", mock.Anything, "mexc").Return(tickers, nil)
+
+	return NewPortfolioValuationService(walletRepo, marketRepo, "mexc", "USDT", []string{"BTC", "ETH"}, &logger)
+}
+
+func TestValuePortfolio(t *testing.T) {
+	wallets := []*model.Wallet{
+		{
+			ID: "exchange",
+			Balances: map[model.Asset]*model.Balance{
+				model.AssetBTC:  {Asset: model.AssetBTC, Free: 0.5, Total: 0.5},
+				model.AssetUSDT: {Asset: model.AssetUSDT, Free: 100, Total: 100},
+				"ALT":           {Asset: "ALT", Free: 1000, Total: 1000},
+			},
+		},
+		{
+			ID: "web3",
+			Balances: map[model.Asset]*model.Balance{
+				model.AssetBTC: {Asset: model.AssetBTC, Free: 0.25, Locked: 0.25},
+				"DUST":         {Asset: "DUST", Free: 42, Total: 42},
+			},
+		},
+	}
+	tickers := []*market.Ticker{
+		{Symbol: "BTCUSDT", Price: 30000},
+		{Symbol: "ALTBTC", Price: 0.00001},
+		{Symbol: "ETHUSDT", Price: 2000},
+	}
+	service := newTestPortfolioValuationService(t, wallets, tickers)
+
+	valuation, err := service.ValuePortfolio(context.Background(), "user123")
+	require.NoError(t, err)
+
+	assert.Equal(t, "USDT", valuation.QuoteCurrency)
+	require.Len(t, valuation.Assets, 3)
+	byAsset := make(map[model.Asset]model.AssetValuation)
+	for _, asset := range valuation.Assets {
+		byAsset[asset.Asset] = asset
+	}
+
+	// Direct pair, quantities summed across wallets
+	btc := byAsset[model.AssetBTC]
+	assert.InDelta(t, 1.0, btc.Quantity, 1e-9)
+	assert.InDelta(t, 30000.0, btc.Value, 1e-6)
+	assert.Equal(t, []string{"BTCUSDT"}, btc.Route)
+
+	// Routed ALT -> BTC -> USDT
+	alt := byAsset["ALT"]
+	assert.InDelta(t, 0.3, alt.Price, 1e-9)
+	assert.InDelta(t, 300.0, alt.Value, 1e-6)
+	assert.Equal(t, []string{"ALTBTC", "BTCUSDT"}, alt.Route)
+
+	// Quote currency itself
+	assert.InDelta(t, 100.0, byAsset[model.AssetUSDT].Value, 1e-9)
+
+	// Unpriceable asset is reported separately and excluded from the total
+	require.Len(t, valuation.Unpriced, 1)
+	assert.Equal(t, model.Asset("DUST"), valuation.Unpriced[0].Asset)
+	assert.InDelta(t, 42.0, valuation.Unpriced[0].Quantity, 1e-9)
+	assert.InDelta(t, 30400.0, valuation.TotalValue, 1e-6)
+}
+
+func TestValuePortfolioInversePair(t *testing.T) {
+	wallets := []*model.Wallet{
+		{
+			Balances: map[model.Asset]*model.Balance{
+				"EUR": {Asset: "EUR", Free: 50, Total: 50},
+			},
+		},
+	}
+	tickers := []*market.Ticker{
+		{Symbol: "USDTEUR", Price: 0.5},
+	}
+	service := newTestPortfolioValuationService(t, wallets, tickers)
+
+	valuation, err := service.ValuePortfolio(context.Background(), "user123")
+	require.NoError(t, err)
+	require.Len(t, valuation.Assets, 1)
+	assert.InDelta(t, 2.0, valuation.Assets[0].Price, 1e-9)
+	assert.InDelta(t, 100.0, valuation.TotalValue, 1e-9)
+}
@@ -109,6 +109,14 @@ func (m *PositionMockMarketRepository) GetOrderBook(ctx context.Context, symbol,
 	return args.Get(0).(*market.OrderBook), args.Error(1)
 }
 
+func (m *PositionMockMarketRepository) SearchSymbols(ctx context.Context, query string, limit int) ([]*market.Symbol, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*market.Symbol), args.Error(1)
+}
+
 func (m *PositionMockMarketRepository) GetTicker(ctx context.Context, symbol, exchange string) (*market.Ticker, error) {
 	args := m.Called(ctx, symbol, exchange)
 	if args.Get(0) == nil {
@@ -156,6 +164,14 @@ func (m *PositionMockMarketRepository) GetCandles(ctx context.Context, symbol, e
 	return args.Get(0).([]*market.Candle), args.Error(1)
 }
 
+func (m *PositionMockMarketRepository) GetResampledCandles(ctx context.Context, symbol, exchange string, source, target market.Interval, start, end time.Time) ([]*market.Candle, error) {
+	args := m.Called(ctx, symbol, exchange, source, target, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*market.Candle), args.Error(1)
+}
+
 func (m *PositionMockMarketRepository) GetLatestCandle(ctx context.Context, symbol, exchange string, interval market.Interval) (*market.Candle, error) {
 	args := m.Called(ctx, symbol, exchange, interval)
 	if args.Get(0) == nil {
@@ -223,6 +239,29 @@ func (m *PositionMockSymbolRepository) Delete(ctx context.Context, symbol string
 	return args.Error(0)
 }
 
+func (m *PositionMockSymbolRepository) GetAllIncludingDeleted(ctx context.Context) ([]*market.Symbol, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*market.Symbol), args.Error(1)
+}
+
+func (m *PositionMockSymbolRepository) Restore(ctx context.Context, symbol string) error {
+	args := m.Called(ctx, symbol)
+	return args.Error(0)
+}
+
+func (m *PositionMockSymbolRepository) Upsert(ctx context.Context, symbol *market.Symbol) error {
+	args := m.Called(ctx, symbol)
+	return args.Error(0)
+}
+
+func (m *PositionMockSymbolRepository) UpsertMany(ctx context.Context, symbols []*market.Symbol) (int, int, error) {
+	args := m.Called(ctx, symbols)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
 // Test setup helper
 func setupPositionUseCase(
 	positionRepo *PositionMockRepository,
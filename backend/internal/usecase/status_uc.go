@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"maps"
 	"sync"
 	"time"
 
@@ -27,6 +28,7 @@ type StatusUseCaseImpl struct {
 	stopChan        chan struct{}
 	updateTicker    *time.Ticker
 	notifyThreshold map[string]status.Status
+	now             func() time.Time
 }
 
 // StatusUseCaseConfig contains configuration for the status use case
@@ -62,6 +64,7 @@ func NewStatusUseCase(
 		updateInterval:  updateInterval,
 		stopChan:        make(chan struct{}),
 		notifyThreshold: make(map[string]status.Status),
+		now:             time.Now,
 	}
 }
 
@@ -123,7 +126,7 @@ func (uc *StatusUseCaseImpl) Start(ctx context.Context) error {
 		for {
 			select {
 			case <-uc.updateTicker.C:
-				if err := uc.updateSystemStatus(ctx); err != nil {
+				if err := uc.Refresh(ctx); err != nil {
 					uc.logger.Error().Err(err).Msg("Failed to update system status")
 				}
 			case <-uc.stopChan:
@@ -152,16 +155,24 @@ func (uc *StatusUseCaseImpl) Stop() {
 	}
 }
 
-// GetSystemStatus returns the current system status
+// Refresh queries the system info and every provider and saves the resulting status. It
+// runs on every update interval once started.
+func (uc *StatusUseCaseImpl) Refresh(ctx context.Context) error {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	return uc.updateSystemStatus(ctx)
+}
+
+// GetSystemStatus returns a snapshot of the status from the latest refresh. The uptime is
+// current; LastUpdated is when the status was refreshed.
 func (uc *StatusUseCaseImpl) GetSystemStatus(ctx context.Context) (*status.SystemStatus, error) {
 	uc.mu.RLock()
 	defer uc.mu.RUnlock()
 
-	// Update uptime
-	uc.systemStatus.Uptime = time.Since(uc.startTime).String()
-	uc.systemStatus.LastUpdated = time.Now()
-
-	return uc.systemStatus, nil
+	snapshot := *uc.systemStatus
+	snapshot.Components = maps.Clone(uc.systemStatus.Components)
+	snapshot.SetUptime(uc.now())
+	return &snapshot, nil
 }
 
 // GetComponentStatus returns the status of a specific component
@@ -268,7 +279,8 @@ func (uc *StatusUseCaseImpl) ControlComponent(ctx context.Context, control statu
 	return response, nil
 }
 
-// updateSystemStatus updates the system status by querying all providers
+// updateSystemStatus updates the system status by querying all providers. The caller
+// holds uc.mu.
 func (uc *StatusUseCaseImpl) updateSystemStatus(ctx context.Context) error {
 	// Update system info
 	if uc.systemInfo != nil {
@@ -325,6 +337,9 @@ func (uc *StatusUseCaseImpl) updateSystemStatus(ctx context.Context) error {
 	// Update overall system status
 	prevStatus := uc.systemStatus.Status
 	uc.systemStatus.UpdateSystemStatus()
+	// The saved status carries the uptime and process stats of this refresh
+	uc.systemStatus.LastUpdated = uc.now()
+	uc.systemStatus.SetUptime(uc.systemStatus.LastUpdated)
 
 	// Notify on system status change
 	if prevStatus != uc.systemStatus.Status && uc.notifier != nil {
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model/status"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sequenceSystemInfo reports a growing number of goroutines on every call
+type sequenceSystemInfo struct {
+	calls int
+}
+
+func (s *sequenceSystemInfo) GetSystemInfo(ctx context.Context) (*status.SystemInfo, error) {
+	s.calls++
+	return &status.SystemInfo{NumGoroutines: 10 * s.calls, AllocatedMemory: uint64(1024 * s.calls)}, nil
+}
+
+// savedStatuses records every saved system status
+type savedStatuses struct {
+	system []status.SystemStatus
+}
+
+func (s *savedStatuses) SaveSystemStatus(ctx context.Context, systemStatus *status.SystemStatus) error {
+	s.system = append(s.system, *systemStatus)
+	return nil
+}
+
+func (s *savedStatuses) GetSystemStatus(ctx context.Context) (*status.SystemStatus, error) {
+	return nil, nil
+}
+
+func (s *savedStatuses) SaveComponentStatus(ctx context.Context, componentStatus *status.ComponentStatus) error {
+	return nil
+}
+
+func (s *savedStatuses) GetComponentStatus(ctx context.Context, name string) (*status.ComponentStatus, error) {
+	return nil, nil
+}
+
+func (s *savedStatuses) GetComponentHistory(ctx context.Context, name string, limit int) ([]*status.ComponentStatus, error) {
+	return nil, nil
+}
+
+func TestStatusUseCase_RefreshUpdatesStoredValues(t *testing.T) {
+	logger := zerolog.Nop()
+	repo := &savedStatuses{}
+	uc := NewStatusUseCase(&sequenceSystemInfo{}, repo, nil, &logger, StatusUseCaseConfig{Version: "1.2.3"})
+	now := uc.startTime
+	uc.now = func() time.Time { return now }
+
+	now = now.Add(90 * time.Second)
+	require.NoError(t, uc.Refresh(context.Background()))
+	now = now.Add(time.Minute)
+	require.NoError(t, uc.Refresh(context.Background()))
+
+	require.Len(t, repo.system, 2)
+	first, second := repo.system[0], repo.system[1]
+	assert.Equal(t, 90.0, first.UptimeSeconds)
+	assert.Equal(t, "1m30s", first.Uptime)
+	assert.Equal(t, 10, first.SystemInfo.NumGoroutines)
+	assert.Equal(t, 150.0, second.UptimeSeconds)
+	assert.Equal(t, 20, second.SystemInfo.NumGoroutines)
+	assert.Equal(t, uint64(2048), second.SystemInfo.AllocatedMemory)
+	assert.Equal(t, now, second.LastUpdated)
+
+	// Reads report the latest refresh with a current uptime and do not change it
+	now = now.Add(time.Minute)
+	snapshot, err := uc.GetSystemStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 210.0, snapshot.UptimeSeconds)
+	assert.Equal(t, 20, snapshot.SystemInfo.NumGoroutines)
+	assert.Equal(t, now.Add(-time.Minute), snapshot.LastUpdated)
+	assert.Equal(t, 150.0, uc.systemStatus.UptimeSeconds)
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/adapter/delivery"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+)
+
+// tickerFeedBuffer is how many updates a subscriber may fall behind before it is dropped
+const tickerFeedBuffer = 256
+
+// TickerFeed fans live ticker updates out to subscribers, using the symbol as the hub
+// topic. Tickers are polled from the exchange for the symbols someone is subscribed to; a
+// push source, such as a WebSocket client, can feed updates in with Publish as well.
+type TickerFeed struct {
+	client   port.ExchangeClient
+	interval time.Duration
+	hub      *delivery.Hub[*model.Ticker]
+	logger   *zerolog.Logger
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewTickerFeed creates a TickerFeed polling client every interval
+func NewTickerFeed(client port.ExchangeClient, interval time.Duration, logger *zerolog.Logger) *TickerFeed {
+	return &TickerFeed{
+		client:   client,
+		interval: interval,
+		hub:      delivery.NewHub[*model.Ticker](tickerFeedBuffer, *logger),
+		logger:   logger,
+	}
+}
+
+// Subscribe starts receiving the updates of symbols, which match case-insensitively. A
+// subscriber that falls a full buffer behind is dropped and its channel closed.
+func (f *TickerFeed) Subscribe(symbols []string) *delivery.HubSubscription[*model.Ticker] {
+	topics := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		topics = append(topics, strings.ToUpper(symbol))
+	}
+	return f.hub.Subscribe(topics...)
+}
+
+// Publish delivers ticker to the subscribers of its symbol
+func (f *TickerFeed) Publish(ticker *model.Ticker) {
+	if ticker == nil {
+		return
+	}
+	f.hub.Publish(strings.ToUpper(ticker.Symbol), ticker)
+}
+
+// Start polls the subscribed symbols every interval until Stop is called or ctx ends
+func (f *TickerFeed) Start(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.cancel != nil {
+		return fmt.Errorf("ticker feed is already running")
+	}
+
+	ctx, f.cancel = context.WithCancel(ctx)
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		ticker := time.NewTicker(f.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				f.Poll(ctx)
+			}
+		}
+	}()
+
+	f.logger.Info().Dur("interval", f.interval).Msg("Ticker feed started")
+	return nil
+}
+
+// Stop stops polling and waits for a running poll to finish
+func (f *TickerFeed) Stop() {
+	f.mu.Lock()
+	cancel := f.cancel
+	f.cancel = nil
+	f.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		f.wg.Wait()
+		f.logger.Info().Msg("Ticker feed stopped")
+	}
+}
+
+// Poll fetches the tickers of every subscribed symbol once and publishes them
+func (f *TickerFeed) Poll(ctx context.Context) {
+	symbols := f.hub.Topics()
+	if len(symbols) == 0 {
+		return
+	}
+
+	if batch, ok := f.client.(port.TickerBatchProvider); ok {
+		tickers, err := batch.GetTickers(ctx, symbols)
+		if err != nil {
+			f.logger.Warn().Err(err).Int("symbols", len(symbols)).Msg("Failed to poll tickers")
+			return
+		}
+		for _, ticker := range tickers {
+			f.Publish(ticker)
+		}
+		return
+	}
+
+	for _, symbol := range symbols {
+		if ctx.Err() != nil {
+			return
+		}
+		ticker, err := f.client.GetMarketData(ctx, symbol)
+		if err != nil {
+			f.logger.Warn().Err(err).Str("symbol", symbol).Msg("Failed to poll ticker")
+			continue
+		}
+		f.Publish(ticker)
+	}
+}
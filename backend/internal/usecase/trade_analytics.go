@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"sort"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+)
+
+// pnlEpsilon absorbs floating point residue when lots are fully consumed
+const pnlEpsilon = 1e-12
+
+// TradeAnalytics computes trade performance figures from order history
+type TradeAnalytics struct{}
+
+// NewTradeAnalytics creates a new TradeAnalytics helper
+func NewTradeAnalytics() *TradeAnalytics {
+	return &TradeAnalytics{}
+}
+
+// fifoLot is an open buy quantity waiting to be matched by a sell
+type fifoLot struct {
+	quantity   float64
+	price      float64
+	feePerUnit float64
+}
+
+// ComputePnL matches executed sells against earlier buys in FIFO order and returns the
+// realized PnL for the symbol. Orders for other symbols and orders without executed
+// quantity are ignored. Commissions are assumed to be denominated in the quote asset.
+func (a *TradeAnalytics) ComputePnL(symbol string, orders []*model.Order) *model.PnLReport {
+	report := &model.PnLReport{
+		Symbol:      symbol,
+		GeneratedAt: time.Now(),
+	}
+
+	fills := make([]*model.Order, 0, len(orders))
+	for _, order := range orders {
+		if order == nil || order.Symbol != symbol || order.ExecutedQty <= 0 {
+			continue
+		}
+		fills = append(fills, order)
+	}
+	sort.SliceStable(fills, func(i, j int) bool {
+		return fills[i].CreatedAt.Before(fills[j].CreatedAt)
+	})
+
+	var lots []*fifoLot
+	var buyNotional, sellNotional float64
+
+	for _, order := range fills {
+		qty := order.ExecutedQty
+		price := fillPrice(order)
+		report.OrderCount++
+		report.TotalFees += order.Commission
+
+		switch order.Side {
+		case model.OrderSideBuy:
+			report.BoughtQuantity += qty
+			buyNotional += qty * price
+			lots = append(lots, &fifoLot{quantity: qty, price: price, feePerUnit: order.Commission / qty})
+
+		case model.OrderSideSell:
+			report.SoldQuantity += qty
+			sellNotional += qty * price
+			report.RealizedFees += order.Commission
+
+			remaining := qty
+			for remaining > pnlEpsilon && len(lots) > 0 {
+				lot := lots[0]
+				matched := remaining
+				if lot.quantity < matched {
+					matched = lot.quantity
+				}
+
+				report.GrossRealizedPnL += matched * (price - lot.price)
+				report.RealizedFees += matched * lot.feePerUnit
+
+				lot.quantity -= matched
+				remaining -= matched
+				if lot.quantity <= pnlEpsilon {
+					lots = lots[1:]
+				}
+			}
+			if remaining > pnlEpsilon {
+				report.UnmatchedSellQty += remaining
+			}
+		}
+	}
+
+	for _, lot := range lots {
+		report.OpenQuantity += lot.quantity
+		report.OpenCostBasis += lot.quantity * (lot.price + lot.feePerUnit)
+	}
+	if report.BoughtQuantity > 0 {
+		report.AverageEntryPrice = buyNotional / report.BoughtQuantity
+	}
+	if report.SoldQuantity > 0 {
+		report.AverageExitPrice = sellNotional / report.SoldQuantity
+	}
+	report.RealizedPnL = report.GrossRealizedPnL - report.RealizedFees
+
+	return report
+}
+
+// fillPrice returns the average fill price of an order, falling back to the limit price
+func fillPrice(order *model.Order) float64 {
+	if order.AvgFillPrice > 0 {
+		return order.AvgFillPrice
+	}
+	return order.Price
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTradeAnalyticsComputePnLFIFO(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fill := func(minute int, side model.OrderSide, qty, price, fee float64) *model.Order {
+		return &model.Order{
+			Symbol:       "BTCUSDT",
+			Side:         side,
+			Status:       model.OrderStatusFilled,
+			ExecutedQty:  qty,
+			AvgFillPrice: price,
+			Commission:   fee,
+			CreatedAt:    start.Add(time.Duration(minute) * time.Minute),
+		}
+	}
+
+	// Orders are deliberately out of order; FIFO must follow CreatedAt
+	orders := []*model.Order{
+		fill(3, model.OrderSideSell, 1.5, 120, 1.5), // matches 1.0@100 and 0.5@110
+		fill(1, model.OrderSideBuy, 1.0, 100, 1.0),
+		fill(2, model.OrderSideBuy, 1.0, 110, 2.0),
+		fill(4, model.OrderSideBuy, 0.5, 130, 0.5),
+		fill(5, model.OrderSideSell, 0.5, 140, 0.5), // matches 0.5@110
+		{Symbol: "BTCUSDT", Side: model.OrderSideBuy, Status: model.OrderStatusCanceled, Quantity: 3, Price: 90},
+		{Symbol: "ETHUSDT", Side: model.OrderSideBuy, Status: model.OrderStatusFilled, ExecutedQty: 2, Price: 10},
+	}
+
+	report := NewTradeAnalytics().ComputePnL("BTCUSDT", orders)
+
+	require.NotNil(t, report)
+	assert.Equal(t, 5, report.OrderCount)
+	assert.InDelta(t, 2.5, report.BoughtQuantity, 1e-9)
+	assert.InDelta(t, 2.0, report.SoldQuantity, 1e-9)
+
+	// Gross: 1.0*(120-100) + 0.5*(120-110) + 0.5*(140-110) = 20 + 5 + 15
+	assert.InDelta(t, 40.0, report.GrossRealizedPnL, 1e-9)
+	// Fees: sells 1.5 + 0.5, matched buys 1.0 + (1.0 lot of 2.0 fee fully matched) = 5.0
+	assert.InDelta(t, 5.0, report.RealizedFees, 1e-9)
+	assert.InDelta(t, 35.0, report.RealizedPnL, 1e-9)
+	assert.InDelta(t, 5.5, report.TotalFees, 1e-9)
+
+	// The 0.5@130 lot (with its 0.5 fee) stays open
+	assert.InDelta(t, 0.5, report.OpenQuantity, 1e-9)
+	assert.InDelta(t, 65.5, report.OpenCostBasis, 1e-9)
+	assert.InDelta(t, (100.0+110.0+65.0)/2.5, report.AverageEntryPrice, 1e-9)
+	assert.InDelta(t, (180.0+70.0)/2.0, report.AverageExitPrice, 1e-9)
+	assert.Zero(t, report.UnmatchedSellQty)
+}
+
+func TestTradeAnalyticsPartialFillsAndUnmatchedSells(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	orders := []*model.Order{
+		// Partially filled buy falls back to the limit price
+		{Symbol: "ETHUSDT", Side: model.OrderSideBuy, Status: model.OrderStatusPartiallyFilled, Quantity: 2, ExecutedQty: 0.4, Price: 2000, CreatedAt: start},
+		{Symbol: "ETHUSDT", Side: model.OrderSideSell, Status: model.OrderStatusFilled, ExecutedQty: 0.6, AvgFillPrice: 2100, CreatedAt: start.Add(time.Minute)},
+	}
+
+	report := NewTradeAnalytics().ComputePnL("ETHUSDT", orders)
+
+	assert.InDelta(t, 40.0, report.GrossRealizedPnL, 1e-9)
+	assert.InDelta(t, 40.0, report.RealizedPnL, 1e-9)
+	assert.InDelta(t, 0.2, report.UnmatchedSellQty, 1e-9)
+	assert.Zero(t, report.OpenQuantity)
+}
+
+// pnlHistoryService serves a fixed order history
+type pnlHistoryService struct {
+	port.TradeService
+	orders []*model.Order
+}
+
+func (s *pnlHistoryService) GetOrderHistory(ctx context.Context, symbol string, limit, offset int) ([]*model.Order, error) {
+	if offset >= len(s.orders) {
+		return nil, nil
+	}
+	return s.orders[offset:min(offset+limit, len(s.orders))], nil
+}
+
+func TestTradeUseCase_GetPnLReportCountsOnlyTheUsersOrders(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fill := func(userID string, minute int, side model.OrderSide, price float64) *model.Order {
+		return &model.Order{
+			UserID:       userID,
+			Symbol:       "BTCUSDT",
+			Side:         side,
+			Status:       model.OrderStatusFilled,
+			ExecutedQty:  1,
+			AvgFillPrice: price,
+			CreatedAt:    start.Add(time.Duration(minute) * time.Minute),
+		}
+	}
+	service := &pnlHistoryService{orders: []*model.Order{
+		fill("alice", 1, model.OrderSideBuy, 100),
+		fill("bob", 2, model.OrderSideBuy, 50),
+		fill("alice", 3, model.OrderSideSell, 120),
+		fill("bob", 4, model.OrderSideSell, 40),
+	}}
+	uc := NewTradeUseCase(nil, nil, nil, service, nil, nil, zerolog.Nop())
+
+	report, err := uc.GetPnLReport(context.Background(), "alice", "BTCUSDT")
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.OrderCount)
+	assert.InDelta(t, 20.0, report.RealizedPnL, 1e-9)
+}
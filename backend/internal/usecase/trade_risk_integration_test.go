@@ -125,6 +125,15 @@ func (m *MockTradeService) CalculateRequiredQuantity(ctx context.Context, symbol
 	return val.(float64), args.Error(1)
 }
 
+func (m *MockTradeService) CalculateQuantityForRisk(ctx context.Context, symbol string, side model.OrderSide, riskPct, stopPrice float64) (float64, error) {
+	args := m.Called(ctx, symbol, side, riskPct, stopPrice)
+	val := args.Get(0)
+	if val == nil {
+		return 0.0, args.Error(1)
+	}
+	return val.(float64), args.Error(1)
+}
+
 // Minimal mocks for required dependencies
 
 type MockMEXCClient struct{ mock.Mock }
@@ -189,10 +198,19 @@ func (m *MockOrderRepository) GetByID(ctx context.Context, id string) (*model.Or
 func (m *MockOrderRepository) GetByClientOrderID(ctx context.Context, clientOrderID string) (*model.Order, error) {
 	return nil, nil
 }
+func (m *MockOrderRepository) GetByOrderID(ctx context.Context, orderID string) (*model.Order, error) {
+	return nil, nil
+}
 func (m *MockOrderRepository) Update(ctx context.Context, order *model.Order) error { return nil }
 func (m *MockOrderRepository) GetBySymbol(ctx context.Context, symbol string, limit, offset int) ([]*model.Order, error) {
 	return nil, nil
 }
+func (m *MockOrderRepository) Query(ctx context.Context, filter model.OrderFilter) ([]*model.Order, int64, error) {
+	return nil, 0, nil
+}
+func (m *MockOrderRepository) GetBySymbolBefore(ctx context.Context, userID, symbol string, cursor *model.OrderCursor, limit int) ([]*model.Order, error) {
+	return nil, nil
+}
 func (m *MockOrderRepository) GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*model.Order, error) {
 	return nil, nil
 }
@@ -217,6 +235,14 @@ func (m *MockSymbolRepository) GetByExchange(ctx context.Context, exchange strin
 func (m *MockSymbolRepository) GetAll(ctx context.Context) ([]*market.Symbol, error)    { return nil, nil }
 func (m *MockSymbolRepository) Update(ctx context.Context, symbol *market.Symbol) error { return nil }
 func (m *MockSymbolRepository) Delete(ctx context.Context, symbol string) error         { return nil }
+func (m *MockSymbolRepository) Restore(ctx context.Context, symbol string) error        { return nil }
+func (m *MockSymbolRepository) Upsert(ctx context.Context, symbol *market.Symbol) error { return nil }
+func (m *MockSymbolRepository) UpsertMany(ctx context.Context, symbols []*market.Symbol) (int, int, error) {
+	return len(symbols), 0, nil
+}
+func (m *MockSymbolRepository) GetAllIncludingDeleted(ctx context.Context) ([]*market.Symbol, error) {
+	return nil, nil
+}
 
 type MockTransactionManager struct {
 	mock.Mock
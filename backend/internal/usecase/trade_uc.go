@@ -18,6 +18,9 @@ var (
 	ErrSymbolNotFound      = errors.New("symbol not found")
 )
 
+// pnlHistoryPageSize is the number of orders fetched per page when building a PnL report
+const pnlHistoryPageSize = 500
+
 // TradeUseCase defines methods for trade operations
 type TradeUseCase interface {
 	// Place a new order
@@ -30,24 +33,34 @@ type TradeUseCase interface {
 	GetOpenOrders(ctx context.Context, symbol string) ([]*model.Order, error)
 	// Get order history for a symbol with pagination
 	GetOrderHistory(ctx context.Context, symbol string, limit, offset int) ([]*model.Order, error)
+	// Get a page of order history for a symbol, newest first, resuming after cursor
+	GetOrderHistoryPage(ctx context.Context, userID, symbol, cursor string, limit int) (*model.OrderPage, error)
 	// Calculate the required quantity for an order based on amount in quote currency
 	CalculateRequiredQuantity(ctx context.Context, symbol string, side model.OrderSide, amount float64) (float64, error)
+	// Calculate the quantity that risks riskPct percent of equity if stopPrice is hit
+	CalculateQuantityForRisk(ctx context.Context, symbol string, side model.OrderSide, riskPct, stopPrice float64) (float64, error)
+	// Get realized PnL of a user for a symbol computed from their order history
+	GetPnLReport(ctx context.Context, userID, symbol string) (*model.PnLReport, error)
 }
 
+// defaultOrderPageSize is the page size used when GetOrderHistoryPage is given no limit
+const defaultOrderPageSize = 50
+
 // tradeUseCase implements the TradeUseCase interface
 type tradeUseCase struct {
-	mexcClient   port.MEXCClient
+	mexcClient   port.ExchangeClient
 	orderRepo    port.OrderRepository
 	symbolRepo   port.SymbolRepository
 	tradeService port.TradeService
 	riskUC       RiskUseCase
 	txManager    port.TransactionManager
+	analytics    *TradeAnalytics
 	logger       zerolog.Logger
 }
 
 // NewTradeUseCase creates a new TradeUseCase
 func NewTradeUseCase(
-	mexcClient port.MEXCClient,
+	mexcClient port.ExchangeClient,
 	orderRepo port.OrderRepository,
 	symbolRepo port.SymbolRepository,
 	tradeService port.TradeService,
@@ -62,6 +75,7 @@ func NewTradeUseCase(
 		tradeService: tradeService,
 		riskUC:       riskUC,
 		txManager:    txManager,
+		analytics:    NewTradeAnalytics(),
 		logger:       logger.With().Str("component", "trade_usecase").Logger(),
 	}
 }
@@ -255,6 +269,43 @@ func (uc *tradeUseCase) GetOrderHistory(ctx context.Context, symbol string, limi
 	return orders, nil
 }
 
+// GetOrderHistoryPage retrieves a page of a user's order history using keyset pagination.
+// Unlike GetOrderHistory, pages do not drift when new orders arrive between requests.
+func (uc *tradeUseCase) GetOrderHistoryPage(ctx context.Context, userID, symbol, cursor string, limit int) (*model.OrderPage, error) {
+	if limit <= 0 {
+		limit = defaultOrderPageSize
+	}
+
+	var after *model.OrderCursor
+	if cursor != "" {
+		decoded, err := model.DecodeOrderCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = decoded
+	}
+
+	// Fetch one extra order to learn whether another page follows
+	orders, err := uc.orderRepo.GetBySymbolBefore(ctx, userID, symbol, after, limit+1)
+	if err != nil {
+		uc.logger.Error().Err(err).
+			Str("userID", userID).
+			Str("symbol", symbol).
+			Int("limit", limit).
+			Msg("Failed to get order history page")
+		return nil, err
+	}
+
+	page := &model.OrderPage{Orders: orders}
+	if len(orders) > limit {
+		page.Orders = orders[:limit]
+		last := page.Orders[limit-1]
+		page.NextCursor = model.OrderCursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	return page, nil
+}
+
 // CalculateRequiredQuantity calculates the required quantity for an order based on amount
 func (uc *tradeUseCase) CalculateRequiredQuantity(ctx context.Context, symbol string, side model.OrderSide, amount float64) (float64, error) {
 	// Delegate to the trade service
@@ -270,3 +321,45 @@ func (uc *tradeUseCase) CalculateRequiredQuantity(ctx context.Context, symbol st
 
 	return quantity, nil
 }
+
+// CalculateQuantityForRisk calculates the order quantity that risks riskPct percent of equity down to stopPrice
+func (uc *tradeUseCase) CalculateQuantityForRisk(ctx context.Context, symbol string, side model.OrderSide, riskPct, stopPrice float64) (float64, error) {
+	// Delegate to the trade service
+	quantity, err := uc.tradeService.CalculateQuantityForRisk(ctx, symbol, side, riskPct, stopPrice)
+	if err != nil {
+		uc.logger.Error().Err(err).
+			Str("symbol", symbol).
+			Str("side", string(side)).
+			Float64("riskPct", riskPct).
+			Float64("stopPrice", stopPrice).
+			Msg("Failed to calculate risk-based quantity")
+		return 0, err
+	}
+
+	return quantity, nil
+}
+
+// GetPnLReport computes the realized PnL of a user for a symbol from their full order history
+func (uc *tradeUseCase) GetPnLReport(ctx context.Context, userID, symbol string) (*model.PnLReport, error) {
+	var orders []*model.Order
+	for offset := 0; ; offset += pnlHistoryPageSize {
+		page, err := uc.tradeService.GetOrderHistory(ctx, symbol, pnlHistoryPageSize, offset)
+		if err != nil {
+			uc.logger.Error().Err(err).
+				Str("symbol", symbol).
+				Int("offset", offset).
+				Msg("Failed to load order history for PnL report")
+			return nil, err
+		}
+		for _, order := range page {
+			if order.UserID == userID {
+				orders = append(orders, order)
+			}
+		}
+		if len(page) < pnlHistoryPageSize {
+			break
+		}
+	}
+
+	return uc.analytics.ComputePnL(symbol, orders), nil
+}
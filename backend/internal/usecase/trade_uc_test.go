@@ -208,6 +208,14 @@ func (m *mockOrderRepository) GetByClientOrderID(ctx context.Context, clientOrde
 	}
 	return order, args.Error(1)
 }
+func (m *mockOrderRepository) GetByOrderID(ctx context.Context, orderID string) (*model.Order, error) {
+	args := m.Called(ctx, orderID)
+	var order *model.Order
+	if arg0 := args.Get(0); arg0 != nil {
+		order = arg0.(*model.Order)
+	}
+	return order, args.Error(1)
+}
 func (m *mockOrderRepository) Update(ctx context.Context, order *model.Order) error {
 	args := m.Called(ctx, order)
 	return args.Error(0)
@@ -228,6 +236,22 @@ func (m *mockOrderRepository) GetByUserID(ctx context.Context, userID string, li
 	}
 	return orders, args.Error(1)
 }
+func (m *mockOrderRepository) Query(ctx context.Context, filter model.OrderFilter) ([]*model.Order, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*model.Order), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *mockOrderRepository) GetBySymbolBefore(ctx context.Context, userID, symbol string, cursor *model.OrderCursor, limit int) ([]*model.Order, error) {
+	args := m.Called(ctx, userID, symbol, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Order), args.Error(1)
+}
+
 func (m *mockOrderRepository) GetByStatus(ctx context.Context, status model.OrderStatus, limit, offset int) ([]*model.Order, error) {
 	args := m.Called(ctx, status, limit, offset)
 	var orders []*model.Order
@@ -290,6 +314,29 @@ func (m *mockSymbolRepository) Delete(ctx context.Context, symbol string) error
 	return args.Error(0)
 }
 
+func (m *mockSymbolRepository) GetAllIncludingDeleted(ctx context.Context) ([]*market.Symbol, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*market.Symbol), args.Error(1)
+}
+
+func (m *mockSymbolRepository) Restore(ctx context.Context, symbol string) error {
+	args := m.Called(ctx, symbol)
+	return args.Error(0)
+}
+
+func (m *mockSymbolRepository) Upsert(ctx context.Context, symbol *market.Symbol) error {
+	args := m.Called(ctx, symbol)
+	return args.Error(0)
+}
+
+func (m *mockSymbolRepository) UpsertMany(ctx context.Context, symbols []*market.Symbol) (int, int, error) {
+	args := m.Called(ctx, symbols)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
 type mockTradeService struct {
 	mock.Mock
 }
@@ -333,6 +380,10 @@ func (m *mockTradeService) CalculateRequiredQuantity(ctx context.Context, symbol
 	args := m.Called(ctx, symbol, side, amount)
 	return args.Get(0).(float64), args.Error(1) // Use Get().(float64) instead of Double
 }
+func (m *mockTradeService) CalculateQuantityForRisk(ctx context.Context, symbol string, side model.OrderSide, riskPct, stopPrice float64) (float64, error) {
+	args := m.Called(ctx, symbol, side, riskPct, stopPrice)
+	return args.Get(0).(float64), args.Error(1)
+}
 
 // Assume MockRiskUseCase is defined elsewhere (e.g., trade_risk_integration_test.go)
 // If not, define it here:
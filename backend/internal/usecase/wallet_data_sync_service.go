@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"sync"
 	"time"
 
@@ -45,6 +46,9 @@ type walletDataSyncService struct {
 	walletRepo           port.WalletRepository
 	apiCredentialManager APICredentialManagerService
 	providerRegistry     *wallet.ProviderRegistry
+	transactionRepo      port.WalletTransactionRepository
+	notifier             port.BalanceChangeNotifier
+	changeThreshold      float64
 	logger               *zerolog.Logger
 	syncJobs             map[string]*syncJob
 	mu                   sync.RWMutex
@@ -60,17 +64,24 @@ type syncJob struct {
 	isRunning bool
 }
 
-// NewWalletDataSyncService creates a new wallet data sync service
+// NewWalletDataSyncService creates a new wallet data sync service. Balance changes larger than
+// changeThreshold percent are recorded in transactionRepo and sent to notifier; either may be nil.
 func NewWalletDataSyncService(
 	walletRepo port.WalletRepository,
 	apiCredentialManager APICredentialManagerService,
 	providerRegistry *wallet.ProviderRegistry,
+	transactionRepo port.WalletTransactionRepository,
+	notifier port.BalanceChangeNotifier,
+	changeThreshold float64,
 	logger *zerolog.Logger,
 ) WalletDataSyncService {
 	return &walletDataSyncService{
 		walletRepo:           walletRepo,
 		apiCredentialManager: apiCredentialManager,
 		providerRegistry:     providerRegistry,
+		transactionRepo:      transactionRepo,
+		notifier:             notifier,
+		changeThreshold:      changeThreshold,
 		logger:               logger,
 		syncJobs:             make(map[string]*syncJob),
 	}
@@ -89,6 +100,11 @@ func (s *walletDataSyncService) SyncWallet(ctx context.Context, walletID string)
 		return nil, err
 	}
 
+	// Snapshot the stored balances before providers update the wallet. A wallet never
+	// synced has no snapshot to compare with: its first sync records the baseline.
+	previousBalances := snapshotBalances(wallet.Balances)
+	firstSync := wallet.LastSynced == nil && wallet.LastSyncAt.IsZero()
+
 	// Update sync status
 	s.updateSyncStatus(walletID, model.SyncStatusInProgress)
 
@@ -127,6 +143,14 @@ func (s *walletDataSyncService) SyncWallet(ctx context.Context, walletID string)
 		// Continue anyway, this is not critical
 	}
 
+	// Record and announce deposits and withdrawals. On the first sync every held asset
+	// would otherwise be reported as a deposit.
+	if firstSync {
+		s.logger.Debug().Str("walletID", walletID).Msg("Recorded baseline balances, skipping change detection on first sync")
+	} else {
+		s.processBalanceChanges(ctx, syncedWallet, previousBalances)
+	}
+
 	s.updateSyncStatus(walletID, model.SyncStatusSuccess)
 	s.logger.Info().Str("walletID", walletID).Msg("Wallet synced successfully")
 	return syncedWallet, nil
@@ -445,15 +469,48 @@ func (s *walletDataSyncService) syncExchangeWallet(ctx context.Context, wallet *
 		return nil, fmt.Errorf("failed to set API credentials: %w", err)
 	}
 
+	// The provider updates the wallet in place, so note the previous sync first
+	lastSync := wallet.LastSyncAt
+
 	// Get balance
 	syncedWallet, err := provider.GetBalance(ctx, wallet)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get balance: %w", err)
 	}
 
+	if history, ok := provider.(transferHistorySyncer); ok {
+		s.syncTransferHistory(ctx, history, syncedWallet, lastSync)
+	}
+
 	return syncedWallet, nil
 }
 
+// transferHistorySyncer is implemented by exchange providers that can store the
+// account's deposit and withdrawal history
+type transferHistorySyncer interface {
+	SyncTransferHistory(ctx context.Context, wallet *model.Wallet, start, end time.Time) (int, error)
+}
+
+// Transfer history is fetched from shortly before the previous sync, so transfers still
+// pending then are updated; a wallet never synced fetches the last week
+const (
+	transferHistoryOverlap         = 24 * time.Hour
+	transferHistoryInitialLookback = 7 * 24 * time.Hour
+)
+
+// syncTransferHistory stores the wallet's deposits and withdrawals since the previous
+// sync. A failure is logged; the balances are synced regardless.
+func (s *walletDataSyncService) syncTransferHistory(ctx context.Context, history transferHistorySyncer, wallet *model.Wallet, lastSync time.Time) {
+	end := time.Now()
+	start := end.Add(-transferHistoryInitialLookback)
+	if !lastSync.IsZero() && lastSync.Add(-transferHistoryOverlap).After(start) {
+		start = lastSync.Add(-transferHistoryOverlap)
+	}
+	if _, err := history.SyncTransferHistory(ctx, wallet, start, end); err != nil {
+		s.logger.Warn().Err(err).Str("walletID", wallet.ID).Msg("Failed to sync transfer history")
+	}
+}
+
 // syncWeb3Wallet synchronizes a Web3 wallet
 func (s *walletDataSyncService) syncWeb3Wallet(ctx context.Context, wallet *model.Wallet) (*model.Wallet, error) {
 	// Get Web3 provider
@@ -471,6 +528,86 @@ func (s *walletDataSyncService) syncWeb3Wallet(ctx context.Context, wallet *mode
 	return syncedWallet, nil
 }
 
+// processBalanceChanges records a transaction and sends a notification for every balance change
+// above the configured threshold
+func (s *walletDataSyncService) processBalanceChanges(ctx context.Context, synced *model.Wallet, previous map[model.Asset]float64) {
+	for _, tx := range detectBalanceChanges(synced, previous, s.changeThreshold) {
+		if s.transactionRepo != nil {
+			if err := s.transactionRepo.Save(ctx, tx); err != nil {
+				s.logger.Error().Err(err).Str("walletID", tx.WalletID).Str("asset", string(tx.Asset)).Msg("Failed to record wallet transaction")
+			}
+		}
+		if s.notifier != nil {
+			if err := s.notifier.NotifyBalanceChange(ctx, tx); err != nil {
+				s.logger.Error().Err(err).Str("walletID", tx.WalletID).Str("asset", string(tx.Asset)).Msg("Failed to send balance change notification")
+			}
+		}
+	}
+}
+
+// detectBalanceChanges compares synced balances with the previous totals and returns a deposit or
+// withdrawal for each asset whose total moved by more than thresholdPct percent
+func detectBalanceChanges(synced *model.Wallet, previous map[model.Asset]float64, thresholdPct float64) []*model.WalletTransaction {
+	now := time.Now()
+	current := snapshotBalances(synced.Balances)
+
+	assets := make(map[model.Asset]struct{}, len(current)+len(previous))
+	for asset := range current {
+		assets[asset] = struct{}{}
+	}
+	for asset := range previous {
+		assets[asset] = struct{}{}
+	}
+
+	var txs []*model.WalletTransaction
+	for asset := range assets {
+		before, after := previous[asset], current[asset]
+		delta := after - before
+		if delta == 0 {
+			continue
+		}
+		// New assets are always reported, otherwise the change must exceed the threshold
+		if before != 0 && math.Abs(delta)/math.Abs(before)*100 < thresholdPct {
+			continue
+		}
+
+		txType := model.TransactionTypeDeposit
+		if delta < 0 {
+			txType = model.TransactionTypeWithdrawal
+		}
+
+		txs = append(txs, &model.WalletTransaction{
+			ID:              model.GenerateID(),
+			UserID:          synced.UserID,
+			WalletID:        synced.ID,
+			Type:            txType,
+			Asset:           asset,
+			Amount:          math.Abs(delta),
+			PreviousBalance: before,
+			NewBalance:      after,
+			DetectedAt:      now,
+		})
+	}
+
+	return txs
+}
+
+// snapshotBalances copies the total balance of every asset
+func snapshotBalances(balances map[model.Asset]*model.Balance) map[model.Asset]float64 {
+	snapshot := make(map[model.Asset]float64, len(balances))
+	for asset, balance := range balances {
+		if balance == nil {
+			continue
+		}
+		total := balance.Total
+		if total == 0 {
+			total = balance.Free + balance.Locked
+		}
+		snapshot[asset] = total
+	}
+	return snapshot
+}
+
 // updateSyncStatus updates the sync status for a wallet
 func (s *walletDataSyncService) updateSyncStatus(walletID string, status model.SyncStatus) {
 	s.mu.Lock()
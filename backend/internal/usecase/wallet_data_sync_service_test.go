@@ -125,7 +125,7 @@ func TestSyncWallet(t *testing.T) {
 	providerRegistry.RegisterProvider(mockProvider)
 
 	// Create service
-	service := NewWalletDataSyncService(mockRepo, mockCredentialManager, providerRegistry, &logger)
+	service := NewWalletDataSyncService(mockRepo, mockCredentialManager, providerRegistry, nil, nil, 0, &logger)
 
 	// Setup mock wallet
 	wallet := &model.Wallet{
@@ -209,7 +209,7 @@ func TestSyncWalletError(t *testing.T) {
 	providerRegistry.RegisterProvider(mockProvider)
 
 	// Create service
-	service := NewWalletDataSyncService(mockRepo, mockCredentialManager, providerRegistry, &logger)
+	service := NewWalletDataSyncService(mockRepo, mockCredentialManager, providerRegistry, nil, nil, 0, &logger)
 
 	// Setup mock wallet
 	wallet := &model.Wallet{
@@ -268,7 +268,7 @@ func TestSyncWalletsByUserID(t *testing.T) {
 	providerRegistry.RegisterProvider(mockProvider)
 
 	// Create service
-	service := NewWalletDataSyncService(mockRepo, mockCredentialManager, providerRegistry, &logger)
+	service := NewWalletDataSyncService(mockRepo, mockCredentialManager, providerRegistry, nil, nil, 0, &logger)
 
 	// Setup mock wallets
 	wallet1 := &model.Wallet{
@@ -354,7 +354,7 @@ func TestScheduleWalletSync(t *testing.T) {
 	providerRegistry := wallet.NewProviderRegistry()
 
 	// Create service
-	service := NewWalletDataSyncService(mockRepo, mockCredentialManager, providerRegistry, &logger)
+	service := NewWalletDataSyncService(mockRepo, mockCredentialManager, providerRegistry, nil, nil, 0, &logger)
 
 	// Setup mock wallet
 	wallet := &model.Wallet{
@@ -391,7 +391,7 @@ func TestGetLastSyncTime(t *testing.T) {
 	providerRegistry := wallet.NewProviderRegistry()
 
 	// Create service
-	service := NewWalletDataSyncService(mockRepo, mockCredentialManager, providerRegistry, &logger)
+	service := NewWalletDataSyncService(mockRepo, mockCredentialManager, providerRegistry, nil, nil, 0, &logger)
 
 	// Setup mock wallet
 	now := time.Now()
@@ -428,7 +428,7 @@ func TestGetSyncStatus(t *testing.T) {
 	providerRegistry := wallet.NewProviderRegistry()
 
 	// Create service
-	service := NewWalletDataSyncService(mockRepo, mockCredentialManager, providerRegistry, &logger)
+	service := NewWalletDataSyncService(mockRepo, mockCredentialManager, providerRegistry, nil, nil, 0, &logger)
 
 	// Setup mock wallet
 	wallet := &model.Wallet{
@@ -463,7 +463,7 @@ func TestSaveBalanceHistory(t *testing.T) {
 	providerRegistry := wallet.NewProviderRegistry()
 
 	// Create service
-	service := NewWalletDataSyncService(mockRepo, mockCredentialManager, providerRegistry, &logger)
+	service := NewWalletDataSyncService(mockRepo, mockCredentialManager, providerRegistry, nil, nil, 0, &logger)
 
 	// Setup mock wallet
 	wallet := &model.Wallet{
@@ -492,3 +492,131 @@ func TestSaveBalanceHistory(t *testing.T) {
 	// Verify mocks
 	mockRepo.AssertExpectations(t)
 }
+
+// recordingWalletTransactionRepo collects saved wallet transactions
+type recordingWalletTransactionRepo struct {
+	saved []*model.WalletTransaction
+}
+
+func (r *recordingWalletTransactionRepo) Save(ctx context.Context, tx *model.WalletTransaction) error {
+	r.saved = append(r.saved, tx)
+	return nil
+}
+
+func (r *recordingWalletTransactionRepo) GetByWalletID(ctx context.Context, walletID string, limit, offset int) ([]*model.WalletTransaction, error) {
+	return r.saved, nil
+}
+
+// recordingBalanceChangeNotifier collects balance change notifications
+type recordingBalanceChangeNotifier struct {
+	notified []*model.WalletTransaction
+}
+
+func (n *recordingBalanceChangeNotifier) NotifyBalanceChange(ctx context.Context, tx *model.WalletTransaction) error {
+	n.notified = append(n.notified, tx)
+	return nil
+}
+
+// syncWalletWithBalances runs SyncWallet for a stored BTC balance of before, from a previous
+// sync, that the exchange reports as after
+func syncWalletWithBalances(t *testing.T, before, after float64) (*recordingWalletTransactionRepo, *recordingBalanceChangeNotifier) {
+	lastSynced := time.Now().Add(-time.Hour)
+	return syncStoredWallet(t, &model.Wallet{
+		ID:         "wallet123",
+		UserID:     "user123",
+		Type:       model.WalletTypeExchange,
+		Exchange:   "MEXC",
+		LastSynced: &lastSynced,
+		LastSyncAt: lastSynced,
+		Balances: map[model.Asset]*model.Balance{
+			model.AssetBTC: {Asset: model.AssetBTC, Free: before, Total: before},
+		},
+	}, after)
+}
+
+// syncStoredWallet runs SyncWallet for the stored wallet, whose BTC balance the exchange
+// reports as after
+func syncStoredWallet(t *testing.T, stored *model.Wallet, after float64) (*recordingWalletTransactionRepo, *recordingBalanceChangeNotifier) {
+	ctx := context.Background()
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	mockRepo := new(MockWalletRepository)
+	mockCredentialManager := new(MockAPICredentialManagerService)
+	providerRegistry := wallet.NewProviderRegistry()
+	mockProvider := new(MockExchangeWalletProvider)
+	mockProvider.On("GetName").Return("MEXC")
+	providerRegistry.RegisterProvider(mockProvider)
+
+	txRepo := &recordingWalletTransactionRepo{}
+	notifier := &recordingBalanceChangeNotifier{}
+	service := NewWalletDataSyncService(mockRepo, mockCredentialManager, providerRegistry, txRepo, notifier, 0.5, &logger)
+
+	synced := &model.Wallet{
+		ID:       "wallet123",
+		UserID:   "user123",
+		Type:     model.WalletTypeExchange,
+		Exchange: "MEXC",
+		Balances: map[model.Asset]*model.Balance{
+			model.AssetBTC: {Asset: model.AssetBTC, Free: after, Total: after},
+		},
+	}
+	credential := &model.APICredential{ID: "cred123", APIKey: "api_key", APISecret: "api_secret"}
+
+	mockRepo.On("GetByID", ctx, "wallet123").Return(stored, nil)
+	mockRepo.On("Save", ctx, mock.AnythingOfType("*model.Wallet")).Return(nil)
+	mockRepo.On("SaveBalanceHistory", ctx, mock.AnythingOfType("*model.BalanceHistory")).Return(nil)
+	mockCredentialManager.On("GetCredentialForExchange", ctx, "user123", "MEXC").Return(credential, nil)
+	mockCredentialManager.On("MarkCredentialAsUsed", ctx, "cred123").Return(nil)
+	mockProvider.On("SetAPICredentials", ctx, "api_key", "api_secret").Return(nil)
+	mockProvider.On("GetBalance", ctx, stored).Return(synced, nil)
+
+	_, err := service.SyncWallet(ctx, "wallet123")
+	require.NoError(t, err)
+
+	return txRepo, notifier
+}
+
+func TestSyncWalletDetectsDeposit(t *testing.T) {
+	txRepo, notifier := syncWalletWithBalances(t, 1.0, 1.5)
+
+	require.Len(t, notifier.notified, 1)
+	tx := notifier.notified[0]
+	assert.Equal(t, model.TransactionTypeDeposit, tx.Type)
+	assert.Equal(t, model.AssetBTC, tx.Asset)
+	assert.InDelta(t, 0.5, tx.Amount, 1e-9)
+	assert.Equal(t, 1.0, tx.PreviousBalance)
+	assert.Equal(t, 1.5, tx.NewBalance)
+	assert.Equal(t, "wallet123", tx.WalletID)
+	assert.Equal(t, "user123", tx.UserID)
+
+	require.Len(t, txRepo.saved, 1)
+	assert.Equal(t, tx, txRepo.saved[0])
+}
+
+func TestSyncWalletDetectsWithdrawal(t *testing.T) {
+	_, notifier := syncWalletWithBalances(t, 2.0, 1.0)
+
+	require.Len(t, notifier.notified, 1)
+	assert.Equal(t, model.TransactionTypeWithdrawal, notifier.notified[0].Type)
+	assert.InDelta(t, 1.0, notifier.notified[0].Amount, 1e-9)
+}
+
+func TestSyncWalletIgnoresSubThresholdNoise(t *testing.T) {
+	// 0.1% change is below the 0.5% threshold
+	txRepo, notifier := syncWalletWithBalances(t, 1.0, 1.001)
+
+	assert.Empty(t, notifier.notified)
+	assert.Empty(t, txRepo.saved)
+}
+
+func TestSyncWalletFirstSyncRecordsBaseline(t *testing.T) {
+	// A wallet never synced holds its existing balance, which is not a deposit
+	txRepo, notifier := syncStoredWallet(t, &model.Wallet{
+		ID:       "wallet123",
+		UserID:   "user123",
+		Type:     model.WalletTypeExchange,
+		Exchange: "MEXC",
+	}, 2.0)
+
+	assert.Empty(t, notifier.notified)
+	assert.Empty(t, txRepo.saved)
+}
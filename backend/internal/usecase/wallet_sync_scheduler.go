@@ -0,0 +1,317 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+)
+
+// Default wallet sync schedule
+const (
+	DefaultWalletSyncInterval      = 15 * time.Minute
+	DefaultWalletSyncCheckInterval = 30 * time.Second
+	DefaultWalletSyncJitter        = 0.1
+	DefaultWalletSyncMaxBackoff    = 4 * time.Hour
+)
+
+// Outcomes of a scheduled wallet sync
+const (
+	WalletSyncResultSuccess = "success"
+	WalletSyncResultFailed  = "failed"
+	// WalletSyncResultSkipped means the user has no active credentials to sync with
+	WalletSyncResultSkipped = "skipped"
+)
+
+// WalletSyncScheduleConfig configures the wallet sync scheduler
+type WalletSyncScheduleConfig struct {
+	// Interval is the time between syncs of a user's wallets
+	Interval time.Duration
+	// UserIntervals overrides Interval for individual users, keyed by user ID. Keys match
+	// case-insensitively, as configuration keys are lowercased when loaded.
+	UserIntervals map[string]time.Duration
+	// CheckInterval is how often the scheduler looks for users due a sync
+	CheckInterval time.Duration
+	// Jitter spreads syncs by up to this fraction of the interval either way, so users
+	// scheduled together do not all hit the providers at once
+	Jitter float64
+	// MaxBackoff caps the delay after consecutive failures
+	MaxBackoff time.Duration
+}
+
+// UserWalletSyncStatus is the scheduler's record of a user's wallet syncs
+type UserWalletSyncStatus struct {
+	UserID string `json:"user_id"`
+	// Result is the outcome of the latest attempt
+	Result      string     `json:"result"`
+	LastAttempt time.Time  `json:"last_attempt"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	// ConsecutiveFailures is the number of failed attempts since the last success
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	WalletsSynced       int       `json:"wallets_synced"`
+	NextSync            time.Time `json:"next_sync"`
+}
+
+// WalletSyncScheduler periodically syncs the wallets of every user with active credentials
+type WalletSyncScheduler struct {
+	syncService  WalletDataSyncService
+	userRepo     port.UserRepository
+	credentials  APICredentialManagerService
+	config       WalletSyncScheduleConfig
+	logger       *zerolog.Logger
+	now          func() time.Time
+	randFloat    func() float64
+	mu           sync.RWMutex
+	statuses     map[string]*UserWalletSyncStatus
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+	isRunning    bool
+	runningMutex sync.Mutex
+}
+
+// NewWalletSyncScheduler creates a WalletSyncScheduler. Unset config fields take their defaults.
+func NewWalletSyncScheduler(
+	syncService WalletDataSyncService,
+	userRepo port.UserRepository,
+	credentials APICredentialManagerService,
+	config WalletSyncScheduleConfig,
+	logger *zerolog.Logger,
+) *WalletSyncScheduler {
+	if config.Interval <= 0 {
+		config.Interval = DefaultWalletSyncInterval
+	}
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = DefaultWalletSyncCheckInterval
+	}
+	if config.Jitter < 0 || config.Jitter >= 1 {
+		config.Jitter = DefaultWalletSyncJitter
+	}
+	if config.MaxBackoff < config.Interval {
+		config.MaxBackoff = config.Interval
+	}
+	userIntervals := make(map[string]time.Duration, len(config.UserIntervals))
+	for userID, interval := range config.UserIntervals {
+		userIntervals[strings.ToLower(userID)] = interval
+	}
+	config.UserIntervals = userIntervals
+	return &WalletSyncScheduler{
+		syncService: syncService,
+		userRepo:    userRepo,
+		credentials: credentials,
+		config:      config,
+		logger:      logger,
+		now:         time.Now,
+		randFloat:   rand.Float64,
+		statuses:    make(map[string]*UserWalletSyncStatus),
+	}
+}
+
+// Start runs the scheduler in the background until Stop is called or ctx is done
+func (s *WalletSyncScheduler) Start(ctx context.Context) error {
+	s.runningMutex.Lock()
+	defer s.runningMutex.Unlock()
+	if s.isRunning {
+		return nil
+	}
+	s.isRunning = true
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.config.CheckInterval)
+		defer ticker.Stop()
+		for {
+			s.RunDue(ctx)
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	s.logger.Info().
+		Dur("interval", s.config.Interval).
+		Float64("jitter", s.config.Jitter).
+		Dur("maxBackoff", s.config.MaxBackoff).
+		Msg("Started wallet sync scheduler")
+	return nil
+}
+
+// Stop stops the scheduler, cancelling a sync in progress and waiting for it to return
+func (s *WalletSyncScheduler) Stop() {
+	s.runningMutex.Lock()
+	defer s.runningMutex.Unlock()
+	if !s.isRunning {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+	s.isRunning = false
+	s.logger.Info().Msg("Stopped wallet sync scheduler")
+}
+
+// RunDue syncs the wallets of every user whose next sync is due. Users seen for the
+// first time are given a jittered first sync rather than all being synced at once.
+func (s *WalletSyncScheduler) RunDue(ctx context.Context) {
+	users, err := s.userRepo.List(ctx)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to list users for wallet sync")
+		return
+	}
+
+	for _, user := range users {
+		if ctx.Err() != nil {
+			return
+		}
+		now := s.now()
+
+		s.mu.Lock()
+		status, known := s.statuses[user.ID]
+		if !known {
+			status = &UserWalletSyncStatus{
+				UserID:   user.ID,
+				NextSync: now.Add(time.Duration(s.randFloat() * float64(s.intervalFor(user.ID)))),
+			}
+			s.statuses[user.ID] = status
+		}
+		due := !now.Before(status.NextSync)
+		s.mu.Unlock()
+
+		if due {
+			s.SyncUser(ctx, user.ID)
+		}
+	}
+}
+
+// SyncUser syncs a user's wallets now and schedules the next sync. Users without an
+// active credential are skipped; failures push the next sync back exponentially.
+func (s *WalletSyncScheduler) SyncUser(ctx context.Context, userID string) UserWalletSyncStatus {
+	var synced int
+	var err error
+	active, credErr := s.hasActiveCredential(ctx, userID)
+	switch {
+	case credErr != nil:
+		err = credErr
+	case active:
+		var wallets []*model.Wallet
+		wallets, err = s.syncService.SyncWalletsByUserID(ctx, userID)
+		synced = len(wallets)
+	}
+
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status, ok := s.statuses[userID]
+	if !ok {
+		status = &UserWalletSyncStatus{UserID: userID}
+		s.statuses[userID] = status
+	}
+	status.LastAttempt = now
+
+	interval := s.intervalFor(userID)
+	switch {
+	case err != nil:
+		status.Result = WalletSyncResultFailed
+		status.LastError = err.Error()
+		status.ConsecutiveFailures++
+		status.NextSync = now.Add(s.backoff(interval, status.ConsecutiveFailures))
+		s.logger.Warn().Err(err).
+			Str("userID", userID).
+			Int("failures", status.ConsecutiveFailures).
+			Time("nextSync", status.NextSync).
+			Msg("Scheduled wallet sync failed, backing off")
+	case !active:
+		status.Result = WalletSyncResultSkipped
+		status.LastError = ""
+		status.ConsecutiveFailures = 0
+		status.NextSync = now.Add(s.jittered(interval))
+		s.logger.Debug().Str("userID", userID).Msg("Skipping wallet sync for user without active credentials")
+	default:
+		status.Result = WalletSyncResultSuccess
+		status.LastSuccess = &now
+		status.LastError = ""
+		status.ConsecutiveFailures = 0
+		status.WalletsSynced = synced
+		status.NextSync = now.Add(s.jittered(interval))
+	}
+	return *status
+}
+
+// Status returns the sync status of a user, or false if the scheduler has not seen them
+func (s *WalletSyncScheduler) Status(userID string) (UserWalletSyncStatus, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	status, ok := s.statuses[userID]
+	if !ok {
+		return UserWalletSyncStatus{}, false
+	}
+	return *status, true
+}
+
+// Statuses returns the sync status of every user the scheduler has seen, ordered by user ID
+func (s *WalletSyncScheduler) Statuses() []UserWalletSyncStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	statuses := make([]UserWalletSyncStatus, 0, len(s.statuses))
+	for _, status := range s.statuses {
+		statuses = append(statuses, *status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].UserID < statuses[j].UserID })
+	return statuses
+}
+
+// hasActiveCredential reports whether the user has a credential the providers can use
+func (s *WalletSyncScheduler) hasActiveCredential(ctx context.Context, userID string) (bool, error) {
+	credentials, err := s.credentials.ListCredentialsByUserID(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list credentials: %w", err)
+	}
+	for _, credential := range credentials {
+		if credential.Status == model.APICredentialStatusActive {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// intervalFor returns the sync interval of a user
+func (s *WalletSyncScheduler) intervalFor(userID string) time.Duration {
+	if interval, ok := s.config.UserIntervals[strings.ToLower(userID)]; ok && interval > 0 {
+		return interval
+	}
+	return s.config.Interval
+}
+
+// jittered returns interval moved by a random amount within the configured jitter
+func (s *WalletSyncScheduler) jittered(interval time.Duration) time.Duration {
+	spread := s.config.Jitter * float64(interval)
+	return interval + time.Duration((s.randFloat()*2-1)*spread)
+}
+
+// backoff returns the delay after the given number of consecutive failures: the interval
+// doubled for each failure, capped at MaxBackoff but never shorter than the interval
+func (s *WalletSyncScheduler) backoff(interval time.Duration, failures int) time.Duration {
+	limit := s.config.MaxBackoff
+	if limit < interval {
+		limit = interval
+	}
+	delay := interval
+	for i := 0; i < failures && delay < limit; i++ {
+		delay *= 2
+	}
+	if delay > limit {
+		delay = limit
+	}
+	return delay
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubUserRepository lists a fixed set of users
+type stubUserRepository struct {
+	port.UserRepository
+	users []*model.User
+}
+
+func (r *stubUserRepository) List(ctx context.Context) ([]*model.User, error) {
+	return r.users, nil
+}
+
+// stubUserCredentials lists credentials by user ID
+type stubUserCredentials struct {
+	APICredentialManagerService
+	byUser map[string][]*model.APICredential
+}
+
+func (s *stubUserCredentials) ListCredentialsByUserID(ctx context.Context, userID string) ([]*model.APICredential, error) {
+	return s.byUser[userID], nil
+}
+
+// countingWalletSync counts syncs per user and fails while err is set
+type countingWalletSync struct {
+	WalletDataSyncService
+	calls map[string]int
+	err   error
+}
+
+func (s *countingWalletSync) SyncWalletsByUserID(ctx context.Context, userID string) ([]*model.Wallet, error) {
+	s.calls[userID]++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []*model.Wallet{{ID: "wallet-" + userID, UserID: userID}}, nil
+}
+
+func newTestWalletSyncScheduler(users []string, credentials map[string][]*model.APICredential, syncer *countingWalletSync) (*WalletSyncScheduler, *time.Time) {
+	repo := &stubUserRepository{}
+	for _, id := range users {
+		repo.users = append(repo.users, &model.User{ID: id})
+	}
+	logger := zerolog.Nop()
+	scheduler := NewWalletSyncScheduler(syncer, repo, &stubUserCredentials{byUser: credentials}, WalletSyncScheduleConfig{
+		Interval:   10 * time.Minute,
+		Jitter:     0.1,
+		MaxBackoff: time.Hour,
+	}, &logger)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	scheduler.now = func() time.Time { return now }
+	// Always the middle of the jitter range, so delays are exact
+	scheduler.randFloat = func() float64 { return 0.5 }
+	return scheduler, &now
+}
+
+func TestWalletSyncScheduler_SkipsUsersWithoutActiveCredentials(t *testing.T) {
+	syncer := &countingWalletSync{calls: map[string]int{}}
+	scheduler, _ := newTestWalletSyncScheduler(
+		[]string{"no-creds", "revoked", "active"},
+		map[string][]*model.APICredential{
+			"revoked": {{ID: "c-1", UserID: "revoked", Status: model.APICredentialStatusRevoked}},
+			"active":  {{ID: "c-2", UserID: "active", Status: model.APICredentialStatusActive}},
+		},
+		syncer,
+	)
+
+	for _, userID := range []string{"no-creds", "revoked", "active"} {
+		scheduler.SyncUser(context.Background(), userID)
+	}
+
+	assert.Equal(t, map[string]int{"active": 1}, syncer.calls)
+
+	status, ok := scheduler.Status("no-creds")
+	require.True(t, ok)
+	assert.Equal(t, WalletSyncResultSkipped, status.Result)
+	assert.Nil(t, status.LastSuccess)
+
+	status, _ = scheduler.Status("revoked")
+	assert.Equal(t, WalletSyncResultSkipped, status.Result)
+
+	status, _ = scheduler.Status("active")
+	assert.Equal(t, WalletSyncResultSuccess, status.Result)
+	assert.Equal(t, 1, status.WalletsSynced)
+	require.NotNil(t, status.LastSuccess)
+}
+
+func TestWalletSyncScheduler_BacksOffOnErrors(t *testing.T) {
+	syncer := &countingWalletSync{calls: map[string]int{}, err: errors.New("provider unavailable")}
+	scheduler, now := newTestWalletSyncScheduler(
+		[]string{"user-1"},
+		map[string][]*model.APICredential{
+			"user-1": {{ID: "c-1", UserID: "user-1", Status: model.APICredentialStatusActive}},
+		},
+		syncer,
+	)
+	start := *now
+
+	// Each failure doubles the delay until it reaches the cap
+	for i, want := range []time.Duration{20 * time.Minute, 40 * time.Minute, time.Hour, time.Hour} {
+		status := scheduler.SyncUser(context.Background(), "user-1")
+		assert.Equal(t, WalletSyncResultFailed, status.Result)
+		assert.Equal(t, i+1, status.ConsecutiveFailures)
+		assert.Equal(t, "provider unavailable", status.LastError)
+		assert.Equal(t, now.Add(want), status.NextSync, "failure %d", i+1)
+	}
+
+	// Nothing is attempted before the backed-off time
+	*now = start.Add(59 * time.Minute)
+	scheduler.RunDue(context.Background())
+	assert.Equal(t, 4, syncer.calls["user-1"])
+
+	// Recovery resets the failures and returns to the normal interval
+	syncer.err = nil
+	*now = start.Add(time.Hour)
+	scheduler.RunDue(context.Background())
+	assert.Equal(t, 5, syncer.calls["user-1"])
+
+	status, _ := scheduler.Status("user-1")
+	assert.Equal(t, WalletSyncResultSuccess, status.Result)
+	assert.Zero(t, status.ConsecutiveFailures)
+	assert.Empty(t, status.LastError)
+	assert.Equal(t, now.Add(10*time.Minute), status.NextSync)
+}
+
+func TestWalletSyncScheduler_SpreadsFirstSyncsOverTheInterval(t *testing.T) {
+	syncer := &countingWalletSync{calls: map[string]int{}}
+	scheduler, now := newTestWalletSyncScheduler(
+		[]string{"User-1"},
+		map[string][]*model.APICredential{
+			"User-1": {{ID: "c-1", UserID: "User-1", Status: model.APICredentialStatusActive}},
+		},
+		syncer,
+	)
+	// Overrides are keyed by lowercased user IDs when loaded from configuration
+	scheduler.config.UserIntervals = map[string]time.Duration{"user-1": 30 * time.Minute}
+
+	scheduler.RunDue(context.Background())
+	assert.Empty(t, syncer.calls, "first sync is deferred by a random share of the interval")
+	status, ok := scheduler.Status("User-1")
+	require.True(t, ok)
+	assert.Equal(t, now.Add(15*time.Minute), status.NextSync)
+
+	*now = now.Add(15 * time.Minute)
+	scheduler.RunDue(context.Background())
+	assert.Equal(t, 1, syncer.calls["User-1"])
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/apperror"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// maxWebhookEndpointsPerUser bounds the endpoints a user can register
+const maxWebhookEndpointsPerUser = 10
+
+// WebhookEndpointUseCase manages the endpoints users receive bot events at
+type WebhookEndpointUseCase interface {
+	// CreateEndpoint registers an HTTPS endpoint for eventTypes, or every event type when
+	// empty. The returned endpoint carries the signing secret, which is not shown again.
+	CreateEndpoint(ctx context.Context, userID, endpointURL string, eventTypes []string) (*model.WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context, userID string) ([]*model.WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, userID, id string) error
+	// ListDeliveries returns the latest deliveries to one of the user's endpoints
+	ListDeliveries(ctx context.Context, userID, endpointID string, limit int) ([]*model.WebhookDelivery, error)
+}
+
+// webhookEndpointUseCase implements WebhookEndpointUseCase
+type webhookEndpointUseCase struct {
+	endpoints  port.WebhookEndpointRepository
+	deliveries port.WebhookDeliveryRepository
+	logger     *zerolog.Logger
+}
+
+// NewWebhookEndpointUseCase creates a new WebhookEndpointUseCase
+func NewWebhookEndpointUseCase(endpoints port.WebhookEndpointRepository, deliveries port.WebhookDeliveryRepository, logger *zerolog.Logger) WebhookEndpointUseCase {
+	return &webhookEndpointUseCase{
+		endpoints:  endpoints,
+		deliveries: deliveries,
+		logger:     logger,
+	}
+}
+
+// CreateEndpoint validates and stores a new endpoint with a fresh signing secret
+func (uc *webhookEndpointUseCase) CreateEndpoint(ctx context.Context, userID, endpointURL string, eventTypes []string) (*model.WebhookEndpoint, error) {
+	parsed, err := url.Parse(endpointURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return nil, apperror.NewValidation("Webhook URL must be an absolute https URL", map[string]string{"url": endpointURL}, err)
+	}
+	// Hostnames are checked again by the dispatcher once they are resolved
+	if host := parsed.Hostname(); strings.EqualFold(host, "localhost") || !isPublicWebhookHost(host) {
+		return nil, apperror.NewValidation("Webhook URL must not point to a local or private address", map[string]string{"url": endpointURL}, nil)
+	}
+	for _, eventType := range eventTypes {
+		if !isWebhookEventType(eventType) {
+			return nil, apperror.NewValidation("Unsupported webhook event type", map[string]interface{}{
+				"event_type": eventType,
+				"supported":  event.WebhookEventTypes,
+			}, nil)
+		}
+	}
+
+	existing, err := uc.endpoints.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+	if len(existing) >= maxWebhookEndpointsPerUser {
+		return nil, apperror.NewValidation(fmt.Sprintf("At most %d webhook endpoints can be registered", maxWebhookEndpointsPerUser), nil, nil)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	now := time.Now()
+	endpoint := &model.WebhookEndpoint{
+		ID:         uuid.New().String(),
+		UserID:     userID,
+		URL:        endpointURL,
+		Secret:     hex.EncodeToString(secret),
+		EventTypes: eventTypes,
+		Active:     true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := uc.endpoints.Save(ctx, endpoint); err != nil {
+		return nil, fmt.Errorf("failed to save webhook endpoint: %w", err)
+	}
+
+	uc.logger.Info().Str("userID", userID).Str("endpointID", endpoint.ID).Msg("Registered webhook endpoint")
+	return endpoint, nil
+}
+
+// ListEndpoints returns the user's endpoints
+func (uc *webhookEndpointUseCase) ListEndpoints(ctx context.Context, userID string) ([]*model.WebhookEndpoint, error) {
+	return uc.endpoints.ListByUser(ctx, userID)
+}
+
+// DeleteEndpoint removes one of the user's endpoints
+func (uc *webhookEndpointUseCase) DeleteEndpoint(ctx context.Context, userID, id string) error {
+	if _, err := uc.ownedEndpoint(ctx, userID, id); err != nil {
+		return err
+	}
+	if err := uc.endpoints.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete webhook endpoint: %w", err)
+	}
+	uc.logger.Info().Str("userID", userID).Str("endpointID", id).Msg("Deleted webhook endpoint")
+	return nil
+}
+
+// ListDeliveries returns the latest deliveries to one of the user's endpoints
+func (uc *webhookEndpointUseCase) ListDeliveries(ctx context.Context, userID, endpointID string, limit int) ([]*model.WebhookDelivery, error) {
+	if _, err := uc.ownedEndpoint(ctx, userID, endpointID); err != nil {
+		return nil, err
+	}
+	return uc.deliveries.ListByEndpoint(ctx, endpointID, limit)
+}
+
+// ownedEndpoint returns the endpoint if it belongs to the user. Other users' endpoints are
+// reported as not found.
+func (uc *webhookEndpointUseCase) ownedEndpoint(ctx context.Context, userID, id string) (*model.WebhookEndpoint, error) {
+	endpoint, err := uc.endpoints.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+	if endpoint == nil || endpoint.UserID != userID {
+		return nil, apperror.NewNotFound("webhook endpoint", id, nil)
+	}
+	return endpoint, nil
+}
+
+// isWebhookEventType reports whether eventType can be delivered to webhook endpoints
+func isWebhookEventType(eventType string) bool {
+	for _, supported := range event.WebhookEventTypes {
+		if string(supported) == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// isPublicWebhookHost reports whether host may be registered: hostnames are, and IP
+// literals only when webhooks may be delivered to them
+func isPublicWebhookHost(host string) bool {
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return true
+	}
+	return model.IsPublicWebhookAddress(ip)
+}
@@ -27,7 +27,12 @@ func NewAESEncryptionService() (*AESEncryptionService, error) {
 	if keyB64 == "" {
 		return nil, errors.New("MEXC_CRED_ENCRYPTION_KEY environment variable not set")
 	}
+	return NewAESEncryptionServiceWithKey(keyB64)
+}
 
+// NewAESEncryptionServiceWithKey creates an AESEncryptionService using a base64-encoded
+// 32-byte key
+func NewAESEncryptionServiceWithKey(keyB64 string) (*AESEncryptionService, error) {
 	key, err := base64.StdEncoding.DecodeString(keyB64)
 	if err != nil {
 		return nil, err
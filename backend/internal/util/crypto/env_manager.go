@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 )
@@ -261,3 +262,65 @@ func (m *EnvManager) DecryptEnvFile(inputPath, outputPath string) error {
 
 	return nil
 }
+
+// RekeyEnvFile re-encrypts the encrypted values of an env file under the manager's key.
+// Each ENC: value is decrypted with oldSvc and encrypted again in one pass; comments,
+// blank lines, plain values and the order of the lines are kept as they are. The output
+// is written to a temporary file that replaces outputPath once complete, so outputPath
+// may be inputPath to rekey a file in place.
+func (m *EnvManager) RekeyEnvFile(oldSvc EncryptionService, inputPath, outputPath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	input, err := os.ReadFile(inputPath)
+	if err != nil {
+		return fmt.Errorf("failed to read input env file: %w", err)
+	}
+
+	var output strings.Builder
+	scanner := bufio.NewScanner(strings.NewReader(string(input)))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		parts := strings.SplitN(line, "=", 2)
+		if line == "" || strings.HasPrefix(line, "#") || len(parts) != 2 || !strings.HasPrefix(strings.TrimSpace(parts[1]), "ENC:") {
+			output.WriteString(line + "\n")
+			continue
+		}
+
+		key := strings.TrimSpace(parts[0])
+		encryptedValue := strings.TrimPrefix(strings.TrimSpace(parts[1]), "ENC:")
+		decryptedValue, err := oldSvc.Decrypt([]byte(encryptedValue))
+		if err != nil {
+			return fmt.Errorf("failed to decrypt env value for %s with the old key: %w", key, err)
+		}
+		reencryptedValue, err := m.encryptionSvc.Encrypt(decryptedValue)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt env value for %s: %w", key, err)
+		}
+		// Keep the spacing around the key and before the value
+		leading := parts[1][:strings.Index(parts[1], "ENC:")]
+		fmt.Fprintf(&output, "%s=%sENC:%s\n", parts[0], leading, string(reencryptedValue))
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read input env file: %w", err)
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(outputPath), filepath.Base(outputPath)+".rekey-*")
+	if err != nil {
+		return fmt.Errorf("failed to create output env file: %w", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.WriteString(output.String()); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("failed to write output env file: %w", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("failed to write output env file: %w", err)
+	}
+	if err := os.Rename(tempFile.Name(), outputPath); err != nil {
+		return fmt.Errorf("failed to replace output env file: %w", err)
+	}
+	return nil
+}
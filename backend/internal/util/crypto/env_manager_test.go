@@ -1,12 +1,15 @@
 package crypto
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockEnvEncryptionService is a mock implementation of EncryptionService
@@ -134,3 +137,64 @@ TEST_KEY2=test-value-2
 	// Verify mocks
 	mockEncryptionSvc.AssertExpectations(t)
 }
+
+// taggedEncryptionService "encrypts" by tagging the plaintext with its key name, so tests
+// can tell which key a value is encrypted under
+type taggedEncryptionService struct {
+	key string
+}
+
+func (s taggedEncryptionService) Encrypt(plaintext string) ([]byte, error) {
+	return []byte(s.key + "|" + plaintext), nil
+}
+
+func (s taggedEncryptionService) Decrypt(ciphertext []byte) (string, error) {
+	key, plaintext, ok := strings.Cut(string(ciphertext), "|")
+	if !ok || key != s.key {
+		return "", fmt.Errorf("value is not encrypted under %s", s.key)
+	}
+	return plaintext, nil
+}
+
+func TestEnvManager_RekeyEnvFile(t *testing.T) {
+	envPath := filepath.Join(t.TempDir(), ".env")
+	content := `# Exchange credentials
+MEXC_API_KEY=ENC:old-key|api-key-1
+
+# Not secret
+LOG_LEVEL=debug
+ MEXC_SECRET_KEY = ENC:old-key|secret=with=equals
+`
+	require.NoError(t, os.WriteFile(envPath, []byte(content), 0600))
+
+	oldSvc := taggedEncryptionService{key: "old-key"}
+	newSvc := taggedEncryptionService{key: "new-key"}
+	manager := NewEnvManager(newSvc, "")
+
+	// Rekey in place
+	require.NoError(t, manager.RekeyEnvFile(oldSvc, envPath, envPath))
+
+	rekeyed, err := os.ReadFile(envPath)
+	require.NoError(t, err)
+	assert.Equal(t, `# Exchange credentials
+MEXC_API_KEY=ENC:new-key|api-key-1
+
+# Not secret
+LOG_LEVEL=debug
+ MEXC_SECRET_KEY = ENC:new-key|secret=with=equals
+`, string(rekeyed), "comments, plain values and ordering survive")
+
+	// The rekeyed file decrypts with the new key only
+	decryptedPath := filepath.Join(filepath.Dir(envPath), ".env.decrypted")
+	require.NoError(t, manager.DecryptEnvFile(envPath, decryptedPath))
+	decrypted, err := os.ReadFile(decryptedPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(decrypted), "MEXC_API_KEY=api-key-1")
+	assert.Contains(t, string(decrypted), "MEXC_SECRET_KEY=secret=with=equals")
+
+	err = NewEnvManager(oldSvc, "").RekeyEnvFile(oldSvc, envPath, envPath)
+	assert.Error(t, err, "values are no longer encrypted under the old key")
+	unchanged, err := os.ReadFile(envPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(rekeyed), string(unchanged), "a failed rekey leaves the file untouched")
+}
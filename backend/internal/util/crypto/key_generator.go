@@ -3,6 +3,7 @@ package crypto
 import (
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 )
@@ -89,3 +90,31 @@ func (g *KeyGenerator) RotateKeyConfig(currentConfig map[string]string) (map[str
 
 	return newConfig, nil
 }
+
+// VerifyKeyConfig checks that every sample ciphertext decrypts under the key set of
+// config, such as one returned by RotateKeyConfig, so a rotation does not orphan data
+// encrypted under a key the config no longer holds. Samples are decrypted the way the
+// enhanced encryption service does; legacy samples without a key ID are tried with the
+// config's current key only. The error names every sample that failed.
+func (g *KeyGenerator) VerifyKeyConfig(config map[string]string, samples [][]byte) error {
+	if len(samples) == 0 {
+		return errors.New("no sample ciphertexts to verify")
+	}
+
+	keyManager, err := NewKeyManagerFromConfig(config)
+	if err != nil {
+		return fmt.Errorf("invalid key config: %w", err)
+	}
+	service := NewEnhancedEncryptionService(keyManager)
+
+	var errs []error
+	for i, sample := range samples {
+		if _, err := service.Decrypt(sample); err != nil {
+			errs = append(errs, fmt.Errorf("sample %d does not decrypt: %w", i+1, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d samples would be orphaned: %w", len(errs), len(samples), errors.Join(errs...))
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encryptUnder encrypts plaintext with the current key of config
+func encryptUnder(t *testing.T, config map[string]string, plaintext string) []byte {
+	t.Helper()
+	// The enhanced service labels ciphertexts with the key ID from the environment
+	t.Setenv("ENCRYPTION_CURRENT_KEY_ID", config["ENCRYPTION_CURRENT_KEY_ID"])
+	keyManager, err := NewKeyManagerFromConfig(config)
+	require.NoError(t, err)
+	ciphertext, err := NewEnhancedEncryptionService(keyManager).Encrypt(plaintext)
+	require.NoError(t, err)
+	return ciphertext
+}
+
+func TestKeyGenerator_VerifyKeyConfig(t *testing.T) {
+	generator := NewKeyGenerator()
+	original, err := generator.GenerateKeyConfig()
+	require.NoError(t, err)
+	sample := encryptUnder(t, original, "api-secret")
+
+	rotated, err := generator.RotateKeyConfig(original)
+	require.NoError(t, err)
+	assert.NotEqual(t, original["ENCRYPTION_CURRENT_KEY_ID"], rotated["ENCRYPTION_CURRENT_KEY_ID"])
+	newSample := encryptUnder(t, rotated, "new-secret")
+
+	assert.NoError(t, generator.VerifyKeyConfig(rotated, [][]byte{sample, newSample}),
+		"the rotated config keeps the old key, so old and new ciphertexts decrypt")
+}
+
+func TestKeyGenerator_VerifyKeyConfigRejectsMissingOldKey(t *testing.T) {
+	generator := NewKeyGenerator()
+	original, err := generator.GenerateKeyConfig()
+	require.NoError(t, err)
+	sample := encryptUnder(t, original, "api-secret")
+
+	// A fresh config instead of a rotation drops the old key
+	replacement, err := generator.GenerateKeyConfig()
+	require.NoError(t, err)
+	newSample := encryptUnder(t, replacement, "new-secret")
+
+	err = generator.VerifyKeyConfig(replacement, [][]byte{newSample, sample})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 2 samples would be orphaned")
+	assert.Contains(t, err.Error(), "sample 2 does not decrypt")
+
+	assert.Error(t, generator.VerifyKeyConfig(replacement, nil), "verifying nothing is not a success")
+	assert.Error(t, generator.VerifyKeyConfig(map[string]string{}, [][]byte{sample}))
+}
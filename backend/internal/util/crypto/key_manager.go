@@ -54,6 +54,26 @@ func NewEnvKeyManager() (*EnvKeyManager, error) {
 	return manager, nil
 }
 
+// NewKeyManagerFromConfig creates an EnvKeyManager from a key configuration holding
+// ENCRYPTION_CURRENT_KEY_ID and ENCRYPTION_KEYS, such as one made by KeyGenerator
+func NewKeyManagerFromConfig(config map[string]string) (*EnvKeyManager, error) {
+	manager := &EnvKeyManager{
+		keys: make(map[string]EncryptionKey),
+	}
+
+	if config["ENCRYPTION_CURRENT_KEY_ID"] == "" {
+		return nil, errors.New("ENCRYPTION_CURRENT_KEY_ID not found in config")
+	}
+	if config["ENCRYPTION_KEYS"] == "" {
+		return nil, errors.New("ENCRYPTION_KEYS not found in config")
+	}
+	if err := manager.loadKeys(config["ENCRYPTION_CURRENT_KEY_ID"], config["ENCRYPTION_KEYS"]); err != nil {
+		return nil, err
+	}
+
+	return manager, nil
+}
+
 // loadKeysFromEnv loads encryption keys from environment variables
 func (m *EnvKeyManager) loadKeysFromEnv() error {
 	// Get current key ID
@@ -68,6 +88,11 @@ func (m *EnvKeyManager) loadKeysFromEnv() error {
 		return errors.New("ENCRYPTION_KEYS environment variable not set")
 	}
 
+	return m.loadKeys(currentKeyID, keysEnv)
+}
+
+// loadKeys parses comma-separated "<id>:<base64 key>" pairs and sets the current key
+func (m *EnvKeyManager) loadKeys(currentKeyID, keysEnv string) error {
 	// Parse keys
 	keyPairs := strings.Split(keysEnv, ",")
 	for _, pair := range keyPairs {
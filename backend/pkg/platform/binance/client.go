@@ -0,0 +1,360 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/logger"
+	"github.com/rs/zerolog"
+)
+
+// DefaultBaseURL is the production Binance REST endpoint
+const DefaultBaseURL = "https://api.binance.com"
+
+// ExchangeName is the name the client is registered under
+const ExchangeName = "binance"
+
+// orderBookDepths are the depths the Binance depth endpoint accepts, smallest first
+var orderBookDepths = []int{5, 10, 20, 50, 100, 500, 1000, 5000}
+
+// APIError is returned when Binance answers with a non-200 status
+type APIError struct {
+	StatusCode int
+	Code       int    // Binance error code, zero when the body could not be decoded
+	Message    string // Binance error message
+}
+
+// Error implements error
+func (e *APIError) Error() string {
+	if e.Code == 0 && e.Message == "" {
+		return fmt.Sprintf("request failed with status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("API error %d: %s", e.Code, e.Message)
+}
+
+// Client is a read-only Binance client for public market data. It implements
+// port.ExchangeMarketData; trading is not supported.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	logger     *zerolog.Logger
+}
+
+// Ensure Client implements port.ExchangeMarketData
+var _ port.ExchangeMarketData = (*Client)(nil)
+
+// ClientOption configures optional Client behaviour
+type ClientOption func(*Client)
+
+// WithHTTPClient replaces the HTTP client used for requests
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// NewClient creates a Binance client sending requests to baseURL. An empty baseURL
+// selects the production endpoint.
+func NewClient(baseURL string, logger *zerolog.Logger, options ...ClientOption) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	client := &Client{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		logger:     logger,
+	}
+	for _, option := range options {
+		option(client)
+	}
+	return client
+}
+
+// symbolResponse is a symbol in the exchangeInfo response
+type symbolResponse struct {
+	Symbol               string   `json:"symbol"`
+	Status               string   `json:"status"`
+	BaseAsset            string   `json:"baseAsset"`
+	BaseAssetPrecision   int      `json:"baseAssetPrecision"`
+	QuoteAsset           string   `json:"quoteAsset"`
+	QuoteAssetPrecision  int      `json:"quoteAssetPrecision"`
+	OrderTypes           []string `json:"orderTypes"`
+	IsSpotTradingAllowed bool     `json:"isSpotTradingAllowed"`
+	Permissions          []string `json:"permissions"`
+	Filters              []struct {
+		FilterType  string `json:"filterType"`
+		TickSize    string `json:"tickSize"`
+		MinQty      string `json:"minQty"`
+		MaxQty      string `json:"maxQty"`
+		StepSize    string `json:"stepSize"`
+		MinNotional string `json:"minNotional"`
+	} `json:"filters"`
+}
+
+// toModel converts the symbol, reading its trading rules from the filters
+func (s symbolResponse) toModel() model.SymbolInfo {
+	info := model.SymbolInfo{
+		Symbol:               s.Symbol,
+		Status:               s.Status,
+		BaseAsset:            s.BaseAsset,
+		BaseAssetPrecision:   s.BaseAssetPrecision,
+		QuoteAsset:           s.QuoteAsset,
+		QuoteAssetPrecision:  s.QuoteAssetPrecision,
+		OrderTypes:           s.OrderTypes,
+		IsSpotTradingAllowed: s.IsSpotTradingAllowed,
+		Permissions:          s.Permissions,
+	}
+	for _, filter := range s.Filters {
+		switch filter.FilterType {
+		case "PRICE_FILTER":
+			info.TickSize = filter.TickSize
+			info.PricePrecision = decimals(filter.TickSize)
+		case "LOT_SIZE":
+			info.MinLotSize = filter.MinQty
+			info.MaxLotSize = filter.MaxQty
+			info.StepSize = filter.StepSize
+			info.QuantityPrecision = decimals(filter.StepSize)
+		case "MIN_NOTIONAL", "NOTIONAL":
+			info.MinNotional = filter.MinNotional
+		}
+	}
+	return info
+}
+
+// GetExchangeInfo retrieves information about all symbols on the exchange
+func (c *Client) GetExchangeInfo(ctx context.Context) (*model.ExchangeInfo, error) {
+	var response struct {
+		Symbols []symbolResponse `json:"symbols"`
+	}
+	if err := c.get(ctx, "/api/v3/exchangeInfo", nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get exchange info: %w", err)
+	}
+
+	info := &model.ExchangeInfo{Symbols: make([]model.SymbolInfo, len(response.Symbols))}
+	for i, s := range response.Symbols {
+		info.Symbols[i] = s.toModel()
+	}
+	return info, nil
+}
+
+// GetSymbolInfo retrieves detailed information about a trading symbol
+func (c *Client) GetSymbolInfo(ctx context.Context, symbol string) (*model.SymbolInfo, error) {
+	var response struct {
+		Symbols []symbolResponse `json:"symbols"`
+	}
+	if err := c.get(ctx, "/api/v3/exchangeInfo", url.Values{"symbol": {symbol}}, &response); err != nil {
+		return nil, fmt.Errorf("failed to get symbol info: %w", err)
+	}
+	if len(response.Symbols) == 0 {
+		return nil, fmt.Errorf("symbol %s not found in exchangeInfo", symbol)
+	}
+	info := response.Symbols[0].toModel()
+	return &info, nil
+}
+
+// GetMarketData retrieves the 24h ticker of a symbol
+func (c *Client) GetMarketData(ctx context.Context, symbol string) (*model.Ticker, error) {
+	var response struct {
+		Symbol             string `json:"symbol"`
+		PriceChange        string `json:"priceChange"`
+		PriceChangePercent string `json:"priceChangePercent"`
+		PrevClosePrice     string `json:"prevClosePrice"`
+		LastPrice          string `json:"lastPrice"`
+		BidPrice           string `json:"bidPrice"`
+		BidQty             string `json:"bidQty"`
+		AskPrice           string `json:"askPrice"`
+		AskQty             string `json:"askQty"`
+		OpenPrice          string `json:"openPrice"`
+		HighPrice          string `json:"highPrice"`
+		LowPrice           string `json:"lowPrice"`
+		Volume             string `json:"volume"`
+		QuoteVolume        string `json:"quoteVolume"`
+		CloseTime          int64  `json:"closeTime"`
+		Count              int64  `json:"count"`
+	}
+	if err := c.get(ctx, "/api/v3/ticker/24hr", url.Values{"symbol": {symbol}}, &response); err != nil {
+		return nil, fmt.Errorf("failed to get market data: %w", err)
+	}
+
+	return &model.Ticker{
+		Symbol:             response.Symbol,
+		Exchange:           ExchangeName,
+		LastPrice:          parseFloat(response.LastPrice),
+		PriceChange:        parseFloat(response.PriceChange),
+		PriceChangePercent: parseFloat(response.PriceChangePercent),
+		HighPrice:          parseFloat(response.HighPrice),
+		LowPrice:           parseFloat(response.LowPrice),
+		Volume:             parseFloat(response.Volume),
+		QuoteVolume:        parseFloat(response.QuoteVolume),
+		OpenPrice:          parseFloat(response.OpenPrice),
+		PrevClosePrice:     parseFloat(response.PrevClosePrice),
+		BidPrice:           parseFloat(response.BidPrice),
+		BidQty:             parseFloat(response.BidQty),
+		AskPrice:           parseFloat(response.AskPrice),
+		AskQty:             parseFloat(response.AskQty),
+		Count:              response.Count,
+		Timestamp:          time.UnixMilli(response.CloseTime),
+	}, nil
+}
+
+// GetKlines retrieves the latest candles of a symbol, oldest first. Malformed rows are
+// skipped.
+func (c *Client) GetKlines(ctx context.Context, symbol string, interval model.KlineInterval, limit int) ([]*model.Kline, error) {
+	query := url.Values{
+		"symbol":   {symbol},
+		"interval": {string(interval)},
+		"limit":    {strconv.Itoa(limit)},
+	}
+	var rows [][]json.RawMessage
+	if err := c.get(ctx, "/api/v3/klines", query, &rows); err != nil {
+		return nil, fmt.Errorf("failed to get klines: %w", err)
+	}
+
+	klines := make([]*model.Kline, 0, len(rows))
+	for _, row := range rows {
+		kline, err := parseKline(row)
+		if err != nil {
+			c.logger.Warn().Err(err).Str("symbol", symbol).Str("interval", string(interval)).Msg("Skipping malformed Binance kline")
+			continue
+		}
+		kline.Symbol = symbol
+		kline.Interval = interval
+		klines = append(klines, kline)
+	}
+	return klines, nil
+}
+
+// parseKline parses a kline row: open time, open, high, low, close, volume, close time,
+// quote volume and trade count, followed by fields that are not used
+func parseKline(row []json.RawMessage) (*model.Kline, error) {
+	if len(row) < 9 {
+		return nil, fmt.Errorf("kline has %d fields, want at least 9", len(row))
+	}
+	var openTime, closeTime, trades int64
+	var open, high, low, close, volume, quoteVolume string
+	for i, target := range []interface{}{&openTime, &open, &high, &low, &close, &volume, &closeTime, &quoteVolume, &trades} {
+		if err := json.Unmarshal(row[i], target); err != nil {
+			return nil, fmt.Errorf("kline field %d: %w", i, err)
+		}
+	}
+	closeAt := time.UnixMilli(closeTime)
+	return &model.Kline{
+		OpenTime:    time.UnixMilli(openTime),
+		CloseTime:   closeAt,
+		Open:        parseFloat(open),
+		High:        parseFloat(high),
+		Low:         parseFloat(low),
+		Close:       parseFloat(close),
+		Volume:      parseFloat(volume),
+		QuoteVolume: parseFloat(quoteVolume),
+		TradeCount:  trades,
+		IsClosed:    closeAt.Before(time.Now()),
+	}, nil
+}
+
+// GetOrderBook retrieves the order book of a symbol. A depth Binance does not accept is
+// rounded up to the next one it does.
+func (c *Client) GetOrderBook(ctx context.Context, symbol string, depth int) (*model.OrderBook, error) {
+	limit := orderBookDepths[len(orderBookDepths)-1]
+	for _, d := range orderBookDepths {
+		if depth <= d {
+			limit = d
+			break
+		}
+	}
+
+	var response struct {
+		LastUpdateID int64      `json:"lastUpdateId"`
+		Bids         [][]string `json:"bids"` // [price, quantity]
+		Asks         [][]string `json:"asks"` // [price, quantity]
+	}
+	query := url.Values{"symbol": {symbol}, "limit": {strconv.Itoa(limit)}}
+	if err := c.get(ctx, "/api/v3/depth", query, &response); err != nil {
+		return nil, fmt.Errorf("failed to get order book: %w", err)
+	}
+
+	return &model.OrderBook{
+		Symbol:       symbol,
+		LastUpdateID: response.LastUpdateID,
+		Bids:         parseLevels(response.Bids),
+		Asks:         parseLevels(response.Asks),
+		Timestamp:    time.Now(),
+	}, nil
+}
+
+// parseLevels converts [price, quantity] pairs, skipping malformed ones
+func parseLevels(levels [][]string) []model.OrderBookEntry {
+	entries := make([]model.OrderBookEntry, 0, len(levels))
+	for _, level := range levels {
+		if len(level) < 2 {
+			continue
+		}
+		entries = append(entries, model.OrderBookEntry{
+			Price:    parseFloat(level[0]),
+			Quantity: parseFloat(level[1]),
+		})
+	}
+	return entries
+}
+
+// get sends a GET request for a public endpoint and decodes the JSON response into out
+func (c *Client) get(ctx context.Context, endpoint string, query url.Values, out interface{}) error {
+	target := c.baseURL + endpoint
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	requestLogger := logger.Enrich(ctx, c.logger)
+	requestLogger.Debug().Str("endpoint", endpoint).Msg("Sending Binance request")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		requestLogger.Warn().Err(err).Str("endpoint", endpoint).Msg("Binance request failed")
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		requestLogger.Warn().Int("status", resp.StatusCode).Str("endpoint", endpoint).Msg("Binance request returned error status")
+		var errResp struct {
+			Code    int    `json:"code"`
+			Message string `json:"msg"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+			return &APIError{StatusCode: resp.StatusCode}
+		}
+		return &APIError{StatusCode: resp.StatusCode, Code: errResp.Code, Message: errResp.Message}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// parseFloat parses a decimal string, returning zero for an empty or malformed one
+func parseFloat(value string) float64 {
+	f, _ := strconv.ParseFloat(value, 64)
+	return f
+}
+
+// decimals returns the number of decimal places a step such as "0.00100000" allows
+func decimals(step string) int {
+	dot := strings.IndexByte(step, '.')
+	if dot < 0 {
+		return 0
+	}
+	return len(strings.TrimRight(step[dot+1:], "0"))
+}
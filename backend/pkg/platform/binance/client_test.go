@@ -0,0 +1,202 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exchangeInfoJSON = `{
+  "timezone": "UTC",
+  "serverTime": 1717000000000,
+  "rateLimits": [{"rateLimitType": "REQUEST_WEIGHT", "interval": "MINUTE", "intervalNum": 1, "limit": 6000}],
+  "symbols": [{
+    "symbol": "ETHBTC",
+    "status": "TRADING",
+    "baseAsset": "ETH",
+    "baseAssetPrecision": 8,
+    "quoteAsset": "BTC",
+    "quotePrecision": 8,
+    "quoteAssetPrecision": 8,
+    "orderTypes": ["LIMIT", "LIMIT_MAKER", "MARKET", "STOP_LOSS_LIMIT", "TAKE_PROFIT_LIMIT"],
+    "isSpotTradingAllowed": true,
+    "permissions": [],
+    "filters": [
+      {"filterType": "PRICE_FILTER", "minPrice": "0.00001000", "maxPrice": "922327.00000000", "tickSize": "0.00001000"},
+      {"filterType": "LOT_SIZE", "minQty": "0.00010000", "maxQty": "100000.00000000", "stepSize": "0.00010000"},
+      {"filterType": "ICEBERG_PARTS", "limit": 10},
+      {"filterType": "NOTIONAL", "minNotional": "0.00010000", "applyMinToMarket": true, "maxNotional": "9000000.00000000", "applyMaxToMarket": false, "avgPriceMins": 5},
+      {"filterType": "MAX_NUM_ORDERS", "maxNumOrders": 200}
+    ]
+  }]
+}`
+
+const tickerJSON = `{
+  "symbol": "BTCUSDT",
+  "priceChange": "-94.99999800",
+  "priceChangePercent": "-95.960",
+  "weightedAvgPrice": "0.29628482",
+  "prevClosePrice": "0.10002000",
+  "lastPrice": "4.00000200",
+  "lastQty": "200.00000000",
+  "bidPrice": "4.00000000",
+  "bidQty": "100.00000000",
+  "askPrice": "4.00000200",
+  "askQty": "100.00000000",
+  "openPrice": "99.00000000",
+  "highPrice": "100.00000000",
+  "lowPrice": "0.10000000",
+  "volume": "8913.30000000",
+  "quoteVolume": "15.30000000",
+  "openTime": 1499783499040,
+  "closeTime": 1499869899040,
+  "firstId": 28385,
+  "lastId": 28460,
+  "count": 76
+}`
+
+const klinesJSON = `[
+  [1499040000000, "0.01634790", "0.80000000", "0.01575800", "0.01577100", "148976.11427815", 1499644799999, "2434.19055334", 308, "1756.87402397", "28.46694368", "0"],
+  [1499644800000, "0.01577100", "0.01600000", "0.01500000", "0.01590000", "1000.00000000", 1500249599999, "15.50000000", 12, "500.00000000", "7.75000000", "0"],
+  [1500249600000, "bad"]
+]`
+
+const depthJSON = `{
+  "lastUpdateId": 1027024,
+  "bids": [["4.00000000", "431.00000000"], ["3.90000000", "12.50000000"]],
+  "asks": [["4.00000200", "12.00000000"]]
+}`
+
+// newTestClient serves body for path and records the query of each request
+func newTestClient(t *testing.T, path, body string) (*Client, *[]string) {
+	t.Helper()
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	logger := zerolog.Nop()
+	return NewClient(server.URL+"/", &logger), &queries
+}
+
+func TestClient_GetExchangeInfo(t *testing.T) {
+	client, _ := newTestClient(t, "/api/v3/exchangeInfo", exchangeInfoJSON)
+
+	info, err := client.GetExchangeInfo(context.Background())
+	require.NoError(t, err)
+	require.Len(t, info.Symbols, 1)
+
+	symbol := info.Symbols[0]
+	assert.Equal(t, "ETHBTC", symbol.Symbol)
+	assert.Equal(t, "TRADING", symbol.Status)
+	assert.Equal(t, "ETH", symbol.BaseAsset)
+	assert.Equal(t, "BTC", symbol.QuoteAsset)
+	assert.Equal(t, 8, symbol.QuoteAssetPrecision)
+	assert.True(t, symbol.IsSpotTradingAllowed)
+	assert.Equal(t, "0.00001000", symbol.TickSize)
+	assert.Equal(t, 5, symbol.PricePrecision)
+	assert.Equal(t, "0.00010000", symbol.MinLotSize)
+	assert.Equal(t, "100000.00000000", symbol.MaxLotSize)
+	assert.Equal(t, 4, symbol.QuantityPrecision)
+	assert.Equal(t, "0.00010000", symbol.MinNotional)
+}
+
+func TestClient_GetSymbolInfo(t *testing.T) {
+	client, queries := newTestClient(t, "/api/v3/exchangeInfo", exchangeInfoJSON)
+
+	info, err := client.GetSymbolInfo(context.Background(), "ETHBTC")
+	require.NoError(t, err)
+	assert.Equal(t, "ETHBTC", info.Symbol)
+	assert.Equal(t, []string{"symbol=ETHBTC"}, *queries)
+}
+
+func TestClient_GetMarketData(t *testing.T) {
+	client, queries := newTestClient(t, "/api/v3/ticker/24hr", tickerJSON)
+
+	ticker, err := client.GetMarketData(context.Background(), "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"symbol=BTCUSDT"}, *queries)
+
+	assert.Equal(t, "BTCUSDT", ticker.Symbol)
+	assert.Equal(t, ExchangeName, ticker.Exchange)
+	assert.Equal(t, 4.000002, ticker.LastPrice)
+	assert.Equal(t, -94.999998, ticker.PriceChange)
+	assert.Equal(t, -95.96, ticker.PriceChangePercent)
+	assert.Equal(t, 100.0, ticker.HighPrice)
+	assert.Equal(t, 0.1, ticker.LowPrice)
+	assert.Equal(t, 8913.3, ticker.Volume)
+	assert.Equal(t, 15.3, ticker.QuoteVolume)
+	assert.Equal(t, 4.0, ticker.BidPrice)
+	assert.Equal(t, 4.000002, ticker.AskPrice)
+	assert.Equal(t, int64(76), ticker.Count)
+	assert.Equal(t, time.UnixMilli(1499869899040), ticker.Timestamp)
+}
+
+func TestClient_GetKlines(t *testing.T) {
+	client, queries := newTestClient(t, "/api/v3/klines", klinesJSON)
+
+	klines, err := client.GetKlines(context.Background(), "ETHBTC", model.KlineInterval1w, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"interval=1w&limit=3&symbol=ETHBTC"}, *queries)
+
+	// The malformed row is skipped
+	require.Len(t, klines, 2)
+	first := klines[0]
+	assert.Equal(t, "ETHBTC", first.Symbol)
+	assert.Equal(t, model.KlineInterval1w, first.Interval)
+	assert.Equal(t, time.UnixMilli(1499040000000), first.OpenTime)
+	assert.Equal(t, time.UnixMilli(1499644799999), first.CloseTime)
+	assert.Equal(t, 0.0163479, first.Open)
+	assert.Equal(t, 0.8, first.High)
+	assert.Equal(t, 0.015758, first.Low)
+	assert.Equal(t, 0.015771, first.Close)
+	assert.Equal(t, 148976.11427815, first.Volume)
+	assert.Equal(t, 2434.19055334, first.QuoteVolume)
+	assert.Equal(t, int64(308), first.TradeCount)
+	assert.True(t, first.IsClosed)
+	assert.Equal(t, 0.0159, klines[1].Close)
+}
+
+func TestClient_GetOrderBook(t *testing.T) {
+	client, queries := newTestClient(t, "/api/v3/depth", depthJSON)
+
+	// 15 is not a depth Binance accepts and is rounded up to 20
+	book, err := client.GetOrderBook(context.Background(), "BTCUSDT", 15)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"limit=20&symbol=BTCUSDT"}, *queries)
+
+	assert.Equal(t, "BTCUSDT", book.Symbol)
+	assert.Equal(t, int64(1027024), book.LastUpdateID)
+	assert.Equal(t, []model.OrderBookEntry{{Price: 4, Quantity: 431}, {Price: 3.9, Quantity: 12.5}}, book.Bids)
+	assert.Equal(t, []model.OrderBookEntry{{Price: 4.000002, Quantity: 12}}, book.Asks)
+}
+
+func TestClient_ReturnsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+	}))
+	defer server.Close()
+	logger := zerolog.Nop()
+
+	_, err := NewClient(server.URL, &logger).GetMarketData(context.Background(), "NOPE")
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, -1121, apiErr.Code)
+	assert.Equal(t, "Invalid symbol.", apiErr.Message)
+}
@@ -0,0 +1,461 @@
+package mexc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+)
+
+var (
+	// announcementSymbolPattern matches the ticker in titles such as "MEXC Will List Foo (FOO)"
+	announcementSymbolPattern = regexp.MustCompile(`\(([A-Z0-9]{2,20})\)`)
+	// announcementTimePattern matches listing times such as "2024-05-01 10:00 (UTC)"
+	announcementTimePattern = regexp.MustCompile(`(\d{4}-\d{2}-\d{2}[ T]\d{2}:\d{2}(?::\d{2})?)\s*\(?UTC\)?`)
+)
+
+// Announcement is a listing announcement published by MEXC
+type Announcement struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	Symbol      string    `json:"symbol"`
+	ListingTime time.Time `json:"listing_time"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// defaultAnnouncementPollInterval is used when no poll interval is configured
+const defaultAnnouncementPollInterval = time.Minute
+
+// AnnouncementParserConfig configures an AnnouncementParser
+type AnnouncementParserConfig struct {
+	// PreListingLead is how long before ListingTime the pre-listing alert is emitted
+	PreListingLead time.Duration
+	// PollInterval is the base delay between polls. Defaults to one minute.
+	PollInterval time.Duration
+	// Jitter adds a random delay of up to this duration to each poll interval
+	Jitter time.Duration
+	// URLs are the announcement list pages fetched on every poll
+	URLs []string
+}
+
+// AnnouncementParser extracts listing announcements and schedules pre-listing alerts for them
+type AnnouncementParser struct {
+	cfg       AnnouncementParserConfig
+	store     port.ScheduledListingRepository
+	publisher port.DomainEventBus
+	logger    *zerolog.Logger
+	now       func() time.Time
+
+	mu     sync.Mutex
+	timers map[string]*time.Timer
+	fired  map[string]time.Time // Listing time of each listing whose alert fired
+
+	httpClient *http.Client
+	etagMu     sync.Mutex
+	etags      map[string]string // Last ETag seen per URL
+
+	pollMu   sync.Mutex
+	stopPoll context.CancelFunc
+	pollDone chan struct{}
+}
+
+// NewAnnouncementParser creates a new AnnouncementParser. Alerts are published on the
+// given bus; scheduled listings are persisted in store, which may be nil.
+func NewAnnouncementParser(cfg AnnouncementParserConfig, store port.ScheduledListingRepository, publisher port.DomainEventBus, logger *zerolog.Logger) *AnnouncementParser {
+	return &AnnouncementParser{
+		cfg:       cfg,
+		store:     store,
+		publisher: publisher,
+		logger:    logger,
+		now:       time.Now,
+		timers:    make(map[string]*time.Timer),
+		fired:     make(map[string]time.Time),
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		etags: make(map[string]string),
+	}
+}
+
+// StartPolling fetches the configured announcement URLs immediately and then once per
+// poll interval plus jitter, scheduling every upcoming listing found. It returns at once;
+// polling continues until ctx is cancelled or StopPolling is called.
+func (p *AnnouncementParser) StartPolling(ctx context.Context) {
+	p.pollMu.Lock()
+	defer p.pollMu.Unlock()
+	if p.stopPoll != nil {
+		p.logger.Warn().Msg("Announcement polling already running")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	p.stopPoll = cancel
+	p.pollDone = done
+
+	p.logger.Info().
+		Dur("interval", p.pollInterval()).
+		Dur("jitter", p.cfg.Jitter).
+		Strs("urls", p.cfg.URLs).
+		Msg("Starting announcement polling")
+
+	go func() {
+		defer close(done)
+		for {
+			p.pollOnce(ctx)
+
+			timer := time.NewTimer(p.nextDelay())
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+}
+
+// StopPolling stops polling and waits for an in-flight poll to finish. Scheduled alerts
+// are not affected.
+func (p *AnnouncementParser) StopPolling() {
+	p.pollMu.Lock()
+	cancel, done := p.stopPoll, p.pollDone
+	p.stopPoll, p.pollDone = nil, nil
+	p.pollMu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+	p.logger.Info().Msg("Stopped announcement polling")
+}
+
+// pollInterval returns the configured interval or its default
+func (p *AnnouncementParser) pollInterval() time.Duration {
+	if p.cfg.PollInterval <= 0 {
+		return defaultAnnouncementPollInterval
+	}
+	return p.cfg.PollInterval
+}
+
+// nextDelay returns the poll interval plus a random jitter in [0, Jitter)
+func (p *AnnouncementParser) nextDelay() time.Duration {
+	delay := p.pollInterval()
+	if p.cfg.Jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(p.cfg.Jitter)))
+	}
+	return delay
+}
+
+// pollOnce fetches every configured URL and schedules the listings found
+func (p *AnnouncementParser) pollOnce(ctx context.Context) {
+	for _, url := range p.cfg.URLs {
+		if ctx.Err() != nil {
+			return
+		}
+		if _, err := p.pollURL(ctx, url); err != nil {
+			p.logger.Error().Err(err).Str("url", url).Msg("Failed to poll announcements")
+		}
+	}
+}
+
+// pollURL fetches one announcement page and schedules its listings. It returns false
+// without parsing when the server reports the page unchanged since the last ETag.
+func (p *AnnouncementParser) pollURL(ctx context.Context, url string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	p.etagMu.Lock()
+	etag := p.etags[url]
+	p.etagMu.Unlock()
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch announcements: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		p.logger.Debug().Str("url", url).Msg("Announcements unchanged")
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, fmt.Errorf("failed to read announcements: %w", err)
+	}
+
+	announcements, err := p.Parse(body)
+	if err != nil {
+		return false, err
+	}
+
+	failed := false
+	for _, ann := range announcements {
+		if _, err := p.ScheduleListing(ctx, ann); err != nil {
+			failed = true
+			p.logger.Error().Err(err).Str("symbol", ann.Symbol).Msg("Failed to schedule listing")
+		}
+	}
+
+	// Only remember the ETag once the page has been fully handled so failures are retried
+	if newETag := resp.Header.Get("ETag"); newETag != "" && !failed {
+		p.etagMu.Lock()
+		p.etags[url] = newETag
+		p.etagMu.Unlock()
+	}
+	return true, nil
+}
+
+// announcementFeed is the JSON document returned by the announcement list endpoint
+type announcementFeed struct {
+	Data struct {
+		Results []struct {
+			ID          json.Number `json:"id"`
+			Title       string      `json:"title"`
+			Content     string      `json:"content"`
+			URL         string      `json:"url"`
+			PublishTime int64       `json:"publishTime"`
+		} `json:"results"`
+	} `json:"data"`
+}
+
+// Parse extracts listing announcements from an announcement list document. Entries that
+// are not listings or lack a symbol or listing time are skipped.
+func (p *AnnouncementParser) Parse(data []byte) ([]Announcement, error) {
+	var feed announcementFeed
+	if err := json.Unmarshal(data, &feed); err != nil {
+		return nil, fmt.Errorf("failed to decode announcements: %w", err)
+	}
+
+	announcements := make([]Announcement, 0, len(feed.Data.Results))
+	for _, entry := range feed.Data.Results {
+		if !strings.Contains(strings.ToLower(entry.Title), "list") {
+			continue
+		}
+		text := entry.Title + "\n" + entry.Content
+
+		symbolMatch := announcementSymbolPattern.FindStringSubmatch(text)
+		timeMatch := announcementTimePattern.FindStringSubmatch(text)
+		if symbolMatch == nil || timeMatch == nil {
+			p.logger.Debug().Str("title", entry.Title).Msg("Skipping announcement without symbol or listing time")
+			continue
+		}
+
+		listingTime, err := parseAnnouncementTime(timeMatch[1])
+		if err != nil {
+			p.logger.Debug().Err(err).Str("title", entry.Title).Msg("Skipping announcement with invalid listing time")
+			continue
+		}
+
+		ann := Announcement{
+			ID:          entry.ID.String(),
+			Title:       entry.Title,
+			URL:         entry.URL,
+			Symbol:      symbolMatch[1],
+			ListingTime: listingTime,
+		}
+		if entry.PublishTime > 0 {
+			ann.PublishedAt = time.UnixMilli(entry.PublishTime).UTC()
+		}
+		announcements = append(announcements, ann)
+	}
+
+	return announcements, nil
+}
+
+// parseAnnouncementTime parses a UTC timestamp with or without seconds
+func parseAnnouncementTime(value string) (time.Time, error) {
+	value = strings.Replace(value, "T", " ", 1)
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04"} {
+		if t, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q", value)
+}
+
+// ScheduleListing persists the announcement and arms a timer that publishes a
+// PreListingAlert PreListingLead before its listing time. Announcements whose listing
+// time has passed or whose alert already fired are ignored and false is returned, so
+// polls that see the same announcement again do not repeat the alert. If the alert time
+// has already passed but the listing has not, the alert fires immediately.
+func (p *AnnouncementParser) ScheduleListing(ctx context.Context, ann Announcement) (bool, error) {
+	now := p.now()
+	id := ann.ID
+	if id == "" {
+		id = fmt.Sprintf("%s-%d", ann.Symbol, ann.ListingTime.Unix())
+	}
+
+	if ann.ListingTime.IsZero() || !ann.ListingTime.After(now) {
+		p.logger.Debug().Str("symbol", ann.Symbol).Time("listingTime", ann.ListingTime).Msg("Ignoring past-due listing announcement")
+		// The listing is live, so a record kept to suppress its alert is no longer needed
+		if p.store != nil && !ann.ListingTime.IsZero() {
+			if err := p.store.Delete(ctx, id); err != nil {
+				p.logger.Warn().Err(err).Str("id", id).Msg("Failed to remove expired scheduled listing")
+			}
+		}
+		return false, nil
+	}
+
+	fired, err := p.alreadyFired(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if fired {
+		p.logger.Debug().Str("symbol", ann.Symbol).Str("id", id).Msg("Pre-listing alert already fired")
+		return false, nil
+	}
+
+	listing := &model.ScheduledListing{
+		ID:          id,
+		Symbol:      ann.Symbol,
+		Title:       ann.Title,
+		URL:         ann.URL,
+		ListingTime: ann.ListingTime,
+		AlertAt:     ann.ListingTime.Add(-p.cfg.PreListingLead),
+		CreatedAt:   now,
+	}
+
+	if p.store != nil {
+		if err := p.store.Save(ctx, listing); err != nil {
+			return false, fmt.Errorf("failed to persist scheduled listing: %w", err)
+		}
+	}
+
+	p.arm(listing)
+	return true, nil
+}
+
+// alreadyFired reports whether the alert of the listing with the given ID was published
+func (p *AnnouncementParser) alreadyFired(ctx context.Context, id string) (bool, error) {
+	p.mu.Lock()
+	_, fired := p.fired[id]
+	p.mu.Unlock()
+	if fired || p.store == nil {
+		return fired, nil
+	}
+
+	stored, err := p.store.Get(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to load scheduled listing: %w", err)
+	}
+	return stored != nil && stored.FiredAt != nil, nil
+}
+
+// RestoreSchedules re-arms the timers of persisted listings after a restart. Listings
+// whose listing time has passed are removed. It returns the number of armed timers.
+func (p *AnnouncementParser) RestoreSchedules(ctx context.Context) (int, error) {
+	if p.store == nil {
+		return 0, nil
+	}
+
+	listings, err := p.store.GetPending(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load scheduled listings: %w", err)
+	}
+
+	now := p.now()
+	armed := 0
+	for _, listing := range listings {
+		if !listing.ListingTime.After(now) {
+			if err := p.store.Delete(ctx, listing.ID); err != nil {
+				p.logger.Warn().Err(err).Str("id", listing.ID).Msg("Failed to remove expired scheduled listing")
+			}
+			continue
+		}
+		p.arm(listing)
+		armed++
+	}
+
+	p.logger.Info().Int("armed", armed).Int("loaded", len(listings)).Msg("Restored scheduled listings")
+	return armed, nil
+}
+
+// ScheduledCount returns the number of alerts waiting to fire
+func (p *AnnouncementParser) ScheduledCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.timers)
+}
+
+// CancelSchedules stops all pending timers. Persisted listings are kept so that they are
+// restored on the next start.
+func (p *AnnouncementParser) CancelSchedules() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for id, timer := range p.timers {
+		timer.Stop()
+		delete(p.timers, id)
+	}
+}
+
+// arm starts the alert timer for a listing, replacing any existing timer for the same ID
+func (p *AnnouncementParser) arm(listing *model.ScheduledListing) {
+	delay := listing.AlertAt.Sub(p.now())
+	if delay < 0 {
+		delay = 0
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if existing, ok := p.timers[listing.ID]; ok {
+		existing.Stop()
+	}
+	p.timers[listing.ID] = time.AfterFunc(delay, func() { p.fire(listing) })
+
+	p.logger.Info().
+		Str("symbol", listing.Symbol).
+		Time("listingTime", listing.ListingTime).
+		Time("alertAt", listing.AlertAt).
+		Msg("Scheduled pre-listing alert")
+}
+
+// fire publishes the pre-listing alert and records that it fired. The record is kept
+// until the listing goes live so later polls do not schedule the alert again.
+func (p *AnnouncementParser) fire(listing *model.ScheduledListing) {
+	now := p.now()
+	p.mu.Lock()
+	delete(p.timers, listing.ID)
+	for id, listingTime := range p.fired {
+		if !listingTime.After(now) {
+			delete(p.fired, id)
+		}
+	}
+	p.fired[listing.ID] = listing.ListingTime
+	p.mu.Unlock()
+
+	p.logger.Info().Str("symbol", listing.Symbol).Time("listingTime", listing.ListingTime).Msg("Emitting pre-listing alert")
+	if p.publisher != nil {
+		p.publisher.PublishEvent(event.NewPreListingAlert(listing))
+	}
+
+	if p.store != nil {
+		fired := *listing
+		fired.FiredAt = &now
+		if err := p.store.Save(context.Background(), &fired); err != nil {
+			p.logger.Warn().Err(err).Str("id", listing.ID).Msg("Failed to record fired pre-listing alert")
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package mexc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/event"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryScheduledListings keeps scheduled listings in memory
+type memoryScheduledListings struct {
+	mu       sync.Mutex
+	listings map[string]*model.ScheduledListing
+}
+
+func newMemoryScheduledListings() *memoryScheduledListings {
+	return &memoryScheduledListings{listings: map[string]*model.ScheduledListing{}}
+}
+
+func (s *memoryScheduledListings) Save(ctx context.Context, listing *model.ScheduledListing) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copied := *listing
+	s.listings[listing.ID] = &copied
+	return nil
+}
+
+func (s *memoryScheduledListings) Get(ctx context.Context, id string) (*model.ScheduledListing, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	listing, ok := s.listings[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *listing
+	return &copied, nil
+}
+
+func (s *memoryScheduledListings) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.listings, id)
+	return nil
+}
+
+func (s *memoryScheduledListings) GetPending(ctx context.Context) ([]*model.ScheduledListing, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	listings := make([]*model.ScheduledListing, 0, len(s.listings))
+	for _, listing := range s.listings {
+		if listing.FiredAt == nil {
+			listings = append(listings, listing)
+		}
+	}
+	return listings, nil
+}
+
+func (s *memoryScheduledListings) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.listings)
+}
+
+// alertRecorder collects published pre-listing alerts
+type alertRecorder struct {
+	port.DomainEventBus
+	mu     sync.Mutex
+	alerts []*event.PreListingAlert
+}
+
+func (r *alertRecorder) PublishEvent(evt event.DomainEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.alerts = append(r.alerts, evt.(*event.PreListingAlert))
+}
+
+func (r *alertRecorder) received() []*event.PreListingAlert {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*event.PreListingAlert(nil), r.alerts...)
+}
+
+func newTestAnnouncementParser(t *testing.T, lead time.Duration, store *memoryScheduledListings) (*AnnouncementParser, *alertRecorder) {
+	return newConfiguredAnnouncementParser(t, AnnouncementParserConfig{PreListingLead: lead}, store)
+}
+
+func newConfiguredAnnouncementParser(t *testing.T, cfg AnnouncementParserConfig, store *memoryScheduledListings) (*AnnouncementParser, *alertRecorder) {
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	alerts := &alertRecorder{}
+	parser := NewAnnouncementParser(cfg, store, alerts, &logger)
+	t.Cleanup(parser.CancelSchedules)
+	return parser, alerts
+}
+
+func TestAnnouncementParser_Parse(t *testing.T) {
+	parser, _ := newTestAnnouncementParser(t, time.Minute, newMemoryScheduledListings())
+
+	announcements, err := parser.Parse([]byte(`{"data":{"results":[
+		{"id":101,"title":"MEXC Will List Foo Token (FOO)","content":"Trading opens 2024-05-01 10:00 (UTC)","url":"https://example.com/101","publishTime":1714300000000},
+		{"id":102,"title":"Scheduled system maintenance","content":"2024-05-01 10:00 (UTC)"},
+		{"id":103,"title":"MEXC Will List Bar (BAR)","content":"Date to be announced"}
+	]}}`))
+	require.NoError(t, err)
+	require.Len(t, announcements, 1)
+
+	assert.Equal(t, "101", announcements[0].ID)
+	assert.Equal(t, "FOO", announcements[0].Symbol)
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), announcements[0].ListingTime)
+	assert.Equal(t, "https://example.com/101", announcements[0].URL)
+	assert.False(t, announcements[0].PublishedAt.IsZero())
+}
+
+func TestAnnouncementParser_ScheduleListingTenMinutesOut(t *testing.T) {
+	store := newMemoryScheduledListings()
+	parser, alerts := newTestAnnouncementParser(t, 5*time.Minute, store)
+	listingTime := time.Now().Add(10 * time.Minute)
+
+	scheduled, err := parser.ScheduleListing(context.Background(), Announcement{ID: "101", Symbol: "FOO", ListingTime: listingTime})
+	require.NoError(t, err)
+	assert.True(t, scheduled)
+	assert.Equal(t, 1, parser.ScheduledCount())
+	assert.Empty(t, alerts.received())
+
+	pending, err := store.GetPending(context.Background())
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "FOO", pending[0].Symbol)
+	assert.Equal(t, listingTime.Add(-5*time.Minute), pending[0].AlertAt)
+}
+
+func TestAnnouncementParser_AlertFiresWithinLeadTime(t *testing.T) {
+	store := newMemoryScheduledListings()
+	// A 15 minute lead on a listing 10 minutes out means the alert is already due
+	parser, alerts := newTestAnnouncementParser(t, 15*time.Minute, store)
+
+	scheduled, err := parser.ScheduleListing(context.Background(), Announcement{ID: "101", Symbol: "FOO", ListingTime: time.Now().Add(10 * time.Minute)})
+	require.NoError(t, err)
+	require.True(t, scheduled)
+
+	require.Eventually(t, func() bool { return len(alerts.received()) == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "FOO", alerts.received()[0].Symbol)
+	require.Eventually(t, func() bool {
+		stored, _ := store.Get(context.Background(), "101")
+		return stored != nil && stored.FiredAt != nil
+	}, time.Second, 5*time.Millisecond)
+	assert.Zero(t, parser.ScheduledCount())
+}
+
+func TestAnnouncementParser_FiredAlertIsNotRescheduled(t *testing.T) {
+	store := newMemoryScheduledListings()
+	parser, alerts := newTestAnnouncementParser(t, 15*time.Minute, store)
+	ann := Announcement{ID: "101", Symbol: "FOO", ListingTime: time.Now().Add(10 * time.Minute)}
+
+	_, err := parser.ScheduleListing(context.Background(), ann)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		stored, _ := store.Get(context.Background(), "101")
+		return stored != nil && stored.FiredAt != nil
+	}, time.Second, 5*time.Millisecond)
+
+	// Later polls see the same announcement again
+	scheduled, err := parser.ScheduleListing(context.Background(), ann)
+	require.NoError(t, err)
+	assert.False(t, scheduled)
+
+	// So does a restarted parser, which only knows about the alert from the store
+	restarted, restartedAlerts := newTestAnnouncementParser(t, 15*time.Minute, store)
+	armed, err := restarted.RestoreSchedules(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, armed)
+	scheduled, err = restarted.ScheduleListing(context.Background(), ann)
+	require.NoError(t, err)
+	assert.False(t, scheduled)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, alerts.received(), 1)
+	assert.Empty(t, restartedAlerts.received())
+
+	// Once the listing is live its record is dropped
+	_, err = restarted.ScheduleListing(context.Background(), Announcement{ID: "101", Symbol: "FOO", ListingTime: time.Now().Add(-time.Minute)})
+	require.NoError(t, err)
+	assert.Zero(t, store.count())
+}
+
+func TestAnnouncementParser_PastDueAnnouncementIgnored(t *testing.T) {
+	store := newMemoryScheduledListings()
+	parser, alerts := newTestAnnouncementParser(t, 5*time.Minute, store)
+
+	scheduled, err := parser.ScheduleListing(context.Background(), Announcement{ID: "101", Symbol: "FOO", ListingTime: time.Now().Add(-time.Minute)})
+	require.NoError(t, err)
+	assert.False(t, scheduled)
+	assert.Zero(t, parser.ScheduledCount())
+	assert.Zero(t, store.count())
+	assert.Empty(t, alerts.received())
+}
+
+func TestAnnouncementParser_RestoreSchedulesAfterRestart(t *testing.T) {
+	store := newMemoryScheduledListings()
+	first, _ := newTestAnnouncementParser(t, 5*time.Minute, store)
+	_, err := first.ScheduleListing(context.Background(), Announcement{ID: "101", Symbol: "FOO", ListingTime: time.Now().Add(10 * time.Minute)})
+	require.NoError(t, err)
+	first.CancelSchedules()
+
+	// A listing that went live while the service was down is dropped on restore
+	require.NoError(t, store.Save(context.Background(), &model.ScheduledListing{ID: "102", Symbol: "OLD", ListingTime: time.Now().Add(-time.Minute)}))
+
+	restarted, _ := newTestAnnouncementParser(t, 5*time.Minute, store)
+	armed, err := restarted.RestoreSchedules(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, armed)
+	assert.Equal(t, 1, restarted.ScheduledCount())
+	assert.Equal(t, 1, store.count())
+}
+
+// announcementPage returns an announcement list with a single listing one hour out
+func announcementPage() string {
+	listingTime := time.Now().UTC().Add(time.Hour).Format("2006-01-02 15:04")
+	return fmt.Sprintf(`{"data":{"results":[{"id":101,"title":"MEXC Will List Foo (FOO)","content":"Trading opens %s (UTC)"}]}}`, listingTime)
+}
+
+func TestAnnouncementParser_PollURLShortCircuitsUnchangedETag(t *testing.T) {
+	var requests, conditional atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			conditional.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, announcementPage())
+	}))
+	defer server.Close()
+
+	store := newMemoryScheduledListings()
+	parser, _ := newConfiguredAnnouncementParser(t, AnnouncementParserConfig{PreListingLead: time.Minute, URLs: []string{server.URL}}, store)
+
+	parsed, err := parser.pollURL(context.Background(), server.URL)
+	require.NoError(t, err)
+	assert.True(t, parsed)
+	assert.Equal(t, 1, store.count())
+
+	// Drop the schedule to prove the unchanged page is not parsed and scheduled again
+	require.NoError(t, store.Delete(context.Background(), "101"))
+
+	parsed, err = parser.pollURL(context.Background(), server.URL)
+	require.NoError(t, err)
+	assert.False(t, parsed)
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, int32(1), conditional.Load())
+	assert.Zero(t, store.count())
+}
+
+func TestAnnouncementParser_PollingRespectsInterval(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fmt.Fprint(w, `{"data":{"results":[]}}`)
+	}))
+	defer server.Close()
+
+	parser, _ := newConfiguredAnnouncementParser(t, AnnouncementParserConfig{
+		PollInterval: 100 * time.Millisecond,
+		URLs:         []string{server.URL},
+	}, newMemoryScheduledListings())
+
+	parser.StartPolling(context.Background())
+	time.Sleep(250 * time.Millisecond)
+	parser.StopPolling()
+
+	// An immediate poll plus one per elapsed interval
+	polls := requests.Load()
+	assert.GreaterOrEqual(t, polls, int32(2))
+	assert.LessOrEqual(t, polls, int32(3))
+
+	// No further polls after StopPolling
+	time.Sleep(150 * time.Millisecond)
+	assert.Equal(t, polls, requests.Load())
+	parser.StopPolling()
+}
+
+func TestAnnouncementParser_NextDelayAddsBoundedJitter(t *testing.T) {
+	parser, _ := newConfiguredAnnouncementParser(t, AnnouncementParserConfig{
+		PollInterval: time.Minute,
+		Jitter:       10 * time.Second,
+	}, newMemoryScheduledListings())
+
+	for i := 0; i < 100; i++ {
+		delay := parser.nextDelay()
+		assert.GreaterOrEqual(t, delay, time.Minute)
+		assert.Less(t, delay, time.Minute+10*time.Second)
+	}
+
+	unset, _ := newConfiguredAnnouncementParser(t, AnnouncementParserConfig{}, newMemoryScheduledListings())
+	assert.Equal(t, defaultAnnouncementPollInterval, unset.nextDelay())
+}
@@ -0,0 +1,164 @@
+package mexc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+)
+
+// capitalHistoryLimit is the most records MEXC returns per history request
+const capitalHistoryLimit = 1000
+
+// depositRecord is an entry of MEXC's deposit history
+type depositRecord struct {
+	Amount     string `json:"amount"`
+	Coin       string `json:"coin"`
+	Network    string `json:"network"`
+	Status     int    `json:"status"`
+	Address    string `json:"address"`
+	TxID       string `json:"txId"`
+	InsertTime int64  `json:"insertTime"`
+}
+
+// withdrawRecord is an entry of MEXC's withdrawal history
+type withdrawRecord struct {
+	ID             string `json:"id"`
+	TxID           string `json:"txId"`
+	Coin           string `json:"coin"`
+	Network        string `json:"network"`
+	Address        string `json:"address"`
+	Amount         string `json:"amount"`
+	Status         int    `json:"status"`
+	TransactionFee string `json:"transactionFee"`
+	ApplyTime      int64  `json:"applyTime"`
+}
+
+// GetDepositHistory retrieves the account's deposits of asset between start and end
+func (c *Client) GetDepositHistory(ctx context.Context, asset string, start, end time.Time) ([]*model.WalletTransaction, error) {
+	var records []depositRecord
+	if err := c.getCapitalHistory(ctx, "/api/v3/capital/deposit/hisrec", asset, start, end, &records); err != nil {
+		return nil, fmt.Errorf("failed to get deposit history: %w", err)
+	}
+
+	txs := make([]*model.WalletTransaction, len(records))
+	for i, r := range records {
+		amount, _ := strconv.ParseFloat(r.Amount, 64)
+		// Deposits have no ID of their own; the on-chain hash identifies them
+		externalID := r.TxID
+		if externalID == "" {
+			externalID = fmt.Sprintf("%s:%d:%s", r.Coin, r.InsertTime, r.Amount)
+		}
+		txs[i] = &model.WalletTransaction{
+			Type:       model.TransactionTypeDeposit,
+			Asset:      capitalAsset(r.Coin),
+			Amount:     amount,
+			DetectedAt: time.UnixMilli(r.InsertTime),
+			ExternalID: externalID,
+			Status:     depositStatus(r.Status),
+			Network:    r.Network,
+			Address:    r.Address,
+			TxID:       r.TxID,
+		}
+	}
+	return txs, nil
+}
+
+// GetWithdrawHistory retrieves the account's withdrawals of asset between start and end
+func (c *Client) GetWithdrawHistory(ctx context.Context, asset string, start, end time.Time) ([]*model.WalletTransaction, error) {
+	var records []withdrawRecord
+	if err := c.getCapitalHistory(ctx, "/api/v3/capital/withdraw/history", asset, start, end, &records); err != nil {
+		return nil, fmt.Errorf("failed to get withdrawal history: %w", err)
+	}
+
+	txs := make([]*model.WalletTransaction, len(records))
+	for i, r := range records {
+		amount, _ := strconv.ParseFloat(r.Amount, 64)
+		fee, _ := strconv.ParseFloat(r.TransactionFee, 64)
+		txs[i] = &model.WalletTransaction{
+			Type:       model.TransactionTypeWithdrawal,
+			Asset:      capitalAsset(r.Coin),
+			Amount:     amount,
+			DetectedAt: time.UnixMilli(r.ApplyTime),
+			ExternalID: r.ID,
+			Status:     withdrawStatus(r.Status),
+			Network:    r.Network,
+			Address:    r.Address,
+			TxID:       r.TxID,
+			Fee:        fee,
+		}
+	}
+	return txs, nil
+}
+
+// getCapitalHistory sends a signed history request for asset between start and end and
+// decodes the response into records
+func (c *Client) getCapitalHistory(ctx context.Context, path, asset string, start, end time.Time, records interface{}) error {
+	params := url.Values{}
+	if asset != "" {
+		params.Set("coin", asset)
+	}
+	if !start.IsZero() {
+		params.Set("startTime", strconv.FormatInt(start.UnixMilli(), 10))
+	}
+	if !end.IsZero() {
+		params.Set("endTime", strconv.FormatInt(end.UnixMilli(), 10))
+	}
+	params.Set("limit", strconv.Itoa(capitalHistoryLimit))
+	params.Set("timestamp", strconv.FormatInt(time.Now().UnixMilli(), 10))
+	query := params.Encode()
+
+	resp, err := c.sendRequest(ctx, http.MethodGet, path+"?"+query+"&signature="+c.generateSignature(query), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(records); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// capitalAsset returns the asset of a capital coin, which MEXC may suffix with the
+// network, as in USDT-TRX
+func capitalAsset(coin string) model.Asset {
+	asset, _, _ := strings.Cut(coin, "-")
+	return model.Asset(asset)
+}
+
+// depositStatus maps a MEXC deposit status code to a transaction status
+func depositStatus(status int) model.TransactionStatus {
+	switch status {
+	case 5, 12: // SUCCESS, COMPLETED
+		return model.TransactionStatusCompleted
+	case 7, 8, 10, 11: // REJECTED, REFUND, INVALID, RESTRICTED
+		return model.TransactionStatusFailed
+	default:
+		return model.TransactionStatusPending
+	}
+}
+
+// withdrawStatus maps a MEXC withdrawal status code to a transaction status
+func withdrawStatus(status int) model.TransactionStatus {
+	switch status {
+	case 7: // SUCCESS
+		return model.TransactionStatusCompleted
+	case 8: // FAILED
+		return model.TransactionStatusFailed
+	case 9: // CANCEL
+		return model.TransactionStatusCancelled
+	default:
+		return model.TransactionStatusPending
+	}
+}
+
+// Ensure Client implements port.CapitalHistoryProvider
+var _ port.CapitalHistoryProvider = (*Client)(nil)
@@ -0,0 +1,121 @@
+package mexc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const depositHistoryJSON = `[
+  {"amount":"150.5","coin":"USDT-TRX","network":"TRC20","status":5,"address":"TXyz123","addressTag":"","txId":"0xabc123","insertTime":1717000000000,"unlockConfirm":"20","confirmTimes":"20","memo":""},
+  {"amount":"0.25","coin":"ETH","network":"ERC20","status":4,"address":"0xdef","txId":"0xdef456","insertTime":1717003600000,"unlockConfirm":"64","confirmTimes":"12"}
+]`
+
+const withdrawHistoryJSON = `[
+  {"id":"bb17a2d452684f00a523c015d512a341","txId":"0x789","coin":"BTC","network":"BTC","address":"bc1qxyz","amount":"0.01","transferType":0,"status":7,"transactionFee":"0.0002","confirmNo":null,"applyTime":1717010000000,"remark":"","memo":""},
+  {"id":"c3f7e21b9d5a4e0c8f1a2b3c4d5e6f70","txId":null,"coin":"USDT-TRX","network":"TRC20","address":"TAbc","amount":"25","transferType":0,"status":9,"transactionFee":"1","applyTime":1717020000000}
+]`
+
+// capitalServer serves canned capital history and records the last request
+type capitalServer struct {
+	path   string
+	query  string
+	apiKey string
+}
+
+func (s *capitalServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.path, s.query, s.apiKey = r.URL.Path, r.URL.RawQuery, r.Header.Get("APIKEY")
+	switch r.URL.Path {
+	case "/api/v3/capital/deposit/hisrec":
+		w.Write([]byte(depositHistoryJSON))
+	case "/api/v3/capital/withdraw/history":
+		w.Write([]byte(withdrawHistoryJSON))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newCapitalClient(t *testing.T, handler *capitalServer) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	logger := zerolog.Nop()
+	return NewClientWithBaseURL("key", "secret", server.URL, &logger)
+}
+
+func TestGetDepositHistory_MapsDeposits(t *testing.T) {
+	handler := &capitalServer{}
+	client := newCapitalClient(t, handler)
+	start := time.UnixMilli(1716900000000)
+	end := time.UnixMilli(1717100000000)
+
+	deposits, err := client.GetDepositHistory(context.Background(), "USDT", start, end)
+	require.NoError(t, err)
+	require.Len(t, deposits, 2)
+
+	assert.Equal(t, &model.WalletTransaction{
+		Type:       model.TransactionTypeDeposit,
+		Asset:      "USDT",
+		Amount:     150.5,
+		DetectedAt: time.UnixMilli(1717000000000),
+		ExternalID: "0xabc123",
+		Status:     model.TransactionStatusCompleted,
+		Network:    "TRC20",
+		Address:    "TXyz123",
+		TxID:       "0xabc123",
+	}, deposits[0])
+	assert.Equal(t, model.Asset("ETH"), deposits[1].Asset)
+	assert.Equal(t, model.TransactionStatusPending, deposits[1].Status)
+
+	// The request is signed and filtered by asset and time range
+	assert.Equal(t, "key", handler.apiKey)
+	query, signature, ok := strings.Cut(handler.query, "&signature=")
+	require.True(t, ok)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(query))
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), signature)
+	assert.Contains(t, query, "coin=USDT")
+	assert.Contains(t, query, "startTime=1716900000000")
+	assert.Contains(t, query, "endTime=1717100000000")
+}
+
+func TestGetWithdrawHistory_MapsWithdrawals(t *testing.T) {
+	handler := &capitalServer{}
+	client := newCapitalClient(t, handler)
+
+	withdrawals, err := client.GetWithdrawHistory(context.Background(), "", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, withdrawals, 2)
+
+	assert.Equal(t, &model.WalletTransaction{
+		Type:       model.TransactionTypeWithdrawal,
+		Asset:      "BTC",
+		Amount:     0.01,
+		DetectedAt: time.UnixMilli(1717010000000),
+		ExternalID: "bb17a2d452684f00a523c015d512a341",
+		Status:     model.TransactionStatusCompleted,
+		Network:    "BTC",
+		Address:    "bc1qxyz",
+		TxID:       "0x789",
+		Fee:        0.0002,
+	}, withdrawals[0])
+	assert.Equal(t, model.Asset("USDT"), withdrawals[1].Asset)
+	assert.Equal(t, model.TransactionStatusCancelled, withdrawals[1].Status)
+	assert.Empty(t, withdrawals[1].TxID)
+
+	// Without an asset or range only the paging and signing parameters are sent
+	assert.Equal(t, "/api/v3/capital/withdraw/history", handler.path)
+	assert.NotContains(t, handler.query, "coin=")
+	assert.NotContains(t, handler.query, "startTime=")
+}
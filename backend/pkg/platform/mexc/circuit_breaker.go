@@ -0,0 +1,380 @@
+package mexc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+)
+
+// ErrCircuitOpen is returned without contacting MEXC while the circuit breaker is open
+var ErrCircuitOpen = errors.New("MEXC circuit breaker is open")
+
+// Defaults for the circuit breaker
+const (
+	DefaultBreakerFailureThreshold = 5
+	DefaultBreakerCooldown         = 30 * time.Second
+)
+
+// CircuitState is the state of a circuit breaker
+type CircuitState string
+
+// Circuit breaker states
+const (
+	// CircuitClosed lets every call through
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen rejects every call with ErrCircuitOpen until the cooldown has passed
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets a single probe through to test whether MEXC has recovered
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// CircuitBreakerConfig configures a CircuitBreaker
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit
+	FailureThreshold int
+	// Cooldown is how long the circuit stays open before a probe is allowed
+	Cooldown time.Duration
+	// IsFailure decides whether an error counts towards opening the circuit. Nil uses
+	// IsOutage.
+	IsFailure func(err error) bool
+}
+
+// CircuitBreaker fails fast while MEXC is unavailable. It opens after FailureThreshold
+// consecutive failures, rejects calls for Cooldown, then half-opens and lets one probe
+// through: a successful probe closes the circuit, a failed one opens it again.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	isFailure func(err error) bool
+	logger    *zerolog.Logger
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker creates a closed circuit breaker. Non-positive config values fall
+// back to the defaults.
+func NewCircuitBreaker(cfg CircuitBreakerConfig, logger *zerolog.Logger) *CircuitBreaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = DefaultBreakerFailureThreshold
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = DefaultBreakerCooldown
+	}
+	if cfg.IsFailure == nil {
+		cfg.IsFailure = IsOutage
+	}
+	return &CircuitBreaker{
+		threshold: cfg.FailureThreshold,
+		cooldown:  cfg.Cooldown,
+		isFailure: cfg.IsFailure,
+		logger:    logger,
+		now:       time.Now,
+		state:     CircuitClosed,
+	}
+}
+
+// IsOutage reports whether err indicates that MEXC is unavailable: transport errors,
+// timeouts, rate limiting and 5xx responses. Rejected requests such as an invalid symbol,
+// decoding problems and calls cancelled by the caller do not count.
+func IsOutage(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// Execute runs fn unless the circuit is open and records its outcome
+func (b *CircuitBreaker) Execute(fn func() error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := fn()
+	b.record(err)
+	return err
+}
+
+// State returns the current state. An open circuit whose cooldown has passed is reported
+// as half-open, since the next call will probe.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitOpen && !b.now().Before(b.openedAt.Add(b.cooldown)) {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// CircuitStatus reports the state, the consecutive failure count and, while open, when
+// the next probe is allowed. It is consumed by the health check.
+func (b *CircuitBreaker) CircuitStatus() (state string, failures int, retryAt time.Time) {
+	current := b.State()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if current == CircuitOpen {
+		retryAt = b.openedAt.Add(b.cooldown)
+	}
+	return string(current), b.failures, retryAt
+}
+
+// allow admits a call, moving an open circuit to half-open once the cooldown has passed
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Before(b.openedAt.Add(b.cooldown)) {
+			return ErrCircuitOpen
+		}
+		b.setState(CircuitHalfOpen)
+		b.probing = true
+	case CircuitHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// record updates the circuit with the outcome of an admitted call
+func (b *CircuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen {
+		b.probing = false
+	}
+
+	// A call cancelled by its caller says nothing about MEXC; a cancelled probe leaves the
+	// circuit half-open so the next call probes again
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	if !b.isFailure(err) {
+		b.failures = 0
+		if b.state != CircuitClosed {
+			b.setState(CircuitClosed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= b.threshold {
+		b.openedAt = b.now()
+		b.setState(CircuitOpen)
+	}
+}
+
+// setState changes the state and logs the transition. The caller must hold b.mu.
+func (b *CircuitBreaker) setState(state CircuitState) {
+	if b.state == state {
+		return
+	}
+	b.logger.Warn().
+		Str("from", string(b.state)).
+		Str("to", string(state)).
+		Int("consecutiveFailures", b.failures).
+		Msg("MEXC circuit breaker state changed")
+	b.state = state
+}
+
+// guard runs a client call returning a value through the breaker
+func guard[T any](b *CircuitBreaker, fn func() (T, error)) (T, error) {
+	var result T
+	err := b.Execute(func() error {
+		var err error
+		result, err = fn()
+		return err
+	})
+	return result, err
+}
+
+// CircuitBreakerClient wraps a MEXC client so that every call goes through a circuit
+// breaker
+type CircuitBreakerClient struct {
+	next    port.MEXCClient
+	breaker *CircuitBreaker
+}
+
+// NewCircuitBreakerClient wraps next with breaker. Several clients may share a breaker.
+func NewCircuitBreakerClient(next port.MEXCClient, breaker *CircuitBreaker) *CircuitBreakerClient {
+	return &CircuitBreakerClient{next: next, breaker: breaker}
+}
+
+// Breaker returns the breaker guarding the client
+func (c *CircuitBreakerClient) Breaker() *CircuitBreaker {
+	return c.breaker
+}
+
+// GetNewListings implements port.MEXCClient
+func (c *CircuitBreakerClient) GetNewListings(ctx context.Context) ([]*model.NewCoin, error) {
+	return guard(c.breaker, func() ([]*model.NewCoin, error) { return c.next.GetNewListings(ctx) })
+}
+
+// GetSymbolInfo implements port.MEXCClient
+func (c *CircuitBreakerClient) GetSymbolInfo(ctx context.Context, symbol string) (*model.SymbolInfo, error) {
+	return guard(c.breaker, func() (*model.SymbolInfo, error) { return c.next.GetSymbolInfo(ctx, symbol) })
+}
+
+// GetSymbolStatus implements port.MEXCClient
+func (c *CircuitBreakerClient) GetSymbolStatus(ctx context.Context, symbol string) (model.Status, error) {
+	return guard(c.breaker, func() (model.Status, error) { return c.next.GetSymbolStatus(ctx, symbol) })
+}
+
+// GetTradingSchedule implements port.MEXCClient
+func (c *CircuitBreakerClient) GetTradingSchedule(ctx context.Context, symbol string) (model.TradingSchedule, error) {
+	return guard(c.breaker, func() (model.TradingSchedule, error) { return c.next.GetTradingSchedule(ctx, symbol) })
+}
+
+// GetSymbolConstraints implements port.MEXCClient
+func (c *CircuitBreakerClient) GetSymbolConstraints(ctx context.Context, symbol string) (*model.SymbolConstraints, error) {
+	return guard(c.breaker, func() (*model.SymbolConstraints, error) { return c.next.GetSymbolConstraints(ctx, symbol) })
+}
+
+// GetExchangeInfo implements port.MEXCClient
+func (c *CircuitBreakerClient) GetExchangeInfo(ctx context.Context) (*model.ExchangeInfo, error) {
+	return guard(c.breaker, func() (*model.ExchangeInfo, error) { return c.next.GetExchangeInfo(ctx) })
+}
+
+// ExchangeInfoRefresher is implemented by clients that cache exchange info
+type ExchangeInfoRefresher interface {
+	RefreshExchangeInfo(ctx context.Context) (*model.ExchangeInfo, error)
+}
+
+// RefreshExchangeInfo forces the wrapped client to fetch exchange info past its cache.
+// A wrapped client without a cache simply fetches it.
+func (c *CircuitBreakerClient) RefreshExchangeInfo(ctx context.Context) (*model.ExchangeInfo, error) {
+	refresher, ok := c.next.(ExchangeInfoRefresher)
+	if !ok {
+		return c.GetExchangeInfo(ctx)
+	}
+	return guard(c.breaker, func() (*model.ExchangeInfo, error) { return refresher.RefreshExchangeInfo(ctx) })
+}
+
+// pinger is implemented by clients that can check connectivity cheaply
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Ping checks connectivity to MEXC through the breaker, so failed pings count toward
+// opening it and a ping can probe a half-open circuit
+func (c *CircuitBreakerClient) Ping(ctx context.Context) error {
+	next, ok := c.next.(pinger)
+	if !ok {
+		return fmt.Errorf("MEXC client %T cannot ping", c.next)
+	}
+	return c.breaker.Execute(func() error { return next.Ping(ctx) })
+}
+
+// GetMarketData implements port.MEXCClient
+func (c *CircuitBreakerClient) GetMarketData(ctx context.Context, symbol string) (*model.Ticker, error) {
+	return guard(c.breaker, func() (*model.Ticker, error) { return c.next.GetMarketData(ctx, symbol) })
+}
+
+// GetKlines implements port.MEXCClient
+func (c *CircuitBreakerClient) GetKlines(ctx context.Context, symbol string, interval model.KlineInterval, limit int) ([]*model.Kline, error) {
+	return guard(c.breaker, func() ([]*model.Kline, error) { return c.next.GetKlines(ctx, symbol, interval, limit) })
+}
+
+// GetKlinesRange implements port.KlineHistoryProvider when the wrapped client does
+func (c *CircuitBreakerClient) GetKlinesRange(ctx context.Context, symbol string, interval model.KlineInterval, start, end time.Time, limit int) ([]*model.Kline, error) {
+	history, ok := c.next.(port.KlineHistoryProvider)
+	if !ok {
+		return nil, fmt.Errorf("MEXC client %T cannot fetch klines by time range", c.next)
+	}
+	return guard(c.breaker, func() ([]*model.Kline, error) {
+		return history.GetKlinesRange(ctx, symbol, interval, start, end, limit)
+	})
+}
+
+// GetTickers implements port.TickerBatchProvider when the wrapped client does
+func (c *CircuitBreakerClient) GetTickers(ctx context.Context, symbols []string) ([]*model.Ticker, error) {
+	batch, ok := c.next.(port.TickerBatchProvider)
+	if !ok {
+		return nil, fmt.Errorf("MEXC client %T cannot fetch tickers in batch", c.next)
+	}
+	return guard(c.breaker, func() ([]*model.Ticker, error) { return batch.GetTickers(ctx, symbols) })
+}
+
+// GetDepositHistory implements port.CapitalHistoryProvider when the wrapped client does
+func (c *CircuitBreakerClient) GetDepositHistory(ctx context.Context, asset string, start, end time.Time) ([]*model.WalletTransaction, error) {
+	history, ok := c.next.(port.CapitalHistoryProvider)
+	if !ok {
+		return nil, fmt.Errorf("MEXC client %T cannot fetch deposit history", c.next)
+	}
+	return guard(c.breaker, func() ([]*model.WalletTransaction, error) {
+		return history.GetDepositHistory(ctx, asset, start, end)
+	})
+}
+
+// GetWithdrawHistory implements port.CapitalHistoryProvider when the wrapped client does
+func (c *CircuitBreakerClient) GetWithdrawHistory(ctx context.Context, asset string, start, end time.Time) ([]*model.WalletTransaction, error) {
+	history, ok := c.next.(port.CapitalHistoryProvider)
+	if !ok {
+		return nil, fmt.Errorf("MEXC client %T cannot fetch withdrawal history", c.next)
+	}
+	return guard(c.breaker, func() ([]*model.WalletTransaction, error) {
+		return history.GetWithdrawHistory(ctx, asset, start, end)
+	})
+}
+
+// GetOrderBook implements port.MEXCClient
+func (c *CircuitBreakerClient) GetOrderBook(ctx context.Context, symbol string, depth int) (*model.OrderBook, error) {
+	return guard(c.breaker, func() (*model.OrderBook, error) { return c.next.GetOrderBook(ctx, symbol, depth) })
+}
+
+// GetAccount implements port.MEXCClient
+func (c *CircuitBreakerClient) GetAccount(ctx context.Context) (*model.Wallet, error) {
+	return guard(c.breaker, func() (*model.Wallet, error) { return c.next.GetAccount(ctx) })
+}
+
+// PlaceOrder implements port.MEXCClient
+func (c *CircuitBreakerClient) PlaceOrder(ctx context.Context, symbol string, side model.OrderSide, orderType model.OrderType, quantity float64, price float64, timeInForce model.TimeInForce) (*model.Order, error) {
+	return guard(c.breaker, func() (*model.Order, error) {
+		return c.next.PlaceOrder(ctx, symbol, side, orderType, quantity, price, timeInForce)
+	})
+}
+
+// CancelOrder implements port.MEXCClient
+func (c *CircuitBreakerClient) CancelOrder(ctx context.Context, symbol string, orderID string) error {
+	return c.breaker.Execute(func() error { return c.next.CancelOrder(ctx, symbol, orderID) })
+}
+
+// GetOrderStatus implements port.MEXCClient
+func (c *CircuitBreakerClient) GetOrderStatus(ctx context.Context, symbol string, orderID string) (*model.Order, error) {
+	return guard(c.breaker, func() (*model.Order, error) { return c.next.GetOrderStatus(ctx, symbol, orderID) })
+}
+
+// GetOpenOrders implements port.MEXCClient
+func (c *CircuitBreakerClient) GetOpenOrders(ctx context.Context, symbol string) ([]*model.Order, error) {
+	return guard(c.breaker, func() ([]*model.Order, error) { return c.next.GetOpenOrders(ctx, symbol) })
+}
+
+// GetOrderHistory implements port.MEXCClient
+func (c *CircuitBreakerClient) GetOrderHistory(ctx context.Context, symbol string, limit, offset int) ([]*model.Order, error) {
+	return guard(c.breaker, func() ([]*model.Order, error) { return c.next.GetOrderHistory(ctx, symbol, limit, offset) })
+}
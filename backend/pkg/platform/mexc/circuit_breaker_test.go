@@ -0,0 +1,149 @@
+package mexc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/port"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyMEXCClient fails GetMarketData with a transport error while down is set
+type flakyMEXCClient struct {
+	port.MEXCClient
+	down  bool
+	calls int
+}
+
+func (c *flakyMEXCClient) GetMarketData(ctx context.Context, symbol string) (*model.Ticker, error) {
+	c.calls++
+	if c.down {
+		return nil, fmt.Errorf("failed to send request: %w", &net.OpError{Op: "dial", Err: errors.New("connection refused")})
+	}
+	return &model.Ticker{Symbol: symbol, LastPrice: 100}, nil
+}
+
+func (c *flakyMEXCClient) GetSymbolInfo(ctx context.Context, symbol string) (*model.SymbolInfo, error) {
+	c.calls++
+	return nil, &APIError{StatusCode: 400, Code: -1121, Message: "Invalid symbol."}
+}
+
+// newTestBreaker returns a breaker with a controllable clock
+func newTestBreaker(threshold int, cooldown time.Duration) (*CircuitBreaker, *time.Time) {
+	logger := zerolog.Nop()
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: threshold, Cooldown: cooldown}, &logger)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker.now = func() time.Time { return now }
+	return breaker, &now
+}
+
+func TestCircuitBreakerClient_OpenHalfOpenClosed(t *testing.T) {
+	ctx := context.Background()
+	breaker, now := newTestBreaker(3, time.Minute)
+	stub := &flakyMEXCClient{down: true}
+	client := NewCircuitBreakerClient(stub, breaker)
+
+	// Consecutive failures below the threshold keep the circuit closed
+	for i := 0; i < 2; i++ {
+		_, err := client.GetMarketData(ctx, "BTCUSDT")
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrCircuitOpen)
+	}
+	assert.Equal(t, CircuitClosed, breaker.State())
+
+	// The third failure opens it and later calls fail fast without reaching MEXC
+	_, err := client.GetMarketData(ctx, "BTCUSDT")
+	require.Error(t, err)
+	assert.Equal(t, CircuitOpen, breaker.State())
+
+	_, err = client.GetMarketData(ctx, "BTCUSDT")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 3, stub.calls)
+
+	state, failures, retryAt := breaker.CircuitStatus()
+	assert.Equal(t, "open", state)
+	assert.Equal(t, 3, failures)
+	assert.Equal(t, now.Add(time.Minute), retryAt)
+
+	// After the cooldown a failing probe opens the circuit again
+	*now = now.Add(time.Minute)
+	assert.Equal(t, CircuitHalfOpen, breaker.State())
+	_, err = client.GetMarketData(ctx, "BTCUSDT")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, CircuitOpen, breaker.State())
+	assert.Equal(t, 4, stub.calls)
+
+	// Once MEXC recovers the next probe closes the circuit
+	stub.down = false
+	*now = now.Add(time.Minute)
+	ticker, err := client.GetMarketData(ctx, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, ticker.LastPrice)
+	assert.Equal(t, CircuitClosed, breaker.State())
+
+	_, failures, _ = breaker.CircuitStatus()
+	assert.Zero(t, failures)
+}
+
+func TestCircuitBreaker_HalfOpenAllowsSingleProbe(t *testing.T) {
+	breaker, now := newTestBreaker(1, time.Second)
+	require.Error(t, breaker.Execute(func() error { return context.DeadlineExceeded }))
+	*now = now.Add(time.Second)
+
+	// While the probe is in flight other calls are rejected
+	err := breaker.Execute(func() error {
+		assert.ErrorIs(t, breaker.Execute(func() error { return nil }), ErrCircuitOpen)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreaker_IgnoresRejectedRequestsAndCancellation(t *testing.T) {
+	ctx := context.Background()
+	breaker, _ := newTestBreaker(2, time.Minute)
+	stub := &flakyMEXCClient{}
+	client := NewCircuitBreakerClient(stub, breaker)
+
+	for i := 0; i < 5; i++ {
+		_, err := client.GetSymbolInfo(ctx, "NOPE")
+		require.Error(t, err)
+		require.NoError(t, breaker.Execute(func() error { return nil }))
+		assert.ErrorIs(t, breaker.Execute(func() error { return context.Canceled }), context.Canceled)
+	}
+	assert.Equal(t, CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreakerClient_PingGoesThroughTheBreaker(t *testing.T) {
+	ctx := context.Background()
+	breaker, _ := newTestBreaker(1, time.Minute)
+	logger := zerolog.Nop()
+
+	// A refused connection is an outage and opens the shared breaker
+	client := NewCircuitBreakerClient(NewClientWithBaseURL("", "", "http://127.0.0.1:1", &logger), breaker)
+	require.Error(t, client.Ping(ctx))
+	assert.Equal(t, CircuitOpen, breaker.State())
+	assert.ErrorIs(t, client.Ping(ctx), ErrCircuitOpen)
+
+	// A wrapped client that cannot ping reports it
+	assert.Error(t, NewCircuitBreakerClient(&flakyMEXCClient{}, breaker).Ping(ctx))
+}
+
+func TestIsOutage(t *testing.T) {
+	assert.False(t, IsOutage(nil))
+	assert.False(t, IsOutage(context.Canceled))
+	assert.False(t, IsOutage(&APIError{StatusCode: 400}))
+	assert.False(t, IsOutage(errors.New("failed to decode response")))
+	assert.True(t, IsOutage(context.DeadlineExceeded))
+	assert.True(t, IsOutage(&APIError{StatusCode: 503}))
+	assert.True(t, IsOutage(fmt.Errorf("wrapped: %w", &APIError{StatusCode: 429})))
+	assert.True(t, IsOutage(&net.OpError{Op: "dial", Err: errors.New("refused")}))
+}
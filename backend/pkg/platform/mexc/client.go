@@ -10,35 +10,111 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/logger"
 	"github.com/rs/zerolog"
+	"golang.org/x/sync/singleflight"
 )
 
-const (
-	baseURL = "https://api.mexc.com"
-)
+// DefaultBaseURL is the production MEXC REST endpoint
+const DefaultBaseURL = "https://api.mexc.com"
+
+// APIError is returned when MEXC answers with a non-200 status
+type APIError struct {
+	StatusCode int
+	Code       int    // MEXC error code, zero when the body could not be decoded
+	Message    string // MEXC error message
+}
+
+// Error implements error
+func (e *APIError) Error() string {
+	if e.Code == 0 && e.Message == "" {
+		return fmt.Sprintf("request failed with status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("API error %d: %s", e.Code, e.Message)
+}
+
+// IsRetryable reports whether the same request may succeed later: MEXC is rate limiting,
+// down for maintenance or failing on its side. Rejections such as an insufficient balance
+// are not retryable.
+func (e *APIError) IsRetryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
 
 // Client implements port.MEXCClient interface
 // Note: MEXC API requires the APIKEY header (not X-MBX-APIKEY) for authentication
 type Client struct {
 	httpClient *http.Client
+	baseURL    string
 	apiKey     string
 	apiSecret  string
 	logger     *zerolog.Logger
+	now        func() time.Time
+
+	// flights collapses concurrent identical requests into one upstream call
+	flights singleflight.Group
+
+	exchangeInfoTTL time.Duration
+	exchangeInfoMu  sync.RWMutex
+	exchangeInfo    *model.ExchangeInfo
+	exchangeInfoAt  time.Time
+}
+
+// ClientOption configures optional Client behaviour
+type ClientOption func(*Client)
+
+// WithExchangeInfoTTL sets how long GetExchangeInfo serves a cached response. A
+// non-positive ttl disables caching.
+func WithExchangeInfoTTL(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.exchangeInfoTTL = ttl
+	}
 }
 
-// NewClient creates a new MEXC API client
+// WithHTTPClient replaces the HTTP client used for requests
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// NewClient creates a new MEXC API client for the production endpoint
 func NewClient(apiKey, apiSecret string, logger *zerolog.Logger) *Client {
-	return &Client{
+	return NewClientWithBaseURL(apiKey, apiSecret, DefaultBaseURL, logger)
+}
+
+// NewClientWithBaseURL creates a new MEXC API client sending requests to baseURL, such as
+// a testnet or a local stub. An empty baseURL selects the production endpoint.
+func NewClientWithBaseURL(apiKey, apiSecret, baseURL string, logger *zerolog.Logger, options ...ClientOption) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	client := &Client{
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout:   10 * time.Second,
+			Transport: NewTransport(),
 		},
-		apiKey:    apiKey,
-		apiSecret: apiSecret,
-		logger:    logger,
-	}
+		baseURL:         strings.TrimRight(baseURL, "/"),
+		apiKey:          apiKey,
+		apiSecret:       apiSecret,
+		logger:          logger,
+		now:             time.Now,
+		exchangeInfoTTL: DefaultExchangeInfoTTL,
+	}
+	for _, option := range options {
+		option(client)
+	}
+	client.httpClient = withTracing(client.httpClient)
+	return client
+}
+
+// BaseURL returns the REST endpoint the client sends requests to
+func (c *Client) BaseURL() string {
+	return c.baseURL
 }
 
 // GetNewListings retrieves information about newly listed coins
@@ -273,7 +349,7 @@ func (c *Client) GetSymbolConstraints(ctx context.Context, symbol string) (*mode
 
 // sendRequest sends an HTTP request to the MEXC API
 func (c *Client) sendRequest(ctx context.Context, method, endpoint string, body io.Reader) (*http.Response, error) {
-	req, err := http.NewRequestWithContext(ctx, method, baseURL+endpoint, body)
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+endpoint, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -284,21 +360,26 @@ func (c *Client) sendRequest(ctx context.Context, method, endpoint string, body
 		req.Header.Set("APIKEY", c.apiKey)
 	}
 
+	requestLogger := logger.Enrich(ctx, c.logger)
+	requestLogger.Debug().Str("method", method).Str("endpoint", endpoint).Msg("Sending MEXC request")
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		requestLogger.Warn().Err(err).Str("endpoint", endpoint).Msg("MEXC request failed")
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
+		requestLogger.Warn().Int("status", resp.StatusCode).Str("endpoint", endpoint).Msg("MEXC request returned error status")
 		defer resp.Body.Close()
 		var errResp struct {
 			Code    int    `json:"code"`
 			Message string `json:"msg"`
 		}
 		if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
-			return nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+			return nil, &APIError{StatusCode: resp.StatusCode}
 		}
-		return nil, fmt.Errorf("API error %d: %s", errResp.Code, errResp.Message)
+		return nil, &APIError{StatusCode: resp.StatusCode, Code: errResp.Code, Message: errResp.Message}
 	}
 
 	return resp, nil
@@ -321,7 +402,7 @@ func (c *Client) GetAccount(ctx context.Context) (*model.Wallet, error) {
 	endpoint := fmt.Sprintf("/api/v3/account?%s&signature=%s", params, signature)
 
 	// Create request
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -346,10 +427,10 @@ func (c *Client) GetAccount(ctx context.Context) (*model.Wallet, error) {
 		}
 		if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
 			c.logger.Error().Err(err).Int("status", resp.StatusCode).Msg("Failed to decode error response")
-			return nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+			return nil, &APIError{StatusCode: resp.StatusCode}
 		}
 		c.logger.Error().Int("code", errResp.Code).Str("message", errResp.Message).Msg("MEXC API error")
-		return nil, fmt.Errorf("API error %d: %s", errResp.Code, errResp.Message)
+		return nil, &APIError{StatusCode: resp.StatusCode, Code: errResp.Code, Message: errResp.Message}
 	}
 
 	// Parse response
@@ -451,8 +532,18 @@ func (c *Client) GetOrderHistory(ctx context.Context, symbol string, limit, offs
 	return nil, fmt.Errorf("GetOrderHistory method not fully implemented")
 }
 
-// GetExchangeInfo retrieves information about all symbols on the exchange
-func (c *Client) GetExchangeInfo(ctx context.Context) (*model.ExchangeInfo, error) {
+// Ping checks connectivity to the MEXC REST API
+func (c *Client) Ping(ctx context.Context) error {
+	resp, err := c.sendRequest(ctx, http.MethodGet, "/api/v3/ping", nil)
+	if err != nil {
+		return fmt.Errorf("failed to ping MEXC: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// fetchExchangeInfo retrieves information about all symbols from the exchange
+func (c *Client) fetchExchangeInfo(ctx context.Context) (*model.ExchangeInfo, error) {
 	endpoint := "/api/v3/exchangeInfo"
 
 	resp, err := c.sendRequest(ctx, http.MethodGet, endpoint, nil)
@@ -528,15 +619,30 @@ func (c *Client) GetExchangeInfo(ctx context.Context) (*model.ExchangeInfo, erro
 // GetKlines retrieves candle data for a symbol, interval, and limit
 func (c *Client) GetKlines(ctx context.Context, symbol string, interval model.KlineInterval, limit int) ([]*model.Kline, error) {
 	endpoint := fmt.Sprintf("/api/v3/klines?symbol=%s&interval=%s&limit=%d", symbol, interval, limit)
+	return c.fetchKlines(ctx, endpoint, symbol, interval)
+}
+
+// GetKlinesRange retrieves at most limit klines opening between start and end, oldest first
+func (c *Client) GetKlinesRange(ctx context.Context, symbol string, interval model.KlineInterval, start, end time.Time, limit int) ([]*model.Kline, error) {
+	endpoint := fmt.Sprintf("/api/v3/klines?symbol=%s&interval=%s&startTime=%d&endTime=%d&limit=%d",
+		symbol, interval, start.UnixMilli(), end.UnixMilli(), limit)
+	return c.fetchKlines(ctx, endpoint, symbol, interval)
+}
 
+// fetchKlines requests endpoint and parses the klines it returns, skipping malformed rows
+func (c *Client) fetchKlines(ctx context.Context, endpoint, symbol string, interval model.KlineInterval) ([]*model.Kline, error) {
 	resp, err := c.sendRequest(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get klines: %w", err)
 	}
 	defer resp.Body.Close()
 
+	// Decode numbers as json.Number: millisecond timestamps formatted from float64 would
+	// use exponent notation and fail to parse
 	var rawKlines [][]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&rawKlines); err != nil {
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&rawKlines); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
@@ -589,8 +695,51 @@ func (c *Client) GetKlines(ctx context.Context, symbol string, interval model.Kl
 	return klines, nil
 }
 
-// GetMarketData retrieves current market data for a symbol
-func (c *Client) GetMarketData(ctx context.Context, symbol string) (*model.Ticker, error) {
+// tickerResponse is a 24h ticker as returned by /api/v3/ticker/24hr
+type tickerResponse struct {
+	Symbol             string `json:"symbol"`
+	PriceChange        string `json:"priceChange"`
+	PriceChangePercent string `json:"priceChangePercent"`
+	WeightedAvgPrice   string `json:"weightedAvgPrice"`
+	PrevClosePrice     string `json:"prevClosePrice"`
+	LastPrice          string `json:"lastPrice"`
+	LastQty            string `json:"lastQty"`
+	BidPrice           string `json:"bidPrice"`
+	BidQty             string `json:"bidQty"`
+	AskPrice           string `json:"askPrice"`
+	AskQty             string `json:"askQty"`
+	OpenPrice          string `json:"openPrice"`
+	HighPrice          string `json:"highPrice"`
+	LowPrice           string `json:"lowPrice"`
+	Volume             string `json:"volume"`
+	QuoteVolume        string `json:"quoteVolume"`
+	OpenTime           int64  `json:"openTime"`
+	CloseTime          int64  `json:"closeTime"`
+	Count              int    `json:"count"`
+}
+
+// toModel parses the ticker's string values
+func (r tickerResponse) toModel() *model.Ticker {
+	lastPrice, _ := strconv.ParseFloat(r.LastPrice, 64)
+	volume, _ := strconv.ParseFloat(r.Volume, 64)
+	highPrice, _ := strconv.ParseFloat(r.HighPrice, 64)
+	lowPrice, _ := strconv.ParseFloat(r.LowPrice, 64)
+	priceChange, _ := strconv.ParseFloat(r.PriceChange, 64)
+	priceChangePercent, _ := strconv.ParseFloat(r.PriceChangePercent, 64)
+
+	return &model.Ticker{
+		Symbol:             r.Symbol,
+		LastPrice:          lastPrice,
+		Volume:             volume,
+		HighPrice:          highPrice,
+		LowPrice:           lowPrice,
+		PriceChange:        priceChange,
+		PriceChangePercent: priceChangePercent,
+	}
+}
+
+// fetchMarketData retrieves current market data for a symbol from the exchange
+func (c *Client) fetchMarketData(ctx context.Context, symbol string) (*model.Ticker, error) {
 	endpoint := fmt.Sprintf("/api/v3/ticker/24hr?symbol=%s", symbol)
 
 	resp, err := c.sendRequest(ctx, http.MethodGet, endpoint, nil)
@@ -599,55 +748,16 @@ func (c *Client) GetMarketData(ctx context.Context, symbol string) (*model.Ticke
 	}
 	defer resp.Body.Close()
 
-	var response struct {
-		Symbol             string `json:"symbol"`
-		PriceChange        string `json:"priceChange"`
-		PriceChangePercent string `json:"priceChangePercent"`
-		WeightedAvgPrice   string `json:"weightedAvgPrice"`
-		PrevClosePrice     string `json:"prevClosePrice"`
-		LastPrice          string `json:"lastPrice"`
-		LastQty            string `json:"lastQty"`
-		BidPrice           string `json:"bidPrice"`
-		BidQty             string `json:"bidQty"`
-		AskPrice           string `json:"askPrice"`
-		AskQty             string `json:"askQty"`
-		OpenPrice          string `json:"openPrice"`
-		HighPrice          string `json:"highPrice"`
-		LowPrice           string `json:"lowPrice"`
-		Volume             string `json:"volume"`
-		QuoteVolume        string `json:"quoteVolume"`
-		OpenTime           int64  `json:"openTime"`
-		CloseTime          int64  `json:"closeTime"`
-		Count              int    `json:"count"`
-	}
-
+	var response tickerResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	// Parse string values to float64
-	lastPrice, _ := strconv.ParseFloat(response.LastPrice, 64)
-	volume, _ := strconv.ParseFloat(response.Volume, 64)
-	highPrice, _ := strconv.ParseFloat(response.HighPrice, 64)
-	lowPrice, _ := strconv.ParseFloat(response.LowPrice, 64)
-	priceChange, _ := strconv.ParseFloat(response.PriceChange, 64)
-	priceChangePercent, _ := strconv.ParseFloat(response.PriceChangePercent, 64)
-
-	ticker := &model.Ticker{
-		Symbol:             response.Symbol,
-		LastPrice:          lastPrice,
-		Volume:             volume,
-		HighPrice:          highPrice,
-		LowPrice:           lowPrice,
-		PriceChange:        priceChange,
-		PriceChangePercent: priceChangePercent,
-	}
-
-	return ticker, nil
+	return response.toModel(), nil
 }
 
-// GetOrderBook retrieves the order book for a symbol
-func (c *Client) GetOrderBook(ctx context.Context, symbol string, depth int) (*model.OrderBook, error) {
+// fetchOrderBook retrieves the order book for a symbol from the exchange
+func (c *Client) fetchOrderBook(ctx context.Context, symbol string, depth int) (*model.OrderBook, error) {
 	// Validate depth parameter (MEXC supports 5, 10, 20, 50, 100, 500, 1000)
 	validDepths := []int{5, 10, 20, 50, 100, 500, 1000}
 	isValidDepth := false
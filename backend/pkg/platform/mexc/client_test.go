@@ -0,0 +1,83 @@
+package mexc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SendsRequestsToConfiguredBaseURL(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		assert.Equal(t, "key", r.Header.Get("APIKEY"))
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v3/exchangeInfo":
+			w.Write([]byte(`{"timezone":"UTC","serverTime":1700000000000,"symbols":[]}`))
+		case "/api/v3/account":
+			w.Write([]byte(`{"balances":[{"asset":"USDT","free":"10","locked":"0"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	logger := zerolog.Nop()
+	// A trailing slash in the configured URL must not produce double slashes
+	client := NewClientWithBaseURL("key", "secret", server.URL+"/", &logger)
+	assert.Equal(t, server.URL, client.BaseURL())
+
+	_, err := client.GetExchangeInfo(context.Background())
+	require.NoError(t, err)
+	_, err = client.GetAccount(context.Background())
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"/api/v3/exchangeInfo", "/api/v3/account"}, paths)
+}
+
+func TestNewClient_DefaultsToProductionURL(t *testing.T) {
+	logger := zerolog.Nop()
+
+	assert.Equal(t, DefaultBaseURL, NewClient("", "", &logger).BaseURL())
+	assert.Equal(t, DefaultBaseURL, NewClientWithBaseURL("", "", "", &logger).BaseURL())
+}
+
+func TestGetKlinesRange_SendsTimeRange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "/api/v3/klines", r.URL.Path)
+		assert.Equal(t, "BTCUSDT", query.Get("symbol"))
+		assert.Equal(t, "1700000000000", query.Get("startTime"))
+		assert.Equal(t, "1700000119999", query.Get("endTime"))
+		assert.Equal(t, "1000", query.Get("limit"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[[1700000000000,"100","110","95","105","12.5",1700000059999,"1300",42,"6","650"]]`))
+	}))
+	defer server.Close()
+
+	logger := zerolog.Nop()
+	client := NewClientWithBaseURL("", "", server.URL, &logger)
+
+	start := time.UnixMilli(1700000000000)
+	klines, err := client.GetKlinesRange(context.Background(), "BTCUSDT", model.KlineInterval1m, start, start.Add(2*time.Minute-time.Millisecond), 1000)
+	require.NoError(t, err)
+	require.Len(t, klines, 1)
+	assert.True(t, klines[0].OpenTime.Equal(start))
+	assert.Equal(t, 105.0, klines[0].Close)
+}
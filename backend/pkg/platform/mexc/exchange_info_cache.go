@@ -0,0 +1,57 @@
+package mexc
+
+import (
+	"context"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+)
+
+// DefaultExchangeInfoTTL is how long exchange info is cached unless configured otherwise.
+// MEXC changes it rarely and the response is large.
+const DefaultExchangeInfoTTL = time.Hour
+
+// exchangeInfoKey is the single-flight key shared by exchange info fetches
+const exchangeInfoKey = "exchangeInfo"
+
+// GetExchangeInfo returns information about all symbols on the exchange. Responses are
+// cached for the configured TTL and concurrent callers missing the cache share a single
+// request.
+func (c *Client) GetExchangeInfo(ctx context.Context) (*model.ExchangeInfo, error) {
+	c.exchangeInfoMu.RLock()
+	cached, fetchedAt := c.exchangeInfo, c.exchangeInfoAt
+	c.exchangeInfoMu.RUnlock()
+
+	if cached != nil && c.exchangeInfoTTL > 0 && c.now().Sub(fetchedAt) < c.exchangeInfoTTL {
+		return copyExchangeInfo(cached), nil
+	}
+	return c.RefreshExchangeInfo(ctx)
+}
+
+// RefreshExchangeInfo fetches exchange info from MEXC regardless of the cache and stores
+// the result. Callers arriving while a fetch is in flight share its result.
+func (c *Client) RefreshExchangeInfo(ctx context.Context) (*model.ExchangeInfo, error) {
+	info, err := shared(ctx, &c.flights, exchangeInfoKey, func(ctx context.Context) (*model.ExchangeInfo, error) {
+		info, err := c.fetchExchangeInfo(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		c.exchangeInfoMu.Lock()
+		c.exchangeInfo = info
+		c.exchangeInfoAt = c.now()
+		c.exchangeInfoMu.Unlock()
+		return info, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copyExchangeInfo(info), nil
+}
+
+// copyExchangeInfo returns a copy that callers may modify without affecting the cache
+func copyExchangeInfo(info *model.ExchangeInfo) *model.ExchangeInfo {
+	symbols := make([]model.SymbolInfo, len(info.Symbols))
+	copy(symbols, info.Symbols)
+	return &model.ExchangeInfo{Symbols: symbols}
+}
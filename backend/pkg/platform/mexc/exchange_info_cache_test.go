@@ -0,0 +1,137 @@
+package mexc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exchangeInfoBody = `{"timezone":"UTC","serverTime":1700000000000,"symbols":[{"symbol":"BTCUSDT","status":"1","baseAsset":"BTC","quoteAsset":"USDT","filters":[]}]}`
+
+// newExchangeInfoServer serves exchange info, counting requests and holding each one
+// until release is closed
+func newExchangeInfoServer(t *testing.T, release <-chan struct{}) (*httptest.Server, *int32) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(exchangeInfoBody))
+	}))
+	t.Cleanup(server.Close)
+	return server, &hits
+}
+
+func TestGetExchangeInfo_ServesCachedResponseWithinTTL(t *testing.T) {
+	release := make(chan struct{})
+	close(release)
+	server, hits := newExchangeInfoServer(t, release)
+
+	logger := zerolog.Nop()
+	client := NewClientWithBaseURL("", "", server.URL, &logger, WithExchangeInfoTTL(time.Minute))
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return now }
+
+	first, err := client.GetExchangeInfo(context.Background())
+	require.NoError(t, err)
+	require.Len(t, first.Symbols, 1)
+
+	// Modifying a returned value must not leak into the cache
+	first.Symbols[0].Symbol = "CHANGED"
+
+	now = now.Add(59 * time.Second)
+	second, err := client.GetExchangeInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "BTCUSDT", second.Symbols[0].Symbol)
+	assert.Equal(t, int32(1), atomic.LoadInt32(hits))
+
+	// An expired entry and an explicit refresh both go to the network
+	now = now.Add(time.Second)
+	_, err = client.GetExchangeInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(hits))
+
+	_, err = client.RefreshExchangeInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(hits))
+}
+
+func TestCircuitBreakerClient_ForwardsRefreshExchangeInfo(t *testing.T) {
+	release := make(chan struct{})
+	close(release)
+	server, hits := newExchangeInfoServer(t, release)
+
+	logger := zerolog.Nop()
+	breaker, _ := newTestBreaker(3, time.Minute)
+	client := NewCircuitBreakerClient(NewClientWithBaseURL("", "", server.URL, &logger), breaker)
+
+	_, err := client.GetExchangeInfo(context.Background())
+	require.NoError(t, err)
+	_, err = client.GetExchangeInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(hits))
+
+	var refresher ExchangeInfoRefresher = client
+	info, err := refresher.RefreshExchangeInfo(context.Background())
+	require.NoError(t, err)
+	require.Len(t, info.Symbols, 1)
+	assert.Equal(t, int32(2), atomic.LoadInt32(hits))
+}
+
+func TestGetExchangeInfo_CollapsesConcurrentMisses(t *testing.T) {
+	release := make(chan struct{})
+	server, hits := newExchangeInfoServer(t, release)
+
+	logger := zerolog.Nop()
+	client := NewClientWithBaseURL("", "", server.URL, &logger)
+
+	const callers = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			info, err := client.GetExchangeInfo(context.Background())
+			if err == nil && len(info.Symbols) != 1 {
+				err = assert.AnError
+			}
+			errs <- err
+		}()
+	}
+
+	// Hold the upstream request until every caller has had a chance to join it
+	require.Eventually(t, func() bool { return atomic.LoadInt32(hits) == 1 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(hits))
+}
+
+func TestGetExchangeInfo_ZeroTTLDisablesCache(t *testing.T) {
+	release := make(chan struct{})
+	close(release)
+	server, hits := newExchangeInfoServer(t, release)
+
+	logger := zerolog.Nop()
+	client := NewClientWithBaseURL("", "", server.URL, &logger, WithExchangeInfoTTL(0))
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetExchangeInfo(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(hits))
+}
@@ -0,0 +1,59 @@
+package mexc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"golang.org/x/sync/singleflight"
+)
+
+// GetMarketData retrieves current market data for a symbol. Concurrent requests for the
+// same symbol share one upstream call, and its error is returned to every caller.
+func (c *Client) GetMarketData(ctx context.Context, symbol string) (*model.Ticker, error) {
+	ticker, err := shared(ctx, &c.flights, "ticker:"+symbol, func(ctx context.Context) (*model.Ticker, error) {
+		return c.fetchMarketData(ctx, symbol)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := *ticker
+	return &result, nil
+}
+
+// GetOrderBook retrieves the order book for a symbol. Concurrent requests for the same
+// symbol and depth share one upstream call, and its error is returned to every caller.
+func (c *Client) GetOrderBook(ctx context.Context, symbol string, depth int) (*model.OrderBook, error) {
+	key := fmt.Sprintf("orderbook:%s:%d", symbol, depth)
+	book, err := shared(ctx, &c.flights, key, func(ctx context.Context) (*model.OrderBook, error) {
+		return c.fetchOrderBook(ctx, symbol, depth)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := *book
+	result.Bids = append([]model.OrderBookEntry(nil), book.Bids...)
+	result.Asks = append([]model.OrderBookEntry(nil), book.Asks...)
+	return &result, nil
+}
+
+// shared runs fn once for all concurrent callers using the same key and hands each of
+// them the result, including any error. fn runs detached from the caller's cancellation
+// so one caller giving up does not fail the others; each caller still stops waiting when
+// its own context ends.
+func shared[T any](ctx context.Context, group *singleflight.Group, key string, fn func(ctx context.Context) (T, error)) (T, error) {
+	results := group.DoChan(key, func() (interface{}, error) {
+		return fn(context.WithoutCancel(ctx))
+	})
+
+	var zero T
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case result := <-results:
+		if result.Err != nil {
+			return zero, result.Err
+		}
+		return result.Val.(T), nil
+	}
+}
@@ -0,0 +1,100 @@
+package mexc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// heldServer counts requests and holds each one until release is closed before
+// answering with status and body
+func heldServer(t *testing.T, release <-chan struct{}, status int, body string) (*Client, *int32) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	logger := zerolog.Nop()
+	return NewClientWithBaseURL("", "", server.URL, &logger), &hits
+}
+
+// concurrentTickers calls GetMarketData from n goroutines and releases the upstream
+// request once the first call has reached it
+func concurrentTickers(t *testing.T, client *Client, hits *int32, release chan struct{}, n int) ([]*model.Ticker, []error) {
+	tickers := make([]*model.Ticker, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tickers[i], errs[i] = client.GetMarketData(context.Background(), "BTCUSDT")
+		}(i)
+	}
+
+	require.Eventually(t, func() bool { return atomic.LoadInt32(hits) == 1 }, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	return tickers, errs
+}
+
+func TestGetMarketData_ConcurrentCallsShareOneRequest(t *testing.T) {
+	release := make(chan struct{})
+	client, hits := heldServer(t, release, http.StatusOK, `{"symbol":"BTCUSDT","lastPrice":"42000.5","volume":"10"}`)
+
+	tickers, errs := concurrentTickers(t, client, hits, release, 20)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(hits))
+	for i := range tickers {
+		require.NoError(t, errs[i])
+		assert.Equal(t, 42000.5, tickers[i].LastPrice)
+	}
+	// Every caller receives its own copy
+	tickers[0].LastPrice = 1
+	assert.Equal(t, 42000.5, tickers[1].LastPrice)
+}
+
+func TestGetMarketData_ErrorReachesEveryWaiter(t *testing.T) {
+	release := make(chan struct{})
+	client, hits := heldServer(t, release, http.StatusServiceUnavailable, `{"code":503,"msg":"maintenance"}`)
+
+	_, errs := concurrentTickers(t, client, hits, release, 5)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(hits))
+	for _, err := range errs {
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	}
+
+	// Failures are not cached: the next call goes upstream again
+	_, err := client.GetMarketData(context.Background(), "BTCUSDT")
+	require.Error(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(hits))
+}
+
+func TestGetMarketData_WaiterStopsWhenItsContextEnds(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	client, _ := heldServer(t, release, http.StatusOK, `{"symbol":"BTCUSDT","lastPrice":"1"}`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.GetMarketData(ctx, "BTCUSDT")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
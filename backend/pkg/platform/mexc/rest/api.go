@@ -579,3 +579,51 @@ func (c *Client) GetOrderHistory(ctx context.Context, symbol string, limit, offs
 
 	return orders, nil
 }
+
+// CreateListenKey starts a new user data stream and returns its listen key
+func (c *Client) CreateListenKey(ctx context.Context) (string, error) {
+	data, err := c.callPrivateAPI(ctx, "POST", "/api/v3/userDataStream", nil, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create listen key: %w", err)
+	}
+
+	var resp struct {
+		ListenKey string `json:"listenKey"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return "", fmt.Errorf("failed to unmarshal listen key response: %w", err)
+	}
+	if resp.ListenKey == "" {
+		return "", errors.New("empty listen key in response")
+	}
+
+	return resp.ListenKey, nil
+}
+
+// KeepAliveListenKey extends the validity of a listen key by 60 minutes
+func (c *Client) KeepAliveListenKey(ctx context.Context, listenKey string) error {
+	params := map[string]string{
+		"listenKey": listenKey,
+	}
+
+	_, err := c.callPrivateAPI(ctx, "PUT", "/api/v3/userDataStream", params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to keep listen key alive: %w", err)
+	}
+
+	return nil
+}
+
+// CloseListenKey closes a user data stream
+func (c *Client) CloseListenKey(ctx context.Context, listenKey string) error {
+	params := map[string]string{
+		"listenKey": listenKey,
+	}
+
+	_, err := c.callPrivateAPI(ctx, "DELETE", "/api/v3/userDataStream", params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to close listen key: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package mexc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+)
+
+// GetTickers retrieves the 24h tickers of symbols, in the order requested, with a single
+// call: MEXC's 24hr ticker endpoint takes one symbol or none, and without one it returns
+// every ticker. Symbols the exchange does not list are left out, and no symbols returns
+// every ticker. Only when the batch request fails are the tickers fetched one symbol at a
+// time.
+func (c *Client) GetTickers(ctx context.Context, symbols []string) ([]*model.Ticker, error) {
+	all, err := c.fetchAllTickers(ctx)
+	if err != nil {
+		if len(symbols) == 0 || ctx.Err() != nil {
+			return nil, err
+		}
+		c.logger.Warn().Err(err).Int("symbols", len(symbols)).Msg("Batch ticker request failed, fetching tickers one by one")
+		return c.getTickersOneByOne(ctx, symbols)
+	}
+	if len(symbols) == 0 {
+		return all, nil
+	}
+
+	bySymbol := make(map[string]*model.Ticker, len(all))
+	for _, ticker := range all {
+		bySymbol[ticker.Symbol] = ticker
+	}
+	tickers := make([]*model.Ticker, 0, len(symbols))
+	for _, symbol := range symbols {
+		if ticker, ok := bySymbol[symbol]; ok {
+			tickers = append(tickers, ticker)
+		}
+	}
+	return tickers, nil
+}
+
+// fetchAllTickers retrieves the 24h tickers of every symbol
+func (c *Client) fetchAllTickers(ctx context.Context) ([]*model.Ticker, error) {
+	resp, err := c.sendRequest(ctx, http.MethodGet, "/api/v3/ticker/24hr", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tickers: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var response []tickerResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	tickers := make([]*model.Ticker, len(response))
+	for i, r := range response {
+		tickers[i] = r.toModel()
+	}
+	return tickers, nil
+}
+
+// getTickersOneByOne fetches each symbol's ticker separately. A symbol that fails is
+// logged and left out; an error is returned only when none could be fetched.
+func (c *Client) getTickersOneByOne(ctx context.Context, symbols []string) ([]*model.Ticker, error) {
+	tickers := make([]*model.Ticker, 0, len(symbols))
+	var lastErr error
+	for _, symbol := range symbols {
+		ticker, err := c.GetMarketData(ctx, symbol)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			c.logger.Warn().Err(err).Str("symbol", symbol).Msg("Failed to fetch ticker")
+			lastErr = err
+			continue
+		}
+		tickers = append(tickers, ticker)
+	}
+	if len(tickers) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return tickers, nil
+}
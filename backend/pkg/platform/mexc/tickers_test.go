@@ -0,0 +1,99 @@
+package mexc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const allTickersJSON = `[
+  {"symbol":"BTCUSDT","priceChange":"-120.5","priceChangePercent":"-0.0018","prevClosePrice":"65120.5","lastPrice":"65000","bidPrice":"64999.9","askPrice":"65000.1","openPrice":"65120.5","highPrice":"66000","lowPrice":"64000","volume":"1234.5","quoteVolume":"80242500","openTime":1717000000000,"closeTime":1717086400000,"count":null},
+  {"symbol":"ETHUSDT","priceChange":"12","priceChangePercent":"0.0035","prevClosePrice":"3488","lastPrice":"3500","bidPrice":"3499.9","askPrice":"3500.1","openPrice":"3488","highPrice":"3550","lowPrice":"3450","volume":"9876","quoteVolume":"34566000","openTime":1717000000000,"closeTime":1717086400000,"count":null},
+  {"symbol":"MXUSDT","priceChange":"0","priceChangePercent":"0","prevClosePrice":"3.1","lastPrice":"3.1","bidPrice":"3.09","askPrice":"3.11","openPrice":"3.1","highPrice":"3.2","lowPrice":"3.0","volume":"500000","quoteVolume":"1550000","openTime":1717000000000,"closeTime":1717086400000,"count":null}
+]`
+
+// tickerServer serves the 24hr ticker endpoint and counts the requests it receives
+type tickerServer struct {
+	mu       sync.Mutex
+	queries  []string
+	batchErr bool
+}
+
+func (s *tickerServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.queries = append(s.queries, r.URL.RawQuery)
+	s.mu.Unlock()
+
+	symbol := r.URL.Query().Get("symbol")
+	switch {
+	case symbol == "" && s.batchErr:
+		w.WriteHeader(http.StatusInternalServerError)
+	case symbol == "":
+		w.Write([]byte(allTickersJSON))
+	case symbol == "BTCUSDT":
+		w.Write([]byte(`{"symbol":"BTCUSDT","lastPrice":"65000","highPrice":"66000","lowPrice":"64000","volume":"1234.5"}`))
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+	}
+}
+
+func newTickerClient(t *testing.T, handler *tickerServer) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	logger := zerolog.Nop()
+	return NewClientWithBaseURL("", "", server.URL, &logger)
+}
+
+func TestGetTickers_FetchesRequestedSymbolsInOneCall(t *testing.T) {
+	handler := &tickerServer{}
+	client := newTickerClient(t, handler)
+
+	tickers, err := client.GetTickers(context.Background(), []string{"ETHUSDT", "BTCUSDT", "UNKNOWN"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{""}, handler.queries, "one upstream call without a symbol")
+	require.Len(t, tickers, 2)
+	assert.Equal(t, "ETHUSDT", tickers[0].Symbol)
+	assert.Equal(t, 3500.0, tickers[0].LastPrice)
+	assert.Equal(t, 3550.0, tickers[0].HighPrice)
+	assert.Equal(t, 0.0035, tickers[0].PriceChangePercent)
+	assert.Equal(t, "BTCUSDT", tickers[1].Symbol)
+	assert.Equal(t, 65000.0, tickers[1].LastPrice)
+	assert.Equal(t, 1234.5, tickers[1].Volume)
+	assert.Equal(t, -120.5, tickers[1].PriceChange)
+}
+
+func TestGetTickers_WithoutSymbolsReturnsEveryTicker(t *testing.T) {
+	handler := &tickerServer{}
+	client := newTickerClient(t, handler)
+
+	tickers, err := client.GetTickers(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Len(t, tickers, 3)
+	assert.Len(t, handler.queries, 1)
+}
+
+func TestGetTickers_FallsBackToPerSymbolRequests(t *testing.T) {
+	handler := &tickerServer{batchErr: true}
+	client := newTickerClient(t, handler)
+
+	tickers, err := client.GetTickers(context.Background(), []string{"BTCUSDT", "NOPE"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"", "symbol=BTCUSDT", "symbol=NOPE"}, handler.queries)
+	require.Len(t, tickers, 1)
+	assert.Equal(t, "BTCUSDT", tickers[0].Symbol)
+
+	// When nothing can be fetched the error is returned
+	_, err = client.GetTickers(context.Background(), []string{"NOPE"})
+	var apiErr *APIError
+	assert.ErrorAs(t, err, &apiErr)
+}
@@ -0,0 +1,61 @@
+package mexc
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingTransport records a client span for every request sent to MEXC, carrying the
+// endpoint and, when the request has one, the symbol
+type tracingTransport struct {
+	next http.RoundTripper
+}
+
+// withTracing returns a copy of httpClient whose requests are traced
+func withTracing(httpClient *http.Client) *http.Client {
+	if _, ok := httpClient.Transport.(*tracingTransport); ok {
+		return httpClient
+	}
+	next := httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	traced := *httpClient
+	traced.Transport = &tracingTransport{next: next}
+	return &traced
+}
+
+// RoundTrip sends req inside a span
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attrs := []attribute.KeyValue{
+		attribute.String("exchange", "mexc"),
+		attribute.String("mexc.endpoint", req.URL.Path),
+		attribute.String("http.request.method", req.Method),
+	}
+	if symbol := req.URL.Query().Get("symbol"); symbol != "" {
+		attrs = append(attrs, attribute.String("symbol", symbol))
+	}
+
+	ctx, span := telemetry.Tracer().Start(req.Context(), "mexc "+req.Method+" "+req.URL.Path,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, fmt.Sprintf("MEXC returned status %d", resp.StatusCode))
+	}
+	return resp, nil
+}
@@ -0,0 +1,48 @@
+package mexc
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	// maxIdleConnsPerHost is the number of idle connections kept open to the MEXC API. All
+	// requests go to one host, so this is close to the pool size; Go's default of 2 makes
+	// concurrent requests open a new connection nearly every time.
+	maxIdleConnsPerHost = 32
+	// idleConnTimeout is how long an unused connection stays in the pool
+	idleConnTimeout = 90 * time.Second
+	// keepAlivePeriod is the interval of TCP keep-alive probes on open connections
+	keepAlivePeriod = 30 * time.Second
+)
+
+// NewTransport returns the HTTP transport used by default for MEXC requests. It keeps
+// connections to the API alive and pools enough of them that requests reuse open
+// connections rather than dialing a new one, and a TLS handshake, each time.
+func NewTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: keepAlivePeriod,
+	}
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		IdleConnTimeout:       idleConnTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// WithTransport replaces the transport of the HTTP client used for requests, keeping its
+// timeout. It is meant for tests that observe or stub the connections.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		httpClient := *c.httpClient
+		httpClient.Transport = transport
+		c.httpClient = &httpClient
+	}
+}
@@ -0,0 +1,84 @@
+package mexc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingTransport counts the requests it sends and how many of them reused a pooled
+// connection
+type countingTransport struct {
+	next     http.RoundTripper
+	requests atomic.Int32
+	reused   atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests.Add(1)
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				t.reused.Add(1)
+			}
+		},
+	}
+	return t.next.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+}
+
+func TestClient_ReusesConnectionsAcrossRequests(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[[1700000000000,"100","110","95","105","12.5",1700000059999,"1300",42,"6","650"]]`))
+	}))
+	var mu sync.Mutex
+	dialed := 0
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			mu.Lock()
+			dialed++
+			mu.Unlock()
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	transport := &countingTransport{next: NewTransport()}
+	logger := zerolog.Nop()
+	client := NewClientWithBaseURL("", "", server.URL, &logger, WithTransport(transport))
+
+	const requests = 5
+	for i := 0; i < requests; i++ {
+		klines, err := client.GetKlines(context.Background(), "BTCUSDT", model.KlineInterval1m, 1)
+		require.NoError(t, err)
+		require.Len(t, klines, 1)
+	}
+
+	assert.Equal(t, int32(requests), transport.requests.Load())
+	assert.Equal(t, int32(requests-1), transport.reused.Load())
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 1, dialed)
+}
+
+func TestWithTransport_KeepsTimeout(t *testing.T) {
+	logger := zerolog.Nop()
+	transport := &countingTransport{next: NewTransport()}
+	client := NewClientWithBaseURL("", "", "", &logger, WithTransport(transport))
+
+	traced, ok := client.httpClient.Transport.(*tracingTransport)
+	require.True(t, ok)
+	assert.Same(t, transport, traced.next)
+	assert.Equal(t, 10*time.Second, client.httpClient.Timeout)
+}
@@ -45,14 +45,23 @@ type Client struct {
 	rateLimiter      *rate.Limiter
 }
 
-// NewClient creates a new WebSocket client
+// NewClient creates a new WebSocket client for the production endpoint
 func NewClient(ctx context.Context) *Client {
+	return NewClientWithURL(ctx, mexcWSBaseURL)
+}
+
+// NewClientWithURL creates a new WebSocket client connecting to wsURL. An empty wsURL
+// selects the production endpoint.
+func NewClientWithURL(ctx context.Context, wsURL string) *Client {
+	if wsURL == "" {
+		wsURL = mexcWSBaseURL
+	}
 	// Create a rate limiter with MEXC's WebSocket API limits (10 requests per second)
 	limiter := rate.NewLimiter(rate.Limit(10), 20) // 10 requests/sec, burst 20
 
 	ctx, cancel := context.WithCancel(ctx)
 	return &Client{
-		url:           mexcWSBaseURL,
+		url:           wsURL,
 		subscriptions: make(map[string]bool),
 
 		ctx:         ctx,
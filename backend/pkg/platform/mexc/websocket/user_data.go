@@ -0,0 +1,581 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// Private user data stream endpoint
+	mexcUserDataWSURL = "wss://wbs.mexc.com/ws"
+
+	// Listen keys expire after 60 minutes unless they are kept alive
+	listenKeyKeepAliveInterval = 30 * time.Minute
+
+	// Buffer size of the order and balance channels
+	userDataChannelBuffer = 100
+
+	// Private channels: order states, order executions and balance changes
+	channelPrivateOrders  = "spot@private.orders.v3.api"
+	channelPrivateDeals   = "spot@private.deals.v3.api"
+	channelPrivateAccount = "spot@private.account.v3.api"
+)
+
+// ListenKeyService manages the listen keys that authorize a user data stream
+type ListenKeyService interface {
+	CreateListenKey(ctx context.Context) (string, error)
+	KeepAliveListenKey(ctx context.Context, listenKey string) error
+	CloseListenKey(ctx context.Context, listenKey string) error
+}
+
+// BalanceUpdate is emitted when the exchange reports changed account balances
+type BalanceUpdate struct {
+	EventTime time.Time       `json:"eventTime"`
+	Balances  []model.Balance `json:"balances"`
+}
+
+// UserDataStream connects to the private MEXC WebSocket and emits order, fill and balance
+// updates. When the listen key expires the server drops the connection, and the stream
+// reconnects with a new key.
+type UserDataStream struct {
+	listenKeys        ListenKeyService
+	url               string
+	logger            *zerolog.Logger
+	keepAliveInterval time.Duration
+	reconnectDelay    time.Duration
+	orders            chan *model.Order
+	fills             chan *model.OrderFill
+	balances          chan *BalanceUpdate
+
+	mu        sync.Mutex
+	conn      *websocket.Conn
+	listenKey string
+	cancel    context.CancelFunc
+	running   bool
+	wg        sync.WaitGroup
+}
+
+// NewUserDataStream creates a new user data stream on the production endpoint
+func NewUserDataStream(listenKeys ListenKeyService, logger *zerolog.Logger) *UserDataStream {
+	return NewUserDataStreamWithURL(listenKeys, mexcUserDataWSURL, logger)
+}
+
+// NewUserDataStreamWithURL creates a user data stream connecting to wsURL, such as a
+// testnet endpoint. An empty wsURL selects the production endpoint.
+func NewUserDataStreamWithURL(listenKeys ListenKeyService, wsURL string, logger *zerolog.Logger) *UserDataStream {
+	if wsURL == "" {
+		wsURL = mexcUserDataWSURL
+	}
+	return &UserDataStream{
+		listenKeys:        listenKeys,
+		url:               wsURL,
+		logger:            logger,
+		keepAliveInterval: listenKeyKeepAliveInterval,
+		reconnectDelay:    reconnectDelay,
+		orders:            make(chan *model.Order, userDataChannelBuffer),
+		fills:             make(chan *model.OrderFill, userDataChannelBuffer),
+		balances:          make(chan *BalanceUpdate, userDataChannelBuffer),
+	}
+}
+
+// Orders returns the channel on which order updates are emitted
+func (s *UserDataStream) Orders() <-chan *model.Order {
+	return s.orders
+}
+
+// Fills returns the channel on which the executions of orders are emitted
+func (s *UserDataStream) Fills() <-chan *model.OrderFill {
+	return s.fills
+}
+
+// Balances returns the channel on which balance changes are emitted. Updates are dropped
+// while the channel is full, so reading it is optional.
+func (s *UserDataStream) Balances() <-chan *BalanceUpdate {
+	return s.balances
+}
+
+// Start obtains a listen key, connects to the private stream and starts processing events
+func (s *UserDataStream) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	if err := s.connectLocked(ctx); err != nil {
+		cancel()
+		return err
+	}
+
+	s.cancel = cancel
+	s.running = true
+	s.wg.Add(2)
+	go s.readLoop(ctx)
+	go s.keepAlive(ctx)
+
+	s.logger.Info().Msg("User data stream started")
+	return nil
+}
+
+// Stop closes the connection and the listen key and waits for background work to finish
+func (s *UserDataStream) Stop() {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = false
+	s.cancel()
+	if s.conn != nil {
+		_ = s.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		_ = s.conn.Close()
+	}
+	listenKey := s.listenKey
+	s.listenKey = ""
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	s.closeListenKey(listenKey)
+
+	s.logger.Info().Msg("User data stream stopped")
+}
+
+// giveUp stops a stream whose connection could not be restored, so it can be started again
+func (s *UserDataStream) giveUp() {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = false
+	s.cancel()
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+	listenKey := s.listenKey
+	s.listenKey = ""
+	s.mu.Unlock()
+
+	s.closeListenKey(listenKey)
+	s.logger.Error().Msg("User data stream stopped after failing to reconnect")
+}
+
+// closeListenKey releases a listen key that is no longer used
+func (s *UserDataStream) closeListenKey(listenKey string) {
+	if listenKey == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.listenKeys.CloseListenKey(ctx, listenKey); err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to close listen key")
+	}
+}
+
+// connectLocked creates a listen key, dials the stream and subscribes to the private channels.
+// The caller must hold s.mu.
+func (s *UserDataStream) connectLocked(ctx context.Context) error {
+	listenKey, err := s.listenKeys.CreateListenKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, s.url+"?listenKey="+url.QueryEscape(listenKey), nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to user data stream: %w", err)
+	}
+
+	sub := map[string]any{
+		"method": msgTypeSubscribe,
+		"params": []string{channelPrivateOrders, channelPrivateDeals, channelPrivateAccount},
+		"id":     time.Now().UnixNano(),
+	}
+	if err := conn.WriteJSON(sub); err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to subscribe to user data channels: %w", err)
+	}
+
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+	s.conn = conn
+	s.listenKey = listenKey
+	return nil
+}
+
+// reconnect replaces the current connection and listen key. It returns false when the
+// stream is shutting down or all attempts failed.
+func (s *UserDataStream) reconnect(ctx context.Context) bool {
+	for attempt := 1; attempt <= maxReconnectTries; attempt++ {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(s.reconnectDelay):
+		}
+
+		s.mu.Lock()
+		oldListenKey := s.listenKey
+		err := s.connectLocked(ctx)
+		s.mu.Unlock()
+		if err == nil {
+			// The connection now uses a new key, so the old one is released
+			s.closeListenKey(oldListenKey)
+			s.logger.Info().Int("attempt", attempt).Msg("User data stream reconnected")
+			return true
+		}
+
+		s.logger.Warn().Err(err).
+			Int("attempt", attempt).
+			Int("maxAttempts", maxReconnectTries).
+			Msg("Failed to reconnect user data stream")
+	}
+
+	s.logger.Error().Int("attempts", maxReconnectTries).Msg("Giving up reconnecting user data stream")
+	return false
+}
+
+// reconnectOrGiveUp reconnects the stream, and stops it when every attempt failed. It
+// returns false when the read loop must end.
+func (s *UserDataStream) reconnectOrGiveUp(ctx context.Context) bool {
+	if s.reconnect(ctx) {
+		return true
+	}
+	if ctx.Err() == nil {
+		s.giveUp()
+	}
+	return false
+}
+
+// readLoop reads frames from the stream and dispatches them until the context is canceled.
+// When the connection cannot be restored the stream is stopped.
+func (s *UserDataStream) readLoop(ctx context.Context) {
+	defer s.wg.Done()
+
+	for {
+		s.mu.Lock()
+		conn := s.conn
+		s.mu.Unlock()
+
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.logger.Warn().Err(err).Msg("User data stream read failed")
+			if !s.reconnectOrGiveUp(ctx) {
+				return
+			}
+			continue
+		}
+
+		s.dispatch(ctx, data)
+	}
+}
+
+// keepAlive pings the connection and extends the listen key until the context is canceled
+func (s *UserDataStream) keepAlive(ctx context.Context) {
+	defer s.wg.Done()
+
+	pingTicker := time.NewTicker(pingInterval)
+	defer pingTicker.Stop()
+	keepAliveTicker := time.NewTicker(s.keepAliveInterval)
+	defer keepAliveTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-pingTicker.C:
+			s.mu.Lock()
+			if s.conn != nil {
+				if err := s.conn.WriteJSON(map[string]any{"method": msgTypePing}); err != nil {
+					s.logger.Debug().Err(err).Msg("Failed to ping user data stream")
+				}
+			}
+			s.mu.Unlock()
+		case <-keepAliveTicker.C:
+			s.mu.Lock()
+			listenKey := s.listenKey
+			s.mu.Unlock()
+
+			if err := s.listenKeys.KeepAliveListenKey(ctx, listenKey); err != nil {
+				// The stream reconnects with a new key once the server reports expiry
+				s.logger.Warn().Err(err).Msg("Failed to keep listen key alive")
+			}
+		}
+	}
+}
+
+// dispatch parses a frame and forwards its payload
+func (s *UserDataStream) dispatch(ctx context.Context, data []byte) {
+	update, err := parseUserDataMessage(data)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to parse user data message")
+		return
+	}
+
+	switch {
+	case update.order != nil:
+		select {
+		case s.orders <- update.order:
+		case <-ctx.Done():
+		}
+	case update.fill != nil:
+		select {
+		case s.fills <- update.fill:
+		case <-ctx.Done():
+		}
+	case update.balance != nil:
+		// Balances are optional to consume; an unread channel must not stall orders and fills
+		select {
+		case s.balances <- update.balance:
+		default:
+			s.logger.Debug().Msg("Dropping balance update, channel is full")
+		}
+	}
+}
+
+// userDataFrame is a push frame of a private channel. The symbol is only set on order and
+// deal frames.
+type userDataFrame struct {
+	Channel  string          `json:"c"`
+	Data     json.RawMessage `json:"d"`
+	Symbol   string          `json:"s"`
+	SendTime int64           `json:"t"`
+}
+
+// userDataUpdate is the payload of a user data frame; at most one field is set
+type userDataUpdate struct {
+	order   *model.Order
+	fill    *model.OrderFill
+	balance *BalanceUpdate
+}
+
+// orderMessage is the payload of an orders frame. encoding/json matches keys
+// case-insensitively, so both letters of each single-letter pair are declared to keep
+// e.g. "s" (status) and "S" (trade type) apart.
+type orderMessage struct {
+	OrderID          string     `json:"i"`
+	ClientOrderID    string     `json:"c"`
+	TradeType        int        `json:"S"`
+	Status           int        `json:"s"`
+	OrderType        int        `json:"o"`
+	CreateTime       int64      `json:"O"`
+	Price            flexNumber `json:"p"`
+	Quantity         flexNumber `json:"v"`
+	RemainQuantity   flexNumber `json:"V"`
+	Amount           flexNumber `json:"a"`
+	RemainAmount     flexNumber `json:"A"`
+	AvgPrice         flexNumber `json:"ap"`
+	CumulativeQty    flexNumber `json:"cv"`
+	CumulativeAmount flexNumber `json:"ca"`
+	IsMaker          int        `json:"m"`
+}
+
+// dealMessage is the payload of a deals frame: one execution of an order
+type dealMessage struct {
+	OrderID       string     `json:"o"`
+	ClientOrderID string     `json:"c"`
+	TradeID       string     `json:"t"`
+	TradeTime     int64      `json:"T"`
+	TradeType     int        `json:"S"`
+	Price         flexNumber `json:"p"`
+	Quantity      flexNumber `json:"v"`
+	Amount        flexNumber `json:"a"`
+	Fee           flexNumber `json:"n"`
+	FeeAsset      string     `json:"N"`
+	IsMaker       int        `json:"m"`
+}
+
+// accountMessage is the payload of an account frame: the balance of one asset after a change
+type accountMessage struct {
+	Asset      string     `json:"a"`
+	ChangeTime int64      `json:"c"`
+	Free       flexNumber `json:"f"`
+	Locked     flexNumber `json:"l"`
+	ChangeType string     `json:"o"`
+}
+
+// flexNumber is a number MEXC sends either as a JSON number or as a string
+type flexNumber float64
+
+func (n *flexNumber) UnmarshalJSON(data []byte) error {
+	text := strings.Trim(string(data), `"`)
+	if text == "" || text == "null" {
+		*n = 0
+		return nil
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %s", data)
+	}
+	*n = flexNumber(value)
+	return nil
+}
+
+// parseUserDataMessage decodes a user data frame into an order, fill or balance update.
+// Frames that carry none (subscription acks, pongs) yield an empty update.
+func parseUserDataMessage(data []byte) (userDataUpdate, error) {
+	if len(data) == 0 {
+		return userDataUpdate{}, nil
+	}
+
+	var frame userDataFrame
+	if err := json.Unmarshal(data, &frame); err != nil {
+		return userDataUpdate{}, fmt.Errorf("failed to parse user data message: %w", err)
+	}
+
+	switch frame.Channel {
+	case channelPrivateOrders:
+		var msg orderMessage
+		if err := json.Unmarshal(frame.Data, &msg); err != nil {
+			return userDataUpdate{}, fmt.Errorf("failed to unmarshal order update: %w", err)
+		}
+		order, err := msg.toOrder(frame)
+		return userDataUpdate{order: order}, err
+	case channelPrivateDeals:
+		var msg dealMessage
+		if err := json.Unmarshal(frame.Data, &msg); err != nil {
+			return userDataUpdate{}, fmt.Errorf("failed to unmarshal deal: %w", err)
+		}
+		fill, err := msg.toFill(frame)
+		return userDataUpdate{fill: fill}, err
+	case channelPrivateAccount:
+		var msg accountMessage
+		if err := json.Unmarshal(frame.Data, &msg); err != nil {
+			return userDataUpdate{}, fmt.Errorf("failed to unmarshal account update: %w", err)
+		}
+		return userDataUpdate{balance: msg.toBalanceUpdate(frame)}, nil
+	default:
+		return userDataUpdate{}, nil
+	}
+}
+
+// tradeSide maps the trade type of order and deal frames, 1 for buys and 2 for sells
+func tradeSide(tradeType int) model.OrderSide {
+	if tradeType == 2 {
+		return model.OrderSideSell
+	}
+	return model.OrderSideBuy
+}
+
+// orderStatuses maps the order states of orders frames. A partially canceled order had part
+// of its quantity filled before the rest was canceled.
+var orderStatuses = map[int]model.OrderStatus{
+	1: model.OrderStatusNew,
+	2: model.OrderStatusFilled,
+	3: model.OrderStatusPartiallyFilled,
+	4: model.OrderStatusCanceled,
+	5: model.OrderStatusCanceled,
+}
+
+// parseOrderType maps the order type of orders frames to an order type and time in force
+func parseOrderType(code int) (model.OrderType, model.TimeInForce) {
+	switch code {
+	case 1:
+		return model.OrderTypeLimit, model.TimeInForceGTC
+	case 2:
+		return model.OrderType("LIMIT_MAKER"), model.TimeInForceGTC
+	case 3:
+		return model.OrderTypeLimit, model.TimeInForceIOC
+	case 4:
+		return model.OrderTypeLimit, model.TimeInForceFOK
+	case 5:
+		return model.OrderTypeMarket, ""
+	case 100:
+		return model.OrderType("STOP_LIMIT"), model.TimeInForceGTC
+	default:
+		return "", ""
+	}
+}
+
+// toOrder converts an orders frame into a domain order. The frame carries no fees; they
+// arrive with each fill on the deals channel.
+func (m *orderMessage) toOrder(frame userDataFrame) (*model.Order, error) {
+	if m.OrderID == "" {
+		return nil, errors.New("order update without order id")
+	}
+	status, ok := orderStatuses[m.Status]
+	if !ok {
+		return nil, fmt.Errorf("order update %s has unknown status %d", m.OrderID, m.Status)
+	}
+	orderType, timeInForce := parseOrderType(m.OrderType)
+
+	avgFillPrice := float64(m.AvgPrice)
+	if avgFillPrice == 0 && m.CumulativeQty > 0 {
+		avgFillPrice = float64(m.CumulativeAmount / m.CumulativeQty)
+	}
+
+	return &model.Order{
+		OrderID:       m.OrderID,
+		ClientOrderID: m.ClientOrderID,
+		Symbol:        frame.Symbol,
+		Side:          tradeSide(m.TradeType),
+		Type:          orderType,
+		Status:        status,
+		TimeInForce:   timeInForce,
+		Price:         float64(m.Price),
+		Quantity:      float64(m.Quantity),
+		ExecutedQty:   float64(m.CumulativeQty),
+		AvgFillPrice:  avgFillPrice,
+		CreatedAt:     time.UnixMilli(m.CreateTime),
+		UpdatedAt:     time.UnixMilli(frame.SendTime),
+		Exchange:      "MEXC",
+	}, nil
+}
+
+// toFill converts a deals frame into a fill
+func (m *dealMessage) toFill(frame userDataFrame) (*model.OrderFill, error) {
+	if m.OrderID == "" {
+		return nil, errors.New("deal without order id")
+	}
+	executedAt := time.UnixMilli(m.TradeTime)
+	if m.TradeTime == 0 {
+		executedAt = time.UnixMilli(frame.SendTime)
+	}
+
+	return &model.OrderFill{
+		TradeID:         m.TradeID,
+		OrderID:         m.OrderID,
+		ClientOrderID:   m.ClientOrderID,
+		Symbol:          frame.Symbol,
+		Side:            tradeSide(m.TradeType),
+		Price:           float64(m.Price),
+		Quantity:        float64(m.Quantity),
+		Commission:      float64(m.Fee),
+		CommissionAsset: m.FeeAsset,
+		ExecutedAt:      executedAt,
+	}, nil
+}
+
+// toBalanceUpdate converts an account frame into a balance update
+func (m *accountMessage) toBalanceUpdate(frame userDataFrame) *BalanceUpdate {
+	eventTime := time.UnixMilli(m.ChangeTime)
+	if m.ChangeTime == 0 {
+		eventTime = time.UnixMilli(frame.SendTime)
+	}
+
+	free, locked := float64(m.Free), float64(m.Locked)
+	return &BalanceUpdate{
+		EventTime: eventTime,
+		Balances: []model.Balance{{
+			Asset:  model.Asset(m.Asset),
+			Free:   free,
+			Locked: locked,
+			Total:  free + locked,
+		}},
+	}
+}
@@ -0,0 +1,340 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/RyanLisse/go-crypto-bot-clean/backend/internal/domain/model"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Sample frames of the private channels, as given in the MEXC spot v3 WebSocket
+// documentation. Order frames send numbers as JSON numbers, deal and account frames as
+// strings.
+const (
+	sampleOrderFrame   = `{"c":"spot@private.orders.v3.api","d":{"A":8.0,"O":1661938138000,"S":1,"V":10,"a":8,"c":"","i":"e03a5c7441e44ed899466a7140b71391","m":0,"o":1,"p":0.8,"s":1,"v":10,"ap":0,"cv":0,"ca":0},"s":"MXUSDT","t":1661938138193}`
+	sampleDealFrame    = `{"c":"spot@private.deals.v3.api","d":{"p":"1.804","v":"0.31","a":"0.55924","S":1,"T":1678901086198,"t":"5bbb6ad8b4474570b155610e3960cd","o":"2a0ad973ec2e4da5b8c7d18f8d1e1c8e","c":"","m":0,"n":"0.000248206380027431","N":"MX"},"s":"MXUSDT","t":1661938980285}`
+	sampleAccountFrame = `{"c":"spot@private.account.v3.api","d":{"a":"USDT","c":1678185928428,"f":"302.185113007893322435","fd":"-4.990689704","l":"4.990689704","ld":"4.990689704","o":"ENTRUST_PLACE"},"t":1678185928435}`
+)
+
+// filledSellFrame is a sell order of sampleOrderFrame's shape, partially filled and then
+// canceled
+const filledSellFrame = `{"c":"spot@private.orders.v3.api","d":{"A":"0","O":1700000000000,"S":2,"V":"0.2","a":"0","c":"client-1","i":"C02__123","m":1,"o":5,"p":"0","s":5,"v":"0.5","ap":"0","cv":"0.3","ca":"9000"},"s":"BTCUSDT","t":1700000000400}`
+
+func TestParseOrderFrame(t *testing.T) {
+	update, err := parseUserDataMessage([]byte(sampleOrderFrame))
+	require.NoError(t, err)
+	assert.Nil(t, update.fill)
+	assert.Nil(t, update.balance)
+	order := update.order
+	require.NotNil(t, order)
+
+	assert.Equal(t, "e03a5c7441e44ed899466a7140b71391", order.OrderID)
+	assert.Empty(t, order.ClientOrderID)
+	assert.Equal(t, "MXUSDT", order.Symbol)
+	assert.Equal(t, model.OrderSideBuy, order.Side)
+	assert.Equal(t, model.OrderTypeLimit, order.Type)
+	assert.Equal(t, model.TimeInForceGTC, order.TimeInForce)
+	assert.Equal(t, model.OrderStatusNew, order.Status)
+	assert.InDelta(t, 0.8, order.Price, 1e-9)
+	assert.InDelta(t, 10.0, order.Quantity, 1e-9)
+	assert.Zero(t, order.ExecutedQty)
+	assert.Zero(t, order.Commission)
+	assert.Equal(t, time.UnixMilli(1661938138000), order.CreatedAt)
+	assert.Equal(t, time.UnixMilli(1661938138193), order.UpdatedAt)
+}
+
+func TestParseOrderFrameWithStringNumbers(t *testing.T) {
+	update, err := parseUserDataMessage([]byte(filledSellFrame))
+	require.NoError(t, err)
+	order := update.order
+	require.NotNil(t, order)
+
+	assert.Equal(t, "C02__123", order.OrderID)
+	assert.Equal(t, "client-1", order.ClientOrderID)
+	assert.Equal(t, model.OrderSideSell, order.Side)
+	assert.Equal(t, model.OrderTypeMarket, order.Type)
+	assert.Equal(t, model.OrderStatusCanceled, order.Status)
+	assert.InDelta(t, 0.3, order.ExecutedQty, 1e-9)
+	// Without an average price it is derived from the cumulative amount
+	assert.InDelta(t, 30000.0, order.AvgFillPrice, 1e-9)
+}
+
+func TestParseDealFrame(t *testing.T) {
+	update, err := parseUserDataMessage([]byte(sampleDealFrame))
+	require.NoError(t, err)
+	assert.Nil(t, update.order)
+	fill := update.fill
+	require.NotNil(t, fill)
+
+	assert.Equal(t, "5bbb6ad8b4474570b155610e3960cd", fill.TradeID)
+	assert.Equal(t, "2a0ad973ec2e4da5b8c7d18f8d1e1c8e", fill.OrderID)
+	assert.Equal(t, "MXUSDT", fill.Symbol)
+	assert.Equal(t, model.OrderSideBuy, fill.Side)
+	assert.InDelta(t, 1.804, fill.Price, 1e-9)
+	assert.InDelta(t, 0.31, fill.Quantity, 1e-9)
+	assert.InDelta(t, 0.000248206380027431, fill.Commission, 1e-15)
+	assert.Equal(t, "MX", fill.CommissionAsset)
+	assert.Equal(t, time.UnixMilli(1678901086198), fill.ExecutedAt)
+}
+
+func TestParseAccountFrame(t *testing.T) {
+	update, err := parseUserDataMessage([]byte(sampleAccountFrame))
+	require.NoError(t, err)
+	assert.Nil(t, update.order)
+	balance := update.balance
+	require.NotNil(t, balance)
+
+	assert.Equal(t, time.UnixMilli(1678185928428), balance.EventTime)
+	require.Len(t, balance.Balances, 1)
+	assert.Equal(t, model.AssetUSDT, balance.Balances[0].Asset)
+	assert.InDelta(t, 302.185113007893322435, balance.Balances[0].Free, 1e-9)
+	assert.InDelta(t, 4.990689704, balance.Balances[0].Locked, 1e-9)
+	assert.InDelta(t, 307.175802711893322435, balance.Balances[0].Total, 1e-9)
+}
+
+func TestParseUserDataControlFrames(t *testing.T) {
+	// Subscription acknowledgements and pongs carry no update
+	for _, frame := range []string{
+		`{"id":1,"code":0,"msg":"spot@private.orders.v3.api"}`,
+		`{"id":0,"code":0,"msg":"PONG"}`,
+	} {
+		update, err := parseUserDataMessage([]byte(frame))
+		require.NoError(t, err)
+		assert.Equal(t, userDataUpdate{}, update)
+	}
+
+	_, err := parseUserDataMessage([]byte(`not json`))
+	assert.Error(t, err)
+
+	_, err = parseUserDataMessage([]byte(`{"c":"spot@private.orders.v3.api","d":{"i":"1","s":9},"s":"MXUSDT","t":1}`))
+	assert.Error(t, err, "unknown order status")
+}
+
+// fakeListenKeyService hands out sequential listen keys and records keepalive calls
+type fakeListenKeyService struct {
+	mu         sync.Mutex
+	created    []string
+	keptAlive  []string
+	closedKeys []string
+}
+
+func (f *fakeListenKeyService) CreateListenKey(_ context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := "key-" + string(rune('a'+len(f.created)))
+	f.created = append(f.created, key)
+	return key, nil
+}
+
+func (f *fakeListenKeyService) KeepAliveListenKey(_ context.Context, listenKey string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.keptAlive = append(f.keptAlive, listenKey)
+	return nil
+}
+
+func (f *fakeListenKeyService) CloseListenKey(_ context.Context, listenKey string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closedKeys = append(f.closedKeys, listenKey)
+	return nil
+}
+
+func (f *fakeListenKeyService) snapshot() (created, keptAlive, closed []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.created...), append([]string(nil), f.keptAlive...), append([]string(nil), f.closedKeys...)
+}
+
+func TestUserDataStreamReconnectsWithNewListenKey(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// Consume the subscription request
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+
+		switch r.URL.Query().Get("listenKey") {
+		case "key-a":
+			// The server drops the connection when the listen key expires
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(sampleAccountFrame))
+			return
+		case "key-b":
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(sampleOrderFrame))
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(sampleDealFrame))
+		}
+
+		// Keep the connection open until the client goes away
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	logger := zerolog.Nop()
+	keys := &fakeListenKeyService{}
+	stream := NewUserDataStream(keys, &logger)
+	stream.url = "ws" + strings.TrimPrefix(server.URL, "http")
+	stream.reconnectDelay = 10 * time.Millisecond
+	stream.keepAliveInterval = 20 * time.Millisecond
+
+	require.NoError(t, stream.Start(context.Background()))
+
+	select {
+	case balance := <-stream.Balances():
+		require.Len(t, balance.Balances, 1)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for balance update")
+	}
+
+	select {
+	case order := <-stream.Orders():
+		assert.Equal(t, "e03a5c7441e44ed899466a7140b71391", order.OrderID)
+		assert.Equal(t, model.OrderStatusNew, order.Status)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for order update after reconnect")
+	}
+
+	select {
+	case fill := <-stream.Fills():
+		assert.Equal(t, "5bbb6ad8b4474570b155610e3960cd", fill.TradeID)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for fill after reconnect")
+	}
+
+	assert.Eventually(t, func() bool {
+		_, keptAlive, _ := keys.snapshot()
+		return len(keptAlive) > 0
+	}, time.Second, 10*time.Millisecond)
+
+	stream.Stop()
+
+	// The expired key is released on reconnect and the current one on stop
+	created, _, closed := keys.snapshot()
+	assert.Equal(t, []string{"key-a", "key-b"}, created)
+	assert.Equal(t, []string{"key-a", "key-b"}, closed)
+}
+
+func TestUserDataStreamUnreadBalancesDoNotBlockOrders(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+
+		// More balance updates than the channel holds, then an order
+		for i := 0; i < 2*userDataChannelBuffer; i++ {
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(sampleAccountFrame))
+		}
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(sampleOrderFrame))
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	logger := zerolog.Nop()
+	stream := NewUserDataStream(&fakeListenKeyService{}, &logger)
+	stream.url = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	require.NoError(t, stream.Start(context.Background()))
+	defer stream.Stop()
+
+	select {
+	case order := <-stream.Orders():
+		assert.Equal(t, "e03a5c7441e44ed899466a7140b71391", order.OrderID)
+	case <-time.After(2 * time.Second):
+		t.Fatal("order update was stalled behind unread balance updates")
+	}
+	assert.Len(t, stream.Balances(), userDataChannelBuffer)
+}
+
+// flakyListenKeyService fails to create listen keys while failing is set
+type flakyListenKeyService struct {
+	fakeListenKeyService
+	failing atomic.Bool
+}
+
+func (f *flakyListenKeyService) CreateListenKey(ctx context.Context) (string, error) {
+	if f.failing.Load() {
+		return "", errors.New("listen key unavailable")
+	}
+	return f.fakeListenKeyService.CreateListenKey(ctx)
+}
+
+func TestUserDataStreamCanRestartAfterGivingUp(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	var connections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		connections.Add(1)
+
+		// The first connection drops straight away, later ones stay open
+		if r.URL.Query().Get("listenKey") == "key-a" {
+			return
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	logger := zerolog.Nop()
+	keys := &flakyListenKeyService{}
+	stream := NewUserDataStream(keys, &logger)
+	stream.url = "ws" + strings.TrimPrefix(server.URL, "http")
+	stream.reconnectDelay = time.Millisecond
+
+	require.NoError(t, stream.Start(context.Background()))
+	keys.failing.Store(true)
+
+	assert.Eventually(t, func() bool {
+		stream.mu.Lock()
+		defer stream.mu.Unlock()
+		return !stream.running
+	}, 2*time.Second, 5*time.Millisecond, "stream should stop after the reconnect attempts fail")
+	_, _, closed := keys.snapshot()
+	assert.Equal(t, []string{"key-a"}, closed)
+
+	keys.failing.Store(false)
+	require.NoError(t, stream.Start(context.Background()))
+	assert.Eventually(t, func() bool { return connections.Load() == 2 }, time.Second, 5*time.Millisecond)
+	stream.Stop()
+
+	created, _, closed := keys.snapshot()
+	assert.Equal(t, []string{"key-a", "key-b"}, created)
+	assert.Equal(t, []string{"key-a", "key-b"}, closed)
+}
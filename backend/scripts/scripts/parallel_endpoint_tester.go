@@ -0,0 +1,108 @@
+package scripts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// EndpointCheck is a single named check of an API endpoint
+type EndpointCheck struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// EndpointResult is the outcome of one EndpointCheck
+type EndpointResult struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+}
+
+// EndpointReport combines the results of a run, in the order the checks were given
+type EndpointReport struct {
+	Results []EndpointResult
+	Passed  int
+	Failed  int
+}
+
+// OK reports whether every check passed
+func (r *EndpointReport) OK() bool {
+	return r.Failed == 0
+}
+
+// Print writes a line per check followed by a summary
+func (r *EndpointReport) Print(w io.Writer) {
+	fmt.Fprintln(w, "\n=== MEXC Endpoint Checks ===")
+	for _, result := range r.Results {
+		if result.Err != nil {
+			fmt.Fprintf(w, "FAIL %-30s %8s  %v\n", result.Name, result.Duration.Round(time.Millisecond), result.Err)
+			continue
+		}
+		fmt.Fprintf(w, "PASS %-30s %8s\n", result.Name, result.Duration.Round(time.Millisecond))
+	}
+	fmt.Fprintf(w, "%d passed, %d failed\n", r.Passed, r.Failed)
+}
+
+// ParallelEndpointTester runs endpoint checks concurrently, at most workers at a time
+type ParallelEndpointTester struct {
+	workers int
+}
+
+// NewParallelEndpointTester creates a tester running up to workers checks at once.
+// Non-positive workers runs the checks one at a time.
+func NewParallelEndpointTester(workers int) *ParallelEndpointTester {
+	if workers < 1 {
+		workers = 1
+	}
+	return &ParallelEndpointTester{workers: workers}
+}
+
+// Run runs every check and reports the combined result. A check that panics fails
+// rather than ending the run.
+func (t *ParallelEndpointTester) Run(ctx context.Context, checks []EndpointCheck) *EndpointReport {
+	report := &EndpointReport{Results: make([]EndpointResult, len(checks))}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < min(t.workers, len(checks)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				report.Results[index] = runCheck(ctx, checks[index])
+			}
+		}()
+	}
+	for index := range checks {
+		jobs <- index
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, result := range report.Results {
+		if result.Err != nil {
+			report.Failed++
+		} else {
+			report.Passed++
+		}
+	}
+	return report
+}
+
+// runCheck runs a single check, turning a panic into its error
+func runCheck(ctx context.Context, check EndpointCheck) (result EndpointResult) {
+	result.Name = check.Name
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			result.Err = fmt.Errorf("panic: %v", r)
+		}
+		result.Duration = time.Since(start)
+	}()
+
+	result.Err = check.Run(ctx)
+	return result
+}
@@ -0,0 +1,82 @@
+package scripts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParallelEndpointTester_BoundsConcurrency(t *testing.T) {
+	const workers = 3
+	var running, peak atomic.Int32
+	full := make(chan struct{})
+	var closeFull sync.Once
+
+	checks := make([]EndpointCheck, 10)
+	for i := range checks {
+		checks[i] = EndpointCheck{Name: fmt.Sprintf("check %d", i), Run: func(ctx context.Context) error {
+			now := running.Add(1)
+			defer running.Add(-1)
+			for {
+				highest := peak.Load()
+				if now <= highest || peak.CompareAndSwap(highest, now) {
+					break
+				}
+			}
+			// Hold the first checks until the worker limit is reached
+			if now == workers {
+				closeFull.Do(func() { close(full) })
+			}
+			<-full
+			return nil
+		}}
+	}
+
+	report := NewParallelEndpointTester(workers).Run(context.Background(), checks)
+
+	assert.Equal(t, int32(workers), peak.Load())
+	assert.Equal(t, 10, report.Passed)
+	assert.True(t, report.OK())
+}
+
+func TestParallelEndpointTester_AggregatesResults(t *testing.T) {
+	checks := []EndpointCheck{
+		{Name: "account", Run: func(ctx context.Context) error { return nil }},
+		{Name: "ticker BTCUSDT", Run: func(ctx context.Context) error { return errors.New("rate limited") }},
+		{Name: "order book BTCUSDT", Run: func(ctx context.Context) error { panic("nil order book") }},
+		{Name: "klines BTCUSDT 1d", Run: func(ctx context.Context) error { return nil }},
+	}
+
+	report := NewParallelEndpointTester(2).Run(context.Background(), checks)
+
+	assert.False(t, report.OK())
+	assert.Equal(t, 2, report.Passed)
+	assert.Equal(t, 2, report.Failed)
+	require.Len(t, report.Results, 4)
+	for i, result := range report.Results {
+		assert.Equal(t, checks[i].Name, result.Name, "results keep the order of the checks")
+	}
+	assert.NoError(t, report.Results[0].Err)
+	assert.EqualError(t, report.Results[1].Err, "rate limited")
+	assert.EqualError(t, report.Results[2].Err, "panic: nil order book")
+
+	var out bytes.Buffer
+	report.Print(&out)
+	assert.Contains(t, out.String(), "PASS account")
+	assert.Contains(t, out.String(), "FAIL ticker BTCUSDT")
+	assert.Contains(t, out.String(), "rate limited")
+	assert.Contains(t, out.String(), "2 passed, 2 failed")
+}
+
+func TestParallelEndpointTester_NoChecks(t *testing.T) {
+	report := NewParallelEndpointTester(0).Run(context.Background(), nil)
+	assert.True(t, report.OK())
+	assert.Empty(t, report.Results)
+}
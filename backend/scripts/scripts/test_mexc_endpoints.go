@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -15,6 +16,9 @@ import (
 	"github.com/RyanLisse/go-crypto-bot-clean/backend/pkg/platform/mexc"
 )
 
+// endpointWorkers is how many endpoint checks run at once
+const endpointWorkers = 4
+
 // TestMexcEndpoints tests all MEXC endpoints
 func TestMexcEndpoints() {
 	// Setup logger
@@ -54,58 +58,95 @@ func TestMexcEndpoints() {
 	defer cancel()
 
 	// Test all endpoints
-	testAccount(ctx, client, &logger)
-	testExchangeInfo(ctx, client, &logger)
-	testTicker(ctx, client, &logger)
-	testOrderBook(ctx, client, &logger)
-	testKlines(ctx, client, &logger)
-	testMarketData(ctx, client, &logger)
+	report := NewParallelEndpointTester(endpointWorkers).Run(ctx, mexcEndpointChecks(client, &logger))
+	report.Print(os.Stdout)
+	if !report.OK() {
+		logger.Fatal().Int("failed", report.Failed).Msg("Some endpoint tests failed")
+	}
 
 	logger.Info().Msg("All tests completed successfully")
 }
 
+// mexcEndpointChecks returns a check per endpoint and tested symbol
+func mexcEndpointChecks(client *mexc.Client, logger *zerolog.Logger) []EndpointCheck {
+	// Test with a few popular symbols
+	symbols := []string{"BTCUSDT", "ETHUSDT", "SOLUSDT"}
+	// MEXC uses different interval format, so we'll use string literals
+	intervals := []model.KlineInterval{"60m", "4h", "1d"}
+
+	checks := []EndpointCheck{
+		{Name: "account", Run: func(ctx context.Context) error { return testAccount(ctx, client, logger) }},
+		{Name: "exchange info", Run: func(ctx context.Context) error { return testExchangeInfo(ctx, client, logger) }},
+	}
+	for _, symbol := range symbols {
+		checks = append(checks,
+			EndpointCheck{Name: "ticker " + symbol, Run: func(ctx context.Context) error {
+				return testTicker(ctx, client, symbol, logger)
+			}},
+			EndpointCheck{Name: "order book " + symbol, Run: func(ctx context.Context) error {
+				return testOrderBook(ctx, client, symbol, logger)
+			}},
+			EndpointCheck{Name: "market data " + symbol, Run: func(ctx context.Context) error {
+				return testMarketData(ctx, client, symbol, logger)
+			}},
+		)
+		for _, interval := range intervals {
+			checks = append(checks, EndpointCheck{
+				Name: fmt.Sprintf("klines %s %s", symbol, interval),
+				Run: func(ctx context.Context) error {
+					return testKlines(ctx, client, symbol, interval, logger)
+				},
+			})
+		}
+	}
+	return checks
+}
+
 // testAccount tests the account endpoint
-func testAccount(ctx context.Context, client *mexc.Client, logger *zerolog.Logger) {
+func testAccount(ctx context.Context, client *mexc.Client, logger *zerolog.Logger) error {
 	logger.Info().Msg("Testing account endpoint...")
 
 	wallet, err := client.GetAccount(ctx)
 	if err != nil {
-		logger.Fatal().Err(err).Msg("Failed to get account information")
+		return fmt.Errorf("failed to get account information: %w", err)
 	}
 
 	logger.Info().
 		Int("Number of balances", len(wallet.Balances)).
 		Msg("Account information retrieved successfully")
 
-	// Print balances
-	fmt.Println("\n=== MEXC Account Balances ===")
-	fmt.Printf("%-10s %-15s %-15s\n", "Asset", "Free", "Locked")
-	fmt.Println("----------------------------------------")
+	// Print balances in one write, as other checks print concurrently
+	var out strings.Builder
+	fmt.Fprintln(&out, "\n=== MEXC Account Balances ===")
+	fmt.Fprintf(&out, "%-10s %-15s %-15s\n", "Asset", "Free", "Locked")
+	fmt.Fprintln(&out, "----------------------------------------")
 
 	// Filter out zero balances
 	nonZeroBalances := 0
 	for asset, balance := range wallet.Balances {
 		if balance.Free > 0 || balance.Locked > 0 {
-			fmt.Printf("%-10s %-15f %-15f\n", asset, balance.Free, balance.Locked)
+			fmt.Fprintf(&out, "%-10s %-15f %-15f\n", asset, balance.Free, balance.Locked)
 			nonZeroBalances++
 		}
 	}
 
 	if nonZeroBalances == 0 {
-		fmt.Println("No non-zero balances found.")
+		fmt.Fprintln(&out, "No non-zero balances found.")
 	}
+	fmt.Print(out.String())
 
 	// Save wallet data to file
 	saveToFile(wallet, "mexc_wallet.json", logger)
+	return nil
 }
 
 // testExchangeInfo tests the exchange info endpoint
-func testExchangeInfo(ctx context.Context, client *mexc.Client, logger *zerolog.Logger) {
+func testExchangeInfo(ctx context.Context, client *mexc.Client, logger *zerolog.Logger) error {
 	logger.Info().Msg("Testing exchange info endpoint...")
 
 	exchangeInfo, err := client.GetExchangeInfo(ctx)
 	if err != nil {
-		logger.Fatal().Err(err).Msg("Failed to get exchange information")
+		return fmt.Errorf("failed to get exchange information: %w", err)
 	}
 
 	logger.Info().
@@ -113,175 +154,149 @@ func testExchangeInfo(ctx context.Context, client *mexc.Client, logger *zerolog.
 		Msg("Exchange information retrieved successfully")
 
 	// Print a few symbols
-	fmt.Println("\n=== MEXC Exchange Symbols ===")
-	fmt.Printf("%-10s %-10s %-10s %-10s\n", "Symbol", "Base", "Quote", "Status")
-	fmt.Println("----------------------------------------")
+	var out strings.Builder
+	fmt.Fprintln(&out, "\n=== MEXC Exchange Symbols ===")
+	fmt.Fprintf(&out, "%-10s %-10s %-10s %-10s\n", "Symbol", "Base", "Quote", "Status")
+	fmt.Fprintln(&out, "----------------------------------------")
 
 	// Print first 10 symbols
 	for i := 0; i < 10 && i < len(exchangeInfo.Symbols); i++ {
 		symbol := exchangeInfo.Symbols[i]
-		fmt.Printf("%-10s %-10s %-10s %-10s\n", symbol.Symbol, symbol.BaseAsset, symbol.QuoteAsset, symbol.Status)
+		fmt.Fprintf(&out, "%-10s %-10s %-10s %-10s\n", symbol.Symbol, symbol.BaseAsset, symbol.QuoteAsset, symbol.Status)
 	}
+	fmt.Print(out.String())
 
 	// Save exchange info to file
 	saveToFile(exchangeInfo, "mexc_exchange_info.json", logger)
+	return nil
 }
 
-// testTicker tests the ticker endpoint
-func testTicker(ctx context.Context, client *mexc.Client, logger *zerolog.Logger) {
-	logger.Info().Msg("Testing ticker endpoint...")
-
-	// Test with a few popular symbols
-	symbols := []string{"BTCUSDT", "ETHUSDT", "SOLUSDT"}
-
-	for _, symbol := range symbols {
-		ticker, err := client.GetMarketData(ctx, symbol)
-		if err != nil {
-			logger.Error().Err(err).Str("symbol", symbol).Msg("Failed to get ticker")
-			continue
-		}
-
-		logger.Info().
-			Str("Symbol", ticker.Symbol).
-			Float64("Price", ticker.LastPrice).
-			Float64("24h Volume", ticker.Volume).
-			Float64("24h High", ticker.HighPrice).
-			Float64("24h Low", ticker.LowPrice).
-			Float64("24h Change %", ticker.PriceChangePercent).
-			Msg("Ticker retrieved successfully")
-
-		// Save ticker to file
-		saveToFile(ticker, fmt.Sprintf("mexc_ticker_%s.json", symbol), logger)
+// testTicker tests the ticker endpoint for a symbol
+func testTicker(ctx context.Context, client *mexc.Client, symbol string, logger *zerolog.Logger) error {
+	ticker, err := client.GetMarketData(ctx, symbol)
+	if err != nil {
+		return fmt.Errorf("failed to get ticker: %w", err)
 	}
-}
-
-// testOrderBook tests the order book endpoint
-func testOrderBook(ctx context.Context, client *mexc.Client, logger *zerolog.Logger) {
-	logger.Info().Msg("Testing order book endpoint...")
 
-	// Test with a few popular symbols
-	symbols := []string{"BTCUSDT", "ETHUSDT", "SOLUSDT"}
-
-	for _, symbol := range symbols {
-		orderBook, err := client.GetOrderBook(ctx, symbol, 10)
-		if err != nil {
-			logger.Error().Err(err).Str("symbol", symbol).Msg("Failed to get order book")
-			continue
-		}
-
-		logger.Info().
-			Str("Symbol", orderBook.Symbol).
-			Int64("Last Update ID", orderBook.LastUpdateID).
-			Int("Bids", len(orderBook.Bids)).
-			Int("Asks", len(orderBook.Asks)).
-			Msg("Order book retrieved successfully")
-
-		// Print top 3 bids and asks
-		fmt.Printf("\n=== MEXC Order Book for %s ===\n", symbol)
-		fmt.Println("Top 3 Bids:")
-		fmt.Printf("%-15s %-15s\n", "Price", "Quantity")
-		fmt.Println("------------------------------")
-		for i := 0; i < 3 && i < len(orderBook.Bids); i++ {
-			fmt.Printf("%-15f %-15f\n", orderBook.Bids[i].Price, orderBook.Bids[i].Quantity)
-		}
-
-		fmt.Println("\nTop 3 Asks:")
-		fmt.Printf("%-15s %-15s\n", "Price", "Quantity")
-		fmt.Println("------------------------------")
-		for i := 0; i < 3 && i < len(orderBook.Asks); i++ {
-			fmt.Printf("%-15f %-15f\n", orderBook.Asks[i].Price, orderBook.Asks[i].Quantity)
-		}
-
-		// Save order book to file
-		saveToFile(orderBook, fmt.Sprintf("mexc_orderbook_%s.json", symbol), logger)
-	}
+	logger.Info().
+		Str("Symbol", ticker.Symbol).
+		Float64("Price", ticker.LastPrice).
+		Float64("24h Volume", ticker.Volume).
+		Float64("24h High", ticker.HighPrice).
+		Float64("24h Low", ticker.LowPrice).
+		Float64("24h Change %", ticker.PriceChangePercent).
+		Msg("Ticker retrieved successfully")
+
+	// Save ticker to file
+	saveToFile(ticker, fmt.Sprintf("mexc_ticker_%s.json", symbol), logger)
+	return nil
 }
 
-// testKlines tests the klines endpoint
-func testKlines(ctx context.Context, client *mexc.Client, logger *zerolog.Logger) {
-	logger.Info().Msg("Testing klines endpoint...")
+// testOrderBook tests the order book endpoint for a symbol
+func testOrderBook(ctx context.Context, client *mexc.Client, symbol string, logger *zerolog.Logger) error {
+	orderBook, err := client.GetOrderBook(ctx, symbol, 10)
+	if err != nil {
+		return fmt.Errorf("failed to get order book: %w", err)
+	}
 
-	// Test with a few popular symbols and intervals
-	symbols := []string{"BTCUSDT", "ETHUSDT", "SOLUSDT"}
-	// MEXC uses different interval format, so we'll use string literals
-	intervals := []model.KlineInterval{"60m", "4h", "1d"}
+	logger.Info().
+		Str("Symbol", orderBook.Symbol).
+		Int64("Last Update ID", orderBook.LastUpdateID).
+		Int("Bids", len(orderBook.Bids)).
+		Int("Asks", len(orderBook.Asks)).
+		Msg("Order book retrieved successfully")
+
+	// Print top 3 bids and asks
+	var out strings.Builder
+	fmt.Fprintf(&out, "\n=== MEXC Order Book for %s ===\n", symbol)
+	fmt.Fprintln(&out, "Top 3 Bids:")
+	fmt.Fprintf(&out, "%-15s %-15s\n", "Price", "Quantity")
+	fmt.Fprintln(&out, "------------------------------")
+	for i := 0; i < 3 && i < len(orderBook.Bids); i++ {
+		fmt.Fprintf(&out, "%-15f %-15f\n", orderBook.Bids[i].Price, orderBook.Bids[i].Quantity)
+	}
 
-	for _, symbol := range symbols {
-		for _, interval := range intervals {
-			klines, err := client.GetKlines(ctx, symbol, interval, 10)
-			if err != nil {
-				logger.Error().Err(err).Str("symbol", symbol).Str("interval", string(interval)).Msg("Failed to get klines")
-				continue
-			}
-
-			logger.Info().
-				Str("Symbol", symbol).
-				Str("Interval", string(interval)).
-				Int("Count", len(klines)).
-				Msg("Klines retrieved successfully")
-
-			// Print the most recent kline
-			if len(klines) > 0 {
-				kline := klines[0]
-				fmt.Printf("\n=== Most Recent Kline for %s (%s) ===\n", symbol, interval)
-				fmt.Printf("Open Time: %s\n", kline.OpenTime.Format(time.RFC3339))
-				fmt.Printf("Open: %.8f\n", kline.Open)
-				fmt.Printf("High: %.8f\n", kline.High)
-				fmt.Printf("Low: %.8f\n", kline.Low)
-				fmt.Printf("Close: %.8f\n", kline.Close)
-				fmt.Printf("Volume: %.8f\n", kline.Volume)
-				fmt.Printf("Close Time: %s\n", kline.CloseTime.Format(time.RFC3339))
-			}
-
-			// Save klines to file
-			saveToFile(klines, fmt.Sprintf("mexc_klines_%s_%s.json", symbol, interval), logger)
-		}
+	fmt.Fprintln(&out, "\nTop 3 Asks:")
+	fmt.Fprintf(&out, "%-15s %-15s\n", "Price", "Quantity")
+	fmt.Fprintln(&out, "------------------------------")
+	for i := 0; i < 3 && i < len(orderBook.Asks); i++ {
+		fmt.Fprintf(&out, "%-15f %-15f\n", orderBook.Asks[i].Price, orderBook.Asks[i].Quantity)
 	}
-}
+	fmt.Print(out.String())
 
-// testMarketData tests the market data endpoint
-func testMarketData(ctx context.Context, client *mexc.Client, logger *zerolog.Logger) {
-	logger.Info().Msg("Testing market data endpoint...")
+	// Save order book to file
+	saveToFile(orderBook, fmt.Sprintf("mexc_orderbook_%s.json", symbol), logger)
+	return nil
+}
 
-	// Test with a few popular symbols
-	symbols := []string{"BTCUSDT", "ETHUSDT", "SOLUSDT"}
+// testKlines tests the klines endpoint for a symbol and interval
+func testKlines(ctx context.Context, client *mexc.Client, symbol string, interval model.KlineInterval, logger *zerolog.Logger) error {
+	klines, err := client.GetKlines(ctx, symbol, interval, 10)
+	if err != nil {
+		return fmt.Errorf("failed to get klines: %w", err)
+	}
 
-	for _, symbol := range symbols {
-		// Get ticker
-		ticker, err := client.GetMarketData(ctx, symbol)
-		if err != nil {
-			logger.Error().Err(err).Str("symbol", symbol).Msg("Failed to get ticker")
-			continue
-		}
+	logger.Info().
+		Str("Symbol", symbol).
+		Str("Interval", string(interval)).
+		Int("Count", len(klines)).
+		Msg("Klines retrieved successfully")
+
+	// Print the most recent kline
+	if len(klines) > 0 {
+		kline := klines[0]
+		var out strings.Builder
+		fmt.Fprintf(&out, "\n=== Most Recent Kline for %s (%s) ===\n", symbol, interval)
+		fmt.Fprintf(&out, "Open Time: %s\n", kline.OpenTime.Format(time.RFC3339))
+		fmt.Fprintf(&out, "Open: %.8f\n", kline.Open)
+		fmt.Fprintf(&out, "High: %.8f\n", kline.High)
+		fmt.Fprintf(&out, "Low: %.8f\n", kline.Low)
+		fmt.Fprintf(&out, "Close: %.8f\n", kline.Close)
+		fmt.Fprintf(&out, "Volume: %.8f\n", kline.Volume)
+		fmt.Fprintf(&out, "Close Time: %s\n", kline.CloseTime.Format(time.RFC3339))
+		fmt.Print(out.String())
+	}
 
-		// Get order book
-		orderBook, err := client.GetOrderBook(ctx, symbol, 10)
-		if err != nil {
-			logger.Error().Err(err).Str("symbol", symbol).Msg("Failed to get order book")
-			continue
-		}
+	// Save klines to file
+	saveToFile(klines, fmt.Sprintf("mexc_klines_%s_%s.json", symbol, interval), logger)
+	return nil
+}
 
-		// Create a combined market data object
-		marketData := struct {
-			Symbol    string           `json:"symbol"`
-			Ticker    *model.Ticker    `json:"ticker"`
-			OrderBook *model.OrderBook `json:"orderBook"`
-		}{
-			Symbol:    symbol,
-			Ticker:    ticker,
-			OrderBook: orderBook,
-		}
+// testMarketData tests the combined ticker and order book data for a symbol
+func testMarketData(ctx context.Context, client *mexc.Client, symbol string, logger *zerolog.Logger) error {
+	// Get ticker
+	ticker, err := client.GetMarketData(ctx, symbol)
+	if err != nil {
+		return fmt.Errorf("failed to get ticker: %w", err)
+	}
 
-		logger.Info().
-			Str("Symbol", symbol).
-			Float64("Price", ticker.LastPrice).
-			Int("Order Book Bids", len(orderBook.Bids)).
-			Int("Order Book Asks", len(orderBook.Asks)).
-			Msg("Market data retrieved successfully")
+	// Get order book
+	orderBook, err := client.GetOrderBook(ctx, symbol, 10)
+	if err != nil {
+		return fmt.Errorf("failed to get order book: %w", err)
+	}
 
-		// Save market data to file
-		saveToFile(marketData, fmt.Sprintf("mexc_market_data_%s.json", symbol), logger)
+	// Create a combined market data object
+	marketData := struct {
+		Symbol    string           `json:"symbol"`
+		Ticker    *model.Ticker    `json:"ticker"`
+		OrderBook *model.OrderBook `json:"orderBook"`
+	}{
+		Symbol:    symbol,
+		Ticker:    ticker,
+		OrderBook: orderBook,
 	}
+
+	logger.Info().
+		Str("Symbol", symbol).
+		Float64("Price", ticker.LastPrice).
+		Int("Order Book Bids", len(orderBook.Bids)).
+		Int("Order Book Asks", len(orderBook.Asks)).
+		Msg("Market data retrieved successfully")
+
+	// Save market data to file
+	saveToFile(marketData, fmt.Sprintf("mexc_market_data_%s.json", symbol), logger)
+	return nil
 }
 
 // saveToFile saves data to a JSON file